@@ -24,18 +24,28 @@ import (
 
 	"github.com/spf13/cobra"
 
+	flowkitAccounts "github.com/onflow/flow-cli/flowkit/accounts"
+	flowkitConfig "github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/internal/accounts"
+	"github.com/onflow/flow-cli/internal/agent"
+	"github.com/onflow/flow-cli/internal/alias"
+	"github.com/onflow/flow-cli/internal/auth"
 	"github.com/onflow/flow-cli/internal/blocks"
 	"github.com/onflow/flow-cli/internal/cadence"
+	"github.com/onflow/flow-cli/internal/catalog"
 	"github.com/onflow/flow-cli/internal/collections"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/config"
+	"github.com/onflow/flow-cli/internal/dashboard"
 	"github.com/onflow/flow-cli/internal/emulator"
 	"github.com/onflow/flow-cli/internal/events"
+	"github.com/onflow/flow-cli/internal/generate"
 	"github.com/onflow/flow-cli/internal/keys"
 	"github.com/onflow/flow-cli/internal/project"
 	"github.com/onflow/flow-cli/internal/quick"
 	"github.com/onflow/flow-cli/internal/scripts"
+	"github.com/onflow/flow-cli/internal/security"
+	"github.com/onflow/flow-cli/internal/serve"
 	"github.com/onflow/flow-cli/internal/settings"
 	"github.com/onflow/flow-cli/internal/signatures"
 	"github.com/onflow/flow-cli/internal/snapshot"
@@ -44,6 +54,7 @@ import (
 	"github.com/onflow/flow-cli/internal/test"
 	"github.com/onflow/flow-cli/internal/tools"
 	"github.com/onflow/flow-cli/internal/transactions"
+	"github.com/onflow/flow-cli/internal/trust"
 	"github.com/onflow/flow-cli/internal/util"
 	"github.com/onflow/flow-cli/internal/version"
 )
@@ -61,8 +72,12 @@ func main() {
 
 	// single commands
 	status.Command.AddToParent(cmd)
+	status.NetworkInfoCommand.AddToParent(cmd)
 	tools.DevWallet.AddToParent(cmd)
 	tools.Flowser.AddToParent(cmd)
+	tools.Explore.AddToParent(cmd)
+	dashboard.Command.AddToParent(cmd)
+	serve.Command.AddToParent(cmd)
 	test.TestCommand.AddToParent(cmd)
 
 	// super commands
@@ -72,7 +87,14 @@ func main() {
 
 	// structured commands
 	cmd.AddCommand(settings.Cmd)
+	cmd.AddCommand(alias.Cmd)
 	cmd.AddCommand(cadence.Cmd)
+	cmd.AddCommand(catalog.Cmd)
+	cmd.AddCommand(agent.Cmd)
+	cmd.AddCommand(security.Cmd)
+	cmd.AddCommand(trust.Cmd)
+	cmd.AddCommand(auth.Cmd)
+	cmd.AddCommand(generate.Cmd)
 	cmd.AddCommand(version.Cmd)
 	cmd.AddCommand(emulator.Cmd)
 	cmd.AddCommand(accounts.Cmd)
@@ -115,6 +137,21 @@ func main() {
 
 	cmd.SetUsageTemplate(command.UsageTemplate)
 
+	// encrypted keys are decrypted using passphrase from environment, keychain or prompt
+	flowkitAccounts.Passphrase = util.Passphrase
+
+	// encrypted keys held by the running agent are signed with by the agent without the passphrase
+	flowkitAccounts.Agent = agent.NewClient(agent.SocketPath())
+
+	// walletconnect keys sign in the connected wallet which is paired using the displayed link
+	flowkitAccounts.WalletConnectPrompt = util.WalletConnectPrompt
+
+	// remote configuration includes are verified against the trusted keys and checksums
+	flowkitConfig.VerifyInclude = trust.Verify
+
+	// aliases of frequently used commands are replaced with the aliased command
+	cmd.SetArgs(alias.Expand(cmd, os.Args[1:]))
+
 	if err := cmd.Execute(); err != nil {
 		util.Exit(1, err.Error())
 	}
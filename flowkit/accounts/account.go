@@ -31,9 +31,10 @@ import (
 
 // Account is defined by an address and name and contains an Key which can be used for signing.
 type Account struct {
-	Name    string
-	Address flow.Address
-	Key     Key
+	Name     string
+	Address  flow.Address
+	Key      Key
+	FromFile string // file in which the account is defined, if kept separate from the main configuration
 }
 
 func FromConfig(conf *config.Config) (Accounts, error) {
@@ -65,10 +66,15 @@ func fromConfig(account config.Account) (*Account, error) {
 		return nil, err
 	}
 
+	if wcKey, ok := key.(*WalletConnectKey); ok {
+		wcKey.address = account.Address
+	}
+
 	return &Account{
-		Name:    account.Name,
-		Address: account.Address,
-		Key:     key,
+		Name:     account.Name,
+		Address:  account.Address,
+		Key:      key,
+		FromFile: account.FromFile,
 	}, nil
 }
 
@@ -79,9 +85,10 @@ func toConfig(account Account) config.Account {
 	}
 
 	return config.Account{
-		Name:    account.Name,
-		Address: account.Address,
-		Key:     key,
+		Name:     account.Name,
+		Address:  account.Address,
+		Key:      key,
+		FromFile: account.FromFile,
 	}
 }
 
@@ -161,11 +168,23 @@ func (a Accounts) ByName(name string) (*Account, error) {
 	return nil, fmt.Errorf("could not find account with name %s in the configuration", name)
 }
 
+// SetNetwork sets the network used to resolve network specific account keys.
+func (a *Accounts) SetNetwork(network string) {
+	for _, account := range *a {
+		switch key := account.Key.(type) {
+		case *EnvKey:
+			key.SetNetwork(network)
+		case *WalletConnectKey:
+			key.SetNetwork(network)
+		}
+	}
+}
+
 // AddOrUpdate add account if missing or updates if present.
 func (a *Accounts) AddOrUpdate(account *Account) {
 	for i, acc := range *a {
 		if acc.Name == account.Name {
-			(*a)[i] = acc
+			(*a)[i] = *account
 			return
 		}
 	}
@@ -0,0 +1,55 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package accounts
+
+var _ Key = &CoSignedKey{}
+
+// CoSignedKey is an account key whose signatures are accompanied by the signatures of co-signer keys
+// of the same account, used when a single key doesn't hold enough weight to authorize a transaction.
+//
+// All the key properties are the properties of the primary key, the co-signer keys are only used for signing.
+type CoSignedKey struct {
+	Key
+	coSigners []Key
+}
+
+// NewCoSignedKey creates a key signing with the primary key and all the co-signer keys.
+func NewCoSignedKey(key Key, coSigners ...Key) *CoSignedKey {
+	return &CoSignedKey{
+		Key:       key,
+		coSigners: coSigners,
+	}
+}
+
+// CoSigners returns the co-signer keys.
+func (c *CoSignedKey) CoSigners() []Key {
+	return c.coSigners
+}
+
+func (c *CoSignedKey) Validate() error {
+	if err := c.Key.Validate(); err != nil {
+		return err
+	}
+
+	for _, key := range c.coSigners {
+		if err := key.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// PassphraseEnv is the environment variable from which the passphrase for the encrypted keys is read.
+const PassphraseEnv = "FLOW_KEY_PASSPHRASE"
+
+const saltLength = 16
+
+// PassphraseProvider provides the project passphrase used to encrypt and decrypt the keys.
+type PassphraseProvider func() (string, error)
+
+// Passphrase is used to obtain the passphrase when an encrypted key is decrypted,
+// by default the passphrase is read from the environment.
+var Passphrase PassphraseProvider = EnvPassphrase
+
+// EnvPassphrase reads the passphrase from the environment variable.
+func EnvPassphrase() (string, error) {
+	passphrase := os.Getenv(PassphraseEnv)
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase for encrypted keys is required, set it using %s environment variable", PassphraseEnv)
+	}
+
+	return passphrase, nil
+}
+
+// KeyAgent signs with the encrypted keys held decrypted by an agent process,
+// so the passphrase is only required when the agent is started.
+type KeyAgent interface {
+	// PublicKey returns the public key of the encrypted key or an error if the agent doesn't hold the key.
+	PublicKey(encryptedKey string) (crypto.PublicKey, error)
+	// Sign signs the message with the encrypted key held by the agent.
+	Sign(encryptedKey string, hashAlgo crypto.HashAlgorithm, message []byte) ([]byte, error)
+}
+
+// Agent is used to sign with the encrypted keys before decrypting them with the passphrase,
+// by default no agent is used.
+var Agent KeyAgent
+
+var _ Key = &EncryptedKey{}
+
+// EncryptedKey represents a private key stored encrypted with the project passphrase.
+//
+// The key is decrypted when it's first used for signing.
+type EncryptedKey struct {
+	*baseKey
+	privateKey   crypto.PrivateKey
+	encryptedKey string
+}
+
+// NewEncryptedKey encrypts the private key with the passphrase and returns the encrypted account key.
+func NewEncryptedKey(
+	privateKey crypto.PrivateKey,
+	index int,
+	hashAlgo crypto.HashAlgorithm,
+	passphrase string,
+) (*EncryptedKey, error) {
+	encrypted, err := EncryptPrivateKey(privateKey, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedKey{
+		baseKey: &baseKey{
+			keyType:  config.KeyTypeEncrypted,
+			index:    index,
+			sigAlgo:  privateKey.Algorithm(),
+			hashAlgo: hashAlgo,
+		},
+		privateKey:   privateKey,
+		encryptedKey: encrypted,
+	}, nil
+}
+
+func encryptedKeyFromConfig(accountKey config.AccountKey) (*EncryptedKey, error) {
+	return &EncryptedKey{
+		baseKey:      baseKeyFromConfig(accountKey),
+		encryptedKey: accountKey.EncryptedKey,
+	}, nil
+}
+
+func (e *EncryptedKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	if signer := e.agentSigner(); signer != nil {
+		return signer, nil
+	}
+
+	key, err := e.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewInMemorySigner(*key, e.HashAlgo())
+}
+
+func (e *EncryptedKey) PrivateKey() (*crypto.PrivateKey, error) {
+	if e.privateKey == nil { // lazy decrypt the key
+		passphrase, err := Passphrase()
+		if err != nil {
+			return nil, err
+		}
+
+		pkey, err := DecryptPrivateKey(e.encryptedKey, e.SigAlgo(), passphrase)
+		if err != nil {
+			return nil, err
+		}
+		e.privateKey = pkey
+	}
+
+	return &e.privateKey, nil
+}
+
+func (e *EncryptedKey) Validate() error {
+	if e.agentSigner() != nil {
+		return nil
+	}
+
+	_, err := e.PrivateKey()
+	return err
+}
+
+// EncryptedKey returns the hex encoded encrypted private key.
+func (e *EncryptedKey) EncryptedKey() string {
+	return e.encryptedKey
+}
+
+// agentSigner returns the signer using the agent if the key is not yet decrypted and the agent holds it.
+func (e *EncryptedKey) agentSigner() crypto.Signer {
+	if e.privateKey != nil || Agent == nil {
+		return nil
+	}
+
+	publicKey, err := Agent.PublicKey(e.encryptedKey)
+	if err != nil {
+		return nil
+	}
+
+	return &agentSigner{key: e, publicKey: publicKey}
+}
+
+// agentSigner signs with the encrypted key held by the agent.
+type agentSigner struct {
+	key       *EncryptedKey
+	publicKey crypto.PublicKey
+}
+
+func (a *agentSigner) Sign(message []byte) ([]byte, error) {
+	return Agent.Sign(a.key.encryptedKey, a.key.HashAlgo(), message)
+}
+
+func (a *agentSigner) PublicKey() crypto.PublicKey {
+	return a.publicKey
+}
+
+// ToConfig converts the key to configuration, only the encrypted key is stored.
+func (e *EncryptedKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:         config.KeyTypeEncrypted,
+		Index:        e.index,
+		SigAlgo:      e.sigAlgo,
+		HashAlgo:     e.hashAlgo,
+		EncryptedKey: e.encryptedKey,
+	}
+}
+
+// EncryptPrivateKey encrypts the private key using AES-GCM with a key derived from the passphrase.
+//
+// The result is hex encoded salt, nonce and the cipher text.
+func EncryptPrivateKey(privateKey crypto.PrivateKey, passphrase string) (string, error) {
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	gcm, err := passphraseCipher(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	encrypted := gcm.Seal(nil, nonce, privateKey.Encode(), nil)
+	out := append(append(salt, nonce...), encrypted...)
+
+	return hex.EncodeToString(out), nil
+}
+
+// DecryptPrivateKey decrypts the private key encrypted with EncryptPrivateKey.
+func DecryptPrivateKey(
+	encrypted string,
+	sigAlgo crypto.SignatureAlgorithm,
+	passphrase string,
+) (crypto.PrivateKey, error) {
+	raw, err := hex.DecodeString(encrypted)
+	if err != nil || len(raw) < saltLength {
+		return nil, fmt.Errorf("invalid encrypted private key")
+	}
+
+	gcm, err := passphraseCipher(passphrase, raw[:saltLength])
+	if err != nil {
+		return nil, err
+	}
+
+	raw = raw[saltLength:]
+	if len(raw) < gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid encrypted private key")
+	}
+
+	decrypted, err := gcm.Open(nil, raw[:gcm.NonceSize()], raw[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key, the passphrase might be wrong")
+	}
+
+	return crypto.DecodePrivateKey(sigAlgo, decrypted)
+}
+
+func passphraseCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
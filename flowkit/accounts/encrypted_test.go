@@ -0,0 +1,118 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_Encrypted_Key(t *testing.T) {
+	pkey, err := crypto.DecodePrivateKeyHex(
+		crypto.ECDSA_P256,
+		"dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47",
+	)
+	require.NoError(t, err)
+
+	encryptedKey, err := NewEncryptedKey(pkey, 1, crypto.SHA3_256, "secret")
+	require.NoError(t, err)
+
+	confKey := encryptedKey.ToConfig()
+	assert.Equal(t, config.KeyTypeEncrypted, confKey.Type)
+	assert.Equal(t, 1, confKey.Index)
+	assert.Nil(t, confKey.PrivateKey)
+	assert.NotContains(t, confKey.EncryptedKey, "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+
+	t.Run("Decrypt with passphrase", func(t *testing.T) {
+		t.Setenv(PassphraseEnv, "secret")
+
+		key, err := keyFromConfig(confKey)
+		require.NoError(t, err)
+		assert.NoError(t, key.Validate())
+
+		decrypted, err := key.PrivateKey()
+		require.NoError(t, err)
+		assert.Equal(t, pkey.String(), (*decrypted).String())
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+		assert.NotNil(t, signer)
+	})
+
+	t.Run("Fail with wrong passphrase", func(t *testing.T) {
+		t.Setenv(PassphraseEnv, "wrong")
+
+		key, err := keyFromConfig(confKey)
+		require.NoError(t, err)
+		assert.EqualError(t, key.Validate(), "failed to decrypt private key, the passphrase might be wrong")
+	})
+
+	t.Run("Sign with agent", func(t *testing.T) {
+		t.Setenv(PassphraseEnv, "")
+		Agent = &testAgent{keys: map[string]crypto.PrivateKey{confKey.EncryptedKey: pkey}}
+		defer func() { Agent = nil }()
+
+		key, err := keyFromConfig(confKey)
+		require.NoError(t, err)
+		assert.NoError(t, key.Validate())
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, pkey.PublicKey().String(), signer.PublicKey().String())
+
+		sig, err := signer.Sign([]byte("message"))
+		require.NoError(t, err)
+		assert.Equal(t, []byte("signed message"), sig)
+
+		// keys not held by the agent are decrypted with the passphrase
+		Agent = &testAgent{}
+		assert.EqualError(t, key.Validate(), "passphrase for encrypted keys is required, set it using FLOW_KEY_PASSPHRASE environment variable")
+	})
+
+	t.Run("Fail without passphrase", func(t *testing.T) {
+		t.Setenv(PassphraseEnv, "")
+
+		key, err := keyFromConfig(confKey)
+		require.NoError(t, err)
+		assert.EqualError(t, key.Validate(), "passphrase for encrypted keys is required, set it using FLOW_KEY_PASSPHRASE environment variable")
+	})
+}
+
+type testAgent struct {
+	keys map[string]crypto.PrivateKey
+}
+
+func (a *testAgent) PublicKey(encryptedKey string) (crypto.PublicKey, error) {
+	key, ok := a.keys[encryptedKey]
+	if !ok {
+		return nil, fmt.Errorf("key not held by the agent")
+	}
+	return key.PublicKey(), nil
+}
+
+func (a *testAgent) Sign(encryptedKey string, _ crypto.HashAlgorithm, message []byte) ([]byte, error) {
+	return append([]byte("signed "), message...), nil
+}
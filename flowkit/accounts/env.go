@@ -0,0 +1,126 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Key = &EnvKey{}
+
+// EnvKey represents a private key read from an environment variable which can differ per network.
+//
+// The environment variable is resolved for the current network in the following order:
+// variable explicitly defined for the network, variable prefixed with the network name
+// (e.g. TESTNET_DEPLOYER_KEY) and the variable without the prefix.
+type EnvKey struct {
+	*baseKey
+	env        string
+	networkEnv map[string]string
+	network    string
+}
+
+func envKeyFromConfig(accountKey config.AccountKey) (*EnvKey, error) {
+	return &EnvKey{
+		baseKey:    baseKeyFromConfig(accountKey),
+		env:        accountKey.Env,
+		networkEnv: accountKey.NetworkEnv,
+	}, nil
+}
+
+// SetNetwork sets the network for which the environment variable is resolved.
+func (e *EnvKey) SetNetwork(network string) {
+	e.network = network
+}
+
+// EnvVar returns the name of the environment variable holding the key for the current network.
+func (e *EnvKey) EnvVar() (string, error) {
+	if env, ok := e.networkEnv[e.network]; ok {
+		return env, nil
+	}
+
+	if e.network != "" && e.env != "" {
+		prefixed := fmt.Sprintf("%s_%s", networkEnvPrefix(e.network), e.env)
+		if _, ok := os.LookupEnv(prefixed); ok {
+			return prefixed, nil
+		}
+	}
+
+	if e.env == "" {
+		return "", fmt.Errorf("no environment variable defined for the key on network %s", e.network)
+	}
+
+	return e.env, nil
+}
+
+func (e *EnvKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	key, err := e.PrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.NewInMemorySigner(*key, e.HashAlgo())
+}
+
+func (e *EnvKey) PrivateKey() (*crypto.PrivateKey, error) {
+	env, err := e.EnvVar()
+	if err != nil {
+		return nil, err
+	}
+
+	value := os.Getenv(env)
+	if value == "" {
+		return nil, fmt.Errorf("required environment variable %s not set", env)
+	}
+
+	key, err := crypto.DecodePrivateKeyHex(e.SigAlgo(), strings.TrimPrefix(value, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key in environment variable %s: %w", env, err)
+	}
+
+	return &key, nil
+}
+
+func (e *EnvKey) Validate() error {
+	_, err := e.PrivateKey()
+	return err
+}
+
+func (e *EnvKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:       config.KeyTypeEnv,
+		Index:      e.index,
+		SigAlgo:    e.sigAlgo,
+		HashAlgo:   e.hashAlgo,
+		Env:        e.env,
+		NetworkEnv: e.networkEnv,
+	}
+}
+
+// networkEnvPrefix converts the network name to environment variable prefix, e.g. "my-testnet" to "MY_TESTNET".
+func networkEnvPrefix(network string) string {
+	return strings.ToUpper(strings.ReplaceAll(network, "-", "_"))
+}
@@ -0,0 +1,91 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_Env_Key(t *testing.T) {
+	const testnetKey = "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+	const defaultKey = "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+
+	t.Setenv("DEPLOYER_KEY", defaultKey)
+	t.Setenv("TESTNET_DEPLOYER_KEY", testnetKey)
+	t.Setenv("PREVIEW_KEY", testnetKey)
+
+	key, err := keyFromConfig(config.AccountKey{
+		Type:       config.KeyTypeEnv,
+		Env:        "DEPLOYER_KEY",
+		NetworkEnv: map[string]string{"previewnet": "PREVIEW_KEY"},
+	})
+	require.NoError(t, err)
+	envKey := key.(*EnvKey)
+
+	testCases := []struct {
+		network string
+		env     string
+		key     string
+	}{
+		{"", "DEPLOYER_KEY", defaultKey},
+		{"testnet", "TESTNET_DEPLOYER_KEY", testnetKey},
+		{"mainnet", "DEPLOYER_KEY", defaultKey},
+		{"previewnet", "PREVIEW_KEY", testnetKey},
+	}
+
+	for _, tc := range testCases {
+		envKey.SetNetwork(tc.network)
+
+		env, err := envKey.EnvVar()
+		require.NoError(t, err)
+		assert.Equal(t, tc.env, env)
+
+		pkey, err := envKey.PrivateKey()
+		require.NoError(t, err)
+		assert.Equal(t, "0x"+tc.key, (*pkey).String())
+	}
+
+	t.Run("Fail missing variable", func(t *testing.T) {
+		key, err := keyFromConfig(config.AccountKey{
+			Type:       config.KeyTypeEnv,
+			NetworkEnv: map[string]string{"mainnet": "MAINNET_MISSING_KEY"},
+		})
+		require.NoError(t, err)
+
+		key.(*EnvKey).SetNetwork("mainnet")
+		assert.EqualError(t, key.Validate(), "required environment variable MAINNET_MISSING_KEY not set")
+
+		key.(*EnvKey).SetNetwork("testnet")
+		assert.EqualError(t, key.Validate(), "no environment variable defined for the key on network testnet")
+	})
+
+	t.Run("Set network for accounts", func(t *testing.T) {
+		accounts := Accounts{{Name: "deployer", Key: envKey}}
+		accounts.SetNetwork("testnet")
+
+		env, err := envKey.EnvVar()
+		require.NoError(t, err)
+		assert.Equal(t, "TESTNET_DEPLOYER_KEY", env)
+	})
+}
@@ -0,0 +1,200 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Key = &ExecKey{}
+
+// ExecProtocolVersion is the version of the protocol used to communicate with the external signer programs.
+const ExecProtocolVersion = 1
+
+// ExecKey represents a key held by an external signer program, e.g. a bridge to an HSM,
+// a custom KMS or a remote approval service.
+//
+// The program is started for each request, it reads a single JSON request from the standard input
+// and writes a single JSON response to the standard output:
+//
+//	{"version":1,"method":"sign","keyIndex":0,"sigAlgo":"ECDSA_P256","hashAlgo":"SHA3_256","message":"<hex encoded message>"}
+//	{"signature":"<hex encoded signature>"}
+//
+// The message includes the domain tag and must be hashed with the hash algorithm before signing.
+// Failures are reported with {"error":"<reason>"} or a non-zero exit code, the standard error of the program
+// is displayed so it can be used for prompts or progress. Signatures are verified against the public key
+// of the account key, which must be defined so a signature can't be verified with a key chosen by the program.
+type ExecKey struct {
+	*baseKey
+	command   []string
+	publicKey crypto.PublicKey
+}
+
+type execRequest struct {
+	Version  int    `json:"version"`
+	Method   string `json:"method"`
+	KeyIndex int    `json:"keyIndex"`
+	SigAlgo  string `json:"sigAlgo"`
+	HashAlgo string `json:"hashAlgo"`
+	Message  string `json:"message,omitempty"`
+}
+
+type execResponse struct {
+	Signature string `json:"signature"`
+	Error     string `json:"error"`
+}
+
+func execKeyFromConfig(accountKey config.AccountKey) (*ExecKey, error) {
+	return &ExecKey{
+		baseKey:   baseKeyFromConfig(accountKey),
+		command:   accountKey.Command,
+		publicKey: accountKey.PublicKey,
+	}, nil
+}
+
+// PublicKey returns the public key of the account key the signatures are verified against.
+func (e *ExecKey) PublicKey() crypto.PublicKey {
+	return e.publicKey
+}
+
+func (e *ExecKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &execSigner{ctx: ctx, key: e, publicKey: e.publicKey}, nil
+}
+
+func (e *ExecKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible, the key is held by the signer program")
+}
+
+func (e *ExecKey) Validate() error {
+	if len(e.command) == 0 {
+		return fmt.Errorf("missing command of the signer program")
+	}
+	if _, err := exec.LookPath(e.command[0]); err != nil {
+		return fmt.Errorf("signer program %s not found: %w", e.command[0], err)
+	}
+	if e.publicKey == nil {
+		return fmt.Errorf("missing public key the signatures of the signer program are verified against")
+	}
+	return nil
+}
+
+func (e *ExecKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:      config.KeyTypeExec,
+		Index:     e.index,
+		SigAlgo:   e.sigAlgo,
+		HashAlgo:  e.hashAlgo,
+		Command:   e.command,
+		PublicKey: e.publicKey,
+	}
+}
+
+// request runs the signer program with the request and returns the response.
+func (e *ExecKey) request(ctx context.Context, req execRequest) (*execResponse, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	req.Version = ExecProtocolVersion
+	req.KeyIndex = e.Index()
+	req.SigAlgo = e.SigAlgo().String()
+	req.HashAlgo = e.HashAlgo().String()
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, e.command[0], e.command[1:]...)
+	cmd.Stdin = bytes.NewReader(append(input, '\n'))
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	runErr := cmd.Run()
+
+	var res execResponse
+	if err := json.Unmarshal(stdout.Bytes(), &res); err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("signer program failed: %w", runErr)
+		}
+		return nil, fmt.Errorf("invalid response from the signer program: %w", err)
+	}
+	if res.Error != "" {
+		return nil, fmt.Errorf("signer program failed: %s", res.Error)
+	}
+	if runErr != nil {
+		return nil, fmt.Errorf("signer program failed: %w", runErr)
+	}
+
+	return &res, nil
+}
+
+// execSigner signs the messages with the signer program and verifies the signatures.
+type execSigner struct {
+	ctx       context.Context
+	key       *ExecKey
+	publicKey crypto.PublicKey
+}
+
+func (s *execSigner) Sign(message []byte) ([]byte, error) {
+	res, err := s.key.request(s.ctx, execRequest{Method: "sign", Message: hex.EncodeToString(message)})
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(res.Signature, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature returned by the signer program: %w", err)
+	}
+
+	hasher, err := crypto.NewHasher(s.key.HashAlgo())
+	if err != nil {
+		return nil, err
+	}
+
+	valid, err := s.publicKey.Verify(sig, message, hasher)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify the signature returned by the signer program: %w", err)
+	}
+	if !valid {
+		return nil, fmt.Errorf("signature returned by the signer program is not valid for the public key %s", s.publicKey.String())
+	}
+
+	return sig, nil
+}
+
+func (s *execSigner) PublicKey() crypto.PublicKey {
+	return s.publicKey
+}
@@ -0,0 +1,141 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+const execHelperEnv = "FLOW_EXEC_SIGNER_HELPER"
+
+func execHelperKey(t *testing.T) crypto.PrivateKey {
+	key, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_secp256k1, "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+	require.NoError(t, err)
+	return key
+}
+
+// Test_ExecSignerHelper is not a test, it's the signer program started by the exec key tests.
+func Test_ExecSignerHelper(t *testing.T) {
+	mode := os.Getenv(execHelperEnv)
+	if mode == "" {
+		return
+	}
+
+	var req execRequest
+	require.NoError(t, json.NewDecoder(os.Stdin).Decode(&req))
+
+	key := execHelperKey(t)
+	res := execResponse{}
+	switch {
+	case mode == "fail":
+		res.Error = "request rejected"
+	case req.Method == "sign":
+		message, _ := hex.DecodeString(req.Message)
+		if mode == "invalid" {
+			message = []byte("other message")
+		}
+		signer, _ := crypto.NewInMemorySigner(key, crypto.SHA3_256)
+		sig, _ := signer.Sign(message)
+		res.Signature = hex.EncodeToString(sig)
+	}
+
+	_ = json.NewEncoder(os.Stdout).Encode(res)
+	os.Exit(0)
+}
+
+func Test_Exec_Key(t *testing.T) {
+	command := []string{os.Args[0], "-test.run=Test_ExecSignerHelper"}
+	newKey := func(mode string, publicKey crypto.PublicKey) Key {
+		t.Setenv(execHelperEnv, mode)
+		key, err := keyFromConfig(config.AccountKey{
+			Type:      config.KeyTypeExec,
+			SigAlgo:   crypto.ECDSA_secp256k1,
+			HashAlgo:  crypto.SHA3_256,
+			Command:   command,
+			PublicKey: publicKey,
+		})
+		require.NoError(t, err)
+		require.NoError(t, key.Validate())
+		return key
+	}
+
+	t.Run("Sign", func(t *testing.T) {
+		key := newKey("sign", execHelperKey(t).PublicKey())
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, execHelperKey(t).PublicKey().String(), signer.PublicKey().String())
+
+		message := []byte("message to sign")
+		sig, err := signer.Sign(message)
+		require.NoError(t, err)
+
+		hasher, _ := crypto.NewHasher(crypto.SHA3_256)
+		valid, err := execHelperKey(t).PublicKey().Verify(sig, message, hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Fail invalid signature", func(t *testing.T) {
+		key := newKey("invalid", execHelperKey(t).PublicKey())
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+
+		_, err = signer.Sign([]byte("message to sign"))
+		assert.EqualError(t, err, fmt.Sprintf("signature returned by the signer program is not valid for the public key %s", execHelperKey(t).PublicKey().String()))
+	})
+
+	t.Run("Fail rejected", func(t *testing.T) {
+		key := newKey("fail", execHelperKey(t).PublicKey())
+
+		signer, err := key.Signer(context.Background())
+		require.NoError(t, err)
+
+		_, err = signer.Sign([]byte("message to sign"))
+		assert.EqualError(t, err, "signer program failed: request rejected")
+	})
+
+	t.Run("Fail missing public key", func(t *testing.T) {
+		key, err := keyFromConfig(config.AccountKey{Type: config.KeyTypeExec, Command: command})
+		require.NoError(t, err)
+		assert.EqualError(t, key.Validate(), "missing public key the signatures of the signer program are verified against")
+
+		_, err = key.Signer(context.Background())
+		assert.EqualError(t, err, "missing public key the signatures of the signer program are verified against")
+		assert.Nil(t, key.ToConfig().PublicKey)
+	})
+
+	t.Run("Fail missing program", func(t *testing.T) {
+		key, err := keyFromConfig(config.AccountKey{Type: config.KeyTypeExec, Command: []string{"flow-missing-signer"}})
+		require.NoError(t, err)
+		assert.ErrorContains(t, key.Validate(), "signer program flow-missing-signer not found")
+	})
+}
@@ -81,6 +81,14 @@ func keyFromConfig(accountKeyConf config.AccountKey) (Key, error) {
 		return kmsKeyFromConfig(accountKeyConf)
 	case config.KeyTypeFile:
 		return fileKeyFromConfig(accountKeyConf)
+	case config.KeyTypeEncrypted:
+		return encryptedKeyFromConfig(accountKeyConf)
+	case config.KeyTypeEnv:
+		return envKeyFromConfig(accountKeyConf)
+	case config.KeyTypeWalletConnect:
+		return walletConnectKeyFromConfig(accountKeyConf)
+	case config.KeyTypeExec:
+		return execKeyFromConfig(accountKeyConf)
 	}
 
 	return nil, fmt.Errorf(`invalid key type: "%s"`, accountKeyConf.Type)
@@ -0,0 +1,179 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Key = &PayerServiceKey{}
+
+// PayerServiceProtocolVersion is the version of the protocol used to communicate with the payer services.
+const PayerServiceProtocolVersion = 1
+
+// PayerServiceTimeout is the time the payer service has to sign a transaction.
+var PayerServiceTimeout = time.Minute
+
+// PayerServiceKey represents the key of a remote payer service which pays the fees of transactions
+// by signing their envelope, so the payer key is never held locally.
+//
+// The whole transaction is sent to the service so it can decide whether to pay for it, the service is called
+// with a single JSON POST request:
+//
+//	{"version":1,"network":"testnet","address":"<payer address>","keyIndex":0,"transaction":"<hex encoded RLP transaction>"}
+//	{"signature":"<hex encoded envelope signature>"}
+//
+// The transaction includes all the payload signatures. Failures are reported with {"error":"<reason>"}
+// or a non-success status code.
+type PayerServiceKey struct {
+	*baseKey
+	service config.PayerService
+	network string
+	client  *http.Client
+}
+
+type payerServiceRequest struct {
+	Version     int    `json:"version"`
+	Network     string `json:"network"`
+	Address     string `json:"address"`
+	KeyIndex    int    `json:"keyIndex"`
+	Transaction string `json:"transaction"`
+}
+
+type payerServiceResponse struct {
+	Signature string `json:"signature"`
+	Error     string `json:"error"`
+}
+
+// NewPayerServiceAccount creates the payer account signing with the payer service of the network.
+func NewPayerServiceAccount(network config.Network) (*Account, error) {
+	if !network.Payer.Enabled() {
+		return nil, fmt.Errorf("network %s has no payer service configured", network.Name)
+	}
+
+	return &Account{
+		Name:    fmt.Sprintf("%s-payer-service", network.Name),
+		Address: network.Payer.Address,
+		Key: &PayerServiceKey{
+			baseKey: &baseKey{
+				keyType: config.KeyTypePayerService,
+				index:   network.Payer.KeyIndex,
+			},
+			service: network.Payer,
+			network: network.Name,
+			client:  &http.Client{Timeout: PayerServiceTimeout},
+		},
+	}, nil
+}
+
+// SignTransaction sends the transaction to the payer service and returns the envelope signature.
+func (p *PayerServiceKey) SignTransaction(ctx context.Context, tx *flow.Transaction) ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(payerServiceRequest{
+		Version:     PayerServiceProtocolVersion,
+		Network:     p.network,
+		Address:     fmt.Sprintf("0x%s", p.service.Address.Hex()),
+		KeyIndex:    p.Index(),
+		Transaction: hex.EncodeToString(tx.Encode()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.service.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.service.TokenEnv != "" {
+		token := os.Getenv(p.service.TokenEnv)
+		if token == "" {
+			return nil, fmt.Errorf("payer service token is not set, set it with the %s environment variable", p.service.TokenEnv)
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach the payer service: %w", err)
+	}
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the payer service response: %w", err)
+	}
+
+	var payerRes payerServiceResponse
+	decodeErr := json.Unmarshal(data, &payerRes)
+	if decodeErr == nil && payerRes.Error != "" {
+		return nil, fmt.Errorf("payer service rejected the transaction: %s", payerRes.Error)
+	}
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil, fmt.Errorf("payer service rejected the transaction: %s", res.Status)
+	}
+	if decodeErr != nil {
+		return nil, fmt.Errorf("invalid response from the payer service: %w", decodeErr)
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(payerRes.Signature, "0x"))
+	if err != nil || len(sig) == 0 {
+		return nil, fmt.Errorf("invalid signature returned by the payer service")
+	}
+
+	return sig, nil
+}
+
+func (p *PayerServiceKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	return nil, fmt.Errorf("payer service only signs complete transactions as payer")
+}
+
+func (p *PayerServiceKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible, the key is held by the payer service")
+}
+
+func (p *PayerServiceKey) Validate() error {
+	if !p.service.Enabled() {
+		return fmt.Errorf("missing payer service URL")
+	}
+	return nil
+}
+
+func (p *PayerServiceKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:  config.KeyTypePayerService,
+		Index: p.index,
+	}
+}
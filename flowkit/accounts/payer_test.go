@@ -0,0 +1,105 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package accounts
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func payerServiceNetwork(url string) config.Network {
+	return config.Network{
+		Name: "testnet",
+		Payer: config.PayerService{
+			URL:      url,
+			Address:  flow.HexToAddress("0x01"),
+			KeyIndex: 3,
+			TokenEnv: "FLOW_TEST_PAYER_TOKEN",
+		},
+	}
+}
+
+func Test_PayerService(t *testing.T) {
+	tx := flow.NewTransaction().SetPayer(flow.HexToAddress("0x01"))
+
+	t.Run("Sign", func(t *testing.T) {
+		t.Setenv("FLOW_TEST_PAYER_TOKEN", "secret")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+
+			var req payerServiceRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, PayerServiceProtocolVersion, req.Version)
+			assert.Equal(t, "testnet", req.Network)
+			assert.Equal(t, "0x0000000000000001", req.Address)
+			assert.Equal(t, 3, req.KeyIndex)
+			assert.Equal(t, hex.EncodeToString(tx.Encode()), req.Transaction)
+
+			_ = json.NewEncoder(w).Encode(payerServiceResponse{Signature: "0xabcd"})
+		}))
+		defer server.Close()
+
+		account, err := NewPayerServiceAccount(payerServiceNetwork(server.URL))
+		require.NoError(t, err)
+		assert.Equal(t, flow.HexToAddress("0x01"), account.Address)
+		assert.Equal(t, 3, account.Key.Index())
+
+		sig, err := account.Key.(*PayerServiceKey).SignTransaction(context.Background(), tx)
+		require.NoError(t, err)
+		assert.Equal(t, []byte{0xab, 0xcd}, sig)
+	})
+
+	t.Run("Fail Rejected", func(t *testing.T) {
+		t.Setenv("FLOW_TEST_PAYER_TOKEN", "secret")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(payerServiceResponse{Error: "quota exceeded"})
+		}))
+		defer server.Close()
+
+		account, err := NewPayerServiceAccount(payerServiceNetwork(server.URL))
+		require.NoError(t, err)
+
+		_, err = account.Key.(*PayerServiceKey).SignTransaction(context.Background(), tx)
+		assert.EqualError(t, err, "payer service rejected the transaction: quota exceeded")
+	})
+
+	t.Run("Fail Missing Token", func(t *testing.T) {
+		t.Setenv("FLOW_TEST_PAYER_TOKEN", "")
+		account, err := NewPayerServiceAccount(payerServiceNetwork("http://127.0.0.1:1"))
+		require.NoError(t, err)
+
+		_, err = account.Key.(*PayerServiceKey).SignTransaction(context.Background(), tx)
+		assert.EqualError(t, err, "payer service token is not set, set it with the FLOW_TEST_PAYER_TOKEN environment variable")
+	})
+
+	t.Run("Fail Not Configured", func(t *testing.T) {
+		_, err := NewPayerServiceAccount(config.Network{Name: "testnet"})
+		assert.EqualError(t, err, "network testnet has no payer service configured")
+	})
+}
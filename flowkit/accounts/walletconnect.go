@@ -0,0 +1,167 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Key = &WalletConnectKey{}
+
+// WalletConnectProjectIDEnv is the environment variable from which the WalletConnect project ID is read
+// if it is not defined on the key.
+const WalletConnectProjectIDEnv = "FLOW_WALLETCONNECT_PROJECT_ID"
+
+// WalletConnectRelayURL is the relay server used to exchange messages with the wallet.
+var WalletConnectRelayURL = "wss://relay.walletconnect.com"
+
+// WalletConnectTimeout is the time the user has to approve a request in the wallet.
+var WalletConnectTimeout = 5 * time.Minute
+
+// WalletConnectPrompt is called with the pairing URI which must be opened by the wallet to connect,
+// it can be overridden to display the URI differently (e.g. as a QR code).
+var WalletConnectPrompt = func(uri string) {
+	_, _ = fmt.Fprintf(os.Stderr, "Open the following link with your wallet to connect:\n\n%s\n\n", uri)
+}
+
+// WalletConnectKey represents a key held by a mobile wallet, each signature is approved in the wallet
+// connected with WalletConnect so the private key never leaves the device.
+//
+// The wallet is connected on the first signature and the session is reused for all subsequent signatures.
+type WalletConnectKey struct {
+	*baseKey
+	projectID string
+	address   flow.Address
+	network   string
+
+	mu      sync.Mutex
+	session *wcSession
+}
+
+func walletConnectKeyFromConfig(accountKey config.AccountKey) (*WalletConnectKey, error) {
+	return &WalletConnectKey{
+		baseKey:   baseKeyFromConfig(accountKey),
+		projectID: accountKey.ProjectID,
+	}, nil
+}
+
+// SetNetwork sets the network for which the wallet signs.
+func (w *WalletConnectKey) SetNetwork(network string) {
+	w.network = network
+}
+
+// ProjectID returns the WalletConnect project ID used to connect to the relay.
+func (w *WalletConnectKey) ProjectID() string {
+	if w.projectID != "" {
+		return w.projectID
+	}
+	return os.Getenv(WalletConnectProjectIDEnv)
+}
+
+func (w *WalletConnectKey) Signer(ctx context.Context) (crypto.Signer, error) {
+	return &walletConnectSigner{key: w}, nil
+}
+
+func (w *WalletConnectKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return nil, fmt.Errorf("private key not accessible, the key is held by the wallet connected with WalletConnect")
+}
+
+func (w *WalletConnectKey) Validate() error {
+	if w.ProjectID() == "" {
+		return fmt.Errorf("missing WalletConnect project ID, set it on the key or with the %s environment variable", WalletConnectProjectIDEnv)
+	}
+	return nil
+}
+
+func (w *WalletConnectKey) ToConfig() config.AccountKey {
+	return config.AccountKey{
+		Type:      config.KeyTypeWalletConnect,
+		Index:     w.index,
+		SigAlgo:   w.sigAlgo,
+		HashAlgo:  w.hashAlgo,
+		ProjectID: w.projectID,
+	}
+}
+
+// chainID returns the CAIP-2 chain identifier of the network as used by the Flow wallets.
+func (w *WalletConnectKey) chainID() string {
+	network := w.network
+	if network == "" || network == config.EmulatorNetwork.Name {
+		network = "local"
+	}
+	return fmt.Sprintf("flow:%s", network)
+}
+
+// sign requests the signature of the message from the connected wallet, connecting the wallet first if needed.
+func (w *WalletConnectKey) sign(message []byte) ([]byte, error) {
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), WalletConnectTimeout)
+	defer cancel()
+
+	if w.session == nil {
+		session, err := connectWallet(ctx, WalletConnectRelayURL, w.ProjectID(), w.chainID(), w.address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect the wallet: %w", err)
+		}
+		w.session = session
+	}
+
+	signable, err := newSignable(message, w.address, w.index)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := w.session.requestSignature(ctx, signable)
+	if err != nil {
+		w.session.close()
+		w.session = nil
+		return nil, err
+	}
+
+	return sig, nil
+}
+
+// walletConnectSigner signs the messages with the wallet connected to the key.
+type walletConnectSigner struct {
+	key *WalletConnectKey
+}
+
+func (s *walletConnectSigner) Sign(message []byte) ([]byte, error) {
+	return s.key.sign(message)
+}
+
+// PublicKey is not known to the signer since the key is held by the wallet.
+func (s *walletConnectSigner) PublicKey() crypto.PublicKey {
+	return nil
+}
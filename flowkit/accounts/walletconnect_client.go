@@ -0,0 +1,609 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/mr-tron/base58"
+	"github.com/onflow/flow-go-sdk"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/hkdf"
+	"nhooyr.io/websocket"
+)
+
+// WalletConnect v2 protocol constants, see https://specs.walletconnect.com/2.0.
+const (
+	wcMethodAuthz = "flow_authz"
+
+	wcTagSessionPropose         = 1100
+	wcTagSessionProposeResponse = 1101
+	wcTagSessionSettleResponse  = 1103
+	wcTagSessionRequest         = 1108
+	wcTagSessionRequestResponse = 1109
+	wcTagSessionPingResponse    = 1115
+
+	wcTTL = 300
+)
+
+// wcRandom and wcNow are the randomness and clock used by the client, recorded relay sessions
+// replace them so the recorded traffic can be replayed deterministically.
+var (
+	wcRandom io.Reader = rand.Reader
+	wcNow              = time.Now
+)
+
+// wcRPC is a JSON-RPC message exchanged with the relay or with the wallet.
+type wcRPC struct {
+	ID      int64           `json:"id"`
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *wcError        `json:"error,omitempty"`
+}
+
+type wcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *wcError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+type wcSubscription struct {
+	ID   string `json:"id"`
+	Data struct {
+		Topic   string `json:"topic"`
+		Message string `json:"message"`
+	} `json:"data"`
+}
+
+type wcPublish struct {
+	Topic   string `json:"topic"`
+	Message string `json:"message"`
+	TTL     int    `json:"ttl"`
+	Tag     int    `json:"tag"`
+	Prompt  bool   `json:"prompt"`
+}
+
+type wcNamespace struct {
+	Chains   []string `json:"chains,omitempty"`
+	Accounts []string `json:"accounts,omitempty"`
+	Methods  []string `json:"methods"`
+	Events   []string `json:"events"`
+}
+
+type wcProposalResponse struct {
+	ResponderPublicKey string `json:"responderPublicKey"`
+}
+
+type wcSessionSettle struct {
+	Namespaces map[string]wcNamespace `json:"namespaces"`
+}
+
+type wcPollingResponse struct {
+	Status string `json:"status"`
+	Reason string `json:"reason"`
+	Data   struct {
+		Signature string `json:"signature"`
+	} `json:"data"`
+	Signature string `json:"signature"`
+}
+
+// wcClient is a minimal WalletConnect client exchanging encrypted messages with the wallet through the relay.
+type wcClient struct {
+	conn *websocket.Conn
+	keys map[string][]byte // symmetric keys by topic
+}
+
+// wcSession is a session established with the wallet.
+type wcSession struct {
+	client  *wcClient
+	topic   string
+	chainID string
+}
+
+func newWCID() int64 {
+	n, _ := rand.Int(wcRandom, big.NewInt(1000))
+	return wcNow().UnixMilli()*1000 + n.Int64()
+}
+
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	_, _ = io.ReadFull(wcRandom, b)
+	return b
+}
+
+// relayAuthToken creates the JWT authenticating the client on the relay signed by a random ed25519 key,
+// the key is identified using the did:key format.
+func relayAuthToken(relayURL string) (string, error) {
+	pub, priv, err := ed25519.GenerateKey(wcRandom)
+	if err != nil {
+		return "", err
+	}
+
+	header, _ := json.Marshal(map[string]string{"alg": "EdDSA", "typ": "JWT"})
+	now := wcNow().Unix()
+	claims, _ := json.Marshal(map[string]any{
+		"iss": "did:key:z" + base58.Encode(append([]byte{0xed, 0x01}, pub...)),
+		"sub": hex.EncodeToString(randomBytes(32)),
+		"aud": relayURL,
+		"iat": now,
+		"exp": now + 24*60*60,
+	})
+
+	data := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	sig := ed25519.Sign(priv, []byte(data))
+	return data + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
+
+// encryptEnvelope encrypts the payload with the symmetric key into a type 0 envelope.
+func encryptEnvelope(key []byte, payload []byte) (string, error) {
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return "", err
+	}
+
+	iv := randomBytes(chacha20poly1305.NonceSize)
+	envelope := append([]byte{0}, iv...)
+	envelope = aead.Seal(envelope, iv, payload, nil)
+	return base64.StdEncoding.EncodeToString(envelope), nil
+}
+
+// decryptEnvelope decrypts the type 0 envelope with the symmetric key.
+func decryptEnvelope(key []byte, message string) ([]byte, error) {
+	envelope, err := base64.StdEncoding.DecodeString(message)
+	if err != nil {
+		return nil, err
+	}
+	if len(envelope) < 1+chacha20poly1305.NonceSize || envelope[0] != 0 {
+		return nil, fmt.Errorf("unsupported envelope")
+	}
+
+	aead, err := chacha20poly1305.New(key)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := envelope[1 : 1+chacha20poly1305.NonceSize]
+	return aead.Open(nil, iv, envelope[1+chacha20poly1305.NonceSize:], nil)
+}
+
+// deriveSessionKey derives the session symmetric key and topic from the key exchange.
+func deriveSessionKey(privateKey []byte, peerPublicKey []byte) ([]byte, string, error) {
+	shared, err := curve25519.X25519(privateKey, peerPublicKey)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, shared, nil, nil), key); err != nil {
+		return nil, "", err
+	}
+
+	topic := sha256.Sum256(key)
+	return key, hex.EncodeToString(topic[:]), nil
+}
+
+func dialRelay(ctx context.Context, relayURL string, projectID string) (*wcClient, error) {
+	token, err := relayAuthToken(relayURL)
+	if err != nil {
+		return nil, err
+	}
+
+	query := url.Values{}
+	query.Set("auth", token)
+	query.Set("projectId", projectID)
+	query.Set("ua", "wc-2/go/flow-cli")
+
+	conn, _, err := websocket.Dial(ctx, fmt.Sprintf("%s?%s", relayURL, query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+	conn.SetReadLimit(1 << 20)
+
+	return &wcClient{conn: conn, keys: make(map[string][]byte)}, nil
+}
+
+func (c *wcClient) send(ctx context.Context, msg wcRPC) error {
+	msg.JSONRPC = "2.0"
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return c.conn.Write(ctx, websocket.MessageText, data)
+}
+
+func (c *wcClient) subscribe(ctx context.Context, topic string, key []byte) error {
+	c.keys[topic] = key
+	params, _ := json.Marshal(map[string]string{"topic": topic})
+	return c.send(ctx, wcRPC{ID: newWCID(), Method: "irn_subscribe", Params: params})
+}
+
+// publish encrypts the message with the topic key and publishes it to the topic.
+func (c *wcClient) publish(ctx context.Context, topic string, msg wcRPC, tag int) error {
+	msg.JSONRPC = "2.0"
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	message, err := encryptEnvelope(c.keys[topic], payload)
+	if err != nil {
+		return err
+	}
+
+	params, _ := json.Marshal(wcPublish{Topic: topic, Message: message, TTL: wcTTL, Tag: tag, Prompt: true})
+	return c.send(ctx, wcRPC{ID: newWCID(), Method: "irn_publish", Params: params})
+}
+
+// receive reads the next message published by the wallet to any of the subscribed topics.
+func (c *wcClient) receive(ctx context.Context) (string, *wcRPC, error) {
+	for {
+		_, data, err := c.conn.Read(ctx)
+		if err != nil {
+			return "", nil, err
+		}
+
+		var msg wcRPC
+		if err := json.Unmarshal(data, &msg); err != nil {
+			return "", nil, err
+		}
+
+		if msg.Method != "irn_subscription" {
+			if msg.Error != nil {
+				return "", nil, fmt.Errorf("relay error: %w", msg.Error)
+			}
+			continue // acknowledgment of our own request
+		}
+
+		if err := c.send(ctx, wcRPC{ID: msg.ID, Result: json.RawMessage("true")}); err != nil {
+			return "", nil, err
+		}
+
+		var sub wcSubscription
+		if err := json.Unmarshal(msg.Params, &sub); err != nil {
+			return "", nil, err
+		}
+
+		key, ok := c.keys[sub.Data.Topic]
+		if !ok {
+			continue
+		}
+
+		payload, err := decryptEnvelope(key, sub.Data.Message)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to decrypt wallet message: %w", err)
+		}
+
+		var walletMsg wcRPC
+		if err := json.Unmarshal(payload, &walletMsg); err != nil {
+			return "", nil, err
+		}
+
+		return sub.Data.Topic, &walletMsg, nil
+	}
+}
+
+// await receives wallet messages until the response to the request with the provided ID,
+// it also responds to the pings and settles the session on the provided topic.
+func (c *wcClient) await(ctx context.Context, id int64, onSettle func(topic string, settle wcSessionSettle) error) (json.RawMessage, error) {
+	for {
+		topic, msg, err := c.receive(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		switch msg.Method {
+		case "":
+			if msg.ID != id {
+				continue
+			}
+			if msg.Error != nil {
+				return nil, msg.Error
+			}
+			return msg.Result, nil
+
+		case "wc_sessionSettle":
+			var settle wcSessionSettle
+			if err := json.Unmarshal(msg.Params, &settle); err != nil {
+				return nil, err
+			}
+			if onSettle != nil {
+				if err := onSettle(topic, settle); err != nil {
+					return nil, err
+				}
+			}
+			if err := c.publish(ctx, topic, wcRPC{ID: msg.ID, Result: json.RawMessage("true")}, wcTagSessionSettleResponse); err != nil {
+				return nil, err
+			}
+			if id == 0 {
+				return nil, nil
+			}
+
+		case "wc_sessionPing", "wc_pairingPing":
+			if err := c.publish(ctx, topic, wcRPC{ID: msg.ID, Result: json.RawMessage("true")}, wcTagSessionPingResponse); err != nil {
+				return nil, err
+			}
+
+		case "wc_sessionDelete":
+			return nil, fmt.Errorf("session was disconnected by the wallet")
+		}
+	}
+}
+
+// connectWallet pairs with the wallet and waits for the wallet to approve the session
+// including the account for the chain.
+func connectWallet(ctx context.Context, relayURL string, projectID string, chainID string, address flow.Address) (*wcSession, error) {
+	client, err := dialRelay(ctx, relayURL, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	pairingTopic := hex.EncodeToString(randomBytes(32))
+	pairingKey := randomBytes(32)
+	if err := client.subscribe(ctx, pairingTopic, pairingKey); err != nil {
+		client.close()
+		return nil, err
+	}
+
+	privateKey := randomBytes(curve25519.ScalarSize)
+	publicKey, err := curve25519.X25519(privateKey, curve25519.Basepoint)
+	if err != nil {
+		client.close()
+		return nil, err
+	}
+
+	proposal, _ := json.Marshal(map[string]any{
+		"requiredNamespaces": map[string]wcNamespace{
+			"flow": {Chains: []string{chainID}, Methods: []string{wcMethodAuthz}, Events: []string{}},
+		},
+		"relays": []map[string]string{{"protocol": "irn"}},
+		"proposer": map[string]any{
+			"publicKey": hex.EncodeToString(publicKey),
+			"metadata": map[string]any{
+				"name":        "Flow CLI",
+				"description": "Flow command line interface",
+				"url":         "https://developers.flow.com/tools/flow-cli",
+				"icons":       []string{},
+			},
+		},
+		"expiryTimestamp": wcNow().Add(WalletConnectTimeout).Unix(),
+	})
+
+	proposalID := newWCID()
+	err = client.publish(ctx, pairingTopic, wcRPC{ID: proposalID, Method: "wc_sessionPropose", Params: proposal}, wcTagSessionPropose)
+	if err != nil {
+		client.close()
+		return nil, err
+	}
+
+	WalletConnectPrompt(fmt.Sprintf(
+		"wc:%s@2?relay-protocol=irn&symKey=%s&expiryTimestamp=%d",
+		pairingTopic,
+		hex.EncodeToString(pairingKey),
+		wcNow().Add(WalletConnectTimeout).Unix(),
+	))
+
+	result, err := client.await(ctx, proposalID, nil)
+	if err != nil {
+		client.close()
+		return nil, fmt.Errorf("session not approved: %w", err)
+	}
+
+	var response wcProposalResponse
+	if err := json.Unmarshal(result, &response); err != nil {
+		client.close()
+		return nil, err
+	}
+	peerKey, err := hex.DecodeString(response.ResponderPublicKey)
+	if err != nil {
+		client.close()
+		return nil, fmt.Errorf("invalid wallet public key: %w", err)
+	}
+
+	sessionKey, sessionTopic, err := deriveSessionKey(privateKey, peerKey)
+	if err != nil {
+		client.close()
+		return nil, err
+	}
+	if err := client.subscribe(ctx, sessionTopic, sessionKey); err != nil {
+		client.close()
+		return nil, err
+	}
+
+	account := fmt.Sprintf("%s:0x%s", chainID, address.Hex())
+	_, err = client.await(ctx, 0, func(topic string, settle wcSessionSettle) error {
+		if topic != sessionTopic {
+			return fmt.Errorf("session settled on unknown topic")
+		}
+		for _, acc := range settle.Namespaces["flow"].Accounts {
+			if acc == account {
+				return nil
+			}
+		}
+		return fmt.Errorf("wallet did not connect the account 0x%s on %s", address.Hex(), chainID)
+	})
+	if err != nil {
+		client.close()
+		return nil, err
+	}
+
+	return &wcSession{client: client, topic: sessionTopic, chainID: chainID}, nil
+}
+
+// requestSignature requests the wallet to sign the signable and returns the signature.
+func (s *wcSession) requestSignature(ctx context.Context, signable map[string]any) ([]byte, error) {
+	params, err := json.Marshal(map[string]any{
+		"request": map[string]any{
+			"method": wcMethodAuthz,
+			"params": signable,
+		},
+		"chainId": s.chainID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	id := newWCID()
+	err = s.client.publish(ctx, s.topic, wcRPC{ID: id, Method: "wc_sessionRequest", Params: params}, wcTagSessionRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.client.await(ctx, id, nil)
+	if err != nil {
+		return nil, fmt.Errorf("signature not approved: %w", err)
+	}
+
+	var response wcPollingResponse
+	if err := json.Unmarshal(result, &response); err != nil {
+		return nil, err
+	}
+	if response.Status != "" && response.Status != "APPROVED" {
+		return nil, fmt.Errorf("signature not approved: %s", strings.ToLower(response.Reason))
+	}
+
+	signature := response.Data.Signature
+	if signature == "" {
+		signature = response.Signature
+	}
+	if signature == "" {
+		return nil, fmt.Errorf("wallet returned no signature")
+	}
+
+	return hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+}
+
+func (s *wcSession) close() {
+	s.client.close()
+}
+
+func (c *wcClient) close() {
+	_ = c.conn.Close(websocket.StatusNormalClosure, "")
+}
+
+type signablePayload struct {
+	Script                    []byte
+	Arguments                 [][]byte
+	ReferenceBlockID          []byte
+	GasLimit                  uint64
+	ProposalKeyAddress        []byte
+	ProposalKeyIndex          uint64
+	ProposalKeySequenceNumber uint64
+	Payer                     []byte
+	Authorizers               [][]byte
+}
+
+type signableSignature struct {
+	SignerIndex uint
+	KeyIndex    uint
+	Signature   []byte
+}
+
+type signableEnvelope struct {
+	Payload           signablePayload
+	PayloadSignatures []signableSignature
+}
+
+// newSignable creates the signable sent to the wallet, the transaction voucher is decoded from the
+// message so the wallet can display the transaction being signed.
+func newSignable(message []byte, address flow.Address, keyIndex int) (map[string]any, error) {
+	if !bytes.HasPrefix(message, flow.TransactionDomainTag[:]) {
+		return nil, fmt.Errorf("only transactions can be signed with WalletConnect")
+	}
+	encoded := message[len(flow.TransactionDomainTag):]
+
+	var envelope signableEnvelope
+	if err := rlp.DecodeBytes(encoded, &envelope); err != nil {
+		if err := rlp.DecodeBytes(encoded, &envelope.Payload); err != nil {
+			return nil, fmt.Errorf("failed to decode transaction: %w", err)
+		}
+	}
+	payload := envelope.Payload
+
+	withPrefix := func(b []byte) string {
+		return "0x" + flow.BytesToAddress(b).Hex()
+	}
+
+	args := make([]json.RawMessage, len(payload.Arguments))
+	for i, arg := range payload.Arguments {
+		args[i] = bytes.TrimSpace(arg)
+	}
+
+	authorizers := make([]string, len(payload.Authorizers))
+	authorizer := false
+	for i, auth := range payload.Authorizers {
+		authorizers[i] = withPrefix(auth)
+		authorizer = authorizer || flow.BytesToAddress(auth) == address
+	}
+
+	payloadSigs := make([]map[string]any, len(envelope.PayloadSignatures))
+	for i, sig := range envelope.PayloadSignatures {
+		payloadSigs[i] = map[string]any{"keyId": sig.KeyIndex, "sig": hex.EncodeToString(sig.Signature)}
+	}
+
+	return map[string]any{
+		"f_type":  "Signable",
+		"f_vsn":   "1.0.1",
+		"message": hex.EncodeToString(message),
+		"addr":    address.Hex(),
+		"keyId":   keyIndex,
+		"roles": map[string]bool{
+			"proposer":   flow.BytesToAddress(payload.ProposalKeyAddress) == address,
+			"authorizer": authorizer,
+			"payer":      flow.BytesToAddress(payload.Payer) == address,
+			"param":      false,
+		},
+		"cadence": string(payload.Script),
+		"args":    args,
+		"voucher": map[string]any{
+			"cadence":      string(payload.Script),
+			"refBlock":     hex.EncodeToString(payload.ReferenceBlockID),
+			"computeLimit": payload.GasLimit,
+			"arguments":    args,
+			"proposalKey": map[string]any{
+				"address":     withPrefix(payload.ProposalKeyAddress),
+				"keyId":       payload.ProposalKeyIndex,
+				"sequenceNum": payload.ProposalKeySequenceNumber,
+			},
+			"payer":        withPrefix(payload.Payer),
+			"authorizers":  authorizers,
+			"payloadSigs":  payloadSigs,
+			"envelopeSigs": []any{},
+		},
+	}, nil
+}
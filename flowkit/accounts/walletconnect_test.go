@@ -0,0 +1,438 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/curve25519"
+	"nhooyr.io/websocket"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// fakeWallet acts as the relay and as the wallet approving the session and signing the requests.
+type fakeWallet struct {
+	t         *testing.T
+	uris      chan string
+	address   flow.Address
+	signature []byte
+	keys      map[string][]byte
+	signables []map[string]any
+}
+
+func (w *fakeWallet) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	assert.Equal(w.t, "test-project", r.URL.Query().Get("projectId"))
+	assert.NotEmpty(w.t, r.URL.Query().Get("auth"))
+
+	conn, err := websocket.Accept(rw, r, nil)
+	require.NoError(w.t, err)
+	defer conn.Close(websocket.StatusNormalClosure, "")
+
+	ctx := r.Context()
+	send := func(msg wcRPC) {
+		msg.JSONRPC = "2.0"
+		data, _ := json.Marshal(msg)
+		_ = conn.Write(ctx, websocket.MessageText, data)
+	}
+	publish := func(topic string, msg wcRPC) {
+		msg.JSONRPC = "2.0"
+		payload, _ := json.Marshal(msg)
+		message, err := encryptEnvelope(w.keys[topic], payload)
+		require.NoError(w.t, err)
+
+		sub := wcSubscription{ID: "sub"}
+		sub.Data.Topic = topic
+		sub.Data.Message = message
+		params, _ := json.Marshal(sub)
+		send(wcRPC{ID: newWCID(), Method: "irn_subscription", Params: params})
+	}
+
+	for {
+		_, data, err := conn.Read(ctx)
+		if err != nil {
+			return
+		}
+
+		var msg wcRPC
+		require.NoError(w.t, json.Unmarshal(data, &msg))
+		switch msg.Method {
+		case "irn_subscribe":
+			send(wcRPC{ID: msg.ID, Result: json.RawMessage(`"sub"`)})
+			continue
+		case "irn_publish":
+			send(wcRPC{ID: msg.ID, Result: json.RawMessage("true")})
+		default:
+			continue
+		}
+
+		var pub wcPublish
+		require.NoError(w.t, json.Unmarshal(msg.Params, &pub))
+
+		switch pub.Tag {
+		case wcTagSessionPropose:
+			uri, err := url.Parse(<-w.uris)
+			require.NoError(w.t, err)
+			pairingKey, _ := hex.DecodeString(uri.Query().Get("symKey"))
+			w.keys[pub.Topic] = pairingKey
+
+			request := w.decrypt(pub)
+			var proposal struct {
+				Proposer struct {
+					PublicKey string `json:"publicKey"`
+				} `json:"proposer"`
+			}
+			require.NoError(w.t, json.Unmarshal(request.Params, &proposal))
+			peerKey, _ := hex.DecodeString(proposal.Proposer.PublicKey)
+
+			privateKey := randomBytes(curve25519.ScalarSize)
+			publicKey, _ := curve25519.X25519(privateKey, curve25519.Basepoint)
+			sessionKey, sessionTopic, err := deriveSessionKey(privateKey, peerKey)
+			require.NoError(w.t, err)
+			w.keys[sessionTopic] = sessionKey
+
+			result, _ := json.Marshal(wcProposalResponse{ResponderPublicKey: hex.EncodeToString(publicKey)})
+			publish(pub.Topic, wcRPC{ID: request.ID, Result: result})
+
+			settle, _ := json.Marshal(wcSessionSettle{Namespaces: map[string]wcNamespace{
+				"flow": {Accounts: []string{"flow:testnet:0x" + w.address.Hex()}, Methods: []string{wcMethodAuthz}},
+			}})
+			publish(sessionTopic, wcRPC{ID: newWCID(), Method: "wc_sessionSettle", Params: settle})
+
+		case wcTagSessionRequest:
+			request := w.decrypt(pub)
+			var params struct {
+				ChainID string `json:"chainId"`
+				Request struct {
+					Method string         `json:"method"`
+					Params map[string]any `json:"params"`
+				} `json:"request"`
+			}
+			require.NoError(w.t, json.Unmarshal(request.Params, &params))
+			assert.Equal(w.t, "flow:testnet", params.ChainID)
+			assert.Equal(w.t, wcMethodAuthz, params.Request.Method)
+			w.signables = append(w.signables, params.Request.Params)
+
+			result, _ := json.Marshal(map[string]any{
+				"f_type": "PollingResponse",
+				"status": "APPROVED",
+				"data":   map[string]any{"f_type": "CompositeSignature", "signature": hex.EncodeToString(w.signature)},
+			})
+			publish(pub.Topic, wcRPC{ID: request.ID, Result: result})
+		}
+	}
+}
+
+func (w *fakeWallet) decrypt(pub wcPublish) wcRPC {
+	payload, err := decryptEnvelope(w.keys[pub.Topic], pub.Message)
+	require.NoError(w.t, err)
+
+	var msg wcRPC
+	require.NoError(w.t, json.Unmarshal(payload, &msg))
+	return msg
+}
+
+func Test_WalletConnect_Key(t *testing.T) {
+	address := flow.HexToAddress("f8d6e0586b0a20c7")
+	wallet := &fakeWallet{
+		t:         t,
+		uris:      make(chan string, 1),
+		address:   address,
+		signature: []byte{0xde, 0xad, 0xbe, 0xef},
+		keys:      make(map[string][]byte),
+	}
+	server := httptest.NewServer(wallet)
+	defer server.Close()
+
+	relayURL, prompt := WalletConnectRelayURL, WalletConnectPrompt
+	defer func() { WalletConnectRelayURL, WalletConnectPrompt = relayURL, prompt }()
+	WalletConnectRelayURL = "ws" + strings.TrimPrefix(server.URL, "http")
+	WalletConnectPrompt = func(uri string) {
+		assert.True(t, strings.HasPrefix(uri, "wc:"))
+		wallet.uris <- uri
+	}
+
+	accounts, err := FromConfig(&config.Config{Accounts: config.Accounts{{
+		Name:    "admin",
+		Address: address,
+		Key: config.AccountKey{
+			Type:      config.KeyTypeWalletConnect,
+			SigAlgo:   config.DefaultSigAlgo,
+			HashAlgo:  config.DefaultHashAlgo,
+			ProjectID: "test-project",
+		},
+	}}})
+	require.NoError(t, err)
+	accounts.SetNetwork("testnet")
+
+	key := accounts[0].Key
+	require.NoError(t, key.Validate())
+	assert.Equal(t, "test-project", key.ToConfig().ProjectID)
+	_, err = key.PrivateKey()
+	assert.Error(t, err)
+
+	signer, err := key.Signer(context.Background())
+	require.NoError(t, err)
+
+	tx := flow.NewTransaction().
+		SetScript([]byte("transaction {}")).
+		SetProposalKey(address, 0, 1).
+		SetPayer(address).
+		AddAuthorizer(address)
+
+	for i := 0; i < 2; i++ { // second signature reuses the session
+		require.NoError(t, tx.SignEnvelope(address, 0, signer))
+	}
+
+	require.Len(t, tx.EnvelopeSignatures, 2)
+	assert.Equal(t, wallet.signature, tx.EnvelopeSignatures[0].Signature)
+	require.Len(t, wallet.signables, 2)
+
+	signable := wallet.signables[0]
+	assert.Equal(t, address.Hex(), signable["addr"])
+	assert.Equal(t, map[string]any{"proposer": true, "authorizer": true, "payer": true, "param": false}, signable["roles"])
+	assert.Equal(t, "transaction {}", signable["voucher"].(map[string]any)["cadence"])
+	message := append(flow.TransactionDomainTag[:], tx.EnvelopeMessage()...)
+	assert.Equal(t, hex.EncodeToString(message), signable["message"])
+
+	t.Run("Fail missing project ID", func(t *testing.T) {
+		key, err := keyFromConfig(config.AccountKey{Type: config.KeyTypeWalletConnect})
+		require.NoError(t, err)
+		assert.ErrorContains(t, key.Validate(), "missing WalletConnect project ID")
+	})
+}
+
+// wcTranscript is the traffic of a session with a real wallet recorded through the relay,
+// together with the randomness and clock used by the client so the session can be replayed.
+type wcTranscript struct {
+	ProjectID  string    `json:"projectId"`
+	Address    string    `json:"address"`
+	Network    string    `json:"network"`
+	Start      int64     `json:"start"`
+	Random     string    `json:"random"`
+	Frames     []wcFrame `json:"frames"`
+	Signatures []string  `json:"signatures"`
+}
+
+// wcFrame is a websocket message sent by the client to the relay or received from it.
+type wcFrame struct {
+	Sent bool   `json:"sent"`
+	Data string `json:"data"`
+}
+
+// wcTestClock returns a clock starting at the unix milliseconds and advancing a millisecond on every call.
+func wcTestClock(start int64) func() time.Time {
+	now := start
+	return func() time.Time {
+		now++
+		return time.UnixMilli(now)
+	}
+}
+
+type recordingReader struct {
+	r   io.Reader
+	buf bytes.Buffer
+}
+
+func (r *recordingReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	r.buf.Write(p[:n])
+	return n, err
+}
+
+// signWithWallet connects the wallet through the relay and signs a fixed transaction twice,
+// the second signature reusing the session, and returns the signatures.
+func signWithWallet(t *testing.T, relayURL string, transcript *wcTranscript) []string {
+	relay, prompt := WalletConnectRelayURL, WalletConnectPrompt
+	defer func() { WalletConnectRelayURL, WalletConnectPrompt = relay, prompt }()
+	WalletConnectRelayURL = relayURL
+	WalletConnectPrompt = func(uri string) {
+		t.Logf("open the pairing URI in the wallet: %s", uri)
+	}
+
+	address := flow.HexToAddress(transcript.Address)
+	accounts, err := FromConfig(&config.Config{Accounts: config.Accounts{{
+		Name:    "admin",
+		Address: address,
+		Key: config.AccountKey{
+			Type:      config.KeyTypeWalletConnect,
+			SigAlgo:   config.DefaultSigAlgo,
+			HashAlgo:  config.DefaultHashAlgo,
+			ProjectID: transcript.ProjectID,
+		},
+	}}})
+	require.NoError(t, err)
+	accounts.SetNetwork(transcript.Network)
+
+	signer, err := accounts[0].Key.Signer(context.Background())
+	require.NoError(t, err)
+
+	tx := flow.NewTransaction().
+		SetScript([]byte("transaction {}")).
+		SetGasLimit(100).
+		SetProposalKey(address, 0, 0).
+		SetPayer(address).
+		AddAuthorizer(address)
+
+	var signatures []string
+	for i := 0; i < 2; i++ {
+		require.NoError(t, tx.SignEnvelope(address, 0, signer))
+		signatures = append(signatures, hex.EncodeToString(tx.EnvelopeSignatures[i].Signature))
+	}
+	return signatures
+}
+
+// Test_WalletConnect_Record records a session with a real wallet through the WalletConnect relay into testdata.
+//
+// It only runs when FLOW_WALLETCONNECT_RECORD is set to a WalletConnect project ID and FLOW_WALLETCONNECT_ADDRESS
+// to the testnet address connected in the wallet, run it with -v to see the pairing URI.
+func Test_WalletConnect_Record(t *testing.T) {
+	projectID := os.Getenv("FLOW_WALLETCONNECT_RECORD")
+	if projectID == "" {
+		t.Skip("set FLOW_WALLETCONNECT_RECORD to record a session with a real wallet")
+	}
+
+	transcript := &wcTranscript{
+		ProjectID: projectID,
+		Address:   os.Getenv("FLOW_WALLETCONNECT_ADDRESS"),
+		Network:   "testnet",
+		Start:     time.Now().UnixMilli(),
+	}
+
+	random := &recordingReader{r: rand.Reader}
+	defer func(r io.Reader, now func() time.Time) { wcRandom, wcNow = r, now }(wcRandom, wcNow)
+	wcRandom, wcNow = random, wcTestClock(transcript.Start)
+
+	var mu sync.Mutex
+	record := func(sent bool, data []byte) {
+		mu.Lock()
+		defer mu.Unlock()
+		transcript.Frames = append(transcript.Frames, wcFrame{Sent: sent, Data: string(data)})
+	}
+
+	// the proxy forwards the messages between the client and the relay and records them in order
+	relayURL := WalletConnectRelayURL
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		client, err := websocket.Accept(rw, r, nil)
+		require.NoError(t, err)
+		defer client.Close(websocket.StatusNormalClosure, "")
+
+		relay, _, err := websocket.Dial(r.Context(), relayURL+"?"+r.URL.RawQuery, nil)
+		require.NoError(t, err)
+		defer relay.Close(websocket.StatusNormalClosure, "")
+		relay.SetReadLimit(1 << 20)
+
+		forward := func(from *websocket.Conn, to *websocket.Conn, sent bool) {
+			for {
+				_, data, err := from.Read(r.Context())
+				if err != nil {
+					return
+				}
+				record(sent, data)
+				if err := to.Write(r.Context(), websocket.MessageText, data); err != nil {
+					return
+				}
+			}
+		}
+		go forward(relay, client, false)
+		forward(client, relay, true)
+	}))
+	defer proxy.Close()
+
+	transcript.Signatures = signWithWallet(t, "ws"+strings.TrimPrefix(proxy.URL, "http"), transcript)
+	transcript.Random = hex.EncodeToString(random.buf.Bytes())
+
+	mu.Lock()
+	data, err := json.MarshalIndent(transcript, "", "  ")
+	mu.Unlock()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join("testdata", "walletconnect"), 0755))
+	path := filepath.Join("testdata", "walletconnect", time.Now().Format("20060102-150405")+".json")
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	t.Logf("recorded session to %s", path)
+}
+
+// Test_WalletConnect_Replay replays the sessions recorded with real wallets, the client must send exactly
+// the recorded messages and return the signatures made by the wallet.
+//
+// No recorded session is committed yet, so the client is only tested against the simulated relay
+// until a session recorded with Test_WalletConnect_Record is added to testdata/walletconnect.
+func Test_WalletConnect_Replay(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "walletconnect", "*.json"))
+	require.NoError(t, err)
+	if len(paths) == 0 {
+		t.Skip("no recorded sessions in testdata/walletconnect, the client is not tested against real wallet traffic")
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			var transcript wcTranscript
+			require.NoError(t, json.Unmarshal(data, &transcript))
+
+			random, err := hex.DecodeString(transcript.Random)
+			require.NoError(t, err)
+			defer func(r io.Reader, now func() time.Time) { wcRandom, wcNow = r, now }(wcRandom, wcNow)
+			wcRandom, wcNow = bytes.NewReader(random), wcTestClock(transcript.Start)
+
+			relay := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				conn, err := websocket.Accept(rw, r, nil)
+				require.NoError(t, err)
+				defer conn.Close(websocket.StatusNormalClosure, "")
+
+				for i, frame := range transcript.Frames {
+					if !frame.Sent {
+						if err := conn.Write(r.Context(), websocket.MessageText, []byte(frame.Data)); err != nil {
+							return
+						}
+						continue
+					}
+
+					_, data, err := conn.Read(r.Context())
+					if err != nil {
+						return
+					}
+					assert.Equal(t, frame.Data, string(data), "message %d sent by the client", i)
+				}
+			}))
+			defer relay.Close()
+
+			signatures := signWithWallet(t, "ws"+strings.TrimPrefix(relay.URL, "http"), &transcript)
+			assert.Equal(t, transcript.Signatures, signatures)
+		})
+	}
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/onflow/cadence/runtime/cmd"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/interpreter"
+	"github.com/onflow/cadence/runtime/parser"
 	"github.com/onflow/cadence/runtime/sema"
 )
 
@@ -81,6 +82,87 @@ func ParseWithoutType(args []string, code []byte, fileName string) (scriptArgs [
 	program, must := cmd.PrepareProgram(code, location, codes)
 	checker, _ := cmd.PrepareChecker(program, location, codes, nil, must)
 
+	parameterList := declaredParameters(program)
+	if parameterList == nil {
+		return resultArgs, nil
+	}
+
+	if len(parameterList) != len(args) {
+		return nil, fmt.Errorf("argument count is %d, expected %d", len(args), len(parameterList))
+	}
+
+	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	for index, argumentString := range args {
+		astType := parameterList[index].TypeAnnotation.Type
+		semaType := checker.ConvertType(astType)
+
+		if semaType == sema.StringType {
+			if !strings.HasPrefix(argumentString, "\"") {
+				argumentString = ast.QuoteString(argumentString)
+			}
+		} else if _, ok := semaType.(*sema.AddressType); ok {
+			if !strings.HasPrefix(argumentString, "0x") {
+				argumentString = fmt.Sprintf("0x%s", argumentString)
+			}
+		}
+
+		value, err := runtime.ParseLiteral(argumentString, semaType, inter)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"argument `%s` is not expected type `%s`",
+				parameterList[index].Identifier,
+				semaType.QualifiedString(),
+			)
+		}
+
+		resultArgs = append(resultArgs, value)
+	}
+	return resultArgs, nil
+}
+
+// Parameter is a parameter of a script, transaction or contract initializer.
+type Parameter struct {
+	Name string
+	Type sema.Type
+}
+
+// ParseParameters returns the parameters of the script, transaction or contract initializer declared in the Cadence code.
+//
+// Only the types which can be determined from the code alone are converted, parameters of imported or
+// user-defined types have the invalid type.
+func ParseParameters(code []byte, fileName string) ([]Parameter, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, err
+	}
+
+	checker, err := sema.NewChecker(
+		program,
+		common.StringLocation(fileName),
+		nil,
+		&sema.Config{AccessCheckMode: sema.AccessCheckModeNotSpecifiedUnrestricted},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	list := declaredParameters(program)
+	parameters := make([]Parameter, len(list))
+	for i, parameter := range list {
+		parameters[i] = Parameter{
+			Name: parameter.Identifier.Identifier,
+			Type: checker.ConvertType(parameter.TypeAnnotation.Type),
+		}
+	}
+	return parameters, nil
+}
+
+// declaredParameters returns the parameters of the script function, transaction or contract initializer of the program.
+func declaredParameters(program *ast.Program) []*ast.Parameter {
 	var parameterList []*ast.Parameter
 
 	functionDeclaration := sema.FunctionEntryPointDeclaration(program)
@@ -107,43 +189,111 @@ func ParseWithoutType(args []string, code []byte, fileName string) (scriptArgs [
 		}
 	}
 
-	if parameterList == nil {
-		return resultArgs, nil
+	return parameterList
+}
+
+// ValidateInitializer validates the arguments count and types match the contract initializer parameters.
+//
+// Only the argument types which can be determined from the contract code alone are validated,
+// arguments of imported or user-defined types are only counted.
+func ValidateInitializer(args []cadence.Value, code []byte, fileName string) error {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return err
 	}
 
-	if len(parameterList) != len(args) {
-		return nil, fmt.Errorf("argument count is %d, expected %d", len(args), len(parameterList))
+	contract := program.SoleContractDeclaration()
+	if contract == nil {
+		return nil
 	}
 
-	inter, err := interpreter.NewInterpreter(nil, nil, &interpreter.Config{})
-	if err != nil {
-		return nil, err
+	var parameterList []*ast.Parameter
+	initializers := contract.Members.Initializers()
+	if len(initializers) == 1 && initializers[0].FunctionDeclaration.ParameterList != nil {
+		parameterList = initializers[0].FunctionDeclaration.ParameterList.Parameters
 	}
 
-	for index, argumentString := range args {
-		astType := parameterList[index].TypeAnnotation.Type
-		semaType := checker.ConvertType(astType)
+	if len(parameterList) != len(args) {
+		return fmt.Errorf("argument count is %d, expected %d", len(args), len(parameterList))
+	}
 
-		if semaType == sema.StringType {
-			if !strings.HasPrefix(argumentString, "\"") {
-				argumentString = ast.QuoteString(argumentString)
-			}
-		} else if _, ok := semaType.(*sema.AddressType); ok {
-			if !strings.HasPrefix(argumentString, "0x") {
-				argumentString = fmt.Sprintf("0x%s", argumentString)
-			}
-		}
+	checker, err := sema.NewChecker(
+		program,
+		common.StringLocation(fileName),
+		nil,
+		&sema.Config{AccessCheckMode: sema.AccessCheckModeNotSpecifiedUnrestricted},
+	)
+	if err != nil {
+		return err
+	}
 
-		value, err := runtime.ParseLiteral(argumentString, semaType, inter)
-		if err != nil {
-			return nil, fmt.Errorf(
+	for i, arg := range args {
+		semaType := checker.ConvertType(parameterList[i].TypeAnnotation.Type)
+		if !matchesType(arg, semaType) {
+			return fmt.Errorf(
 				"argument `%s` is not expected type `%s`",
-				parameterList[index].Identifier,
+				parameterList[i].Identifier,
 				semaType.QualifiedString(),
 			)
 		}
+	}
 
-		resultArgs = append(resultArgs, value)
+	return nil
+}
+
+// matchesType checks whether the value is of the type, types which can't be compared are considered matching.
+func matchesType(value cadence.Value, semaType sema.Type) bool {
+	switch t := semaType.(type) {
+	case *sema.OptionalType:
+		if optional, ok := value.(cadence.Optional); ok {
+			return optional.Value == nil || matchesType(optional.Value, t.Type)
+		}
+		return matchesType(value, t.Type)
+	case *sema.VariableSizedType:
+		array, ok := value.(cadence.Array)
+		if !ok {
+			return false
+		}
+		for _, v := range array.Values {
+			if !matchesType(v, t.Type) {
+				return false
+			}
+		}
+		return true
+	case *sema.ConstantSizedType:
+		array, ok := value.(cadence.Array)
+		if !ok || int64(len(array.Values)) != t.Size {
+			return false
+		}
+		for _, v := range array.Values {
+			if !matchesType(v, t.Type) {
+				return false
+			}
+		}
+		return true
+	case *sema.DictionaryType:
+		dictionary, ok := value.(cadence.Dictionary)
+		if !ok {
+			return false
+		}
+		for _, pair := range dictionary.Pairs {
+			if !matchesType(pair.Key, t.KeyType) || !matchesType(pair.Value, t.ValueType) {
+				return false
+			}
+		}
+		return true
+	case *sema.SimpleType:
+		if t == sema.AnyStructType || t == sema.AnyType || t == sema.InvalidType {
+			return true
+		}
+		return value.Type().ID() == string(t.ID())
+	case *sema.NumericType:
+		return t.IsSuperType() || value.Type().ID() == string(t.ID())
+	case *sema.FixedPointNumericType:
+		return t.IsSuperType() || value.Type().ID() == string(t.ID())
+	case *sema.AddressType:
+		return value.Type().ID() == string(t.ID())
+	default:
+		return true
 	}
-	return resultArgs, nil
 }
@@ -252,3 +252,107 @@ func Test_ParseJSON(t *testing.T) {
 	assert.Equal(t, `"Hello World"`, values[0].String())
 	assert.Equal(t, "String", values[0].Type().ID())
 }
+
+func Test_ValidateInitializer(t *testing.T) {
+	t.Parallel()
+
+	code := []byte(`
+		import "FungibleToken"
+
+		pub contract Foo {
+			init(name: String, supply: UFix64, owners: [Address], limit: UInt64?, vault: FungibleToken.Vault?) {}
+		}
+	`)
+
+	address := cadence.NewAddress([8]byte{0, 0, 0, 0, 0, 0, 0, 1})
+	supply, _ := cadence.NewUFix64("10.0")
+	valid := []cadence.Value{
+		cadence.String("foo"),
+		supply,
+		cadence.NewArray([]cadence.Value{address}),
+		cadence.NewOptional(nil),
+		cadence.NewOptional(nil),
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+		assert.NoError(t, ValidateInitializer(valid, code, "Foo.cdc"))
+
+		withLimit := append([]cadence.Value{}, valid...)
+		withLimit[3] = cadence.NewOptional(cadence.NewUInt64(10))
+		assert.NoError(t, ValidateInitializer(withLimit, code, "Foo.cdc"))
+	})
+
+	t.Run("Invalid count", func(t *testing.T) {
+		t.Parallel()
+		err := ValidateInitializer(valid[:2], code, "Foo.cdc")
+		assert.EqualError(t, err, "argument count is 2, expected 5")
+	})
+
+	t.Run("Invalid type", func(t *testing.T) {
+		t.Parallel()
+		invalid := append([]cadence.Value{}, valid...)
+		invalid[1] = cadence.NewUInt64(10)
+		err := ValidateInitializer(invalid, code, "Foo.cdc")
+		assert.EqualError(t, err, "argument `supply` is not expected type `UFix64`")
+
+		invalid = append([]cadence.Value{}, valid...)
+		invalid[2] = cadence.NewArray([]cadence.Value{cadence.String("0x01")})
+		err = ValidateInitializer(invalid, code, "Foo.cdc")
+		assert.EqualError(t, err, "argument `owners` is not expected type `[Address]`")
+	})
+
+	t.Run("Without initializer", func(t *testing.T) {
+		t.Parallel()
+		err := ValidateInitializer(nil, []byte(`pub contract Bar {}`), "Bar.cdc")
+		assert.NoError(t, err)
+
+		err = ValidateInitializer([]cadence.Value{cadence.String("foo")}, []byte(`pub contract Bar {}`), "Bar.cdc")
+		assert.EqualError(t, err, "argument count is 1, expected 0")
+	})
+}
+
+func Test_ParseParameters(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Transaction", func(t *testing.T) {
+		t.Parallel()
+		parameters, err := ParseParameters([]byte(`
+			import "FungibleToken"
+
+			transaction(amount: UFix64, to: Address, tags: {String: [Int8]}, vault: FungibleToken.Vault?) {
+				prepare(signer: AuthAccount) {}
+			}
+		`), "tx.cdc")
+		require.NoError(t, err)
+		require.Len(t, parameters, 4)
+
+		types := make([]string, len(parameters))
+		for i, parameter := range parameters {
+			types[i] = fmt.Sprintf("%s: %s", parameter.Name, parameter.Type.QualifiedString())
+		}
+		assert.Equal(t, []string{"amount: UFix64", "to: Address", "tags: {String: [Int8]}", "vault: <<invalid>>?"}, types)
+	})
+
+	t.Run("Script", func(t *testing.T) {
+		t.Parallel()
+		parameters, err := ParseParameters([]byte(`pub fun main(a: Int, b: String?): Int { return a }`), "script.cdc")
+		require.NoError(t, err)
+		require.Len(t, parameters, 2)
+		assert.Equal(t, "b", parameters[1].Name)
+		assert.Equal(t, "String?", parameters[1].Type.QualifiedString())
+	})
+
+	t.Run("Without parameters", func(t *testing.T) {
+		t.Parallel()
+		parameters, err := ParseParameters([]byte(`transaction { execute {} }`), "tx.cdc")
+		require.NoError(t, err)
+		assert.Empty(t, parameters)
+	})
+
+	t.Run("Invalid code", func(t *testing.T) {
+		t.Parallel()
+		_, err := ParseParameters([]byte(`transaction(`), "tx.cdc")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,98 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arguments
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"golang.org/x/exp/slices"
+)
+
+const literalSeparator = " as "
+
+// literalTypes are all the types that can be expressed using a simple literal.
+var literalTypes = []string{
+	"String", "Bool", "Address",
+	"Int", "Int8", "Int16", "Int32", "Int64", "Int128", "Int256",
+	"UInt", "UInt8", "UInt16", "UInt32", "UInt64", "UInt128", "UInt256",
+	"Word8", "Word16", "Word32", "Word64",
+	"Fix64", "UFix64",
+}
+
+// ParseLiteral parses a simple typed literal in the format "<value> as <Type>", e.g. "1.0 as UFix64".
+func ParseLiteral(literal string) (cadence.Value, error) {
+	i := strings.LastIndex(literal, literalSeparator)
+	if i == -1 {
+		return nil, fmt.Errorf("invalid argument literal %s, expected format: <value> as <Type>", literal)
+	}
+
+	value := literal[:i]
+	typeID := strings.TrimSpace(literal[i+len(literalSeparator):])
+	if !slices.Contains(literalTypes, typeID) {
+		return nil, fmt.Errorf("unsupported type %s in argument literal %s", typeID, literal)
+	}
+
+	var jsonValue any = value
+	switch typeID {
+	case "Bool":
+		jsonValue = value == "true"
+		if value != "true" && value != "false" {
+			return nil, fmt.Errorf("invalid Bool value %s in argument literal", value)
+		}
+	case "Address":
+		if !strings.HasPrefix(value, "0x") {
+			jsonValue = fmt.Sprintf("0x%s", value)
+		}
+	}
+
+	b, err := json.Marshal(map[string]any{
+		"type":  typeID,
+		"value": jsonValue,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	arg, err := jsoncdc.Decode(nil, b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid argument literal %s: %w", literal, err)
+	}
+
+	return arg, nil
+}
+
+// FormatLiteral formats the value as a simple typed literal, if the value type can't be expressed
+// as a literal false is returned.
+func FormatLiteral(value cadence.Value) (string, bool) {
+	typeID := value.Type().ID()
+	if !slices.Contains(literalTypes, typeID) {
+		return "", false
+	}
+
+	literal := value.String()
+	if str, ok := value.(cadence.String); ok {
+		literal = string(str) // avoid quoting of strings
+	}
+
+	return fmt.Sprintf("%s%s%s", literal, literalSeparator, typeID), true
+}
@@ -0,0 +1,71 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package arguments
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseLiteral(t *testing.T) {
+	ufix, _ := cadence.NewUFix64("1.0")
+
+	testCases := []struct {
+		literal   string
+		value     cadence.Value
+		formatted string
+	}{
+		{"1.0 as UFix64", ufix, "1.00000000 as UFix64"},
+		{"42 as UInt64", cadence.NewUInt64(42), "42 as UInt64"},
+		{"-3 as Int", cadence.NewInt(-3), "-3 as Int"},
+		{"true as Bool", cadence.NewBool(true), "true as Bool"},
+		{"Hello as World as String", cadence.String("Hello as World"), "Hello as World as String"},
+		{"01cf0e2f2f715450 as Address", cadence.NewAddress([8]byte{0x01, 0xcf, 0x0e, 0x2f, 0x2f, 0x71, 0x54, 0x50}), "0x01cf0e2f2f715450 as Address"},
+	}
+
+	for _, tc := range testCases {
+		value, err := ParseLiteral(tc.literal)
+		require.NoError(t, err, tc.literal)
+		assert.Equal(t, tc.value, value)
+
+		formatted, ok := FormatLiteral(value)
+		assert.True(t, ok)
+		assert.Equal(t, tc.formatted, formatted)
+	}
+}
+
+func Test_ParseLiteralInvalid(t *testing.T) {
+	_, err := ParseLiteral("1.0")
+	assert.EqualError(t, err, "invalid argument literal 1.0, expected format: <value> as <Type>")
+
+	_, err = ParseLiteral("1 as Path")
+	assert.EqualError(t, err, "unsupported type Path in argument literal 1 as Path")
+
+	_, err = ParseLiteral("yes as Bool")
+	assert.EqualError(t, err, "invalid Bool value yes in argument literal")
+
+	_, err = ParseLiteral("foo as UInt8")
+	assert.Error(t, err)
+
+	_, ok := FormatLiteral(cadence.NewArray([]cadence.Value{}).WithType(cadence.NewVariableSizedArrayType(cadence.StringType{})))
+	assert.False(t, ok)
+}
@@ -33,9 +33,10 @@ const (
 
 // Account defines the configuration for a Flow account.
 type Account struct {
-	Name    string
-	Address flow.Address
-	Key     AccountKey
+	Name     string
+	Address  flow.Address
+	Key      AccountKey
+	FromFile string // path of the file defining the account, relative to the configuration
 }
 
 type Accounts []Account
@@ -52,6 +53,11 @@ type AccountKey struct {
 	PrivateKey     crypto.PrivateKey
 	Location       string
 	Env            string
+	EncryptedKey   string
+	NetworkEnv     map[string]string // environment variables holding the key for specific networks
+	ProjectID      string            // WalletConnect project ID
+	Command        []string          // external signer program and its arguments
+	PublicKey      crypto.PublicKey  // public key the external signatures are verified against
 }
 
 func NewDefaultAccountKey(pkey crypto.PrivateKey) AccountKey {
@@ -0,0 +1,192 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// resolveAccountFiles replaces the accounts referencing a file with the account definitions from that file.
+func (l *Loader) resolveAccountFiles(conf *Config, baseDir string) error {
+	loaded := make(map[string]*Config)
+
+	for i, account := range conf.Accounts {
+		if account.FromFile == "" {
+			continue
+		}
+		if baseDir == "" {
+			return fmt.Errorf("account %s from file is not supported inside a remote include", account.Name)
+		}
+
+		path := accountFilePath(baseDir, account.FromFile)
+		fileConf, ok := loaded[path]
+		if !ok {
+			var err error
+			fileConf, err = l.loadAccountFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to load account %s from file %s: %w", account.Name, account.FromFile, err)
+			}
+			loaded[path] = fileConf
+		}
+
+		fileAccount, err := fileConf.Accounts.ByName(account.Name)
+		if err != nil {
+			return fmt.Errorf("account %s is not present in file %s", account.Name, account.FromFile)
+		}
+		if fileAccount.FromFile != "" {
+			return fmt.Errorf("account %s in file %s can not reference another file", account.Name, account.FromFile)
+		}
+
+		fileAccount.FromFile = account.FromFile
+		conf.Accounts[i] = *fileAccount
+	}
+
+	return nil
+}
+
+func (l *Loader) loadAccountFile(path string) (*Config, error) {
+	raw, err := l.loadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := l.accountFileParser(path)
+	if parser == nil {
+		return nil, fmt.Errorf("parser not found for account file: %s", path)
+	}
+
+	if validator, ok := parser.(Validator); ok {
+		if err := validator.Validate(raw); err != nil {
+			return nil, err
+		}
+	}
+
+	preProcessed, err := l.preprocess(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return parser.Deserialize(preProcessed)
+}
+
+// saveAccountFiles saves the accounts referencing a file to that file and makes sure the files are ignored by git.
+//
+// Other accounts already defined in the files are preserved.
+func (l *Loader) saveAccountFiles(conf *Config, confPath string) error {
+	baseDir := filepath.Dir(confPath)
+	files := make([]string, 0)
+	accounts := make(map[string]Accounts)
+
+	for _, account := range conf.Accounts {
+		file := account.FromFile
+		if file == "" {
+			continue
+		}
+		if _, ok := accounts[file]; !ok {
+			files = append(files, file)
+		}
+
+		account.FromFile = ""
+		accounts[file] = append(accounts[file], account)
+	}
+
+	for _, file := range files {
+		path := accountFilePath(baseDir, file)
+
+		fileConf, err := l.loadAccountFile(path)
+		if errors.Is(err, ErrDoesNotExist) {
+			fileConf = &Config{}
+		} else if err != nil {
+			return fmt.Errorf("failed to load account file %s: %w", file, err)
+		}
+
+		for _, account := range accounts[file] {
+			fileConf.Accounts.AddOrUpdate(account.Name, account)
+		}
+
+		data, err := l.accountFileParser(path).Serialize(fileConf)
+		if err != nil {
+			return err
+		}
+
+		err = l.readerWriter.WriteFile(path, data, 0600)
+		if err != nil {
+			return err
+		}
+
+		err = l.ignoreInGit(baseDir, file)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ignoreInGit adds the file to the .gitignore file in the directory if the file is not already listed.
+func (l *Loader) ignoreInGit(dir string, file string) error {
+	if filepath.IsAbs(file) {
+		return nil
+	}
+
+	entry := filepath.ToSlash(filepath.Clean(file))
+	if strings.HasPrefix(entry, "../") {
+		return nil // file is outside the project
+	}
+
+	path := filepath.Join(dir, ".gitignore")
+	raw, err := l.loadFile(path)
+	if err != nil && !errors.Is(err, ErrDoesNotExist) {
+		return err
+	}
+
+	for _, line := range strings.Split(string(raw), "\n") {
+		line = strings.TrimSpace(line)
+		if line == entry || line == "/"+entry {
+			return nil
+		}
+	}
+
+	content := string(raw)
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	content += entry + "\n"
+
+	return l.readerWriter.WriteFile(path, []byte(content), 0644)
+}
+
+// accountFileParser finds the parser for the account file, defaulting to JSON.
+func (l *Loader) accountFileParser(path string) Parser {
+	parser := l.configParsers.FindForFormat(filepath.Ext(path))
+	if parser == nil {
+		parser = l.configParsers.FindForFormat(".json")
+	}
+	return parser
+}
+
+func accountFilePath(baseDir string, file string) string {
+	if filepath.IsAbs(file) {
+		return file
+	}
+	return filepath.Join(baseDir, file)
+}
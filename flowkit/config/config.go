@@ -32,21 +32,35 @@ import (
 // Networks defines all the Flow networks addresses
 // Accounts defines Flow accounts and their addresses, private key and more properties
 // Deployments describes which contracts should be deployed to which accounts
+// Includes lists shared configuration fragments merged into this configuration
+// Projects references configurations of other projects whose contracts and accounts are namespaced with the project name
+// Vars defines variables which can be used in contract locations
+// Profiles defines deployment environments binding a network with signer accounts and variable values
 type Config struct {
+	Includes    []string
+	Projects    map[string]string
+	Vars        map[string]string
 	Emulators   Emulators
 	Contracts   Contracts
 	Networks    Networks
 	Accounts    Accounts
 	Deployments Deployments
+	Profiles    Profiles
 }
 
 type KeyType string
 
 const (
-	KeyTypeHex       KeyType = "hex"
-	KeyTypeGoogleKMS KeyType = "google-kms"
-	KeyTypeBip44     KeyType = "bip44"
-	KeyTypeFile      KeyType = "file"
+	KeyTypeHex           KeyType = "hex"
+	KeyTypeGoogleKMS     KeyType = "google-kms"
+	KeyTypeBip44         KeyType = "bip44"
+	KeyTypeFile          KeyType = "file"
+	KeyTypeEncrypted     KeyType = "encrypted"
+	KeyTypeEnv           KeyType = "env"
+	KeyTypeWalletConnect KeyType = "walletconnect"
+	KeyTypeExec          KeyType = "exec"
+	// KeyTypePayerService is the key of a network payer service, it is defined on the network and not on accounts.
+	KeyTypePayerService KeyType = "payer-service"
 )
 
 // Validate the configuration values.
@@ -82,6 +96,20 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	for _, p := range c.Profiles {
+		_, err := c.Networks.ByName(p.Network)
+		_, presetErr := PresetNetworks.ByName(p.Network)
+		if err != nil && presetErr != nil {
+			return fmt.Errorf("profile %s contains nonexisting network %s", p.Name, p.Network)
+		}
+
+		for _, account := range p.Accounts {
+			if _, err := c.Accounts.ByName(account); err != nil {
+				return fmt.Errorf("profile %s contains nonexisting account %s", p.Name, account)
+			}
+		}
+	}
+
 	return nil
 }
 
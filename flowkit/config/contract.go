@@ -30,6 +30,8 @@ type Contract struct {
 	Name     string
 	Location string
 	Aliases  Aliases
+	// RawLocation is the location before the variables were interpolated.
+	RawLocation string
 }
 
 // Alias defines an existing pre-deployed contract address for specific network.
@@ -62,6 +64,17 @@ func (a *Aliases) Add(network string, address flow.Address) {
 	})
 }
 
+// AddOrUpdate adds a new alias or updates the address if alias for network is already present.
+func (a *Aliases) AddOrUpdate(network string, address flow.Address) {
+	for i, alias := range *a {
+		if alias.Network == network {
+			(*a)[i].Address = address
+			return
+		}
+	}
+	a.Add(network, address)
+}
+
 type Contracts []Contract
 
 // IsAliased checks if contract has an alias.
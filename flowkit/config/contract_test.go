@@ -41,6 +41,15 @@ func TestAliases_Add_Duplicate(t *testing.T) {
 	assert.Len(t, aliases, 1)
 }
 
+func TestAliases_AddOrUpdate(t *testing.T) {
+	aliases := Aliases{}
+	aliases.AddOrUpdate("testnet", flow.HexToAddress("0xabcdef"))
+	aliases.AddOrUpdate("testnet", flow.HexToAddress("0x123456"))
+
+	assert.Len(t, aliases, 1)
+	assert.Equal(t, flow.HexToAddress("0x123456"), aliases.ByNetwork("testnet").Address)
+}
+
 func TestContracts_AddOrUpdate_Add(t *testing.T) {
 	contracts := Contracts{}
 	contracts.AddOrUpdate(Contract{Name: "mycontract", Location: "path/to/contract.cdc"})
@@ -26,9 +26,26 @@ import (
 )
 
 // ContractDeployment defines the deployment of the contract with possible args.
+//
+// Args can optionally be named, in which case ArgNames contains the initializer parameter name for each argument.
+// Arguments defined using simple literal syntax have their literal in LiteralArgs at the same index,
+// arguments defined as JSON-Cadence have an empty literal.
 type ContractDeployment struct {
-	Name string
-	Args []cadence.Value
+	Name        string
+	Args        []cadence.Value
+	ArgNames    []string
+	LiteralArgs []string
+	Hooks       []DeploymentHook
+}
+
+// DeploymentHook runs after the contract is deployed.
+//
+// The hook is either a Cadence transaction signed by the deployment account or a shell command.
+type DeploymentHook struct {
+	Transaction string          // location of the transaction file
+	Args        []cadence.Value // transaction arguments
+	LiteralArgs []string        // literals of the args defined using simple literal syntax
+	Command     string          // shell command
 }
 
 // Deployment defines the configuration for a contract deployment.
@@ -18,6 +18,12 @@
 
 package config
 
+import (
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
 var (
 	DefaultEmulator = Emulator{
 		Name:           "default",
@@ -32,8 +38,22 @@ type Emulator struct {
 	Name           string
 	Port           int
 	ServiceAccount string
+	RestPort       int
+	BlockTime      time.Duration
+	PersistDir     string
+	SigAlgo        crypto.SignatureAlgorithm
+	HashAlgo       crypto.HashAlgorithm
+	// Fork is the name of the network the emulator forks, state is lazily fetched from the network archive node.
+	Fork string
+	// ForkHeight is the block height the fork starts at, the latest height is used if zero.
+	ForkHeight uint64
+	// Seed is the location of the seed file applied when the emulator starts.
+	Seed string
 }
 
+// ForkNetworks are the networks which can be forked by the emulator.
+var ForkNetworks = []string{MainnetNetwork.Name, TestnetNetwork.Name}
+
 type Emulators []Emulator
 
 // Default gets default emulator.
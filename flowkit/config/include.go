@@ -0,0 +1,225 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// IncludeCacheTTL defines how long a fetched remote include is used from the local cache before being fetched again.
+const IncludeCacheTTL = 24 * time.Hour
+
+const githubPrefix = "github:"
+
+// VerifyInclude verifies the content fetched from the remote include URL, e.g. against trusted checksums
+// or signatures, the include isn't used if the verification fails. No verification is done if not set.
+var VerifyInclude func(url string, data []byte) error
+
+// IncludeFetcher is interface for any remote configuration include fetcher to implement.
+type IncludeFetcher interface {
+	Fetch(location string) ([]byte, error)
+	SupportsLocation(string) bool
+}
+
+// IncludeFetchers is a list of all include fetchers.
+type IncludeFetchers []IncludeFetcher
+
+// FindForLocation finds a fetcher that can fetch the provided include location.
+func (f *IncludeFetchers) FindForLocation(location string) IncludeFetcher {
+	for _, fetcher := range *f {
+		if fetcher.SupportsLocation(location) {
+			return fetcher
+		}
+	}
+
+	return nil
+}
+
+// IsRemoteInclude checks whether the include location points to a remote resource.
+func IsRemoteInclude(location string) bool {
+	return strings.HasPrefix(location, "https://") ||
+		strings.HasPrefix(location, "http://") ||
+		strings.HasPrefix(location, githubPrefix)
+}
+
+// HTTPIncludeFetcher fetches includes over HTTP and caches them in the cache directory.
+//
+// Git references in the format "github:owner/repo/path/to/file.json@ref" are resolved
+// to the raw content URL for the provided reference, if reference is omitted the default branch is used.
+type HTTPIncludeFetcher struct {
+	cacheDir string
+	client   *http.Client
+}
+
+// NewHTTPIncludeFetcher returns a new HTTP include fetcher caching the includes in the provided directory.
+//
+// If cache directory is empty the default user cache directory is used.
+func NewHTTPIncludeFetcher(cacheDir string) *HTTPIncludeFetcher {
+	if cacheDir == "" {
+		cacheDir = DefaultIncludeCacheDir()
+	}
+
+	return &HTTPIncludeFetcher{
+		cacheDir: cacheDir,
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// DefaultIncludeCacheDir gets the default include cache directory based on user cache dir.
+func DefaultIncludeCacheDir() string {
+	dirname, err := os.UserCacheDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), "flow", "includes")
+	}
+
+	return filepath.Join(dirname, "flow", "includes")
+}
+
+// SupportsLocation checks if the location is a remote location.
+func (h *HTTPIncludeFetcher) SupportsLocation(location string) bool {
+	return IsRemoteInclude(location)
+}
+
+// Fetch the include from the cache if it's fresh, otherwise fetch it from remote and update the cache.
+//
+// If fetching fails but a stale cached copy exists the cached copy is used.
+func (h *HTTPIncludeFetcher) Fetch(location string) ([]byte, error) {
+	url, err := ResolveIncludeURL(location)
+	if err != nil {
+		return nil, err
+	}
+
+	cachePath := h.cachePath(url)
+	info, statErr := os.Stat(cachePath)
+	if statErr == nil && time.Since(info.ModTime()) < IncludeCacheTTL {
+		return h.readCache(location, url, cachePath)
+	}
+
+	raw, err := h.get(url)
+	if err != nil {
+		if statErr == nil { // fallback to stale cache
+			return h.readCache(location, url, cachePath)
+		}
+		return nil, fmt.Errorf("failed to fetch configuration include %s: %w", location, err)
+	}
+
+	if err := verifyInclude(location, url, raw); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(h.cacheDir, 0755); err == nil {
+		_ = os.WriteFile(cachePath, raw, 0644) // caching is best effort
+	}
+
+	return raw, nil
+}
+
+// readCache reads the cached include, which is verified again as the trusted content might have changed.
+func (h *HTTPIncludeFetcher) readCache(location string, url string, cachePath string) ([]byte, error) {
+	raw, err := os.ReadFile(cachePath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := verifyInclude(location, url, raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+func verifyInclude(location string, url string, raw []byte) error {
+	if VerifyInclude == nil {
+		return nil
+	}
+
+	if err := VerifyInclude(url, raw); err != nil {
+		return fmt.Errorf("failed to verify configuration include %s: %w", location, err)
+	}
+	return nil
+}
+
+func (h *HTTPIncludeFetcher) get(url string) ([]byte, error) {
+	resp, err := h.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (h *HTTPIncludeFetcher) cachePath(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return filepath.Join(h.cacheDir, fmt.Sprintf("%s.json", hex.EncodeToString(hash[:])))
+}
+
+// validateRemoteInclude checks the remote include doesn't define values running local programs,
+// such as deployment hooks or accounts with keys signing through external programs and wallets.
+//
+// Such values must be defined in the local configuration, so fetched content can't run commands.
+func validateRemoteInclude(conf *Config) error {
+	for _, deployment := range conf.Deployments {
+		for _, contract := range deployment.Contracts {
+			if len(contract.Hooks) > 0 {
+				return fmt.Errorf("deployment hooks of contract %s on network %s are not allowed in remote includes, define them in the local configuration", contract.Name, deployment.Network)
+			}
+		}
+	}
+
+	for _, account := range conf.Accounts {
+		if account.Key.Type == KeyTypeExec || account.Key.Type == KeyTypeWalletConnect {
+			return fmt.Errorf("account %s with key type %s is not allowed in remote includes, define it in the local configuration", account.Name, account.Key.Type)
+		}
+	}
+
+	return nil
+}
+
+// ResolveIncludeURL converts the include location to URL from which it can be fetched.
+func ResolveIncludeURL(location string) (string, error) {
+	if !strings.HasPrefix(location, githubPrefix) {
+		return location, nil
+	}
+
+	ref := "HEAD"
+	reference := strings.TrimPrefix(location, githubPrefix)
+	if i := strings.LastIndex(reference, "@"); i != -1 {
+		ref = reference[i+1:]
+		reference = reference[:i]
+	}
+
+	parts := strings.SplitN(reference, "/", 3)
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" || ref == "" {
+		return "", fmt.Errorf("invalid git include reference %s, expected format: github:owner/repo/path/file.json@ref", location)
+	}
+
+	return fmt.Sprintf("https://raw.githubusercontent.com/%s/%s/%s/%s", parts[0], parts[1], ref, parts[2]), nil
+}
@@ -0,0 +1,93 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ResolveIncludeURL(t *testing.T) {
+	url, err := ResolveIncludeURL("https://example.com/core.json")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/core.json", url)
+
+	url, err = ResolveIncludeURL("github:onflow/configs/shared/core.json@v1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "https://raw.githubusercontent.com/onflow/configs/v1.0.0/shared/core.json", url)
+
+	url, err = ResolveIncludeURL("github:onflow/configs/core.json")
+	require.NoError(t, err)
+	assert.Equal(t, "https://raw.githubusercontent.com/onflow/configs/HEAD/core.json", url)
+
+	_, err = ResolveIncludeURL("github:onflow/core.json")
+	assert.EqualError(t, err, "invalid git include reference github:onflow/core.json, expected format: github:owner/repo/path/file.json@ref")
+}
+
+func Test_HTTPIncludeFetcherCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"networks":{"testnet":"access.devnet.nodes.onflow.org:9000"}}`))
+	}))
+	defer server.Close()
+
+	fetcher := NewHTTPIncludeFetcher(t.TempDir())
+
+	raw, err := fetcher.Fetch(server.URL + "/core.json")
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "testnet")
+
+	raw, err = fetcher.Fetch(server.URL + "/core.json")
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), "testnet")
+	assert.Equal(t, 1, requests) // second fetch served from the cache
+}
+
+func Test_HTTPIncludeFetcherVerify(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"networks":{"testnet":"access.devnet.nodes.onflow.org:9000"}}`))
+	}))
+	defer server.Close()
+
+	trusted := true
+	VerifyInclude = func(url string, data []byte) error {
+		assert.Equal(t, server.URL+"/core.json", url)
+		if !trusted {
+			return fmt.Errorf("checksum mismatch")
+		}
+		return nil
+	}
+	t.Cleanup(func() { VerifyInclude = nil })
+
+	fetcher := NewHTTPIncludeFetcher(t.TempDir())
+
+	_, err := fetcher.Fetch(server.URL + "/core.json")
+	require.NoError(t, err)
+
+	// cached includes are verified as well
+	trusted = false
+	_, err = fetcher.Fetch(server.URL + "/core.json")
+	assert.EqualError(t, err, fmt.Sprintf("failed to verify configuration include %s/core.json: checksum mismatch", server.URL))
+}
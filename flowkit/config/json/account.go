@@ -151,19 +151,19 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 		return nil, fmt.Errorf("invalid hash algorithm for account %s", accountName)
 	}
 
-	validTypes := []config.KeyType{config.KeyTypeHex, config.KeyTypeFile, config.KeyTypeBip44, config.KeyTypeGoogleKMS}
+	validTypes := []config.KeyType{config.KeyTypeHex, config.KeyTypeFile, config.KeyTypeBip44, config.KeyTypeGoogleKMS, config.KeyTypeEncrypted, config.KeyTypeEnv, config.KeyTypeWalletConnect, config.KeyTypeExec}
 	if !slices.Contains(validTypes, a.Key.Type) {
 		return nil, fmt.Errorf("invalid key type for account %s", accountName)
 	}
 
 	// check that only one is provided because the values are mutually exclusive
 	set := false
-	for _, v := range []string{a.Key.ResourceID, a.Key.PrivateKey, a.Key.Location} {
+	for _, v := range []string{a.Key.ResourceID, a.Key.PrivateKey, a.Key.Location, a.Key.EncryptedPrivateKey, a.Key.Env} {
 		if v == "" {
 			continue
 		}
 		if set {
-			return nil, fmt.Errorf("can only provide one property (resource ID, private key, location) on account %s", accountName)
+			return nil, fmt.Errorf("can only provide one property (resource ID, private key, location, encrypted private key, env) on account %s", accountName)
 		}
 		set = true
 	}
@@ -225,6 +225,38 @@ func transformAdvancedToConfig(accountName string, a advancedAccount) (*config.A
 			return nil, fmt.Errorf("missing location to a file containing the private key value for the account %s", accountName)
 		}
 		key.Location = a.Key.Location
+
+	case config.KeyTypeEncrypted:
+		if a.Key.EncryptedPrivateKey == "" {
+			return nil, fmt.Errorf("missing encrypted private key value for encrypted key type on account %s", accountName)
+		}
+		key.EncryptedKey = a.Key.EncryptedPrivateKey
+
+	case config.KeyTypeEnv:
+		if a.Key.Env == "" && len(a.Key.NetworkEnv) == 0 {
+			return nil, fmt.Errorf("missing environment variable name for env key type on account %s", accountName)
+		}
+		key.Env = a.Key.Env
+		key.NetworkEnv = a.Key.NetworkEnv
+
+	case config.KeyTypeWalletConnect:
+		key.ProjectID = a.Key.ProjectID
+
+	case config.KeyTypeExec:
+		if len(a.Key.Command) == 0 {
+			return nil, fmt.Errorf("missing command of the signer program for exec key type on account %s", accountName)
+		}
+		key.Command = a.Key.Command
+
+		// signatures of the signer program are verified against the public key of the account key
+		if a.Key.PublicKey == "" {
+			return nil, fmt.Errorf("missing public key for exec key type on account %s", accountName)
+		}
+		pKey, err := crypto.DecodePublicKeyHex(sigAlgo, strings.TrimPrefix(a.Key.PublicKey, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key for exec key type on account %s: %w", accountName, err)
+		}
+		key.PublicKey = pKey
 	}
 
 	return &config.Account{
@@ -241,7 +273,12 @@ func (j jsonAccounts) transformToConfig() (config.Accounts, error) {
 	for accountName, a := range j {
 		var account *config.Account
 		var err error
-		if a.Simple.Address != "" {
+		if a.FromFile.FromFile != "" {
+			account = &config.Account{
+				Name:     accountName,
+				FromFile: a.FromFile.FromFile,
+			}
+		} else if a.Simple.Address != "" {
 			account, err = transformSimpleToConfig(accountName, a.Simple)
 			if err != nil {
 				return nil, err
@@ -264,7 +301,11 @@ func transformAccountsToJSON(accounts config.Accounts) jsonAccounts {
 	jsonAccounts := jsonAccounts{}
 
 	for _, a := range accounts {
-		if a.Key.IsDefault() {
+		if a.FromFile != "" {
+			jsonAccounts[a.Name] = account{
+				FromFile: fromFileAccount{FromFile: a.FromFile},
+			}
+		} else if a.Key.IsDefault() {
 			jsonAccounts[a.Name] = transformSimpleAccountToJSON(a)
 		} else {
 			jsonAccounts[a.Name] = transformAdvancedAccountToJSON(a)
@@ -327,6 +368,18 @@ func transformAdvancedKeyToJSON(key config.AccountKey) advanceKey {
 		advancedKey.ResourceID = key.ResourceID
 	case config.KeyTypeFile:
 		advancedKey.Location = key.Location
+	case config.KeyTypeEncrypted:
+		advancedKey.EncryptedPrivateKey = key.EncryptedKey
+	case config.KeyTypeEnv:
+		advancedKey.Env = key.Env
+		advancedKey.NetworkEnv = key.NetworkEnv
+	case config.KeyTypeWalletConnect:
+		advancedKey.ProjectID = key.ProjectID
+	case config.KeyTypeExec:
+		advancedKey.Command = key.Command
+		if key.PublicKey != nil {
+			advancedKey.PublicKey = strings.TrimPrefix(key.PublicKey.String(), "0x")
+		}
 	}
 
 	return advancedKey
@@ -335,6 +388,12 @@ func transformAdvancedKeyToJSON(key config.AccountKey) advanceKey {
 type account struct {
 	Simple   simpleAccount
 	Advanced advancedAccount
+	FromFile fromFileAccount
+}
+
+// fromFileAccount references the file in which the account is defined.
+type fromFileAccount struct {
+	FromFile string `json:"fromFile"`
 }
 
 type simpleAccount struct {
@@ -361,6 +420,16 @@ type advanceKey struct {
 	ResourceID string `json:"resourceID,omitempty"`
 	// key location
 	Location string `json:"location,omitempty"`
+	// encrypted key type
+	EncryptedPrivateKey string `json:"encryptedPrivateKey,omitempty"`
+	// env key type
+	Env        string            `json:"env,omitempty"`
+	NetworkEnv map[string]string `json:"networkEnv,omitempty"`
+	// walletconnect key type
+	ProjectID string `json:"projectId,omitempty"`
+	// exec key type
+	Command   []string `json:"command,omitempty"`
+	PublicKey string   `json:"publicKey,omitempty"`
 	// old key format
 	Context map[string]string `json:"context,omitempty"`
 }
@@ -384,6 +453,7 @@ const (
 	advancedFormat       formatType = 1
 	simpleFormatPre022   formatType = 2 // pre v.022 format
 	advancedFormatPre022 formatType = 3 // pre v.022 format
+	fromFileFormat       formatType = 4
 )
 
 func decideFormat(b []byte) (formatType, error) {
@@ -393,6 +463,10 @@ func decideFormat(b []byte) (formatType, error) {
 		return 0, err
 	}
 
+	if raw["fromFile"] != nil {
+		return fromFileFormat, nil
+	}
+
 	if raw["keys"] != nil {
 		switch raw["keys"].(type) {
 		case string:
@@ -445,12 +519,21 @@ func (j *account) UnmarshalJSON(b []byte) error {
 		var advanced advancedAccount
 		err = json.Unmarshal(b, &advanced)
 		j.Advanced = advanced
+
+	case fromFileFormat:
+		var fromFile fromFileAccount
+		err = json.Unmarshal(b, &fromFile)
+		j.FromFile = fromFile
 	}
 
 	return err
 }
 
 func (j account) MarshalJSON() ([]byte, error) {
+	if j.FromFile.FromFile != "" {
+		return json.Marshal(j.FromFile)
+	}
+
 	if j.Simple != (simpleAccount{}) {
 		return json.Marshal(j.Simple)
 	}
@@ -473,12 +556,16 @@ func (a account) JSONSchema() *jsonschema.Schema {
 			{
 				Ref: "#/$defs/advanceAccountPre022",
 			},
+			{
+				Ref: "#/$defs/fromFileAccount",
+			},
 		},
 		Definitions: map[string]*jsonschema.Schema{
-			"simpleAccount": jsonschema.Reflect(simpleAccount{}),
-			"advancedAccount": jsonschema.Reflect(advancedAccount{}),
-			"simpleAccountPre022": jsonschema.Reflect(simpleAccountPre022{}),
+			"simpleAccount":        jsonschema.Reflect(simpleAccount{}),
+			"advancedAccount":      jsonschema.Reflect(advancedAccount{}),
+			"simpleAccountPre022":  jsonschema.Reflect(simpleAccountPre022{}),
 			"advanceAccountPre022": jsonschema.Reflect(advanceAccountPre022{}),
+			"fromFileAccount":      jsonschema.Reflect(fromFileAccount{}),
 		},
 	}
 }
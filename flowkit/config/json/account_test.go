@@ -564,3 +564,112 @@ func Test_ReplaceENV(t *testing.T) {
 		}
 	})
 }
+
+func Test_ConfigAccountFromFile(t *testing.T) {
+	b := []byte(`{"testnet-account":{"fromFile":"accounts.private.json"}}`)
+
+	var jsonAccounts jsonAccounts
+	err := json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := jsonAccounts.transformToConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, config.Accounts{{
+		Name:     "testnet-account",
+		FromFile: "accounts.private.json",
+	}}, accounts)
+
+	x, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigAccountEnvKey(t *testing.T) {
+	b := []byte(`{"deployer":{"address":"f8d6e0586b0a20c7","key":{"type":"env","env":"DEPLOYER_KEY","networkEnv":{"mainnet":"PROD_DEPLOYER_KEY"}}}}`)
+
+	var jsonAccounts jsonAccounts
+	err := json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := jsonAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	deployer, err := accounts.ByName("deployer")
+	assert.NoError(t, err)
+	assert.Equal(t, config.KeyTypeEnv, deployer.Key.Type)
+	assert.Equal(t, "DEPLOYER_KEY", deployer.Key.Env)
+	assert.Equal(t, map[string]string{"mainnet": "PROD_DEPLOYER_KEY"}, deployer.Key.NetworkEnv)
+
+	x, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+
+	b = []byte(`{"deployer":{"address":"f8d6e0586b0a20c7","key":{"type":"env"}}}`)
+	err = json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	_, err = jsonAccounts.transformToConfig()
+	assert.EqualError(t, err, "missing environment variable name for env key type on account deployer")
+}
+
+func Test_ConfigAccountWalletConnectKey(t *testing.T) {
+	b := []byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":{"type":"walletconnect","index":1,"projectId":"2f05ae7f1116030fde2d36508f472bfb"}}}`)
+
+	var jsonAccounts jsonAccounts
+	err := json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := jsonAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	admin, err := accounts.ByName("admin")
+	assert.NoError(t, err)
+	assert.Equal(t, config.KeyTypeWalletConnect, admin.Key.Type)
+	assert.Equal(t, 1, admin.Key.Index)
+	assert.Equal(t, "2f05ae7f1116030fde2d36508f472bfb", admin.Key.ProjectID)
+
+	x, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigAccountExecKey(t *testing.T) {
+	b := []byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":{"type":"exec","signatureAlgorithm":"ECDSA_secp256k1","command":["./hsm-signer","--slot","1"],"publicKey":"d6ebafd8e7e1b7b6e4b4fc4dba7df3b5c8d2fd1dd24e5e1c4d5e1a0e6b2b1d4ce0aea6c1de23db4ae8fbd1ddfff3f6c0e6b1d2dcb7eebf5f4f34ed1f1a29c5b5"}}}`)
+
+	var jsonAccounts jsonAccounts
+	err := json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	_, err = jsonAccounts.transformToConfig()
+	assert.ErrorContains(t, err, "invalid public key for exec key type on account admin")
+
+	b = []byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":{"type":"exec","command":["./hsm-signer","--slot","1"]}}}`)
+	err = json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	_, err = jsonAccounts.transformToConfig()
+	assert.EqualError(t, err, "missing public key for exec key type on account admin")
+
+	b = []byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":{"type":"exec","command":["./hsm-signer","--slot","1"],"publicKey":"5ce61c89922042c91d1e6177fb89b887a7268c3017b7f92fcfa28d6521eca40c1fb26c449a246cb6ccf5de7cd5b2f2eb7581e8c4084e2778112d2b737cce83c0"}}}`)
+	err = json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	accounts, err := jsonAccounts.transformToConfig()
+	assert.NoError(t, err)
+
+	admin, err := accounts.ByName("admin")
+	assert.NoError(t, err)
+	assert.Equal(t, config.KeyTypeExec, admin.Key.Type)
+	assert.Equal(t, []string{"./hsm-signer", "--slot", "1"}, admin.Key.Command)
+
+	x, err := json.Marshal(transformAccountsToJSON(accounts))
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+
+	b = []byte(`{"admin":{"address":"f8d6e0586b0a20c7","key":{"type":"exec"}}}`)
+	err = json.Unmarshal(b, &jsonAccounts)
+	assert.NoError(t, err)
+
+	_, err = jsonAccounts.transformToConfig()
+	assert.EqualError(t, err, "missing command of the signer program for exec key type on account admin")
+}
@@ -27,11 +27,17 @@ import (
 
 // jsonConfig implements JSON format for persisting and parsing configuration.
 type jsonConfig struct {
-	Emulators   jsonEmulators   `json:"emulators,omitempty"`
-	Contracts   jsonContracts   `json:"contracts,omitempty"`
-	Networks    jsonNetworks    `json:"networks,omitempty"`
-	Accounts    jsonAccounts    `json:"accounts,omitempty"`
-	Deployments jsonDeployments `json:"deployments,omitempty"`
+	Schema      string            `json:"$schema,omitempty"`
+	Version     int               `json:"version,omitempty"`
+	Includes    []string          `json:"include,omitempty"`
+	Projects    map[string]string `json:"projects,omitempty"`
+	Vars        map[string]string `json:"vars,omitempty"`
+	Emulators   jsonEmulators     `json:"emulators,omitempty"`
+	Contracts   jsonContracts     `json:"contracts,omitempty"`
+	Networks    jsonNetworks      `json:"networks,omitempty"`
+	Accounts    jsonAccounts      `json:"accounts,omitempty"`
+	Deployments jsonDeployments   `json:"deployments,omitempty"`
+	Profiles    jsonProfiles      `json:"profiles,omitempty"`
 }
 
 func (j *jsonConfig) transformToConfig() (*config.Config, error) {
@@ -60,12 +66,21 @@ func (j *jsonConfig) transformToConfig() (*config.Config, error) {
 		return nil, err
 	}
 
+	profiles, err := j.Profiles.transformToConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	conf := &config.Config{
+		Includes:    j.Includes,
+		Projects:    j.Projects,
+		Vars:        j.Vars,
 		Emulators:   emulators,
 		Contracts:   contracts,
 		Networks:    networks,
 		Accounts:    accounts,
 		Deployments: deployments,
+		Profiles:    profiles,
 	}
 
 	return conf, nil
@@ -73,11 +88,16 @@ func (j *jsonConfig) transformToConfig() (*config.Config, error) {
 
 func transformConfigToJSON(config *config.Config) jsonConfig {
 	return jsonConfig{
+		Version:     CurrentVersion,
+		Includes:    config.Includes,
+		Projects:    config.Projects,
+		Vars:        config.Vars,
 		Emulators:   transformEmulatorsToJSON(config.Emulators),
 		Contracts:   transformContractsToJSON(config.Contracts),
 		Networks:    transformNetworksToJSON(config.Networks),
 		Accounts:    transformAccountsToJSON(config.Accounts),
 		Deployments: transformDeploymentsToJSON(config.Deployments),
+		Profiles:    transformProfilesToJSON(config.Profiles),
 	}
 }
 
@@ -133,9 +153,27 @@ func (p *Parser) Deserialize(raw []byte) (*config.Config, error) {
 		return nil, fmt.Errorf("configuration syntax error: %w", err)
 	}
 
+	if jsonConf.Version > CurrentVersion {
+		return nil, fmt.Errorf("configuration version %d is not supported, the latest supported version is %d", jsonConf.Version, CurrentVersion)
+	}
+
 	return jsonConf.transformToConfig()
 }
 
+// Validate the raw configuration against the configuration schema.
+func (p *Parser) Validate(raw []byte) error {
+	if oldConfigFormat(raw) {
+		return config.ErrOutdatedFormat
+	}
+
+	// deprecated formats are parsed leniently until migrated
+	if NeedsMigration(raw) {
+		return nil
+	}
+
+	return ValidateSchema(raw)
+}
+
 // SupportsFormat check if the file format is supported.
 func (p *Parser) SupportsFormat(extension string) bool {
 	return extension == ".json"
@@ -171,6 +171,7 @@ func Test_NonExistingAccountForEmulator(t *testing.T) {
 // If config has default emulator values, it will not show up in flow.json
 func Test_SerializeConfigToJsonEmulatorDefault(t *testing.T) {
 	configJson := []byte(`{
+		"version": 1,
 		"accounts": {
 			"emulator-account": {
 				"address": "f8d6e0586b0a20c7",
@@ -212,6 +213,7 @@ func Test_SerializeConfigToJsonEmulatorDefault(t *testing.T) {
 
 func Test_SerializeConfigToJsonEmulatorNotDefault(t *testing.T) {
 	configJson := []byte(`{
+		"version": 1,
 		"emulators": {
 			"default": {
 				"port": 6000,
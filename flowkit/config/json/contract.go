@@ -67,10 +67,15 @@ func transformContractsToJSON(contracts config.Contracts) jsonContracts {
 	jsonContracts := jsonContracts{}
 
 	for _, c := range contracts {
+		location := c.Location
+		if c.RawLocation != "" {
+			location = c.RawLocation // if we used variables then use them when saving
+		}
+
 		// if simple case
 		if !c.IsAliased() {
 			jsonContracts[c.Name] = jsonContract{
-				Simple: c.Location,
+				Simple: location,
 			}
 		} else { // if advanced config
 			// check if we already created for this name then add or create
@@ -81,7 +86,7 @@ func transformContractsToJSON(contracts config.Contracts) jsonContracts {
 
 			jsonContracts[c.Name] = jsonContract{
 				Advanced: jsonContractAdvanced{
-					Source:  c.Location,
+					Source:  location,
 					Aliases: aliases,
 				},
 			}
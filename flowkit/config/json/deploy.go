@@ -19,6 +19,7 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
@@ -26,6 +27,7 @@ import (
 	"github.com/onflow/cadence"
 	jsoncdc "github.com/onflow/cadence/encoding/json"
 
+	"github.com/onflow/flow-cli/flowkit/arguments"
 	"github.com/onflow/flow-cli/flowkit/config"
 )
 
@@ -56,25 +58,30 @@ func (j jsonDeployments) transformToConfig() (config.Deployments, error) {
 					)
 				} else {
 					args := make([]cadence.Value, 0)
-					for _, arg := range contract.advanced.Args {
-						b, err := json.Marshal(arg)
-						if err != nil {
-							return nil, err
-						}
-
-						cadenceArg, err := jsoncdc.Decode(nil, b)
+					var literals []string
+					for i, arg := range contract.advanced.Args.values {
+						cadenceArg, literal, err := decodeDeploymentArg(arg)
 						if err != nil {
 							return nil, err
 						}
 
+						literals = addLiteral(literals, i, literal)
 						args = append(args, cadenceArg)
 					}
 
+					hooks, err := contract.advanced.transformHooksToConfig()
+					if err != nil {
+						return nil, err
+					}
+
 					contractDeploys = append(
 						contractDeploys,
 						config.ContractDeployment{
-							Name: contract.advanced.Name,
-							Args: args,
+							Name:        contract.advanced.Name,
+							Args:        args,
+							ArgNames:    contract.advanced.Args.names,
+							LiteralArgs: literals,
+							Hooks:       hooks,
 						},
 					)
 				}
@@ -96,31 +103,24 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 
 		deployments := make([]deployment, 0)
 		for _, c := range d.Contracts {
-			if len(c.Args) == 0 {
+			if len(c.Args) == 0 && len(c.Hooks) == 0 {
 				deployments = append(deployments, deployment{
 					simple: c.Name,
 				})
 			} else {
-				args := make([]map[string]any, 0)
-				for _, arg := range c.Args {
-					switch arg.Type().ID() {
-					case "Bool":
-						args = append(args, map[string]any{
-							"type":  arg.Type().ID(),
-							"value": arg.ToGoValue(),
-						})
-					default:
-						args = append(args, map[string]any{
-							"type":  arg.Type().ID(),
-							"value": fmt.Sprintf("%v", arg.ToGoValue()),
-						})
-					}
+				args := make([]any, 0)
+				for i, arg := range c.Args {
+					args = append(args, encodeDeploymentArg(arg, literalAt(c.LiteralArgs, i)))
 				}
 
 				deployments = append(deployments, deployment{
 					advanced: contractDeployment{
 						Name: c.Name,
-						Args: args,
+						Args: deploymentArgs{
+							values: args,
+							names:  c.ArgNames,
+						},
+						Hooks: transformHooksToJSON(c.Hooks),
 					},
 				})
 			}
@@ -139,9 +139,238 @@ func transformDeploymentsToJSON(configDeployments config.Deployments) jsonDeploy
 	return jsonDeploys
 }
 
+// decodeDeploymentArg decodes the argument either from JSON-Cadence or from a simple literal and returns the literal if it was one.
+func decodeDeploymentArg(arg any) (cadence.Value, string, error) {
+	if literal, ok := arg.(string); ok {
+		value, err := arguments.ParseLiteral(literal)
+		return value, literal, err
+	}
+
+	b, err := json.Marshal(arg)
+	if err != nil {
+		return nil, "", err
+	}
+
+	value, err := jsoncdc.Decode(nil, b)
+	return value, "", err
+}
+
+// encodeDeploymentArg encodes the argument in the form it was defined in.
+//
+// Arguments defined as a literal keep the original literal unless their value changed, in which case
+// the new value is formatted as a literal if possible. Other arguments are encoded as JSON-Cadence.
+func encodeDeploymentArg(arg cadence.Value, literal string) any {
+	if literal != "" {
+		if value, err := arguments.ParseLiteral(literal); err == nil && sameValue(value, arg) {
+			return literal
+		}
+		if formatted, ok := arguments.FormatLiteral(arg); ok {
+			return formatted
+		}
+	}
+
+	switch arg.Type().ID() {
+	case "Bool":
+		return map[string]any{
+			"type":  arg.Type().ID(),
+			"value": arg.ToGoValue(),
+		}
+	default:
+		return map[string]any{
+			"type":  arg.Type().ID(),
+			"value": fmt.Sprintf("%v", arg.ToGoValue()),
+		}
+	}
+}
+
+// sameValue checks whether both values have the same JSON-Cadence encoding.
+func sameValue(a cadence.Value, b cadence.Value) bool {
+	encodedA, err := jsoncdc.Encode(a)
+	if err != nil {
+		return false
+	}
+	encodedB, err := jsoncdc.Encode(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(encodedA, encodedB)
+}
+
+// addLiteral sets the literal of the argument at the index, literals are only allocated once an argument is a literal.
+func addLiteral(literals []string, index int, literal string) []string {
+	if literal == "" {
+		return literals
+	}
+	for len(literals) <= index {
+		literals = append(literals, "")
+	}
+	literals[index] = literal
+	return literals
+}
+
+// literalAt returns the literal of the argument at the index, or empty if the argument isn't a literal.
+func literalAt(literals []string, index int) string {
+	if index < len(literals) {
+		return literals[index]
+	}
+	return ""
+}
+
 type contractDeployment struct {
-	Name string           `json:"name"`
-	Args []map[string]any `json:"args"`
+	Name  string           `json:"name"`
+	Args  deploymentArgs   `json:"args"`
+	Hooks []deploymentHook `json:"hooks,omitempty"`
+}
+
+// deploymentHook is either a transaction with optional arguments or a shell command run after the contract is deployed.
+type deploymentHook struct {
+	Transaction string `json:"transaction,omitempty"`
+	Args        []any  `json:"args,omitempty"`
+	Command     string `json:"command,omitempty"`
+}
+
+func (c contractDeployment) transformHooksToConfig() ([]config.DeploymentHook, error) {
+	hooks := make([]config.DeploymentHook, 0, len(c.Hooks))
+	for _, h := range c.Hooks {
+		if (h.Transaction == "") == (h.Command == "") {
+			return nil, fmt.Errorf("deployment hook of contract %s must define either a transaction or a command", c.Name)
+		}
+		if h.Command != "" && len(h.Args) > 0 {
+			return nil, fmt.Errorf("deployment hook command of contract %s can't have arguments", c.Name)
+		}
+
+		hook := config.DeploymentHook{
+			Transaction: h.Transaction,
+			Command:     h.Command,
+		}
+		for i, arg := range h.Args {
+			cadenceArg, literal, err := decodeDeploymentArg(arg)
+			if err != nil {
+				return nil, err
+			}
+
+			hook.LiteralArgs = addLiteral(hook.LiteralArgs, i, literal)
+			hook.Args = append(hook.Args, cadenceArg)
+		}
+
+		hooks = append(hooks, hook)
+	}
+
+	return hooks, nil
+}
+
+func transformHooksToJSON(configHooks []config.DeploymentHook) []deploymentHook {
+	hooks := make([]deploymentHook, 0, len(configHooks))
+	for _, h := range configHooks {
+		hook := deploymentHook{
+			Transaction: h.Transaction,
+			Command:     h.Command,
+		}
+		for i, arg := range h.Args {
+			hook.Args = append(hook.Args, encodeDeploymentArg(arg, literalAt(h.LiteralArgs, i)))
+		}
+
+		hooks = append(hooks, hook)
+	}
+
+	return hooks
+}
+
+// deploymentArgs are either a list of arguments or a map of initializer parameter names to arguments.
+//
+// Each argument is either a JSON-Cadence value or a simple literal in the format "<value> as <Type>".
+type deploymentArgs struct {
+	values []any
+	names  []string
+}
+
+func (d *deploymentArgs) UnmarshalJSON(b []byte) error {
+	var values []any
+	err := json.Unmarshal(b, &values)
+	if err == nil {
+		d.values = values
+		return nil
+	}
+
+	// named arguments are decoded using tokens, so we preserve the order
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("deployment arguments must be a list or a map of named arguments")
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		var value any
+		err = decoder.Decode(&value)
+		if err != nil {
+			return err
+		}
+
+		d.names = append(d.names, token.(string))
+		d.values = append(d.values, value)
+	}
+
+	return nil
+}
+
+func (d deploymentArgs) MarshalJSON() ([]byte, error) {
+	if len(d.names) == 0 {
+		return json.Marshal(d.values)
+	}
+
+	var b bytes.Buffer
+	b.WriteString("{")
+	for i, name := range d.names {
+		if i > 0 {
+			b.WriteString(",")
+		}
+
+		key, err := json.Marshal(name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(d.values[i])
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(key)
+		b.WriteString(":")
+		b.Write(value)
+	}
+	b.WriteString("}")
+
+	return b.Bytes(), nil
+}
+
+func (d deploymentArgs) JSONSchema() *jsonschema.Schema {
+	arg := &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{Type: "string"},
+			{Type: "object"},
+		},
+	}
+
+	return &jsonschema.Schema{
+		OneOf: []*jsonschema.Schema{
+			{
+				Type:  "array",
+				Items: arg,
+			},
+			{
+				Type:                 "object",
+				AdditionalProperties: arg,
+			},
+		},
+	}
 }
 
 type deployment struct {
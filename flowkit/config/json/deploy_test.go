@@ -23,6 +23,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/onflow/cadence"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -151,3 +152,112 @@ func Test_DeploymentAdvanced(t *testing.T) {
 	assert.Equal(t, "KittyItemsMarket", alice.Contracts[1].Name)
 	assert.Len(t, alice.Contracts[1].Args, 0)
 }
+
+func Test_DeploymentLiteralArgs(t *testing.T) {
+	b := []byte(`{
+		"emulator": {
+			"alice": [
+				{
+					"name": "Kibble",
+					"args": ["Hello World as String", "1.0 as UFix64", { "type": "Int8", "value": "10" }]
+				},
+				{
+					"name": "KittyItems",
+					"args": { "supply": "100 as UInt64", "admin": "0x01cf0e2f2f715450 as Address" }
+				}
+			]
+		}
+	}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	require.NoError(t, err)
+
+	deployments, err := jsonDeployments.transformToConfig()
+	require.NoError(t, err)
+
+	alice := deployments.ByAccountAndNetwork("alice", "emulator")
+	require.NotNil(t, alice)
+	assert.Len(t, alice.Contracts[0].Args, 3)
+	assert.Equal(t, []string{"Hello World as String", "1.0 as UFix64"}, alice.Contracts[0].LiteralArgs)
+	assert.Equal(t, `"Hello World"`, alice.Contracts[0].Args[0].String())
+	assert.Equal(t, "1.00000000", alice.Contracts[0].Args[1].String())
+	assert.Equal(t, "Int8", alice.Contracts[0].Args[2].Type().ID())
+
+	assert.Equal(t, []string{"supply", "admin"}, alice.Contracts[1].ArgNames)
+	assert.Equal(t, "100", alice.Contracts[1].Args[0].String())
+	assert.Equal(t, "0x01cf0e2f2f715450", alice.Contracts[1].Args[1].String())
+
+	j := transformDeploymentsToJSON(deployments)
+	x, _ := json.Marshal(j)
+
+	assert.Equal(t, cleanSpecialChars(b), cleanSpecialChars(x))
+
+	// changed literal values are formatted again
+	alice.Contracts[0].Args[1], _ = cadence.NewUFix64("2.5")
+	x, _ = json.Marshal(transformDeploymentsToJSON(deployments))
+	assert.Contains(t, string(x), `"2.50000000 as UFix64"`)
+	assert.Contains(t, string(x), `"Hello World as String"`)
+}
+
+func Test_DeploymentLiteralArgsInvalid(t *testing.T) {
+	b := []byte(`{
+		"emulator": {
+			"alice": [{ "name": "Kibble", "args": ["1.0 as Foo"] }]
+		}
+	}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	require.NoError(t, err)
+
+	_, err = jsonDeployments.transformToConfig()
+	assert.EqualError(t, err, "unsupported type Foo in argument literal 1.0 as Foo")
+}
+
+func Test_DeploymentHooks(t *testing.T) {
+	b := []byte(`{
+		"emulator": {
+			"alice": [
+				{
+					"name": "Kibble",
+					"args": [],
+					"hooks": [
+						{ "transaction": "./transactions/setup.cdc", "args": ["10 as UInt64"] },
+						{ "command": "echo deployed" }
+					]
+				}
+			]
+		}
+	}`)
+
+	var jsonDeployments jsonDeployments
+	err := json.Unmarshal(b, &jsonDeployments)
+	require.NoError(t, err)
+
+	deployments, err := jsonDeployments.transformToConfig()
+	require.NoError(t, err)
+
+	alice := deployments.ByAccountAndNetwork("alice", "emulator")
+	require.NotNil(t, alice)
+	require.Len(t, alice.Contracts[0].Hooks, 2)
+	assert.Equal(t, "./transactions/setup.cdc", alice.Contracts[0].Hooks[0].Transaction)
+	assert.Equal(t, "10", alice.Contracts[0].Hooks[0].Args[0].String())
+	assert.Equal(t, "echo deployed", alice.Contracts[0].Hooks[1].Command)
+
+	j := transformDeploymentsToJSON(deployments)
+	x, _ := json.Marshal(j)
+
+	assert.Equal(t, cleanSpecialChars(b), cleanSpecialChars(x))
+
+	invalid := []byte(`{
+		"emulator": {
+			"alice": [{ "name": "Kibble", "args": [], "hooks": [{ "transaction": "./tx.cdc", "command": "echo" }] }]
+		}
+	}`)
+	err = json.Unmarshal(invalid, &jsonDeployments)
+	require.NoError(t, err)
+
+	_, err = jsonDeployments.transformToConfig()
+	assert.EqualError(t, err, "deployment hook of contract Kibble must define either a transaction or a command")
+}
@@ -20,6 +20,11 @@ package json
 
 import (
 	"fmt"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"golang.org/x/exp/slices"
 
 	"github.com/onflow/flow-cli/flowkit/config"
 )
@@ -35,10 +40,51 @@ func (j jsonEmulators) transformToConfig() (config.Emulators, error) {
 			return nil, fmt.Errorf("invalid port value")
 		}
 
+		if e.RestPort < 0 || e.RestPort > 65535 {
+			return nil, fmt.Errorf("invalid REST port value for emulator %s", name)
+		}
+
 		emulator := config.Emulator{
 			Name:           name,
 			Port:           e.Port,
 			ServiceAccount: e.ServiceAccount,
+			RestPort:       e.RestPort,
+			PersistDir:     e.PersistDir,
+			Fork:           e.Fork,
+			ForkHeight:     e.ForkHeight,
+			Seed:           e.Seed,
+		}
+
+		if e.Fork != "" && !slices.Contains(config.ForkNetworks, e.Fork) {
+			return nil, fmt.Errorf(
+				"invalid fork network %s for emulator %s, valid networks are: %s",
+				e.Fork, name, strings.Join(config.ForkNetworks, ", "),
+			)
+		}
+		if e.ForkHeight != 0 && e.Fork == "" {
+			return nil, fmt.Errorf("fork height for emulator %s requires a fork network", name)
+		}
+
+		if e.BlockTime != "" {
+			blockTime, err := time.ParseDuration(e.BlockTime)
+			if err != nil || blockTime < 0 {
+				return nil, fmt.Errorf("invalid block time %s for emulator %s", e.BlockTime, name)
+			}
+			emulator.BlockTime = blockTime
+		}
+
+		if e.SigAlgo != "" {
+			emulator.SigAlgo = crypto.StringToSignatureAlgorithm(e.SigAlgo)
+			if emulator.SigAlgo == crypto.UnknownSignatureAlgorithm {
+				return nil, fmt.Errorf("invalid service key signature algorithm for emulator %s", name)
+			}
+		}
+
+		if e.HashAlgo != "" {
+			emulator.HashAlgo = crypto.StringToHashAlgorithm(e.HashAlgo)
+			if emulator.HashAlgo == crypto.UnknownHashAlgorithm {
+				return nil, fmt.Errorf("invalid service key hash algorithm for emulator %s", name)
+			}
 		}
 
 		emulators = append(emulators, emulator)
@@ -55,10 +101,26 @@ func transformEmulatorsToJSON(emulators config.Emulators) jsonEmulators {
 		if e == config.DefaultEmulator {
 			continue
 		}
-		jsonEmulators[e.Name] = jsonEmulator{
+		jsonEmulator := jsonEmulator{
 			Port:           e.Port,
 			ServiceAccount: e.ServiceAccount,
+			RestPort:       e.RestPort,
+			PersistDir:     e.PersistDir,
+			Fork:           e.Fork,
+			ForkHeight:     e.ForkHeight,
+			Seed:           e.Seed,
 		}
+		if e.BlockTime != 0 {
+			jsonEmulator.BlockTime = e.BlockTime.String()
+		}
+		if e.SigAlgo != crypto.UnknownSignatureAlgorithm {
+			jsonEmulator.SigAlgo = e.SigAlgo.String()
+		}
+		if e.HashAlgo != crypto.UnknownHashAlgorithm {
+			jsonEmulator.HashAlgo = e.HashAlgo.String()
+		}
+
+		jsonEmulators[e.Name] = jsonEmulator
 	}
 
 	return jsonEmulators
@@ -67,4 +129,12 @@ func transformEmulatorsToJSON(emulators config.Emulators) jsonEmulators {
 type jsonEmulator struct {
 	Port           int    `json:"port"`
 	ServiceAccount string `json:"serviceAccount"`
+	RestPort       int    `json:"restPort,omitempty"`
+	BlockTime      string `json:"blockTime,omitempty"`
+	PersistDir     string `json:"persistDir,omitempty"`
+	SigAlgo        string `json:"serviceKeySigAlgo,omitempty"`
+	HashAlgo       string `json:"serviceKeyHashAlgo,omitempty"`
+	Fork           string `json:"fork,omitempty"`
+	ForkHeight     uint64 `json:"forkHeight,omitempty"`
+	Seed           string `json:"seed,omitempty"`
 }
@@ -21,8 +21,12 @@ import (
 	"encoding/json"
 	"sort"
 	"testing"
+	"time"
 
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/flowkit/config"
 )
 
 func Test_ConfigEmulatorSimple(t *testing.T) {
@@ -74,3 +78,86 @@ func Test_ConfigMultipleEmulators(t *testing.T) {
 	assert.Equal(t, emulators[1].Port, 3000)
 	assert.Equal(t, emulators[1].ServiceAccount, "custom-emulator-account")
 }
+
+func Test_ConfigEmulatorSettings(t *testing.T) {
+	b := []byte(`{
+		"default": {
+			"port": 3569,
+			"serviceAccount": "emulator-account",
+			"restPort": 9999,
+			"blockTime": "1s",
+			"persistDir": "./flowdb",
+			"serviceKeySigAlgo": "ECDSA_secp256k1",
+			"serviceKeyHashAlgo": "SHA2_256",
+			"seed": "./seed.json"
+		}
+	}`)
+
+	var jsonEmulators jsonEmulators
+	err := json.Unmarshal(b, &jsonEmulators)
+	assert.NoError(t, err)
+
+	emulators, err := jsonEmulators.transformToConfig()
+	assert.NoError(t, err)
+
+	assert.Equal(t, config.Emulator{
+		Name:           "default",
+		Port:           3569,
+		ServiceAccount: "emulator-account",
+		RestPort:       9999,
+		BlockTime:      time.Second,
+		PersistDir:     "./flowdb",
+		SigAlgo:        crypto.ECDSA_secp256k1,
+		HashAlgo:       crypto.SHA2_256,
+		Seed:           "./seed.json",
+	}, emulators[0])
+
+	out, err := json.Marshal(transformEmulatorsToJSON(emulators))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(out))
+}
+
+func Test_ConfigEmulatorFork(t *testing.T) {
+	b := []byte(`{
+		"default": {
+			"port": 3569,
+			"serviceAccount": "emulator-account",
+			"fork": "mainnet",
+			"forkHeight": 65264619
+		}
+	}`)
+
+	var jsonEmulators jsonEmulators
+	err := json.Unmarshal(b, &jsonEmulators)
+	assert.NoError(t, err)
+
+	emulators, err := jsonEmulators.transformToConfig()
+	assert.NoError(t, err)
+
+	assert.Equal(t, "mainnet", emulators[0].Fork)
+	assert.Equal(t, uint64(65264619), emulators[0].ForkHeight)
+
+	out, err := json.Marshal(transformEmulatorsToJSON(emulators))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(b), string(out))
+}
+
+func Test_ConfigEmulatorInvalidSettings(t *testing.T) {
+	tests := map[string]string{
+		`{ "default": { "port": 3569, "restPort": 70000 } }`:           "invalid REST port value for emulator default",
+		`{ "default": { "port": 3569, "blockTime": "fast" } }`:         "invalid block time fast for emulator default",
+		`{ "default": { "port": 3569, "serviceKeySigAlgo": "RSA" } }`:  "invalid service key signature algorithm for emulator default",
+		`{ "default": { "port": 3569, "serviceKeyHashAlgo": "MD5" } }`: "invalid service key hash algorithm for emulator default",
+		`{ "default": { "port": 3569, "fork": "previewnet" } }`:        "invalid fork network previewnet for emulator default, valid networks are: mainnet, testnet",
+		`{ "default": { "port": 3569, "forkHeight": 100 } }`:           "fork height for emulator default requires a fork network",
+	}
+
+	for raw, expected := range tests {
+		var jsonEmulators jsonEmulators
+		err := json.Unmarshal([]byte(raw), &jsonEmulators)
+		assert.NoError(t, err)
+
+		_, err = jsonEmulators.transformToConfig()
+		assert.EqualError(t, err, expected)
+	}
+}
@@ -0,0 +1,432 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// CurrentVersion is the version of the configuration format written by this parser.
+const CurrentVersion = 1
+
+type migration struct {
+	description string
+	apply       func(conf *orderedObject) bool // returns true if configuration was changed
+}
+
+// migrations are applied in order on unversioned configurations.
+var migrations = []migration{
+	{"convert legacy host and account format", migrateLegacyFormat},
+	{"replace deprecated account keys field with key", migrateAccountKeys},
+	{"remove deprecated chain fields", migrateChainFields},
+}
+
+// Migrate upgrades the raw configuration to the current configuration format version.
+//
+// The order of the configuration fields is preserved and the descriptions of applied migrations are returned,
+// if the configuration is already up-to-date it's returned unchanged.
+func Migrate(raw []byte) ([]byte, []string, error) {
+	conf, err := parseOrdered(raw)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applied, err := migrate(conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(applied) == 0 {
+		return raw, nil, nil
+	}
+
+	var buf bytes.Buffer
+	writeOrdered(&buf, conf, "", detectIndent(raw))
+	buf.WriteString("\n")
+
+	return buf.Bytes(), applied, nil
+}
+
+// NeedsMigration checks whether the raw configuration uses any deprecated format that must be migrated.
+func NeedsMigration(raw []byte) bool {
+	conf, err := parseOrdered(raw)
+	if err != nil {
+		return false
+	}
+
+	applied, err := migrate(conf)
+	if err != nil {
+		return false
+	}
+
+	// setting the version alone doesn't change the format
+	return len(applied) > 1
+}
+
+func migrate(conf *orderedObject) ([]string, error) {
+	version, err := conf.version()
+	if err != nil {
+		return nil, err
+	}
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("configuration version %d is not supported, the latest supported version is %d", version, CurrentVersion)
+	}
+	if version == CurrentVersion {
+		return nil, nil
+	}
+
+	applied := make([]string, 0)
+	for _, m := range migrations {
+		if m.apply(conf) {
+			applied = append(applied, m.description)
+		}
+	}
+
+	conf.prepend("version", json.Number(fmt.Sprint(CurrentVersion)))
+	applied = append(applied, fmt.Sprintf("set configuration version to %d", CurrentVersion))
+
+	return applied, nil
+}
+
+// migrateLegacyFormat converts the legacy format using a single host and accounts with private keys.
+func migrateLegacyFormat(conf *orderedObject) bool {
+	host, ok := conf.get("host")
+	if !ok {
+		return false
+	}
+
+	hostValue, _ := host.(string)
+	networkName := "default"
+	if strings.HasPrefix(hostValue, "127.0.0.1") || strings.HasPrefix(hostValue, "localhost") {
+		networkName = "emulator"
+	}
+
+	networks := &orderedObject{}
+	networks.set(networkName, host)
+	conf.rename("host", "networks", networks)
+
+	accounts, _ := conf.object("accounts")
+	for _, m := range accounts.entries() {
+		account, ok := m.value.(*orderedObject)
+		if !ok {
+			continue
+		}
+
+		privateKey, ok := account.get("privateKey")
+		if !ok {
+			continue
+		}
+
+		key := &orderedObject{}
+		key.set("type", "hex")
+		key.set("index", json.Number("0"))
+		key.set("signatureAlgorithm", account.valueOrDefault("sigAlgorithm", "ECDSA_P256"))
+		key.set("hashAlgorithm", account.valueOrDefault("hashAlgorithm", "SHA3_256"))
+		key.set("privateKey", privateKey)
+
+		account.rename("privateKey", "key", key)
+		account.remove("sigAlgorithm")
+		account.remove("hashAlgorithm")
+
+		// legacy service account is used by the emulator
+		if m.key == "service" {
+			if _, exists := conf.get("emulators"); !exists {
+				emulator := &orderedObject{}
+				emulator.set("port", json.Number("3569"))
+				emulator.set("serviceAccount", "service")
+				emulators := &orderedObject{}
+				emulators.set("default", emulator)
+				conf.set("emulators", emulators)
+			}
+		}
+	}
+
+	return true
+}
+
+// migrateAccountKeys converts the pre v0.22 keys field and the keys using context to the current key format.
+func migrateAccountKeys(conf *orderedObject) bool {
+	changed := false
+	accounts, _ := conf.object("accounts")
+
+	for _, m := range accounts.entries() {
+		account, ok := m.value.(*orderedObject)
+		if !ok {
+			continue
+		}
+
+		if keys, ok := account.get("keys"); ok {
+			switch k := keys.(type) {
+			case string:
+				account.rename("keys", "key", k)
+				changed = true
+			case []any:
+				if len(k) > 0 {
+					account.rename("keys", "key", k[0])
+					changed = true
+				}
+			}
+		}
+
+		key, ok := account.object("key")
+		if !ok {
+			continue
+		}
+
+		if context, ok := key.object("context"); ok {
+			if privateKey, ok := context.get("privateKey"); ok {
+				key.rename("context", "privateKey", privateKey)
+			} else {
+				key.remove("context")
+			}
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// migrateChainFields removes the chain fields which are no longer used in accounts and networks.
+func migrateChainFields(conf *orderedObject) bool {
+	changed := false
+	for _, section := range []string{"accounts", "networks"} {
+		entries, _ := conf.object(section)
+		for _, m := range entries.entries() {
+			if entry, ok := m.value.(*orderedObject); ok && entry.remove("chain") {
+				changed = true
+			}
+		}
+	}
+
+	return changed
+}
+
+// orderedObject is a JSON object which preserves the order of the fields.
+type orderedObject struct {
+	members []orderedMember
+}
+
+type orderedMember struct {
+	key   string
+	value any
+}
+
+func (o *orderedObject) entries() []orderedMember {
+	if o == nil {
+		return nil
+	}
+	return o.members
+}
+
+func (o *orderedObject) get(key string) (any, bool) {
+	for _, m := range o.entries() {
+		if m.key == key {
+			return m.value, true
+		}
+	}
+	return nil, false
+}
+
+func (o *orderedObject) object(key string) (*orderedObject, bool) {
+	value, _ := o.get(key)
+	obj, ok := value.(*orderedObject)
+	return obj, ok
+}
+
+func (o *orderedObject) valueOrDefault(key string, defaultValue any) any {
+	if value, ok := o.get(key); ok {
+		return value
+	}
+	return defaultValue
+}
+
+func (o *orderedObject) version() (int, error) {
+	value, ok := o.get("version")
+	if !ok {
+		return 0, nil
+	}
+
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("invalid configuration version %v", value)
+	}
+
+	version, err := number.Int64()
+	if err != nil {
+		return 0, fmt.Errorf("invalid configuration version %s", number)
+	}
+
+	return int(version), nil
+}
+
+// set the value of existing field or add the field at the end.
+func (o *orderedObject) set(key string, value any) {
+	for i, m := range o.members {
+		if m.key == key {
+			o.members[i].value = value
+			return
+		}
+	}
+	o.members = append(o.members, orderedMember{key, value})
+}
+
+func (o *orderedObject) prepend(key string, value any) {
+	o.remove(key)
+	o.members = append([]orderedMember{{key, value}}, o.members...)
+}
+
+// rename the field and replace its value while keeping its position.
+func (o *orderedObject) rename(key string, newKey string, value any) {
+	for i, m := range o.members {
+		if m.key == key {
+			o.members[i] = orderedMember{newKey, value}
+			return
+		}
+	}
+}
+
+func (o *orderedObject) remove(key string) bool {
+	for i, m := range o.members {
+		if m.key == key {
+			o.members = append(o.members[:i], o.members[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func parseOrdered(raw []byte) (*orderedObject, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	value, err := decodeOrdered(decoder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config JSON: %w", err)
+	}
+
+	conf, ok := value.(*orderedObject)
+	if !ok {
+		return nil, fmt.Errorf("failed to parse config JSON: configuration must be an object")
+	}
+
+	return conf, nil
+}
+
+func decodeOrdered(decoder *json.Decoder) (any, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		obj := &orderedObject{}
+		for decoder.More() {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			obj.members = append(obj.members, orderedMember{key.(string), value})
+		}
+		_, err = decoder.Token()
+		return obj, err
+	case json.Delim('['):
+		arr := make([]any, 0)
+		for decoder.More() {
+			value, err := decodeOrdered(decoder)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, value)
+		}
+		_, err = decoder.Token()
+		return arr, err
+	default:
+		return token, nil
+	}
+}
+
+// detectIndent returns the indentation used in the raw configuration, defaulting to tabs.
+func detectIndent(raw []byte) string {
+	for _, line := range strings.Split(string(raw), "\n")[1:] {
+		indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		if indent != "" {
+			return indent
+		}
+	}
+
+	return "\t"
+}
+
+func writeOrdered(buf *bytes.Buffer, value any, indent string, unit string) {
+	switch v := value.(type) {
+	case *orderedObject:
+		if len(v.members) == 0 {
+			buf.WriteString("{}")
+			return
+		}
+		buf.WriteString("{")
+		for i, m := range v.members {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n" + indent + unit)
+			writeString(buf, m.key)
+			buf.WriteString(": ")
+			writeOrdered(buf, m.value, indent+unit, unit)
+		}
+		buf.WriteString("\n" + indent + "}")
+	case []any:
+		if len(v) == 0 {
+			buf.WriteString("[]")
+			return
+		}
+		buf.WriteString("[")
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteString(",")
+			}
+			buf.WriteString("\n" + indent + unit)
+			writeOrdered(buf, item, indent+unit, unit)
+		}
+		buf.WriteString("\n" + indent + "]")
+	case string:
+		writeString(buf, v)
+	case json.Number:
+		buf.WriteString(v.String())
+	case bool:
+		buf.WriteString(fmt.Sprint(v))
+	default:
+		buf.WriteString("null")
+	}
+}
+
+func writeString(buf *bytes.Buffer, value string) {
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+	buf.Truncate(buf.Len() - 1) // remove newline added by encoder
+}
@@ -0,0 +1,171 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_MigrateLegacyFormat(t *testing.T) {
+	b := []byte(`{
+	"host": "127.0.0.1:3569",
+	"accounts": {
+		"service": {
+			"address": "f8d6e0586b0a20c7",
+			"privateKey": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47",
+			"sigAlgorithm": "ECDSA_P256",
+			"hashAlgorithm": "SHA3_256"
+		}
+	}
+}`)
+
+	_, err := NewParser().Deserialize(b)
+	assert.ErrorIs(t, err, config.ErrOutdatedFormat)
+	assert.ErrorIs(t, NewParser().Validate(b), config.ErrOutdatedFormat)
+
+	migrated, applied, err := Migrate(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"convert legacy host and account format",
+		"set configuration version to 1",
+	}, applied)
+
+	assert.Equal(t, `{
+	"version": 1,
+	"networks": {
+		"emulator": "127.0.0.1:3569"
+	},
+	"accounts": {
+		"service": {
+			"address": "f8d6e0586b0a20c7",
+			"key": {
+				"type": "hex",
+				"index": 0,
+				"signatureAlgorithm": "ECDSA_P256",
+				"hashAlgorithm": "SHA3_256",
+				"privateKey": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+			}
+		}
+	},
+	"emulators": {
+		"default": {
+			"port": 3569,
+			"serviceAccount": "service"
+		}
+	}
+}
+`, string(migrated))
+
+	assert.False(t, NeedsMigration(migrated))
+	conf, err := NewParser().Deserialize(migrated)
+	require.NoError(t, err)
+	assert.Equal(t, "service", conf.Emulators[0].ServiceAccount)
+}
+
+func Test_MigrateDeprecatedKeys(t *testing.T) {
+	b := []byte(`{
+		"networks": {
+			"mainnet": {
+				"host": "access.mainnet.nodes.onflow.org:9000",
+				"chain": "flow-mainnet",
+				"key": "5000676131ad3e22d853a3f75a5b5d0db4236d08dd6612e2baad771014b5266a242bccecc3522ff7207ac357dbe4f225c709d9b273ac484fed5d13976a39bdcd"
+			}
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "service",
+				"chain": "flow-emulator",
+				"keys": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+			},
+			"testnet-account": {
+				"address": "3c1162386b0a245f",
+				"keys": [{
+					"type": "hex",
+					"index": 0,
+					"signatureAlgorithm": "ECDSA_P256",
+					"hashAlgorithm": "SHA3_256",
+					"context": {
+						"privateKey": "1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+					}
+				}]
+			}
+		}
+	}`)
+
+	assert.True(t, NeedsMigration(b))
+	assert.NoError(t, NewParser().Validate(b))
+
+	migrated, applied, err := Migrate(b)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"replace deprecated account keys field with key",
+		"remove deprecated chain fields",
+		"set configuration version to 1",
+	}, applied)
+
+	assert.JSONEq(t, `{
+		"version": 1,
+		"networks": {
+			"mainnet": {
+				"host": "access.mainnet.nodes.onflow.org:9000",
+				"key": "5000676131ad3e22d853a3f75a5b5d0db4236d08dd6612e2baad771014b5266a242bccecc3522ff7207ac357dbe4f225c709d9b273ac484fed5d13976a39bdcd"
+			}
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "service",
+				"key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+			},
+			"testnet-account": {
+				"address": "3c1162386b0a245f",
+				"key": {
+					"type": "hex",
+					"index": 0,
+					"signatureAlgorithm": "ECDSA_P256",
+					"hashAlgorithm": "SHA3_256",
+					"privateKey": "1272967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+				}
+			}
+		}
+	}`, string(migrated))
+
+	assert.NoError(t, ValidateSchema(migrated))
+}
+
+func Test_MigrateCurrentVersion(t *testing.T) {
+	b := []byte(`{ "version": 1, "networks": { "emulator": "127.0.0.1:3569" } }`)
+
+	migrated, applied, err := Migrate(b)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Equal(t, b, migrated)
+
+	_, _, err = Migrate([]byte(`{ "version": 2 }`))
+	assert.EqualError(t, err, "configuration version 2 is not supported, the latest supported version is 1")
+
+	_, err = NewParser().Deserialize([]byte(`{ "version": 2 }`))
+	assert.EqualError(t, err, "configuration version 2 is not supported, the latest supported version is 1")
+}
@@ -22,9 +22,12 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/invopop/jsonschema"
+	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 
 	"github.com/onflow/flow-cli/flowkit/config"
@@ -37,25 +40,77 @@ func (j jsonNetworks) transformToConfig() (config.Networks, error) {
 	networks := make(config.Networks, 0)
 
 	for networkName, n := range j {
-		if n.Advanced.Key != "" && n.Advanced.Host != "" {
-			err := validateECDSAP256Pub(n.Advanced.Key)
-			if err != nil {
-				return nil, fmt.Errorf("invalid key %s for network with name %s", n.Advanced.Key, networkName)
-			}
-
-			networks = append(networks, config.Network{
-				Name: networkName,
-				Host: n.Advanced.Host,
-				Key:  n.Advanced.Key,
-			})
-		} else if n.Simple.Host != "" {
+		if n.Simple.Host != "" {
 			networks = append(networks, config.Network{
 				Name: networkName,
 				Host: n.Simple.Host,
 			})
-		} else {
+			continue
+		}
+
+		hosts := n.Advanced.Hosts
+		if n.Advanced.Host != "" {
+			hosts = append([]string{n.Advanced.Host}, hosts...)
+		}
+		options, err := n.Advanced.gatewayOptions()
+		if err != nil {
+			return nil, fmt.Errorf("invalid options for network with name %s: %w", networkName, err)
+		}
+
+		tlsConfig, err := n.Advanced.TLS.transformToConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid tls configuration for network with name %s: %w", networkName, err)
+		}
+		if tlsConfig.Enabled && n.Advanced.Key != "" {
+			return nil, fmt.Errorf("network with name %s can not use both key and tls configuration", networkName)
+		}
+
+		// advanced format with a single host is only used to provide the key, tls, archive host or the gateway options
+		if n.Advanced.Proxy != "" {
+			err := validateProxy(n.Advanced.Proxy)
+			if err != nil {
+				return nil, fmt.Errorf("invalid proxy %s for network with name %s: %w", n.Advanced.Proxy, networkName, err)
+			}
+		}
+
+		payer, err := n.Advanced.Payer.transformToConfig()
+		if err != nil {
+			return nil, fmt.Errorf("invalid payer service for network with name %s: %w", networkName, err)
+		}
+
+		noOptions := options == config.GatewayOptions{} && tlsConfig == config.TLSConfig{} &&
+			n.Advanced.ArchiveHost == "" && n.Advanced.Proxy == "" && !payer.Enabled()
+		if len(hosts) == 0 || (n.Advanced.Key == "" && len(n.Advanced.Hosts) == 0 && noOptions) {
 			return nil, fmt.Errorf("failed to transform networks configuration")
 		}
+
+		if n.Advanced.Key != "" {
+			err := validateECDSAP256Pub(n.Advanced.Key)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key %s for network with name %s", n.Advanced.Key, networkName)
+			}
+		}
+
+		failover := config.FailoverStrategy(n.Advanced.Failover)
+		if failover != "" && failover != config.FailoverOrdered && failover != config.FailoverRoundRobin {
+			return nil, fmt.Errorf(
+				"invalid failover strategy %s for network with name %s, valid strategies are: %s, %s",
+				n.Advanced.Failover, networkName, config.FailoverOrdered, config.FailoverRoundRobin,
+			)
+		}
+
+		networks = append(networks, config.Network{
+			Name:          networkName,
+			Host:          hosts[0],
+			Key:           n.Advanced.Key,
+			FallbackHosts: hosts[1:],
+			Failover:      failover,
+			ArchiveHost:   n.Advanced.ArchiveHost,
+			Proxy:         n.Advanced.Proxy,
+			Options:       options,
+			TLS:           tlsConfig,
+			Payer:         payer,
+		})
 	}
 
 	return networks, nil
@@ -66,7 +121,7 @@ func transformNetworksToJSON(networks config.Networks) jsonNetworks {
 	jsonNetworks := jsonNetworks{}
 
 	for _, n := range networks {
-		if n.Key != "" {
+		if n.Key != "" || len(n.FallbackHosts) > 0 || n.Failover != "" || n.ArchiveHost != "" || n.Proxy != "" || n.Options != (config.GatewayOptions{}) || n.TLS != (config.TLSConfig{}) || n.Payer.Enabled() {
 			jsonNetworks[n.Name] = transformAdvancedNetworkToJSON(n)
 		} else {
 			jsonNetworks[n.Name] = transformSimpleNetworkToJSON(n)
@@ -85,12 +140,45 @@ func transformSimpleNetworkToJSON(n config.Network) jsonNetwork {
 }
 
 func transformAdvancedNetworkToJSON(n config.Network) jsonNetwork {
-	return jsonNetwork{
-		Advanced: advancedNetwork{
-			Host: n.Host,
-			Key:  n.Key,
-		},
+	advanced := advancedNetwork{
+		Host:           n.Host,
+		Key:            n.Key,
+		Failover:       string(n.Failover),
+		ArchiveHost:    n.ArchiveHost,
+		Proxy:          n.Proxy,
+		MaxMessageSize: n.Options.MaxMessageSize,
+		UserAgent:      n.Options.UserAgent,
+		RateLimit:      n.Options.RateLimit,
+	}
+	if n.Options.Timeout != 0 {
+		advanced.Timeout = n.Options.Timeout.String()
+	}
+	if n.Options.Keepalive != 0 {
+		advanced.Keepalive = n.Options.Keepalive.String()
 	}
+	if n.TLS != (config.TLSConfig{}) {
+		advanced.TLS = &jsonTLS{
+			Enabled:    n.TLS.Enabled,
+			CAFile:     n.TLS.CAFile,
+			CertFile:   n.TLS.CertFile,
+			KeyFile:    n.TLS.KeyFile,
+			ServerName: n.TLS.ServerName,
+		}
+	}
+	if n.Payer.Enabled() {
+		advanced.Payer = &jsonPayer{
+			URL:      n.Payer.URL,
+			Address:  n.Payer.Address.String(),
+			KeyIndex: n.Payer.KeyIndex,
+			TokenEnv: n.Payer.TokenEnv,
+		}
+	}
+	if len(n.FallbackHosts) > 0 {
+		advanced.Host = ""
+		advanced.Hosts = n.Hosts()
+	}
+
+	return jsonNetwork{Advanced: advanced}
 }
 
 type jsonNetwork struct {
@@ -103,8 +191,117 @@ type simpleNetwork struct {
 }
 
 type advancedNetwork struct {
-	Host string `json:"host"`
-	Key  string `json:"key"`
+	Host           string     `json:"host,omitempty"`
+	Hosts          []string   `json:"hosts,omitempty"`
+	Key            string     `json:"key,omitempty"`
+	Failover       string     `json:"failover,omitempty"`
+	ArchiveHost    string     `json:"archiveHost,omitempty"`
+	Proxy          string     `json:"proxy,omitempty"`
+	Timeout        string     `json:"timeout,omitempty"`
+	MaxMessageSize int        `json:"maxMessageSize,omitempty"`
+	Keepalive      string     `json:"keepalive,omitempty"`
+	UserAgent      string     `json:"userAgent,omitempty"`
+	RateLimit      float64    `json:"rateLimit,omitempty"`
+	TLS            *jsonTLS   `json:"tls,omitempty"`
+	Payer          *jsonPayer `json:"payer,omitempty"`
+}
+
+type jsonPayer struct {
+	URL      string `json:"url"`
+	Address  string `json:"address"`
+	KeyIndex int    `json:"keyIndex,omitempty"`
+	TokenEnv string `json:"tokenEnv,omitempty"`
+}
+
+func (j *jsonPayer) transformToConfig() (config.PayerService, error) {
+	if j == nil {
+		return config.PayerService{}, nil
+	}
+
+	payerURL, err := url.Parse(j.URL)
+	if err != nil {
+		return config.PayerService{}, err
+	}
+	if (payerURL.Scheme != "http" && payerURL.Scheme != "https") || payerURL.Host == "" {
+		return config.PayerService{}, fmt.Errorf("url must be an HTTP or HTTPS URL")
+	}
+
+	address := flow.HexToAddress(j.Address)
+	if j.Address == "" || address == flow.EmptyAddress {
+		return config.PayerService{}, fmt.Errorf("address of the payer account must be provided")
+	}
+	if j.KeyIndex < 0 {
+		return config.PayerService{}, fmt.Errorf("key index must be positive")
+	}
+
+	return config.PayerService{
+		URL:      j.URL,
+		Address:  address,
+		KeyIndex: j.KeyIndex,
+		TokenEnv: j.TokenEnv,
+	}, nil
+}
+
+type jsonTLS struct {
+	Enabled    bool   `json:"enabled"`
+	CAFile     string `json:"caFile,omitempty"`
+	CertFile   string `json:"certFile,omitempty"`
+	KeyFile    string `json:"keyFile,omitempty"`
+	ServerName string `json:"serverName,omitempty"`
+}
+
+func (j *jsonTLS) transformToConfig() (config.TLSConfig, error) {
+	if j == nil {
+		return config.TLSConfig{}, nil
+	}
+
+	tlsConfig := config.TLSConfig{
+		Enabled:    j.Enabled,
+		CAFile:     j.CAFile,
+		CertFile:   j.CertFile,
+		KeyFile:    j.KeyFile,
+		ServerName: j.ServerName,
+	}
+
+	if !tlsConfig.Enabled && (tlsConfig.CAFile != "" || tlsConfig.CertFile != "" || tlsConfig.KeyFile != "") {
+		return tlsConfig, fmt.Errorf("certificates can only be provided when tls is enabled")
+	}
+	if (tlsConfig.CertFile == "") != (tlsConfig.KeyFile == "") {
+		return tlsConfig, fmt.Errorf("client certificate and key must be provided together")
+	}
+
+	return tlsConfig, nil
+}
+
+func (a advancedNetwork) gatewayOptions() (config.GatewayOptions, error) {
+	options := config.GatewayOptions{
+		MaxMessageSize: a.MaxMessageSize,
+		UserAgent:      a.UserAgent,
+		RateLimit:      a.RateLimit,
+	}
+
+	if a.MaxMessageSize < 0 {
+		return options, fmt.Errorf("max message size must be positive")
+	}
+	if a.RateLimit < 0 {
+		return options, fmt.Errorf("rate limit must be positive")
+	}
+
+	var err error
+	if a.Timeout != "" {
+		options.Timeout, err = time.ParseDuration(a.Timeout)
+		if err != nil {
+			return options, fmt.Errorf("invalid timeout %s: %w", a.Timeout, err)
+		}
+	}
+	if a.Keepalive != "" {
+		options.Keepalive, err = time.ParseDuration(a.Keepalive)
+		if err != nil {
+			return options, fmt.Errorf("invalid keepalive %s: %w", a.Keepalive, err)
+		}
+	}
+
+	return options, nil
 }
 
 func (j *jsonNetwork) UnmarshalJSON(b []byte) error {
@@ -119,8 +316,7 @@ func (j *jsonNetwork) UnmarshalJSON(b []byte) error {
 	var advanced advancedNetwork
 	err = json.Unmarshal(b, &advanced)
 	if err == nil {
-		j.Advanced.Host = advanced.Host
-		j.Advanced.Key = advanced.Key
+		j.Advanced = advanced
 	}
 
 	return err
@@ -134,6 +330,25 @@ func (j jsonNetwork) MarshalJSON() ([]byte, error) {
 	return json.Marshal(j.Advanced)
 }
 
+// validateProxy checks the proxy is an HTTP or SOCKS5 proxy URL.
+func validateProxy(proxy string) error {
+	proxyURL, err := url.Parse(proxy)
+	if err != nil {
+		return err
+	}
+
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5", "socks5h":
+	default:
+		return fmt.Errorf("unsupported proxy scheme %s, supported schemes are: http, https, socks5, socks5h", proxyURL.Scheme)
+	}
+	if proxyURL.Host == "" {
+		return fmt.Errorf("proxy host must be provided")
+	}
+
+	return nil
+}
+
 // validateECDSAP256Pub attempt to decode the hex string representation of a ECDSA P256 public key
 func validateECDSAP256Pub(key string) error {
 	b, err := hex.DecodeString(strings.TrimPrefix(key, "0x"))
@@ -166,4 +381,4 @@ func (j jsonNetwork) JSONSchema() *jsonschema.Schema {
 			"advancedNetwork": jsonschema.Reflect(advancedNetwork{}),
 		},
 	}
-}
\ No newline at end of file
+}
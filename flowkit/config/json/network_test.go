@@ -20,8 +20,12 @@ package json
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
 )
 
 func Test_ConfigNetworkSimple(t *testing.T) {
@@ -153,3 +157,172 @@ func Test_TransformConfigAdvanced(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+func Test_ConfigNetworkHosts(t *testing.T) {
+	b := []byte(`{"testnet":{"hosts":["access.devnet.nodes.onflow.org:9000","access-001.devnet.nodes.onflow.org:9000"],"failover":"round-robin"}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	require.NoError(t, err)
+
+	testnet, err := networks.ByName("testnet")
+	require.NoError(t, err)
+	assert.Equal(t, "access.devnet.nodes.onflow.org:9000", testnet.Host)
+	assert.Equal(t, []string{"access-001.devnet.nodes.onflow.org:9000"}, testnet.FallbackHosts)
+	assert.Equal(t, config.FailoverRoundRobin, testnet.Failover)
+
+	x, err := json.Marshal(transformNetworksToJSON(networks))
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+
+	b = []byte(`{"testnet":{"hosts":["access.devnet.nodes.onflow.org:9000"],"failover":"random"}}`)
+	err = json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, "invalid failover strategy random for network with name testnet, valid strategies are: ordered, round-robin")
+}
+
+func Test_ConfigNetworkOptions(t *testing.T) {
+	b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","timeout":"30s","maxMessageSize":104857600,"keepalive":"1m0s","userAgent":"ci","rateLimit":2.5}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	require.NoError(t, err)
+
+	mainnet, err := networks.ByName("mainnet")
+	require.NoError(t, err)
+	assert.Equal(t, "access.mainnet.nodes.onflow.org:9000", mainnet.Host)
+	assert.Equal(t, config.GatewayOptions{
+		Timeout:        30 * time.Second,
+		MaxMessageSize: 104857600,
+		Keepalive:      time.Minute,
+		UserAgent:      "ci",
+		RateLimit:      2.5,
+	}, mainnet.Options)
+
+	x, err := json.Marshal(transformNetworksToJSON(networks))
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+
+	b = []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","timeout":"30 seconds"}}`)
+	err = json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, `invalid options for network with name mainnet: invalid timeout 30 seconds: time: unknown unit " seconds" in duration "30 seconds"`)
+}
+
+func Test_ConfigNetworkTLS(t *testing.T) {
+	b := []byte(`{"private":{"host":"access.private.example.com:9000","tls":{"enabled":true,"caFile":"./certs/ca.pem","certFile":"./certs/client.pem","keyFile":"./certs/client.key","serverName":"access.example.com"}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	require.NoError(t, err)
+
+	private, err := networks.ByName("private")
+	require.NoError(t, err)
+	assert.Equal(t, config.TLSConfig{
+		Enabled:    true,
+		CAFile:     "./certs/ca.pem",
+		CertFile:   "./certs/client.pem",
+		KeyFile:    "./certs/client.key",
+		ServerName: "access.example.com",
+	}, private.TLS)
+
+	x, err := json.Marshal(transformNetworksToJSON(networks))
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+
+	b = []byte(`{"private":{"host":"access.private.example.com:9000","tls":{"enabled":true,"certFile":"./certs/client.pem"}}}`)
+	err = json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, "invalid tls configuration for network with name private: client certificate and key must be provided together")
+}
+
+func Test_ConfigNetworkArchiveHost(t *testing.T) {
+	b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","archiveHost":"archive.mainnet.nodes.onflow.org:9000"}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	require.NoError(t, err)
+
+	mainnet, err := networks.ByName("mainnet")
+	require.NoError(t, err)
+	assert.Equal(t, "access.mainnet.nodes.onflow.org:9000", mainnet.Host)
+	assert.Equal(t, "archive.mainnet.nodes.onflow.org:9000", mainnet.ArchiveHost)
+
+	x, err := json.Marshal(transformNetworksToJSON(networks))
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+}
+
+func Test_ConfigNetworkProxy(t *testing.T) {
+	b := []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","proxy":"socks5://127.0.0.1:1080"}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	require.NoError(t, err)
+
+	mainnet, err := networks.ByName("mainnet")
+	require.NoError(t, err)
+	assert.Equal(t, "socks5://127.0.0.1:1080", mainnet.Proxy)
+
+	x, err := json.Marshal(transformNetworksToJSON(networks))
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+
+	b = []byte(`{"mainnet":{"host":"access.mainnet.nodes.onflow.org:9000","proxy":"ftp://127.0.0.1:21"}}`)
+	err = json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, "invalid proxy ftp://127.0.0.1:21 for network with name mainnet: unsupported proxy scheme ftp, supported schemes are: http, https, socks5, socks5h")
+}
+
+func Test_ConfigNetworkPayer(t *testing.T) {
+	b := []byte(`{"testnet":{"host":"access.devnet.nodes.onflow.org:9000","payer":{"url":"https://payer.example.com/sign","address":"f8d6e0586b0a20c7","keyIndex":2,"tokenEnv":"PAYER_TOKEN"}}}`)
+
+	var jsonNetworks jsonNetworks
+	err := json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	networks, err := jsonNetworks.transformToConfig()
+	require.NoError(t, err)
+
+	testnet, err := networks.ByName("testnet")
+	require.NoError(t, err)
+	assert.True(t, testnet.Payer.Enabled())
+	assert.Equal(t, "https://payer.example.com/sign", testnet.Payer.URL)
+	assert.Equal(t, "f8d6e0586b0a20c7", testnet.Payer.Address.String())
+	assert.Equal(t, 2, testnet.Payer.KeyIndex)
+	assert.Equal(t, "PAYER_TOKEN", testnet.Payer.TokenEnv)
+
+	x, err := json.Marshal(transformNetworksToJSON(networks))
+	require.NoError(t, err)
+	assert.Equal(t, string(b), string(x))
+
+	b = []byte(`{"testnet":{"host":"access.devnet.nodes.onflow.org:9000","payer":{"url":"https://payer.example.com/sign"}}}`)
+	err = json.Unmarshal(b, &jsonNetworks)
+	require.NoError(t, err)
+
+	_, err = jsonNetworks.transformToConfig()
+	assert.EqualError(t, err, "invalid payer service for network with name testnet: address of the payer account must be provided")
+}
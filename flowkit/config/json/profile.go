@@ -0,0 +1,68 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"fmt"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+type jsonProfiles map[string]jsonProfile
+
+// transformToConfig transforms json structures to config structure.
+func (j jsonProfiles) transformToConfig() (config.Profiles, error) {
+	profiles := make(config.Profiles, 0)
+
+	for name, p := range j {
+		if p.Network == "" {
+			return nil, fmt.Errorf("profile %s must define a network", name)
+		}
+
+		profiles = append(profiles, config.Profile{
+			Name:     name,
+			Network:  p.Network,
+			Accounts: p.Accounts,
+			Vars:     p.Vars,
+		})
+	}
+
+	return profiles, nil
+}
+
+// transformProfilesToJSON transforms config structure to json structures for saving.
+func transformProfilesToJSON(profiles config.Profiles) jsonProfiles {
+	jsonProfiles := jsonProfiles{}
+
+	for _, p := range profiles {
+		jsonProfiles[p.Name] = jsonProfile{
+			Network:  p.Network,
+			Accounts: p.Accounts,
+			Vars:     p.Vars,
+		}
+	}
+
+	return jsonProfiles
+}
+
+type jsonProfile struct {
+	Network  string            `json:"network"`
+	Accounts map[string]string `json:"accounts,omitempty"`
+	Vars     map[string]string `json:"vars,omitempty"`
+}
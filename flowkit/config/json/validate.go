@@ -0,0 +1,295 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const definitionsPrefix = "#/$defs/"
+
+// SchemaError describes a configuration value that doesn't conform to the configuration schema.
+type SchemaError struct {
+	Field   string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+	}
+
+	return fmt.Sprintf("field %s at line %d, column %d: %s", e.Field, e.Line, e.Column, e.Message)
+}
+
+// ValidateSchema validates the raw JSON configuration against the configuration schema.
+//
+// Syntax errors are not reported by the validation and are left to the parser.
+func ValidateSchema(raw []byte) error {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil
+	}
+
+	rawSchema, err := json.Marshal(GenerateSchema())
+	if err != nil {
+		return err
+	}
+
+	var schema map[string]any
+	if err := json.Unmarshal(rawSchema, &schema); err != nil {
+		return err
+	}
+
+	definitions, _ := schema["$defs"].(map[string]any)
+	v := &schemaValidator{definitions: definitions}
+
+	schemaErr := v.validate(schema, value, nil)
+	if schemaErr == nil {
+		return nil
+	}
+
+	offset := valueOffsets(raw)[schemaErr.Field]
+	schemaErr.Line = bytes.Count(raw[:offset], []byte("\n")) + 1
+	schemaErr.Column = offset - bytes.LastIndexByte(raw[:offset], '\n')
+
+	return schemaErr
+}
+
+type schemaValidator struct {
+	definitions map[string]any
+}
+
+// validate validates the value against the schema and returns the first error found.
+func (v *schemaValidator) validate(schema map[string]any, value any, path []string) *SchemaError {
+	if ref, ok := schema["$ref"].(string); ok {
+		definition, ok := v.definitions[strings.TrimPrefix(ref, definitionsPrefix)].(map[string]any)
+		if !ok {
+			return schemaError(path, "unknown schema reference %s", ref)
+		}
+		if err := v.validate(definition, value, path); err != nil {
+			return err
+		}
+	}
+
+	if oneOf, ok := schema["oneOf"].([]any); ok {
+		if err := v.validateOneOf(oneOf, value, path); err != nil {
+			return err
+		}
+	}
+
+	if typ, ok := schema["type"].(string); ok && !matchesType(typ, value) {
+		return schemaError(path, "expected %s, got %s", typ, typeName(value))
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		return v.validateObject(schema, val, path)
+	case []any:
+		items, ok := schema["items"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		for i, item := range val {
+			if err := v.validate(items, item, append(path[:len(path):len(path)], fmt.Sprint(i))); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateOneOf checks the value matches at least one of the schemas, if none match the most specific error is returned.
+func (v *schemaValidator) validateOneOf(schemas []any, value any, path []string) *SchemaError {
+	var best *SchemaError
+	for _, s := range schemas {
+		schema, _ := s.(map[string]any)
+		err := v.validate(schema, value, path)
+		if err == nil {
+			return nil
+		}
+		if best == nil || strings.Count(err.Field, ".") > strings.Count(best.Field, ".") {
+			best = err
+		}
+	}
+
+	if best == nil || best.Field == strings.Join(path, ".") {
+		return schemaError(path, "value doesn't match any of the allowed formats")
+	}
+
+	return best
+}
+
+func (v *schemaValidator) validateObject(schema map[string]any, object map[string]any, path []string) *SchemaError {
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := object[name]; !ok {
+				return schemaError(path, "missing required field %s", name)
+			}
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	patternProperties, _ := schema["patternProperties"].(map[string]any)
+
+	// iterate in sorted order so the reported error is deterministic
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		fieldPath := append(path[:len(path):len(path)], key)
+		matched := false
+
+		if property, ok := properties[key].(map[string]any); ok {
+			matched = true
+			if err := v.validate(property, object[key], fieldPath); err != nil {
+				return err
+			}
+		}
+
+		for pattern, property := range patternProperties {
+			if ok, _ := regexp.MatchString(pattern, key); !ok {
+				continue
+			}
+			matched = true
+			if err := v.validate(property.(map[string]any), object[key], fieldPath); err != nil {
+				return err
+			}
+		}
+
+		if matched {
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return schemaError(fieldPath, "unknown field %s", key)
+			}
+		case map[string]any:
+			if err := v.validate(additional, object[key], fieldPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func matchesType(typ string, value any) bool {
+	switch typ {
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return typeName(value) == typ
+	}
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return "null"
+	}
+}
+
+func schemaError(path []string, format string, args ...any) *SchemaError {
+	return &SchemaError{
+		Field:   strings.Join(path, "."),
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// valueOffsets maps every value path in the raw JSON to the byte offset at which the value is defined.
+//
+// Object fields are mapped to the offset of the field name, so errors point at the field.
+func valueOffsets(raw []byte) map[string]int {
+	offsets := map[string]int{"": skipSeparators(raw, 0)}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+
+	var scan func(path []string) error
+	scan = func(path []string) error {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		delim, ok := token.(json.Delim)
+		if !ok || (delim != '{' && delim != '[') {
+			return nil
+		}
+
+		for i := 0; decoder.More(); i++ {
+			start := skipSeparators(raw, int(decoder.InputOffset()))
+			key := fmt.Sprint(i)
+			if delim == '{' {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				key = fmt.Sprint(keyToken)
+			}
+
+			itemPath := append(path[:len(path):len(path)], key)
+			offsets[strings.Join(itemPath, ".")] = start
+			if err := scan(itemPath); err != nil {
+				return err
+			}
+		}
+
+		_, err = decoder.Token() // closing delimiter
+		return err
+	}
+
+	_ = scan(nil)
+	return offsets
+}
+
+func skipSeparators(raw []byte, offset int) int {
+	for offset < len(raw) && strings.ContainsRune(" \t\r\n,:", rune(raw[offset])) {
+		offset++
+	}
+	return offset
+}
@@ -0,0 +1,128 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ValidateSchema(t *testing.T) {
+	b := []byte(`{
+		"$schema": "./flow.schema.json",
+		"contracts": {
+			"NonFungibleToken": "./NonFungibleToken.cdc"
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"deployments": {
+			"emulator": {
+				"emulator-account": ["NonFungibleToken"]
+			}
+		}
+	}`)
+
+	assert.NoError(t, ValidateSchema(b))
+}
+
+func Test_ValidateSchemaErrors(t *testing.T) {
+	tests := []struct {
+		raw   string
+		field string
+		line  int
+		err   string
+	}{{
+		raw: `{
+			"networks": {
+				"emulator": 3569
+			}
+		}`,
+		field: "networks.emulator",
+		line:  3,
+		err:   "field networks.emulator at line 3, column 5: value doesn't match any of the allowed formats",
+	}, {
+		raw: `{
+			"accounts": {
+				"emulator-account": {
+					"address": "f8d6e0586b0a20c7",
+					"key": {
+						"type": "hex",
+						"index": "first",
+						"privateKey": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+					}
+				}
+			}
+		}`,
+		field: "accounts.emulator-account.key.index",
+		line:  7,
+		err:   "field accounts.emulator-account.key.index at line 7, column 7: expected integer, got string",
+	}, {
+		raw: `{
+			"emulators": {
+				"default": {
+					"port": 3569,
+					"serviceAccount": "emulator-account",
+					"restport": 8888
+				}
+			}
+		}`,
+		field: "emulators.default.restport",
+		line:  6,
+		err:   "field emulators.default.restport at line 6, column 6: unknown field restport",
+	}, {
+		raw: `{
+			"emulators": {
+				"default": { "port": 3569 }
+			}
+		}`,
+		field: "emulators.default",
+		line:  3,
+		err:   "field emulators.default at line 3, column 5: missing required field serviceAccount",
+	}, {
+		raw:   `{ "host": "127.0.0.1:3569" }`,
+		field: "host",
+		line:  1,
+		err:   "field host at line 1, column 3: unknown field host",
+	}}
+
+	for _, test := range tests {
+		err := ValidateSchema([]byte(test.raw))
+		require.Error(t, err)
+
+		schemaErr, ok := err.(*SchemaError)
+		require.True(t, ok)
+		assert.Equal(t, test.field, schemaErr.Field)
+		assert.Equal(t, test.line, schemaErr.Line)
+		assert.EqualError(t, err, test.err)
+	}
+}
+
+func Test_ValidateSchemaSyntaxError(t *testing.T) {
+	// syntax errors are reported by the parser
+	assert.NoError(t, ValidateSchema([]byte(`{ "networks": `)))
+}
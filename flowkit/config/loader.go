@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 )
 
 // ErrDoesNotExist is error to be returned when config file does not exists.
@@ -44,6 +45,11 @@ type Parser interface {
 	SupportsFormat(string) bool
 }
 
+// Validator is an optional interface for configuration parsers that can validate raw configuration before it's parsed.
+type Validator interface {
+	Validate([]byte) error
+}
+
 type ReaderWriter interface {
 	ReadFile(source string) ([]byte, error)
 	WriteFile(filename string, data []byte, perm os.FileMode) error
@@ -67,6 +73,11 @@ func (c *Parsers) FindForFormat(extension string) Parser {
 type Loader struct {
 	readerWriter    ReaderWriter
 	configParsers   Parsers
+	includeFetchers IncludeFetchers
+	included        *Config
+	defined         map[string]bool
+	varOverrides    map[string]string
+	profile         string
 	LoadedLocations []string
 }
 
@@ -82,7 +93,28 @@ func (l *Loader) AddConfigParser(format Parser) {
 	l.configParsers = append(l.configParsers, format)
 }
 
+// AddIncludeFetcher adds a new fetcher for remote configuration includes.
+func (l *Loader) AddIncludeFetcher(fetcher IncludeFetcher) {
+	l.includeFetchers = append(l.includeFetchers, fetcher)
+}
+
+// SetVarOverrides sets variables which take precedence over the variables defined
+// in the configuration and environment.
+func (l *Loader) SetVarOverrides(vars map[string]string) {
+	l.varOverrides = vars
+}
+
+// SetProfile sets the name of the profile whose variable values are used when resolving the configuration.
+//
+// Profile variables take precedence over the variables defined in the configuration and environment,
+// but not over the variable overrides.
+func (l *Loader) SetProfile(name string) {
+	l.profile = name
+}
+
 // Save saves a configuration to a path with correct serializer.
+//
+// Values that were merged from includes and were not changed are not saved.
 func (l *Loader) Save(conf *Config, path string) error {
 	configFormat := l.configParsers.FindForFormat(
 		filepath.Ext(path),
@@ -92,11 +124,17 @@ func (l *Loader) Save(conf *Config, path string) error {
 		return fmt.Errorf("parser not found for format")
 	}
 
+	conf = l.withoutIncluded(conf)
 	data, err := configFormat.Serialize(conf)
 	if err != nil {
 		return err
 	}
 
+	err = l.saveAccountFiles(conf, path)
+	if err != nil {
+		return err
+	}
+
 	err = l.readerWriter.WriteFile(path, data, 0644)
 	if err != nil {
 		return err
@@ -123,7 +161,148 @@ func (l *Loader) loadConfig(confPath string) (*Config, error) {
 		return nil, fmt.Errorf("parser not found for config: %s", confPath)
 	}
 
-	return configParser.Deserialize(preProcessed)
+	if validator, ok := configParser.(Validator); ok {
+		if err := validator.Validate(raw); err != nil {
+			return nil, fmt.Errorf("invalid configuration %s: %w", confPath, err)
+		}
+	}
+
+	conf, err := configParser.Deserialize(preProcessed)
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.resolveAccountFiles(conf, filepath.Dir(confPath))
+	if err != nil {
+		return nil, err
+	}
+
+	// keep track of the values defined in the configuration, so they are saved even if equal to included values
+	if l.defined == nil {
+		l.defined = make(map[string]bool)
+	}
+	for _, key := range configKeys(conf) {
+		l.defined[key] = true
+	}
+
+	conf, err = l.resolveIncludes(conf, filepath.Dir(confPath), map[string]bool{filepath.Clean(confPath): true})
+	if err != nil {
+		return nil, err
+	}
+
+	err = l.resolveProjects(conf, filepath.Dir(confPath))
+	if err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+// resolveIncludes loads all the includes of the configuration and merges them with the configuration,
+// values defined in the configuration take precedence over the included values.
+func (l *Loader) resolveIncludes(conf *Config, baseDir string, visited map[string]bool) (*Config, error) {
+	if len(conf.Includes) == 0 {
+		return conf, nil
+	}
+
+	included := &Config{}
+	for _, location := range conf.Includes {
+		if !IsRemoteInclude(location) {
+			if baseDir == "" {
+				return nil, fmt.Errorf("local include %s is not supported inside a remote include", location)
+			}
+			if !filepath.IsAbs(location) {
+				location = filepath.Join(baseDir, location)
+			}
+		}
+
+		if visited[location] {
+			return nil, fmt.Errorf("circular configuration include: %s", location)
+		}
+		visited[location] = true
+
+		includedConf, err := l.loadInclude(location, visited)
+		if err != nil {
+			return nil, err
+		}
+		delete(visited, location)
+
+		l.composeConfig(included, includedConf)
+	}
+
+	// keep track of all included values, so we don't persist them when saving
+	if l.included == nil {
+		l.included = &Config{}
+	}
+	l.composeConfig(l.included, included)
+
+	merged := &Config{
+		Includes: conf.Includes,
+		Projects: conf.Projects,
+	}
+	l.composeConfig(merged, included)
+	l.composeConfig(merged, conf)
+
+	return merged, nil
+}
+
+// loadInclude loads a single include either from a remote location using a fetcher or from a local file.
+func (l *Loader) loadInclude(location string, visited map[string]bool) (*Config, error) {
+	var raw []byte
+	var err error
+	baseDir := ""
+
+	if IsRemoteInclude(location) {
+		fetcher := l.includeFetchers.FindForLocation(location)
+		if fetcher == nil {
+			return nil, fmt.Errorf("fetcher not found for include: %s", location)
+		}
+		raw, err = fetcher.Fetch(location)
+	} else {
+		baseDir = filepath.Dir(location)
+		raw, err = l.loadFile(location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load include %s: %w", location, err)
+	}
+
+	preProcessed, err := l.preprocess(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preprocess include %s: %w", location, err)
+	}
+
+	// remote includes might not have an extension, in which case we default to JSON
+	configParser := l.configParsers.FindForFormat(filepath.Ext(location))
+	if configParser == nil {
+		configParser = l.configParsers.FindForFormat(".json")
+	}
+	if configParser == nil {
+		return nil, fmt.Errorf("parser not found for include: %s", location)
+	}
+
+	if validator, ok := configParser.(Validator); ok {
+		if err := validator.Validate(raw); err != nil {
+			return nil, fmt.Errorf("invalid include %s: %w", location, err)
+		}
+	}
+
+	conf, err := configParser.Deserialize(preProcessed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid include %s: %w", location, err)
+	}
+
+	if IsRemoteInclude(location) {
+		if err := validateRemoteInclude(conf); err != nil {
+			return nil, fmt.Errorf("invalid include %s: %w", location, err)
+		}
+	}
+
+	err = l.resolveAccountFiles(conf, baseDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid include %s: %w", location, err)
+	}
+
+	return l.resolveIncludes(conf, baseDir, visited)
 }
 
 // Load loads configuration from one or more file paths.
@@ -180,8 +359,22 @@ func (l *Loader) preprocess(raw []byte) ([]byte, error) {
 
 // postprocess does all stateful changes to configuration structures here after it is parsed.
 func (l *Loader) postprocess(baseConf *Config) (*Config, error) {
+	overrides, err := l.profileVarOverrides(baseConf)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resolveVars(baseConf.Contracts, baseConf.Vars, overrides)
+	if err != nil {
+		return nil, err
+	}
+	// included values must be resolved the same way, so they can be compared when saving
+	if l.included != nil {
+		_ = resolveVars(l.included.Contracts, baseConf.Vars, overrides)
+	}
+
 	// validate as part of post-processing
-	err := baseConf.Validate()
+	err = baseConf.Validate()
 	if err != nil {
 		return nil, err
 	}
@@ -189,9 +382,40 @@ func (l *Loader) postprocess(baseConf *Config) (*Config, error) {
 	return baseConf, nil
 }
 
+// profileVarOverrides returns the variable overrides merged over the variables of the selected profile.
+func (l *Loader) profileVarOverrides(conf *Config) (map[string]string, error) {
+	if l.profile == "" {
+		return l.varOverrides, nil
+	}
+
+	profile, err := conf.Profiles.ByName(l.profile)
+	if err != nil {
+		return nil, err
+	}
+
+	overrides := make(map[string]string)
+	for name, value := range profile.Vars {
+		overrides[name] = value
+	}
+	for name, value := range l.varOverrides {
+		overrides[name] = value
+	}
+
+	return overrides, nil
+}
+
 // composeConfig merges multiple configuration files from right to left.
 func (l *Loader) composeConfig(baseConf *Config, conf *Config) {
 	// overwrite base config with the provided one
+	for name, value := range conf.Vars {
+		if baseConf.Vars == nil {
+			baseConf.Vars = make(map[string]string)
+		}
+		baseConf.Vars[name] = value
+	}
+	for _, emulator := range conf.Emulators {
+		baseConf.Emulators.AddOrUpdate(emulator.Name, emulator)
+	}
 	for _, account := range conf.Accounts {
 		baseConf.Accounts.AddOrUpdate(account.Name, account)
 	}
@@ -204,8 +428,97 @@ func (l *Loader) composeConfig(baseConf *Config, conf *Config) {
 	for _, deployment := range conf.Deployments {
 		baseConf.Deployments.AddOrUpdate(deployment)
 	}
+	for _, profile := range conf.Profiles {
+		baseConf.Profiles.AddOrUpdate(profile)
+	}
+}
+
+// withoutIncluded returns a copy of the configuration without the unchanged values that were merged from includes.
+//
+// Values defined in the loaded configuration are always kept, even if they are equal to the included values.
+func (l *Loader) withoutIncluded(conf *Config) *Config {
+	if l.included == nil {
+		return conf
+	}
+
+	stripped := *conf
+	stripped.Vars = nil
+	for name, value := range conf.Vars {
+		if included, ok := l.included.Vars[name]; ok && included == value && !l.defined[varKey(name)] {
+			continue
+		}
+		if stripped.Vars == nil {
+			stripped.Vars = make(map[string]string)
+		}
+		stripped.Vars[name] = value
+	}
+	stripped.Emulators = withoutIncludedValues(conf.Emulators, l.included.Emulators, emulatorKey, l.defined)
+	stripped.Accounts = withoutIncludedValues(conf.Accounts, l.included.Accounts, accountKey, l.defined)
+	stripped.Networks = withoutIncludedValues(conf.Networks, l.included.Networks, networkKey, l.defined)
+	stripped.Contracts = withoutIncludedValues(conf.Contracts, l.included.Contracts, contractKey, l.defined)
+	stripped.Deployments = withoutIncludedValues(conf.Deployments, l.included.Deployments, deploymentKey, l.defined)
+	stripped.Profiles = withoutIncludedValues(conf.Profiles, l.included.Profiles, profileKey, l.defined)
+
+	return &stripped
+}
+
+// withoutIncludedValues filters out the values which are not defined in the configuration
+// and are equal to the included value with the same key.
+func withoutIncludedValues[T any](values []T, included []T, key func(T) string, defined map[string]bool) []T {
+	filtered := make([]T, 0)
+	for _, v := range values {
+		isIncluded := false
+		if !defined[key(v)] {
+			for _, inc := range included {
+				if key(inc) == key(v) && reflect.DeepEqual(v, inc) {
+					isIncluded = true
+					break
+				}
+			}
+		}
+		if !isIncluded {
+			filtered = append(filtered, v)
+		}
+	}
+
+	return filtered
 }
 
+// configKeys returns the keys identifying all the values of the configuration.
+func configKeys(conf *Config) []string {
+	keys := make([]string, 0)
+	for name := range conf.Vars {
+		keys = append(keys, varKey(name))
+	}
+	for _, emulator := range conf.Emulators {
+		keys = append(keys, emulatorKey(emulator))
+	}
+	for _, account := range conf.Accounts {
+		keys = append(keys, accountKey(account))
+	}
+	for _, network := range conf.Networks {
+		keys = append(keys, networkKey(network))
+	}
+	for _, contract := range conf.Contracts {
+		keys = append(keys, contractKey(contract))
+	}
+	for _, deployment := range conf.Deployments {
+		keys = append(keys, deploymentKey(deployment))
+	}
+	for _, profile := range conf.Profiles {
+		keys = append(keys, profileKey(profile))
+	}
+	return keys
+}
+
+func varKey(name string) string         { return "vars/" + name }
+func emulatorKey(e Emulator) string     { return "emulators/" + e.Name }
+func accountKey(a Account) string       { return "accounts/" + a.Name }
+func networkKey(n Network) string       { return "networks/" + n.Name }
+func contractKey(c Contract) string     { return "contracts/" + c.Name }
+func deploymentKey(d Deployment) string { return "deployments/" + d.Network + "/" + d.Account }
+func profileKey(p Profile) string       { return "profiles/" + p.Name }
+
 // loadFile simple file loader.
 func (l *Loader) loadFile(path string) ([]byte, error) {
 	raw, err := l.readerWriter.ReadFile(path)
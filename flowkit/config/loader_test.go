@@ -24,6 +24,7 @@ import (
 
 	"github.com/onflow/flow-cli/flowkit/config"
 
+	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -200,6 +201,51 @@ func Test_ConfigurationMalformedJSON(t *testing.T) {
 	assert.Nil(t, conf)
 }
 
+func Test_ConfigurationOutdatedFormat(t *testing.T) {
+	b := []byte(`{
+		"host": "127.0.0.1:3569",
+		"accounts": {
+			"service": {
+				"address": "f8d6e0586b0a20c7",
+				"privateKey": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47",
+				"sigAlgorithm": "ECDSA_P256",
+				"hashAlgorithm": "SHA3_256"
+			}
+		}
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	err := afero.WriteFile(mockFS, "flow.json", b, 0644)
+	assert.NoError(t, err)
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+
+	conf, err := composer.Load(config.DefaultPaths())
+	assert.ErrorIs(t, err, config.ErrOutdatedFormat)
+	assert.Nil(t, conf)
+}
+
+func Test_ConfigurationUnsupportedVersion(t *testing.T) {
+	b := []byte(`{
+		"version": 99,
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		}
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	err := afero.WriteFile(mockFS, "flow.json", b, 0644)
+	assert.NoError(t, err)
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+
+	conf, err := composer.Load(config.DefaultPaths())
+	assert.ErrorContains(t, err, "configuration version 99 is not supported, the latest supported version is 1")
+	assert.Nil(t, conf)
+}
+
 func Test_ConfigurationWrongFormat(t *testing.T) {
 	b := []byte(`{
 		"deployments": {
@@ -219,7 +265,7 @@ func Test_ConfigurationWrongFormat(t *testing.T) {
 	composer.AddConfigParser(json.NewParser())
 
 	conf, err := composer.Load(config.DefaultPaths())
-	assert.EqualError(t, err, "configuration syntax error: json: cannot unmarshal string into Go struct field jsonConfig.deployments of type []json.deployment")
+	assert.EqualError(t, err, "invalid configuration flow.json: field deployments.emulator-account.address at line 4, column 5: expected array, got string")
 	assert.Nil(t, conf)
 }
 
@@ -461,3 +507,589 @@ func Test_LoadAccountFileType(t *testing.T) {
 	assert.Len(t, conf.Accounts, 1)
 	assert.Equal(t, "./test.pkey", acc.Key.Location)
 }
+
+type mockIncludeFetcher struct {
+	includes map[string][]byte
+}
+
+func (m *mockIncludeFetcher) Fetch(location string) ([]byte, error) {
+	raw, ok := m.includes[location]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return raw, nil
+}
+
+func (m *mockIncludeFetcher) SupportsLocation(location string) bool {
+	return config.IsRemoteInclude(location)
+}
+
+func Test_LoadIncludes(t *testing.T) {
+	b := []byte(`{
+		"include": ["https://example.com/core.json", "./shared/networks.json"],
+		"emulators": {
+			"default": {
+				"port": 3569,
+				"serviceAccount": "emulator-account"
+			}
+		},
+		"contracts": {
+			"FungibleToken": "./cadence/FungibleToken.cdc"
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		}
+	}`)
+
+	remote := []byte(`{
+		"contracts": {
+			"FungibleToken": {
+				"source": "./FungibleToken.cdc",
+				"aliases": { "testnet": "9a0766d93b6608b7" }
+			},
+			"FlowToken": {
+				"source": "./FlowToken.cdc",
+				"aliases": { "testnet": "7e60df042a9c0868" }
+			}
+		}
+	}`)
+
+	local := []byte(`{
+		"emulators": {
+			"default": {
+				"port": 9999,
+				"serviceAccount": "emulator-account"
+			},
+			"ci": {
+				"port": 3570,
+				"serviceAccount": "emulator-account"
+			}
+		},
+		"networks": {
+			"testnet": "access.devnet.nodes.onflow.org:9000"
+		}
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "project/flow.json", b, 0644))
+	require.NoError(t, afero.WriteFile(mockFS, "project/shared/networks.json", local, 0644))
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+	composer.AddIncludeFetcher(&mockIncludeFetcher{includes: map[string][]byte{
+		"https://example.com/core.json": remote,
+	}})
+
+	conf, err := composer.Load([]string{"project/flow.json"})
+	require.NoError(t, err)
+
+	assert.Len(t, conf.Contracts, 2)
+	ft, err := conf.Contracts.ByName("FungibleToken")
+	require.NoError(t, err)
+	assert.Equal(t, "./cadence/FungibleToken.cdc", ft.Location) // local takes precedence
+	assert.Len(t, ft.Aliases, 0)
+
+	flowToken, err := conf.Contracts.ByName("FlowToken")
+	require.NoError(t, err)
+	assert.Equal(t, "7e60df042a9c0868", flowToken.Aliases.ByNetwork("testnet").Address.String())
+
+	_, err = conf.Networks.ByName("testnet")
+	assert.NoError(t, err)
+
+	require.Len(t, conf.Emulators, 2)
+	assert.Equal(t, 3569, conf.Emulators.Default().Port) // local takes precedence
+	assert.Contains(t, conf.Emulators, config.Emulator{Name: "ci", Port: 3570, ServiceAccount: "emulator-account"})
+
+	t.Run("Save without included values", func(t *testing.T) {
+		err := composer.Save(conf, "project/flow.json")
+		require.NoError(t, err)
+
+		saved, err := afero.ReadFile(mockFS, "project/flow.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(saved), "https://example.com/core.json")
+		assert.Contains(t, string(saved), "FungibleToken")
+		assert.NotContains(t, string(saved), "FlowToken")
+		assert.NotContains(t, string(saved), "testnet")
+		assert.NotContains(t, string(saved), `"ci"`)
+	})
+}
+
+func Test_SaveIncludesDefinedValues(t *testing.T) {
+	b := []byte(`{
+		"include": ["https://example.com/core.json"],
+		"networks": {
+			"testnet": "access.devnet.nodes.onflow.org:9000"
+		}
+	}`)
+
+	remote := []byte(`{
+		"networks": {
+			"testnet": "access.devnet.nodes.onflow.org:9000",
+			"mainnet": "access.mainnet.nodes.onflow.org:9000"
+		}
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "flow.json", b, 0644))
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+	composer.AddIncludeFetcher(&mockIncludeFetcher{includes: map[string][]byte{
+		"https://example.com/core.json": remote,
+	}})
+
+	conf, err := composer.Load([]string{"flow.json"})
+	require.NoError(t, err)
+	require.NoError(t, composer.Save(conf, "flow.json"))
+
+	// the network defined in the configuration is kept although it's equal to the included one
+	saved, err := afero.ReadFile(mockFS, "flow.json")
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), "testnet")
+	assert.NotContains(t, string(saved), "mainnet")
+}
+
+func Test_LoadIncludesErrors(t *testing.T) {
+	t.Run("Fail circular include", func(t *testing.T) {
+		mockFS := afero.NewMemMapFs()
+		_ = afero.WriteFile(mockFS, "a.json", []byte(`{ "include": ["./b.json"] }`), 0644)
+		_ = afero.WriteFile(mockFS, "b.json", []byte(`{ "include": ["./a.json"] }`), 0644)
+
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+
+		_, err := composer.Load([]string{"a.json"})
+		assert.EqualError(t, err, "circular configuration include: a.json")
+	})
+
+	t.Run("Fail missing fetcher", func(t *testing.T) {
+		mockFS := afero.NewMemMapFs()
+		_ = afero.WriteFile(mockFS, "flow.json", []byte(`{ "include": ["https://example.com/core.json"] }`), 0644)
+
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+
+		_, err := composer.Load([]string{"flow.json"})
+		assert.EqualError(t, err, "fetcher not found for include: https://example.com/core.json")
+	})
+
+	t.Run("Fail remote include running programs", func(t *testing.T) {
+		mockFS := afero.NewMemMapFs()
+		_ = afero.WriteFile(mockFS, "flow.json", []byte(`{ "include": ["https://example.com/hooks.json", "https://example.com/exec.json"] }`), 0644)
+
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+		fetcher := &mockIncludeFetcher{includes: map[string][]byte{
+			"https://example.com/hooks.json": []byte(`{
+				"deployments": { "testnet": { "alice": [{ "name": "Kibble", "args": [], "hooks": [{ "command": "echo deployed" }] }] } }
+			}`),
+			"https://example.com/exec.json": []byte(`{}`),
+		}}
+		composer.AddIncludeFetcher(fetcher)
+
+		_, err := composer.Load([]string{"flow.json"})
+		assert.EqualError(t, err, "invalid include https://example.com/hooks.json: deployment hooks of contract Kibble on network testnet are not allowed in remote includes, define them in the local configuration")
+
+		fetcher.includes["https://example.com/hooks.json"] = []byte(`{}`)
+		fetcher.includes["https://example.com/exec.json"] = []byte(`{
+			"accounts": { "admin": { "address": "f8d6e0586b0a20c7", "key": { "type": "exec", "command": ["./signer"], "publicKey": "5ce61c89922042c91d1e6177fb89b887a7268c3017b7f92fcfa28d6521eca40c1fb26c449a246cb6ccf5de7cd5b2f2eb7581e8c4084e2778112d2b737cce83c0" } } }
+		}`)
+
+		_, err = composer.Load([]string{"flow.json"})
+		assert.EqualError(t, err, "invalid include https://example.com/exec.json: account admin with key type exec is not allowed in remote includes, define it in the local configuration")
+	})
+}
+
+func Test_LoadProjects(t *testing.T) {
+	app := []byte(`{
+		"projects": { "core": "../core/flow.json" },
+		"contracts": {
+			"App": "./cadence/App.cdc"
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"deployments": {
+			"emulator": {
+				"core.admin": ["core.Foo"],
+				"emulator-account": ["App"]
+			}
+		}
+	}`)
+
+	core := []byte(`{
+		"contracts": {
+			"Foo": "./cadence/Foo.cdc",
+			"App": "./cadence/App.cdc"
+		},
+		"networks": {
+			"testnet": "access.devnet.nodes.onflow.org:9000"
+		},
+		"accounts": {
+			"admin": {
+				"address": "01cf0e2f2f715450",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		}
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "app/flow.json", app, 0644))
+	require.NoError(t, afero.WriteFile(mockFS, "core/flow.json", core, 0644))
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+
+	conf, err := composer.Load([]string{"app/flow.json"})
+	require.NoError(t, err)
+
+	assert.Len(t, conf.Contracts, 3)
+	foo, err := conf.Contracts.ByName("core.Foo")
+	require.NoError(t, err)
+	assert.Equal(t, "../core/cadence/Foo.cdc", foo.Location)
+
+	app2, err := conf.Contracts.ByName("App")
+	require.NoError(t, err)
+	assert.Equal(t, "./cadence/App.cdc", app2.Location) // not clashing with the referenced App
+	_, err = conf.Contracts.ByName("core.App")
+	assert.NoError(t, err)
+
+	admin, err := conf.Accounts.ByName("core.admin")
+	require.NoError(t, err)
+	assert.Equal(t, "01cf0e2f2f715450", admin.Address.String())
+
+	_, err = conf.Networks.ByName("testnet")
+	assert.Error(t, err) // networks are not referenced
+
+	assert.Equal(t, "Foo", config.BaseName(foo.Name))
+
+	t.Run("Save without referenced values", func(t *testing.T) {
+		err := composer.Save(conf, "app/flow.json")
+		require.NoError(t, err)
+
+		saved, err := afero.ReadFile(mockFS, "app/flow.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(saved), "../core/flow.json")
+		assert.Contains(t, string(saved), "core.Foo") // used in deployment
+		assert.NotContains(t, string(saved), "../core/cadence/Foo.cdc")
+		assert.NotContains(t, string(saved), "01cf0e2f2f715450")
+	})
+
+	t.Run("Fail invalid project references", func(t *testing.T) {
+		mockFS := afero.NewMemMapFs()
+		_ = afero.WriteFile(mockFS, "remote.json", []byte(`{ "projects": { "core": "https://example.com/flow.json" } }`), 0644)
+		_ = afero.WriteFile(mockFS, "name.json", []byte(`{ "projects": { "co.re": "./core/flow.json" } }`), 0644)
+		_ = afero.WriteFile(mockFS, "missing.json", []byte(`{ "projects": { "core": "./core/flow.json" } }`), 0644)
+
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+
+		_, err := composer.Load([]string{"remote.json"})
+		assert.EqualError(t, err, "project core must reference a local configuration, remote location https://example.com/flow.json is not supported")
+
+		_, err = composer.Load([]string{"name.json"})
+		assert.EqualError(t, err, "invalid project name co.re, only letters, numbers, underscores and dashes are allowed")
+
+		_, err = composer.Load([]string{"missing.json"})
+		assert.ErrorContains(t, err, "failed to load project core")
+	})
+}
+
+func Test_LoadAccountFromFile(t *testing.T) {
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "project/flow.json", []byte(`{
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			},
+			"testnet-account": {
+				"fromFile": "accounts.private.json"
+			}
+		}
+	}`), 0644))
+	require.NoError(t, afero.WriteFile(mockFS, "project/accounts.private.json", []byte(`{
+		"accounts": {
+			"testnet-account": {
+				"address": "3c1162386b0a245f",
+				"key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+			}
+		}
+	}`), 0644))
+	require.NoError(t, afero.WriteFile(mockFS, "project/.gitignore", []byte("node_modules"), 0644))
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+
+	conf, err := composer.Load([]string{"project/flow.json"})
+	require.NoError(t, err)
+
+	account, err := conf.Accounts.ByName("testnet-account")
+	require.NoError(t, err)
+	assert.Equal(t, "3c1162386b0a245f", account.Address.String())
+	assert.Equal(t, "accounts.private.json", account.FromFile)
+
+	t.Run("Save to account file", func(t *testing.T) {
+		account.Address = flow.HexToAddress("0x0ae53cb6e3f42a79")
+		conf.Accounts.AddOrUpdate(account.Name, *account)
+		conf.Accounts.AddOrUpdate("mainnet-account", config.Account{
+			Name:     "mainnet-account",
+			Address:  flow.HexToAddress("0xf233dcee88fe0abe"),
+			Key:      account.Key,
+			FromFile: "accounts.private.json",
+		})
+
+		err := composer.Save(conf, "project/flow.json")
+		require.NoError(t, err)
+
+		saved, err := afero.ReadFile(mockFS, "project/flow.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(saved), `"fromFile": "accounts.private.json"`)
+		assert.NotContains(t, string(saved), "0ae53cb6e3f42a79")
+		assert.NotContains(t, string(saved), "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+
+		private, err := afero.ReadFile(mockFS, "project/accounts.private.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(private), "0ae53cb6e3f42a79")
+		assert.Contains(t, string(private), "f233dcee88fe0abe")
+		assert.NotContains(t, string(private), "fromFile")
+
+		gitignore, err := afero.ReadFile(mockFS, "project/.gitignore")
+		require.NoError(t, err)
+		assert.Equal(t, "node_modules\naccounts.private.json\n", string(gitignore))
+
+		// saving again doesn't duplicate the ignored file
+		err = composer.Save(conf, "project/flow.json")
+		require.NoError(t, err)
+		gitignore, _ = afero.ReadFile(mockFS, "project/.gitignore")
+		assert.Equal(t, "node_modules\naccounts.private.json\n", string(gitignore))
+
+		reloader := config.NewLoader(afero.Afero{Fs: mockFS})
+		reloader.AddConfigParser(json.NewParser())
+		reloaded, err := reloader.Load([]string{"project/flow.json"})
+		require.NoError(t, err)
+
+		mainnetAccount, err := reloaded.Accounts.ByName("mainnet-account")
+		require.NoError(t, err)
+		assert.Equal(t, "f233dcee88fe0abe", mainnetAccount.Address.String())
+		assert.Equal(t, "accounts.private.json", mainnetAccount.FromFile)
+	})
+}
+
+func Test_LoadAccountFromFileErrors(t *testing.T) {
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "flow.json", []byte(`{
+		"accounts": {
+			"testnet-account": { "fromFile": "accounts.private.json" }
+		}
+	}`), 0644))
+
+	composer := config.NewLoader(afero.Afero{Fs: mockFS})
+	composer.AddConfigParser(json.NewParser())
+
+	_, err := composer.Load([]string{"flow.json"})
+	assert.EqualError(t, err, "failed to load account testnet-account from file accounts.private.json: missing configuration")
+
+	require.NoError(t, afero.WriteFile(mockFS, "accounts.private.json", []byte(`{ "accounts": {} }`), 0644))
+	_, err = composer.Load([]string{"flow.json"})
+	assert.EqualError(t, err, "account testnet-account is not present in file accounts.private.json")
+}
+
+func Test_LoadVars(t *testing.T) {
+	b := []byte(`{
+		"vars": {
+			"CONTRACTS_DIR": "./cadence",
+			"TOKENS_DIR": "./tokens"
+		},
+		"contracts": {
+			"FungibleToken": "${CONTRACTS_DIR}/FungibleToken.cdc",
+			"FlowToken": {
+				"source": "${TOKENS_DIR}/FlowToken.cdc",
+				"aliases": { "emulator": "0ae53cb6e3f42a79" }
+			},
+			"Foo": "./Foo.cdc"
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		}
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "flow.json", b, 0644))
+
+	t.Run("Resolve vars", func(t *testing.T) {
+		t.Setenv("TOKENS_DIR", "./env-tokens")
+
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+
+		conf, err := composer.Load([]string{"flow.json"})
+		require.NoError(t, err)
+
+		ft, _ := conf.Contracts.ByName("FungibleToken")
+		assert.Equal(t, "./cadence/FungibleToken.cdc", ft.Location)
+		flowToken, _ := conf.Contracts.ByName("FlowToken")
+		assert.Equal(t, "./env-tokens/FlowToken.cdc", flowToken.Location) // environment takes precedence
+		foo, _ := conf.Contracts.ByName("Foo")
+		assert.Equal(t, "./Foo.cdc", foo.Location)
+
+		// variables are kept when saving
+		require.NoError(t, composer.Save(conf, "saved.json"))
+		saved, err := afero.ReadFile(mockFS, "saved.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(saved), `"FungibleToken": "${CONTRACTS_DIR}/FungibleToken.cdc"`)
+		assert.Contains(t, string(saved), `"CONTRACTS_DIR": "./cadence"`)
+	})
+
+	t.Run("Resolve var overrides", func(t *testing.T) {
+		t.Setenv("CONTRACTS_DIR", "./env")
+
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+		composer.SetVarOverrides(map[string]string{"CONTRACTS_DIR": "./generated"})
+
+		conf, err := composer.Load([]string{"flow.json"})
+		require.NoError(t, err)
+
+		ft, _ := conf.Contracts.ByName("FungibleToken")
+		assert.Equal(t, "./generated/FungibleToken.cdc", ft.Location)
+	})
+
+	t.Run("Fail undefined var", func(t *testing.T) {
+		b := []byte(`{
+			"contracts": { "Foo": "${MISSING_DIR}/Foo.cdc" },
+			"networks": { "emulator": "127.0.0.1:3569" }
+		}`)
+		require.NoError(t, afero.WriteFile(mockFS, "missing.json", b, 0644))
+
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+
+		_, err := composer.Load([]string{"missing.json"})
+		assert.EqualError(t, err, "failed to resolve location of contract Foo: variable MISSING_DIR is not defined, define it in the vars section, environment or using the --var flag")
+	})
+}
+
+func Test_LoadProfiles(t *testing.T) {
+	b := []byte(`{
+		"vars": {
+			"CONTRACTS_DIR": "./cadence"
+		},
+		"contracts": {
+			"Foo": "${CONTRACTS_DIR}/Foo.cdc"
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569",
+			"testnet": "access.devnet.nodes.onflow.org:9000"
+		},
+		"accounts": {
+			"deployer": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			},
+			"staging-deployer": {
+				"address": "0x2c1162386b0a245f",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"deployments": {
+			"testnet": {
+				"deployer": ["Foo"]
+			}
+		},
+		"profiles": {
+			"staging": {
+				"network": "testnet",
+				"accounts": { "deployer": "staging-deployer" },
+				"vars": { "CONTRACTS_DIR": "./staging" }
+			}
+		}
+	}`)
+
+	mockFS := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(mockFS, "flow.json", b, 0644))
+
+	t.Run("Load profiles", func(t *testing.T) {
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+
+		conf, err := composer.Load([]string{"flow.json"})
+		require.NoError(t, err)
+
+		profile, err := conf.Profiles.ByName("staging")
+		require.NoError(t, err)
+		assert.Equal(t, "testnet", profile.Network)
+		assert.Equal(t, "staging-deployer", profile.AccountName("deployer"))
+		assert.Equal(t, "other", profile.AccountName("other"))
+
+		foo, _ := conf.Contracts.ByName("Foo")
+		assert.Equal(t, "./cadence/Foo.cdc", foo.Location)
+
+		// deployments are kept unchanged when saving
+		require.NoError(t, composer.Save(conf, "saved.json"))
+		saved, err := afero.ReadFile(mockFS, "saved.json")
+		require.NoError(t, err)
+		assert.Contains(t, string(saved), `"staging-deployer"`)
+		assert.Contains(t, string(saved), `"deployer": [`)
+	})
+
+	t.Run("Resolve profile vars", func(t *testing.T) {
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+		composer.SetProfile("staging")
+
+		conf, err := composer.Load([]string{"flow.json"})
+		require.NoError(t, err)
+
+		foo, _ := conf.Contracts.ByName("Foo")
+		assert.Equal(t, "./staging/Foo.cdc", foo.Location)
+
+		composer = config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+		composer.SetProfile("staging")
+		composer.SetVarOverrides(map[string]string{"CONTRACTS_DIR": "./override"})
+
+		conf, err = composer.Load([]string{"flow.json"})
+		require.NoError(t, err)
+
+		foo, _ = conf.Contracts.ByName("Foo")
+		assert.Equal(t, "./override/Foo.cdc", foo.Location) // overrides take precedence
+	})
+
+	t.Run("Fail unknown profile", func(t *testing.T) {
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+		composer.SetProfile("production")
+
+		_, err := composer.Load([]string{"flow.json"})
+		assert.EqualError(t, err, "profile named production does not exist in configuration")
+	})
+
+	t.Run("Fail nonexisting profile account", func(t *testing.T) {
+		invalid := []byte(`{
+			"networks": { "testnet": "access.devnet.nodes.onflow.org:9000" },
+			"profiles": {
+				"staging": { "network": "testnet", "accounts": { "deployer": "missing" } }
+			}
+		}`)
+		require.NoError(t, afero.WriteFile(mockFS, "invalid.json", invalid, 0644))
+
+		composer := config.NewLoader(afero.Afero{Fs: mockFS})
+		composer.AddConfigParser(json.NewParser())
+
+		_, err := composer.Load([]string{"invalid.json"})
+		assert.EqualError(t, err, "profile staging contains nonexisting account missing")
+	})
+}
@@ -20,6 +20,9 @@ package config
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
 )
 
 var (
@@ -36,20 +39,115 @@ var (
 		Name: "mainnet",
 		Host: "access.mainnet.nodes.onflow.org:9000",
 	}
+	SandboxnetNetwork = Network{
+		Name: "sandboxnet",
+		Host: "access.sandboxnet.nodes.onflow.org:9000",
+	}
+	CanarynetNetwork = Network{
+		Name: "canarynet",
+		Host: "access.canary.nodes.onflow.org:9000",
+	}
+	CrescendoNetwork = Network{
+		Name: "crescendo",
+		Host: "access.crescendo.nodes.onflow.org:9000",
+	}
+	PreviewnetNetwork = Network{
+		Name: "previewnet",
+		Host: "access.previewnet.nodes.onflow.org:9000",
+	}
 	DefaultNetworks = Networks{
 		EmulatorNetwork,
 		TestnetNetwork,
 		MainnetNetwork,
 	}
+	// PresetNetworks are all the built-in networks which can be used without being defined in configuration.
+	PresetNetworks = Networks{
+		EmulatorNetwork,
+		TestnetNetwork,
+		MainnetNetwork,
+		SandboxnetNetwork,
+		CanarynetNetwork,
+		CrescendoNetwork,
+		PreviewnetNetwork,
+	}
 )
 
 type Networks []Network
 
+// FailoverStrategy defines how the hosts of a network are selected.
+type FailoverStrategy string
+
+const (
+	// FailoverOrdered always tries the hosts in the configured order.
+	FailoverOrdered FailoverStrategy = "ordered"
+	// FailoverRoundRobin rotates the host tried first on every request.
+	FailoverRoundRobin FailoverStrategy = "round-robin"
+)
+
 // Network defines the configuration for a Flow network.
 type Network struct {
 	Name string
 	Host string
 	Key  string
+	// FallbackHosts are tried after the host on connection errors or rate-limit responses.
+	FallbackHosts []string
+	Failover      FailoverStrategy
+	// ArchiveHost is the archive access node used to execute scripts at past blocks.
+	ArchiveHost string
+	// Proxy is the URL of the HTTP or SOCKS5 proxy used to connect to the hosts,
+	// the proxy environment variables are used if empty.
+	Proxy   string
+	Options GatewayOptions
+	TLS     TLSConfig
+	// Payer is the remote service paying the fees of transactions on the network, it is only used when requested.
+	Payer PayerService
+}
+
+// PayerService is a remote HTTP endpoint co-signing transactions as payer,
+// so the fees are paid by a managed account without holding the payer key locally.
+type PayerService struct {
+	URL string
+	// Address of the account paying the fees and KeyIndex of the key the service signs with.
+	Address  flow.Address
+	KeyIndex int
+	// TokenEnv is the environment variable holding the bearer token sent to the service, no token is sent if empty.
+	TokenEnv string
+}
+
+// Enabled checks if the payer service is configured.
+func (p PayerService) Enabled() bool {
+	return p.URL != ""
+}
+
+// TLSConfig configures TLS secured gRPC connections to the network hosts.
+type TLSConfig struct {
+	// Enabled secures the connections with TLS, the system certificates are used if no CA bundle is provided.
+	Enabled bool
+	// CAFile is the path to the PEM encoded CA bundle used to verify the host certificates.
+	CAFile string
+	// CertFile and KeyFile are the paths to the PEM encoded client certificate and key used for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// ServerName overrides the name used to verify the host certificate.
+	ServerName string
+}
+
+// GatewayOptions configure the client connections to the network hosts.
+type GatewayOptions struct {
+	// Timeout of a single request, requests don't time out if zero.
+	Timeout time.Duration
+	// MaxMessageSize is the maximum size of received messages in bytes, the default size is used if zero.
+	MaxMessageSize int
+	// Keepalive is the interval of pings keeping idle connections alive, pings are disabled if zero.
+	Keepalive time.Duration
+	UserAgent string
+	// RateLimit is the maximum number of requests per second sent to the network, requests are not limited if zero.
+	RateLimit float64
+}
+
+// Hosts returns the host followed by all the fallback hosts.
+func (n Network) Hosts() []string {
+	return append([]string{n.Host}, n.FallbackHosts...)
 }
 
 // ByName get network by name or return an error if not found.
@@ -63,6 +161,16 @@ func (n *Networks) ByName(name string) (*Network, error) {
 	return nil, fmt.Errorf("network named %s does not exist in configuration", name)
 }
 
+// Names returns the names of all the networks.
+func (n *Networks) Names() []string {
+	names := make([]string, 0, len(*n))
+	for _, network := range *n {
+		names = append(names, network.Name)
+	}
+
+	return names
+}
+
 // AddOrUpdate add new network or update if already present.
 func (n *Networks) AddOrUpdate(network Network) {
 	for i, existingNetwork := range *n {
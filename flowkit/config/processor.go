@@ -26,11 +26,17 @@ import (
 // processorRun all pre-processors.
 func processorRun(raw []byte) ([]byte, error) {
 	type config struct {
+		Schema      any                       `json:"$schema,omitempty"`
+		Version     any                       `json:"version,omitempty"`
+		Include     any                       `json:"include,omitempty"`
+		Projects    any                       `json:"projects,omitempty"`
+		Vars        any                       `json:"vars,omitempty"`
 		Accounts    map[string]map[string]any `json:"accounts,omitempty"`
 		Contracts   any                       `json:"contracts,omitempty"`
 		Networks    any                       `json:"networks,omitempty"`
 		Deployments any                       `json:"deployments,omitempty"`
 		Emulators   any                       `json:"emulators,omitempty"`
+		Profiles    any                       `json:"profiles,omitempty"`
 	}
 
 	var conf config
@@ -0,0 +1,84 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+)
+
+// Profile defines a named deployment environment which binds a network with the signer accounts and variable values.
+//
+// Accounts maps the account names used in the deployments to the accounts used when the profile is selected,
+// this allows the same deployments to target different accounts per environment (e.g. staging and production).
+type Profile struct {
+	Name     string
+	Network  string
+	Accounts map[string]string
+	Vars     map[string]string
+}
+
+// AccountName returns the account mapped to the provided account name or the same name if not mapped.
+func (p *Profile) AccountName(name string) string {
+	if mapped, ok := p.Accounts[name]; ok {
+		return mapped
+	}
+
+	return name
+}
+
+type Profiles []Profile
+
+// ByName get profile by name or return an error if not found.
+func (p *Profiles) ByName(name string) (*Profile, error) {
+	for _, profile := range *p {
+		if profile.Name == name {
+			return &profile, nil
+		}
+	}
+
+	return nil, fmt.Errorf("profile named %s does not exist in configuration", name)
+}
+
+// AddOrUpdate add new profile or update if already present.
+func (p *Profiles) AddOrUpdate(profile Profile) {
+	for i, existingProfile := range *p {
+		if existingProfile.Name == profile.Name {
+			(*p)[i] = profile
+			return
+		}
+	}
+
+	*p = append(*p, profile)
+}
+
+// Remove profile by the name.
+func (p *Profiles) Remove(name string) error {
+	_, err := p.ByName(name)
+	if err != nil {
+		return err
+	}
+
+	for i, profile := range *p {
+		if profile.Name == name {
+			*p = append((*p)[0:i], (*p)[i+1:]...) // remove item
+		}
+	}
+
+	return nil
+}
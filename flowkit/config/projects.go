@@ -0,0 +1,107 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// namespaceSeparator separates the referenced project name from the contract or account name, e.g. core.Foo.
+const namespaceSeparator = "."
+
+var projectNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// BaseName returns the name without the namespace of the referenced project, e.g. core.Foo returns Foo.
+func BaseName(name string) string {
+	return name[strings.LastIndex(name, namespaceSeparator)+1:]
+}
+
+// resolveProjects loads the referenced projects and adds their contracts and accounts to the configuration,
+// namespaced with the project name, e.g. contract Foo of the project core is added as core.Foo.
+//
+// Locations of the referenced contracts are made relative to the configuration directory. Networks and deployments
+// of the referenced projects are not added and the projects referenced by them are not resolved.
+func (l *Loader) resolveProjects(conf *Config, baseDir string) error {
+	if len(conf.Projects) == 0 {
+		return nil
+	}
+
+	if l.included == nil {
+		l.included = &Config{}
+	}
+
+	for name, location := range conf.Projects {
+		if !projectNameRegex.MatchString(name) {
+			return fmt.Errorf("invalid project name %s, only letters, numbers, underscores and dashes are allowed", name)
+		}
+		if IsRemoteInclude(location) {
+			return fmt.Errorf("project %s must reference a local configuration, remote location %s is not supported", name, location)
+		}
+
+		path := location
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(baseDir, location)
+		}
+
+		// values included by the referenced project are not tracked, only its namespaced values are
+		included := l.included
+		ref, err := l.loadInclude(path, map[string]bool{})
+		l.included = included
+		if err != nil {
+			return fmt.Errorf("failed to load project %s: %w", name, err)
+		}
+
+		projectDir := filepath.Dir(location)
+		for _, contract := range ref.Contracts {
+			contract.Name = namespaced(name, contract.Name)
+			if _, err := conf.Contracts.ByName(contract.Name); err == nil {
+				continue // values defined in the configuration take precedence
+			}
+			if contract.Location != "" && !filepath.IsAbs(contract.Location) {
+				contract.Location = filepath.Join(projectDir, contract.Location)
+			}
+
+			conf.Contracts.AddOrUpdate(contract)
+			l.included.Contracts.AddOrUpdate(contract)
+		}
+
+		for _, account := range ref.Accounts {
+			account.Name = namespaced(name, account.Name)
+			if _, err := conf.Accounts.ByName(account.Name); err == nil {
+				continue
+			}
+			if account.Key.Type == KeyTypeFile && !filepath.IsAbs(account.Key.Location) {
+				account.Key.Location = filepath.Join(projectDir, account.Key.Location)
+			}
+			account.FromFile = "" // already resolved from the referenced project
+
+			conf.Accounts.AddOrUpdate(account.Name, account)
+			l.included.Accounts.AddOrUpdate(account.Name, account)
+		}
+	}
+
+	return nil
+}
+
+func namespaced(project string, name string) string {
+	return fmt.Sprintf("%s%s%s", project, namespaceSeparator, name)
+}
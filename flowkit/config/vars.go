@@ -0,0 +1,69 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+)
+
+var varPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// resolveVars interpolates the variables used in contract locations and keeps the original locations for saving.
+//
+// Variable values are taken from the overrides, environment and the configuration variables in that order.
+func resolveVars(contracts Contracts, vars map[string]string, overrides map[string]string) error {
+	for i, contract := range contracts {
+		if contract.RawLocation != "" || !varPattern.MatchString(contract.Location) {
+			continue
+		}
+
+		location, err := interpolateVars(contract.Location, vars, overrides)
+		if err != nil {
+			return fmt.Errorf("failed to resolve location of contract %s: %w", contract.Name, err)
+		}
+
+		contracts[i].RawLocation = contract.Location
+		contracts[i].Location = location
+	}
+
+	return nil
+}
+
+func interpolateVars(value string, vars map[string]string, overrides map[string]string) (string, error) {
+	var err error
+	interpolated := varPattern.ReplaceAllStringFunc(value, func(match string) string {
+		name := varPattern.FindStringSubmatch(match)[1]
+		if v, ok := overrides[name]; ok {
+			return v
+		}
+		if v, ok := os.LookupEnv(name); ok {
+			return v
+		}
+		if v, ok := vars[name]; ok {
+			return v
+		}
+
+		err = fmt.Errorf("variable %s is not defined, define it in the vars section, environment or using the --var flag", name)
+		return match
+	})
+
+	return interpolated, err
+}
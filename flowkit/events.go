@@ -19,10 +19,15 @@
 package flowkit
 
 import (
+	"strings"
+
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 )
 
+// feesDeductedEvent is the type suffix of the event emitted when the transaction fees are deducted from the payer.
+const feesDeductedEvent = "FlowFees.FeesDeducted"
+
 type Event struct {
 	Type   string
 	Values map[string]cadence.Value
@@ -75,3 +80,28 @@ func (e *Events) GetCreatedAddresses() []*flow.Address {
 
 	return addresses
 }
+
+// TransactionFees contains the fees paid for a transaction and the efforts they were calculated from.
+type TransactionFees struct {
+	Amount          cadence.UFix64
+	InclusionEffort cadence.UFix64
+	ExecutionEffort cadence.UFix64
+}
+
+// GetFees returns the fees deducted for the transaction or nil if no fees were deducted,
+// which is the case on networks without transaction fees enabled.
+func (e *Events) GetFees() *TransactionFees {
+	for _, event := range *e {
+		if !strings.HasSuffix(event.Type, feesDeductedEvent) {
+			continue
+		}
+
+		fees := &TransactionFees{}
+		fees.Amount, _ = event.Values["amount"].(cadence.UFix64)
+		fees.InclusionEffort, _ = event.Values["inclusionEffort"].(cadence.UFix64)
+		fees.ExecutionEffort, _ = event.Values["executionEffort"].(cadence.UFix64)
+		return fees
+	}
+
+	return nil
+}
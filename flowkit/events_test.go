@@ -24,6 +24,7 @@ import (
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/tests"
@@ -50,6 +51,31 @@ func Test_AccountCreatedEvent(t *testing.T) {
 	assert.Equal(t, `flow.AccountCreated(address: 0x00c4fef62310c807)`, flowEvent.Value.String())
 }
 
+func Test_FeesDeductedEvent(t *testing.T) {
+	amount, _ := cadence.NewUFix64("0.00001000")
+	inclusion, _ := cadence.NewUFix64("1.0")
+	execution, _ := cadence.NewUFix64("0.00002616")
+	flowEvent := tests.NewEvent(0,
+		"A.f919ee77447b7497.FlowFees.FeesDeducted",
+		[]cadence.Field{
+			{Identifier: "amount", Type: cadence.UFix64Type{}},
+			{Identifier: "inclusionEffort", Type: cadence.UFix64Type{}},
+			{Identifier: "executionEffort", Type: cadence.UFix64Type{}},
+		},
+		[]cadence.Value{amount, inclusion, execution},
+	)
+	events := flowkit.EventsFromTransaction(tests.NewTransactionResult([]flow.Event{*flowEvent}))
+
+	fees := events.GetFees()
+	require.NotNil(t, fees)
+	assert.Equal(t, amount, fees.Amount)
+	assert.Equal(t, inclusion, fees.InclusionEffort)
+	assert.Equal(t, execution, fees.ExecutionEffort)
+
+	events = flowkit.EventsFromTransaction(tests.NewTransactionResult(nil))
+	assert.Nil(t, events.GetFees())
+}
+
 func TestAddress(t *testing.T) {
 	address := flow.HexToAddress("cdfef0f4f0786e9")
 	assert.Equal(t, "0cdfef0f4f0786e9", address.String())
@@ -35,6 +35,7 @@ import (
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/pkg/errors"
 	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/sha3"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
@@ -92,8 +93,13 @@ var LatestScriptQuery = ScriptQuery{Latest: true}
 type EventWorker struct {
 	Count           int
 	BlocksPerWorker uint64
+	// Progress is optionally called with the number of completed and total requests after each request finishes.
+	Progress func(done int, total int)
 }
 
+// MaxEventBlockRange is the maximum number of blocks access nodes allow in a single event range request.
+const MaxEventBlockRange uint64 = 250
+
 var _ Services = &Flowkit{}
 
 func NewFlowkit(
@@ -102,6 +108,10 @@ func NewFlowkit(
 	gateway gateway.Gateway,
 	logger output.Logger,
 ) *Flowkit {
+	if state != nil {
+		state.Accounts().SetNetwork(network.Name)
+	}
+
 	return &Flowkit{state, network, gateway, logger}
 }
 
@@ -244,6 +254,30 @@ func (f *Flowkit) prepareTransaction(
 	return tx, nil
 }
 
+// resolveProgram creates the program and replaces its imports with the addresses on the current network.
+func (f *Flowkit) resolveProgram(state *State, script Script) (*project.Program, error) {
+	program, err := project.NewProgram(script.Code, script.Args, script.Location)
+	if err != nil {
+		return nil, err
+	}
+
+	if !program.HasImports() {
+		return program, nil
+	}
+
+	contracts, err := state.DeploymentContractsByNetwork(f.network)
+	if err != nil {
+		return nil, err
+	}
+
+	importReplacer := project.NewImportReplacer(
+		contracts,
+		state.AliasesForNetwork(f.network),
+	)
+
+	return importReplacer.Replace(program)
+}
+
 var errUpdateNoDiff = errors.New("contract already exists and is the same as the contract provided for update")
 
 type UpdateContract func(existing []byte, new []byte) bool
@@ -270,28 +304,11 @@ func (f *Flowkit) AddContract(
 		return flow.EmptyID, false, err
 	}
 
-	program, err := project.NewProgram(contract.Code, contract.Args, contract.Location)
+	program, err := f.resolveProgram(state, contract)
 	if err != nil {
 		return flow.EmptyID, false, err
 	}
 
-	if program.HasImports() {
-		contracts, err := state.DeploymentContractsByNetwork(f.network)
-		if err != nil {
-			return flow.EmptyID, false, err
-		}
-
-		importReplacer := project.NewImportReplacer(
-			contracts,
-			state.AliasesForNetwork(f.network),
-		)
-
-		program, err = importReplacer.Replace(program)
-		if err != nil {
-			return flow.EmptyID, false, err
-		}
-	}
-
 	name, err := program.Name()
 	if err != nil {
 		return flow.EmptyID, false, err
@@ -359,7 +376,7 @@ func (f *Flowkit) AddContract(
 		return tx.FlowTransaction().ID(), false, trx.Error
 	}
 
-	d := state.Deployments().ByAccountAndNetwork(account.Name, f.network.Name)
+	d := state.DeploymentByAccountAndNetwork(account.Name, f.network.Name)
 	if d != nil {
 		d.AddContract(config.ContractDeployment{
 			Name: name,
@@ -466,9 +483,10 @@ func (f *Flowkit) GetCollection(_ context.Context, ID flow.Identifier) (*flow.Co
 // and how many blocks between the provided interval each worker fetches.
 //
 // Providing worker value will produce faster response as the interval will be scanned concurrently. This parameter is optional,
-// if not provided only a single worker will be used.
+// if not provided only a single worker will be used. The blocks fetched by each worker are limited to MaxEventBlockRange,
+// so large intervals are split into multiple requests and the returned events keep the order of the interval.
 func (f *Flowkit) GetEvents(
-	_ context.Context,
+	ctx context.Context,
 	names []string,
 	startHeight uint64,
 	endHeight uint64,
@@ -481,22 +499,37 @@ func (f *Flowkit) GetEvents(
 	if worker == nil { // if no worker is passed, create a default one
 		worker = &EventWorker{
 			Count:           1,
-			BlocksPerWorker: 250,
+			BlocksPerWorker: MaxEventBlockRange,
 		}
 	}
 
-	queries := makeEventQueries(names, startHeight, endHeight, worker.BlocksPerWorker)
+	workerCount := worker.Count
+	if workerCount < 1 {
+		workerCount = 1
+	}
+	blockCount := worker.BlocksPerWorker
+	if blockCount == 0 || blockCount > MaxEventBlockRange {
+		blockCount = MaxEventBlockRange
+	}
+
+	queries := makeEventQueries(names, startHeight, endHeight, blockCount)
+	if worker.Progress != nil {
+		worker.Progress(0, len(queries))
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel() // stops the workers if we return early because of an error
 
-	jobChan := make(chan grpc.EventRangeQuery, worker.Count)
+	jobChan := make(chan eventWorkerJob, workerCount)
 	results := make(chan eventWorkerResult)
 
 	var wg sync.WaitGroup
 
-	for i := 0; i < worker.Count; i++ {
+	for i := 0; i < workerCount; i++ {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			f.eventWorker(jobChan, results)
+			f.eventWorker(ctx, jobChan, results)
 		}()
 	}
 
@@ -509,34 +542,61 @@ func (f *Flowkit) GetEvents(
 
 	go func() {
 		defer close(jobChan)
-		for _, query := range queries {
-			jobChan <- query
+		for i, query := range queries {
+			select {
+			case jobChan <- eventWorkerJob{index: i, query: query}:
+			case <-ctx.Done():
+				return
+			}
 		}
 	}()
 
-	var resultEvents []flow.BlockEvents
+	queryEvents := make([][]flow.BlockEvents, len(queries))
+	done := 0
 	for eventResult := range results {
 		if eventResult.err != nil {
 			return nil, eventResult.err
 		}
 
-		resultEvents = append(resultEvents, eventResult.events...)
+		queryEvents[eventResult.index] = eventResult.events
+		done++
+		if worker.Progress != nil {
+			worker.Progress(done, len(queries))
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var resultEvents []flow.BlockEvents
+	for _, events := range queryEvents {
+		resultEvents = append(resultEvents, events...)
 	}
 
 	return resultEvents, nil
 }
 
-func (f *Flowkit) eventWorker(jobChan <-chan grpc.EventRangeQuery, results chan<- eventWorkerResult) {
-	for q := range jobChan {
+func (f *Flowkit) eventWorker(ctx context.Context, jobChan <-chan eventWorkerJob, results chan<- eventWorkerResult) {
+	for job := range jobChan {
+		q := job.query
 		blockEvents, err := f.gateway.GetEvents(q.Type, q.StartHeight, q.EndHeight)
-		if err != nil {
-			results <- eventWorkerResult{nil, err}
+
+		select {
+		case results <- eventWorkerResult{index: job.index, events: blockEvents, err: err}:
+		case <-ctx.Done():
+			return
 		}
-		results <- eventWorkerResult{blockEvents, nil}
 	}
 }
 
+type eventWorkerJob struct {
+	index int
+	query grpc.EventRangeQuery
+}
+
 type eventWorkerResult struct {
+	index  int
 	events []flow.BlockEvents
 	err    error
 }
@@ -688,6 +748,19 @@ func (f *Flowkit) derivePrivateKeyFromSeed(
 // the imports in the contract source, so it corresponds to the account name the contract was deployed to.
 // If contracts already exist use UpdateExistingContract(bool) to define whether a contract should be updated or not.
 func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*project.Contract, error) {
+	return f.DeployProjectParallel(ctx, update, 1)
+}
+
+// DeployProjectParallel deploys the project contracts like DeployProject, but deploys contracts of different
+// accounts concurrently to at most maxParallel accounts at a time.
+//
+// A contract is only deployed after all the contracts it imports are deployed, and is not deployed if any of them
+// failed to deploy. Values lower than 2 deploy contracts one by one.
+func (f *Flowkit) DeployProjectParallel(
+	ctx context.Context,
+	update UpdateContract,
+	maxParallel int,
+) ([]*project.Contract, error) {
 	state, err := f.State()
 	if err != nil {
 		return nil, err
@@ -703,6 +776,11 @@ func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*
 		return nil, err
 	}
 
+	err = deployment.ValidateArguments()
+	if err != nil {
+		return nil, err
+	}
+
 	sorted, err := deployment.Sort()
 	if err != nil {
 		return nil, err
@@ -715,46 +793,246 @@ func (f *Flowkit) DeployProject(ctx context.Context, update UpdateContract) ([]*
 	))
 	defer f.logger.StopProgress()
 
-	deployErr := &ProjectDeploymentError{}
+	targets := make(map[string]*accounts.Account)
 	for _, contract := range sorted {
-		targetAccount, err := state.Accounts().ByName(contract.AccountName)
+		targets[contract.AccountName], err = state.Accounts().ByName(contract.AccountName)
 		if err != nil {
 			return nil, fmt.Errorf("target account for deploying contract not found in configuration")
 		}
+	}
 
-		txID, updated, err := f.AddContract(
-			ctx,
-			targetAccount,
-			Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()},
-			update,
-		)
-		if err != nil && errors.Is(err, errUpdateNoDiff) {
-			f.logger.Info(fmt.Sprintf(
-				"%s -> 0x%s [skipping, no changes found]",
-				output.Italic(contract.Name),
-				contract.AccountAddress.String(),
-			))
-			continue
-		} else if err != nil {
-			deployErr.add(contract, err, fmt.Sprintf("failed to deploy contract %s", contract.Name))
-			continue
+	d := &projectDeployer{
+		flowkit:   f,
+		state:     state,
+		targets:   targets,
+		update:    update,
+		deployErr: &ProjectDeploymentError{},
+		progress:  output.LoggerProgress(f.logger, "Deploying contracts"),
+		total:     len(sorted),
+	}
+	d.progress(0, d.total)
+
+	if maxParallel < 2 {
+		onChain := make(map[flow.Address]map[string][]byte)
+		for _, contract := range sorted {
+			if !d.skipFailedImport(contract, deployment.Dependencies(contract)) {
+				d.deploy(ctx, contract, onChain)
+			}
+		}
+	} else {
+		d.deployParallel(ctx, deployment, sorted, maxParallel)
+	}
+
+	if len(d.deployErr.contracts) > 0 {
+		return nil, d.deployErr
+	}
+
+	f.logger.Info(fmt.Sprintf("\nContracts added: %d, updated: %d, skipped: %d", d.added, d.updated, d.skipped))
+	f.logger.Info(fmt.Sprintf("\n%s All contracts deployed successfully", output.SuccessEmoji()))
+	return sorted, nil
+}
+
+// projectDeployer deploys the project contracts and collects the deployment results.
+type projectDeployer struct {
+	flowkit *Flowkit
+	state   *State
+	targets map[string]*accounts.Account // target accounts by name
+	update  UpdateContract
+
+	progress output.ProgressFunc
+	total    int // number of contracts to deploy
+
+	mu                      sync.Mutex // guards the results and update prompts when deploying in parallel
+	deployErr               *ProjectDeploymentError
+	failed                  map[*project.Contract]bool
+	added, updated, skipped int
+	finished                int
+}
+
+// deployParallel deploys the contracts of each account in a separate worker, in the sorted order.
+//
+// Each account uses its own proposer sequence number, so transactions of different accounts don't conflict.
+// Contracts wait for the contracts they import from other accounts to be deployed first, and are not deployed
+// if any of the imported contracts failed.
+func (d *projectDeployer) deployParallel(
+	ctx context.Context,
+	deployment *project.Deployment,
+	sorted []*project.Contract,
+	maxParallel int,
+) {
+	done := make(map[*project.Contract]chan struct{}, len(sorted))
+	accountOrder := make([]string, 0)
+	byAccount := make(map[string][]*project.Contract)
+	for _, contract := range sorted {
+		done[contract] = make(chan struct{})
+		if _, ok := byAccount[contract.AccountName]; !ok {
+			accountOrder = append(accountOrder, contract.AccountName)
 		}
+		byAccount[contract.AccountName] = append(byAccount[contract.AccountName], contract)
+	}
+
+	// update prompts must not run concurrently
+	update := d.update
+	d.update = func(existing []byte, new []byte) bool {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		return update(existing, new)
+	}
 
+	slots := make(chan struct{}, maxParallel)
+	var wg sync.WaitGroup
+	for _, name := range accountOrder {
+		wg.Add(1)
+		go func(contracts []*project.Contract) {
+			defer wg.Done()
+			onChain := make(map[flow.Address]map[string][]byte)
+			for _, contract := range contracts {
+				deps := deployment.Dependencies(contract)
+				for _, dep := range deps {
+					<-done[dep]
+				}
+
+				if !d.skipFailedImport(contract, deps) {
+					slots <- struct{}{}
+					d.deploy(ctx, contract, onChain)
+					<-slots
+				}
+				close(done[contract])
+			}
+		}(byAccount[name])
+	}
+
+	wg.Wait()
+}
+
+// deploy the contract unless the same code is already deployed and record the result.
+func (d *projectDeployer) deploy(ctx context.Context, contract *project.Contract, onChain map[flow.Address]map[string][]byte) {
+	f := d.flowkit
+	defer d.step()
+	script := Script{Code: contract.Code(), Args: contract.Args, Location: contract.Location()}
+
+	unchanged, err := f.contractUnchanged(d.state, contract.AccountAddress, script, onChain)
+	if err != nil {
+		d.fail(contract, err, fmt.Sprintf("failed to check contract %s", contract.Name))
+		return
+	}
+
+	var txID flow.Identifier
+	var isUpdate bool
+	if !unchanged {
+		txID, isUpdate, err = f.AddContract(ctx, d.targets[contract.AccountName], script, d.update)
+	}
+	if unchanged || errors.Is(err, errUpdateNoDiff) {
+		d.mu.Lock()
+		d.skipped++
+		d.mu.Unlock()
 		f.logger.Info(fmt.Sprintf(
-			"%s -> 0x%s (%s) %s",
-			output.Green(contract.Name),
-			contract.AccountAddress,
-			txID.String(),
-			map[bool]string{true: "[updated]", false: ""}[updated],
+			"%s -> 0x%s [skipping, no changes found]",
+			output.Italic(contract.Name),
+			contract.AccountAddress.String(),
 		))
+		return
+	} else if err != nil {
+		d.fail(contract, err, fmt.Sprintf("failed to deploy contract %s", contract.Name))
+		return
 	}
 
-	if len(deployErr.contracts) > 0 {
-		return nil, deployErr
+	contract.TransactionID = txID
+	d.mu.Lock()
+	if isUpdate {
+		d.updated++
+	} else {
+		d.added++
 	}
+	d.mu.Unlock()
 
-	f.logger.Info(fmt.Sprintf("\n%s All contracts deployed successfully", output.SuccessEmoji()))
-	return sorted, nil
+	f.logger.Info(fmt.Sprintf(
+		"%s -> 0x%s (%s) %s",
+		output.Green(contract.Name),
+		contract.AccountAddress,
+		txID.String(),
+		map[bool]string{true: "[updated]", false: ""}[isUpdate],
+	))
+}
+
+// step reports the progress after a contract deployment finished.
+func (d *projectDeployer) step() {
+	d.mu.Lock()
+	d.finished++
+	finished := d.finished
+	d.mu.Unlock()
+
+	d.progress(finished, d.total)
+}
+
+func (d *projectDeployer) fail(contract *project.Contract, err error, msg string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.deployErr.add(contract, err, msg)
+	if d.failed == nil {
+		d.failed = make(map[*project.Contract]bool)
+	}
+	d.failed[contract] = true
+}
+
+// skipFailedImport records the contract as failed without deploying it if any of its dependencies failed to deploy.
+func (d *projectDeployer) skipFailedImport(contract *project.Contract, deps []*project.Contract) bool {
+	dep := d.failedDependency(deps)
+	if dep == nil {
+		return false
+	}
+
+	d.fail(
+		contract,
+		fmt.Errorf("imported contract %s failed to deploy", dep.Name),
+		fmt.Sprintf("skipped deploying contract %s", contract.Name),
+	)
+	d.step()
+	return true
+}
+
+// failedDependency returns the first of the dependencies that failed to deploy, or nil if none failed.
+func (d *projectDeployer) failedDependency(deps []*project.Contract) *project.Contract {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for _, dep := range deps {
+		if d.failed[dep] {
+			return dep
+		}
+	}
+	return nil
+}
+
+// contractUnchanged checks whether the hash of the resolved contract code matches the hash of the code
+// already deployed on the account, on-chain contracts are fetched once per account and cached.
+func (f *Flowkit) contractUnchanged(
+	state *State,
+	address flow.Address,
+	script Script,
+	onChain map[flow.Address]map[string][]byte,
+) (bool, error) {
+	program, err := f.resolveProgram(state, script)
+	if err != nil {
+		return false, err
+	}
+
+	name, err := program.Name()
+	if err != nil {
+		return false, err
+	}
+
+	contracts, ok := onChain[address]
+	if !ok {
+		account, err := f.gateway.GetAccount(address)
+		if err != nil {
+			return false, err
+		}
+		contracts = account.Contracts
+		onChain[address] = contracts
+	}
+
+	existing, exists := contracts[name]
+	return exists && sha3.Sum256(existing) == sha3.Sum256(program.Code()), nil
 }
 
 type ProjectDeploymentError struct {
@@ -816,10 +1094,10 @@ func (f *Flowkit) ExecuteScript(_ context.Context, script Script, query ScriptQu
 		if state == nil {
 			return nil, config.ErrDoesNotExist
 		}
-		if f.network == config.EmptyNetwork {
+		if f.network.Name == config.EmptyNetwork.Name {
 			return nil, fmt.Errorf("missing network, specify which network to use to resolve imports in script code")
 		}
-		if script.Location == "" {
+		if script.Location == "" && program.HasPathImports() { // path imports are resolved relative to the location
 			return nil, fmt.Errorf("resolving imports in scripts not supported")
 		}
 
@@ -912,10 +1190,10 @@ func (f *Flowkit) BuildTransaction(
 	}
 
 	if program.HasImports() {
-		if f.network == config.EmptyNetwork {
+		if f.network.Name == config.EmptyNetwork.Name {
 			return nil, fmt.Errorf("missing network, specify which network to use to resolve imports in transaction code")
 		}
-		if script.Location == "" { // when used as lib with code we don't support imports
+		if script.Location == "" && program.HasPathImports() { // when used as lib with code we only support imports by name
 			return nil, fmt.Errorf("resolving imports in transactions not supported")
 		}
 
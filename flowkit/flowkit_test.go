@@ -24,7 +24,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/transactions"
@@ -38,6 +40,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/slices"
 
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/gateway"
@@ -765,6 +768,40 @@ func TestEvents(t *testing.T) {
 		assert.EqualError(t, err, "failed getting event")
 	})
 
+	t.Run("Should split large ranges and report progress", func(t *testing.T) {
+		t.Parallel()
+
+		_, flowkit, gw := setup()
+		flowkit.gateway = rangeEventsGateway{gw.Mock}
+
+		var progress []int
+		events, err := flowkit.GetEvents(ctx, []string{"flow.CreateAccount"}, 0, 999, &EventWorker{
+			Count:           4,
+			BlocksPerWorker: 1000,
+			Progress: func(done int, total int) {
+				assert.Equal(t, 4, total)
+				progress = append(progress, done)
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, progress)
+		require.Len(t, events, 4)
+		for i, e := range events {
+			assert.Equal(t, uint64(i*250), e.Height)
+		}
+	})
+}
+
+// rangeEventsGateway returns a block event at the start of every requested range,
+// answering later ranges first so results arrive out of order.
+type rangeEventsGateway struct {
+	*mocks.Gateway
+}
+
+func (g rangeEventsGateway) GetEvents(_ string, start uint64, _ uint64) ([]flow.BlockEvents, error) {
+	time.Sleep(time.Duration(1000-start) * time.Microsecond)
+	return []flow.BlockEvents{{Height: start}}, nil
 }
 
 func TestEvents_Integration(t *testing.T) {
@@ -1201,6 +1238,118 @@ func TestProject(t *testing.T) {
 
 }
 
+func TestProject_SkipUnchanged(t *testing.T) {
+	state, flowkit, gw := setup()
+
+	c := config.Contract{
+		Name:     tests.ContractHelloString.Name,
+		Location: tests.ContractHelloString.Filename,
+	}
+	state.Contracts().AddOrUpdate(c)
+	state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+	acct := Donald()
+	state.Accounts().AddOrUpdate(acct)
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   acct.Name,
+		Contracts: []config.ContractDeployment{{Name: c.Name}},
+	})
+
+	gw.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(acct.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractHelloString.Name: tests.ContractHelloString.Source,
+		}
+		gw.GetAccount.Return(account, nil)
+	})
+
+	contracts, err := flowkit.DeployProject(ctx, UpdateExistingContract(true))
+	require.NoError(t, err)
+	assert.Len(t, contracts, 1)
+	gw.Mock.AssertNumberOfCalls(t, mocks.GetAccountFunc, 1)
+	gw.Mock.AssertNotCalled(t, mocks.SendSignedTransactionFunc)
+}
+
+// setupParallelDeployment configures contracts of three accounts, where the contract of alice imports the contract of donald.
+func setupParallelDeployment() (*State, Flowkit, *mocks.TestGateway, *accounts.Account, *accounts.Account) {
+	state, flowkit, gw := setup()
+	state.Networks().AddOrUpdate(config.EmulatorNetwork)
+
+	donald, alice, bob := Donald(), Alice(), Bob()
+	deployments := map[*accounts.Account]tests.Resource{
+		donald: tests.ContractA,
+		alice:  tests.ContractB, // imports ContractA deployed to donald
+		bob:    tests.ContractHelloString,
+	}
+	for acct, res := range deployments {
+		state.Contracts().AddOrUpdate(config.Contract{Name: res.Name, Location: res.Filename})
+		state.Accounts().AddOrUpdate(acct)
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   acct.Name,
+			Contracts: []config.ContractDeployment{{Name: res.Name}},
+		})
+	}
+
+	return state, flowkit, gw, donald, alice
+}
+
+func TestProject_DeployParallel(t *testing.T) {
+	_, flowkit, gw, donald, alice := setupParallelDeployment()
+
+	var mu sync.Mutex
+	payers := make([]flow.Address, 0)
+	gw.SendSignedTransaction.Run(func(args mock.Arguments) {
+		mu.Lock()
+		payers = append(payers, args.Get(0).(*flow.Transaction).Payer)
+		mu.Unlock()
+		gw.SendSignedTransaction.Return(tests.NewTransaction(), nil)
+	})
+
+	contracts, err := flowkit.DeployProjectParallel(ctx, UpdateExistingContract(false), 3)
+	require.NoError(t, err)
+	assert.Len(t, contracts, 3)
+	gw.Mock.AssertNumberOfCalls(t, mocks.GetTransactionResultFunc, 3)
+
+	require.Len(t, payers, 3)
+	assert.Less(t, slices.Index(payers, donald.Address), slices.Index(payers, alice.Address))
+}
+
+func TestProject_DeployParallelFailedDependency(t *testing.T) {
+	_, flowkit, gw, donald, alice := setupParallelDeployment()
+
+	var mu sync.Mutex
+	payers := make([]flow.Address, 0)
+	gw.SendSignedTransaction.Run(func(mock.Arguments) {}).Return(func(tx *flow.Transaction) (*flow.Transaction, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		payers = append(payers, tx.Payer)
+		if tx.Payer == donald.Address {
+			return nil, fmt.Errorf("failed sending transaction")
+		}
+		return tests.NewTransaction(), nil
+	}, nil)
+
+	for _, maxParallel := range []int{1, 3} {
+		payers = payers[:0]
+
+		_, err := flowkit.DeployProjectParallel(ctx, UpdateExistingContract(false), maxParallel)
+		var deployErr *ProjectDeploymentError
+		require.ErrorAs(t, err, &deployErr)
+		require.Len(t, deployErr.Contracts(), 2)
+		assert.ErrorContains(t, deployErr.Contracts()[tests.ContractA.Name], "failed to deploy contract ContractA")
+		assert.EqualError(
+			t,
+			deployErr.Contracts()[tests.ContractB.Name],
+			"skipped deploying contract ContractB: imported contract ContractA failed to deploy",
+		)
+
+		assert.Len(t, payers, 2)
+		assert.NotContains(t, payers, alice.Address)
+	}
+}
+
 // used for integration tests
 func simpleDeploy(state *State, flowkit Flowkit, update bool) ([]*project.Contract, error) {
 	srvAcc, _ := state.EmulatorServiceAccount()
@@ -1432,6 +1581,47 @@ func TestScripts_Integration(t *testing.T) {
 		assert.Equal(t, res.String(), "\"Hello Hello, World!\"")
 	})
 
+	t.Run("Execute With Identifier Imports", func(t *testing.T) {
+		t.Parallel()
+		state, flowkit := setupIntegration()
+		srvAcc, _ := state.EmulatorServiceAccount()
+
+		c := config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+		}
+		state.Contracts().AddOrUpdate(c)
+		state.Networks().AddOrUpdate(config.EmulatorNetwork)
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   srvAcc.Name,
+			Contracts: []config.ContractDeployment{{Name: c.Name}},
+		})
+		_, _, _ = flowkit.AddContract(
+			ctx,
+			srvAcc,
+			resourceToContract(tests.ContractHelloString),
+			UpdateExistingContract(false),
+		)
+
+		// imports by name don't require the script location
+		res, err := flowkit.ExecuteScript(
+			ctx,
+			Script{
+				Code: []byte(`
+					import "Hello"
+
+					pub fun main(): String {
+						return "Hello ".concat(Hello.greeting)
+					}
+				`),
+			},
+			LatestScriptQuery,
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, res.String(), "\"Hello Hello, World!\"")
+	})
+
 	t.Run("Execute Script Invalid", func(t *testing.T) {
 		t.Parallel()
 		_, flowkit := setupIntegration()
@@ -0,0 +1,76 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Gateway = &ArchiveGateway{}
+
+// ArchiveGateway is a gateway executing scripts at past blocks on an archive access node.
+//
+// Access nodes only keep a limited history of the execution state,
+// so scripts at past blocks are sent to the archive node and all the other calls to the wrapped gateway.
+type ArchiveGateway struct {
+	Gateway
+	archive Gateway
+}
+
+// NewArchiveGateway returns a gateway wrapping the gateway with a connection to the network archive host.
+func NewArchiveGateway(gateway Gateway, network config.Network) (*ArchiveGateway, error) {
+	archiveNetwork := network
+	archiveNetwork.Host = network.ArchiveHost
+	archiveNetwork.Key = ""
+	archiveNetwork.FallbackHosts = nil
+	archiveNetwork.ArchiveHost = ""
+	if IsHTTPHost(archiveNetwork.Host) {
+		archiveNetwork.TLS = config.TLSConfig{}
+	}
+
+	archive, err := NewHostGateway(archiveNetwork)
+	if err != nil {
+		return nil, err
+	}
+
+	return newArchiveGateway(gateway, archive), nil
+}
+
+func newArchiveGateway(gateway Gateway, archive Gateway) *ArchiveGateway {
+	return &ArchiveGateway{
+		Gateway: gateway,
+		archive: archive,
+	}
+}
+
+// Unwrap returns the wrapped gateway.
+func (g *ArchiveGateway) Unwrap() Gateway {
+	return g.Gateway
+}
+
+func (g *ArchiveGateway) ExecuteScriptAtHeight(script []byte, args []cadence.Value, height uint64) (cadence.Value, error) {
+	return g.archive.ExecuteScriptAtHeight(script, args, height)
+}
+
+func (g *ArchiveGateway) ExecuteScriptAtID(script []byte, args []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return g.archive.ExecuteScriptAtID(script, args, ID)
+}
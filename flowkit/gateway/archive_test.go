@@ -0,0 +1,78 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+func Test_ArchiveGateway(t *testing.T) {
+	script := []byte(`access(all) fun main(): Int { return 1 }`)
+	block := &flow.Block{BlockHeader: flow.BlockHeader{Height: 10}}
+
+	t.Run("Execute past scripts on archive", func(t *testing.T) {
+		gw := &mocks.Gateway{}
+		archive := &mocks.Gateway{}
+		gw.On("ExecuteScript", script, []cadence.Value(nil)).Return(cadence.NewInt(1), nil).Once()
+		gw.On("GetLatestBlock").Return(block, nil).Once()
+		archive.On("ExecuteScriptAtHeight", script, []cadence.Value(nil), uint64(5)).Return(cadence.NewInt(2), nil).Once()
+		archive.On("ExecuteScriptAtID", script, []cadence.Value(nil), flow.EmptyID).Return(cadence.NewInt(3), nil).Once()
+
+		archiveGateway := newArchiveGateway(gw, archive)
+
+		value, err := archiveGateway.ExecuteScript(script, nil)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(1), value)
+
+		value, err = archiveGateway.ExecuteScriptAtHeight(script, nil, 5)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(2), value)
+
+		value, err = archiveGateway.ExecuteScriptAtID(script, nil, flow.EmptyID)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(3), value)
+
+		latest, err := archiveGateway.GetLatestBlock()
+		require.NoError(t, err)
+		assert.Equal(t, block, latest)
+
+		gw.AssertExpectations(t)
+		archive.AssertExpectations(t)
+	})
+
+	t.Run("Create archive gateway", func(t *testing.T) {
+		gw := &mocks.Gateway{}
+		archiveGateway, err := NewArchiveGateway(gw, config.Network{
+			Name:        "mainnet",
+			Host:        "access.mainnet.nodes.onflow.org:9000",
+			ArchiveHost: "https://rest-archive.example.com",
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &HTTPGateway{}, archiveGateway.archive)
+		assert.Equal(t, gw, archiveGateway.Unwrap())
+	})
+}
@@ -0,0 +1,440 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Gateway = &CachedGateway{}
+
+// CachedGateway is a gateway caching the responses of the read-only calls.
+//
+// Blocks, collections, events and scripts at past blocks are cached by their height or ID,
+// accounts are cached until a transaction is sent by the account. Responses expire after the TTL,
+// and are also persisted in the cache directory so they can be reused by later commands.
+type CachedGateway struct {
+	Gateway
+	cache *responseCache
+	host  string
+}
+
+// NewCachedGateway returns a gateway caching the responses of the gateway for the network.
+//
+// Responses are only cached in memory if the directory is empty.
+func NewCachedGateway(gateway Gateway, network config.Network, ttl time.Duration, dir string) *CachedGateway {
+	return &CachedGateway{
+		Gateway: gateway,
+		cache:   newResponseCache(ttl, dir),
+		host:    network.Host,
+	}
+}
+
+// Unwrap returns the wrapped gateway.
+func (g *CachedGateway) Unwrap() Gateway {
+	return g.Gateway
+}
+
+// key returns the cache key of the call on the network host.
+func (g *CachedGateway) key(method string, args ...any) string {
+	hash := sha256.Sum256([]byte(fmt.Sprint(append([]any{g.host, method}, args...)...)))
+	return hex.EncodeToString(hash[:])
+}
+
+// codec encodes cached responses so they can be persisted.
+type codec[T any] struct {
+	encode func(T) ([]byte, error)
+	decode func([]byte) (T, error)
+}
+
+func jsonCodec[T any]() codec[T] {
+	return codec[T]{
+		encode: func(value T) ([]byte, error) {
+			return json.Marshal(value)
+		},
+		decode: func(data []byte) (T, error) {
+			var value T
+			err := json.Unmarshal(data, &value)
+			return value, err
+		},
+	}
+}
+
+var valueCodec = codec[cadence.Value]{
+	encode: func(value cadence.Value) ([]byte, error) {
+		return jsoncdc.Encode(value)
+	},
+	decode: func(data []byte) (cadence.Value, error) {
+		return jsoncdc.Decode(nil, data)
+	},
+}
+
+// cached returns the cached response for the key or fetches and caches it.
+func cached[T any](g *CachedGateway, key string, codec codec[T], fetch func() (T, error)) (T, error) {
+	if data, ok := g.cache.get(key); ok {
+		value, err := codec.decode(data)
+		if err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := fetch()
+	if err != nil {
+		return value, err
+	}
+
+	if data, err := codec.encode(value); err == nil {
+		g.cache.set(key, data)
+	}
+
+	return value, nil
+}
+
+func (g *CachedGateway) GetAccount(address flow.Address) (*flow.Account, error) {
+	return cached(g, g.key("GetAccount", address), accountCodec, func() (*flow.Account, error) {
+		return g.Gateway.GetAccount(address)
+	})
+}
+
+// SendSignedTransaction sends the transaction and removes the cached accounts of the transaction signers,
+// so the updated keys sequence numbers and balances are fetched.
+func (g *CachedGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
+	g.cache.remove(g.key("GetAccount", tx.ProposalKey.Address))
+	g.cache.remove(g.key("GetAccount", tx.Payer))
+	for _, authorizer := range tx.Authorizers {
+		g.cache.remove(g.key("GetAccount", authorizer))
+	}
+
+	return g.Gateway.SendSignedTransaction(tx)
+}
+
+func (g *CachedGateway) ExecuteScriptAtHeight(script []byte, args []cadence.Value, height uint64) (cadence.Value, error) {
+	key, err := g.scriptKey("ExecuteScriptAtHeight", script, args, height)
+	if err != nil {
+		return g.Gateway.ExecuteScriptAtHeight(script, args, height)
+	}
+
+	return cached(g, key, valueCodec, func() (cadence.Value, error) {
+		return g.Gateway.ExecuteScriptAtHeight(script, args, height)
+	})
+}
+
+func (g *CachedGateway) ExecuteScriptAtID(script []byte, args []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	key, err := g.scriptKey("ExecuteScriptAtID", script, args, ID)
+	if err != nil {
+		return g.Gateway.ExecuteScriptAtID(script, args, ID)
+	}
+
+	return cached(g, key, valueCodec, func() (cadence.Value, error) {
+		return g.Gateway.ExecuteScriptAtID(script, args, ID)
+	})
+}
+
+// scriptKey returns the cache key of the script execution with the encoded arguments.
+func (g *CachedGateway) scriptKey(method string, script []byte, args []cadence.Value, block any) (string, error) {
+	encoded := make([]string, len(args))
+	for i, arg := range args {
+		b, err := jsoncdc.Encode(arg)
+		if err != nil {
+			return "", err
+		}
+		encoded[i] = string(b)
+	}
+
+	return g.key(method, string(script), encoded, block), nil
+}
+
+func (g *CachedGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
+	return cached(g, g.key("GetBlockByHeight", height), jsonCodec[*flow.Block](), func() (*flow.Block, error) {
+		return g.Gateway.GetBlockByHeight(height)
+	})
+}
+
+func (g *CachedGateway) GetBlockByID(ID flow.Identifier) (*flow.Block, error) {
+	return cached(g, g.key("GetBlockByID", ID), jsonCodec[*flow.Block](), func() (*flow.Block, error) {
+		return g.Gateway.GetBlockByID(ID)
+	})
+}
+
+func (g *CachedGateway) GetCollection(ID flow.Identifier) (*flow.Collection, error) {
+	return cached(g, g.key("GetCollection", ID), jsonCodec[*flow.Collection](), func() (*flow.Collection, error) {
+		return g.Gateway.GetCollection(ID)
+	})
+}
+
+func (g *CachedGateway) GetEvents(eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	key := g.key("GetEvents", eventType, startHeight, endHeight)
+	return cached(g, key, eventsCodec, func() ([]flow.BlockEvents, error) {
+		return g.Gateway.GetEvents(eventType, startHeight, endHeight)
+	})
+}
+
+type cachedAccountKey struct {
+	Index          int
+	PublicKey      []byte
+	SigAlgo        crypto.SignatureAlgorithm
+	HashAlgo       crypto.HashAlgorithm
+	Weight         int
+	SequenceNumber uint64
+	Revoked        bool
+}
+
+type cachedAccount struct {
+	Address   flow.Address
+	Balance   uint64
+	Code      []byte
+	Keys      []cachedAccountKey
+	Contracts map[string][]byte
+}
+
+var accountCodec = codec[*flow.Account]{
+	encode: func(account *flow.Account) ([]byte, error) {
+		keys := make([]cachedAccountKey, len(account.Keys))
+		for i, key := range account.Keys {
+			keys[i] = cachedAccountKey{
+				Index:          key.Index,
+				PublicKey:      key.PublicKey.Encode(),
+				SigAlgo:        key.SigAlgo,
+				HashAlgo:       key.HashAlgo,
+				Weight:         key.Weight,
+				SequenceNumber: key.SequenceNumber,
+				Revoked:        key.Revoked,
+			}
+		}
+
+		return json.Marshal(cachedAccount{
+			Address:   account.Address,
+			Balance:   account.Balance,
+			Code:      account.Code,
+			Keys:      keys,
+			Contracts: account.Contracts,
+		})
+	},
+	decode: func(data []byte) (*flow.Account, error) {
+		var account cachedAccount
+		err := json.Unmarshal(data, &account)
+		if err != nil {
+			return nil, err
+		}
+
+		keys := make([]*flow.AccountKey, len(account.Keys))
+		for i, key := range account.Keys {
+			publicKey, err := crypto.DecodePublicKey(key.SigAlgo, key.PublicKey)
+			if err != nil {
+				return nil, err
+			}
+
+			keys[i] = &flow.AccountKey{
+				Index:          key.Index,
+				PublicKey:      publicKey,
+				SigAlgo:        key.SigAlgo,
+				HashAlgo:       key.HashAlgo,
+				Weight:         key.Weight,
+				SequenceNumber: key.SequenceNumber,
+				Revoked:        key.Revoked,
+			}
+		}
+
+		return &flow.Account{
+			Address:   account.Address,
+			Balance:   account.Balance,
+			Code:      account.Code,
+			Keys:      keys,
+			Contracts: account.Contracts,
+		}, nil
+	},
+}
+
+type cachedEvent struct {
+	Type             string
+	TransactionID    flow.Identifier
+	TransactionIndex int
+	EventIndex       int
+	Value            json.RawMessage
+	Payload          []byte
+}
+
+type cachedBlockEvents struct {
+	BlockID        flow.Identifier
+	Height         uint64
+	BlockTimestamp time.Time
+	Events         []cachedEvent
+}
+
+var eventsCodec = codec[[]flow.BlockEvents]{
+	encode: func(blockEvents []flow.BlockEvents) ([]byte, error) {
+		cachedEvents := make([]cachedBlockEvents, len(blockEvents))
+		for i, block := range blockEvents {
+			events := make([]cachedEvent, len(block.Events))
+			for j, event := range block.Events {
+				value, err := jsoncdc.Encode(event.Value)
+				if err != nil {
+					return nil, err
+				}
+
+				events[j] = cachedEvent{
+					Type:             event.Type,
+					TransactionID:    event.TransactionID,
+					TransactionIndex: event.TransactionIndex,
+					EventIndex:       event.EventIndex,
+					Value:            value,
+					Payload:          event.Payload,
+				}
+			}
+
+			cachedEvents[i] = cachedBlockEvents{
+				BlockID:        block.BlockID,
+				Height:         block.Height,
+				BlockTimestamp: block.BlockTimestamp,
+				Events:         events,
+			}
+		}
+
+		return json.Marshal(cachedEvents)
+	},
+	decode: func(data []byte) ([]flow.BlockEvents, error) {
+		var cachedEvents []cachedBlockEvents
+		err := json.Unmarshal(data, &cachedEvents)
+		if err != nil {
+			return nil, err
+		}
+
+		blockEvents := make([]flow.BlockEvents, len(cachedEvents))
+		for i, block := range cachedEvents {
+			events := make([]flow.Event, len(block.Events))
+			for j, event := range block.Events {
+				value, err := jsoncdc.Decode(nil, event.Value)
+				if err != nil {
+					return nil, err
+				}
+				eventValue, ok := value.(cadence.Event)
+				if !ok {
+					return nil, fmt.Errorf("invalid cached event value")
+				}
+
+				events[j] = flow.Event{
+					Type:             event.Type,
+					TransactionID:    event.TransactionID,
+					TransactionIndex: event.TransactionIndex,
+					EventIndex:       event.EventIndex,
+					Value:            eventValue,
+					Payload:          event.Payload,
+				}
+			}
+
+			blockEvents[i] = flow.BlockEvents{
+				BlockID:        block.BlockID,
+				Height:         block.Height,
+				BlockTimestamp: block.BlockTimestamp,
+				Events:         events,
+			}
+		}
+
+		return blockEvents, nil
+	},
+}
+
+// cacheEntry is a cached response with its expiration time.
+type cacheEntry struct {
+	Expires time.Time
+	Data    []byte
+}
+
+// responseCache stores encoded responses in memory and in files in the directory.
+type responseCache struct {
+	ttl     time.Duration
+	dir     string
+	entries map[string]cacheEntry
+	mu      sync.Mutex
+}
+
+func newResponseCache(ttl time.Duration, dir string) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		dir:     dir,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+func (c *responseCache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok && c.dir != "" {
+		data, err := os.ReadFile(filepath.Join(c.dir, key))
+		if err != nil || json.Unmarshal(data, &entry) != nil {
+			return nil, false
+		}
+		ok = true
+	}
+
+	if !ok || time.Now().After(entry.Expires) {
+		return nil, false
+	}
+
+	c.entries[key] = entry
+	return entry.Data, true
+}
+
+// set caches the data, failing to persist the data only disables reusing it in later commands.
+func (c *responseCache) set(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := cacheEntry{
+		Expires: time.Now().Add(c.ttl),
+		Data:    data,
+	}
+	c.entries[key] = entry
+
+	if c.dir == "" {
+		return
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil || os.MkdirAll(c.dir, 0755) != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(c.dir, key), b, 0644)
+}
+
+func (c *responseCache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+	if c.dir != "" {
+		_ = os.Remove(filepath.Join(c.dir, key))
+	}
+}
@@ -0,0 +1,117 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+func Test_CachedGateway(t *testing.T) {
+	network := config.Network{Name: "testnet", Host: "access.devnet.nodes.onflow.org:9000"}
+	block := &flow.Block{BlockHeader: flow.BlockHeader{ID: flow.HexToID("01"), Height: 10}}
+
+	privateKey, err := crypto.GeneratePrivateKey(crypto.ECDSA_P256, []byte("seedseedseedseedseedseedseedseedseedseed"))
+	require.NoError(t, err)
+	address := flow.HexToAddress("0x01")
+	account := &flow.Account{
+		Address: address,
+		Balance: 10,
+		Keys: []*flow.AccountKey{{
+			PublicKey:      privateKey.PublicKey(),
+			SigAlgo:        crypto.ECDSA_P256,
+			HashAlgo:       crypto.SHA3_256,
+			Weight:         flow.AccountKeyWeightThreshold,
+			SequenceNumber: 1,
+		}},
+		Contracts: map[string][]byte{},
+	}
+
+	t.Run("Cache responses in memory", func(t *testing.T) {
+		gw := &mocks.Gateway{}
+		gw.On("GetBlockByHeight", uint64(10)).Return(block, nil).Once()
+		gw.On("ExecuteScriptAtHeight", []byte("script"), []cadence.Value{cadence.NewInt(1)}, uint64(10)).
+			Return(cadence.NewInt(2), nil).Once()
+
+		cachedGateway := NewCachedGateway(gw, network, time.Minute, "")
+		for i := 0; i < 2; i++ {
+			result, err := cachedGateway.GetBlockByHeight(10)
+			require.NoError(t, err)
+			assert.Equal(t, block.ID, result.ID)
+
+			value, err := cachedGateway.ExecuteScriptAtHeight([]byte("script"), []cadence.Value{cadence.NewInt(1)}, 10)
+			require.NoError(t, err)
+			assert.Equal(t, cadence.NewInt(2), value)
+		}
+		gw.AssertExpectations(t)
+	})
+
+	t.Run("Persist responses in directory", func(t *testing.T) {
+		dir := t.TempDir()
+		gw := &mocks.Gateway{}
+		gw.On("GetAccount", address).Return(account, nil).Once()
+
+		_, err := NewCachedGateway(gw, network, time.Minute, dir).GetAccount(address)
+		require.NoError(t, err)
+
+		result, err := NewCachedGateway(gw, network, time.Minute, dir).GetAccount(address)
+		require.NoError(t, err)
+		assert.Equal(t, account.Keys[0].PublicKey.String(), result.Keys[0].PublicKey.String())
+		assert.Equal(t, account.Keys[0].SequenceNumber, result.Keys[0].SequenceNumber)
+		assert.Equal(t, account.Balance, result.Balance)
+		gw.AssertExpectations(t)
+	})
+
+	t.Run("Remove accounts after sending transaction", func(t *testing.T) {
+		gw := &mocks.Gateway{}
+		tx := flow.NewTransaction().SetProposalKey(address, 0, 1).SetPayer(address)
+		gw.On("GetAccount", address).Return(account, nil).Twice()
+		gw.On("SendSignedTransaction", tx).Return(tx, nil).Once()
+
+		cachedGateway := NewCachedGateway(gw, network, time.Minute, t.TempDir())
+		_, err := cachedGateway.GetAccount(address)
+		require.NoError(t, err)
+		_, err = cachedGateway.SendSignedTransaction(tx)
+		require.NoError(t, err)
+		_, err = cachedGateway.GetAccount(address)
+		require.NoError(t, err)
+		gw.AssertExpectations(t)
+	})
+
+	t.Run("Expire responses", func(t *testing.T) {
+		gw := &mocks.Gateway{}
+		gw.On("GetBlockByHeight", uint64(10)).Return(block, nil).Twice()
+
+		cachedGateway := NewCachedGateway(gw, network, 0, "")
+		for i := 0; i < 2; i++ {
+			_, err := cachedGateway.GetBlockByHeight(10)
+			require.NoError(t, err)
+		}
+		gw.AssertExpectations(t)
+	})
+}
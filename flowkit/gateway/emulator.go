@@ -94,6 +94,12 @@ func (g *EmulatorGateway) SetContext(ctx context.Context) {
 	g.ctx = ctx
 }
 
+func (g *EmulatorGateway) withContext(ctx context.Context) Gateway {
+	gateway := *g
+	gateway.ctx = ctx
+	return &gateway
+}
+
 func newEmulator(key *EmulatorKey, emulatorOptions ...emulator.Option) *emulator.Blockchain {
 	var opts []emulator.Option
 
@@ -0,0 +1,316 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	httpAccess "github.com/onflow/flow-go-sdk/access/http"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Gateway = &FailoverGateway{}
+
+// HostHealth contains the health of a network host as observed by the failover gateway.
+type HostHealth struct {
+	Host string
+	// Healthy is false if the last request to the host failed with a connection error or a rate-limit response.
+	Healthy bool
+	// Failures is the number of consecutive failed requests.
+	Failures  int
+	LastError error
+	LastCheck time.Time
+}
+
+// FailoverGateway is a gateway using multiple hosts of the same network.
+//
+// Requests are sent to the next host when a host can't be reached or responds with a rate-limit error,
+// hosts marked as unhealthy are tried last.
+type FailoverGateway struct {
+	gateways []Gateway
+	health   []HostHealth
+	strategy config.FailoverStrategy
+	secure   bool
+	next     int
+	mu       sync.Mutex
+}
+
+// NewFailoverGateway returns a new gateway using gRPC or REST API connections to all the network hosts.
+func NewFailoverGateway(network config.Network) (*FailoverGateway, error) {
+	hosts := network.Hosts()
+	gateways := make([]Gateway, len(hosts))
+
+	for i, host := range hosts {
+		hostNetwork := network
+		hostNetwork.Host = host
+		hostNetwork.FallbackHosts = nil
+		if IsHTTPHost(host) {
+			hostNetwork.Key = ""
+			hostNetwork.TLS = config.TLSConfig{}
+		}
+
+		gw, err := NewHostGateway(hostNetwork)
+		if err != nil {
+			return nil, err
+		}
+		gateways[i] = gw
+	}
+
+	return newFailoverGateway(hosts, gateways, network.Failover, network.Key != "" || network.TLS.Enabled), nil
+}
+
+func newFailoverGateway(
+	hosts []string,
+	gateways []Gateway,
+	strategy config.FailoverStrategy,
+	secure bool,
+) *FailoverGateway {
+	health := make([]HostHealth, len(hosts))
+	for i, host := range hosts {
+		health[i] = HostHealth{Host: host, Healthy: true}
+	}
+
+	return &FailoverGateway{
+		gateways: gateways,
+		health:   health,
+		strategy: strategy,
+		secure:   secure,
+	}
+}
+
+// Health returns the health of all the hosts in the configured order.
+func (g *FailoverGateway) Health() []HostHealth {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append([]HostHealth{}, g.health...)
+}
+
+// order returns the indexes of the hosts in the order they should be tried.
+func (g *FailoverGateway) order() []int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	start := 0
+	if g.strategy == config.FailoverRoundRobin {
+		start = g.next
+		g.next = (g.next + 1) % len(g.gateways)
+	}
+
+	healthy := make([]int, 0, len(g.gateways))
+	unhealthy := make([]int, 0)
+	for i := range g.gateways {
+		index := (start + i) % len(g.gateways)
+		if g.health[index].Healthy {
+			healthy = append(healthy, index)
+		} else {
+			unhealthy = append(unhealthy, index)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+func (g *FailoverGateway) record(index int, err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	health := &g.health[index]
+	health.LastCheck = time.Now()
+	health.LastError = err
+	health.Healthy = err == nil
+	if err == nil {
+		health.Failures = 0
+	} else {
+		health.Failures++
+	}
+}
+
+// isFailoverError checks whether the error means the host can't currently serve requests.
+func isFailoverError(err error) bool {
+	var httpErr httpAccess.HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.Code {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		default:
+			return false
+		}
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &grpcErr) {
+		return false
+	}
+
+	switch grpcErr.GRPCStatus().Code() {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
+
+// failover calls the function with the gateways of the hosts until a host handles the request.
+func failover[T any](g *FailoverGateway, fn func(Gateway) (T, error)) (T, error) {
+	var result T
+	var err error
+
+	for _, i := range g.order() {
+		result, err = fn(g.gateways[i])
+		if !isFailoverError(err) {
+			g.record(i, nil) // host responded, even if the response is an error
+			return result, err
+		}
+		g.record(i, err)
+	}
+
+	return result, fmt.Errorf("all network hosts failed: %w", err)
+}
+
+func (g *FailoverGateway) GetAccount(address flow.Address) (*flow.Account, error) {
+	return failover(g, func(gw Gateway) (*flow.Account, error) {
+		return gw.GetAccount(address)
+	})
+}
+
+func (g *FailoverGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
+	return failover(g, func(gw Gateway) (*flow.Transaction, error) {
+		return gw.SendSignedTransaction(tx)
+	})
+}
+
+func (g *FailoverGateway) GetTransaction(ID flow.Identifier) (*flow.Transaction, error) {
+	return failover(g, func(gw Gateway) (*flow.Transaction, error) {
+		return gw.GetTransaction(ID)
+	})
+}
+
+func (g *FailoverGateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return failover(g, func(gw Gateway) ([]*flow.TransactionResult, error) {
+		return gw.GetTransactionResultsByBlockID(blockID)
+	})
+}
+
+func (g *FailoverGateway) GetTransactionResult(ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	return failover(g, func(gw Gateway) (*flow.TransactionResult, error) {
+		return gw.GetTransactionResult(ID, waitSeal)
+	})
+}
+
+func (g *FailoverGateway) GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return failover(g, func(gw Gateway) ([]*flow.Transaction, error) {
+		return gw.GetTransactionsByBlockID(blockID)
+	})
+}
+
+func (g *FailoverGateway) ExecuteScript(script []byte, args []cadence.Value) (cadence.Value, error) {
+	return failover(g, func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScript(script, args)
+	})
+}
+
+func (g *FailoverGateway) ExecuteScriptAtHeight(script []byte, args []cadence.Value, height uint64) (cadence.Value, error) {
+	return failover(g, func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScriptAtHeight(script, args, height)
+	})
+}
+
+func (g *FailoverGateway) ExecuteScriptAtID(script []byte, args []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return failover(g, func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScriptAtID(script, args, ID)
+	})
+}
+
+func (g *FailoverGateway) GetLatestBlock() (*flow.Block, error) {
+	return failover(g, func(gw Gateway) (*flow.Block, error) {
+		return gw.GetLatestBlock()
+	})
+}
+
+func (g *FailoverGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
+	return failover(g, func(gw Gateway) (*flow.Block, error) {
+		return gw.GetBlockByHeight(height)
+	})
+}
+
+func (g *FailoverGateway) GetBlockByID(ID flow.Identifier) (*flow.Block, error) {
+	return failover(g, func(gw Gateway) (*flow.Block, error) {
+		return gw.GetBlockByID(ID)
+	})
+}
+
+func (g *FailoverGateway) GetEvents(eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return failover(g, func(gw Gateway) ([]flow.BlockEvents, error) {
+		return gw.GetEvents(eventType, startHeight, endHeight)
+	})
+}
+
+func (g *FailoverGateway) GetCollection(ID flow.Identifier) (*flow.Collection, error) {
+	return failover(g, func(gw Gateway) (*flow.Collection, error) {
+		return gw.GetCollection(ID)
+	})
+}
+
+func (g *FailoverGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
+	return failover(g, func(gw Gateway) ([]byte, error) {
+		return gw.GetLatestProtocolStateSnapshot()
+	})
+}
+
+// Ping pings all the hosts to update their health, an error is returned only if no host is reachable.
+func (g *FailoverGateway) Ping() error {
+	var err error
+	reachable := false
+
+	for i, gw := range g.gateways {
+		pingErr := gw.Ping()
+		g.record(i, pingErr)
+		if pingErr == nil {
+			reachable = true
+		} else {
+			err = pingErr
+		}
+	}
+
+	if !reachable {
+		return err
+	}
+
+	return nil
+}
+
+func (g *FailoverGateway) SecureConnection() bool {
+	return g.secure
+}
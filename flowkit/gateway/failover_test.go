@@ -0,0 +1,140 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	httpAccess "github.com/onflow/flow-go-sdk/access/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+func Test_FailoverGateway(t *testing.T) {
+	hosts := []string{"first:9000", "second:9000"}
+	block := &flow.Block{BlockHeader: flow.BlockHeader{Height: 10}}
+
+	t.Run("Failover on unavailable host", func(t *testing.T) {
+		first, second := &mocks.Gateway{}, &mocks.Gateway{}
+		first.On("GetLatestBlock").Return(nil, status.Error(codes.Unavailable, "connection refused"))
+		second.On("GetLatestBlock").Return(block, nil)
+
+		gw := newFailoverGateway(hosts, []Gateway{first, second}, config.FailoverOrdered, false)
+
+		result, err := gw.GetLatestBlock()
+		require.NoError(t, err)
+		assert.Equal(t, block, result)
+
+		health := gw.Health()
+		assert.False(t, health[0].Healthy)
+		assert.Equal(t, 1, health[0].Failures)
+		assert.True(t, health[1].Healthy)
+
+		// unhealthy host is tried last
+		_, err = gw.GetLatestBlock()
+		require.NoError(t, err)
+		first.AssertNumberOfCalls(t, "GetLatestBlock", 1)
+		second.AssertNumberOfCalls(t, "GetLatestBlock", 2)
+	})
+
+	t.Run("Failover on rate limit", func(t *testing.T) {
+		first, second := &mocks.Gateway{}, &mocks.Gateway{}
+		first.On("GetLatestBlock").Return(nil, fmt.Errorf("failed: %w", status.Error(codes.ResourceExhausted, "rate limited")))
+		second.On("GetLatestBlock").Return(block, nil)
+
+		gw := newFailoverGateway(hosts, []Gateway{first, second}, config.FailoverOrdered, false)
+
+		_, err := gw.GetLatestBlock()
+		require.NoError(t, err)
+		second.AssertNumberOfCalls(t, "GetLatestBlock", 1)
+	})
+
+	t.Run("Failover on REST API unavailable host", func(t *testing.T) {
+		first, second := &mocks.Gateway{}, &mocks.Gateway{}
+		first.On("GetLatestBlock").Return(nil, httpAccess.HTTPError{Code: http.StatusServiceUnavailable, Message: "unavailable"})
+		second.On("GetLatestBlock").Return(block, nil)
+
+		gw := newFailoverGateway(hosts, []Gateway{first, second}, config.FailoverOrdered, false)
+
+		_, err := gw.GetLatestBlock()
+		require.NoError(t, err)
+		second.AssertNumberOfCalls(t, "GetLatestBlock", 1)
+		assert.False(t, isFailoverError(httpAccess.HTTPError{Code: http.StatusNotFound, Message: "not found"}))
+	})
+
+	t.Run("No failover on other errors", func(t *testing.T) {
+		first, second := &mocks.Gateway{}, &mocks.Gateway{}
+		first.On("GetLatestBlock").Return(nil, status.Error(codes.NotFound, "not found"))
+
+		gw := newFailoverGateway(hosts, []Gateway{first, second}, config.FailoverOrdered, false)
+
+		_, err := gw.GetLatestBlock()
+		assert.Error(t, err)
+		second.AssertNotCalled(t, "GetLatestBlock")
+		assert.True(t, gw.Health()[0].Healthy)
+	})
+
+	t.Run("All hosts fail", func(t *testing.T) {
+		first, second := &mocks.Gateway{}, &mocks.Gateway{}
+		first.On("GetLatestBlock").Return(nil, status.Error(codes.Unavailable, "first down"))
+		second.On("GetLatestBlock").Return(nil, status.Error(codes.Unavailable, "second down"))
+
+		gw := newFailoverGateway(hosts, []Gateway{first, second}, config.FailoverOrdered, false)
+
+		_, err := gw.GetLatestBlock()
+		assert.ErrorContains(t, err, "all network hosts failed")
+	})
+
+	t.Run("Round robin", func(t *testing.T) {
+		first, second := &mocks.Gateway{}, &mocks.Gateway{}
+		first.On("GetLatestBlock").Return(block, nil)
+		second.On("GetLatestBlock").Return(block, nil)
+
+		gw := newFailoverGateway(hosts, []Gateway{first, second}, config.FailoverRoundRobin, false)
+
+		for i := 0; i < 4; i++ {
+			_, err := gw.GetLatestBlock()
+			require.NoError(t, err)
+		}
+		first.AssertNumberOfCalls(t, "GetLatestBlock", 2)
+		second.AssertNumberOfCalls(t, "GetLatestBlock", 2)
+	})
+
+	t.Run("Ping updates health", func(t *testing.T) {
+		first, second := &mocks.Gateway{}, &mocks.Gateway{}
+		first.On("Ping").Return(fmt.Errorf("unreachable"))
+		second.On("Ping").Return(nil)
+
+		gw := newFailoverGateway(hosts, []Gateway{first, second}, config.FailoverOrdered, false)
+
+		assert.NoError(t, gw.Ping())
+		health := gw.Health()
+		assert.False(t, health[0].Healthy)
+		assert.EqualError(t, health[0].LastError, "unreachable")
+		assert.True(t, health[1].Healthy)
+	})
+}
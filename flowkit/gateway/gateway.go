@@ -21,6 +21,8 @@ package gateway
 import (
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/config"
 )
 
 //go:generate  mockery --name=Gateway
@@ -45,3 +47,23 @@ type Gateway interface {
 	Ping() error
 	SecureConnection() bool
 }
+
+// NodeVersionInfo contains the version of the access node software.
+type NodeVersionInfo struct {
+	Semver          string
+	Commit          string
+	ProtocolVersion uint64
+}
+
+// NewHostGateway returns a gateway to the network host, the REST API is used if the host is an HTTP URL
+// and a secure gRPC connection is used if the network key is provided.
+func NewHostGateway(network config.Network) (Gateway, error) {
+	if IsHTTPHost(network.Host) {
+		return NewHTTPGateway(network)
+	}
+	if network.Key != "" {
+		return NewSecureGrpcGateway(network)
+	}
+
+	return NewGrpcGateway(network)
+}
@@ -20,7 +20,11 @@ package gateway
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"net/url"
+	"os"
 	"strings"
 	"time"
 
@@ -28,8 +32,11 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	grpcAccess "github.com/onflow/flow-go-sdk/access/grpc"
 	"github.com/onflow/flow-go/utils/grpcutils"
+	"github.com/onflow/flow/protobuf/go/flow/access"
 	"google.golang.org/grpc"
+	grpcCredentials "google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
 
 	"github.com/onflow/flow-cli/flowkit/config"
 )
@@ -42,16 +49,30 @@ type GrpcGateway struct {
 	client       *grpcAccess.Client
 	ctx          context.Context
 	secureClient bool
+	host         string
+	options      []grpc.DialOption
 }
 
 // NewGrpcGateway returns a new gRPC gateway.
+//
+// The connection is secured with TLS if it is enabled in the network configuration.
 func NewGrpcGateway(network config.Network) (*GrpcGateway, error) {
+	credentials := insecure.NewCredentials()
+	if network.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(network.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create TLS configuration for host %s: %w", network.Host, err)
+		}
+		credentials = grpcCredentials.NewTLS(tlsConfig)
+	}
 
-	gClient, err := grpcAccess.NewClient(
-		network.Host,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
-	)
+	networkOptions, err := dialOptions(network)
+	if err != nil {
+		return nil, err
+	}
+	options := append([]grpc.DialOption{grpc.WithTransportCredentials(credentials)}, networkOptions...)
+
+	gClient, err := grpcAccess.NewClient(network.Host, options...)
 	ctx := context.Background()
 
 	if err != nil || gClient == nil {
@@ -61,7 +82,9 @@ func NewGrpcGateway(network config.Network) (*GrpcGateway, error) {
 	return &GrpcGateway{
 		client:       gClient,
 		ctx:          ctx,
-		secureClient: false,
+		secureClient: network.TLS.Enabled,
+		host:         network.Host,
+		options:      options,
 	}, nil
 }
 
@@ -72,11 +95,13 @@ func NewSecureGrpcGateway(network config.Network) (*GrpcGateway, error) {
 		return nil, fmt.Errorf("failed to create secure GRPC dial options with network key \"%s\": %w", network.Key, err)
 	}
 
-	gClient, err := grpcAccess.NewClient(
-		network.Host,
-		secureDialOpts,
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)),
-	)
+	networkOptions, err := dialOptions(network)
+	if err != nil {
+		return nil, err
+	}
+	options := append([]grpc.DialOption{secureDialOpts}, networkOptions...)
+
+	gClient, err := grpcAccess.NewClient(network.Host, options...)
 	ctx := context.Background()
 
 	if err != nil || gClient == nil {
@@ -87,9 +112,107 @@ func NewSecureGrpcGateway(network config.Network) (*GrpcGateway, error) {
 		client:       gClient,
 		ctx:          ctx,
 		secureClient: true,
+		host:         network.Host,
+		options:      options,
 	}, nil
 }
 
+// dialOptions returns the gRPC dial options for the network gateway options and proxy.
+func dialOptions(network config.Network) ([]grpc.DialOption, error) {
+	options := network.Options
+	maxMessageSize := maxGRPCMessageSize
+	if options.MaxMessageSize > 0 {
+		maxMessageSize = options.MaxMessageSize
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageSize)),
+	}
+
+	if options.Timeout > 0 {
+		dialOpts = append(dialOpts, grpc.WithUnaryInterceptor(timeoutInterceptor(options.Timeout)))
+	}
+
+	if options.Keepalive > 0 {
+		dialOpts = append(dialOpts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                options.Keepalive,
+			PermitWithoutStream: true,
+		}))
+	}
+
+	if options.UserAgent != "" {
+		dialOpts = append(dialOpts, grpc.WithUserAgent(options.UserAgent))
+	}
+
+	if network.Proxy != "" {
+		proxyURL, err := url.Parse(network.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %s: %w", network.Proxy, err)
+		}
+		dialer, err := proxyDialer(proxyURL)
+		if err != nil {
+			return nil, err
+		}
+		dialOpts = append(dialOpts, grpc.WithContextDialer(dialer), grpc.WithNoProxy())
+	}
+
+	return dialOpts, nil
+}
+
+// newTLSConfig creates the TLS configuration with the CA bundle and client certificates from the network configuration.
+func newTLSConfig(config config.TLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.ServerName,
+	}
+
+	if config.CAFile != "" {
+		ca, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no valid certificates found in CA bundle %s", config.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// timeoutInterceptor cancels requests which take longer than the timeout.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+func (g *GrpcGateway) withContext(ctx context.Context) Gateway {
+	gateway := *g
+	gateway.ctx = ctx
+	return &gateway
+}
+
 // GetAccount gets an account by address from the Flow Access API.
 func (g *GrpcGateway) GetAccount(address flow.Address) (*flow.Account, error) {
 	account, err := g.client.GetAccountAtLatestBlock(g.ctx, address)
@@ -200,6 +323,28 @@ func (g *GrpcGateway) Ping() error {
 	return g.client.Ping(g.ctx)
 }
 
+// GetNodeVersionInfo gets the version of the access node software.
+//
+// The Go SDK client doesn't support the version request, so it is sent with a new connection to the access node.
+func (g *GrpcGateway) GetNodeVersionInfo() (*NodeVersionInfo, error) {
+	conn, err := grpc.Dial(g.host, g.options...)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	res, err := access.NewAccessAPIClient(conn).GetNodeVersionInfo(g.ctx, &access.GetNodeVersionInfoRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &NodeVersionInfo{
+		Semver:          res.GetInfo().GetSemver(),
+		Commit:          res.GetInfo().GetCommit(),
+		ProtocolVersion: res.GetInfo().GetProtocolVersion(),
+	}, nil
+}
+
 // SecureConnection is used to log warning if a service should be using a secure client but is not
 func (g *GrpcGateway) SecureConnection() bool {
 	return g.secureClient
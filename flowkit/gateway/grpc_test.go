@@ -0,0 +1,73 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_GrpcGatewayTLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caFile, ca, 0644))
+
+	t.Run("Create secure gateway", func(t *testing.T) {
+		gw, err := NewGrpcGateway(config.Network{
+			Name: "private",
+			Host: "127.0.0.1:9000",
+			TLS:  config.TLSConfig{Enabled: true, CAFile: caFile, ServerName: "example.com"},
+		})
+		require.NoError(t, err)
+		assert.True(t, gw.SecureConnection())
+
+		tlsConfig, err := newTLSConfig(config.TLSConfig{Enabled: true, CAFile: caFile, ServerName: "example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, "example.com", tlsConfig.ServerName)
+		assert.NotNil(t, tlsConfig.RootCAs)
+	})
+
+	t.Run("Fail invalid certificates", func(t *testing.T) {
+		invalidFile := filepath.Join(dir, "invalid.pem")
+		require.NoError(t, os.WriteFile(invalidFile, []byte("invalid"), 0644))
+
+		_, err := NewGrpcGateway(config.Network{
+			Name: "private",
+			Host: "127.0.0.1:9000",
+			TLS:  config.TLSConfig{Enabled: true, CAFile: invalidFile},
+		})
+		assert.EqualError(t, err, "failed to create TLS configuration for host 127.0.0.1:9000: no valid certificates found in CA bundle "+invalidFile)
+
+		_, err = newTLSConfig(config.TLSConfig{Enabled: true, CertFile: caFile, KeyFile: invalidFile})
+		assert.ErrorContains(t, err, "failed to load client certificate")
+	})
+}
@@ -0,0 +1,206 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	httpAccess "github.com/onflow/flow-go-sdk/access/http"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var _ Gateway = &HTTPGateway{}
+
+// restAPIVersion is the path prefix of the Flow Access REST API.
+const restAPIVersion = "/v1"
+
+// IsHTTPHost checks whether the host is a Flow Access REST API URL, e.g. https://rest-mainnet.onflow.org.
+func IsHTTPHost(host string) bool {
+	return strings.HasPrefix(host, "http://") || strings.HasPrefix(host, "https://")
+}
+
+// HTTPGateway is a gateway implementation that uses the Flow Access REST API.
+//
+// The REST API can be used in environments where gRPC connections are not possible.
+type HTTPGateway struct {
+	client *httpAccess.Client
+	ctx    context.Context
+	secure bool
+}
+
+// NewHTTPGateway returns a new REST API gateway.
+func NewHTTPGateway(network config.Network) (*HTTPGateway, error) {
+	if !IsHTTPHost(network.Host) {
+		return nil, fmt.Errorf("host %s is not a REST API URL, the URL must start with http:// or https://", network.Host)
+	}
+	if network.Key != "" {
+		return nil, fmt.Errorf("network key is not supported with REST API host %s", network.Host)
+	}
+	if network.TLS.Enabled {
+		return nil, fmt.Errorf("tls configuration is not supported with REST API host %s, use an https:// URL instead", network.Host)
+	}
+
+	host := strings.TrimSuffix(network.Host, "/")
+	if !strings.HasSuffix(host, restAPIVersion) {
+		host = host + restAPIVersion
+	}
+
+	if network.Proxy != "" {
+		proxyURL, err := url.Parse(network.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %s: %w", network.Proxy, err)
+		}
+		hostURL, err := url.Parse(host)
+		if err != nil {
+			return nil, fmt.Errorf("invalid host %s: %w", network.Host, err)
+		}
+		useHTTPProxy(hostURL.Host, proxyURL)
+	}
+
+	client, err := httpAccess.NewClient(host)
+	if err != nil || client == nil {
+		return nil, fmt.Errorf("failed to connect to host %s", network.Host)
+	}
+
+	return &HTTPGateway{
+		client: client,
+		ctx:    context.Background(),
+		secure: strings.HasPrefix(host, "https://"),
+	}, nil
+}
+
+func (g *HTTPGateway) withContext(ctx context.Context) Gateway {
+	gateway := *g
+	gateway.ctx = ctx
+	return &gateway
+}
+
+// GetAccount gets an account by address from the Flow Access API.
+func (g *HTTPGateway) GetAccount(address flow.Address) (*flow.Account, error) {
+	account, err := g.client.GetAccountAtLatestBlock(g.ctx, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account with address %s: %w", address, err)
+	}
+
+	return account, nil
+}
+
+// SendSignedTransaction sends a transaction to flow that is already prepared and signed.
+func (g *HTTPGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
+	err := g.client.SendTransaction(g.ctx, *tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to submit transaction: %w", err)
+	}
+
+	return tx, nil
+}
+
+// GetTransaction gets a transaction by ID from the Flow Access API.
+func (g *HTTPGateway) GetTransaction(ID flow.Identifier) (*flow.Transaction, error) {
+	return g.client.GetTransaction(g.ctx, ID)
+}
+
+func (g *HTTPGateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return g.client.GetTransactionResultsByBlockID(g.ctx, blockID)
+}
+
+func (g *HTTPGateway) GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return g.client.GetTransactionsByBlockID(g.ctx, blockID)
+}
+
+// GetTransactionResult gets a transaction result by ID from the Flow Access API.
+func (g *HTTPGateway) GetTransactionResult(ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	result, err := g.client.GetTransactionResult(g.ctx, ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Status != flow.TransactionStatusSealed && waitSeal {
+		time.Sleep(time.Second)
+		return g.GetTransactionResult(ID, waitSeal)
+	}
+
+	return result, nil
+}
+
+// ExecuteScript executes a script on Flow through the Access API.
+func (g *HTTPGateway) ExecuteScript(script []byte, arguments []cadence.Value) (cadence.Value, error) {
+	return g.client.ExecuteScriptAtLatestBlock(g.ctx, script, arguments)
+}
+
+// ExecuteScriptAtHeight executes a script at block height.
+func (g *HTTPGateway) ExecuteScriptAtHeight(script []byte, arguments []cadence.Value, height uint64) (cadence.Value, error) {
+	return g.client.ExecuteScriptAtBlockHeight(g.ctx, height, script, arguments)
+}
+
+// ExecuteScriptAtID executes a script at block ID.
+func (g *HTTPGateway) ExecuteScriptAtID(script []byte, arguments []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return g.client.ExecuteScriptAtBlockID(g.ctx, ID, script, arguments)
+}
+
+// GetLatestBlock gets the latest block on Flow through the Access API.
+func (g *HTTPGateway) GetLatestBlock() (*flow.Block, error) {
+	return g.client.GetLatestBlock(g.ctx, true)
+}
+
+// GetBlockByID get block by ID from the Flow Access API.
+func (g *HTTPGateway) GetBlockByID(id flow.Identifier) (*flow.Block, error) {
+	return g.client.GetBlockByID(g.ctx, id)
+}
+
+// GetBlockByHeight get block by height from the Flow Access API.
+func (g *HTTPGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
+	return g.client.GetBlockByHeight(g.ctx, height)
+}
+
+// GetEvents gets events by name and block range from the Flow Access API.
+func (g *HTTPGateway) GetEvents(
+	eventType string,
+	startHeight uint64,
+	endHeight uint64,
+) ([]flow.BlockEvents, error) {
+	return g.client.GetEventsForHeightRange(g.ctx, eventType, startHeight, endHeight)
+}
+
+// GetCollection gets a collection by ID from the Flow Access API.
+func (g *HTTPGateway) GetCollection(id flow.Identifier) (*flow.Collection, error) {
+	return g.client.GetCollection(g.ctx, id)
+}
+
+// GetLatestProtocolStateSnapshot gets the latest finalized protocol state snapshot
+func (g *HTTPGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
+	return g.client.GetLatestProtocolStateSnapshot(g.ctx)
+}
+
+// Ping is used to check if the access node is alive and healthy.
+func (g *HTTPGateway) Ping() error {
+	return g.client.Ping(g.ctx)
+}
+
+// SecureConnection is true if the REST API is accessed over HTTPS.
+func (g *HTTPGateway) SecureConnection() bool {
+	return g.secure
+}
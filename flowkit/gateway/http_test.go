@@ -0,0 +1,71 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_HTTPGateway(t *testing.T) {
+	t.Run("Create gateway", func(t *testing.T) {
+		gw, err := NewHTTPGateway(config.Network{Name: "mainnet", Host: "https://rest-mainnet.onflow.org"})
+		require.NoError(t, err)
+		assert.True(t, gw.SecureConnection())
+
+		gw, err = NewHTTPGateway(config.Network{Name: "emulator", Host: "http://127.0.0.1:8888/v1/"})
+		require.NoError(t, err)
+		assert.False(t, gw.SecureConnection())
+
+		assert.True(t, IsHTTPHost("https://rest-testnet.onflow.org"))
+		assert.False(t, IsHTTPHost("access.devnet.nodes.onflow.org:9000"))
+	})
+
+	t.Run("Fail invalid network", func(t *testing.T) {
+		_, err := NewHTTPGateway(config.Network{Name: "testnet", Host: "access.devnet.nodes.onflow.org:9000"})
+		assert.EqualError(t, err, "host access.devnet.nodes.onflow.org:9000 is not a REST API URL, the URL must start with http:// or https://")
+
+		_, err = NewHTTPGateway(config.Network{Name: "testnet", Host: "https://rest-testnet.onflow.org", Key: "0x01"})
+		assert.EqualError(t, err, "network key is not supported with REST API host https://rest-testnet.onflow.org")
+	})
+
+	t.Run("Request REST API", func(t *testing.T) {
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"code": 503, "message": "service unavailable"}`))
+		}))
+		defer server.Close()
+
+		gw, err := NewHTTPGateway(config.Network{Name: "emulator", Host: server.URL})
+		require.NoError(t, err)
+
+		_, err = gw.GetLatestBlock()
+		assert.ErrorContains(t, err, "service unavailable")
+		assert.True(t, isFailoverError(err))
+		assert.Equal(t, "/v1/blocks", path)
+	})
+}
@@ -0,0 +1,211 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"google.golang.org/grpc/metadata"
+)
+
+var _ Gateway = &InterceptedGateway{}
+
+// Invoker performs the access API call with the provided context.
+type Invoker func(ctx context.Context) error
+
+// Interceptor is called around every access API call made by the gateway.
+//
+// The interceptor receives the name of the gateway method and must call the invoker to perform the call,
+// which allows adding logging, metrics or tracing spans and changing the context passed to the call.
+type Interceptor func(ctx context.Context, method string, invoke Invoker) error
+
+// contextGateway is implemented by gateways which can make the calls with a different context.
+type contextGateway interface {
+	withContext(ctx context.Context) Gateway
+}
+
+// InterceptedGateway is a gateway calling the interceptor chain around every call of the wrapped gateway.
+//
+// The context changed by the interceptors is passed to the gRPC, REST API and emulator gateways,
+// other gateways make the calls with their own context.
+type InterceptedGateway struct {
+	gateway      Gateway
+	interceptors []Interceptor
+	ctx          context.Context
+}
+
+// NewInterceptedGateway returns a gateway wrapping the gateway with the interceptors,
+// the first interceptor is the outermost one in the chain.
+func NewInterceptedGateway(gateway Gateway, interceptors ...Interceptor) *InterceptedGateway {
+	return &InterceptedGateway{
+		gateway:      gateway,
+		interceptors: interceptors,
+		ctx:          context.Background(),
+	}
+}
+
+// HeaderInterceptor returns an interceptor adding the headers to the gRPC requests metadata, e.g. for authentication.
+func HeaderInterceptor(headers map[string]string) Interceptor {
+	return func(ctx context.Context, _ string, invoke Invoker) error {
+		for key, value := range headers {
+			ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+		}
+		return invoke(ctx)
+	}
+}
+
+// Unwrap returns the wrapped gateway.
+func (g *InterceptedGateway) Unwrap() Gateway {
+	return g.gateway
+}
+
+func (g *InterceptedGateway) withContext(ctx context.Context) Gateway {
+	intercepted := *g
+	intercepted.ctx = ctx
+	return &intercepted
+}
+
+// intercept calls the function with the wrapped gateway through the interceptor chain.
+func intercept[T any](g *InterceptedGateway, method string, fn func(Gateway) (T, error)) (T, error) {
+	var result T
+
+	invoke := func(ctx context.Context) error {
+		gateway := g.gateway
+		if gw, ok := gateway.(contextGateway); ok {
+			gateway = gw.withContext(ctx)
+		}
+
+		var err error
+		result, err = fn(gateway)
+		return err
+	}
+
+	for i := len(g.interceptors) - 1; i >= 0; i-- {
+		interceptor, next := g.interceptors[i], invoke
+		invoke = func(ctx context.Context) error {
+			return interceptor(ctx, method, next)
+		}
+	}
+
+	err := invoke(g.ctx)
+	return result, err
+}
+
+func (g *InterceptedGateway) GetAccount(address flow.Address) (*flow.Account, error) {
+	return intercept(g, "GetAccount", func(gw Gateway) (*flow.Account, error) {
+		return gw.GetAccount(address)
+	})
+}
+
+func (g *InterceptedGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
+	return intercept(g, "SendSignedTransaction", func(gw Gateway) (*flow.Transaction, error) {
+		return gw.SendSignedTransaction(tx)
+	})
+}
+
+func (g *InterceptedGateway) GetTransaction(ID flow.Identifier) (*flow.Transaction, error) {
+	return intercept(g, "GetTransaction", func(gw Gateway) (*flow.Transaction, error) {
+		return gw.GetTransaction(ID)
+	})
+}
+
+func (g *InterceptedGateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	return intercept(g, "GetTransactionResultsByBlockID", func(gw Gateway) ([]*flow.TransactionResult, error) {
+		return gw.GetTransactionResultsByBlockID(blockID)
+	})
+}
+
+func (g *InterceptedGateway) GetTransactionResult(ID flow.Identifier, waitSeal bool) (*flow.TransactionResult, error) {
+	return intercept(g, "GetTransactionResult", func(gw Gateway) (*flow.TransactionResult, error) {
+		return gw.GetTransactionResult(ID, waitSeal)
+	})
+}
+
+func (g *InterceptedGateway) GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error) {
+	return intercept(g, "GetTransactionsByBlockID", func(gw Gateway) ([]*flow.Transaction, error) {
+		return gw.GetTransactionsByBlockID(blockID)
+	})
+}
+
+func (g *InterceptedGateway) ExecuteScript(script []byte, args []cadence.Value) (cadence.Value, error) {
+	return intercept(g, "ExecuteScript", func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScript(script, args)
+	})
+}
+
+func (g *InterceptedGateway) ExecuteScriptAtHeight(script []byte, args []cadence.Value, height uint64) (cadence.Value, error) {
+	return intercept(g, "ExecuteScriptAtHeight", func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScriptAtHeight(script, args, height)
+	})
+}
+
+func (g *InterceptedGateway) ExecuteScriptAtID(script []byte, args []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	return intercept(g, "ExecuteScriptAtID", func(gw Gateway) (cadence.Value, error) {
+		return gw.ExecuteScriptAtID(script, args, ID)
+	})
+}
+
+func (g *InterceptedGateway) GetLatestBlock() (*flow.Block, error) {
+	return intercept(g, "GetLatestBlock", func(gw Gateway) (*flow.Block, error) {
+		return gw.GetLatestBlock()
+	})
+}
+
+func (g *InterceptedGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
+	return intercept(g, "GetBlockByHeight", func(gw Gateway) (*flow.Block, error) {
+		return gw.GetBlockByHeight(height)
+	})
+}
+
+func (g *InterceptedGateway) GetBlockByID(ID flow.Identifier) (*flow.Block, error) {
+	return intercept(g, "GetBlockByID", func(gw Gateway) (*flow.Block, error) {
+		return gw.GetBlockByID(ID)
+	})
+}
+
+func (g *InterceptedGateway) GetEvents(eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	return intercept(g, "GetEvents", func(gw Gateway) ([]flow.BlockEvents, error) {
+		return gw.GetEvents(eventType, startHeight, endHeight)
+	})
+}
+
+func (g *InterceptedGateway) GetCollection(ID flow.Identifier) (*flow.Collection, error) {
+	return intercept(g, "GetCollection", func(gw Gateway) (*flow.Collection, error) {
+		return gw.GetCollection(ID)
+	})
+}
+
+func (g *InterceptedGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
+	return intercept(g, "GetLatestProtocolStateSnapshot", func(gw Gateway) ([]byte, error) {
+		return gw.GetLatestProtocolStateSnapshot()
+	})
+}
+
+func (g *InterceptedGateway) Ping() error {
+	_, err := intercept(g, "Ping", func(gw Gateway) (any, error) {
+		return nil, gw.Ping()
+	})
+	return err
+}
+
+func (g *InterceptedGateway) SecureConnection() bool {
+	return g.gateway.SecureConnection()
+}
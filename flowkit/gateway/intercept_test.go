@@ -0,0 +1,100 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+// contextRecorder is a gateway recording the context it was called with.
+type contextRecorder struct {
+	Gateway
+	ctx context.Context
+}
+
+func (c *contextRecorder) withContext(ctx context.Context) Gateway {
+	return &contextRecorder{Gateway: c.Gateway, ctx: ctx}
+}
+
+func (c *contextRecorder) GetLatestBlock() (*flow.Block, error) {
+	md, _ := metadata.FromOutgoingContext(c.ctx)
+	if len(md.Get("authorization")) == 0 {
+		return nil, fmt.Errorf("missing authorization")
+	}
+	return &flow.Block{}, nil
+}
+
+func Test_InterceptedGateway(t *testing.T) {
+	t.Run("Call interceptors in order", func(t *testing.T) {
+		block := &flow.Block{BlockHeader: flow.BlockHeader{Height: 10}}
+		gw := &mocks.Gateway{}
+		gw.On("GetLatestBlock").Return(block, nil).Once()
+
+		var calls []string
+		interceptor := func(name string) Interceptor {
+			return func(ctx context.Context, method string, invoke Invoker) error {
+				calls = append(calls, fmt.Sprintf("%s %s start", name, method))
+				err := invoke(ctx)
+				calls = append(calls, fmt.Sprintf("%s %s end", name, method))
+				return err
+			}
+		}
+
+		intercepted := NewInterceptedGateway(gw, interceptor("first"), interceptor("second"))
+		result, err := intercepted.GetLatestBlock()
+		require.NoError(t, err)
+		assert.Equal(t, block, result)
+		assert.Equal(t, []string{
+			"first GetLatestBlock start",
+			"second GetLatestBlock start",
+			"second GetLatestBlock end",
+			"first GetLatestBlock end",
+		}, calls)
+	})
+
+	t.Run("Return interceptor error", func(t *testing.T) {
+		gw := &mocks.Gateway{}
+		intercepted := NewInterceptedGateway(gw, func(ctx context.Context, method string, invoke Invoker) error {
+			return fmt.Errorf("call %s denied", method)
+		})
+
+		_, err := intercepted.GetAccount(flow.HexToAddress("0x01"))
+		assert.EqualError(t, err, "call GetAccount denied")
+		gw.AssertNotCalled(t, "GetAccount")
+	})
+
+	t.Run("Pass context to gateway", func(t *testing.T) {
+		gw := &contextRecorder{ctx: context.Background()}
+		_, err := gw.GetLatestBlock()
+		require.Error(t, err)
+
+		intercepted := NewInterceptedGateway(gw, HeaderInterceptor(map[string]string{"authorization": "Bearer token"}))
+		_, err = intercepted.GetLatestBlock()
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,117 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-emulator/server"
+	"github.com/onflow/flow-go/fvm"
+	"github.com/rs/zerolog"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// ManagedEmulator is an emulator server running in-process which serves the emulator network until it is stopped.
+//
+// It allows running commands against the emulator network without starting the emulator separately.
+type ManagedEmulator struct {
+	server *server.EmulatorServer
+	done   chan struct{}
+}
+
+// StartManagedEmulator starts an emulator server listening on the port of the network host,
+// the emulator state is kept in memory and discarded when the emulator is stopped.
+func StartManagedEmulator(network config.Network, key *EmulatorKey) (*ManagedEmulator, error) {
+	host, port, err := net.SplitHostPort(network.Host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid emulator host %s: %w", network.Host, err)
+	}
+	grpcPort, err := strconv.Atoi(port)
+	if err != nil {
+		return nil, fmt.Errorf("invalid emulator host port %s: %w", port, err)
+	}
+
+	tokenSupply, err := cadence.NewUFix64("1000000000.0")
+	if err != nil {
+		return nil, err
+	}
+
+	// use the same defaults as the emulator command
+	conf := &server.Config{
+		Host:                      host,
+		GRPCPort:                  grpcPort,
+		GenesisTokenSupply:        tokenSupply,
+		TransactionExpiry:         10,
+		TransactionMaxGasLimit:    9999,
+		ScriptGasLimit:            100000,
+		StorageLimitEnabled:       true,
+		StorageMBPerFLOW:          fvm.DefaultStorageMBPerFLOW,
+		MinimumStorageReservation: fvm.DefaultMinimumStorageReservation,
+		TransactionFeesEnabled:    false,
+		ContractRemovalEnabled:    true,
+		SkipTransactionValidation: false,
+		LivenessCheckTolerance:    time.Second,
+	}
+	if key != nil {
+		conf.ServicePublicKey = key.PublicKey
+		conf.ServiceKeySigAlgo = key.SigAlgo
+		conf.ServiceKeyHashAlgo = key.HashAlgo
+	}
+
+	logger := zerolog.Nop()
+	emulatorServer := server.NewEmulatorServer(&logger, conf)
+	if emulatorServer == nil {
+		return nil, fmt.Errorf("failed to create the emulator server")
+	}
+
+	err = emulatorServer.Listen()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the emulator on port %d: %w", grpcPort, err)
+	}
+
+	managed := &ManagedEmulator{
+		server: emulatorServer,
+		done:   make(chan struct{}),
+	}
+	go func() {
+		emulatorServer.Start()
+		close(managed.done)
+	}()
+
+	return managed, nil
+}
+
+// Stop the emulator server and wait for it to shut down.
+func (m *ManagedEmulator) Stop() {
+	for {
+		m.server.Stop()
+
+		// the server might not have started all the routines yet, in which case stopping is retried
+		select {
+		case <-m.done:
+			return
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"fmt"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_ManagedEmulator(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	require.NoError(t, listener.Close())
+
+	network := config.Network{Name: "emulator", Host: fmt.Sprintf("127.0.0.1:%d", port)}
+
+	emulator, err := StartManagedEmulator(network, nil)
+	require.NoError(t, err)
+
+	gw, err := NewGrpcGateway(network)
+	require.NoError(t, err)
+
+	block, err := gw.GetLatestBlock()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), block.Height)
+
+	emulator.Stop()
+
+	_, err = gw.GetLatestBlock()
+	assert.Error(t, err)
+}
+
+func Test_ManagedEmulatorInvalidHost(t *testing.T) {
+	_, err := StartManagedEmulator(config.Network{Name: "emulator", Host: "localhost"}, nil)
+	assert.EqualError(t, err, "invalid emulator host localhost: address localhost: missing port in address")
+}
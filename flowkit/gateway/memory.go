@@ -0,0 +1,353 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ Gateway = &MemoryGateway{}
+
+// MemoryGateway is an in-memory gateway for testing tools using flowkit without running an emulator.
+//
+// Accounts, blocks, collections, events and script results are provided with the gateway methods,
+// and requests for missing data fail with a not found error like the access API.
+// Sent transactions are sealed in a new block, the result can be changed with HandleTransactions.
+type MemoryGateway struct {
+	accounts     map[flow.Address]*flow.Account
+	blocks       []*flow.Block
+	collections  map[flow.Identifier]*flow.Collection
+	transactions map[flow.Identifier]*flow.Transaction
+	results      map[flow.Identifier]*flow.TransactionResult
+	scripts      map[string]cadence.Value
+	events       []flow.BlockEvents
+	sent         []*flow.Transaction
+	handler      func(tx *flow.Transaction) *flow.TransactionResult
+	mu           sync.Mutex
+}
+
+// NewMemoryGateway returns a new in-memory gateway with a genesis block.
+func NewMemoryGateway() *MemoryGateway {
+	return &MemoryGateway{
+		accounts:     make(map[flow.Address]*flow.Account),
+		blocks:       []*flow.Block{newMemoryBlock(nil)},
+		collections:  make(map[flow.Identifier]*flow.Collection),
+		transactions: make(map[flow.Identifier]*flow.Transaction),
+		results:      make(map[flow.Identifier]*flow.TransactionResult),
+		scripts:      make(map[string]cadence.Value),
+	}
+}
+
+// newMemoryBlock returns a new block following the parent block.
+func newMemoryBlock(parent *flow.Block) *flow.Block {
+	block := &flow.Block{BlockHeader: flow.BlockHeader{Timestamp: time.Now(), Status: flow.BlockStatusSealed}}
+	if parent != nil {
+		block.ParentID = parent.ID
+		block.Height = parent.Height + 1
+	}
+	block.ID = flow.HashToID([]byte(fmt.Sprintf("block-%d", block.Height)))
+
+	return block
+}
+
+func notFound(format string, args ...any) error {
+	return status.Errorf(codes.NotFound, format, args...)
+}
+
+// AddAccount adds or replaces the account.
+func (g *MemoryGateway) AddAccount(account *flow.Account) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.accounts[account.Address] = account
+}
+
+// AddBlock adds the block as the latest block, the block height must follow the latest block.
+func (g *MemoryGateway) AddBlock(block *flow.Block) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	latest := g.blocks[len(g.blocks)-1]
+	if block.Height != latest.Height+1 {
+		return fmt.Errorf("block height %d doesn't follow the latest block height %d", block.Height, latest.Height)
+	}
+
+	g.blocks = append(g.blocks, block)
+	return nil
+}
+
+// AddCollection adds the collection.
+func (g *MemoryGateway) AddCollection(collection *flow.Collection) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.collections[collection.ID()] = collection
+}
+
+// AddTransaction adds the transaction with its result.
+func (g *MemoryGateway) AddTransaction(tx *flow.Transaction, result *flow.TransactionResult) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.transactions[tx.ID()] = tx
+	g.results[tx.ID()] = result
+}
+
+// AddEvents adds the events emitted in a block.
+func (g *MemoryGateway) AddEvents(events flow.BlockEvents) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.events = append(g.events, events)
+}
+
+// SetScriptResult sets the value returned by executing the script code, at any block.
+func (g *MemoryGateway) SetScriptResult(code []byte, value cadence.Value) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.scripts[string(code)] = value
+}
+
+// HandleTransactions sets the handler returning the results of sent transactions.
+func (g *MemoryGateway) HandleTransactions(handler func(tx *flow.Transaction) *flow.TransactionResult) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.handler = handler
+}
+
+// SentTransactions returns all the transactions sent to the gateway.
+func (g *MemoryGateway) SentTransactions() []*flow.Transaction {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return append([]*flow.Transaction(nil), g.sent...)
+}
+
+func (g *MemoryGateway) GetAccount(address flow.Address) (*flow.Account, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	account, ok := g.accounts[address]
+	if !ok {
+		return nil, notFound("account with address %s not found", address)
+	}
+
+	return account, nil
+}
+
+// SendSignedTransaction seals the transaction in a new block.
+func (g *MemoryGateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	block := newMemoryBlock(g.blocks[len(g.blocks)-1])
+	g.blocks = append(g.blocks, block)
+
+	result := &flow.TransactionResult{Status: flow.TransactionStatusSealed}
+	if g.handler != nil {
+		result = g.handler(tx)
+	}
+	result.TransactionID = tx.ID()
+	result.BlockID = block.ID
+	result.BlockHeight = block.Height
+
+	g.sent = append(g.sent, tx)
+	g.transactions[tx.ID()] = tx
+	g.results[tx.ID()] = result
+
+	return tx, nil
+}
+
+func (g *MemoryGateway) GetTransaction(ID flow.Identifier) (*flow.Transaction, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	tx, ok := g.transactions[ID]
+	if !ok {
+		return nil, notFound("transaction %s not found", ID)
+	}
+
+	return tx, nil
+}
+
+func (g *MemoryGateway) GetTransactionResultsByBlockID(blockID flow.Identifier) ([]*flow.TransactionResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	results := make([]*flow.TransactionResult, 0)
+	for _, result := range g.results {
+		if result.BlockID == blockID {
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+func (g *MemoryGateway) GetTransactionResult(ID flow.Identifier, _ bool) (*flow.TransactionResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	result, ok := g.results[ID]
+	if !ok {
+		return nil, notFound("transaction result %s not found", ID)
+	}
+
+	return result, nil
+}
+
+func (g *MemoryGateway) GetTransactionsByBlockID(blockID flow.Identifier) ([]*flow.Transaction, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	txs := make([]*flow.Transaction, 0)
+	for ID, result := range g.results {
+		if result.BlockID == blockID {
+			txs = append(txs, g.transactions[ID])
+		}
+	}
+
+	return txs, nil
+}
+
+func (g *MemoryGateway) executeScript(script []byte) (cadence.Value, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	value, ok := g.scripts[string(script)]
+	if !ok {
+		return nil, notFound("no result set for script")
+	}
+
+	return value, nil
+}
+
+func (g *MemoryGateway) ExecuteScript(script []byte, _ []cadence.Value) (cadence.Value, error) {
+	return g.executeScript(script)
+}
+
+func (g *MemoryGateway) ExecuteScriptAtHeight(script []byte, _ []cadence.Value, height uint64) (cadence.Value, error) {
+	if _, err := g.GetBlockByHeight(height); err != nil {
+		return nil, err
+	}
+
+	return g.executeScript(script)
+}
+
+func (g *MemoryGateway) ExecuteScriptAtID(script []byte, _ []cadence.Value, ID flow.Identifier) (cadence.Value, error) {
+	if _, err := g.GetBlockByID(ID); err != nil {
+		return nil, err
+	}
+
+	return g.executeScript(script)
+}
+
+func (g *MemoryGateway) GetLatestBlock() (*flow.Block, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	return g.blocks[len(g.blocks)-1], nil
+}
+
+func (g *MemoryGateway) GetBlockByHeight(height uint64) (*flow.Block, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, block := range g.blocks {
+		if block.Height == height {
+			return block, nil
+		}
+	}
+
+	return nil, notFound("block at height %d not found", height)
+}
+
+func (g *MemoryGateway) GetBlockByID(ID flow.Identifier) (*flow.Block, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for _, block := range g.blocks {
+		if block.ID == ID {
+			return block, nil
+		}
+	}
+
+	return nil, notFound("block %s not found", ID)
+}
+
+func (g *MemoryGateway) GetEvents(eventType string, startHeight uint64, endHeight uint64) ([]flow.BlockEvents, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	blockEvents := make([]flow.BlockEvents, 0)
+	for _, block := range g.events {
+		if block.Height < startHeight || block.Height > endHeight {
+			continue
+		}
+
+		events := make([]flow.Event, 0)
+		for _, event := range block.Events {
+			if event.Type == eventType {
+				events = append(events, event)
+			}
+		}
+
+		blockEvents = append(blockEvents, flow.BlockEvents{
+			BlockID:        block.BlockID,
+			Height:         block.Height,
+			BlockTimestamp: block.BlockTimestamp,
+			Events:         events,
+		})
+	}
+
+	return blockEvents, nil
+}
+
+func (g *MemoryGateway) GetCollection(ID flow.Identifier) (*flow.Collection, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	collection, ok := g.collections[ID]
+	if !ok {
+		return nil, notFound("collection %s not found", ID)
+	}
+
+	return collection, nil
+}
+
+func (g *MemoryGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
+	return nil, status.Error(codes.Unimplemented, "protocol state snapshot is not supported by the memory gateway")
+}
+
+func (g *MemoryGateway) Ping() error {
+	return nil
+}
+
+func (g *MemoryGateway) SecureConnection() bool {
+	return false
+}
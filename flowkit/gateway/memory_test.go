@@ -0,0 +1,129 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_MemoryGateway(t *testing.T) {
+	t.Run("Get accounts", func(t *testing.T) {
+		gw := NewMemoryGateway()
+		address := flow.HexToAddress("0x01")
+
+		_, err := gw.GetAccount(address)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		gw.AddAccount(&flow.Account{Address: address, Balance: 10})
+		account, err := gw.GetAccount(address)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(10), account.Balance)
+	})
+
+	t.Run("Execute scripts", func(t *testing.T) {
+		gw := NewMemoryGateway()
+		script := []byte(`access(all) fun main(): Int { return 1 }`)
+		gw.SetScriptResult(script, cadence.NewInt(1))
+
+		value, err := gw.ExecuteScript(script, nil)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(1), value)
+
+		value, err = gw.ExecuteScriptAtHeight(script, nil, 0)
+		require.NoError(t, err)
+		assert.Equal(t, cadence.NewInt(1), value)
+
+		_, err = gw.ExecuteScriptAtHeight(script, nil, 5)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = gw.ExecuteScript([]byte("unknown"), nil)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("Seal sent transactions", func(t *testing.T) {
+		gw := NewMemoryGateway()
+		tx := flow.NewTransaction().SetScript([]byte(`transaction {}`))
+
+		_, err := gw.SendSignedTransaction(tx)
+		require.NoError(t, err)
+
+		result, err := gw.GetTransactionResult(tx.ID(), true)
+		require.NoError(t, err)
+		assert.Equal(t, flow.TransactionStatusSealed, result.Status)
+
+		latest, err := gw.GetLatestBlock()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(1), latest.Height)
+		assert.Equal(t, latest.ID, result.BlockID)
+
+		txs, err := gw.GetTransactionsByBlockID(latest.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []*flow.Transaction{tx}, txs)
+		assert.Equal(t, []*flow.Transaction{tx}, gw.SentTransactions())
+
+		gw.HandleTransactions(func(tx *flow.Transaction) *flow.TransactionResult {
+			return &flow.TransactionResult{Status: flow.TransactionStatusSealed, Error: assert.AnError}
+		})
+		failed := flow.NewTransaction().SetScript([]byte(`transaction { prepare() {} }`))
+		_, err = gw.SendSignedTransaction(failed)
+		require.NoError(t, err)
+
+		result, err = gw.GetTransactionResult(failed.ID(), true)
+		require.NoError(t, err)
+		assert.Equal(t, assert.AnError, result.Error)
+	})
+
+	t.Run("Get blocks and events", func(t *testing.T) {
+		gw := NewMemoryGateway()
+		genesis, err := gw.GetLatestBlock()
+		require.NoError(t, err)
+
+		err = gw.AddBlock(&flow.Block{BlockHeader: flow.BlockHeader{ID: flow.HexToID("02"), Height: 2}})
+		assert.EqualError(t, err, "block height 2 doesn't follow the latest block height 0")
+
+		block := &flow.Block{BlockHeader: flow.BlockHeader{ID: flow.HexToID("01"), ParentID: genesis.ID, Height: 1}}
+		require.NoError(t, gw.AddBlock(block))
+
+		result, err := gw.GetBlockByID(block.ID)
+		require.NoError(t, err)
+		assert.Equal(t, block, result)
+
+		gw.AddEvents(flow.BlockEvents{
+			BlockID: block.ID,
+			Height:  1,
+			Events:  []flow.Event{{Type: "A.01.Foo.Bar"}, {Type: "A.01.Foo.Baz"}},
+		})
+
+		events, err := gw.GetEvents("A.01.Foo.Bar", 0, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, []flow.Event{{Type: "A.01.Foo.Bar"}}, events[0].Events)
+
+		events, err = gw.GetEvents("A.01.Foo.Bar", 2, 3)
+		require.NoError(t, err)
+		assert.Len(t, events, 0)
+	})
+}
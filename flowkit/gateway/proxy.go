@@ -0,0 +1,145 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"golang.org/x/net/proxy"
+)
+
+// httpProxies are the proxies of the REST API hosts, other hosts use the proxy environment variables.
+var httpProxies sync.Map
+
+var installProxyTransport sync.Once
+
+// useHTTPProxy sends the REST API requests to the host through the proxy.
+//
+// The REST API client uses the default HTTP client, so the proxy is selected by the host of the request.
+func useHTTPProxy(host string, proxyURL *url.URL) {
+	httpProxies.Store(host, proxyURL)
+
+	installProxyTransport.Do(func() {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			if proxyURL, ok := httpProxies.Load(req.URL.Host); ok {
+				return proxyURL.(*url.URL), nil
+			}
+			return http.ProxyFromEnvironment(req)
+		}
+		http.DefaultClient.Transport = transport
+	})
+}
+
+// proxyDialer returns a gRPC dialer connecting to the hosts through the HTTP or SOCKS5 proxy.
+func proxyDialer(proxyURL *url.URL) (func(context.Context, string) (net.Conn, error), error) {
+	switch proxyURL.Scheme {
+	case "socks5", "socks5h":
+		dialer, err := proxy.FromURL(proxyURL, proxy.Direct)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(ctx context.Context, address string) (net.Conn, error) {
+			if contextDialer, ok := dialer.(proxy.ContextDialer); ok {
+				return contextDialer.DialContext(ctx, "tcp", address)
+			}
+			return dialer.Dial("tcp", address)
+		}, nil
+	case "http", "https":
+		return func(ctx context.Context, address string) (net.Conn, error) {
+			return dialHTTPProxy(ctx, proxyURL, address)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported proxy scheme %s", proxyURL.Scheme)
+	}
+}
+
+// dialHTTPProxy opens a tunnel to the address with an HTTP CONNECT request to the proxy.
+func dialHTTPProxy(ctx context.Context, proxyURL *url.URL, address string) (net.Conn, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", proxyURL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to proxy %s: %w", proxyURL.Host, err)
+	}
+
+	if proxyURL.Scheme == "https" {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: proxyURL.Hostname()})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("failed to connect to proxy %s: %w", proxyURL.Host, err)
+		}
+		conn = tlsConn
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Host: address},
+		Host:   address,
+		Header: make(http.Header),
+	}
+	if proxyURL.User != nil {
+		password, _ := proxyURL.User.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(proxyURL.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to send proxy request: %w", err)
+	}
+
+	reader := bufio.NewReader(conn)
+	res, err := http.ReadResponse(reader, req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to read proxy response: %w", err)
+	}
+	_ = res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf("proxy %s refused connection to %s: %s", proxyURL.Host, address, res.Status)
+	}
+
+	// the host may already send data which was buffered while reading the proxy response
+	if reader.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, reader: reader}, nil
+	}
+
+	return conn, nil
+}
+
+// bufferedConn is a connection reading the data buffered by the reader first.
+type bufferedConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -0,0 +1,141 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_Proxy(t *testing.T) {
+	t.Run("Tunnel through HTTP proxy", func(t *testing.T) {
+		target, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer target.Close()
+		go func() {
+			conn, err := target.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			_, _ = io.Copy(conn, conn)
+		}()
+
+		proxyListener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer proxyListener.Close()
+
+		var authorization string
+		go func() {
+			conn, err := proxyListener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+
+			req, err := http.ReadRequest(bufio.NewReader(conn))
+			if err != nil {
+				return
+			}
+			authorization = req.Header.Get("Proxy-Authorization")
+
+			targetConn, err := net.Dial("tcp", req.Host)
+			if err != nil {
+				return
+			}
+			defer targetConn.Close()
+
+			_, _ = conn.Write([]byte("HTTP/1.1 200 Connection established\r\n\r\n"))
+			go func() { _, _ = io.Copy(targetConn, conn) }()
+			_, _ = io.Copy(conn, targetConn)
+		}()
+
+		proxyURL, err := url.Parse("http://" + proxyListener.Addr().String())
+		require.NoError(t, err)
+		proxyURL.User = url.UserPassword("user", "pass")
+
+		dialer, err := proxyDialer(proxyURL)
+		require.NoError(t, err)
+		conn, err := dialer(context.Background(), target.Addr().String())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.Write([]byte("ping"))
+		require.NoError(t, err)
+		b := make([]byte, 4)
+		_, err = io.ReadFull(conn, b)
+		require.NoError(t, err)
+		assert.Equal(t, "ping", string(b))
+		assert.Equal(t, "Basic dXNlcjpwYXNz", authorization)
+	})
+
+	t.Run("Fail refused HTTP proxy connection", func(t *testing.T) {
+		proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusProxyAuthRequired)
+		}))
+		defer proxyServer.Close()
+
+		proxyURL, err := url.Parse(proxyServer.URL)
+		require.NoError(t, err)
+
+		_, err = dialHTTPProxy(context.Background(), proxyURL, "access.devnet.nodes.onflow.org:9000")
+		assert.ErrorContains(t, err, "refused connection to access.devnet.nodes.onflow.org:9000: 407 Proxy Authentication Required")
+	})
+
+	t.Run("Send REST API requests through proxy", func(t *testing.T) {
+		var host string
+		proxyServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			host = r.URL.Host
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte(`{"code": 503, "message": "service unavailable"}`))
+		}))
+		defer proxyServer.Close()
+
+		gw, err := NewHTTPGateway(config.Network{
+			Name:  "private",
+			Host:  "http://rest.private.example.com:8888",
+			Proxy: proxyServer.URL,
+		})
+		require.NoError(t, err)
+
+		_, err = gw.GetLatestBlock()
+		assert.Error(t, err)
+		assert.Equal(t, "rest.private.example.com:8888", host)
+	})
+
+	t.Run("Create SOCKS5 proxy dialer", func(t *testing.T) {
+		dialer, err := proxyDialer(&url.URL{Scheme: "socks5", Host: "127.0.0.1:1080"})
+		require.NoError(t, err)
+		assert.NotNil(t, dialer)
+
+		_, err = proxyDialer(&url.URL{Scheme: "ftp", Host: "127.0.0.1:21"})
+		assert.EqualError(t, err, "unsupported proxy scheme ftp")
+	})
+}
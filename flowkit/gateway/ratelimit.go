@@ -0,0 +1,124 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	httpAccess "github.com/onflow/flow-go-sdk/access/http"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// maxRateLimitRetries is the number of times a rate-limited request is retried.
+	maxRateLimitRetries = 5
+	// rateLimitBackoff is the initial wait before retrying a rate-limited request, doubled on every retry.
+	rateLimitBackoff = 500 * time.Millisecond
+)
+
+// limiter spaces the requests evenly to not exceed the requests per second.
+type limiter struct {
+	interval time.Duration
+	next     time.Time
+	mu       sync.Mutex
+}
+
+func newLimiter(requestsPerSecond float64) *limiter {
+	return &limiter{
+		interval: time.Duration(float64(time.Second) / requestsPerSecond),
+	}
+}
+
+// wait blocks until the next request is allowed or the context is done.
+func (l *limiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	if l.next.Before(now) {
+		l.next = now
+	}
+	delay := l.next.Sub(now)
+	l.next = l.next.Add(l.interval)
+	l.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// isRateLimitError checks whether the error is a rate-limit response of the access node.
+func isRateLimitError(err error) bool {
+	var httpErr httpAccess.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code == http.StatusTooManyRequests
+	}
+
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	return errors.As(err, &grpcErr) && grpcErr.GRPCStatus().Code() == codes.ResourceExhausted
+}
+
+// RateLimitInterceptor returns an interceptor limiting the requests per second of all the gateway calls,
+// requests are not limited if the limit is zero.
+//
+// Rate-limited requests are retried with an exponential backoff.
+func RateLimitInterceptor(requestsPerSecond float64) Interceptor {
+	var rateLimiter *limiter
+	if requestsPerSecond > 0 {
+		rateLimiter = newLimiter(requestsPerSecond)
+	}
+
+	return rateLimitInterceptor(rateLimiter, rateLimitBackoff)
+}
+
+func rateLimitInterceptor(rateLimiter *limiter, backoff time.Duration) Interceptor {
+	return func(ctx context.Context, method string, invoke Invoker) error {
+		for retry := 0; ; retry++ {
+			if rateLimiter != nil {
+				if err := rateLimiter.wait(ctx); err != nil {
+					return err
+				}
+			}
+
+			err := invoke(ctx)
+			if !isRateLimitError(err) || retry == maxRateLimitRetries {
+				return err
+			}
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff << retry):
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	httpAccess "github.com/onflow/flow-go-sdk/access/http"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+)
+
+func Test_RateLimitInterceptor(t *testing.T) {
+	block := &flow.Block{BlockHeader: flow.BlockHeader{Height: 10}}
+
+	t.Run("Limit requests per second", func(t *testing.T) {
+		rateLimiter := newLimiter(100)
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			require.NoError(t, rateLimiter.wait(context.Background()))
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond)
+	})
+
+	t.Run("Retry rate-limited requests", func(t *testing.T) {
+		gw := &mocks.Gateway{}
+		gw.On("GetLatestBlock").Return(nil, status.Error(codes.ResourceExhausted, "rate limited")).Twice()
+		gw.On("GetLatestBlock").Return(block, nil).Once()
+
+		intercepted := NewInterceptedGateway(gw, rateLimitInterceptor(nil, time.Millisecond))
+		result, err := intercepted.GetLatestBlock()
+		require.NoError(t, err)
+		assert.Equal(t, block, result)
+		gw.AssertExpectations(t)
+	})
+
+	t.Run("Fail after retries", func(t *testing.T) {
+		gw := &mocks.Gateway{}
+		rateLimitErr := httpAccess.HTTPError{Code: http.StatusTooManyRequests, Message: "rate limited"}
+		gw.On("GetLatestBlock").Return(nil, rateLimitErr).Times(maxRateLimitRetries + 1)
+
+		intercepted := NewInterceptedGateway(gw, rateLimitInterceptor(nil, time.Millisecond))
+		_, err := intercepted.GetLatestBlock()
+		assert.ErrorIs(t, err, rateLimitErr)
+		gw.AssertExpectations(t)
+	})
+
+	t.Run("Return other errors", func(t *testing.T) {
+		gw := &mocks.Gateway{}
+		gw.On("GetLatestBlock").Return(nil, status.Error(codes.NotFound, "not found")).Once()
+
+		intercepted := NewInterceptedGateway(gw, rateLimitInterceptor(nil, time.Millisecond))
+		_, err := intercepted.GetLatestBlock()
+		assert.Error(t, err)
+		gw.AssertExpectations(t)
+	})
+}
@@ -153,6 +153,32 @@ func (_m *Services) DeployProject(_a0 context.Context, _a1 flowkit.UpdateContrac
 	return r0, r1
 }
 
+// DeployProjectParallel provides a mock function with given fields: _a0, _a1, _a2
+func (_m *Services) DeployProjectParallel(_a0 context.Context, _a1 flowkit.UpdateContract, _a2 int) ([]*project.Contract, error) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 []*project.Contract
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, flowkit.UpdateContract, int) ([]*project.Contract, error)); ok {
+		return rf(_a0, _a1, _a2)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, flowkit.UpdateContract, int) []*project.Contract); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*project.Contract)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, flowkit.UpdateContract, int) error); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DerivePrivateKeyFromMnemonic provides a mock function with given fields: _a0, _a1, _a2, _a3
 func (_m *Services) DerivePrivateKeyFromMnemonic(_a0 context.Context, _a1 string, _a2 crypto.SigningAlgorithm, _a3 string) (crypto.PrivateKey, error) {
 	ret := _m.Called(_a0, _a1, _a2, _a3)
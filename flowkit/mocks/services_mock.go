@@ -33,6 +33,7 @@ const (
 	buildTransactionFunc             = "BuildTransaction"
 	createAccountFunc                = "CreateAccount"
 	deployProjectFunc                = "DeployProject"
+	deployProjectParallelFunc        = "DeployProjectParallel"
 	derivePrivateKeyFromMnemonicFunc = "DerivePrivateKeyFromMnemonic"
 	gatewayFunc                      = "Gateway"
 	generateKeyFunc                  = "GenerateKey"
@@ -55,6 +56,7 @@ type MockServices struct {
 	BuildTransaction             *mock.Call
 	CreateAccount                *mock.Call
 	DeployProject                *mock.Call
+	DeployProjectParallel        *mock.Call
 	DerivePrivateKeyFromMnemonic *mock.Call
 	Gateway                      *mock.Call
 	GenerateKey                  *mock.Call
@@ -135,6 +137,12 @@ func DefaultMockServices() *MockServices {
 			mock.Anything,
 			mock.AnythingOfType("flowkit.UpdateContract"),
 		),
+		DeployProjectParallel: m.On(
+			deployProjectParallelFunc,
+			mock.Anything,
+			mock.AnythingOfType("flowkit.UpdateContract"),
+			mock.AnythingOfType("int"),
+		),
 		DerivePrivateKeyFromMnemonic: m.On(
 			derivePrivateKeyFromMnemonicFunc,
 			mock.Anything,
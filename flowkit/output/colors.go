@@ -20,20 +20,86 @@ package output
 
 import (
 	"fmt"
+	"os"
 	"runtime"
 )
 
 const (
-	red     = "\033[31m"
-	green   = "\033[32m"
-	magenta = "\033[35m"
-	bold    = "\033[1m"
-	reset   = "\033[0m"
-	italic  = "\033[3m"
+	// ColorAuto uses colors if stdout is a terminal and the NO_COLOR environment variable is not set.
+	ColorAuto   = "auto"
+	ColorAlways = "always"
+	ColorNever  = "never"
 )
 
+const (
+	DefaultTheme      = "default"
+	HighContrastTheme = "high-contrast"
+)
+
+const reset = "\033[0m"
+
+type theme struct {
+	red     string
+	green   string
+	magenta string
+	bold    string
+	italic  string
+}
+
+var themes = map[string]theme{
+	DefaultTheme: {
+		red:     "\033[31m",
+		green:   "\033[32m",
+		magenta: "\033[35m",
+		bold:    "\033[1m",
+		italic:  "\033[3m",
+	},
+	// bold colors without dim styles, readable on both light and dark terminals
+	HighContrastTheme: {
+		red:     "\033[1;31m",
+		green:   "\033[1;32m",
+		magenta: "\033[1;34m",
+		bold:    "\033[1m",
+		italic:  "\033[1m",
+	},
+}
+
+var (
+	colors        = themes[DefaultTheme]
+	colorDisabled = false
+)
+
+// SetColorMode sets whether colors and emoji are used in the output, using one of the color modes.
+func SetColorMode(mode string) error {
+	switch mode {
+	case ColorAlways:
+		colorDisabled = false
+	case ColorNever:
+		colorDisabled = true
+	case ColorAuto, "":
+		_, noColor := os.LookupEnv("NO_COLOR")
+		colorDisabled = noColor || !isTerminal(os.Stdout)
+	default:
+		return fmt.Errorf("invalid color mode %s, options are: %s, %s, %s", mode, ColorAuto, ColorAlways, ColorNever)
+	}
+
+	emojiDisabled = emojiDisabled || colorDisabled
+	return nil
+}
+
+// SetTheme sets the colors used in the output.
+func SetTheme(name string) error {
+	t, ok := themes[name]
+	if !ok {
+		return fmt.Errorf("invalid theme %s, options are: %s, %s", name, DefaultTheme, HighContrastTheme)
+	}
+
+	colors = t
+	return nil
+}
+
 func printColor(msg string, color string) string {
-	if runtime.GOOS == "windows" {
+	if runtime.GOOS == "windows" || colorDisabled {
 		return msg
 	}
 
@@ -41,21 +107,21 @@ func printColor(msg string, color string) string {
 }
 
 func Red(msg string) string {
-	return printColor(msg, red)
+	return printColor(msg, colors.red)
 }
 
 func Green(msg string) string {
-	return printColor(msg, green)
+	return printColor(msg, colors.green)
 }
 
 func Magenta(msg string) string {
-	return printColor(msg, magenta)
+	return printColor(msg, colors.magenta)
 }
 
 func Bold(msg string) string {
-	return printColor(msg, bold)
+	return printColor(msg, colors.bold)
 }
 
 func Italic(msg string) string {
-	return printColor(msg, italic)
+	return printColor(msg, colors.italic)
 }
@@ -0,0 +1,63 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColors(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("colors are not used on windows")
+	}
+	defer func() {
+		colorDisabled, emojiDisabled = false, false
+		colors = themes[DefaultTheme]
+	}()
+
+	t.Run("Themes", func(t *testing.T) {
+		assert.NoError(t, SetColorMode(ColorAlways))
+		assert.Equal(t, "\033[31mfailed\033[0m", Red("failed"))
+
+		assert.NoError(t, SetTheme(HighContrastTheme))
+		assert.Equal(t, "\033[1;31mfailed\033[0m", Red("failed"))
+
+		assert.EqualError(t, SetTheme("neon"), "invalid theme neon, options are: default, high-contrast")
+	})
+
+	t.Run("Never", func(t *testing.T) {
+		assert.NoError(t, SetColorMode(ColorNever))
+		assert.Equal(t, "failed", Red("failed"))
+		assert.Equal(t, "", SuccessEmoji())
+	})
+
+	t.Run("Auto with NO_COLOR", func(t *testing.T) {
+		colorDisabled, emojiDisabled = false, false
+		t.Setenv("NO_COLOR", "1")
+		assert.NoError(t, SetColorMode(ColorAuto))
+		assert.Equal(t, "failed", Bold("failed"))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		assert.EqualError(t, SetColorMode("sometimes"), "invalid color mode sometimes, options are: auto, always, never")
+	})
+}
@@ -20,8 +20,15 @@ package output
 
 import "runtime"
 
+var emojiDisabled = false
+
+// DisableEmoji disables emoji in the output, e.g. for quiet output in CI logs.
+func DisableEmoji() {
+	emojiDisabled = true
+}
+
 func printEmoji(emoji string) string {
-	if runtime.GOOS == "windows" {
+	if runtime.GOOS == "windows" || emojiDisabled {
 		return ""
 	}
 
@@ -19,14 +19,25 @@
 package output
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
 )
 
+// Log levels ordered by verbosity, a logger writes the logs of its level and all lower levels.
 const (
 	NoneLog  = 0
 	ErrorLog = 1
-	DebugLog = 2
-	InfoLog  = 3
+	InfoLog  = 2
+	DebugLog = 3
+)
+
+const (
+	TextLogFormat = "text"
+	JSONLogFormat = "json"
 )
 
 type Logger interface {
@@ -39,42 +50,123 @@ type Logger interface {
 
 // NewStdoutLogger returns a new stdout logger.
 func NewStdoutLogger(level int) *StdoutLogger {
-	return &StdoutLogger{
-		level: level,
+	return NewLogger(os.Stdout, level, TextLogFormat)
+}
+
+// NewStderrLogger returns a new logger writing to stderr,
+// so the logs don't mix with the results written to stdout.
+func NewStderrLogger(level int, format string) *WriterLogger {
+	return NewLogger(os.Stderr, level, format)
+}
+
+// NewLogger returns a new logger writing to the writer in the text or JSON format.
+//
+// The JSON format writes every log as a JSON line with the time, level and message,
+// and progress is logged once at the info level instead of showing a spinner.
+func NewLogger(writer io.Writer, level int, format string) *WriterLogger {
+	return &WriterLogger{
+		level:  level,
+		format: format,
+		writer: writer,
 	}
 }
 
-var _ Logger = &StdoutLogger{}
+var _ Logger = &WriterLogger{}
+
+// StdoutLogger is the logger writing to stdout, kept for compatibility.
+type StdoutLogger = WriterLogger
+
+// WriterLogger is a leveled logging implementation writing to a writer.
+type WriterLogger struct {
+	level      int
+	format     string
+	writer     io.Writer
+	noProgress bool
+	mu         sync.Mutex // guards the spinner, progress bar and writes, so the logger can be used concurrently
+	spinner    *Spinner
+	bar        *Progress
+}
+
+var _ ProgressReporter = &WriterLogger{}
 
-// StdoutLogger is a stdout logging implementation.
-type StdoutLogger struct {
-	level   int
-	spinner *Spinner
+type jsonLog struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Message string    `json:"message"`
 }
 
-func (s *StdoutLogger) log(msg string, level int) {
+// DisableProgress disables the progress spinners, e.g. for quiet output in CI logs.
+func (s *WriterLogger) DisableProgress() {
+	s.noProgress = true
+}
+
+var levelNames = map[int]string{
+	ErrorLog: "error",
+	DebugLog: "debug",
+	InfoLog:  "info",
+}
+
+func (s *WriterLogger) log(msg string, level int) {
 	if s.level < level {
 		return
 	}
 
-	fmt.Printf("%s\n", msg)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.format == JSONLogFormat {
+		line, _ := json.Marshal(jsonLog{
+			Time:    time.Now().UTC(),
+			Level:   levelNames[level],
+			Message: msg,
+		})
+		_, _ = fmt.Fprintf(s.writer, "%s\n", line)
+		return
+	}
+
+	// logs are written above the progress bar, so the bar stays on the last line
+	if s.bar != nil && s.bar.active() {
+		s.bar.clear()
+		_, _ = fmt.Fprintf(s.writer, "%s\n", msg)
+		s.bar.draw(time.Now())
+		return
+	}
+
+	_, _ = fmt.Fprintf(s.writer, "%s\n", msg)
 }
 
-func (s *StdoutLogger) Info(msg string) {
+func (s *WriterLogger) Info(msg string) {
 	s.StopProgress()
 	s.log(msg, InfoLog)
 }
 
-func (s *StdoutLogger) Debug(msg string) {
+func (s *WriterLogger) Debug(msg string) {
 	s.log(msg, DebugLog)
 }
 
-func (s *StdoutLogger) Error(msg string) {
+func (s *WriterLogger) Error(msg string) {
+	if s.format == JSONLogFormat {
+		s.log(msg, ErrorLog)
+		return
+	}
 	s.log(fmt.Sprintf("%s %s", ErrorEmoji(), Red(msg)), ErrorLog)
 }
 
-func (s *StdoutLogger) StartProgress(msg string) {
-	if s.level == NoneLog {
+func (s *WriterLogger) StartProgress(msg string) {
+	if s.level == NoneLog || s.noProgress {
+		return
+	}
+
+	if s.format == JSONLogFormat {
+		s.log(msg, InfoLog)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the progress bar already shows the progress of the operation
+	if s.bar != nil && s.bar.active() {
 		return
 	}
 
@@ -83,16 +175,57 @@ func (s *StdoutLogger) StartProgress(msg string) {
 	}
 
 	s.spinner = NewSpinner(msg, "")
+	s.spinner.out = s.writer
 	s.spinner.Start()
 }
 
-func (s *StdoutLogger) StopProgress() {
+func (s *WriterLogger) StopProgress() {
 	if s.level == NoneLog {
 		return
 	}
 
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
 	if s.spinner != nil {
 		s.spinner.Stop()
 		s.spinner = nil
 	}
 }
+
+// Progress returns the callback reporting the progress of the operation with the label,
+// progress is drawn as a bar on a terminal with the text format, otherwise it's logged periodically at the info level.
+func (s *WriterLogger) Progress(label string) ProgressFunc {
+	if s.level < InfoLog || s.noProgress {
+		return func(int, int) {}
+	}
+
+	s.StopProgress()
+
+	bar := &Progress{
+		label:    label,
+		writer:   s.writer,
+		log:      func(msg string) { s.log(msg, InfoLog) },
+		terminal: s.format != JSONLogFormat && isTerminal(s.writer),
+		lock:     &s.mu,
+	}
+
+	s.mu.Lock()
+	s.bar = bar
+	s.mu.Unlock()
+
+	return bar.Update
+}
+
+// EndProgress ends the progress bar of an operation which didn't finish, e.g. because it failed,
+// so the following output is written on a new line.
+func (s *WriterLogger) EndProgress() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.bar != nil && s.bar.active() {
+		_, _ = fmt.Fprint(s.writer, "\n")
+		s.bar.finished = true
+		s.bar.drawn = false
+	}
+}
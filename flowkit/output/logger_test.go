@@ -0,0 +1,81 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogger(t *testing.T) {
+	t.Run("Text levels", func(t *testing.T) {
+		var b bytes.Buffer
+		logger := NewLogger(&b, ErrorLog, TextLogFormat)
+		logger.Info("info")
+		logger.Debug("debug")
+		logger.Error("failed")
+
+		assert.NotContains(t, b.String(), "info")
+		assert.NotContains(t, b.String(), "debug")
+		assert.Contains(t, b.String(), "failed")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var b bytes.Buffer
+		logger := NewLogger(&b, DebugLog, JSONLogFormat)
+		logger.StartProgress("loading")
+		logger.StopProgress()
+		logger.Debug("debug")
+		logger.Error("failed")
+
+		lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+		require.Len(t, lines, 3)
+
+		var entries []jsonLog
+		for _, line := range lines {
+			var entry jsonLog
+			require.NoError(t, json.Unmarshal([]byte(line), &entry))
+			entries = append(entries, entry)
+		}
+
+		assert.Equal(t, "info", entries[0].Level)
+		assert.Equal(t, "loading", entries[0].Message)
+		assert.Equal(t, "debug", entries[1].Level)
+		assert.Equal(t, "error", entries[2].Level)
+		assert.Equal(t, "failed", entries[2].Message)
+	})
+}
+
+func TestQuiet(t *testing.T) {
+	var b bytes.Buffer
+	logger := NewLogger(&b, InfoLog, TextLogFormat)
+	logger.DisableProgress()
+	logger.StartProgress("loading")
+	assert.Nil(t, logger.spinner)
+	logger.StopProgress()
+
+	DisableEmoji()
+	defer func() { emojiDisabled = false }()
+	assert.Equal(t, "", OkEmoji())
+}
@@ -0,0 +1,191 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProgressFunc is called by long operations with the number of completed and total steps.
+type ProgressFunc func(done int, total int)
+
+// ProgressReporter is implemented by loggers which report the progress of long operations.
+type ProgressReporter interface {
+	// Progress returns the callback reporting the progress of the operation with the label.
+	Progress(label string) ProgressFunc
+}
+
+// LoggerProgress returns the progress callback of the logger, or a callback reporting nothing
+// if the logger doesn't report progress.
+func LoggerProgress(logger Logger, label string) ProgressFunc {
+	if reporter, ok := logger.(ProgressReporter); ok {
+		return reporter.Progress(label)
+	}
+	return func(int, int) {}
+}
+
+const (
+	progressBarWidth    = 30
+	progressLogInterval = 5 * time.Second
+)
+
+// Progress reports the progress of a long operation.
+//
+// On a terminal a bar with the estimated remaining time is redrawn in place,
+// otherwise a log line is written when the operation starts, finishes and periodically in between.
+type Progress struct {
+	label    string
+	writer   io.Writer
+	log      func(string)
+	terminal bool
+	lock     sync.Locker
+
+	start    time.Time
+	logged   time.Time
+	done     int
+	total    int
+	drawn    bool
+	finished bool
+}
+
+// NewProgress returns a progress writing to the writer, the bar is only drawn if the writer is a terminal.
+func NewProgress(writer io.Writer, label string) *Progress {
+	return &Progress{
+		label:    label,
+		writer:   writer,
+		log:      func(msg string) { _, _ = fmt.Fprintf(writer, "%s\n", msg) },
+		terminal: isTerminal(writer),
+		lock:     &sync.Mutex{},
+	}
+}
+
+// Update reports the number of completed and total steps, it can be used as a ProgressFunc.
+func (p *Progress) Update(done int, total int) {
+	p.lock.Lock()
+	if p.finished {
+		p.lock.Unlock()
+		return
+	}
+
+	now := time.Now()
+	if p.start.IsZero() {
+		p.start = now
+	}
+	p.done, p.total = done, total
+	p.finished = total > 0 && done >= total
+
+	if p.terminal {
+		p.draw(now)
+		if p.finished {
+			_, _ = fmt.Fprint(p.writer, "\n")
+			p.drawn = false
+		}
+		p.lock.Unlock()
+		return
+	}
+
+	var line string
+	if p.finished || p.logged.IsZero() || now.Sub(p.logged) >= progressLogInterval {
+		p.logged = now
+		line = p.line(now)
+	}
+	p.lock.Unlock()
+
+	// the log function can use the same lock, so it's called after unlocking
+	if line != "" {
+		p.log(line)
+	}
+}
+
+// active checks whether the bar is drawn and not yet finished, the lock must be held.
+func (p *Progress) active() bool {
+	return p.drawn && !p.finished
+}
+
+// clear removes the drawn bar from the terminal line, the lock must be held.
+func (p *Progress) clear() {
+	if p.drawn {
+		_, _ = fmt.Fprint(p.writer, "\r\033[K")
+	}
+}
+
+// draw the bar in place of the current terminal line, the lock must be held.
+func (p *Progress) draw(now time.Time) {
+	filled := 0
+	if p.total > 0 {
+		filled = progressBarWidth * p.done / p.total
+	}
+	if filled > progressBarWidth {
+		filled = progressBarWidth
+	}
+
+	_, _ = fmt.Fprintf(
+		p.writer,
+		"\r\033[K%s [%s%s] %s",
+		p.label,
+		strings.Repeat("=", filled),
+		strings.Repeat(" ", progressBarWidth-filled),
+		p.status(now),
+	)
+	p.drawn = true
+}
+
+func (p *Progress) line(now time.Time) string {
+	return fmt.Sprintf("%s: %s", p.label, p.status(now))
+}
+
+// status formats the completed steps, percentage and the estimated remaining or the elapsed time when finished.
+func (p *Progress) status(now time.Time) string {
+	percent := 0
+	if p.total > 0 {
+		percent = 100 * p.done / p.total
+	}
+	status := fmt.Sprintf("%d/%d (%d%%)", p.done, p.total, percent)
+
+	elapsed := now.Sub(p.start)
+	switch {
+	case p.finished:
+		return fmt.Sprintf("%s in %s", status, elapsed.Round(time.Second))
+	case p.done > 0:
+		remaining := time.Duration(float64(elapsed) / float64(p.done) * float64(p.total-p.done))
+		return fmt.Sprintf("%s ETA %s", status, remaining.Round(time.Second))
+	default:
+		return status
+	}
+}
+
+// isTerminal checks whether the writer is a terminal, so the output can be redrawn in place.
+func isTerminal(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+	if !ok {
+		return false
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,73 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProgress(t *testing.T) {
+	t.Run("Log lines", func(t *testing.T) {
+		var b bytes.Buffer
+		progress := NewProgress(&b, "Fetching events")
+		progress.Update(0, 4)
+		progress.Update(1, 4)
+		progress.Update(4, 4)
+		progress.Update(4, 4)
+
+		lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+		assert.Len(t, lines, 2)
+		assert.Equal(t, "Fetching events: 0/4 (0%)", lines[0])
+		assert.Equal(t, "Fetching events: 4/4 (100%) in 0s", lines[1])
+	})
+
+	t.Run("Bar", func(t *testing.T) {
+		var b bytes.Buffer
+		progress := NewProgress(&b, "Deploying")
+		progress.terminal = true
+		progress.Update(1, 2)
+
+		assert.Contains(t, b.String(), "Deploying [===============               ] 1/2 (50%) ETA")
+		assert.True(t, progress.active())
+
+		progress.Update(2, 2)
+		assert.False(t, progress.active())
+		assert.True(t, strings.HasSuffix(b.String(), "\n"))
+	})
+
+	t.Run("Logger", func(t *testing.T) {
+		var b bytes.Buffer
+		logger := NewLogger(&b, InfoLog, JSONLogFormat)
+		progress := LoggerProgress(logger, "Scanning")
+		progress(0, 2)
+		progress(2, 2)
+		assert.Contains(t, b.String(), `"message":"Scanning: 0/2 (0%)"`)
+		assert.Contains(t, b.String(), `"message":"Scanning: 2/2 (100%) in 0s"`)
+
+		b.Reset()
+		quiet := NewLogger(&b, InfoLog, TextLogFormat)
+		quiet.DisableProgress()
+		LoggerProgress(quiet, "Scanning")(1, 2)
+		assert.Empty(t, b.String())
+	})
+}
@@ -20,6 +20,8 @@ package output
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/gosuri/uilive"
@@ -30,6 +32,7 @@ var spinnerCharset = []rune{'⠋', '⠙', '⠹', '⠸', '⠼', '⠴', '⠦', '
 type Spinner struct {
 	prefix string
 	suffix string
+	out    io.Writer
 	done   chan string
 }
 
@@ -37,6 +40,7 @@ func NewSpinner(prefix, suffix string) *Spinner {
 	return &Spinner{
 		prefix: prefix,
 		suffix: suffix,
+		out:    os.Stdout,
 		done:   make(chan string),
 	}
 }
@@ -47,6 +51,7 @@ func (s *Spinner) Start() {
 
 func (s *Spinner) run() {
 	writer := uilive.New()
+	writer.Out = s.out
 
 	ticker := time.NewTicker(100 * time.Millisecond)
 
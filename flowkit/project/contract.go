@@ -31,6 +31,7 @@ type Contract struct {
 	AccountAddress flow.Address
 	AccountName    string
 	Args           []cadence.Value
+	TransactionID  flow.Identifier // ID of the transaction that deployed the contract, empty if it wasn't deployed
 }
 
 func NewContract(
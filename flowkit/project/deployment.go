@@ -20,10 +20,14 @@ package project
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"gonum.org/v1/gonum/graph"
 	"gonum.org/v1/gonum/graph/simple"
 	"gonum.org/v1/gonum/graph/topo"
+
+	"github.com/onflow/flow-cli/flowkit/arguments"
 )
 
 type deployContract struct {
@@ -92,6 +96,25 @@ func (d *Deployment) add(contract *Contract) error {
 	return nil
 }
 
+// ValidateArguments validates the initializer arguments of all the contracts before any contract is deployed.
+//
+// All the invalid contracts are reported in the returned error.
+func (d *Deployment) ValidateArguments() error {
+	invalid := make([]string, 0)
+	for _, c := range d.contracts {
+		err := arguments.ValidateInitializer(c.Args, c.code, c.location)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("  - %s: %s", c.Name, err))
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid contract initializer arguments:\n%s", strings.Join(invalid, "\n"))
+	}
+
+	return nil
+}
+
 // Sort contracts by deployment order.
 //
 // Order of sorting is dependent on the possible imports contract contains, since
@@ -120,6 +143,23 @@ func (d *Deployment) Sort() ([]*Contract, error) {
 	return contracts, nil
 }
 
+// Dependencies returns the deployment contracts imported by the contract.
+//
+// Dependencies are resolved when the deployment is sorted.
+func (d *Deployment) Dependencies(contract *Contract) []*Contract {
+	c, ok := d.contractsByName[contract.Name]
+	if !ok {
+		return nil
+	}
+
+	deps := make([]*Contract, 0, len(c.dependencies))
+	for _, dep := range c.sortedDependencies() {
+		deps = append(deps, dep.Contract)
+	}
+
+	return deps
+}
+
 // conflictExists returns true if the same contract is configured to deploy to more than one account for the same network.
 func (d *Deployment) conflictExists() bool {
 	uniq := make(map[string]bool)
@@ -185,7 +225,10 @@ func sortByDeploymentOrder(contracts []*deployContract) ([]*deployContract, erro
 	}
 
 	for _, c := range contracts {
-		for _, dep := range c.dependencies {
+		for _, dep := range c.sortedDependencies() {
+			if dep == c { // graph doesn't allow self edges
+				return nil, &CyclicImportError{Cycles: [][]*deployContract{{c}}}
+			}
 			g.SetEdge(g.NewEdge(dep, c))
 		}
 	}
@@ -194,7 +237,7 @@ func sortByDeploymentOrder(contracts []*deployContract) ([]*deployContract, erro
 	if err != nil {
 		switch topoErr := err.(type) {
 		case topo.Unorderable:
-			return nil, &CyclicImportError{Cycles: nodeSetsToContractSets(topoErr)}
+			return nil, &CyclicImportError{Cycles: importCycles(topoErr)}
 		default:
 			return nil, err
 		}
@@ -203,14 +246,70 @@ func sortByDeploymentOrder(contracts []*deployContract) ([]*deployContract, erro
 	return nodesToContracts(sorted), nil
 }
 
-func nodeSetsToContractSets(nodes [][]graph.Node) [][]*deployContract {
-	contracts := make([][]*deployContract, len(nodes))
+// sortedDependencies returns the dependencies of the contract in the order contracts were added to the deployment.
+func (d *deployContract) sortedDependencies() []*deployContract {
+	deps := make([]*deployContract, 0, len(d.dependencies))
+	for _, dep := range d.dependencies {
+		deps = append(deps, dep)
+	}
+	sort.Slice(deps, func(i, j int) bool { return deps[i].index < deps[j].index })
 
-	for i, s := range nodes {
-		contracts[i] = nodesToContracts(s)
+	return deps
+}
+
+// importCycles converts the strongly connected components which can't be ordered to import cycles.
+//
+// Each cycle lists the contracts in the import order, where each contract imports the next one and the last
+// contract imports the first one. The cycles are sorted by the order contracts were added to the deployment.
+func importCycles(components [][]graph.Node) [][]*deployContract {
+	cycles := make([][]*deployContract, 0, len(components))
+
+	for _, component := range components {
+		contracts := nodesToContracts(component)
+		sort.Slice(contracts, func(i, j int) bool { return contracts[i].index < contracts[j].index })
+		cycles = append(cycles, findCycle(contracts))
 	}
 
-	return contracts
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0].index < cycles[j][0].index })
+
+	return cycles
+}
+
+// findCycle finds the shortest import path from the first contract of the component back to itself.
+func findCycle(component []*deployContract) []*deployContract {
+	start := component[0]
+	inComponent := make(map[*deployContract]bool, len(component))
+	for _, c := range component {
+		inComponent[c] = true
+	}
+
+	previous := make(map[*deployContract]*deployContract)
+	queue := []*deployContract{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, dep := range current.sortedDependencies() {
+			if !inComponent[dep] {
+				continue
+			}
+
+			if dep == start {
+				cycle := []*deployContract{current}
+				for cycle[0] != start {
+					cycle = append([]*deployContract{previous[cycle[0]]}, cycle...)
+				}
+				return cycle
+			}
+
+			if _, visited := previous[dep]; !visited {
+				previous[dep] = current
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	return component // not reachable for strongly connected components
 }
 
 func nodesToContracts(nodes []graph.Node) []*deployContract {
@@ -225,20 +324,28 @@ func nodesToContracts(nodes []graph.Node) []*deployContract {
 
 // CyclicImportError is returned when contract contain cyclic imports one to the
 // other which is not possible to be resolved and deployed.
+//
+// Each cycle lists the contracts in the import order, the last contract imports the first one.
 type CyclicImportError struct {
 	Cycles [][]*deployContract
 }
 
-func (e *CyclicImportError) contractNames() [][]string {
-	cycles := make([][]string, 0, len(e.Cycles))
+// describeCycles returns readable descriptions of the cycles, e.g. "A (alice) imports B (bob) imports A (alice)".
+func (e *CyclicImportError) describeCycles() []string {
+	cycles := make([]string, 0, len(e.Cycles))
 
 	for _, cycle := range e.Cycles {
-		contracts := make([]string, 0, len(cycle))
+		contracts := make([]string, 0, len(cycle)+1)
 		for _, contract := range cycle {
-			contracts = append(contracts, contract.Name)
+			name := contract.Name
+			if contract.AccountName != "" {
+				name = fmt.Sprintf("%s (%s)", contract.Name, contract.AccountName)
+			}
+			contracts = append(contracts, name)
 		}
+		contracts = append(contracts, contracts[0])
 
-		cycles = append(cycles, contracts)
+		cycles = append(cycles, strings.Join(contracts, " imports "))
 	}
 
 	return cycles
@@ -246,7 +353,7 @@ func (e *CyclicImportError) contractNames() [][]string {
 
 func (e *CyclicImportError) Error() string {
 	return fmt.Sprintf(
-		"contracts: import cycle(s) detected: %v",
-		e.contractNames(),
+		"contracts: import cycle(s) detected: %s",
+		strings.Join(e.describeCycles(), ", "),
 	)
 }
@@ -22,6 +22,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/test"
 	"github.com/stretchr/testify/assert"
@@ -211,3 +212,66 @@ func TestContractDeploymentOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestContractDeploymentCycles(t *testing.T) {
+	newContract := func(name string, account string, code string) *Contract {
+		return NewContract(name, name+".cdc", []byte(code), addresses.New(), account, nil)
+	}
+
+	t.Run("Cycle across accounts", func(t *testing.T) {
+		deployment, err := NewDeployment([]*Contract{
+			newContract("A", "alice", `import B from "B.cdc"
+				pub contract A {}`),
+			newContract("B", "bob", `import C from "C.cdc"
+				pub contract B {}`),
+			newContract("C", "charlie", `import A from "A.cdc"
+				pub contract C {}`),
+			newContract("D", "alice", `pub contract D {}`),
+		}, nil)
+		require.NoError(t, err)
+
+		_, err = deployment.Sort()
+		assert.EqualError(t, err, "contracts: import cycle(s) detected: A (alice) imports B (bob) imports C (charlie) imports A (alice)")
+	})
+
+	t.Run("Self import", func(t *testing.T) {
+		deployment, err := NewDeployment([]*Contract{
+			newContract("A", "", `import A from "A.cdc"
+				pub contract A {}`),
+		}, nil)
+		require.NoError(t, err)
+
+		_, err = deployment.Sort()
+		assert.EqualError(t, err, "contracts: import cycle(s) detected: A imports A")
+	})
+}
+
+func TestContractDeploymentArguments(t *testing.T) {
+	newContract := func(name string, code string, args []cadence.Value) *Contract {
+		return NewContract(name, name+".cdc", []byte(code), addresses.New(), "alice", args)
+	}
+
+	t.Run("Valid arguments", func(t *testing.T) {
+		deployment, err := NewDeployment([]*Contract{
+			newContract("A", `pub contract A { init(a: String) {} }`, []cadence.Value{cadence.String("a")}),
+			newContract("B", `pub contract B {}`, nil),
+		}, nil)
+		require.NoError(t, err)
+
+		assert.NoError(t, deployment.ValidateArguments())
+	})
+
+	t.Run("Invalid arguments", func(t *testing.T) {
+		deployment, err := NewDeployment([]*Contract{
+			newContract("A", `pub contract A { init(a: String) {} }`, []cadence.Value{cadence.NewUInt64(1)}),
+			newContract("B", `pub contract B { init(b: UInt64, c: Bool) {} }`, []cadence.Value{cadence.NewUInt64(1)}),
+			newContract("C", `pub contract C {}`, nil),
+		}, nil)
+		require.NoError(t, err)
+
+		err = deployment.ValidateArguments()
+		assert.EqualError(t, err, "invalid contract initializer arguments:\n"+
+			"  - A: argument `a` is not expected type `String`\n"+
+			"  - B: argument count is 1, expected 2")
+	})
+}
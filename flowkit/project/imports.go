@@ -44,28 +44,51 @@ func NewImportReplacer(contracts []*Contract, aliases LocationAliases) *ImportRe
 }
 
 func (i *ImportReplacer) Replace(program *Program) (*Program, error) {
-	imports := program.imports()
+	imports, err := i.Imports(program)
+	if err != nil {
+		return nil, err
+	}
+
+	for imp, address := range imports {
+		program.replaceImport(imp, address)
+	}
+
+	return program, nil
+}
+
+// Imports resolves the program imports and returns a map with import locations as keys and addresses
+// the imports resolve to as values.
+func (i *ImportReplacer) Imports(program *Program) (map[string]string, error) {
+	imports := make(map[string]string)
 	contractsLocations := i.getContractsLocations()
 
-	for _, imp := range imports {
+	for _, imp := range program.imports() {
 		// check if import by path exists (e.g. import X from ["./X.cdc"])
 		importLocation := path.Clean(absolutePath(program.Location(), imp))
 		address, isPath := contractsLocations[importLocation]
 		if isPath {
-			program.replaceImport(imp, address)
+			imports[imp] = address
 			continue
 		}
 		// check if import by identifier exists (e.g. import ["X"])
 		address, isIdentifier := contractsLocations[imp]
 		if isIdentifier {
-			program.replaceImport(imp, address)
+			imports[imp] = address
 			continue
 		}
 
+		if isIdentifierImport(imp) {
+			return nil, fmt.Errorf(
+				"import \"%s\" could not be resolved, make sure contract %s is added to the deployments or has an alias on the network",
+				imp,
+				imp,
+			)
+		}
+
 		return nil, fmt.Errorf("import %s could not be resolved from provided contracts", imp)
 	}
 
-	return program, nil
+	return imports, nil
 }
 
 // getContractsLocations return a map with contract locations as keys and addresses where they are deployed as values.
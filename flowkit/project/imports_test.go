@@ -135,4 +135,66 @@ func TestResolver(t *testing.T) {
 		assert.Equal(t, cleanCode(expected), cleanCode(replaced.Code()))
 	})
 
+	t.Run("Resolve namespaced imports", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("core.Foo", "../core/Foo.cdc", nil, flow.HexToAddress("0x1"), "", nil),
+		}
+
+		replacer := NewImportReplacer(contracts, nil)
+
+		program, err := NewProgram([]byte(`
+			import "core.Foo"
+
+			pub contract Zoo {}
+		`), nil, "./Zoo.cdc")
+		require.NoError(t, err)
+		assert.False(t, program.HasPathImports())
+
+		replaced, err := replacer.Replace(program)
+		require.NoError(t, err)
+
+		expected := []byte(`
+			import Foo from 0x0000000000000001
+
+			pub contract Zoo {}
+		`)
+
+		assert.Equal(t, cleanCode(expected), cleanCode(replaced.Code()))
+	})
+
+	t.Run("Resolved imports", func(t *testing.T) {
+		contracts := []*Contract{
+			NewContract("Bar", "./Bar.cdc", nil, flow.HexToAddress("0x2"), "", nil),
+			NewContract("Foo", "./Foo.cdc", nil, flow.HexToAddress("0x1"), "", nil),
+		}
+
+		replacer := NewImportReplacer(contracts, nil)
+
+		program, err := NewProgram([]byte(`
+			import Foo from "./Foo.cdc"
+			import "Bar"
+
+			pub contract Zoo {}
+		`), nil, "./Zoo.cdc")
+		require.NoError(t, err)
+
+		imports, err := replacer.Imports(program)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"./Foo.cdc": "0000000000000001",
+			"Bar":       "0000000000000002",
+		}, imports)
+
+		program, err = NewProgram([]byte(`import "Missing"`), nil, "./Zoo.cdc")
+		require.NoError(t, err)
+
+		_, err = replacer.Imports(program)
+		assert.EqualError(t, err, `import "Missing" could not be resolved, make sure contract Missing is added to the deployments or has an alias on the network`)
+
+		program, err = NewProgram([]byte(`import Missing from "./Missing.cdc"`), nil, "./Zoo.cdc")
+		require.NoError(t, err)
+
+		_, err = replacer.Imports(program)
+		assert.EqualError(t, err, "import ./Missing.cdc could not be resolved from provided contracts")
+	})
 }
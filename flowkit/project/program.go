@@ -21,11 +21,14 @@ package project
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/common"
 	"github.com/onflow/cadence/runtime/parser"
+
+	"github.com/onflow/flow-cli/flowkit/config"
 )
 
 type Program struct {
@@ -71,15 +74,37 @@ func (p *Program) HasImports() bool {
 	return len(p.imports()) > 0
 }
 
+// HasPathImports checks if any of the imports is a file path, which is resolved relative to the program location.
+func (p *Program) HasPathImports() bool {
+	for _, imp := range p.imports() {
+		if !isIdentifierImport(imp) {
+			return true
+		}
+	}
+	return false
+}
+
+var identifierImportRegex = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_-]*\.)?[A-Za-z_][A-Za-z0-9_]*$`)
+
+// isIdentifierImport checks if the import location is a contract name, e.g. import "Foo", optionally
+// namespaced with the name of the referenced project, e.g. import "core.Foo".
+func isIdentifierImport(location string) bool {
+	return identifierImportRegex.MatchString(location) && !strings.HasSuffix(location, ".cdc")
+}
+
 func (p *Program) replaceImport(from string, to string) *Program {
 	code := string(p.Code())
 
-	pathRegex := regexp.MustCompile(fmt.Sprintf(`import\s+(\w+)\s+from\s+"%s"`, from))
-	identifierRegex := regexp.MustCompile(fmt.Sprintf(`import\s+"(%s)"`, from))
+	pathRegex := regexp.MustCompile(fmt.Sprintf(`import\s+(\w+)\s+from\s+"%s"`, regexp.QuoteMeta(from)))
+	identifierRegex := regexp.MustCompile(fmt.Sprintf(`import\s+"%s"`, regexp.QuoteMeta(from)))
 
-	replacement := fmt.Sprintf(`import $1 from 0x%s`, to)
-	code = pathRegex.ReplaceAllString(code, replacement)
-	code = identifierRegex.ReplaceAllString(code, replacement)
+	code = pathRegex.ReplaceAllString(code, fmt.Sprintf(`import $1 from 0x%s`, to))
+	// namespaced imports of referenced projects import the contract by its name, e.g. import "core.Foo"
+	name := from
+	if isIdentifierImport(from) {
+		name = config.BaseName(from)
+	}
+	code = identifierRegex.ReplaceAllString(code, fmt.Sprintf(`import %s from 0x%s`, name, to))
 
 	p.code = []byte(code)
 	p.reload()
@@ -115,6 +140,54 @@ func (p *Program) Name() (string, error) {
 	return "", fmt.Errorf("unable to determine contract name")
 }
 
+// InitializerParameters returns the names of the contract initializer parameters in declared order.
+func (p *Program) InitializerParameters() []string {
+	params := make([]string, 0)
+
+	contract := p.astProgram.SoleContractDeclaration()
+	if contract == nil {
+		return params
+	}
+
+	initializers := contract.Members.Initializers()
+	if len(initializers) != 1 || initializers[0].FunctionDeclaration.ParameterList == nil {
+		return params
+	}
+
+	for _, param := range initializers[0].FunctionDeclaration.ParameterList.Parameters {
+		params = append(params, param.Identifier.Identifier)
+	}
+
+	return params
+}
+
+// Events returns the names of the events declared in the contract or contract interface in declared order.
+func (p *Program) Events() []string {
+	events := make([]string, 0)
+
+	var members []*ast.Members
+	for _, compositeDeclaration := range p.astProgram.CompositeDeclarations() {
+		if compositeDeclaration.CompositeKind == common.CompositeKindContract {
+			members = append(members, compositeDeclaration.Members)
+		}
+	}
+	for _, interfaceDeclaration := range p.astProgram.InterfaceDeclarations() {
+		if interfaceDeclaration.CompositeKind == common.CompositeKindContract {
+			members = append(members, interfaceDeclaration.Members)
+		}
+	}
+
+	for _, m := range members {
+		for _, composite := range m.Composites() {
+			if composite.CompositeKind == common.CompositeKindEvent {
+				events = append(events, composite.Identifier.Identifier)
+			}
+		}
+	}
+
+	return events
+}
+
 func (p *Program) reload() {
 	astProgram, err := parser.ParseProgram(nil, p.code, parser.Config{})
 	if err != nil {
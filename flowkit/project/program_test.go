@@ -30,8 +30,9 @@ func TestProgram(t *testing.T) {
 
 	t.Run("Imports", func(t *testing.T) {
 		tests := []struct {
-			code    []byte
-			imports []string
+			code        []byte
+			imports     []string
+			pathImports bool
 		}{{
 			code:    []byte(`pub contract Foo {}`),
 			imports: []string{},
@@ -43,14 +44,16 @@ func TestProgram(t *testing.T) {
 				import Bar from "./Bar.cdc"
 				pub contract Foo {}
 			`),
-			imports: []string{"./Bar.cdc"},
+			imports:     []string{"./Bar.cdc"},
+			pathImports: true,
 		}, {
 			code: []byte(`
 				import Bar from "./Bar.cdc"
 				import Zoo from "./zoo/Zoo.cdc"
 				pub contract Foo {}
 			`),
-			imports: []string{"./Bar.cdc", "./zoo/Zoo.cdc"},
+			imports:     []string{"./Bar.cdc", "./zoo/Zoo.cdc"},
+			pathImports: true,
 		}, { // new schema import
 			code: []byte(`
 				import "Bar"
@@ -66,7 +69,8 @@ func TestProgram(t *testing.T) {
 
 				pub contract Foo {}
 			`),
-			imports: []string{"Bar", "./Zoo.cdc"},
+			imports:     []string{"Bar", "./Zoo.cdc"},
+			pathImports: true,
 		}}
 
 		for i, test := range tests {
@@ -74,6 +78,7 @@ func TestProgram(t *testing.T) {
 			require.NoError(t, err, fmt.Sprintf("import test %d failed", i))
 			assert.Equal(t, len(test.imports) > 0, program.HasImports(), fmt.Sprintf("import test %d failed", i))
 			assert.Equal(t, test.imports, program.imports(), fmt.Sprintf("import test %d failed", i))
+			assert.Equal(t, test.pathImports, program.HasPathImports(), fmt.Sprintf("import test %d failed", i))
 		}
 	})
 
@@ -129,6 +134,22 @@ func TestProgram(t *testing.T) {
 		assert.EqualError(t, err, "unable to determine contract name")
 	})
 
+	t.Run("Events", func(t *testing.T) {
+		program, err := NewProgram([]byte(`
+			pub contract Foo {
+				pub event Deposited(amount: UFix64)
+				pub resource Vault {}
+				pub event Withdrawn(amount: UFix64)
+			}
+		`), nil, "")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Deposited", "Withdrawn"}, program.Events())
+
+		program, err = NewProgram([]byte(`pub fun main() {}`), nil, "")
+		require.NoError(t, err)
+		assert.Empty(t, program.Events())
+	})
+
 	t.Run("Replace", func(t *testing.T) {
 		code := []byte(`
 			import Foo from "./Foo.cdc"
@@ -93,6 +93,13 @@ type Services interface {
 	// If contracts already exist use UpdateExistingContract(bool) to define whether a contract should be updated or not.
 	DeployProject(context.Context, UpdateContract) ([]*project.Contract, error)
 
+	// DeployProjectParallel deploys the project contracts like DeployProject, but deploys contracts of different
+	// accounts concurrently to at most the provided number of accounts at a time.
+	//
+	// Contracts importing a contract that failed to deploy are not deployed. Values lower than 2 deploy
+	// contracts one by one.
+	DeployProjectParallel(context.Context, UpdateContract, int) ([]*project.Contract, error)
+
 	// ExecuteScript on the Flow network and return the Cadence value as a result. The script is executed at the
 	// block provided as part of the ScriptQuery value.
 	ExecuteScript(context.Context, Script, ScriptQuery) (cadence.Value, error)
@@ -24,6 +24,7 @@ import (
 	"path"
 	"path/filepath"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/pkg/errors"
 	"golang.org/x/exp/slices"
@@ -46,6 +47,7 @@ type State struct {
 	confLoader   *config.Loader
 	readerWriter ReaderWriter
 	accounts     *accounts.Accounts
+	profile      *config.Profile
 }
 
 // ReaderWriter retrieve current file reader writer.
@@ -119,6 +121,33 @@ func (p *State) Config() *config.Config {
 	return p.conf
 }
 
+// Profile returns the selected deployment profile or nil if no profile is selected.
+func (p *State) Profile() *config.Profile {
+	return p.profile
+}
+
+// DeploymentAccountName returns the name of the account the deployments for the account name are deployed to,
+// which is the account mapped by the selected profile or the same account if not mapped.
+func (p *State) DeploymentAccountName(name string) string {
+	if p.profile == nil {
+		return name
+	}
+
+	return p.profile.AccountName(name)
+}
+
+// DeploymentByAccountAndNetwork returns the deployment for the network which is deployed to the account,
+// taking the account mapping of the selected profile into account.
+func (p *State) DeploymentByAccountAndNetwork(account string, network string) *config.Deployment {
+	for i, d := range p.conf.Deployments {
+		if d.Network == network && p.DeploymentAccountName(d.Account) == account {
+			return &p.conf.Deployments[i]
+		}
+	}
+
+	return nil
+}
+
 // EmulatorServiceAccount returns the service account for the default emulator profile.
 func (p *State) EmulatorServiceAccount() (*accounts.Account, error) {
 	emulator := p.conf.Emulators.Default()
@@ -144,7 +173,7 @@ func (p *State) DeploymentContractsByNetwork(network config.Network) ([]*project
 
 	// get deployments for the specified network
 	for _, deploy := range p.conf.Deployments.ByNetwork(network.Name) {
-		account, err := p.accounts.ByName(deploy.Account)
+		account, err := p.accounts.ByName(p.DeploymentAccountName(deploy.Account))
 		if err != nil {
 			return nil, err
 		}
@@ -171,13 +200,21 @@ func (p *State) DeploymentContractsByNetwork(network config.Network) ([]*project
 				return nil, errors.Wrap(err, "deployment by network failed to read contract code")
 			}
 
+			args := deploymentContract.Args
+			if len(deploymentContract.ArgNames) > 0 {
+				args, err = argsByInitializer(code, deploymentContract)
+				if err != nil {
+					return nil, err
+				}
+			}
+
 			contract := project.NewContract(
 				c.Name,
 				path.Clean(location),
 				code,
 				account.Address,
 				account.Name,
-				deploymentContract.Args,
+				args,
 			)
 
 			contracts = append(contracts, contract)
@@ -187,6 +224,35 @@ func (p *State) DeploymentContractsByNetwork(network config.Network) ([]*project
 	return contracts, nil
 }
 
+// argsByInitializer orders the named deployment arguments to match the contract initializer parameters.
+func argsByInitializer(code []byte, deployment config.ContractDeployment) ([]cadence.Value, error) {
+	program, err := project.NewProgram(code, nil, "")
+	if err != nil {
+		return nil, err
+	}
+
+	params := program.InitializerParameters()
+	if len(params) != len(deployment.ArgNames) {
+		return nil, fmt.Errorf(
+			"contract %s initializer expects %d arguments, but %d were provided",
+			deployment.Name,
+			len(params),
+			len(deployment.ArgNames),
+		)
+	}
+
+	args := make([]cadence.Value, 0, len(params))
+	for _, param := range params {
+		i := slices.Index(deployment.ArgNames, param)
+		if i == -1 {
+			return nil, fmt.Errorf("missing argument %s for contract %s initializer", param, deployment.Name)
+		}
+		args = append(args, deployment.Args[i])
+	}
+
+	return args, nil
+}
+
 // AccountsForNetwork returns all accounts used on a network defined by deployments.
 func (p *State) AccountsForNetwork(network config.Network) *accounts.Accounts {
 	exists := make(map[string]bool, 0)
@@ -223,10 +289,32 @@ func (p *State) AliasesForNetwork(network config.Network) project.LocationAliase
 
 // Load loads a project configuration and returns the resulting project.
 func Load(configFilePaths []string, readerWriter ReaderWriter) (*State, error) {
+	return LoadWithVars(configFilePaths, readerWriter, nil)
+}
+
+// LoadWithVars loads a project configuration resolving variables with the provided values,
+// which take precedence over the variables defined in environment and configuration.
+func LoadWithVars(configFilePaths []string, readerWriter ReaderWriter, vars map[string]string) (*State, error) {
+	return LoadWithProfile(configFilePaths, readerWriter, vars, "")
+}
+
+// LoadWithProfile loads a project configuration with the named deployment profile selected.
+//
+// Deployments of the selected profile are deployed to the accounts mapped by the profile, and the profile
+// variables are used when resolving the configuration unless overridden by the provided values.
+func LoadWithProfile(
+	configFilePaths []string,
+	readerWriter ReaderWriter,
+	vars map[string]string,
+	profile string,
+) (*State, error) {
 	confLoader := config.NewLoader(readerWriter)
+	confLoader.SetVarOverrides(vars)
+	confLoader.SetProfile(profile)
 
 	// here we add all available parsers (more to add yaml etc...)
 	confLoader.AddConfigParser(json.NewParser())
+	confLoader.AddIncludeFetcher(config.NewHTTPIncludeFetcher(""))
 	conf, err := confLoader.Load(configFilePaths)
 	if err != nil {
 		return nil, err
@@ -241,6 +329,13 @@ func Load(configFilePaths []string, readerWriter ReaderWriter) (*State, error) {
 		return nil, fmt.Errorf("invalid project configuration: %s", err)
 	}
 
+	if profile != "" {
+		proj.profile, err = conf.Profiles.ByName(profile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return proj, nil
 }
 
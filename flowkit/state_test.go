@@ -887,3 +887,109 @@ func Test_CustomEmulatorValuesInConfig(t *testing.T) {
 	assert.Equal(t, state.conf, &cfg)
 	assert.NoError(t, err)
 }
+
+func Test_DeploymentNamedArgs(t *testing.T) {
+	configJson := []byte(`{
+		"contracts": {
+			"Simple": "./contractArgs.cdc"
+		},
+		"accounts": {
+			"emulator-account": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"
+			}
+		},
+		"networks": {
+			"emulator": "127.0.0.1:3569"
+		},
+		"deployments": {
+			"emulator": {
+				"emulator-account": [{
+					"name": "Simple",
+					"args": { "name": "Hello as String", "id": "1 as UInt64" }
+				}]
+			}
+		}
+	}`)
+	code := []byte(`
+		pub contract Simple {
+			init(id: UInt64, name: String) {}
+		}
+	`)
+
+	af := afero.Afero{Fs: afero.NewMemMapFs()}
+	require.NoError(t, afero.WriteFile(af.Fs, "flow.json", configJson, 0644))
+	require.NoError(t, afero.WriteFile(af.Fs, "contractArgs.cdc", code, 0644))
+
+	state, err := Load([]string{"flow.json"}, af)
+	require.NoError(t, err)
+
+	contracts, err := state.DeploymentContractsByNetwork(config.EmulatorNetwork)
+	require.NoError(t, err)
+	require.Len(t, contracts, 1)
+	assert.Equal(t, "1", contracts[0].Args[0].String())
+	assert.Equal(t, `"Hello"`, contracts[0].Args[1].String())
+
+	state.Deployments().ByNetwork("emulator")[0].Contracts[0].ArgNames = []string{"name", "foo"}
+	_, err = state.DeploymentContractsByNetwork(config.EmulatorNetwork)
+	assert.EqualError(t, err, "missing argument id for contract Simple initializer")
+}
+
+func Test_LoadStateWithProfile(t *testing.T) {
+	b := []byte(`{
+		"contracts": {
+			"Foo": "./Foo.cdc"
+		},
+		"networks": {
+			"testnet": "access.devnet.nodes.onflow.org:9000"
+		},
+		"accounts": {
+			"deployer": {
+				"address": "f8d6e0586b0a20c7",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			},
+			"staging-deployer": {
+				"address": "0x2c1162386b0a245f",
+				"key": "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7"
+			}
+		},
+		"deployments": {
+			"testnet": {
+				"deployer": ["Foo"]
+			}
+		},
+		"profiles": {
+			"staging": {
+				"network": "testnet",
+				"accounts": { "deployer": "staging-deployer" }
+			}
+		}
+	}`)
+
+	af := afero.Afero{Fs: afero.NewMemMapFs()}
+	require.NoError(t, afero.WriteFile(af.Fs, "flow.json", b, 0644))
+	require.NoError(t, afero.WriteFile(af.Fs, "Foo.cdc", []byte(`access(all) contract Foo {}`), 0644))
+
+	state, err := LoadWithProfile([]string{"flow.json"}, af, nil, "staging")
+	require.NoError(t, err)
+	require.NotNil(t, state.Profile())
+	assert.Equal(t, "testnet", state.Profile().Network)
+
+	contracts, err := state.DeploymentContractsByNetwork(config.TestnetNetwork)
+	require.NoError(t, err)
+	require.Len(t, contracts, 1)
+	assert.Equal(t, "staging-deployer", contracts[0].AccountName)
+	assert.Equal(t, "2c1162386b0a245f", contracts[0].AccountAddress.String())
+
+	deployment := state.DeploymentByAccountAndNetwork("staging-deployer", "testnet")
+	require.NotNil(t, deployment)
+	assert.Equal(t, "deployer", deployment.Account)
+
+	state, err = Load([]string{"flow.json"}, af)
+	require.NoError(t, err)
+	assert.Nil(t, state.Profile())
+
+	contracts, err = state.DeploymentContractsByNetwork(config.TestnetNetwork)
+	require.NoError(t, err)
+	assert.Equal(t, "deployer", contracts[0].AccountName)
+}
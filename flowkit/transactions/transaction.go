@@ -28,6 +28,7 @@ import (
 	"github.com/onflow/cadence/runtime/ast"
 	"github.com/onflow/cadence/runtime/parser"
 	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/onflow/flow-go-sdk/templates"
 
 	"github.com/onflow/flow-cli/flowkit/accounts"
@@ -335,25 +336,64 @@ func (t *Transaction) AddAuthorizers(authorizers []flow.Address) (*Transaction,
 
 // Sign signs transaction using signer account.
 func (t *Transaction) Sign() (*Transaction, error) {
+	// payer services receive the whole transaction to decide whether they pay for it
+	if payerService, ok := t.signer.Key.(*accounts.PayerServiceKey); ok {
+		if !t.shouldSignEnvelope() {
+			return nil, fmt.Errorf("payer service can only sign transactions as payer")
+		}
+
+		sig, err := payerService.SignTransaction(context.Background(), t.tx)
+		if err != nil {
+			return nil, err
+		}
+
+		t.tx.AddEnvelopeSignature(t.signer.Address, payerService.Index(), sig)
+		return t, nil
+	}
+
 	keyIndex := t.signer.Key.Index()
 	signer, err := t.signer.Key.Signer(context.Background())
 	if err != nil {
 		return nil, err
 	}
 
+	err = t.signWith(keyIndex, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	// co-signer keys of the same account add their signatures to reach the signing weight
+	if coSigned, ok := t.signer.Key.(*accounts.CoSignedKey); ok {
+		for _, key := range coSigned.CoSigners() {
+			coSigner, err := key.Signer(context.Background())
+			if err != nil {
+				return nil, err
+			}
+
+			err = t.signWith(key.Index(), coSigner)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return t, nil
+}
+
+func (t *Transaction) signWith(keyIndex int, signer crypto.Signer) error {
 	if t.shouldSignEnvelope() {
-		err = t.tx.SignEnvelope(t.signer.Address, keyIndex, signer)
+		err := t.tx.SignEnvelope(t.signer.Address, keyIndex, signer)
 		if err != nil {
-			return nil, fmt.Errorf("failed to sign transaction: %s", err)
+			return fmt.Errorf("failed to sign transaction: %s", err)
 		}
 	} else {
-		err = t.tx.SignPayload(t.signer.Address, keyIndex, signer)
+		err := t.tx.SignPayload(t.signer.Address, keyIndex, signer)
 		if err != nil {
-			return nil, fmt.Errorf("failed to sign transaction: %s", err)
+			return fmt.Errorf("failed to sign transaction: %s", err)
 		}
 	}
 
-	return t, nil
+	return nil
 }
 
 // shouldSignEnvelope checks if signer should sign envelope or payload
@@ -19,6 +19,8 @@
 package transactions_test
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/onflow/cadence"
@@ -28,6 +30,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/flowkit/transactions"
 )
@@ -85,3 +88,65 @@ func TestNew(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, signed.FlowTransaction().EnvelopeSignatures, 1)
 }
+
+func TestSign_CoSigned(t *testing.T) {
+	tx := transactions.New()
+	address := flow.HexToAddress("0x01")
+	tx.SetPayer(address)
+
+	key := func(index int) accounts.Key {
+		pkey, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, make([]byte, crypto.MinSeedLength))
+		assert.NoError(t, err)
+		return accounts.NewHexKeyFromPrivateKey(index, crypto.SHA3_256, pkey)
+	}
+
+	err := tx.SetSigner(&accounts.Account{
+		Name:    "admin",
+		Address: address,
+		Key:     accounts.NewCoSignedKey(key(0), key(1)),
+	})
+	assert.NoError(t, err)
+
+	signed, err := tx.Sign()
+	assert.NoError(t, err)
+
+	signatures := signed.FlowTransaction().EnvelopeSignatures
+	assert.Len(t, signatures, 2)
+	assert.Equal(t, 0, signatures[0].KeyIndex)
+	assert.Equal(t, 1, signatures[1].KeyIndex)
+}
+
+func TestSign_PayerService(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"signature":"abcd"}`))
+	}))
+	defer server.Close()
+
+	payer, err := accounts.NewPayerServiceAccount(config.Network{
+		Name:  "testnet",
+		Payer: config.PayerService{URL: server.URL, Address: flow.HexToAddress("0x02"), KeyIndex: 1},
+	})
+	assert.NoError(t, err)
+
+	tx := transactions.New()
+	tx.SetPayer(payer.Address)
+	err = tx.SetSigner(payer)
+	assert.NoError(t, err)
+
+	signed, err := tx.Sign()
+	assert.NoError(t, err)
+
+	signatures := signed.FlowTransaction().EnvelopeSignatures
+	assert.Len(t, signatures, 1)
+	assert.Equal(t, payer.Address, signatures[0].Address)
+	assert.Equal(t, 1, signatures[0].KeyIndex)
+	assert.Equal(t, []byte{0xab, 0xcd}, signatures[0].Signature)
+
+	tx = transactions.New()
+	tx.SetPayer(flow.HexToAddress("0x01"))
+	err = tx.SetSigner(payer)
+	assert.NoError(t, err)
+
+	_, err = tx.Sign()
+	assert.EqualError(t, err, "payer service can only sign transactions as payer")
+}
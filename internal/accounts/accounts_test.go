@@ -25,6 +25,7 @@ import (
 
 	"github.com/onflow/flow-cli/flowkit/accounts"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/stretchr/testify/assert"
@@ -312,3 +313,50 @@ Contracts (hidden, use --include contracts)`, "\n"), result.String())
 	}, result.JSON())
 
 }
+
+func Test_Activity(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	addressField := cadence.Field{Identifier: "address", Type: cadence.AddressType{}}
+	amountField := cadence.Field{Identifier: "amount", Type: cadence.UFix64Type{}}
+	toField := cadence.Field{Identifier: "to", Type: &cadence.OptionalType{Type: cadence.AddressType{}}}
+	fromField := cadence.Field{Identifier: "from", Type: &cadence.OptionalType{Type: cadence.AddressType{}}}
+	contractField := cadence.Field{Identifier: "contract", Type: cadence.StringType{}}
+
+	account := cadence.BytesToAddress(flow.HexToAddress("01").Bytes())
+	other := cadence.BytesToAddress(flow.HexToAddress("02").Bytes())
+	amount, _ := cadence.NewUFix64("10.0")
+	tokens := "A.0ae53cb6e3f42a79.FlowToken."
+
+	srv.GetEvents.Run(func(args mock.Arguments) {
+		assert.Contains(t, args.Get(1).([]string), tokens+"TokensDeposited")
+		assert.Equal(t, uint64(10), args.Get(2).(uint64))
+		assert.Equal(t, uint64(20), args.Get(3).(uint64))
+	}).Return([]flow.BlockEvents{{
+		Height: 15,
+		Events: []flow.Event{
+			*tests.NewEvent(1, tokens+"TokensDeposited", []cadence.Field{amountField, toField}, []cadence.Value{amount, cadence.NewOptional(account)}),
+			*tests.NewEvent(0, tokens+"TokensWithdrawn", []cadence.Field{amountField, fromField}, []cadence.Value{amount, cadence.NewOptional(other)}),
+		},
+	}, {
+		Height: 12,
+		Events: []flow.Event{
+			*tests.NewEvent(0, flow.EventAccountContractAdded, []cadence.Field{addressField, contractField}, []cadence.Value{account, cadence.String("Foo")}),
+			*tests.NewEvent(1, flow.EventAccountContractAdded, []cadence.Field{addressField, contractField}, []cadence.Value{other, cadence.String("Bar")}),
+		},
+	}}, nil)
+
+	activityFlags.FromHeight = 10
+	activityFlags.ToHeight = 20
+	result, err := activity([]string{"0x01"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+	require.NoError(t, err)
+
+	entries := result.(*activityResult).entries
+	require.Len(t, entries, 2)
+	assert.Equal(t, uint64(12), entries[0].Height)
+	assert.Equal(t, "contract added", entries[0].Activity)
+	assert.Equal(t, "contract Foo", entries[0].Details)
+	assert.Equal(t, uint64(15), entries[1].Height)
+	assert.Equal(t, "deposit", entries[1].Activity)
+	assert.Equal(t, "10.00000000 FLOW from 0x0000000000000002", entries[1].Details)
+}
@@ -0,0 +1,337 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package accounts
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsActivity struct {
+	FromHeight uint64 `flag:"from-height" info:"Block height to start scanning from, defaults to the last 1000 blocks"`
+	ToHeight   uint64 `flag:"to-height" info:"Block height to scan to, defaults to the latest block"`
+	Workers    int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+}
+
+var activityFlags = flagsActivity{}
+
+var activityCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "activity <address>",
+		Short: "Show transfers, contract deployments and key changes of an account",
+		Example: `#show the activity of an account in the last 1000 blocks
+flow accounts activity f8d6e0586b0a20c7
+
+#show the activity of an account from a block height
+flow accounts activity 1654653399040a61 --from-height 11559500 --network mainnet`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &activityFlags,
+	Run:   activity,
+}
+
+// defaultActivityBlocks is the number of latest blocks scanned if no start height is provided.
+const defaultActivityBlocks = 1000
+
+const (
+	activityCreated         = "account created"
+	activityDeposit         = "deposit"
+	activityWithdrawal      = "withdrawal"
+	activityKeyAdded        = "key added"
+	activityKeyRemoved      = "key removed"
+	activityContractAdded   = "contract added"
+	activityContractUpdated = "contract updated"
+	activityContractRemoved = "contract removed"
+)
+
+// protocolActivity maps the protocol events to the activity they represent, all include the account address field.
+var protocolActivity = map[string]string{
+	flowsdk.EventAccountCreated:         activityCreated,
+	flowsdk.EventAccountKeyAdded:        activityKeyAdded,
+	flowsdk.EventAccountKeyRemoved:      activityKeyRemoved,
+	flowsdk.EventAccountContractAdded:   activityContractAdded,
+	flowsdk.EventAccountContractUpdated: activityContractUpdated,
+	flowsdk.EventAccountContractRemoved: activityContractRemoved,
+}
+
+func activity(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	address := flowsdk.HexToAddress(args[0])
+	if address == flowsdk.EmptyAddress {
+		return nil, fmt.Errorf("invalid account address %s", args[0])
+	}
+
+	start := activityFlags.FromHeight
+	end := activityFlags.ToHeight
+	if end == 0 {
+		latest, err := flow.GetBlock(context.Background(), flowkit.LatestBlockQuery)
+		if err != nil {
+			return nil, err
+		}
+		end = latest.Height
+	}
+	if start == 0 && end > defaultActivityBlocks {
+		start = end - defaultActivityBlocks
+	}
+	if start > end {
+		return nil, fmt.Errorf("start height %d must not be greater than end height %d", start, end)
+	}
+
+	eventTypes := make([]string, 0, len(protocolActivity)+2)
+	for eventType := range protocolActivity {
+		eventTypes = append(eventTypes, eventType)
+	}
+	sort.Strings(eventTypes)
+
+	flowToken, err := util.CoreContractByName("FlowToken").Address(flow.Network().Name)
+	if err == nil {
+		eventTypes = append(eventTypes, tokenEventType(flowToken, "TokensDeposited"), tokenEventType(flowToken, "TokensWithdrawn"))
+	} else {
+		logger.Info(fmt.Sprintf("FLOW transfers are not included, %s", err))
+	}
+
+	logger.StartProgress(fmt.Sprintf("Scanning blocks %d-%d for account %s activity...", start, end, address))
+	defer logger.StopProgress()
+
+	blockEvents, err := flow.GetEvents(context.Background(), eventTypes, start, end, &flowkit.EventWorker{
+		Count:           activityFlags.Workers,
+		BlocksPerWorker: flowkit.MaxEventBlockRange,
+		Progress:        output.LoggerProgress(logger, fmt.Sprintf("Scanning blocks %d-%d", start, end)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &activityResult{
+		address:    address,
+		startBlock: start,
+		endBlock:   end,
+		entries:    accountActivity(address, flowToken, blockEvents),
+	}, nil
+}
+
+func tokenEventType(address flowsdk.Address, event string) string {
+	return fmt.Sprintf("A.%s.FlowToken.%s", address.Hex(), event)
+}
+
+type activityEntry struct {
+	Height           uint64
+	TransactionID    flowsdk.Identifier
+	transactionIndex int
+	eventIndex       int
+	Activity         string
+	Details          string
+}
+
+// accountActivity reconstructs the activity involving the address from the events, ordered as they were emitted.
+// Deposits and withdrawals of the same amount in a transaction are paired to show the counterparty of transfers.
+func accountActivity(address flowsdk.Address, flowToken flowsdk.Address, blockEvents []flowsdk.BlockEvents) []activityEntry {
+	type transfer struct {
+		txID    flowsdk.Identifier
+		amount  string
+		account flowsdk.Address
+	}
+	var deposits, withdrawals []transfer
+
+	var events []flowsdk.Event
+	var heights []uint64
+	for _, block := range blockEvents {
+		for _, event := range block.Events {
+			events = append(events, event)
+			heights = append(heights, block.Height)
+		}
+	}
+
+	for _, event := range events {
+		switch event.Type {
+		case tokenEventType(flowToken, "TokensDeposited"):
+			if to, ok := eventAddress(event.Value, "to"); ok {
+				deposits = append(deposits, transfer{event.TransactionID, eventField(event.Value, "amount"), to})
+			}
+		case tokenEventType(flowToken, "TokensWithdrawn"):
+			if from, ok := eventAddress(event.Value, "from"); ok {
+				withdrawals = append(withdrawals, transfer{event.TransactionID, eventField(event.Value, "amount"), from})
+			}
+		}
+	}
+
+	counterparty := func(transfers []transfer, txID flowsdk.Identifier, amount string) string {
+		for _, t := range transfers {
+			if t.txID == txID && t.amount == amount && t.account != address {
+				return fmt.Sprintf(" 0x%s", t.account.Hex())
+			}
+		}
+		return ""
+	}
+
+	entries := make([]activityEntry, 0)
+	for i, event := range events {
+		entry := activityEntry{
+			Height:           heights[i],
+			TransactionID:    event.TransactionID,
+			transactionIndex: event.TransactionIndex,
+			eventIndex:       event.EventIndex,
+		}
+
+		if kind, ok := protocolActivity[event.Type]; ok {
+			if account, ok := eventAddress(event.Value, "address"); !ok || account != address {
+				continue
+			}
+			entry.Activity = kind
+			switch kind {
+			case activityContractAdded, activityContractUpdated, activityContractRemoved:
+				entry.Details = fmt.Sprintf("contract %s", eventField(event.Value, "contract"))
+			case activityKeyAdded, activityKeyRemoved:
+				entry.Details = fmt.Sprintf("public key %s", eventField(event.Value, "publicKey"))
+			}
+			entries = append(entries, entry)
+			continue
+		}
+
+		amount := eventField(event.Value, "amount")
+		switch event.Type {
+		case tokenEventType(flowToken, "TokensDeposited"):
+			if to, ok := eventAddress(event.Value, "to"); !ok || to != address {
+				continue
+			}
+			entry.Activity = activityDeposit
+			entry.Details = fmt.Sprintf("%s FLOW", amount)
+			if from := counterparty(withdrawals, event.TransactionID, amount); from != "" {
+				entry.Details += " from" + from
+			}
+		case tokenEventType(flowToken, "TokensWithdrawn"):
+			if from, ok := eventAddress(event.Value, "from"); !ok || from != address {
+				continue
+			}
+			entry.Activity = activityWithdrawal
+			entry.Details = fmt.Sprintf("%s FLOW", amount)
+			if to := counterparty(deposits, event.TransactionID, amount); to != "" {
+				entry.Details += " to" + to
+			}
+		default:
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Height != b.Height {
+			return a.Height < b.Height
+		}
+		if a.transactionIndex != b.transactionIndex {
+			return a.transactionIndex < b.transactionIndex
+		}
+		return a.eventIndex < b.eventIndex
+	})
+
+	return entries
+}
+
+// eventField returns the string representation of the event field, or an empty string if it doesn't exist.
+func eventField(event cadence.Event, name string) string {
+	value := cadence.GetFieldByName(event, name)
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+	if value == nil {
+		return ""
+	}
+	if str, ok := value.(cadence.String); ok {
+		return string(str)
+	}
+	return value.String()
+}
+
+// eventAddress returns the address in the event field if it's set.
+func eventAddress(event cadence.Event, name string) (flowsdk.Address, bool) {
+	value := cadence.GetFieldByName(event, name)
+	if optional, ok := value.(cadence.Optional); ok {
+		value = optional.Value
+	}
+	address, ok := value.(cadence.Address)
+	if !ok {
+		return flowsdk.EmptyAddress, false
+	}
+	return flowsdk.Address(address), true
+}
+
+type activityResult struct {
+	address    flowsdk.Address
+	startBlock uint64
+	endBlock   uint64
+	entries    []activityEntry
+}
+
+func (r *activityResult) JSON() any {
+	entries := make([]map[string]any, 0, len(r.entries))
+	for _, e := range r.entries {
+		entries = append(entries, map[string]any{
+			"height":        e.Height,
+			"transactionId": e.TransactionID.String(),
+			"activity":      e.Activity,
+			"details":       e.Details,
+		})
+	}
+
+	return map[string]any{
+		"address":    fmt.Sprintf("0x%s", r.address.Hex()),
+		"startBlock": r.startBlock,
+		"endBlock":   r.endBlock,
+		"activity":   entries,
+	}
+}
+
+func (r *activityResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Account %s activity in blocks %d-%d\n\n", fmt.Sprintf("0x%s", r.address.Hex()), r.startBlock, r.endBlock)
+	if len(r.entries) == 0 {
+		_, _ = fmt.Fprintf(writer, "No activity found\n")
+	} else {
+		_, _ = fmt.Fprintf(writer, "Height\tTransaction ID\tActivity\tDetails\n")
+		for _, e := range r.entries {
+			_, _ = fmt.Fprintf(writer, "%d\t%s\t%s\t%s\n", e.Height, e.TransactionID, e.Activity, e.Details)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *activityResult) Oneliner() string {
+	return fmt.Sprintf("Account %s has %d activity entries in blocks %d-%d", fmt.Sprintf("0x%s", r.address.Hex()), len(r.entries), r.startBlock, r.endBlock)
+}
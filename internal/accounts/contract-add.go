@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/onflow/flow-cli/internal/settings"
 	"github.com/onflow/flow-cli/internal/util"
 
 	"github.com/onflow/cadence"
@@ -74,6 +75,20 @@ func deployContract(update bool, flags *deployContractFlags) command.RunWithStat
 			return nil, err
 		}
 
+		if update {
+			err = command.GuardMainnet(
+				settings.PolicyContractUpdate,
+				fmt.Sprintf("update a contract on account %s", to.Name),
+				globalFlags,
+				flow,
+				state,
+				to,
+			)
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		var contractArgs []cadence.Value
 		if flags.ArgsJSON != "" {
 			contractArgs, err = arguments.ParseJSON(flags.ArgsJSON)
@@ -82,12 +97,12 @@ func deployContract(update bool, flags *deployContractFlags) command.RunWithStat
 		}
 
 		if err != nil {
-			return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+			return nil, command.WithExitCode(command.ExitValidationError, fmt.Errorf("error parsing transaction arguments: %w", err))
 		}
 
 		deployFunc := flowkit.UpdateExistingContract(update)
 		if updateContractFlags.ShowDiff {
-			deployFunc = util.ShowContractDiffPrompt(logger)
+			deployFunc = util.ShowContractDiffPrompt(logger, globalFlags.Yes)
 		}
 
 		txID, _, err := flow.AddContract(
@@ -24,6 +24,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/internal/settings"
 	"github.com/onflow/flow-cli/internal/util"
 
 	"github.com/onflow/flow-cli/flowkit"
@@ -41,10 +42,11 @@ var flagsRemove = flagsRemoveContract{}
 
 var removeCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "remove-contract <name>",
-		Short:   "Remove a contract deployed to an account",
-		Example: `flow accounts remove-contract FungibleToken`,
-		Args:    cobra.ExactArgs(1),
+		Use:               "remove-contract <name>",
+		Short:             "Remove a contract deployed to an account",
+		Example:           `flow accounts remove-contract FungibleToken`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: command.CompleteContracts,
 	},
 	Flags: &flagsRemove,
 	RunS:  removeContract,
@@ -52,7 +54,7 @@ var removeCommand = &command.Command{
 
 func removeContract(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
 	flow flowkit.Services,
 	state *flowkit.State,
@@ -64,6 +66,18 @@ func removeContract(
 		return nil, err
 	}
 
+	err = command.GuardMainnet(
+		settings.PolicyContractRemove,
+		fmt.Sprintf("remove contract %s from account %s", contractName, from.Name),
+		globalFlags,
+		flow,
+		state,
+		from,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	id, err := flow.RemoveContract(context.Background(), from, contractName)
 	if err != nil {
 		return nil, err
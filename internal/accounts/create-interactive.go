@@ -21,7 +21,10 @@ package accounts
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -41,6 +44,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/auth"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -48,8 +52,8 @@ import (
 //
 // This process takes the user through couple of steps with prompts asking for them to provide name and network,
 // and it then uses account creation APIs to automatically create the account on the network as well as save it.
-func createInteractive(state *flowkit.State) error {
-	log := output.NewStdoutLogger(output.InfoLog)
+func createInteractive(state *flowkit.State, insecure bool) error {
+	log := output.NewStderrLogger(output.InfoLog, output.TextLogFormat)
 	name := util.AccountNamePrompt(state.Accounts().Names())
 	networkName, selectedNetwork := util.CreateAccountNetworkPrompt()
 	privateFile := fmt.Sprintf("%s.pkey", name)
@@ -69,12 +73,12 @@ func createInteractive(state *flowkit.State) error {
 	log.StartProgress(fmt.Sprintf("Creating account %s on %s...", name, networkName))
 
 	var account *accounts.Account
-	if selectedNetwork == config.EmulatorNetwork {
+	if selectedNetwork.Name == config.EmulatorNetwork.Name {
 		account, err = createEmulatorAccount(state, flow, name, key)
 		log.StopProgress()
 		log.Info(output.Italic("\nPlease note that the newly-created account will only be available while you keep the emulator service running. If you restart the emulator service, all accounts will be reset. If you want to persist accounts between restarts, please use the '--persist' flag when starting the flow emulator.\n"))
 	} else {
-		account, err = createNetworkAccount(state, flow, name, key, privateFile, selectedNetwork)
+		account, err = createNetworkAccount(state, flow, name, key, privateFile, selectedNetwork, insecure)
 		log.StopProgress()
 	}
 	if err != nil {
@@ -99,7 +103,7 @@ func createInteractive(state *flowkit.State) error {
 		"Here’s a summary of all the actions that were taken",
 		fmt.Sprintf("Added the new account to %s.", output.Bold("flow.json")),
 	}
-	if selectedNetwork != config.EmulatorNetwork {
+	if selectedNetwork.Name != config.EmulatorNetwork.Name {
 		items = append(items,
 			fmt.Sprintf("Saved the private key to %s.", output.Bold(privateFile)),
 			fmt.Sprintf("Added %s to %s.", output.Bold(privateFile), output.Bold(".gitignore")),
@@ -118,12 +122,13 @@ func createNetworkAccount(
 	key crypto.PrivateKey,
 	privateFile string,
 	network config.Network,
+	insecure bool,
 ) (*accounts.Account, error) {
 	networkAccount := &lilicoAccount{
 		PublicKey: strings.TrimPrefix(key.PublicKey().String(), "0x"),
 	}
 
-	id, err := networkAccount.create(network.Name)
+	id, err := networkAccount.create(network.Name, insecure)
 	if err != nil {
 		return nil, err
 	}
@@ -215,14 +220,22 @@ type lilicoResponse struct {
 	} `json:"data"`
 }
 
+// accountToken is the built-in lilico API token set at build time, the token the user logged in with
+// using 'flow auth login lilico' is used instead if present.
 var accountToken = ""
 
+// accountCertPins are comma separated base64 encoded SHA-256 hashes of the public keys
+// the lilico API certificate chain must contain, pinning is disabled if no hashes are set.
+var accountCertPins = ""
+
 const defaultHashAlgo = crypto.SHA3_256
 
 const defaultSignAlgo = crypto.ECDSA_P256
 
 // create a new account using the lilico API and parsing the response, returning account creation transaction ID.
-func (l *lilicoAccount) create(network string) (flowsdk.Identifier, error) {
+//
+// Certificate verification can only be skipped with the explicit insecure opt-in.
+func (l *lilicoAccount) create(network string, insecure bool) (flowsdk.Identifier, error) {
 	// fix to the defaults as we don't support other values
 	l.HashAlgorithm = defaultHashAlgo.String()
 	l.SignatureAlgorithm = defaultSignAlgo.String()
@@ -251,9 +264,10 @@ func (l *lilicoAccount) create(network string) (flowsdk.Identifier, error) {
 	request.Header.Add("Authorization", accountToken)
 
 	client := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, // lilico api doesn't yet have a valid cert, todo reevaluate
-		},
+		Transport: auth.NewTransport(&http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: lilicoTLSConfig(insecure),
+		}),
 	}
 	res, err := client.Do(request)
 	if err != nil {
@@ -271,6 +285,36 @@ func (l *lilicoAccount) create(network string) (flowsdk.Identifier, error) {
 	return flowsdk.HexToID(lilicoRes.Data.TxId), nil
 }
 
+// lilicoTLSConfig returns the TLS configuration for the lilico API verifying the pinned certificates.
+func lilicoTLSConfig(insecure bool) *tls.Config {
+	if insecure {
+		return &tls.Config{InsecureSkipVerify: true}
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	pins := strings.Split(accountCertPins, ",")
+	if accountCertPins == "" {
+		return tlsConfig
+	}
+
+	tlsConfig.VerifyPeerCertificate = func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+		for _, chain := range verifiedChains {
+			for _, cert := range chain {
+				hash := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+				for _, pin := range pins {
+					if strings.TrimSpace(pin) == base64.StdEncoding.EncodeToString(hash[:]) {
+						return nil
+					}
+				}
+			}
+		}
+
+		return fmt.Errorf("account creation API certificate doesn't match the pinned certificates")
+	}
+
+	return tlsConfig
+}
+
 // outputList helper for printing lists
 func outputList(log *output.StdoutLogger, items []string, numbered bool) {
 	log.Info(fmt.Sprintf("%s:", items[0]))
@@ -40,6 +40,7 @@ type flagsCreate struct {
 	SigAlgo  []string `default:"ECDSA_P256" flag:"sig-algo" info:"Signature algorithm used to generate the keys"`
 	HashAlgo []string `default:"SHA3_256" flag:"hash-algo" info:"Hash used for the digest"`
 	Include  []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Insecure bool     `default:"false" flag:"insecure" info:"Skip certificate verification of the account creation API, use only if you trust the network"`
 }
 
 var createFlags = flagsCreate{}
@@ -67,7 +68,7 @@ func create(
 	weightFlag := createFlags.Weights
 
 	if len(keysFlag) == 0 { // if user doesn't provide any flags go into interactive mode
-		return nil, createInteractive(state)
+		return nil, createInteractive(state, createFlags.Insecure)
 	}
 
 	signer, err := state.Accounts().ByName(createFlags.Signer)
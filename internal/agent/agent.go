@@ -0,0 +1,199 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package agent implements the key agent holding the decrypted encrypted keys in memory and signing
+// on behalf of other invocations of the CLI over a local socket, similar to ssh-agent.
+package agent
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+)
+
+// SocketEnv is the environment variable overriding the path of the agent socket.
+const SocketEnv = "FLOW_AGENT_SOCK"
+
+var Cmd = &cobra.Command{
+	Use:              "agent",
+	Short:            "Hold decrypted keys in an agent signing for other commands",
+	TraverseChildren: true,
+	GroupID:          "security",
+}
+
+func init() {
+	startCommand.AddToParent(Cmd)
+	lockCommand.AddToParent(Cmd)
+	statusCommand.AddToParent(Cmd)
+}
+
+// SocketPath returns the path of the agent socket.
+//
+// The default socket is in a directory private to the current user, in the user runtime directory if set.
+func SocketPath() string {
+	if path := os.Getenv(SocketEnv); path != "" {
+		return path
+	}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "flow-agent", "agent.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("flow-agent-%d", os.Getuid()), "agent.sock")
+}
+
+// checkSocket verifies the socket and its directory belong to the current user and the directory
+// is accessible only by the current user, so other users can't replace the socket or connect to it.
+func checkSocket(socket string) error {
+	if err := checkOwner(filepath.Dir(socket), true); err != nil {
+		return err
+	}
+	return checkOwner(socket, false)
+}
+
+// fingerprint identifies the encrypted key without sending it to the agent.
+func fingerprint(encryptedKey string) string {
+	sum := sha256.Sum256([]byte(encryptedKey))
+	return hex.EncodeToString(sum[:])
+}
+
+// request is sent by the client to the agent, one JSON request per connection.
+type request struct {
+	Method      string `json:"method"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	HashAlgo    string `json:"hashAlgo,omitempty"`
+	Message     string `json:"message,omitempty"`
+}
+
+// response is sent by the agent to the client.
+type response struct {
+	PublicKey string    `json:"publicKey,omitempty"`
+	SigAlgo   string    `json:"sigAlgo,omitempty"`
+	Signature string    `json:"signature,omitempty"`
+	Keys      int       `json:"keys,omitempty"`
+	ExpiresAt time.Time `json:"expiresAt,omitempty"`
+	PID       int       `json:"pid,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+var _ accounts.KeyAgent = &Client{}
+
+// Client sends the requests to the agent listening on the socket.
+type Client struct {
+	socket string
+}
+
+// NewClient creates a client of the agent listening on the socket.
+func NewClient(socket string) *Client {
+	return &Client{socket: socket}
+}
+
+func (c *Client) send(req request) (*response, error) {
+	if err := checkSocket(c.socket); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("agent is not running: %w", err)
+		}
+		return nil, fmt.Errorf("refusing to connect to the agent: %w", err)
+	}
+
+	conn, err := net.DialTimeout("unix", c.socket, time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("agent is not running: %w", err)
+	}
+	defer conn.Close()
+
+	// signing is fast, the deadline only protects from an unresponsive agent
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return nil, err
+	}
+
+	var res response
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&res); err != nil {
+		return nil, fmt.Errorf("invalid response from the agent: %w", err)
+	}
+	if res.Error != "" {
+		return nil, fmt.Errorf("agent: %s", res.Error)
+	}
+
+	return &res, nil
+}
+
+// PublicKey returns the public key of the encrypted key held by the agent.
+func (c *Client) PublicKey(encryptedKey string) (crypto.PublicKey, error) {
+	res, err := c.send(request{Method: "publicKey", Fingerprint: fingerprint(encryptedKey)})
+	if err != nil {
+		return nil, err
+	}
+
+	return crypto.DecodePublicKeyHex(crypto.StringToSignatureAlgorithm(res.SigAlgo), res.PublicKey)
+}
+
+// Sign signs the message with the encrypted key held by the agent.
+func (c *Client) Sign(encryptedKey string, hashAlgo crypto.HashAlgorithm, message []byte) ([]byte, error) {
+	res, err := c.send(request{
+		Method:      "sign",
+		Fingerprint: fingerprint(encryptedKey),
+		HashAlgo:    hashAlgo.String(),
+		Message:     hex.EncodeToString(message),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.DecodeString(res.Signature)
+}
+
+// Status returns the status of the running agent.
+func (c *Client) Status() (*response, error) {
+	return c.send(request{Method: "status"})
+}
+
+// Lock stops the agent, the keys are removed from memory.
+func (c *Client) Lock() error {
+	_, err := c.send(request{Method: "lock"})
+	return err
+}
+
+type result struct {
+	result string
+}
+
+func (r *result) JSON() any {
+	return map[string]any{"result": r.result}
+}
+
+func (r *result) String() string {
+	return r.result
+}
+
+func (r *result) Oneliner() string {
+	return strings.ReplaceAll(r.result, "\n", " ")
+}
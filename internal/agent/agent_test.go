@@ -0,0 +1,125 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+)
+
+func Test_Agent(t *testing.T) {
+	pkey, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_secp256k1, "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+	require.NoError(t, err)
+
+	encrypted, err := accounts.EncryptPrivateKey(pkey, "secret")
+	require.NoError(t, err)
+
+	socket := filepath.Join(t.TempDir(), "agent", "agent.sock")
+	listener, err := listen(socket)
+	require.NoError(t, err)
+
+	srv := newServer(listener, map[string]crypto.PrivateKey{fingerprint(encrypted): pkey}, time.Hour)
+	stopped := make(chan struct{})
+	go func() {
+		srv.serve()
+		close(stopped)
+	}()
+
+	client := NewClient(socket)
+
+	t.Run("Status", func(t *testing.T) {
+		status, err := client.Status()
+		require.NoError(t, err)
+		assert.Equal(t, 1, status.Keys)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), status.ExpiresAt, time.Minute)
+
+		_, err = listen(socket)
+		assert.ErrorContains(t, err, "agent is already running")
+	})
+
+	t.Run("Sign", func(t *testing.T) {
+		publicKey, err := client.PublicKey(encrypted)
+		require.NoError(t, err)
+		assert.Equal(t, pkey.PublicKey().String(), publicKey.String())
+
+		message := []byte("message to sign")
+		sig, err := client.Sign(encrypted, crypto.SHA3_256, message)
+		require.NoError(t, err)
+
+		hasher, _ := crypto.NewHasher(crypto.SHA3_256)
+		valid, err := publicKey.Verify(sig, message, hasher)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("Fail unknown key", func(t *testing.T) {
+		_, err := client.PublicKey("unknown")
+		assert.EqualError(t, err, "agent: key is not held by the agent")
+	})
+
+	t.Run("Lock", func(t *testing.T) {
+		require.NoError(t, client.Lock())
+		<-stopped
+
+		_, err := client.Status()
+		assert.ErrorContains(t, err, "agent is not running")
+	})
+}
+
+func Test_SocketPath(t *testing.T) {
+	t.Setenv(SocketEnv, "")
+	t.Setenv("XDG_RUNTIME_DIR", "/run/user/1000")
+	assert.Equal(t, filepath.Join("/run/user/1000", "flow-agent", "agent.sock"), SocketPath())
+
+	t.Setenv(SocketEnv, "/custom/agent.sock")
+	assert.Equal(t, "/custom/agent.sock", SocketPath())
+}
+
+func Test_CheckSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("socket permissions are not checked on windows")
+	}
+
+	dir := filepath.Join(t.TempDir(), "agent")
+	socket := filepath.Join(dir, "agent.sock")
+	listener, err := listen(socket)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	info, err := os.Stat(dir)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0700), info.Mode().Perm())
+	assert.NoError(t, checkSocket(socket))
+
+	require.NoError(t, os.Chmod(dir, 0755))
+	_, err = NewClient(socket).Status()
+	assert.EqualError(t, err, "refusing to connect to the agent: "+dir+" must be a directory accessible only by the current user (mode 0700)")
+
+	_, err = listen(socket)
+	assert.EqualError(t, err, dir+" must be a directory accessible only by the current user (mode 0700)")
+}
@@ -0,0 +1,56 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsLock struct{}
+
+var lockFlags = flagsLock{}
+
+var lockCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "lock",
+		Short:   "Remove the keys from the agent memory and stop it",
+		Example: "flow agent lock",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &lockFlags,
+	Run:   lock,
+}
+
+func lock(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	if err := NewClient(SocketPath()).Lock(); err != nil {
+		return nil, err
+	}
+
+	return &result{result: "Agent locked, the keys were removed from memory."}, nil
+}
@@ -0,0 +1,45 @@
+//go:build !windows
+
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkOwner returns an error if the file is not owned by the current user, or if private is set
+// and the file is accessible by other users. Symbolic links are not followed.
+func checkOwner(path string, private bool) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return err
+	}
+
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("%s is not owned by the current user", path)
+	}
+	if private && (!info.IsDir() || info.Mode().Perm()&0077 != 0) {
+		return fmt.Errorf("%s must be a directory accessible only by the current user (mode 0700)", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+)
+
+// server holds the decrypted keys by the fingerprint of the encrypted key and signs with them
+// until the TTL expires or the agent is locked.
+type server struct {
+	mu        sync.Mutex
+	keys      map[string]crypto.PrivateKey
+	expiresAt time.Time
+	listener  net.Listener
+	done      chan struct{}
+}
+
+// listen creates the socket accessible only by the current user, a stale socket is removed.
+//
+// The socket is created in a directory private to the current user, so it can't be accessed by other users
+// before its permissions are changed.
+func listen(socket string) (net.Listener, error) {
+	dir := filepath.Dir(socket)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create the agent socket directory: %w", err)
+	}
+	if err := checkOwner(dir, true); err != nil {
+		return nil, err
+	}
+
+	if _, err := NewClient(socket).Status(); err == nil {
+		return nil, fmt.Errorf("agent is already running on %s, lock it first with 'flow agent lock'", socket)
+	}
+	_ = os.Remove(socket)
+
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(socket, 0600); err != nil {
+		_ = listener.Close()
+		return nil, err
+	}
+
+	return listener, nil
+}
+
+func newServer(listener net.Listener, keys map[string]crypto.PrivateKey, ttl time.Duration) *server {
+	s := &server{
+		keys:      keys,
+		expiresAt: time.Now().Add(ttl),
+		listener:  listener,
+		done:      make(chan struct{}),
+	}
+	time.AfterFunc(ttl, s.lock)
+	return s
+}
+
+// serve handles the connections until the agent is locked.
+func (s *server) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				<-s.done
+				return
+			}
+			continue
+		}
+		go s.handle(conn)
+	}
+}
+
+// lock removes the keys from memory and stops the agent.
+func (s *server) lock() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys == nil {
+		return
+	}
+	s.keys = nil
+	_ = s.listener.Close()
+	close(s.done)
+}
+
+func (s *server) handle(conn net.Conn) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	var req request
+	if err := json.NewDecoder(bufio.NewReader(conn)).Decode(&req); err != nil {
+		return
+	}
+
+	res, err := s.respond(req)
+	if err != nil {
+		res = &response{Error: err.Error()}
+	}
+	_ = json.NewEncoder(conn).Encode(res)
+
+	if req.Method == "lock" {
+		s.lock()
+	}
+}
+
+func (s *server) respond(req request) (*response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch req.Method {
+	case "status":
+		return &response{Keys: len(s.keys), ExpiresAt: s.expiresAt, PID: os.Getpid()}, nil
+	case "lock":
+		return &response{}, nil
+	case "publicKey", "sign":
+	default:
+		return nil, fmt.Errorf("unknown method %s", req.Method)
+	}
+
+	key, ok := s.keys[req.Fingerprint]
+	if !ok {
+		return nil, fmt.Errorf("key is not held by the agent")
+	}
+
+	if req.Method == "publicKey" {
+		return &response{
+			PublicKey: hex.EncodeToString(key.PublicKey().Encode()),
+			SigAlgo:   key.Algorithm().String(),
+		}, nil
+	}
+
+	message, err := hex.DecodeString(req.Message)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message: %w", err)
+	}
+
+	signer, err := crypto.NewInMemorySigner(key, crypto.StringToHashAlgorithm(req.HashAlgo))
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := signer.Sign(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response{Signature: hex.EncodeToString(sig)}, nil
+}
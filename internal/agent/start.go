@@ -0,0 +1,203 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsStart struct {
+	TTL             time.Duration `default:"1h" flag:"ttl" info:"Time after which the agent removes the keys from memory and stops"`
+	Socket          string        `default:"" flag:"socket" info:"Path of the agent socket, defaults to the FLOW_AGENT_SOCK environment variable or a socket in a directory private to the current user"`
+	Foreground      bool          `default:"false" flag:"foreground" info:"Run the agent in the foreground instead of in the background"`
+	PassphraseStdin bool          `default:"false" flag:"passphrase-stdin" info:"Read the passphrase from the standard input"`
+}
+
+var startFlags = flagsStart{}
+
+var startCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "start",
+		Short: "Start the agent holding the decrypted keys of the project",
+		Long: `Start the agent holding the decrypted keys of the project.
+
+The passphrase is entered once when the agent starts, the encrypted keys are decrypted and kept in memory
+by the agent running in the background. The following commands sign with the keys held by the agent without
+asking for the passphrase until the agent is locked with 'flow agent lock' or the TTL expires.`,
+		Example: "flow agent start --ttl 30m",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &startFlags,
+	RunS:  start,
+}
+
+func start(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	socket := startFlags.Socket
+	if socket == "" {
+		socket = SocketPath()
+	}
+
+	if startFlags.TTL <= 0 {
+		return nil, fmt.Errorf("TTL must be positive")
+	}
+	if !startFlags.Foreground {
+		if _, err := NewClient(socket).Status(); err == nil {
+			return nil, fmt.Errorf("agent is already running on %s, lock it first with 'flow agent lock'", socket)
+		}
+	}
+
+	passphrase, err := readPassphrase()
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := decryptKeys(state, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if startFlags.Foreground {
+		listener, err := listen(socket)
+		if err != nil {
+			return nil, err
+		}
+
+		logger.Info(fmt.Sprintf("%s Agent holding %d keys listening on %s", output.SuccessEmoji(), len(keys), socket))
+		newServer(listener, keys, startFlags.TTL).serve()
+		return &result{result: "Agent locked, the keys were removed from memory."}, nil
+	}
+
+	pid, err := startBackground(socket, passphrase, globalFlags)
+	if err != nil {
+		return nil, err
+	}
+
+	var out strings.Builder
+	out.WriteString(fmt.Sprintf(
+		"%s Agent started (pid %d) holding %d keys until %s.\n",
+		output.SuccessEmoji(),
+		pid,
+		len(keys),
+		time.Now().Add(startFlags.TTL).Format(time.Kitchen),
+	))
+	if socket != SocketPath() {
+		out.WriteString(fmt.Sprintf("Use the agent by setting %s=%s\n", SocketEnv, socket))
+	}
+	out.WriteString("Lock it with 'flow agent lock'.")
+
+	return &result{result: out.String()}, nil
+}
+
+func readPassphrase() (string, error) {
+	if !startFlags.PassphraseStdin {
+		return util.Passphrase()
+	}
+
+	passphrase, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	passphrase = strings.TrimRight(passphrase, "\r\n")
+	if passphrase == "" {
+		return "", fmt.Errorf("failed to read the passphrase from the standard input: %v", err)
+	}
+	return passphrase, nil
+}
+
+// decryptKeys decrypts the encrypted keys of the project accounts with the passphrase,
+// the keys are identified by the fingerprint of the encrypted key.
+func decryptKeys(state *flowkit.State, passphrase string) (map[string]crypto.PrivateKey, error) {
+	keys := make(map[string]crypto.PrivateKey)
+	for _, account := range *state.Accounts() {
+		key, ok := account.Key.(*accounts.EncryptedKey)
+		if !ok {
+			continue
+		}
+
+		pkey, err := accounts.DecryptPrivateKey(key.EncryptedKey(), key.SigAlgo(), passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt the key of account %s: %w", account.Name, err)
+		}
+		keys[fingerprint(key.EncryptedKey())] = pkey
+	}
+
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no encrypted keys found in the project, encrypt them with 'flow config encrypt-keys'")
+	}
+
+	return keys, nil
+}
+
+// startBackground starts the agent as a detached process passing it the passphrase on the standard input
+// and waits until it's listening.
+func startBackground(socket string, passphrase string, globalFlags command.GlobalFlags) (int, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return 0, err
+	}
+
+	args := []string{
+		"agent", "start",
+		"--foreground",
+		"--passphrase-stdin",
+		"--ttl", startFlags.TTL.String(),
+		"--socket", socket,
+		"--skip-version-check",
+	}
+	for _, path := range globalFlags.ConfigPaths {
+		args = append(args, "--config-path", path)
+	}
+
+	cmd := exec.Command(executable, args...)
+	cmd.Stdin = strings.NewReader(passphrase + "\n")
+	detach(cmd)
+
+	if err := cmd.Start(); err != nil {
+		return 0, fmt.Errorf("failed to start the agent: %w", err)
+	}
+
+	client := NewClient(socket)
+	for i := 0; i < 100; i++ {
+		if status, err := client.Status(); err == nil {
+			_ = cmd.Process.Release()
+			return status.PID, nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	_ = cmd.Process.Kill()
+	return 0, fmt.Errorf("agent did not start listening on %s", socket)
+}
@@ -0,0 +1,65 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package agent
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsStatus struct{}
+
+var statusFlags = flagsStatus{}
+
+var statusCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "status",
+		Short:   "Show whether the agent is running and the keys it holds",
+		Example: "flow agent status",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &statusFlags,
+	Run:   status,
+}
+
+func status(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	res, err := NewClient(SocketPath()).Status()
+	if err != nil {
+		return &result{result: "Agent is not running."}, nil
+	}
+
+	return &result{result: fmt.Sprintf(
+		"Agent (pid %d) is holding %d keys until %s.",
+		res.PID,
+		res.Keys,
+		res.ExpiresAt.Format(time.Kitchen),
+	)}, nil
+}
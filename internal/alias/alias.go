@@ -0,0 +1,192 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package alias implements command aliases with default flags, expanded by the root command.
+package alias
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"text/tabwriter"
+
+	"github.com/anmitsu/go-shlex"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/settings"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "alias",
+	Short: "Manage aliases for frequently used commands",
+	Long: `Aliases are named commands with default flags kept in the global settings.
+Arguments and flags following the alias name are appended to the aliased command, so flags passed
+this way override the defaults of the alias.`,
+	Example:          "flow alias add deploy-stg \"project deploy --network testnet --update\"\nflow deploy-stg\nflow deploy-stg --network previewnet",
+	TraverseChildren: true,
+}
+
+var addCommand = &cobra.Command{
+	Use:     "add <name> <command>",
+	Short:   "Add an alias or replace the command of an existing alias",
+	Example: "flow alias add deploy-stg \"project deploy --network testnet --update\"",
+	Args:    cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, command := args[0], args[1]
+		if err := validate(cmd.Root(), name, command); err != nil {
+			return err
+		}
+
+		if err := settings.SetAlias(name, command); err != nil {
+			return errors.Wrap(err, "failed to update aliases")
+		}
+
+		fmt.Printf("Alias %s added for 'flow %s'. Settings were updated in %s\n", name, command, settings.FileName())
+		return nil
+	},
+}
+
+var removeCommand = &cobra.Command{
+	Use:     "remove <name>",
+	Short:   "Remove an alias",
+	Example: "flow alias remove deploy-stg",
+	Args:    cobra.ExactArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		removed, err := settings.RemoveAlias(args[0])
+		if err != nil {
+			return errors.Wrap(err, "failed to update aliases")
+		}
+		if !removed {
+			return fmt.Errorf("alias %s does not exist", args[0])
+		}
+
+		fmt.Printf("Alias %s removed.\n", args[0])
+		return nil
+	},
+}
+
+var listCommand = &cobra.Command{
+	Use:     "list",
+	Short:   "List the aliases",
+	Example: "flow alias list",
+	Args:    cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		aliases, err := settings.Aliases()
+		if err != nil {
+			return errors.Wrap(err, "failed to read aliases")
+		}
+
+		fmt.Print(listAliases(aliases))
+		return nil
+	},
+}
+
+func init() {
+	Cmd.AddCommand(addCommand)
+	Cmd.AddCommand(removeCommand)
+	Cmd.AddCommand(listCommand)
+}
+
+var namePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// validate checks the alias doesn't shadow a command and its command resolves to a command.
+func validate(root *cobra.Command, name string, command string) error {
+	if !namePattern.MatchString(name) {
+		return fmt.Errorf("invalid alias name %s, the name must start with a letter and only contain letters, digits, dashes and underscores", name)
+	}
+	if isCommand(root, name) {
+		return fmt.Errorf("alias %s can not be used because it is the name of a command", name)
+	}
+
+	args, err := shlex.Split(command, true)
+	if err != nil {
+		return fmt.Errorf("invalid alias command: %w", err)
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("alias command can not be empty")
+	}
+	if !isCommand(root, args[0]) {
+		return fmt.Errorf("alias command must start with a command, %s is not a command", args[0])
+	}
+
+	return nil
+}
+
+// isCommand checks whether the name is a command or a command alias of the root command.
+func isCommand(root *cobra.Command, name string) bool {
+	// help and completion commands are only added by cobra when executing
+	if name == "help" || name == "completion" {
+		return true
+	}
+
+	for _, cmd := range root.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Expand replaces the alias name in the arguments with the aliased command.
+//
+// Only the first argument is expanded and commands always take precedence over aliases,
+// the arguments are returned unchanged if they don't start with an alias.
+func Expand(root *cobra.Command, args []string) []string {
+	aliases, err := settings.Aliases()
+	if err != nil {
+		return args
+	}
+	return expand(root, aliases, args)
+}
+
+func expand(root *cobra.Command, aliases []settings.Alias, args []string) []string {
+	if len(args) == 0 || isCommand(root, args[0]) {
+		return args
+	}
+
+	for _, alias := range aliases {
+		if alias.Name != args[0] {
+			continue
+		}
+
+		expanded, err := shlex.Split(alias.Command, true)
+		if err != nil {
+			return args
+		}
+		return append(expanded, args[1:]...)
+	}
+
+	return args
+}
+
+func listAliases(aliases []settings.Alias) string {
+	if len(aliases) == 0 {
+		return "No aliases were added, add one with 'flow alias add <name> <command>'.\n"
+	}
+
+	var b bytes.Buffer
+	writer := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "Alias\tCommand\n")
+	for _, alias := range aliases {
+		_, _ = fmt.Fprintf(writer, "%s\tflow %s\n", alias.Name, alias.Command)
+	}
+	_ = writer.Flush()
+
+	return b.String()
+}
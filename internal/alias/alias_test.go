@@ -0,0 +1,101 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package alias
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/internal/settings"
+)
+
+func testRoot() *cobra.Command {
+	root := &cobra.Command{Use: "flow"}
+	project := &cobra.Command{Use: "project", Aliases: []string{"p"}}
+	project.AddCommand(&cobra.Command{Use: "deploy", Run: func(*cobra.Command, []string) {}})
+	root.AddCommand(project)
+	return root
+}
+
+func Test_Expand(t *testing.T) {
+	root := testRoot()
+	aliases := []settings.Alias{{
+		Name:    "deploy-stg",
+		Command: `project deploy --network testnet --update --message "staging deploy"`,
+	}, {
+		Name:    "project",
+		Command: "project deploy",
+	}}
+
+	t.Run("Alias", func(t *testing.T) {
+		args := expand(root, aliases, []string{"deploy-stg", "--network", "previewnet"})
+		assert.Equal(t, []string{
+			"project", "deploy", "--network", "testnet", "--update", "--message", "staging deploy",
+			"--network", "previewnet",
+		}, args)
+	})
+
+	t.Run("Command Takes Precedence", func(t *testing.T) {
+		args := expand(root, aliases, []string{"project", "deploy"})
+		assert.Equal(t, []string{"project", "deploy"}, args)
+	})
+
+	t.Run("Not Alias", func(t *testing.T) {
+		assert.Equal(t, []string{"--help"}, expand(root, aliases, []string{"--help"}))
+		assert.Equal(t, []string{"deploy-prod"}, expand(root, aliases, []string{"deploy-prod"}))
+		assert.Empty(t, expand(root, aliases, nil))
+	})
+}
+
+func Test_Validate(t *testing.T) {
+	root := testRoot()
+
+	t.Run("Success", func(t *testing.T) {
+		assert.NoError(t, validate(root, "deploy-stg", "project deploy --network testnet"))
+		assert.NoError(t, validate(root, "d", "p deploy"))
+	})
+
+	t.Run("Fail Invalid Name", func(t *testing.T) {
+		assert.EqualError(t,
+			validate(root, "-d", "project deploy"),
+			"invalid alias name -d, the name must start with a letter and only contain letters, digits, dashes and underscores",
+		)
+	})
+
+	t.Run("Fail Command Name", func(t *testing.T) {
+		assert.EqualError(t, validate(root, "project", "project deploy"), "alias project can not be used because it is the name of a command")
+		assert.EqualError(t, validate(root, "help", "project deploy"), "alias help can not be used because it is the name of a command")
+	})
+
+	t.Run("Fail Invalid Command", func(t *testing.T) {
+		assert.EqualError(t, validate(root, "d", ""), "alias command can not be empty")
+		assert.EqualError(t, validate(root, "d", "deploy --update"), "alias command must start with a command, deploy is not a command")
+		assert.ErrorContains(t, validate(root, "d", `project deploy --message "unterminated`), "invalid alias command")
+	})
+}
+
+func Test_ListAliases(t *testing.T) {
+	assert.Equal(t, "No aliases were added, add one with 'flow alias add <name> <command>'.\n", listAliases(nil))
+	assert.Equal(t,
+		"Alias       Command\ndeploy-stg  flow project deploy --network testnet\n",
+		listAliases([]settings.Alias{{Name: "deploy-stg", Command: "project deploy --network testnet"}}),
+	)
+}
@@ -0,0 +1,280 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package audit implements the append-only audit log of the transactions sent by the CLI.
+//
+// Every entry contains the hash of the previous entry, so removed or changed entries are detected
+// when the log is verified.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// Operations recorded in the audit log.
+const (
+	OperationTransaction    = "transaction"
+	OperationAccountCreate  = "account-create"
+	OperationContractAdd    = "contract-add"
+	OperationContractUpdate = "contract-update"
+	OperationContractRemove = "contract-remove"
+	OperationKeyAdd         = "key-add"
+	OperationKeyRevoke      = "key-revoke"
+)
+
+// Entry is a transaction recorded in the audit log.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	User      string    `json:"user"`
+	Command   string    `json:"command"`
+	Network   string    `json:"network"`
+	Operation string    `json:"operation"`
+	// Contract is the name of the added, updated or removed contract.
+	Contract string `json:"contract,omitempty"`
+	// CodeHash is the SHA-256 hash of the deployed contract code, or of the transaction script for other operations.
+	CodeHash      string   `json:"codeHash,omitempty"`
+	TransactionID string   `json:"transactionId"`
+	Payer         string   `json:"payer"`
+	Proposer      string   `json:"proposer"`
+	Authorizers   []string `json:"authorizers"`
+	// Accounts are the names from configuration of the accounts signing the transaction.
+	Accounts []string `json:"accounts,omitempty"`
+	// Error is the error returned when sending the transaction, empty if it was sent.
+	Error string `json:"error,omitempty"`
+	// Previous is the hash of the previous entry in the audit log.
+	Previous string `json:"previous"`
+}
+
+// NewEntry creates the entry of the transaction, the accounts are named with the names from configuration if found.
+func NewEntry(tx *flow.Transaction, network string, names map[flow.Address]string) *Entry {
+	entry := &Entry{
+		Time:          time.Now().UTC(),
+		User:          currentUser(),
+		Network:       network,
+		TransactionID: tx.ID().String(),
+		Payer:         tx.Payer.Hex(),
+		Proposer:      tx.ProposalKey.Address.Hex(),
+		Authorizers:   make([]string, 0, len(tx.Authorizers)),
+	}
+
+	signers := append([]flow.Address{tx.ProposalKey.Address, tx.Payer}, tx.Authorizers...)
+	for _, authorizer := range tx.Authorizers {
+		entry.Authorizers = append(entry.Authorizers, authorizer.Hex())
+	}
+	for _, address := range signers {
+		if name, ok := names[address]; ok && !contains(entry.Accounts, name) {
+			entry.Accounts = append(entry.Accounts, name)
+		}
+	}
+
+	entry.Operation, entry.Contract, entry.CodeHash = classify(tx)
+	return entry
+}
+
+// classify finds the operation of the transaction from its script, the contract name and the code hash.
+//
+// Contract operations are recognized in the transactions built by the CLI, which pass the contract name
+// and the hex encoded code as the first two arguments.
+func classify(tx *flow.Transaction) (operation string, contract string, codeHash string) {
+	script := string(tx.Script)
+	codeHash = hash(tx.Script)
+
+	switch {
+	case strings.Contains(script, "AuthAccount(payer:"):
+		return OperationAccountCreate, "", codeHash
+	case strings.Contains(script, ".contracts.add("):
+		operation = OperationContractAdd
+	case strings.Contains(script, ".contracts.update__experimental("), strings.Contains(script, ".contracts.update("):
+		operation = OperationContractUpdate
+	case strings.Contains(script, ".contracts.remove("):
+		return OperationContractRemove, stringArgument(tx, 0), ""
+	case strings.Contains(script, ".keys.revoke("):
+		return OperationKeyRevoke, "", codeHash
+	case strings.Contains(script, ".keys.add("):
+		return OperationKeyAdd, "", codeHash
+	default:
+		return OperationTransaction, "", codeHash
+	}
+
+	contract = stringArgument(tx, 0)
+	if code, err := hex.DecodeString(stringArgument(tx, 1)); err == nil && len(code) > 0 {
+		codeHash = hash(code)
+	}
+	return operation, contract, codeHash
+}
+
+// stringArgument decodes the transaction argument at the index, empty if it isn't a string.
+func stringArgument(tx *flow.Transaction, index int) string {
+	if index >= len(tx.Arguments) {
+		return ""
+	}
+
+	value, err := jsoncdc.Decode(nil, tx.Arguments[index])
+	if err != nil {
+		return ""
+	}
+	if str, ok := value.(cadence.String); ok {
+		return string(str)
+	}
+	return ""
+}
+
+// Append adds the entry to the audit log at the path, linking it to the last entry.
+//
+// The log is locked until the entry is written, so entries appended by other processes link to it.
+func Append(path string, entry *Entry) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_RDWR, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := lockFile(file); err != nil {
+		return fmt.Errorf("failed to lock the audit log: %w", err)
+	}
+	defer func() { _ = unlockFile(file) }()
+
+	last, err := lastLine(file)
+	if err != nil {
+		return err
+	}
+	if last != nil {
+		entry.Previous = hash(last)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.Write(append(line, '\n'))
+	return err
+}
+
+// Read returns all the entries in the audit log at the path, it is empty if nothing was recorded yet.
+//
+// An error is returned if an entry doesn't link to the previous entry, which means the log was changed.
+func Read(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	var previous []byte
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var entry Entry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return entries, fmt.Errorf("invalid audit log entry %d: %w", len(entries)+1, err)
+		}
+
+		expected := ""
+		if previous != nil {
+			expected = hash(previous)
+		}
+		if entry.Previous != expected {
+			return entries, fmt.Errorf("audit log entry %d doesn't link to the previous entry, the audit log was changed", len(entries)+1)
+		}
+
+		entries = append(entries, entry)
+		previous = append(previous[:0], line...)
+	}
+
+	return entries, scanner.Err()
+}
+
+// lastLine reads the last line of the file, nil if the file is empty.
+func lastLine(file *os.File) ([]byte, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	// entries are much smaller than the tail, so it contains the whole last line
+	const tailSize = 1024 * 1024
+	offset := info.Size() - tailSize
+	if offset < 0 {
+		offset = 0
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	tail, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+
+	tail = bytes.TrimRight(tail, "\n")
+	if len(tail) == 0 {
+		return nil, nil
+	}
+	return tail[bytes.LastIndexByte(tail, '\n')+1:], nil
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func currentUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
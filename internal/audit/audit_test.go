@@ -0,0 +1,199 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway/mocks"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Classify(t *testing.T) {
+	pkey, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, make([]byte, crypto.MinSeedLength))
+	require.NoError(t, err)
+	signer := &accounts.Account{
+		Name:    "admin",
+		Address: flow.HexToAddress("0x01"),
+		Key:     accounts.NewHexKeyFromPrivateKey(0, crypto.SHA3_256, pkey),
+	}
+	code := []byte("pub contract Hello {}")
+
+	add, err := transactions.NewAddAccountContract(signer, "Hello", code, nil)
+	require.NoError(t, err)
+	operation, contract, codeHash := classify(add.FlowTransaction())
+	assert.Equal(t, OperationContractAdd, operation)
+	assert.Equal(t, "Hello", contract)
+	assert.Equal(t, hash(code), codeHash)
+
+	update, err := transactions.NewUpdateAccountContract(signer, "Hello", code)
+	require.NoError(t, err)
+	operation, contract, codeHash = classify(update.FlowTransaction())
+	assert.Equal(t, OperationContractUpdate, operation)
+	assert.Equal(t, "Hello", contract)
+	assert.Equal(t, hash(code), codeHash)
+
+	remove, err := transactions.NewRemoveAccountContract(signer, "Hello")
+	require.NoError(t, err)
+	operation, contract, codeHash = classify(remove.FlowTransaction())
+	assert.Equal(t, OperationContractRemove, operation)
+	assert.Equal(t, "Hello", contract)
+	assert.Empty(t, codeHash)
+
+	create, err := transactions.NewCreateAccount(signer, []*flow.AccountKey{{
+		PublicKey: pkey.PublicKey(),
+		SigAlgo:   crypto.ECDSA_secp256k1,
+		HashAlgo:  crypto.SHA3_256,
+		Weight:    flow.AccountKeyWeightThreshold,
+	}}, nil)
+	require.NoError(t, err)
+	operation, _, _ = classify(create.FlowTransaction())
+	assert.Equal(t, OperationAccountCreate, operation)
+
+	revoke := flow.NewTransaction().SetScript([]byte(`transaction { prepare(signer: AuthAccount) { signer.keys.revoke(keyIndex: 1) } }`))
+	operation, _, codeHash = classify(revoke)
+	assert.Equal(t, OperationKeyRevoke, operation)
+	assert.Equal(t, hash(revoke.Script), codeHash)
+
+	operation, _, _ = classify(flow.NewTransaction().SetScript([]byte(`transaction { execute {} }`)))
+	assert.Equal(t, OperationTransaction, operation)
+}
+
+func Test_AppendRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	entries, err := Read(path)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, Append(path, &Entry{TransactionID: id, Operation: OperationTransaction}))
+	}
+
+	entries, err = Read(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Empty(t, entries[0].Previous)
+	assert.NotEmpty(t, entries[2].Previous)
+
+	// removing an entry breaks the chain
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.SplitAfter(string(data), "\n")
+	require.NoError(t, os.WriteFile(path, []byte(lines[0]+lines[2]), 0600))
+
+	entries, err = Read(path)
+	assert.EqualError(t, err, "audit log entry 2 doesn't link to the previous entry, the audit log was changed")
+	assert.Len(t, entries, 1)
+}
+
+func Test_AppendConcurrent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+
+	_, state, _ := util.TestMocks(t)
+	emulatorAccount, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	audited := NewGateway(mocks.DefaultMockGateway().Mock, path, "", "flow project deploy", config.TestnetNetwork, state, output.NewStdoutLogger(output.NoneLog))
+
+	// appends must run in parallel even on a single CPU
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	// entries are appended both directly and by the gateway, all starting at the same time
+	const count = 50
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			assert.NoError(t, Append(path, &Entry{TransactionID: fmt.Sprintf("%d", i), Operation: OperationTransaction}))
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			tx := flow.NewTransaction().
+				SetScript([]byte(fmt.Sprintf(`transaction { execute { log(%d) } }`, i))).
+				SetProposalKey(emulatorAccount.Address, 0, uint64(i)).
+				SetPayer(emulatorAccount.Address)
+			_, err := audited.SendSignedTransaction(tx)
+			assert.NoError(t, err)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	entries, err := Read(path)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2*count)
+}
+
+func Test_Gateway(t *testing.T) {
+	var received Entry
+	sink := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&received)
+	}))
+	defer sink.Close()
+
+	_, state, _ := util.TestMocks(t)
+	emulatorAccount, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	gw := mocks.DefaultMockGateway()
+	path := filepath.Join(t.TempDir(), "audit.log")
+	audited := NewGateway(gw.Mock, path, sink.URL, "flow transactions send", config.TestnetNetwork, state, output.NewStdoutLogger(output.NoneLog))
+
+	tx := flow.NewTransaction().
+		SetScript([]byte(`transaction { execute {} }`)).
+		SetProposalKey(emulatorAccount.Address, 0, 0).
+		SetPayer(emulatorAccount.Address).
+		AddAuthorizer(emulatorAccount.Address)
+	_, err = audited.SendSignedTransaction(tx)
+	require.NoError(t, err)
+
+	entries, err := Read(path)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	entry := entries[0]
+	assert.Equal(t, tx.ID().String(), entry.TransactionID)
+	assert.Equal(t, "testnet", entry.Network)
+	assert.Equal(t, "flow transactions send", entry.Command)
+	assert.Equal(t, OperationTransaction, entry.Operation)
+	assert.Equal(t, []string{"emulator-account"}, entry.Accounts)
+	assert.Equal(t, []string{emulatorAccount.Address.Hex()}, entry.Authorizers)
+	assert.Equal(t, entry.TransactionID, received.TransactionID)
+}
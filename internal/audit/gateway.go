@@ -0,0 +1,131 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+var _ gateway.Gateway = &Gateway{}
+
+// Gateway records every transaction sent by the wrapped gateway in the audit log and sends it to the sink if set.
+type Gateway struct {
+	gateway.Gateway
+	path    string
+	sink    string
+	command string
+	network string
+	names   map[flow.Address]string
+	logger  output.Logger
+	// mu serializes appending the entries of transactions sent concurrently
+	mu sync.Mutex
+}
+
+// NewGateway returns a gateway recording the transactions sent by the command in the audit log at the path,
+// the accounts are named with the names from the configuration state if it exists.
+func NewGateway(
+	gw gateway.Gateway,
+	path string,
+	sink string,
+	command string,
+	network config.Network,
+	state *flowkit.State,
+	logger output.Logger,
+) *Gateway {
+	names := make(map[flow.Address]string)
+	if state != nil {
+		for _, account := range *state.Accounts() {
+			if _, ok := names[account.Address]; !ok {
+				names[account.Address] = account.Name
+			}
+		}
+	}
+
+	return &Gateway{
+		Gateway: gw,
+		path:    path,
+		sink:    sink,
+		command: command,
+		network: network.Name,
+		names:   names,
+		logger:  logger,
+	}
+}
+
+// Unwrap returns the wrapped gateway.
+func (g *Gateway) Unwrap() gateway.Gateway {
+	return g.Gateway
+}
+
+func (g *Gateway) SendSignedTransaction(tx *flow.Transaction) (*flow.Transaction, error) {
+	sent, err := g.Gateway.SendSignedTransaction(tx)
+
+	entry := NewEntry(tx, g.network, g.names)
+	entry.Command = g.command
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	// the transaction was already sent, so failing to record it is only reported
+	g.mu.Lock()
+	recordErr := Append(g.path, entry)
+	g.mu.Unlock()
+	if recordErr != nil {
+		g.logger.Error(fmt.Sprintf("%s Failed to record the transaction in the audit log %s: %s", output.WarningEmoji(), g.path, recordErr))
+	}
+	if g.sink != "" {
+		if sinkErr := send(g.sink, entry); sinkErr != nil {
+			g.logger.Error(fmt.Sprintf("%s Failed to send the audit log entry to %s: %s", output.WarningEmoji(), g.sink, sinkErr))
+		}
+	}
+
+	return sent, err
+}
+
+var sinkClient = &http.Client{Timeout: 5 * time.Second}
+
+// send posts the entry to the sink as JSON.
+func send(sink string, entry *Entry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sinkClient.Post(sink, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package auth implements the credentials store of the third-party service tokens and the commands managing them.
+//
+// Tokens are scoped to the provider, they are only added to the requests sent to the provider hosts.
+package auth
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+)
+
+var Cmd = &cobra.Command{
+	Use:              "auth",
+	Short:            "Manage the tokens of third-party services used by the CLI",
+	TraverseChildren: true,
+	GroupID:          "security",
+}
+
+func init() {
+	loginCommand.AddToParent(Cmd)
+	logoutCommand.AddToParent(Cmd)
+	statusCommand.AddToParent(Cmd)
+}
+
+// Provider is a third-party service the CLI sends requests to with the token of the user.
+type Provider struct {
+	Name        string
+	Description string
+	// Hosts are the hosts the token is sent to.
+	Hosts []string
+	// Header is the request header containing the token.
+	Header string
+	// Scheme prefixes the token in the header, e.g. "Bearer", the token is sent as is if empty.
+	Scheme string
+}
+
+// Lilico is the account creation service used when creating testnet and mainnet accounts.
+const Lilico = "lilico"
+
+// Providers are the third-party services supported by the credentials store by name.
+var Providers = map[string]Provider{
+	Lilico: {
+		Name:        Lilico,
+		Description: "Account creation service used by 'flow accounts create' on testnet and mainnet",
+		Hosts:       []string{"openapi.lilico.org"},
+		Header:      "Authorization",
+	},
+}
+
+// ProviderByName returns the provider or an error listing the supported providers.
+func ProviderByName(name string) (Provider, error) {
+	provider, ok := Providers[name]
+	if !ok {
+		names := maps.Keys(Providers)
+		sort.Strings(names)
+		return Provider{}, fmt.Errorf("unknown provider %s, supported providers are: %s", name, strings.Join(names, ", "))
+	}
+	return provider, nil
+}
+
+// TokenEnv is the environment variable overriding the stored token of the provider, e.g. FLOW_LILICO_TOKEN.
+func TokenEnv(provider string) string {
+	return fmt.Sprintf("FLOW_%s_TOKEN", strings.ToUpper(strings.ReplaceAll(provider, "-", "_")))
+}
+
+// Token gets the token of the provider from the environment or the credentials store, empty if the user isn't logged in.
+func Token(provider string) (string, error) {
+	if token := os.Getenv(TokenEnv(provider)); token != "" {
+		return token, nil
+	}
+
+	store, err := LoadStore()
+	if err != nil {
+		return "", err
+	}
+	return store.Token(provider)
+}
+
+// header returns the header value of the token for the provider.
+func (p Provider) header(token string) string {
+	if p.Scheme == "" {
+		return token
+	}
+	return fmt.Sprintf("%s %s", p.Scheme, token)
+}
+
+// matchesHost checks whether the token of the provider is sent to the host.
+func (p Provider) matchesHost(host string) bool {
+	for _, h := range p.Hosts {
+		if strings.EqualFold(h, host) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// useTestStore keeps the credentials file in a temporary directory and the keychain in memory,
+// the keychain is unavailable if the secrets map is nil.
+func useTestStore(t *testing.T, secrets map[string]string) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Cleanup(func() {
+		keychainSecret = util.KeychainSecret
+		saveKeychainSecret = util.SaveKeychainSecret
+		deleteKeychainSecret = util.DeleteKeychainSecret
+	})
+
+	keychainSecret = func(_ string, _ string, value string) (string, error) {
+		return secrets[value], nil
+	}
+	saveKeychainSecret = func(_ string, _ string, value string, _ string, secret string) error {
+		if secrets == nil {
+			return fmt.Errorf("keychain is not supported")
+		}
+		secrets[value] = secret
+		return nil
+	}
+	deleteKeychainSecret = func(_ string, _ string, value string) error {
+		delete(secrets, value)
+		return nil
+	}
+}
+
+func Test_Store(t *testing.T) {
+	t.Run("Keychain", func(t *testing.T) {
+		secrets := make(map[string]string)
+		useTestStore(t, secrets)
+
+		store, err := LoadStore()
+		require.NoError(t, err)
+
+		storage, err := store.Login(Lilico, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, StorageKeychain, storage)
+		assert.Equal(t, "secret", secrets[Lilico])
+
+		token, err := Token(Lilico)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", token)
+
+		// the token is not kept in the file
+		data, err := os.ReadFile(StorePath())
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "secret")
+
+		removed, err := store.Logout(Lilico)
+		require.NoError(t, err)
+		assert.True(t, removed)
+		assert.Empty(t, secrets)
+
+		token, err = Token(Lilico)
+		require.NoError(t, err)
+		assert.Empty(t, token)
+	})
+
+	t.Run("File", func(t *testing.T) {
+		useTestStore(t, nil)
+
+		store, err := LoadStore()
+		require.NoError(t, err)
+
+		storage, err := store.Login(Lilico, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, StorageFile, storage)
+
+		info, err := os.Stat(StorePath())
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+		token, err := Token(Lilico)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", token)
+
+		t.Setenv(TokenEnv(Lilico), "from-env")
+		token, err = Token(Lilico)
+		require.NoError(t, err)
+		assert.Equal(t, "from-env", token)
+	})
+}
+
+func Test_Transport(t *testing.T) {
+	useTestStore(t, nil)
+
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get("Authorization")
+	}))
+	defer server.Close()
+
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	Providers["test"] = Provider{Name: "test", Hosts: []string{serverURL.Hostname()}, Header: "Authorization", Scheme: "Bearer"}
+	t.Cleanup(func() { delete(Providers, "test") })
+
+	client := &http.Client{Transport: NewTransport(nil)}
+	request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	request.Header.Set("Authorization", "built-in")
+
+	// the request header is kept if the user isn't logged in
+	_, err = client.Do(request)
+	require.NoError(t, err)
+	assert.Equal(t, "built-in", received)
+
+	store, err := LoadStore()
+	require.NoError(t, err)
+	_, err = store.Login("test", "secret")
+	require.NoError(t, err)
+
+	_, err = client.Do(request)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer secret", received)
+	assert.Equal(t, "built-in", request.Header.Get("Authorization"))
+
+	// tokens are not sent to other hosts
+	Providers["test"] = Provider{Name: "test", Hosts: []string{"example.com"}, Header: "Authorization"}
+	request.Header.Del("Authorization")
+	_, err = client.Do(request)
+	require.NoError(t, err)
+	assert.Empty(t, received)
+}
+
+func Test_ProviderByName(t *testing.T) {
+	_, err := ProviderByName("unknown")
+	assert.EqualError(t, err, "unknown provider unknown, supported providers are: lilico")
+}
@@ -0,0 +1,156 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package auth
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsLogin struct {
+	Token      string `default:"" flag:"token" info:"Token of the provider, prompted for if not provided"`
+	TokenStdin bool   `default:"false" flag:"token-stdin" info:"Read the token of the provider from stdin"`
+}
+
+var loginFlags = flagsLogin{}
+
+var loginCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "login <provider>",
+		Short: "Store the token of the third-party service provider",
+		Long: `Store the token of the third-party service provider in the OS keychain, or in the credentials file
+readable only by you if the keychain isn't available. The token is only sent to the provider hosts.`,
+		Example: "flow auth login lilico\necho $TOKEN | flow auth login lilico --token-stdin",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &loginFlags,
+	Run:   login,
+}
+
+var logoutCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "logout <provider>",
+		Short:   "Remove the stored token of the third-party service provider",
+		Example: "flow auth logout lilico",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &struct{}{},
+	Run:   logout,
+}
+
+func login(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	provider, err := ProviderByName(args[0])
+	if err != nil {
+		return nil, command.WithExitCode(command.ExitValidationError, err)
+	}
+
+	token := loginFlags.Token
+	if loginFlags.TokenStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return nil, fmt.Errorf("failed to read the token from stdin: %w", err)
+		}
+		token = line
+	}
+	if token == "" {
+		token = util.TokenPrompt(provider.Name, fmt.Sprintf(
+			"use the --token or --token-stdin flag, or the %s environment variable",
+			TokenEnv(provider.Name),
+		))
+	}
+
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return nil, command.WithExitCode(command.ExitValidationError, fmt.Errorf("token can not be empty"))
+	}
+
+	store, err := LoadStore()
+	if err != nil {
+		return nil, err
+	}
+
+	storage, err := store.Login(provider.Name, token)
+	if err != nil {
+		return nil, err
+	}
+
+	location := "the OS keychain"
+	if storage == StorageFile {
+		location = StorePath()
+	}
+	return &result{result: fmt.Sprintf("Logged in to %s, the token is stored in %s.", provider.Name, location)}, nil
+}
+
+func logout(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	provider, err := ProviderByName(args[0])
+	if err != nil {
+		return nil, command.WithExitCode(command.ExitValidationError, err)
+	}
+
+	store, err := LoadStore()
+	if err != nil {
+		return nil, err
+	}
+
+	removed, err := store.Logout(provider.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !removed {
+		return nil, fmt.Errorf("not logged in to %s", provider.Name)
+	}
+
+	return &result{result: fmt.Sprintf("Logged out of %s, the token was removed.", provider.Name)}, nil
+}
+
+type result struct {
+	result string
+}
+
+func (r *result) JSON() any {
+	return map[string]any{"result": r.result}
+}
+
+func (r *result) String() string {
+	return r.result
+}
+
+func (r *result) Oneliner() string {
+	return strings.ReplaceAll(r.result, "\n", " ")
+}
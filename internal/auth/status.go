@@ -0,0 +1,121 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package auth
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var statusCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "status",
+		Short:   "Show the third-party service providers and where their tokens are stored",
+		Example: "flow auth status",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	Run:   status,
+}
+
+func status(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	store, err := LoadStore()
+	if err != nil {
+		return nil, err
+	}
+
+	names := maps.Keys(Providers)
+	sort.Strings(names)
+
+	providers := make([]providerStatus, 0, len(names))
+	for _, name := range names {
+		token := "not logged in"
+		if os.Getenv(TokenEnv(name)) != "" {
+			token = fmt.Sprintf("%s environment variable", TokenEnv(name))
+		} else if credential, ok := store.Credentials[name]; ok {
+			token = credential.Storage
+		}
+
+		providers = append(providers, providerStatus{
+			Name:        name,
+			Description: Providers[name].Description,
+			Hosts:       Providers[name].Hosts,
+			Token:       token,
+		})
+	}
+
+	return &statusResult{providers: providers}, nil
+}
+
+type providerStatus struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Hosts       []string `json:"hosts"`
+	Token       string   `json:"token"`
+}
+
+type statusResult struct {
+	providers []providerStatus
+}
+
+func (s *statusResult) JSON() any {
+	return s.providers
+}
+
+func (s *statusResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for i, provider := range s.providers {
+		if i > 0 {
+			_, _ = fmt.Fprintf(writer, "\n")
+		}
+		_, _ = fmt.Fprintf(writer, "Provider\t%s\n", provider.Name)
+		_, _ = fmt.Fprintf(writer, "Description\t%s\n", provider.Description)
+		_, _ = fmt.Fprintf(writer, "Hosts\t%s\n", strings.Join(provider.Hosts, ", "))
+		_, _ = fmt.Fprintf(writer, "Token\t%s\n", provider.Token)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (s *statusResult) Oneliner() string {
+	items := make([]string, 0, len(s.providers))
+	for _, provider := range s.providers {
+		items = append(items, fmt.Sprintf("%s: %s", provider.Name, provider.Token))
+	}
+	return strings.Join(items, ", ")
+}
@@ -0,0 +1,149 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onflow/flow-cli/internal/settings"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// keychainService is the service name under which the provider tokens are stored in the OS keychain.
+const keychainService = "flow-cli-auth"
+
+const credentialsFile = "flow-cli.credentials.json"
+
+// Storage locations of the tokens.
+const (
+	StorageKeychain = "keychain"
+	StorageFile     = "file"
+)
+
+// Credential is the credentials store entry of a provider, the token is only kept in the file
+// if the OS keychain isn't available.
+type Credential struct {
+	Storage string `json:"storage"`
+	Token   string `json:"token,omitempty"`
+}
+
+// Store keeps the provider tokens in the OS keychain, or in the credentials file readable only by the user
+// if the keychain isn't available. The credentials file lists all the providers the user is logged in to.
+type Store struct {
+	Credentials map[string]Credential `json:"credentials"`
+}
+
+// The OS keychain is accessed through variables so it can be replaced in tests.
+var (
+	keychainSecret       = util.KeychainSecret
+	saveKeychainSecret   = util.SaveKeychainSecret
+	deleteKeychainSecret = util.DeleteKeychainSecret
+)
+
+// StorePath is the path of the credentials file.
+func StorePath() string {
+	return filepath.Join(settings.FileDir(), credentialsFile)
+}
+
+// LoadStore reads the credentials store, it is empty if the user isn't logged in to any provider.
+func LoadStore() (*Store, error) {
+	store := &Store{Credentials: make(map[string]Credential)}
+
+	data, err := os.ReadFile(StorePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the credentials file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, store); err != nil {
+		return nil, fmt.Errorf("failed to parse the credentials file %s: %w", StorePath(), err)
+	}
+	if store.Credentials == nil {
+		store.Credentials = make(map[string]Credential)
+	}
+	return store, nil
+}
+
+// Token gets the stored token of the provider, empty if the user isn't logged in.
+func (s *Store) Token(provider string) (string, error) {
+	credential, ok := s.Credentials[provider]
+	if !ok {
+		return "", nil
+	}
+
+	if credential.Storage == StorageKeychain {
+		token, err := keychainSecret(keychainService, "provider", provider)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the %s token from keychain: %w", provider, err)
+		}
+		return token, nil
+	}
+	return credential.Token, nil
+}
+
+// Login stores the token of the provider in the OS keychain, or in the credentials file if the keychain
+// isn't available, and returns the storage used.
+func (s *Store) Login(provider string, token string) (string, error) {
+	credential := Credential{Storage: StorageKeychain}
+	err := saveKeychainSecret(keychainService, "provider", provider, fmt.Sprintf("Flow CLI %s token", provider), token)
+	if err != nil {
+		credential = Credential{Storage: StorageFile, Token: token}
+	}
+
+	s.Credentials[provider] = credential
+	return credential.Storage, s.save()
+}
+
+// Logout removes the token of the provider, it returns false if the user isn't logged in.
+func (s *Store) Logout(provider string) (bool, error) {
+	credential, ok := s.Credentials[provider]
+	if !ok {
+		return false, nil
+	}
+
+	if credential.Storage == StorageKeychain {
+		if err := deleteKeychainSecret(keychainService, "provider", provider); err != nil {
+			return false, fmt.Errorf("failed to remove the %s token from keychain: %w", provider, err)
+		}
+	}
+
+	delete(s.Credentials, provider)
+	return true, s.save()
+}
+
+func (s *Store) save() error {
+	data, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(StorePath()), 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(StorePath(), append(data, '\n'), 0600); err != nil {
+		return err
+	}
+	// the permissions of an existing file are not changed when writing it
+	return os.Chmod(StorePath(), 0600)
+}
@@ -0,0 +1,60 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package auth
+
+import (
+	"net/http"
+)
+
+var _ http.RoundTripper = &Transport{}
+
+// Transport adds the token of the provider to the requests sent to the provider hosts.
+//
+// Requests to other hosts are sent unchanged, so a token is never sent to another service.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// NewTransport returns a transport adding the provider tokens to the requests sent with the base transport.
+func NewTransport(base http.RoundTripper) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{Base: base}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for _, provider := range Providers {
+		if !provider.matchesHost(req.URL.Hostname()) {
+			continue
+		}
+
+		token, err := Token(provider.Name)
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			// requests must not be modified by the transport
+			req = req.Clone(req.Context())
+			req.Header.Set(provider.Header, provider.header(token))
+		}
+		break
+	}
+
+	return t.Base.RoundTrip(req)
+}
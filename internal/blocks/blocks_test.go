@@ -19,12 +19,14 @@
 package blocks
 
 import (
+	"context"
 	"strings"
 	"testing"
 
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/tests"
@@ -98,3 +100,56 @@ Total Collections	3
 		result.JSON(),
 	)
 }
+
+func Test_GetBlockRange(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	blockFlags.Events = ""
+	blockFlags.Include = nil
+
+	srv.GetBlock.Run(func(args mock.Arguments) {
+		query := args.Get(1).(flowkit.BlockQuery)
+		block := tests.NewBlock()
+		block.Height = query.Height
+		if query.Latest {
+			block.Height = 50
+		}
+		srv.GetBlock.Return(block, nil)
+	})
+	srv.GetCollection.Return(tests.NewCollection(), nil)
+
+	t.Run("Success range", func(t *testing.T) {
+		result, err := get([]string{"10-12"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+
+		blocks := result.(*blockRangeResult).blocks
+		require.Len(t, blocks, 3)
+		assert.Equal(t, uint64(10), blocks[0].block.Height)
+		assert.Equal(t, uint64(12), blocks[2].block.Height)
+		assert.Equal(t, 2, blocks[0].transactions)
+	})
+
+	t.Run("Success range to latest", func(t *testing.T) {
+		start, end, isRange, err := parseBlockRange(context.Background(), srv.Mock, "48-latest")
+		require.NoError(t, err)
+		assert.True(t, isRange)
+		assert.Equal(t, uint64(48), start)
+		assert.Equal(t, uint64(50), end)
+	})
+
+	t.Run("Success relative height", func(t *testing.T) {
+		result, err := get([]string{"latest-10"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(40), result.(*blockResult).block.Height)
+	})
+
+	t.Run("Fail invalid range", func(t *testing.T) {
+		_, err := get([]string{"20-10"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid block range 20-10, start height is greater than end height")
+
+		_, err = get([]string{"10-foo"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "invalid block range 10-foo, must be in the form <start>-<end> with end being a height or latest")
+
+		_, err = get([]string{"latest-100"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "relative block height latest-100 is before the first block, latest height is 50")
+	})
+}
@@ -20,6 +20,12 @@ package blocks
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
@@ -30,18 +36,32 @@ import (
 )
 
 type flagsBlocks struct {
-	Events  string   `default:"" flag:"events" info:"List events of this type for the block"`
-	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: transactions."`
+	Events   string        `default:"" flag:"events" info:"List events of this type for the block"`
+	Include  []string      `default:"" flag:"include" info:"Fields to include in the output. Valid values: transactions."`
+	Follow   bool          `default:"false" flag:"follow" info:"Keep printing new sealed blocks as they are produced"`
+	Interval time.Duration `default:"1s" flag:"interval" info:"Interval at which new blocks are polled when following"`
 }
 
 var blockFlags = flagsBlocks{}
 
 var getCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "get <block_id|latest|block_height>",
-		Short:   "Get block info",
-		Example: "flow blocks get latest --network testnet",
-		Args:    cobra.ExactArgs(1),
+		Use:   "get <block_id|latest|block_height|latest-N|start-end>",
+		Short: "Get block info",
+		Example: `flow blocks get latest --network testnet
+
+#get the blocks in a height range with their transaction counts
+flow blocks get 100-200
+
+#get the block 10 blocks before the latest one
+flow blocks get latest-10
+
+#list the transactions of the blocks from a height to the latest one
+flow blocks get 100-latest --include transactions
+
+#follow new sealed blocks
+flow blocks get latest --follow`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &blockFlags,
 	Run:   get,
@@ -49,13 +69,37 @@ var getCommand = &command.Command{
 
 func get(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
 	_ flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
+	start, end, isRange, err := parseBlockRange(context.Background(), flow, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if isRange || blockFlags.Follow {
+		if !isRange {
+			query, err := parseBlockQuery(context.Background(), flow, args[0])
+			if err != nil {
+				return nil, err
+			}
+			block, err := flow.GetBlock(context.Background(), query)
+			if err != nil {
+				return nil, err
+			}
+			start, end = block.Height, block.Height
+		}
+
+		expand := command.ContainsFlag(blockFlags.Include, "transactions")
+		if blockFlags.Follow {
+			return nil, followBlocks(flow, start, expand, globalFlags)
+		}
+		return getBlockRange(flow, logger, start, end, expand)
+	}
 
-	query, err := flowkit.NewBlockQuery(args[0])
+	query, err := parseBlockQuery(context.Background(), flow, args[0])
 	if err != nil {
 		return nil, err
 	}
@@ -99,3 +143,78 @@ func get(
 		included:    blockFlags.Include,
 	}, nil
 }
+
+func getBlockRange(
+	flow flowkit.Services,
+	logger output.Logger,
+	start uint64,
+	end uint64,
+	expand bool,
+) (command.Result, error) {
+	result := &blockRangeResult{}
+	total := int(end - start + 1)
+	progress := output.LoggerProgress(logger, fmt.Sprintf("Fetching blocks %d-%d", start, end))
+	progress(0, total)
+
+	for height := start; height <= end; height++ {
+		summary, err := getBlockSummary(context.Background(), flow, height, expand)
+		if err != nil {
+			return nil, err
+		}
+		result.blocks = append(result.blocks, summary)
+		progress(len(result.blocks), total)
+	}
+
+	return result, nil
+}
+
+// followBlocks prints the blocks from the start height and every new sealed block after it until interrupted.
+//
+// Blocks are written as they are fetched, so the output can be piped into other tools or saved while following.
+func followBlocks(flow flowkit.Services, start uint64, expand bool, globalFlags command.GlobalFlags) error {
+	jsonOutput := globalFlags.Format == "json"
+
+	out, err := command.OpenOutput(globalFlags)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if !jsonOutput {
+		_, _ = fmt.Fprint(out, strings.ReplaceAll(blockSummaryHeader, "\t", "  "))
+	}
+
+	next := start
+	for {
+		latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+
+		for ; err == nil && next <= latest.Height && ctx.Err() == nil; next++ {
+			summary, err := getBlockSummary(ctx, flow, next, expand)
+			if err != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				return err
+			}
+
+			if jsonOutput {
+				encoded, _ := command.EncodeJSON(globalFlags, summary.JSON())
+				_, _ = fmt.Fprintln(out, string(encoded))
+			} else {
+				_, _ = fmt.Fprint(out, strings.ReplaceAll(summary.String(), "\t", "  "))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(blockFlags.Interval):
+		}
+	}
+}
@@ -0,0 +1,226 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package blocks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// parseBlockQuery parses the block query, supporting heights relative to the latest block like "latest-10".
+func parseBlockQuery(ctx context.Context, flow flowkit.Services, arg string) (flowkit.BlockQuery, error) {
+	if !strings.HasPrefix(arg, "latest-") {
+		return flowkit.NewBlockQuery(arg)
+	}
+
+	n, err := strconv.ParseUint(strings.TrimPrefix(arg, "latest-"), 10, 64)
+	if err != nil {
+		return flowkit.BlockQuery{}, fmt.Errorf("invalid relative block height %s, must be in the form latest-<number>", arg)
+	}
+
+	latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+	if err != nil {
+		return flowkit.BlockQuery{}, err
+	}
+	if n > latest.Height {
+		return flowkit.BlockQuery{}, fmt.Errorf("relative block height %s is before the first block, latest height is %d", arg, latest.Height)
+	}
+
+	return flowkit.BlockQuery{Height: latest.Height - n}, nil
+}
+
+// parseBlockRange parses height ranges like "100-200" or "100-latest",
+// returning false if the argument is not a range and should be handled as a block query.
+func parseBlockRange(ctx context.Context, flow flowkit.Services, arg string) (uint64, uint64, bool, error) {
+	if strings.HasPrefix(arg, "latest") {
+		return 0, 0, false, nil
+	}
+
+	from, to, ok := strings.Cut(arg, "-")
+	if !ok {
+		return 0, 0, false, nil
+	}
+
+	invalidRange := fmt.Errorf("invalid block range %s, must be in the form <start>-<end> with end being a height or latest", arg)
+	start, err := strconv.ParseUint(from, 10, 64)
+	if err != nil {
+		return 0, 0, false, invalidRange
+	}
+
+	var end uint64
+	if to == "latest" {
+		latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+		if err != nil {
+			return 0, 0, false, err
+		}
+		end = latest.Height
+	} else if end, err = strconv.ParseUint(to, 10, 64); err != nil {
+		return 0, 0, false, invalidRange
+	}
+
+	if start > end {
+		return 0, 0, false, fmt.Errorf("invalid block range %s, start height is greater than end height", arg)
+	}
+
+	return start, end, true, nil
+}
+
+// blockSummary is a block with its transaction count and optionally the expanded transactions.
+type blockSummary struct {
+	block        *flowsdk.Block
+	transactions int
+	expanded     []*flowsdk.Transaction
+	results      []*flowsdk.TransactionResult
+}
+
+// getBlockSummary fetches the block at the height with the number of transactions in its collections,
+// and the transactions with their results if they should be expanded.
+func getBlockSummary(ctx context.Context, flow flowkit.Services, height uint64, expand bool) (*blockSummary, error) {
+	block, err := flow.GetBlock(ctx, flowkit.BlockQuery{Height: height})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &blockSummary{block: block}
+	txIDs := make(map[flowsdk.Identifier]bool)
+	for _, guarantee := range block.CollectionGuarantees {
+		collection, err := flow.GetCollection(ctx, guarantee.CollectionID)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range collection.TransactionIDs {
+			txIDs[id] = true
+		}
+	}
+	summary.transactions = len(txIDs)
+
+	if expand && summary.transactions > 0 {
+		txs, results, err := flow.GetTransactionsByBlockID(ctx, block.ID)
+		if err != nil {
+			return nil, err
+		}
+		// only user transactions from the collections are shown, which excludes the system transaction
+		for i, tx := range txs {
+			if txIDs[tx.ID()] && i < len(results) {
+				summary.expanded = append(summary.expanded, tx)
+				summary.results = append(summary.results, results[i])
+			}
+		}
+	}
+
+	return summary, nil
+}
+
+func (s *blockSummary) JSON() any {
+	result := map[string]any{
+		"blockId":           s.block.ID.String(),
+		"parentId":          s.block.ParentID.String(),
+		"height":            s.block.Height,
+		"timestamp":         s.block.Timestamp,
+		"totalCollections":  len(s.block.CollectionGuarantees),
+		"totalTransactions": s.transactions,
+	}
+
+	if s.expanded != nil {
+		txs := make([]any, 0, len(s.expanded))
+		for i, tx := range s.expanded {
+			entry := map[string]any{
+				"id":     tx.ID().String(),
+				"payer":  tx.Payer.Hex(),
+				"status": s.results[i].Status.String(),
+				"events": len(s.results[i].Events),
+			}
+			if s.results[i].Error != nil {
+				entry["error"] = s.results[i].Error.Error()
+			}
+			txs = append(txs, entry)
+		}
+		result["transactions"] = txs
+	}
+
+	return result
+}
+
+// String returns the block as a line, with a line for each expanded transaction below it.
+func (s *blockSummary) String() string {
+	line := fmt.Sprintf(
+		"%d\t%s\t%s\t%d\t%d\n",
+		s.block.Height,
+		s.block.ID,
+		s.block.Timestamp.UTC().Format("2006-01-02 15:04:05"),
+		len(s.block.CollectionGuarantees),
+		s.transactions,
+	)
+
+	for i, tx := range s.expanded {
+		status := s.results[i].Status.String()
+		if s.results[i].Error != nil {
+			status = "FAILED"
+		}
+		line += fmt.Sprintf(
+			"    Transaction %s\t%s\tpayer 0x%s\t%d events\t\t\n",
+			tx.ID(), status, tx.Payer.Hex(), len(s.results[i].Events),
+		)
+	}
+
+	return line
+}
+
+const blockSummaryHeader = "Height\tBlock ID\tTimestamp\tCollections\tTransactions\n"
+
+type blockRangeResult struct {
+	blocks []*blockSummary
+}
+
+func (r *blockRangeResult) JSON() any {
+	result := make([]any, 0, len(r.blocks))
+	for _, b := range r.blocks {
+		result = append(result, b.JSON())
+	}
+	return result
+}
+
+func (r *blockRangeResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprint(writer, blockSummaryHeader)
+	for _, block := range r.blocks {
+		_, _ = fmt.Fprint(writer, block.String())
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *blockRangeResult) Oneliner() string {
+	ids := make([]string, 0, len(r.blocks))
+	for _, b := range r.blocks {
+		ids = append(ids, b.block.ID.String())
+	}
+	return strings.Join(ids, ",")
+}
@@ -22,7 +22,9 @@ import (
 	"github.com/onflow/cadence/runtime/cmd/execute"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/internal/cadence/format"
 	"github.com/onflow/flow-cli/internal/cadence/languageserver"
+	"github.com/onflow/flow-cli/internal/cadence/lint"
 )
 
 var Cmd = &cobra.Command{
@@ -44,4 +46,6 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(languageserver.Cmd)
+	format.Command.AddToParent(Cmd)
+	lint.Command.AddToParent(Cmd)
 }
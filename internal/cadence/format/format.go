@@ -0,0 +1,231 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package format implements formatting of Cadence files using the Cadence parser.
+package format
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/parser/lexer"
+	"github.com/spf13/cobra"
+	"github.com/turbolent/prettier"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+const (
+	maxLineWidth = 100
+	indent       = "    "
+)
+
+// errComments is returned for code with comments, which the Cadence parser doesn't preserve.
+var errComments = errors.New("contains comments, which would be removed by formatting")
+
+type flagsFormat struct {
+	Check bool `default:"false" flag:"check" info:"Only check the files are formatted, failing if they are not or were skipped"`
+}
+
+var formatFlags = flagsFormat{}
+
+var Command = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "fmt [files or directories...]",
+		Short: "Format Cadence files",
+		Long: `Format Cadence files, .cdc files are found recursively in the provided directories. If no files or
+directories are provided, the directories of the configured contracts and the cadence directory are formatted.
+Files containing comments are skipped, since comments are not preserved by the formatter, and fail the check.`,
+		Example: "flow cadence fmt\nflow cadence fmt ./contracts/Hello.cdc\nflow cadence fmt --check",
+		Args:    cobra.ArbitraryArgs,
+	},
+	Flags: &formatFlags,
+	Run:   formatFiles,
+}
+
+func formatFiles(
+	args []string,
+	flags command.GlobalFlags,
+	_ output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	paths := args
+	if len(paths) == 0 {
+		// the configuration is optional, files can be formatted outside of a project
+		state, _ := flowkit.LoadWithVars(flags.ConfigPaths, readerWriter, flags.Vars)
+		paths = util.CadencePaths(state)
+	}
+
+	files, err := util.FindCadenceFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &formatResult{
+		check:   formatFlags.Check,
+		skipped: make(map[string]string),
+	}
+	for _, file := range files {
+		code, err := readerWriter.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading file %s: %w", file, err)
+		}
+
+		formatted, err := format(code)
+		if err != nil {
+			result.skipped[file] = err.Error()
+			continue
+		}
+
+		if bytes.Equal(code, formatted) {
+			result.unchanged++
+			continue
+		}
+
+		result.changed = append(result.changed, file)
+		if formatFlags.Check {
+			continue
+		}
+
+		if err := readerWriter.WriteFile(file, formatted, 0644); err != nil {
+			return nil, fmt.Errorf("error writing file %s: %w", file, err)
+		}
+	}
+
+	return result, nil
+}
+
+// format returns the formatted code, the code isn't formatted if it contains comments or can't be parsed.
+func format(code []byte) ([]byte, error) {
+	if hasComments(code) {
+		return nil, errComments
+	}
+
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	var b strings.Builder
+	prettier.Prettier(&b, program.Doc(), maxLineWidth, indent)
+
+	// blank lines are indented by the formatter
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	formatted := []byte(strings.TrimRight(strings.Join(lines, "\n"), "\n") + "\n")
+
+	// make sure formatting can never turn valid code into invalid code
+	if _, err := parser.ParseProgram(nil, formatted, parser.Config{}); err != nil {
+		return nil, fmt.Errorf("formatted code is invalid: %w", err)
+	}
+
+	return formatted, nil
+}
+
+// hasComments checks whether the code contains line or block comments.
+func hasComments(code []byte) bool {
+	tokens := lexer.Lex(code, nil)
+	defer tokens.Reclaim()
+
+	for {
+		token := tokens.Next()
+		switch token.Type {
+		case lexer.TokenEOF:
+			return false
+		case lexer.TokenLineComment, lexer.TokenBlockCommentStart:
+			return true
+		}
+	}
+}
+
+type formatResult struct {
+	check     bool
+	changed   []string
+	unchanged int
+	skipped   map[string]string
+}
+
+var _ command.ExitCodeResult = &formatResult{}
+
+func (r *formatResult) JSON() any {
+	changedKey := "formatted"
+	if r.check {
+		changedKey = "unformatted"
+	}
+
+	changed := r.changed
+	if changed == nil {
+		changed = []string{}
+	}
+	return map[string]any{
+		changedKey:  changed,
+		"unchanged": r.unchanged,
+		"skipped":   r.skipped,
+	}
+}
+
+func (r *formatResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	status := "Formatted"
+	if r.check {
+		status = "Not formatted"
+	}
+	for _, file := range r.changed {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\n", status, file)
+	}
+
+	skipped := make([]string, 0, len(r.skipped))
+	for file := range r.skipped {
+		skipped = append(skipped, file)
+	}
+	sort.Strings(skipped)
+	for _, file := range skipped {
+		_, _ = fmt.Fprintf(writer, "Skipped\t%s: %s\n", file, r.skipped[file])
+	}
+
+	_ = writer.Flush()
+	_, _ = fmt.Fprintf(&b, "%s\n", r.Oneliner())
+	return b.String()
+}
+
+func (r *formatResult) Oneliner() string {
+	if r.check {
+		return fmt.Sprintf("%d files not formatted, %d formatted, %d skipped", len(r.changed), r.unchanged, len(r.skipped))
+	}
+	return fmt.Sprintf("%d files formatted, %d unchanged, %d skipped", len(r.changed), r.unchanged, len(r.skipped))
+}
+
+// ExitCode fails the check if any of the files is not formatted or could not be checked.
+func (r *formatResult) ExitCode() int {
+	if r.check && (len(r.changed) > 0 || len(r.skipped) > 0) {
+		return command.ExitError
+	}
+	return command.ExitSuccess
+}
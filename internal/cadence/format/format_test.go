@@ -0,0 +1,149 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+const unformatted = `pub contract Hello {
+  pub let greeting:String
+  init(){ self.greeting="Hello, World!" }
+  pub fun hello(name:String):String{ return self.greeting.concat(name) }
+}`
+
+const formatted = `pub contract Hello {
+    pub let greeting: String
+
+    init() {
+        self.greeting = "Hello, World!"
+    }
+
+    pub fun hello(name: String): String {
+        return self.greeting.concat(name)
+    }
+}
+`
+
+func Test_Format(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		code, err := format([]byte(unformatted))
+		require.NoError(t, err)
+		assert.Equal(t, formatted, string(code))
+
+		code, err = format(code)
+		require.NoError(t, err)
+		assert.Equal(t, formatted, string(code))
+	})
+
+	t.Run("Fail Comments", func(t *testing.T) {
+		_, err := format([]byte("// greeting\npub fun main(): String { return \"hi\" }"))
+		assert.ErrorIs(t, err, errComments)
+
+		_, err = format([]byte("pub fun main(): String { /* greeting */ return \"hi\" }"))
+		assert.ErrorIs(t, err, errComments)
+
+		_, err = format([]byte("/// greeting\npub fun main(): String { return \"hi\" }"))
+		assert.ErrorIs(t, err, errComments)
+	})
+
+	t.Run("Fail Invalid", func(t *testing.T) {
+		_, err := format([]byte("pub fun main( {"))
+		assert.ErrorContains(t, err, "failed to parse")
+	})
+}
+
+func Test_FormatFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"contracts/Hello.cdc":      unformatted,
+		"contracts/Formatted.cdc":  formatted,
+		"scripts/comment.cdc":      "// comment\npub fun main() {}",
+		"scripts/.hidden/a.cdc":    unformatted,
+		"scripts/not_cadence.json": "{}",
+		"transactions/invalid.cdc": "transaction {",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	rw := &afero.Afero{Fs: afero.NewOsFs()}
+
+	t.Run("Check", func(t *testing.T) {
+		formatFlags.Check = true
+		defer func() { formatFlags.Check = false }()
+
+		result, err := formatFiles([]string{dir}, command.GlobalFlags{}, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+
+		r := result.(*formatResult)
+		assert.Equal(t, []string{filepath.Join(dir, "contracts/Hello.cdc")}, r.changed)
+		assert.Equal(t, 1, r.unchanged)
+		assert.Len(t, r.skipped, 2)
+		assert.Equal(t, command.ExitError, r.ExitCode())
+		assert.Equal(t, "1 files not formatted, 1 formatted, 2 skipped", r.Oneliner())
+
+		content, _ := os.ReadFile(filepath.Join(dir, "contracts/Hello.cdc"))
+		assert.Equal(t, unformatted, string(content))
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		result, err := formatFiles([]string{dir}, command.GlobalFlags{}, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+		assert.Equal(t, command.ExitSuccess, result.(*formatResult).ExitCode())
+
+		content, _ := os.ReadFile(filepath.Join(dir, "contracts/Hello.cdc"))
+		assert.Equal(t, formatted, string(content))
+
+		content, _ = os.ReadFile(filepath.Join(dir, "scripts/.hidden/a.cdc"))
+		assert.Equal(t, unformatted, string(content))
+	})
+
+	t.Run("Check Skipped", func(t *testing.T) {
+		formatFlags.Check = true
+		defer func() { formatFlags.Check = false }()
+
+		result, err := formatFiles([]string{dir}, command.GlobalFlags{}, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+
+		r := result.(*formatResult)
+		assert.Empty(t, r.changed)
+		assert.Len(t, r.skipped, 2)
+		assert.Equal(t, command.ExitError, r.ExitCode())
+
+		result, err = formatFiles([]string{filepath.Join(dir, "contracts")}, command.GlobalFlags{}, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+		assert.Equal(t, command.ExitSuccess, result.(*formatResult).ExitCode())
+	})
+
+	t.Run("Fail Missing File", func(t *testing.T) {
+		_, err := formatFiles([]string{filepath.Join(dir, "missing.cdc")}, command.GlobalFlags{}, util.NoLogger, rw, nil)
+		assert.ErrorContains(t, err, "error reading")
+	})
+}
@@ -19,17 +19,25 @@
 package languageserver
 
 import (
+	"fmt"
 	"log"
+	"os"
 
-	"github.com/onflow/cadence-tools/languageserver"
+	"github.com/onflow/cadence-tools/languageserver/integration"
+	"github.com/onflow/cadence-tools/languageserver/server"
 	"github.com/psiemens/sconfig"
+	"github.com/sourcegraph/jsonrpc2"
+	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 
+	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
 type config struct {
 	EnableFlowClient bool `default:"true" flag:"enable-flow-client" info:"Enable Flow client functionality"`
+	NumberOfAccounts int  `default:"1" flag:"number-of-accounts" info:"Number of accounts created for code lenses if not set by the client, configured accounts are added as well"`
 }
 
 var conf config
@@ -37,8 +45,11 @@ var conf config
 var Cmd = &cobra.Command{
 	Use:   "language-server",
 	Short: "Start the Cadence language server",
+	Long: `Start the Cadence language server, imports are resolved using the project configuration found with the
+config path flag and the accounts of the configuration are available for code lenses, unless the client sets
+the configuration path in the initialization options.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		languageserver.RunWithStdio(conf.EnableFlowClient)
+		run()
 	},
 }
 
@@ -55,3 +66,40 @@ func initConfig() {
 		log.Fatal(err)
 	}
 }
+
+func run() {
+	if term.IsTerminal(int(os.Stdout.Fd())) {
+		fmt.Print(
+			"This program implements the Language Server Protocol for Cadence.\n" +
+				"Please check the documentation on how to run it.\n" +
+				"It does nothing in a terminal, it should be run with an editor/IDE.\n",
+		)
+		os.Exit(1)
+	}
+
+	languageServer, err := server.NewServer()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = integration.NewFlowIntegration(languageServer, conf.EnableFlowClient)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	readerWriter := &afero.Afero{Fs: afero.NewOsFs()}
+	p := newProject(command.Flags.ConfigPaths, command.Flags.Network, readerWriter)
+
+	// replaces the string import resolver of the integration, which only resolves contracts deployed to the emulator
+	err = languageServer.SetOptions(server.WithStringImportResolver(p.stringImport))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stream := &initializeStream{
+		ObjectStream: jsonrpc2.NewBufferedStream(server.StdinStdoutReadWriterCloser{}, jsonrpc2.VSCodeObjectCodec{}),
+		defaults:     p.initializationDefaults(conf.NumberOfAccounts),
+	}
+
+	<-languageServer.Start(stream)
+}
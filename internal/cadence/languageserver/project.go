@@ -0,0 +1,232 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package languageserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/sourcegraph/jsonrpc2"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+// project resolves imports of the language server using the project configuration.
+//
+// Contracts are imported by name from their location in the configuration, contracts only having
+// aliases are imported from the alias address on the network.
+type project struct {
+	configPath   string
+	network      string
+	readerWriter flowkit.ReaderWriter
+
+	mu       sync.Mutex
+	gateways map[string]gateway.Gateway
+}
+
+func newProject(configPaths []string, network string, readerWriter flowkit.ReaderWriter) *project {
+	return &project{
+		configPath:   findConfigPath(configPaths),
+		network:      network,
+		readerWriter: readerWriter,
+		gateways:     make(map[string]gateway.Gateway),
+	}
+}
+
+// findConfigPath returns the absolute path of the last existing configuration file, the
+// configuration paths are ordered from least to most specific.
+func findConfigPath(configPaths []string) string {
+	for i := len(configPaths) - 1; i >= 0; i-- {
+		path := configPaths[i]
+		if path == "" {
+			continue
+		}
+		if info, err := os.Stat(path); err != nil || info.IsDir() {
+			continue
+		}
+		if abs, err := filepath.Abs(path); err == nil {
+			return abs
+		}
+	}
+	return ""
+}
+
+// stringImport loads the code of a file location or a contract imported by name.
+func (p *project) stringImport(location common.StringLocation) (string, error) {
+	if strings.Contains(location.String(), ".cdc") {
+		code, err := p.readerWriter.ReadFile(cleanWindowsPath(location.String()))
+		if err != nil {
+			return "", err
+		}
+		return string(code), nil
+	}
+
+	if p.configPath == "" {
+		return "", fmt.Errorf("cannot import contract %s, no project configuration found", location)
+	}
+
+	// the configuration is loaded on every import, so changes are used without restarting the language server
+	state, err := flowkit.Load([]string{p.configPath}, p.readerWriter)
+	if err != nil {
+		return "", fmt.Errorf("cannot import contract %s, failed to load configuration: %w", location, err)
+	}
+
+	contract, err := state.Contracts().ByName(location.String())
+	if err != nil {
+		return "", fmt.Errorf("cannot find contract with name '%s' in configuration", location)
+	}
+
+	if contract.Location != "" {
+		path := contract.Location
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(p.configPath), path)
+		}
+		code, err := p.readerWriter.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return string(code), nil
+	}
+
+	alias := contract.Aliases.ByNetwork(p.network)
+	if alias == nil {
+		return "", fmt.Errorf("contract %s has no location and no alias for network %s", location, p.network)
+	}
+
+	gw, err := p.gateway(state)
+	if err != nil {
+		return "", err
+	}
+	account, err := gw.GetAccount(alias.Address)
+	if err != nil {
+		return "", fmt.Errorf("failed to get contract %s from %s: %w", location, alias.Address, err)
+	}
+
+	code, ok := account.Contracts[contract.Name]
+	if !ok {
+		return "", fmt.Errorf("contract %s is not deployed to %s on network %s", location, alias.Address, p.network)
+	}
+	return string(code), nil
+}
+
+// gateway returns the gateway of the network, connections are reused between imports.
+func (p *project) gateway(state *flowkit.State) (gateway.Gateway, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if gw, ok := p.gateways[p.network]; ok {
+		return gw, nil
+	}
+
+	network, err := state.Networks().ByName(p.network)
+	if err != nil {
+		return nil, err
+	}
+	gw, err := gateway.NewGrpcGateway(*network)
+	if err != nil {
+		return nil, err
+	}
+
+	p.gateways[p.network] = gw
+	return gw, nil
+}
+
+// initializationDefaults are the initialization options used if they are not sent by the client.
+func (p *project) initializationDefaults(numberOfAccounts int) map[string]any {
+	defaults := map[string]any{
+		"numberOfAccounts": strconv.Itoa(numberOfAccounts),
+	}
+	if p.configPath != "" {
+		defaults["configPath"] = p.configPath
+	}
+	return defaults
+}
+
+// initializeStream adds default initialization options to the initialize request of the client,
+// so the language server can be used without configuring the project in the client.
+type initializeStream struct {
+	jsonrpc2.ObjectStream
+	defaults map[string]any
+}
+
+func (s *initializeStream) ReadObject(v any) error {
+	var raw json.RawMessage
+	if err := s.ObjectStream.ReadObject(&raw); err != nil {
+		return err
+	}
+	return json.Unmarshal(withInitializationDefaults(raw, s.defaults), v)
+}
+
+// withInitializationDefaults adds the defaults to the initialization options of an initialize request,
+// other messages and options sent by the client are not changed.
+func withInitializationDefaults(raw json.RawMessage, defaults map[string]any) json.RawMessage {
+	var message map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &message); err != nil || string(message["method"]) != `"initialize"` {
+		return raw
+	}
+
+	var params map[string]json.RawMessage
+	if err := json.Unmarshal(message["params"], &params); err != nil || params == nil {
+		return raw
+	}
+
+	options := make(map[string]any)
+	if rawOptions, ok := params["initializationOptions"]; ok && string(rawOptions) != "null" {
+		// options which are not an object are invalid and left to the language server to report
+		if err := json.Unmarshal(rawOptions, &options); err != nil {
+			return raw
+		}
+	}
+
+	for key, value := range defaults {
+		if current, ok := options[key]; !ok || current == "" {
+			options[key] = value
+		}
+	}
+
+	var err error
+	if params["initializationOptions"], err = json.Marshal(options); err != nil {
+		return raw
+	}
+	if message["params"], err = json.Marshal(params); err != nil {
+		return raw
+	}
+
+	encoded, err := json.Marshal(message)
+	if err != nil {
+		return raw
+	}
+	return encoded
+}
+
+// cleanWindowsPath removes the '/' prefix of Windows paths sent by clients, e.g. /c:/test/foo.
+func cleanWindowsPath(path string) string {
+	path = strings.ReplaceAll(path, "%3A", ":")
+	if strings.Contains(path, ":") && strings.HasPrefix(path, "/") {
+		path = path[1:]
+	}
+	return path
+}
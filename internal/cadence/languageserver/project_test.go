@@ -0,0 +1,109 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package languageserver
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_InitializationDefaults(t *testing.T) {
+	defaults := map[string]any{"configPath": "/project/flow.json", "numberOfAccounts": "1"}
+
+	options := func(t *testing.T, raw string) map[string]any {
+		var message struct {
+			Params struct {
+				InitializationOptions map[string]any `json:"initializationOptions"`
+			} `json:"params"`
+		}
+		require.NoError(t, json.Unmarshal(withInitializationDefaults(json.RawMessage(raw), defaults), &message))
+		return message.Params.InitializationOptions
+	}
+
+	t.Run("Missing Options", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, defaults, options(t, `{"id":1,"method":"initialize","params":{"rootUri":"file:///project"}}`))
+	})
+
+	t.Run("Client Options", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t,
+			map[string]any{"configPath": "/other/flow.json", "numberOfAccounts": "1", "accessCheckMode": "strict"},
+			options(t, `{"id":1,"method":"initialize","params":{"initializationOptions":{"configPath":"/other/flow.json","numberOfAccounts":"","accessCheckMode":"strict"}}}`),
+		)
+	})
+
+	t.Run("Other Messages", func(t *testing.T) {
+		t.Parallel()
+		raw := json.RawMessage(`{"method":"initialized","params":{}}`)
+		assert.Equal(t, raw, withInitializationDefaults(raw, defaults))
+	})
+}
+
+func Test_StringImport(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "flow.json")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "contracts"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "contracts", "Foo.cdc"), []byte("pub contract Foo {}"), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`{
+		"contracts": {
+			"Foo": "./contracts/Foo.cdc",
+			"Bar": {"source": "", "aliases": {"testnet": "9a0766d93b6608b7"}}
+		},
+		"networks": {"emulator": "127.0.0.1:3569", "testnet": "access.devnet.nodes.onflow.org:9000"}
+	}`), 0644))
+
+	p := newProject([]string{configPath}, "emulator", &afero.Afero{Fs: afero.NewOsFs()})
+	assert.Equal(t, configPath, p.configPath)
+
+	t.Run("Contract Location", func(t *testing.T) {
+		code, err := p.stringImport(common.StringLocation("Foo"))
+		require.NoError(t, err)
+		assert.Equal(t, "pub contract Foo {}", code)
+	})
+
+	t.Run("File Location", func(t *testing.T) {
+		code, err := p.stringImport(common.StringLocation(filepath.Join(dir, "contracts", "Foo.cdc")))
+		require.NoError(t, err)
+		assert.Equal(t, "pub contract Foo {}", code)
+	})
+
+	t.Run("Fail Unknown Contract", func(t *testing.T) {
+		_, err := p.stringImport(common.StringLocation("Baz"))
+		assert.EqualError(t, err, "cannot find contract with name 'Baz' in configuration")
+	})
+
+	t.Run("Fail Missing Alias", func(t *testing.T) {
+		_, err := p.stringImport(common.StringLocation("Bar"))
+		assert.EqualError(t, err, "contract Bar has no location and no alias for network emulator")
+	})
+
+	t.Run("Fail Missing Configuration", func(t *testing.T) {
+		p := newProject([]string{filepath.Join(dir, "missing.json")}, "emulator", &afero.Afero{Fs: afero.NewOsFs()})
+		_, err := p.stringImport(common.StringLocation("Foo"))
+		assert.EqualError(t, err, "cannot import contract Foo, no project configuration found")
+	})
+}
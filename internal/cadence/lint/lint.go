@@ -0,0 +1,438 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package lint implements linting of Cadence files with the Cadence analyzers.
+package lint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	cdcLint "github.com/onflow/cadence-tools/lint"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/tools/analysis"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// Test files import the test framework which can't be resolved by the analysis, so they are not linted.
+const testFileSuffix = "_test.cdc"
+
+// Category of the diagnostics reported for code which can't be parsed or checked.
+const checkingCategory = "checking"
+
+type severity int
+
+const (
+	severityOff severity = iota
+	severityInfo
+	severityWarning
+	severityError
+)
+
+var severities = map[string]severity{
+	"off":     severityOff,
+	"info":    severityInfo,
+	"warning": severityWarning,
+	"error":   severityError,
+}
+
+func (s severity) String() string {
+	for name, value := range severities {
+		if value == s {
+			return name
+		}
+	}
+	return "unknown"
+}
+
+func parseSeverity(name string) (severity, error) {
+	s, ok := severities[name]
+	if !ok {
+		return 0, fmt.Errorf("invalid severity %s, options: \"off\", \"info\", \"warning\", \"error\"", name)
+	}
+	return s, nil
+}
+
+type flagsLint struct {
+	Severity []string `default:"" flag:"severity" info:"Severity of an analyzer as name=severity, e.g. unnecessary-force=error, severities: \"off\", \"info\", \"warning\", \"error\", analyzers default to warning"`
+	FailOn   string   `default:"error" flag:"fail-on" info:"Minimum severity of the diagnostics failing the command, options: \"info\", \"warning\", \"error\", \"off\" never fails"`
+	SARIF    string   `default:"" flag:"sarif" info:"Filename to write a SARIF report of the diagnostics for code scanning"`
+}
+
+var lintFlags = flagsLint{}
+
+var Command = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "lint [files or directories...]",
+		Short: "Lint Cadence files",
+		Long: fmt.Sprintf(`Lint Cadence files with the Cadence analyzers, .cdc files are found recursively in the provided directories.
+If no files or directories are provided, the directories of the configured contracts and the cadence directory are linted.
+
+Analyzers: %s`, strings.Join(analyzerNames(), ", ")),
+		Example: "flow cadence lint\nflow cadence lint ./contracts --severity unnecessary-force=error --severity redundant-cast=off\nflow cadence lint --sarif lint.sarif",
+		Args:    cobra.ArbitraryArgs,
+	},
+	Flags: &lintFlags,
+	Run:   lint,
+}
+
+// diagnostic is an issue found by an analyzer or when checking the code.
+type diagnostic struct {
+	File     string
+	Analyzer string
+	Severity severity
+	Message  string
+	ast.Range
+}
+
+func lint(
+	args []string,
+	flags command.GlobalFlags,
+	_ output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	ruleSeverities, err := parseSeverities(lintFlags.Severity)
+	if err != nil {
+		return nil, err
+	}
+	failOn, err := parseSeverity(lintFlags.FailOn)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fail on flag: %w", err)
+	}
+
+	// the configuration is optional, imports of contracts are only resolved in a project
+	state, _ := flowkit.LoadWithVars(flags.ConfigPaths, readerWriter, flags.Vars)
+
+	paths := args
+	if len(paths) == 0 {
+		paths = util.CadencePaths(state)
+	}
+
+	found, err := util.FindCadenceFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(found))
+	for _, file := range found {
+		if !strings.HasSuffix(file, testFileSuffix) {
+			files = append(files, file)
+		}
+	}
+
+	diagnostics := analyze(files, newResolver(readerWriter, state), ruleSeverities)
+
+	result := &lintResult{
+		files:       len(files),
+		diagnostics: diagnostics,
+		failOn:      failOn,
+	}
+
+	if lintFlags.SARIF != "" {
+		report, err := sarifReport(diagnostics)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing SARIF report: %w", err)
+		}
+		if err := os.WriteFile(lintFlags.SARIF, report, 0644); err != nil {
+			return nil, fmt.Errorf("error writing SARIF report file: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func analyzerNames() []string {
+	names := make([]string, 0, len(cdcLint.Analyzers))
+	for name := range cdcLint.Analyzers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseSeverities parses the analyzer severities, every analyzer defaults to warning.
+func parseSeverities(values []string) (map[string]severity, error) {
+	ruleSeverities := make(map[string]severity, len(cdcLint.Analyzers))
+	for name := range cdcLint.Analyzers {
+		ruleSeverities[name] = severityWarning
+	}
+
+	for _, value := range values {
+		name, level, ok := strings.Cut(value, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid severity flag %s, the value must be name=severity", value)
+		}
+		if _, exists := cdcLint.Analyzers[name]; !exists {
+			return nil, fmt.Errorf("unknown analyzer %s, analyzers: %s", name, strings.Join(analyzerNames(), ", "))
+		}
+
+		s, err := parseSeverity(level)
+		if err != nil {
+			return nil, err
+		}
+		ruleSeverities[name] = s
+	}
+
+	return ruleSeverities, nil
+}
+
+// analyze runs the enabled analyzers on the files, diagnostics are sorted by file and position.
+func analyze(files []string, resolver *resolver, ruleSeverities map[string]severity) []diagnostic {
+	config := &analysis.Config{
+		Mode:        cdcLint.LoadMode,
+		ResolveCode: resolver.resolveCode,
+		ResolveAddressContractNames: func(address common.Address) ([]string, error) {
+			return nil, fmt.Errorf("importing all contracts of address %s is not supported", address)
+		},
+	}
+
+	diagnostics := make([]diagnostic, 0)
+	programs := make(analysis.Programs, len(files))
+	for _, file := range files {
+		location := common.StringLocation(file)
+		if err := programs.Load(config, location); err != nil {
+			diagnostics = append(diagnostics, checkingDiagnostics(file, resolver, err)...)
+		}
+	}
+
+	for _, name := range analyzerNames() {
+		s := ruleSeverities[name]
+		if s == severityOff {
+			continue
+		}
+
+		analyzers := []*analysis.Analyzer{cdcLint.Analyzers[name]}
+		for _, file := range files {
+			program := programs[common.StringLocation(file)]
+			if program == nil {
+				continue
+			}
+
+			program.Run(analyzers, func(d analysis.Diagnostic) {
+				message := d.Message
+				if d.SecondaryMessage != "" {
+					message = fmt.Sprintf("%s %s", strings.TrimSuffix(message, ":")+":", d.SecondaryMessage)
+				}
+				diagnostics = append(diagnostics, diagnostic{
+					File:     resolver.file(d.Location, file),
+					Analyzer: name,
+					Severity: s,
+					Message:  message,
+					Range:    d.Range,
+				})
+			})
+		}
+	}
+
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i], diagnostics[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.StartPos.Line != b.StartPos.Line {
+			return a.StartPos.Line < b.StartPos.Line
+		}
+		return a.StartPos.Column < b.StartPos.Column
+	})
+	return diagnostics
+}
+
+// checkingDiagnostics converts the parsing and checking errors of the file into diagnostics.
+func checkingDiagnostics(file string, resolver *resolver, err error) []diagnostic {
+	var checkingErr analysis.ParsingCheckingError
+	if errors.As(err, &checkingErr) {
+		file = resolver.file(checkingErr.ImportLocation(), file)
+	}
+
+	diagnostics := make([]diagnostic, 0)
+	for _, childErr := range leafErrors(err) {
+		d := diagnostic{
+			File:     file,
+			Analyzer: checkingCategory,
+			Severity: severityError,
+			Message:  childErr.Error(),
+		}
+		if positioned, ok := childErr.(ast.HasPosition); ok {
+			d.Range = ast.NewRangeFromPositioned(nil, positioned)
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// leafErrors returns the errors without child errors contained in the error.
+func leafErrors(err error) []error {
+	parent, ok := err.(interface{ ChildErrors() []error })
+	if !ok || len(parent.ChildErrors()) == 0 {
+		return []error{err}
+	}
+
+	var leaves []error
+	for _, child := range parent.ChildErrors() {
+		leaves = append(leaves, leafErrors(child)...)
+	}
+	return leaves
+}
+
+// resolver resolves imports of the linted files, files are imported by path relative to the importing file,
+// contracts are imported by name or from an address using the contract locations in the configuration.
+type resolver struct {
+	readerWriter flowkit.ReaderWriter
+	state        *flowkit.State
+	// files contains the file of each resolved location
+	files map[common.Location]string
+}
+
+func newResolver(readerWriter flowkit.ReaderWriter, state *flowkit.State) *resolver {
+	return &resolver{
+		readerWriter: readerWriter,
+		state:        state,
+		files:        make(map[common.Location]string),
+	}
+}
+
+func (r *resolver) resolveCode(location common.Location, importingLocation common.Location, _ ast.Range) ([]byte, error) {
+	var file string
+	switch loc := location.(type) {
+	case common.StringLocation:
+		file = loc.String()
+		if importingLocation != nil && filepath.Ext(file) == ".cdc" {
+			file = filepath.Join(filepath.Dir(r.file(importingLocation, "")), file)
+		} else if importingLocation != nil {
+			contract, err := r.contractFile(file)
+			if err != nil {
+				return nil, err
+			}
+			file = contract
+		}
+	case common.AddressLocation:
+		contract, err := r.contractFile(loc.Name)
+		if err != nil {
+			return nil, err
+		}
+		file = contract
+	default:
+		return nil, fmt.Errorf("cannot import from %s", location)
+	}
+
+	r.files[location] = file
+	return r.readerWriter.ReadFile(file)
+}
+
+func (r *resolver) contractFile(name string) (string, error) {
+	if r.state != nil {
+		for _, contract := range *r.state.Contracts() {
+			if contract.Name == name {
+				return contract.Location, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("cannot find contract with name '%s' in configuration", name)
+}
+
+// file returns the file of the location, or the fallback if the location was not resolved.
+func (r *resolver) file(location common.Location, fallback string) string {
+	if file, ok := r.files[location]; ok {
+		return file
+	}
+	return fallback
+}
+
+type lintResult struct {
+	files       int
+	diagnostics []diagnostic
+	failOn      severity
+}
+
+var _ command.ExitCodeResult = &lintResult{}
+
+func (r *lintResult) JSON() any {
+	diagnostics := make([]map[string]any, 0, len(r.diagnostics))
+	for _, d := range r.diagnostics {
+		diagnostics = append(diagnostics, map[string]any{
+			"file":     d.File,
+			"line":     d.StartPos.Line,
+			"column":   d.StartPos.Column + 1,
+			"analyzer": d.Analyzer,
+			"severity": d.Severity.String(),
+			"message":  d.Message,
+		})
+	}
+	return map[string]any{
+		"files":       r.files,
+		"diagnostics": diagnostics,
+	}
+}
+
+func (r *lintResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	for _, d := range r.diagnostics {
+		_, _ = fmt.Fprintf(writer, "%s:%d:%d\t%s\t%s\t%s\n",
+			d.File,
+			d.StartPos.Line,
+			d.StartPos.Column+1,
+			d.Severity,
+			d.Analyzer,
+			d.Message,
+		)
+	}
+
+	_ = writer.Flush()
+	_, _ = fmt.Fprintf(&b, "%s\n", r.Oneliner())
+	return b.String()
+}
+
+func (r *lintResult) Oneliner() string {
+	counts := make(map[severity]int)
+	for _, d := range r.diagnostics {
+		counts[d.Severity]++
+	}
+	return fmt.Sprintf(
+		"%d files linted: %d errors, %d warnings, %d info",
+		r.files,
+		counts[severityError],
+		counts[severityWarning],
+		counts[severityInfo],
+	)
+}
+
+// ExitCode fails if any diagnostic has at least the fail on severity.
+func (r *lintResult) ExitCode() int {
+	if r.failOn == severityOff {
+		return command.ExitSuccess
+	}
+	for _, d := range r.diagnostics {
+		if d.Severity >= r.failOn {
+			return command.ExitError
+		}
+	}
+	return command.ExitSuccess
+}
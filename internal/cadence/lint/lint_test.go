@@ -0,0 +1,148 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+const helloContract = `pub contract Hello {
+    pub fun get(): String {
+        let greeting: String = "Hello"
+        return greeting!
+    }
+}
+`
+
+const getScript = `import "Hello"
+
+pub fun main(): UFix64 {
+    log(Hello.get())
+    return UFix64(1)
+}
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func Test_Lint(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"contracts/Hello.cdc":     helloContract,
+		"scripts/get.cdc":         getScript,
+		"scripts/invalid.cdc":     "pub fun main(): Int { return missing }\n",
+		"tests/hello_test.cdc":    "import Test\n",
+		"scripts/.hidden/bad.cdc": "pub fun main( {",
+	})
+	_, state, _ := util.TestMocks(t)
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Hello", Location: filepath.Join(dir, "contracts/Hello.cdc")})
+
+	resolver := newResolver(&afero.Afero{Fs: afero.NewOsFs()}, state)
+	files, err := util.FindCadenceFiles([]string{dir})
+	require.NoError(t, err)
+	files = files[:3] // the test file is excluded by the command
+
+	t.Run("Diagnostics", func(t *testing.T) {
+		severities, err := parseSeverities(nil)
+		require.NoError(t, err)
+
+		diagnostics := analyze(files, resolver, severities)
+		require.Len(t, diagnostics, 3)
+
+		assert.Equal(t, filepath.Join(dir, "contracts/Hello.cdc"), diagnostics[0].File)
+		assert.Equal(t, "unnecessary-force", diagnostics[0].Analyzer)
+		assert.Equal(t, severityWarning, diagnostics[0].Severity)
+		assert.Equal(t, 4, diagnostics[0].StartPos.Line)
+
+		assert.Equal(t, filepath.Join(dir, "scripts/get.cdc"), diagnostics[1].File)
+		assert.Equal(t, "number-function-arguments", diagnostics[1].Analyzer)
+		assert.Equal(t, "consider replacing with: 1.0", diagnostics[1].Message)
+
+		assert.Equal(t, filepath.Join(dir, "scripts/invalid.cdc"), diagnostics[2].File)
+		assert.Equal(t, checkingCategory, diagnostics[2].Analyzer)
+		assert.Equal(t, severityError, diagnostics[2].Severity)
+		assert.Contains(t, diagnostics[2].Message, "cannot find variable in this scope: `missing`")
+	})
+
+	t.Run("Severities", func(t *testing.T) {
+		severities, err := parseSeverities([]string{"unnecessary-force=error", "number-function-arguments=off"})
+		require.NoError(t, err)
+
+		diagnostics := analyze(files, resolver, severities)
+		require.Len(t, diagnostics, 2)
+		assert.Equal(t, severityError, diagnostics[0].Severity)
+
+		result := &lintResult{files: 3, diagnostics: diagnostics, failOn: severityError}
+		assert.Equal(t, command.ExitError, result.ExitCode())
+		assert.Equal(t, "3 files linted: 2 errors, 0 warnings, 0 info", result.Oneliner())
+
+		result.failOn = severityOff
+		assert.Equal(t, command.ExitSuccess, result.ExitCode())
+	})
+
+	t.Run("Fail Invalid Severities", func(t *testing.T) {
+		_, err := parseSeverities([]string{"unnecessary-force"})
+		assert.EqualError(t, err, "invalid severity flag unnecessary-force, the value must be name=severity")
+
+		_, err = parseSeverities([]string{"unknown=error"})
+		assert.ErrorContains(t, err, "unknown analyzer unknown")
+
+		_, err = parseSeverities([]string{"unnecessary-force=fatal"})
+		assert.ErrorContains(t, err, "invalid severity fatal")
+	})
+
+	t.Run("SARIF", func(t *testing.T) {
+		severities, err := parseSeverities(nil)
+		require.NoError(t, err)
+
+		report, err := sarifReport(analyze(files, resolver, severities))
+		require.NoError(t, err)
+
+		var log sarifLog
+		require.NoError(t, json.Unmarshal(report, &log))
+		assert.Equal(t, "2.1.0", log.Version)
+		require.Len(t, log.Runs, 1)
+		assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(analyzerNames())+1)
+
+		results := log.Runs[0].Results
+		require.Len(t, results, 3)
+		assert.Equal(t, "unnecessary-force", results[0].RuleID)
+		assert.Equal(t, "warning", results[0].Level)
+		assert.Equal(t, filepath.ToSlash(filepath.Join(dir, "contracts/Hello.cdc")), results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		assert.Equal(t, 4, results[0].Locations[0].PhysicalLocation.Region.StartLine)
+		assert.Equal(t, "error", results[2].Level)
+	})
+}
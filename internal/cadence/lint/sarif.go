@@ -0,0 +1,139 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	cdcLint "github.com/onflow/cadence-tools/lint"
+)
+
+// SARIF report of the diagnostics, see https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+var sarifLevels = map[severity]string{
+	severityInfo:    "note",
+	severityWarning: "warning",
+	severityError:   "error",
+}
+
+func sarifReport(diagnostics []diagnostic) ([]byte, error) {
+	rules := []sarifRule{{
+		ID:               checkingCategory,
+		ShortDescription: sarifMessage{Text: "Parsing and checking errors"},
+	}}
+	for _, name := range analyzerNames() {
+		rules = append(rules, sarifRule{
+			ID:               name,
+			ShortDescription: sarifMessage{Text: cdcLint.Analyzers[name].Description},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		location := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(d.File)},
+		}
+		// columns are zero based in Cadence and one based in SARIF, the end column is exclusive in SARIF
+		if d.StartPos.Line > 0 {
+			location.Region = &sarifRegion{
+				StartLine:   d.StartPos.Line,
+				StartColumn: d.StartPos.Column + 1,
+				EndLine:     d.EndPos.Line,
+				EndColumn:   d.EndPos.Column + 2,
+			}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    d.Analyzer,
+			Level:     sarifLevels[d.Severity],
+			Message:   sarifMessage{Text: d.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	return json.MarshalIndent(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "flow cadence lint",
+				InformationURI: "https://developers.flow.com/tools/flow-cli",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	}, "", "  ")
+}
@@ -0,0 +1,276 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsAdd struct {
+	Dir              string `default:"cadence/transactions" flag:"dir" info:"Directory the example transactions are created in"`
+	SkipTransactions bool   `default:"false" flag:"skip-transactions" info:"Don't create the example setup and transfer transactions"`
+}
+
+var addFlags = flagsAdd{}
+
+var addCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "add <collection identifier>",
+		Short: "Add the contracts of a collection in the NFT catalog to the configuration",
+		Long: `Add the contracts of a collection in the NFT catalog to the configuration with aliases on the network, and
+create example transactions setting up the collection in an account and transferring an NFT.`,
+		Example: "flow catalog add NBATopShot --network mainnet",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &addFlags,
+	RunS:  add,
+}
+
+func add(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	identifier := args[0]
+
+	logger.StartProgress(fmt.Sprintf("Loading collection %s from the NFT catalog...", identifier))
+	entries, err := getEntries(context.Background(), flow, []string{identifier})
+	logger.StopProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	e, ok := entries[identifier]
+	if !ok {
+		return nil, fmt.Errorf(
+			"collection %s not found in the NFT catalog on %s, use flow catalog search to find collections",
+			identifier,
+			flow.Network().Name,
+		)
+	}
+
+	network := flow.Network().Name
+	contracts := referencedContracts(e)
+	names := make([]string, 0, len(contracts))
+	for name, address := range contracts {
+		contract, err := state.Contracts().ByName(name)
+		if err != nil {
+			state.Contracts().AddOrUpdate(config.Contract{Name: name})
+			contract, _ = state.Contracts().ByName(name)
+		}
+		contract.Aliases.AddOrUpdate(network, address)
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := state.SaveEdited(globalFlags.ConfigPaths); err != nil {
+		return nil, err
+	}
+
+	result := &addResult{entry: e, network: network, contracts: names, notSaved: globalFlags.NoSave}
+	if addFlags.SkipTransactions {
+		return result, nil
+	}
+
+	files := map[string]string{
+		filepath.Join(addFlags.Dir, e.ContractName, "setup_collection.cdc"): setupTransaction(e),
+		filepath.Join(addFlags.Dir, e.ContractName, "transfer_nft.cdc"):     transferTransaction(e),
+	}
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		// existing transactions might be changed by the developer, so they are never overwritten
+		if _, err := os.Stat(path); err == nil {
+			logger.Info(fmt.Sprintf("%s Skipping existing transaction %s", output.WarningEmoji(), path))
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := state.ReaderWriter().WriteFile(path, []byte(files[path]), 0644); err != nil {
+			return nil, fmt.Errorf("error writing %s: %w", path, err)
+		}
+		result.files = append(result.files, path)
+	}
+
+	return result, nil
+}
+
+// typeIdentifierPattern matches the contract of a fully qualified type identifier, e.g. A.0b2a3299cc857e29.TopShot.NFT.
+var typeIdentifierPattern = regexp.MustCompile(`A\.([0-9a-fA-F]{16})\.(\w+)\.`)
+
+// referencedContracts returns the addresses of the collection contract and the contracts of the types in the
+// public collection type, e.g. the NonFungibleToken and MetadataViews interfaces, by name.
+func referencedContracts(e entry) map[string]flowsdk.Address {
+	contracts := map[string]flowsdk.Address{
+		e.ContractName: flowsdk.HexToAddress(e.ContractAddress),
+	}
+	for _, match := range typeIdentifierPattern.FindAllStringSubmatch(e.NFTType+" "+e.PublicLinkedType, -1) {
+		contracts[match[2]] = flowsdk.HexToAddress(match[1])
+	}
+	return contracts
+}
+
+// cadenceType converts a fully qualified type identifier to a type used in Cadence code importing the contracts.
+func cadenceType(identifier string) string {
+	return typeIdentifierPattern.ReplaceAllString(identifier, "$2.")
+}
+
+// imports returns the string imports of the contracts referenced by the collection.
+func imports(e entry) string {
+	names := make([]string, 0)
+	for name := range referencedContracts(e) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(fmt.Sprintf("import \"%s\"\n", name))
+	}
+	return b.String()
+}
+
+func setupTransaction(e entry) string {
+	return fmt.Sprintf(`%s
+// Sets up an empty %s collection in the signer account, accounts already set up are not changed.
+transaction {
+
+    prepare(signer: AuthAccount) {
+        if signer.type(at: %s) != nil {
+            return
+        }
+
+        signer.save(<- %s.createEmptyCollection(), to: %s)
+        signer.link<%s>(%s, target: %s)
+    }
+}
+`,
+		imports(e),
+		e.Name,
+		e.StoragePath,
+		e.ContractName, e.StoragePath,
+		cadenceType(e.PublicLinkedType), e.PublicPath, e.StoragePath,
+	)
+}
+
+func transferTransaction(e entry) string {
+	return fmt.Sprintf(`import "NonFungibleToken"
+
+// Transfers a %s NFT from the signer account to the recipient, the recipient collection must be set up.
+transaction(recipient: Address, withdrawID: UInt64) {
+
+    let provider: &{NonFungibleToken.Provider}
+
+    prepare(signer: AuthAccount) {
+        self.provider = signer.borrow<&{NonFungibleToken.Provider}>(from: %s)
+            ?? panic("Could not borrow the collection of the signer")
+    }
+
+    execute {
+        let receiver = getAccount(recipient)
+            .getCapability(%s)
+            .borrow<&{NonFungibleToken.CollectionPublic}>()
+            ?? panic("Could not borrow the collection of the recipient")
+
+        receiver.deposit(token: <- self.provider.withdraw(withdrawID: withdrawID))
+    }
+}
+`,
+		e.Name,
+		e.StoragePath,
+		e.PublicPath,
+	)
+}
+
+type addResult struct {
+	entry     entry
+	network   string
+	contracts []string
+	files     []string
+	notSaved  bool
+}
+
+func (r *addResult) JSON() any {
+	files := r.files
+	if files == nil {
+		files = []string{}
+	}
+	return map[string]any{
+		"collection":   r.entry,
+		"network":      r.network,
+		"contracts":    r.contracts,
+		"transactions": files,
+	}
+}
+
+func (r *addResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Collection\t%s (%s)\n", r.entry.Name, r.entry.Identifier)
+	_, _ = fmt.Fprintf(writer, "Description\t%s\n", truncate(r.entry.Description, 80))
+	_, _ = fmt.Fprintf(writer, "Website\t%s\n", r.entry.ExternalURL)
+	_, _ = fmt.Fprintf(writer, "NFT Type\t%s\n", r.entry.NFTType)
+	_, _ = fmt.Fprintf(writer, "Storage Path\t%s\n", r.entry.StoragePath)
+	_, _ = fmt.Fprintf(writer, "Public Path\t%s\n", r.entry.PublicPath)
+	if r.notSaved {
+		_, _ = fmt.Fprintf(writer, "\nContract aliases not added on %s, the configuration was not saved because of the --no-save flag:\n", r.network)
+	} else {
+		_, _ = fmt.Fprintf(writer, "\nContract aliases added on %s:\n", r.network)
+	}
+	for _, name := range r.contracts {
+		_, _ = fmt.Fprintf(writer, "  %s\n", name)
+	}
+	if len(r.files) > 0 {
+		_, _ = fmt.Fprintf(writer, "\nTransactions created:\n")
+		for _, file := range r.files {
+			_, _ = fmt.Fprintf(writer, "  %s\n", file)
+		}
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *addResult) Oneliner() string {
+	return fmt.Sprintf("Added %s with contracts %s", r.entry.Identifier, strings.Join(r.contracts, ", "))
+}
@@ -0,0 +1,199 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package catalog implements commands finding NFT collections in the Flow NFT Catalog and adding them to the project.
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/onflow/cadence"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+var Cmd = &cobra.Command{
+	Use:              "catalog",
+	Short:            "Find NFT collections in the Flow NFT Catalog and add them to the project",
+	TraverseChildren: true,
+	GroupID:          "project",
+}
+
+func init() {
+	searchCommand.AddToParent(Cmd)
+	addCommand.AddToParent(Cmd)
+}
+
+// catalogAddresses are the addresses of the NFTCatalog contract by network.
+var catalogAddresses = map[string]string{
+	config.MainnetNetwork.Name: "0x49a7cda3a1eecc29",
+	config.TestnetNetwork.Name: "0x324c34e1c517e4db",
+}
+
+// entry is the metadata of a collection in the catalog.
+type entry struct {
+	Identifier       string `json:"identifier"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	ExternalURL      string `json:"externalURL"`
+	ContractName     string `json:"contractName"`
+	ContractAddress  string `json:"contractAddress"`
+	NFTType          string `json:"nftType"`
+	StoragePath      string `json:"storagePath"`
+	PublicPath       string `json:"publicPath"`
+	PublicLinkedType string `json:"publicLinkedType"`
+}
+
+const keysScript = `
+import NFTCatalog from %s
+
+pub fun main(): [String] {
+    return NFTCatalog.getCatalogKeys()
+}
+`
+
+const entriesScript = `
+import NFTCatalog from %s
+
+pub fun main(identifiers: [String]): {String: {String: String}} {
+    let entries: {String: {String: String}} = {}
+    for identifier in identifiers {
+        if let entry = NFTCatalog.getCatalogEntry(collectionIdentifier: identifier) {
+            entries[identifier] = {
+                "identifier": identifier,
+                "name": entry.collectionDisplay.name,
+                "description": entry.collectionDisplay.description,
+                "externalURL": entry.collectionDisplay.externalURL.url,
+                "contractName": entry.contractName,
+                "contractAddress": entry.contractAddress.toString(),
+                "nftType": entry.nftType.identifier,
+                "storagePath": entry.collectionData.storagePath.toString(),
+                "publicPath": entry.collectionData.publicPath.toString(),
+                "publicLinkedType": entry.collectionData.publicLinkedType.identifier
+            }
+        }
+    }
+    return entries
+}
+`
+
+func catalogAddress(network config.Network) (string, error) {
+	address, ok := catalogAddresses[network.Name]
+	if !ok {
+		return "", fmt.Errorf("the NFT catalog is only available on mainnet and testnet, use --network mainnet or --network testnet")
+	}
+	return address, nil
+}
+
+// getKeys returns the identifiers of all collections in the catalog.
+func getKeys(ctx context.Context, flow flowkit.Services) ([]string, error) {
+	address, err := catalogAddress(flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := flow.ExecuteScript(
+		ctx,
+		flowkit.Script{Code: []byte(fmt.Sprintf(keysScript, address))},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the catalog collections: %w", err)
+	}
+
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, fmt.Errorf("unexpected catalog collections %s", value)
+	}
+	keys := make([]string, 0, len(array.Values))
+	for _, v := range array.Values {
+		if key, ok := v.(cadence.String); ok {
+			keys = append(keys, string(key))
+		}
+	}
+	return keys, nil
+}
+
+// getEntries returns the catalog entries of the collections by identifier, missing collections are omitted.
+func getEntries(ctx context.Context, flow flowkit.Services, identifiers []string) (map[string]entry, error) {
+	address, err := catalogAddress(flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]cadence.Value, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		args = append(args, cadence.String(identifier))
+	}
+
+	value, err := flow.ExecuteScript(
+		ctx,
+		flowkit.Script{
+			Code: []byte(fmt.Sprintf(entriesScript, address)),
+			Args: []cadence.Value{cadence.NewArray(args)},
+		},
+		flowkit.LatestScriptQuery,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the catalog entries: %w", err)
+	}
+
+	return decodeEntries(value)
+}
+
+// decodeEntries converts the entries returned by the catalog script, the string fields are matched by the JSON names.
+func decodeEntries(value cadence.Value) (map[string]entry, error) {
+	dictionary, ok := value.(cadence.Dictionary)
+	if !ok {
+		return nil, fmt.Errorf("unexpected catalog entries %s", value)
+	}
+
+	entries := make(map[string]entry, len(dictionary.Pairs))
+	for _, pair := range dictionary.Pairs {
+		identifier, identifierOK := pair.Key.(cadence.String)
+		fields, ok := pair.Value.(cadence.Dictionary)
+		if !identifierOK || !ok {
+			return nil, fmt.Errorf("unexpected catalog entry %s", pair.Value)
+		}
+
+		values := make(map[string]string, len(fields.Pairs))
+		for _, field := range fields.Pairs {
+			key, keyOK := field.Key.(cadence.String)
+			value, valueOK := field.Value.(cadence.String)
+			if keyOK && valueOK {
+				values[string(key)] = string(value)
+			}
+		}
+
+		encoded, err := json.Marshal(values)
+		if err != nil {
+			return nil, err
+		}
+		var e entry
+		if err := json.Unmarshal(encoded, &e); err != nil {
+			return nil, err
+		}
+		entries[string(identifier)] = e
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,167 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package catalog
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var topShot = map[string]string{
+	"identifier":       "NBATopShot",
+	"name":             "NBA-Top-Shot",
+	"description":      "NBA Top Shot is your chance to own, sell, and trade official digital collectibles.",
+	"externalURL":      "https://nbatopshot.com",
+	"contractName":     "TopShot",
+	"contractAddress":  "0x0b2a3299cc857e29",
+	"nftType":          "A.0b2a3299cc857e29.TopShot.NFT",
+	"storagePath":      "/storage/MomentCollection",
+	"publicPath":       "/public/MomentCollection",
+	"publicLinkedType": "&A.0b2a3299cc857e29.TopShot.Collection{A.0b2a3299cc857e29.TopShot.MomentCollectionPublic,A.1d7e57aa55817448.NonFungibleToken.CollectionPublic,A.1d7e57aa55817448.MetadataViews.ResolverCollection}",
+}
+
+func entriesValue(entries ...map[string]string) cadence.Dictionary {
+	pairs := make([]cadence.KeyValuePair, 0, len(entries))
+	for _, e := range entries {
+		fields := make([]cadence.KeyValuePair, 0, len(e))
+		for key, value := range e {
+			fields = append(fields, cadence.KeyValuePair{Key: cadence.String(key), Value: cadence.String(value)})
+		}
+		pairs = append(pairs, cadence.KeyValuePair{Key: cadence.String(e["identifier"]), Value: cadence.NewDictionary(fields)})
+	}
+	return cadence.NewDictionary(pairs)
+}
+
+// executeScript mocks the script execution returning the value of the script.
+func executeScript(call *mock.Call, value func(flowkit.Script) cadence.Value) {
+	call.Run(func(mock.Arguments) {}).Return(
+		func(_ context.Context, script flowkit.Script, _ flowkit.ScriptQuery) (cadence.Value, error) {
+			return value(script), nil
+		},
+		nil,
+	)
+}
+
+func Test_Search(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	srv.Network.Return(config.MainnetNetwork)
+
+	executeScript(srv.ExecuteScript, func(script flowkit.Script) cadence.Value {
+		assert.Contains(t, string(script.Code), "import NFTCatalog from 0x49a7cda3a1eecc29")
+		if strings.Contains(string(script.Code), "getCatalogKeys()") {
+			return cadence.NewArray([]cadence.Value{
+				cadence.String("Flovatar"),
+				cadence.String("NBATopShot"),
+				cadence.String("TopShotPacks"),
+			})
+		}
+
+		assert.Equal(t, []cadence.Value{cadence.NewArray([]cadence.Value{
+			cadence.String("NBATopShot"),
+			cadence.String("TopShotPacks"),
+		})}, script.Args)
+		return entriesValue(topShot)
+	})
+
+	result, err := search([]string{"topshot"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+	require.NoError(t, err)
+
+	entries := result.JSON().([]entry)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "TopShot", entries[0].ContractName)
+	assert.Contains(t, result.String(), "NBATopShot")
+
+	t.Run("Fail Network", func(t *testing.T) {
+		srv, _, rw := util.TestMocks(t)
+		srv.Network.Return(config.EmulatorNetwork)
+
+		_, err := search(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "the NFT catalog is only available on mainnet and testnet, use --network mainnet or --network testnet")
+	})
+}
+
+func Test_MatchKeys(t *testing.T) {
+	keys := []string{"c", "B", "a", "ab"}
+	assert.Equal(t, []string{"B", "a", "ab", "c"}, matchKeys(keys, "", 10))
+	assert.Equal(t, []string{"a", "ab"}, matchKeys(keys, "A", 10))
+	assert.Equal(t, []string{"B"}, matchKeys(keys, "", 1))
+}
+
+func Test_Add(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	require.NoError(t, state.SaveDefault())
+	srv.Network.Return(config.MainnetNetwork)
+	executeScript(srv.ExecuteScript, func(flowkit.Script) cadence.Value {
+		return entriesValue(topShot)
+	})
+
+	addFlags.Dir = t.TempDir()
+	defer func() { addFlags = flagsAdd{Dir: "cadence/transactions"} }()
+
+	result, err := add([]string{"NBATopShot"}, command.GlobalFlags{ConfigPaths: config.DefaultPaths()}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"MetadataViews", "NonFungibleToken", "TopShot"}, result.(*addResult).contracts)
+
+	contract, err := state.Contracts().ByName("TopShot")
+	require.NoError(t, err)
+	assert.Equal(t, flow.HexToAddress("0b2a3299cc857e29"), contract.Aliases.ByNetwork("mainnet").Address)
+	contract, err = state.Contracts().ByName("NonFungibleToken")
+	require.NoError(t, err)
+	assert.Equal(t, flow.HexToAddress("1d7e57aa55817448"), contract.Aliases.ByNetwork("mainnet").Address)
+
+	setup, err := rw.ReadFile(filepath.Join(addFlags.Dir, "TopShot", "setup_collection.cdc"))
+	require.NoError(t, err)
+	assert.Contains(t, string(setup), "import \"TopShot\"\n")
+	assert.Contains(t, string(setup), "signer.link<&TopShot.Collection{TopShot.MomentCollectionPublic,NonFungibleToken.CollectionPublic,MetadataViews.ResolverCollection}>(/public/MomentCollection, target: /storage/MomentCollection)")
+
+	transfer, err := rw.ReadFile(filepath.Join(addFlags.Dir, "TopShot", "transfer_nft.cdc"))
+	require.NoError(t, err)
+
+	// the generated transactions must be valid Cadence
+	for _, code := range [][]byte{setup, transfer} {
+		_, err := parser.ParseProgram(nil, code, parser.Config{})
+		assert.NoError(t, err, string(code))
+	}
+
+	t.Run("Fail Not Found", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Network.Return(config.TestnetNetwork)
+		executeScript(srv.ExecuteScript, func(flowkit.Script) cadence.Value {
+			return entriesValue()
+		})
+
+		_, err := add([]string{"Missing"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		require.Error(t, err)
+		assert.True(t, strings.HasPrefix(err.Error(), "collection Missing not found in the NFT catalog on testnet"))
+	})
+}
@@ -0,0 +1,167 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSearch struct {
+	Limit int `default:"20" flag:"limit" info:"Maximum number of collections shown"`
+}
+
+var searchFlags = flagsSearch{}
+
+var searchCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "search [query]",
+		Short:   "Search the collections of the NFT catalog by identifier",
+		Example: "flow catalog search TopShot --network mainnet",
+		Args:    cobra.MaximumNArgs(1),
+	},
+	Flags: &searchFlags,
+	Run:   search,
+}
+
+func search(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if searchFlags.Limit < 1 {
+		return nil, command.WithExitCode(
+			command.ExitValidationError,
+			fmt.Errorf("limit must be at least 1, got %d", searchFlags.Limit),
+		)
+	}
+
+	query := ""
+	if len(args) > 0 {
+		query = args[0]
+	}
+
+	logger.StartProgress("Searching the NFT catalog...")
+	defer logger.StopProgress()
+
+	ctx := context.Background()
+	keys, err := getKeys(ctx, flow)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := matchKeys(keys, query, searchFlags.Limit)
+	if len(matches) == 0 {
+		return &searchResult{entries: []entry{}}, nil
+	}
+
+	entries, err := getEntries(ctx, flow, matches)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &searchResult{entries: make([]entry, 0, len(matches))}
+	for _, key := range matches {
+		if e, ok := entries[key]; ok {
+			result.entries = append(result.entries, e)
+		}
+	}
+	return result, nil
+}
+
+// matchKeys returns up to limit sorted identifiers containing the query, ignoring the case.
+func matchKeys(keys []string, query string, limit int) []string {
+	query = strings.ToLower(query)
+
+	matches := make([]string, 0)
+	for _, key := range keys {
+		if strings.Contains(strings.ToLower(key), query) {
+			matches = append(matches, key)
+		}
+	}
+
+	sort.Strings(matches)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+type searchResult struct {
+	entries []entry
+}
+
+func (r *searchResult) JSON() any {
+	return r.entries
+}
+
+func (r *searchResult) String() string {
+	if len(r.entries) == 0 {
+		return "No collections found"
+	}
+
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Identifier\tName\tContract\tDescription\n")
+	for _, e := range r.entries {
+		_, _ = fmt.Fprintf(
+			writer,
+			"%s\t%s\t%s (%s)\t%s\n",
+			e.Identifier,
+			e.Name,
+			e.ContractName,
+			e.ContractAddress,
+			truncate(e.Description, 60),
+		)
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *searchResult) Oneliner() string {
+	identifiers := make([]string, 0, len(r.entries))
+	for _, e := range r.entries {
+		identifiers = append(identifiers, e.Identifier)
+	}
+	return strings.Join(identifiers, ", ")
+}
+
+// truncate shortens the text to the length and removes line breaks, so it fits in a table.
+func truncate(text string, length int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	if len(runes) <= length {
+		return text
+	}
+	return string(runes[:length-3]) + "..."
+}
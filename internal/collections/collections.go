@@ -26,6 +26,7 @@ import (
 	"github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
+	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -42,9 +43,43 @@ func init() {
 
 type collectionResult struct {
 	*flow.Collection
+	// transactions and their results in the collection, only set if they are included
+	transactions []*flow.Transaction
+	results      []*flow.TransactionResult
 }
 
 func (c *collectionResult) JSON() any {
+	if c.transactions == nil {
+		return c.transactionIDs()
+	}
+
+	txs := make([]any, 0, len(c.transactions))
+	for i, tx := range c.transactions {
+		authorizers := make([]string, 0, len(tx.Authorizers))
+		for _, a := range tx.Authorizers {
+			authorizers = append(authorizers, a.Hex())
+		}
+
+		entry := map[string]any{
+			"id":          tx.ID().String(),
+			"status":      c.results[i].Status.String(),
+			"payer":       tx.Payer.Hex(),
+			"authorizers": authorizers,
+			"script":      string(tx.Script),
+		}
+		if c.results[i].Error != nil {
+			entry["error"] = c.results[i].Error.Error()
+		}
+		txs = append(txs, entry)
+	}
+
+	return map[string]any{
+		"id":           c.Collection.ID().String(),
+		"transactions": txs,
+	}
+}
+
+func (c *collectionResult) transactionIDs() []string {
 	txIDs := make([]string, 0)
 
 	for _, tx := range c.Collection.TransactionIDs {
@@ -60,8 +95,30 @@ func (c *collectionResult) String() string {
 
 	_, _ = fmt.Fprintf(writer, "Collection ID %s:\n", c.Collection.ID())
 
-	for _, tx := range c.Collection.TransactionIDs {
-		_, _ = fmt.Fprintf(writer, "%s\n", tx.String())
+	if c.transactions == nil {
+		for _, tx := range c.Collection.TransactionIDs {
+			_, _ = fmt.Fprintf(writer, "%s\n", tx.String())
+		}
+	}
+
+	for i, tx := range c.transactions {
+		result := c.results[i]
+
+		statusBadge := ""
+		if result.Status == flow.TransactionStatusSealed {
+			statusBadge = output.OkEmoji()
+		}
+
+		_, _ = fmt.Fprintf(writer, "\nTransaction ID\t%s\n", tx.ID())
+		_, _ = fmt.Fprintf(writer, "Status\t%s %s\n", statusBadge, result.Status)
+		if result.Error != nil {
+			_, _ = fmt.Fprintf(writer, "%s Transaction Error\t%s\n", output.ErrorEmoji(), result.Error.Error())
+		}
+		_, _ = fmt.Fprintf(writer, "Payer\t%s\n", tx.Payer.Hex())
+		_, _ = fmt.Fprintf(writer, "Authorizers\t%s\n", tx.Authorizers)
+		_, _ = fmt.Fprintf(writer, "Script\n")
+		_ = writer.Flush()
+		_, _ = fmt.Fprintf(&b, "%s\n", strings.TrimSpace(string(tx.Script)))
 	}
 
 	_ = writer.Flush()
@@ -70,5 +127,5 @@ func (c *collectionResult) String() string {
 }
 
 func (c *collectionResult) Oneliner() string {
-	return strings.Join(c.JSON().([]string), ",")
+	return strings.Join(c.transactionIDs(), ",")
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -45,4 +46,28 @@ func Test_Get(t *testing.T) {
 		require.NoError(t, err)
 		require.NotNil(t, result)
 	})
+	t.Run("Success include transactions", func(t *testing.T) {
+		inArgs := []string{util.TestID.String()}
+		collectionFlags.Include = []string{"transactions"}
+		defer func() { collectionFlags.Include = nil }()
+
+		collection := tests.NewCollection()
+		srv.GetCollection.Return(collection, nil)
+
+		tx := tests.NewTransaction()
+		tx.Script = []byte("transaction {}")
+		srv.GetTransactionByID.Run(func(args mock.Arguments) {
+			assert.Contains(t, collection.TransactionIDs, args.Get(1).(flow.Identifier))
+		}).Return(tx, tests.NewTransactionResult(nil), nil)
+
+		result, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		srv.GetTransactionByID.Parent.AssertNumberOfCalls(t, "GetTransactionByID", 2)
+
+		res := result.(*collectionResult)
+		assert.Len(t, res.transactions, 2)
+		assert.Contains(t, res.String(), "transaction {}")
+		txs := res.JSON().(map[string]any)["transactions"].([]any)
+		assert.Equal(t, "transaction {}", txs[0].(map[string]any)["script"])
+	})
 }
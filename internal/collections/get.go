@@ -30,16 +30,21 @@ import (
 	"github.com/onflow/flow-cli/internal/command"
 )
 
-type flagsCollections struct{}
+type flagsCollections struct {
+	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: transactions."`
+}
 
 var collectionFlags = flagsCollections{}
 
 var getCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "get <collection_id>",
-		Short:   "Get collection info",
-		Example: "flow collections get 270d...9c31e",
-		Args:    cobra.ExactArgs(1),
+		Use:   "get <collection_id>",
+		Short: "Get collection info",
+		Example: `flow collections get 270d...9c31e
+
+#show the status and script of every transaction in the collection
+flow collections get 270d...9c31e --include transactions`,
+		Args: cobra.ExactArgs(1),
 	},
 	Flags: &collectionFlags,
 	Run:   get,
@@ -62,5 +67,18 @@ func get(
 		return nil, err
 	}
 
-	return &collectionResult{collection}, nil
+	result := &collectionResult{Collection: collection}
+	if command.ContainsFlag(collectionFlags.Include, "transactions") {
+		for i, txID := range collection.TransactionIDs {
+			logger.StartProgress(fmt.Sprintf("Loading transaction %d of %d in collection %s", i+1, len(collection.TransactionIDs), id))
+			tx, txResult, err := flow.GetTransactionByID(context.Background(), txID, false)
+			if err != nil {
+				return nil, err
+			}
+			result.transactions = append(result.transactions, tx)
+			result.results = append(result.results, txResult)
+		}
+	}
+
+	return result, nil
 }
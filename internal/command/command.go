@@ -19,21 +19,17 @@
 package command
 
 import (
-	"crypto/sha256"
-	"encoding/base64"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
-	"os/user"
-	"runtime"
+	"path/filepath"
 	"runtime/debug"
 	"strings"
-	"sync"
 	"time"
 
-	"github.com/dukex/mixpanel"
 	"github.com/getsentry/sentry-go"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
@@ -43,6 +39,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/audit"
 	"github.com/onflow/flow-cli/internal/settings"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -77,6 +74,10 @@ const (
 	formatText   = "text"
 	formatInline = "inline"
 	formatJSON   = "json"
+	formatCSV    = "csv"
+	formatJSONL  = "jsonl"
+	formatYAML   = "yaml"
+	formatTOML   = "toml"
 )
 
 const (
@@ -100,22 +101,87 @@ func (c Command) AddToParent(parent *cobra.Command) {
 			defer sentry.Recover()
 		}
 
+		commandPath = cmd.CommandPath()
+		util.NonInteractive = Flags.NonInteractive
+		if Flags.Quiet {
+			output.DisableEmoji()
+		}
+		handleError("Output Error", output.SetColorMode(Flags.Color))
+		handleError("Output Error", output.SetTheme(Flags.Theme))
+		handleError("Output Error", validateOutputVersion(Flags.OutputVersion))
+
+		Flags.LogFormat = strings.ToLower(Flags.LogFormat)
+		if Flags.LogFormat != output.TextLogFormat && Flags.LogFormat != output.JSONLogFormat {
+			handleError("Log Error", fmt.Errorf("invalid log format %s, options are: text, json", Flags.LogFormat))
+		}
+
+		if Flags.Answers != "" {
+			prompter, err := loadAnswers(Flags.Answers)
+			handleError("Answers Error", WithExitCode(ExitValidationError, err))
+			util.SetPrompter(prompter)
+		}
+
 		// initialize file loader used in commands
-		loader := &afero.Afero{Fs: afero.NewOsFs()}
+		var loader flowkit.ReaderWriter = &afero.Afero{Fs: afero.NewOsFs()}
 
-		// if we receive a config error that isn't missing config we should handle it
-		state, confErr := flowkit.Load(Flags.ConfigPaths, loader)
-		if !errors.Is(confErr, config.ErrDoesNotExist) {
+		// in read-only mode the changes are presented instead of saved
+		var readOnly *readOnlyReaderWriter
+		if Flags.NoSave {
+			readOnly = newReadOnlyReaderWriter(loader)
+			loader = readOnly
+		}
+
+		// if we receive a config error that isn't missing config we should handle it,
+		// commands not requiring state can also run with outdated config (e.g. to migrate it)
+		state, confErr := flowkit.LoadWithProfile(Flags.ConfigPaths, loader, Flags.Vars, Flags.Profile)
+		outdatedConf := c.Run != nil && errors.Is(confErr, config.ErrOutdatedFormat)
+		if !errors.Is(confErr, config.ErrDoesNotExist) && !outdatedConf {
 			handleError("Config Error", confErr)
 		}
 
+		if Flags.Profile != "" {
+			profileNetwork, err := resolveProfileNetwork(state, Flags.Network, c.Cmd.Flags().Changed("network"))
+			handleError("Profile Error", err)
+			Flags.Network = profileNetwork
+		}
+
 		network, err := resolveHost(state, Flags.Host, Flags.HostNetworkKey, Flags.Network)
 		handleError("Host Error", err)
 
+		network = applyGatewayFlags(*network, Flags)
+
+		// the emulator must be started before the gateway connects to it
+		if Flags.AutoEmulator && network.Name == config.EmulatorNetwork.Name {
+			emulator, err := startAutoEmulator(state, *network)
+			handleError("Emulator Error", err)
+			defer emulator.Stop()
+		}
+
 		clientGateway, err := createGateway(*network)
 		handleError("Gateway Error", err)
 
-		logger := createLogger(Flags.Log, Flags.Format)
+		if Flags.Cache {
+			clientGateway = gateway.NewCachedGateway(clientGateway, *network, Flags.CacheTTL, cacheDir())
+		}
+
+		logChanged := cmd.Flags().Changed("log-level") || cmd.Flags().Changed("log")
+		logger := createLogger(Flags.Log, Flags.LogFormat, Flags.Format, logChanged, Flags.Quiet)
+		if Flags.Log == logLevelDebug {
+			clientGateway = gateway.NewInterceptedGateway(clientGateway, debugInterceptor(logger))
+		}
+
+		// transactions sent to networks other than the emulator are recorded in the audit log
+		if network.Name != config.EmulatorNetwork.Name && settings.AuditLogEnabled() {
+			clientGateway = audit.NewGateway(
+				clientGateway,
+				settings.AuditLogPath(),
+				settings.AuditSink(),
+				c.Cmd.CommandPath(),
+				*network,
+				state,
+				logger,
+			)
+		}
 
 		// initialize services
 		flow := flowkit.NewFlowkit(state, *network, clientGateway, logger)
@@ -125,9 +191,9 @@ func (c Command) AddToParent(parent *cobra.Command) {
 			checkVersion(logger)
 		}
 
-		// record command usage
-		wg := sync.WaitGroup{}
-		go UsageMetrics(c.Cmd, &wg)
+		// measure command usage, recorded when the command exits
+		startUsage(c.Cmd)
+		defer finishUsage(ExitSuccess)
 
 		// run command based on requirements for state
 		var result Result
@@ -143,13 +209,22 @@ func (c Command) AddToParent(parent *cobra.Command) {
 			panic("command implementation needs to provide run functionality")
 		}
 
+		logger.EndProgress()
 		handleError("Command Error", err)
 
+		if readOnly != nil {
+			readOnly.logChanges(logger)
+		}
+
 		// Do not print a result if none is provided.
 		//
 		// This is useful for interactive commands that do not
 		// require a printed summary (e.g. flow accounts create).
 		if result == nil {
+			if versionedJSON(Flags) && Flags.Filter == "" {
+				err = outputResult(formatNilResult(), Flags, logger)
+				handleError("Output Error", err)
+			}
 			return
 		}
 
@@ -158,24 +233,119 @@ func (c Command) AddToParent(parent *cobra.Command) {
 		handleError("Result", err)
 
 		// output result
-		err = outputResult(formattedResult, Flags.Save, Flags.Format, Flags.Filter)
+		err = outputResult(formattedResult, Flags, logger)
 		handleError("Output Error", err)
 
-		wg.Wait()
+		// results can still represent a failure, e.g. failed tests or a reverted transaction
+		if c.Status != nil && *c.Status != ExitSuccess {
+			exit(*c.Status)
+		}
+		if r, ok := result.(ExitCodeResult); ok && r.ExitCode() != ExitSuccess {
+			exit(r.ExitCode())
+		}
 	}
 
 	bindFlags(c)
+	registerCompletions(c.Cmd)
 	parent.AddCommand(c.Cmd)
 }
 
-// createGateway creates a gateway to be used, defaults to grpc but can support others.
+// createGateway creates a gateway to be used, scripts at past blocks are executed on the archive host if configured.
+//
+// Requests are limited to the network rate limit and retried when rate-limited.
 func createGateway(network config.Network) (gateway.Gateway, error) {
-	// create secure grpc client if hostNetworkKey provided
-	if network.Key != "" {
-		return gateway.NewSecureGrpcGateway(network)
+	gw, err := createHostGateway(network)
+	if err != nil {
+		return nil, err
+	}
+
+	if network.ArchiveHost != "" {
+		gw, err = gateway.NewArchiveGateway(gw, network)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// limit the requests of all services and back off when the hosts respond with rate-limit errors
+	return gateway.NewInterceptedGateway(gw, gateway.RateLimitInterceptor(network.Options.RateLimit)), nil
+}
+
+// createHostGateway creates a gateway to the network hosts, defaults to grpc unless the host is a REST API URL.
+func createHostGateway(network config.Network) (gateway.Gateway, error) {
+	// use failover between hosts if network has multiple hosts
+	if len(network.FallbackHosts) > 0 {
+		return gateway.NewFailoverGateway(network)
+	}
+
+	return gateway.NewHostGateway(network)
+}
+
+// startAutoEmulator starts an in-process emulator serving the emulator network with the configured service account key.
+func startAutoEmulator(state *flowkit.State, network config.Network) (*gateway.ManagedEmulator, error) {
+	if state == nil {
+		return nil, config.ErrDoesNotExist
+	}
+
+	serviceAccount, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, err := serviceAccount.Key.PrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("only hexadecimal keys can be used as the emulator service account key: %w", err)
 	}
 
-	return gateway.NewGrpcGateway(network)
+	return gateway.StartManagedEmulator(network, &gateway.EmulatorKey{
+		PublicKey: (*privateKey).PublicKey(),
+		SigAlgo:   serviceAccount.Key.SigAlgo(),
+		HashAlgo:  serviceAccount.Key.HashAlgo(),
+	})
+}
+
+// cacheDir returns the directory used to persist cached gateway responses,
+// responses are only cached in memory if the user cache directory is not available.
+func cacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "flow-cli", "gateway")
+}
+
+// debugInterceptor logs every access API call made by the gateway with its duration.
+func debugInterceptor(logger output.Logger) gateway.Interceptor {
+	return func(ctx context.Context, method string, invoke gateway.Invoker) error {
+		start := time.Now()
+		err := invoke(ctx)
+		if err != nil {
+			logger.Debug(fmt.Sprintf("access API call %s failed after %s: %s", method, time.Since(start), err))
+		} else {
+			logger.Debug(fmt.Sprintf("access API call %s took %s", method, time.Since(start)))
+		}
+		return err
+	}
+}
+
+// resolveProfileNetwork returns the network of the selected profile.
+//
+// Network flag can only be provided together with the profile if it matches the profile network,
+// so the deployments are never targeting a different network than the profile defines.
+func resolveProfileNetwork(state *flowkit.State, networkFlag string, networkChanged bool) (string, error) {
+	if state == nil || state.Profile() == nil {
+		return "", fmt.Errorf("profile %s requires a project configuration", Flags.Profile)
+	}
+
+	profile := state.Profile()
+	if networkChanged && networkFlag != profile.Network {
+		return "", fmt.Errorf(
+			"network %s doesn't match the network %s of the profile %s",
+			networkFlag, profile.Network, profile.Name,
+		)
+	}
+
+	return profile.Network, nil
 }
 
 // resolveHost from the flags provided.
@@ -183,7 +353,7 @@ func createGateway(network config.Network) (gateway.Gateway, error) {
 // Resolve the network host in the following order:
 // 1. if host flag is provided resolve to that host
 // 2. if conf is initialized return host by network flag
-// 3. if conf is not initialized and network flag is provided resolve to coded value for that network
+// 3. if network flag is not found in conf or conf is not initialized resolve to preset network with that name
 // 4. default to emulator network
 func resolveHost(state *flowkit.State, hostFlag, networkKeyFlag, networkFlag string) (*config.Network, error) {
 	// host flag has the highest priority
@@ -198,7 +368,8 @@ func resolveHost(state *flowkit.State, hostFlag, networkKeyFlag, networkFlag str
 
 		if state != nil {
 			_, err := state.Networks().ByName(networkFlag)
-			if err != nil {
+			_, presetErr := config.PresetNetworks.ByName(networkFlag)
+			if err != nil && presetErr != nil {
 				return nil, fmt.Errorf("network with name %s does not exist in configuration", networkFlag)
 			}
 		} else {
@@ -211,15 +382,19 @@ func resolveHost(state *flowkit.State, hostFlag, networkKeyFlag, networkFlag str
 	// network flag with project initialized is next
 	if state != nil {
 		stateNetwork, err := state.Networks().ByName(networkFlag)
-		if err != nil {
-			return nil, fmt.Errorf("network with name %s does not exist in configuration", networkFlag)
+		if err == nil {
+			return stateNetwork, nil
+		}
+
+		// networks defined in configuration take precedence over presets
+		if preset, presetErr := config.PresetNetworks.ByName(networkFlag); presetErr == nil {
+			return preset, nil
 		}
 
-		return stateNetwork, nil
+		return nil, fmt.Errorf("network with name %s does not exist in configuration", networkFlag)
 	}
 
-	networks := config.DefaultNetworks
-	network, err := networks.ByName(networkFlag)
+	network, err := config.PresetNetworks.ByName(networkFlag)
 	if err != nil {
 		return nil, fmt.Errorf("invalid network with name %s", networkFlag)
 	}
@@ -227,11 +402,37 @@ func resolveHost(state *flowkit.State, hostFlag, networkKeyFlag, networkFlag str
 	return network, nil
 }
 
-// create logger utility.
-func createLogger(logFlag string, formatFlag string) output.Logger {
+// applyGatewayFlags overrides the network gateway options with the provided global flags.
+func applyGatewayFlags(network config.Network, flags GlobalFlags) *config.Network {
+	if flags.Timeout > 0 {
+		network.Options.Timeout = flags.Timeout
+	}
+	if flags.MaxMessageSize > 0 {
+		network.Options.MaxMessageSize = flags.MaxMessageSize
+	}
+	if flags.Keepalive > 0 {
+		network.Options.Keepalive = flags.Keepalive
+	}
+	if flags.UserAgent != "" {
+		network.Options.UserAgent = flags.UserAgent
+	}
+	if flags.RateLimit > 0 {
+		network.Options.RateLimit = flags.RateLimit
+	}
+	if flags.Proxy != "" {
+		network.Proxy = flags.Proxy
+	}
+
+	return &network
+}
+
+// createLogger creates a logger writing to stderr, so the logs don't mix with the results written to stdout.
+//
+// Quiet logger doesn't show progress spinners.
+func createLogger(logFlag string, logFormatFlag string, formatFlag string, logChanged bool, quiet bool) *output.WriterLogger {
 	// disable logging if we user want a specific format like JSON
-	// (more common they will not want also to have logs)
-	if formatFlag != formatText {
+	// (more common they will not want also to have logs) unless the log level is set explicitly
+	if formatFlag != formatText && !logChanged {
 		logFlag = logLevelNone
 	}
 
@@ -248,7 +449,12 @@ func createLogger(logFlag string, formatFlag string) output.Logger {
 		logLevel = output.InfoLog
 	}
 
-	return output.NewStdoutLogger(logLevel)
+	logger := output.NewStderrLogger(logLevel, logFormatFlag)
+	if quiet {
+		logger.DisableProgress()
+	}
+
+	return logger
 }
 
 // checkVersion fetches latest version and compares it to local.
@@ -322,42 +528,54 @@ func initCrashReporting() {
 	}
 }
 
-// The token is injected at build-time using ldflags
-var mixpanelToken = ""
+// loadAnswers creates a prompter answering the prompts from the answers file, or stdin if the path is "-".
+func loadAnswers(path string) (*util.ScriptedPrompter, error) {
+	if path == "-" {
+		return util.NewScriptedPrompter(os.Stdin)
+	}
 
-func UsageMetrics(command *cobra.Command, wg *sync.WaitGroup) {
-	if !settings.MetricsEnabled() || mixpanelToken == "" {
-		return
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the answers file: %w", err)
 	}
-	wg.Add(1)
-	client := mixpanel.New(mixpanelToken, "")
-
-	// calculates a user ID that doesn't leak any personal information
-	usr, _ := user.Current() // ignore err, just use empty string
-	hash := sha256.Sum256([]byte(fmt.Sprintf("%s%s", usr.Username, usr.Uid)))
-	userID := base64.StdEncoding.EncodeToString(hash[:])
-
-	_ = client.Track(userID, "cli-command", &mixpanel.Event{
-		IP: "0", // do not track IPs
-		Properties: map[string]any{
-			"command": command.CommandPath(),
-			"version": build.Semver(),
-			"os":      runtime.GOOS,
-		},
-	})
-	wg.Done()
+	defer file.Close()
+
+	return util.NewScriptedPrompter(file)
 }
 
 // GlobalFlags contains all global flags definitions.
 type GlobalFlags struct {
 	Filter           string
 	Format           string
+	OutputVersion    int
 	Save             string
+	SaveAppend       bool
 	Host             string
 	HostNetworkKey   string
 	Log              string
+	LogFormat        string
 	Network          string
+	Profile          string
 	Yes              bool
+	YesIAmSure       string
+	CoSigner         string
 	ConfigPaths      []string
+	Vars             map[string]string
+	NoSave           bool
 	SkipVersionCheck bool
+	Timeout          time.Duration
+	MaxMessageSize   int
+	Keepalive        time.Duration
+	UserAgent        string
+	RateLimit        float64
+	Proxy            string
+	Cache            bool
+	CacheTTL         time.Duration
+	AutoEmulator     bool
+	Quiet            bool
+	NonInteractive   bool
+	Verbose          bool
+	Answers          string
+	Color            string
+	Theme            string
 }
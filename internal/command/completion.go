@@ -0,0 +1,127 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"strings"
+
+	"github.com/spf13/afero"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// CompletionFunc completes the values of flags and arguments.
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// completionFlags are the command flags completed from the configuration.
+var completionFlags = map[string]CompletionFunc{
+	"signer":     CompleteAccounts,
+	"proposer":   CompleteAccounts,
+	"payer":      CompleteAccounts,
+	"authorizer": CompleteAccounts,
+	"account":    CompleteAccounts,
+	"contract":   CompleteContracts,
+	"network":    CompleteNetworks,
+}
+
+// registerCompletions registers the completions of the command flags which values are defined in the configuration.
+func registerCompletions(cmd *cobra.Command) {
+	for name, complete := range completionFlags {
+		if cmd.PersistentFlags().Lookup(name) == nil && cmd.Flags().Lookup(name) == nil {
+			continue
+		}
+		_ = cmd.RegisterFlagCompletionFunc(name, complete)
+	}
+}
+
+// CompleteAccounts completes account names from the configuration.
+func CompleteAccounts(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeNames(toComplete, func(state *flowkit.State) []string {
+		return state.Accounts().Names()
+	})
+}
+
+// CompleteNetworks completes network names from the configuration and the preset networks.
+func CompleteNetworks(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names := config.PresetNetworks.Names()
+
+	state := completionState()
+	if state != nil {
+		for _, name := range state.Networks().Names() {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return filterCompletions(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// CompleteContracts completes contract names from the configuration.
+func CompleteContracts(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completeNames(toComplete, func(state *flowkit.State) []string {
+		names := make([]string, 0)
+		for _, contract := range *state.Contracts() {
+			names = append(names, contract.Name)
+		}
+		return names
+	})
+}
+
+// CompleteArgs completes each positional argument with the completion at its position,
+// arguments after the provided completions are not completed.
+func CompleteArgs(completions ...CompletionFunc) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= len(completions) {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completions[len(args)](cmd, args, toComplete)
+	}
+}
+
+func completeNames(toComplete string, names func(state *flowkit.State) []string) ([]string, cobra.ShellCompDirective) {
+	state := completionState()
+	if state == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return filterCompletions(names(state), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionState loads the configuration from the config paths flag, nothing is completed if it can't be loaded.
+func completionState() *flowkit.State {
+	state, err := flowkit.Load(Flags.ConfigPaths, &afero.Afero{Fs: afero.NewOsFs()})
+	if err != nil {
+		return nil
+	}
+	return state
+}
+
+func filterCompletions(names []string, toComplete string) []string {
+	completions := make([]string, 0, len(names))
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name)
+		}
+	}
+	return completions
+}
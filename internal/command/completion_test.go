@@ -0,0 +1,93 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Completions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flow.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"networks": {"emulator": "127.0.0.1:3569", "local-fork": "127.0.0.1:3570"},
+		"accounts": {
+			"alice": {"address": "f8d6e0586b0a20c7", "key": "ea52e823f95942528b3347439dd0b19e1ee7084ca20208f0b8c9b8a3d278dbb4"},
+			"bob": {"address": "01cf0e2f2f715450", "key": "ea52e823f95942528b3347439dd0b19e1ee7084ca20208f0b8c9b8a3d278dbb4"}
+		},
+		"contracts": {"Foo": "./Foo.cdc", "Bar": "./Bar.cdc"}
+	}`), 0644))
+
+	configPaths := Flags.ConfigPaths
+	defer func() { Flags.ConfigPaths = configPaths }()
+	Flags.ConfigPaths = []string{path}
+
+	t.Run("Accounts", func(t *testing.T) {
+		names, directive := CompleteAccounts(nil, nil, "a")
+		assert.Equal(t, []string{"alice"}, names)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+
+	t.Run("Networks", func(t *testing.T) {
+		names, _ := CompleteNetworks(nil, nil, "")
+		assert.Contains(t, names, "testnet")
+		assert.Contains(t, names, "local-fork")
+	})
+
+	t.Run("Contracts", func(t *testing.T) {
+		names, _ := CompleteContracts(nil, nil, "")
+		assert.ElementsMatch(t, []string{"Foo", "Bar"}, names)
+	})
+
+	t.Run("Args", func(t *testing.T) {
+		complete := CompleteArgs(CompleteAccounts, CompleteNetworks)
+		names, _ := complete(nil, []string{"alice"}, "local")
+		assert.Equal(t, []string{"local-fork"}, names)
+
+		names, _ = complete(nil, []string{"alice", "emulator"}, "")
+		assert.Empty(t, names)
+	})
+
+	t.Run("Flags", func(t *testing.T) {
+		root := &cobra.Command{Use: "flow"}
+		cmd := &cobra.Command{Use: "send", Run: func(*cobra.Command, []string) {}}
+		cmd.Flags().String("signer", "", "")
+		root.AddCommand(cmd)
+		registerCompletions(cmd)
+
+		out := &bytes.Buffer{}
+		root.SetOut(out)
+		root.SetArgs([]string{cobra.ShellCompRequestCmd, "send", "--signer", "b"})
+		require.NoError(t, root.Execute())
+		assert.Contains(t, out.String(), "bob")
+		assert.NotContains(t, out.String(), "alice")
+	})
+
+	t.Run("Missing configuration", func(t *testing.T) {
+		Flags.ConfigPaths = []string{filepath.Join(t.TempDir(), "flow.json")}
+		names, _ := CompleteAccounts(nil, nil, "")
+		assert.Empty(t, names)
+	})
+}
@@ -0,0 +1,115 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// Exit codes of the commands, so scripts can branch on the class of the failure instead of parsing the error.
+const (
+	ExitSuccess             = 0
+	ExitError               = 1                        // failure not belonging to any other class
+	ExitConfigError         = 2                        // configuration is missing, outdated or invalid
+	ExitNetworkError        = 3                        // access API is unreachable or unavailable
+	ExitTransactionReverted = 4                        // transaction was executed with an error
+	ExitValidationError     = util.ExitValidationError // arguments, flags or values are invalid or missing
+	ExitAborted             = util.ExitAborted         // user declined a prompt or interrupted the command
+)
+
+// ExitCodeResult is implemented by results which are output but still represent a failure, e.g. a reverted transaction.
+type ExitCodeResult interface {
+	// ExitCode returns the exit code of the command after the result is output.
+	ExitCode() int
+}
+
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// WithExitCode wraps the error so the command exits with the provided exit code when it is returned.
+func WithExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// configErrors are the descriptions of errors handled while loading the configuration and resolving the network.
+var configErrors = []string{"Config Error", "Profile Error", "Host Error"}
+
+// ExitCodeOf returns the exit code of the failure class of an error returned outside of a command run.
+func ExitCodeOf(err error) int {
+	return exitCode("", err)
+}
+
+// exitCode returns the exit code of the failure class of the error.
+func exitCode(description string, err error) int {
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+
+	if errors.Is(err, config.ErrDoesNotExist) || errors.Is(err, config.ErrOutdatedFormat) {
+		return ExitConfigError
+	}
+	for _, d := range configErrors {
+		if description == d {
+			return ExitConfigError
+		}
+	}
+
+	if description == "Gateway Error" || errors.Is(err, context.DeadlineExceeded) {
+		return ExitNetworkError
+	}
+
+	code := codes.OK
+	if s, ok := status.FromError(err); ok {
+		code = s.Code()
+	}
+
+	switch {
+	case code == codes.InvalidArgument || strings.Contains(err.Error(), "code = InvalidArgument"):
+		return ExitValidationError
+	case code == codes.Unavailable || code == codes.DeadlineExceeded ||
+		strings.Contains(err.Error(), "code = Unavailable") ||
+		strings.Contains(err.Error(), "code = DeadlineExceeded") ||
+		strings.Contains(err.Error(), "transport:"):
+		return ExitNetworkError
+	}
+
+	return ExitError
+}
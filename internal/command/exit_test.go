@@ -0,0 +1,59 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_ExitCode(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		err         error
+		code        int
+	}{
+		{"generic", "Command Error", fmt.Errorf("failed"), ExitError},
+		{"missing config", "Command Error", fmt.Errorf("loading: %w", config.ErrDoesNotExist), ExitConfigError},
+		{"invalid network", "Host Error", fmt.Errorf("invalid network with name foo"), ExitConfigError},
+		{"unreachable", "Command Error", status.Error(codes.Unavailable, "connection refused"), ExitNetworkError},
+		{"unreachable text", "Command Error", fmt.Errorf("client: rpc error: code = Unavailable desc = connection error"), ExitNetworkError},
+		{"timeout", "Command Error", fmt.Errorf("request: %w", context.DeadlineExceeded), ExitNetworkError},
+		{"invalid argument", "Command Error", fmt.Errorf("send: %w", status.Error(codes.InvalidArgument, "invalid address")), ExitValidationError},
+		{"not found", "Command Error", status.Error(codes.NotFound, "not found"), ExitError},
+		{"explicit", "Command Error", WithExitCode(ExitAborted, fmt.Errorf("transaction was not approved")), ExitAborted},
+		{"wrapped explicit", "Command Error", fmt.Errorf("build: %w", WithExitCode(ExitValidationError, fmt.Errorf("invalid"))), ExitValidationError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.code, exitCode(test.description, test.err))
+		})
+	}
+
+	assert.NoError(t, WithExitCode(ExitAborted, nil))
+}
@@ -21,11 +21,13 @@ package command
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/psiemens/sconfig"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -33,14 +35,37 @@ import (
 var Flags = GlobalFlags{
 	Filter:           "",
 	Format:           formatText,
+	OutputVersion:    0,
 	Save:             "",
+	SaveAppend:       false,
 	Host:             "",
 	HostNetworkKey:   "",
 	Network:          config.EmulatorNetwork.Name,
+	Profile:          "",
 	Log:              logLevelInfo,
+	LogFormat:        output.TextLogFormat,
 	Yes:              false,
+	YesIAmSure:       "",
+	CoSigner:         "",
 	ConfigPaths:      config.DefaultPaths(),
+	Vars:             map[string]string{},
+	NoSave:           false,
 	SkipVersionCheck: false,
+	Timeout:          0,
+	MaxMessageSize:   0,
+	Keepalive:        0,
+	UserAgent:        "",
+	RateLimit:        0,
+	Proxy:            "",
+	Cache:            false,
+	CacheTTL:         10 * time.Minute,
+	AutoEmulator:     false,
+	Quiet:            false,
+	NonInteractive:   false,
+	Verbose:          false,
+	Answers:          "",
+	Color:            output.ColorAuto,
+	Theme:            output.DefaultTheme,
 }
 
 // InitFlags init all the global persistent flags.
@@ -74,7 +99,15 @@ func InitFlags(cmd *cobra.Command) {
 		"output",
 		"o",
 		Flags.Format,
-		"Output format, options: \"text\", \"json\", \"inline\"",
+		"Output format, options: \"text\", \"json\", \"inline\", \"csv\", \"jsonl\", \"yaml\", \"toml\"",
+	)
+
+	cmd.PersistentFlags().IntVarP(
+		&Flags.OutputVersion,
+		"output-version",
+		"",
+		Flags.OutputVersion,
+		"Version of the JSON output, versions above 0 wrap results and errors in a stable versioned envelope",
 	)
 
 	cmd.PersistentFlags().StringVarP(
@@ -82,17 +115,43 @@ func InitFlags(cmd *cobra.Command) {
 		"save",
 		"s",
 		Flags.Save,
-		"Save result to a filename",
+		"Save result to a file in the output format, missing directories are created",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.SaveAppend,
+		"save-append",
+		"",
+		Flags.SaveAppend,
+		"Append the result to the save file instead of overwriting it",
 	)
 
 	cmd.PersistentFlags().StringVarP(
 		&Flags.Log,
-		"log",
+		"log-level",
 		"l",
 		Flags.Log,
 		"Log level, options: \"debug\", \"info\", \"error\", \"none\"",
 	)
 
+	// log is the previous name of the log level flag, kept so existing scripts keep working
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Log,
+		"log",
+		"",
+		Flags.Log,
+		"Log level, options: \"debug\", \"info\", \"error\", \"none\"",
+	)
+	_ = cmd.PersistentFlags().MarkHidden("log")
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.LogFormat,
+		"log-format",
+		"",
+		Flags.LogFormat,
+		"Log format written to stderr, options: \"text\", \"json\"",
+	)
+
 	cmd.PersistentFlags().StringSliceVarP(
 		&Flags.ConfigPaths,
 		"config-path",
@@ -101,6 +160,14 @@ func InitFlags(cmd *cobra.Command) {
 		"Path to flow configuration file",
 	)
 
+	cmd.PersistentFlags().StringToStringVarP(
+		&Flags.Vars,
+		"var",
+		"",
+		Flags.Vars,
+		"Configuration variable used in contract locations, e.g. --var CONTRACTS_DIR=./cadence",
+	)
+
 	cmd.PersistentFlags().StringVarP(
 		&Flags.Network,
 		"network",
@@ -108,6 +175,15 @@ func InitFlags(cmd *cobra.Command) {
 		Flags.Network,
 		"Network from configuration file",
 	)
+	_ = cmd.RegisterFlagCompletionFunc("network", CompleteNetworks)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Profile,
+		"profile",
+		"",
+		Flags.Profile,
+		"Deployment profile from configuration file, selects the profile network, accounts and variables",
+	)
 
 	cmd.PersistentFlags().BoolVarP(
 		&Flags.Yes,
@@ -117,6 +193,30 @@ func InitFlags(cmd *cobra.Command) {
 		"Approve any prompts",
 	)
 
+	cmd.PersistentFlags().StringVarP(
+		&Flags.YesIAmSure,
+		"yes-i-am-sure",
+		"",
+		Flags.YesIAmSure,
+		"Account name confirming a destructive mainnet operation, bypasses the mainnet policy",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.CoSigner,
+		"cosigner",
+		"",
+		Flags.CoSigner,
+		"Account name from configuration with another key of the signer account co-signing a destructive mainnet operation",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.NoSave,
+		"no-save",
+		"",
+		Flags.NoSave,
+		"Print the changes to configuration instead of saving them",
+	)
+
 	cmd.PersistentFlags().BoolVarP(
 		&Flags.SkipVersionCheck,
 		"skip-version-check",
@@ -124,6 +224,126 @@ func InitFlags(cmd *cobra.Command) {
 		Flags.SkipVersionCheck,
 		"Skip version check during start up",
 	)
+
+	cmd.PersistentFlags().DurationVarP(
+		&Flags.Timeout,
+		"timeout",
+		"",
+		Flags.Timeout,
+		"Access API request timeout, e.g. 30s, overrides the network configuration",
+	)
+
+	cmd.PersistentFlags().IntVarP(
+		&Flags.MaxMessageSize,
+		"max-message-size",
+		"",
+		Flags.MaxMessageSize,
+		"Maximum size in bytes of Access API response messages, overrides the network configuration",
+	)
+
+	cmd.PersistentFlags().DurationVarP(
+		&Flags.Keepalive,
+		"keepalive",
+		"",
+		Flags.Keepalive,
+		"Access API connection keepalive interval, e.g. 1m, overrides the network configuration",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.UserAgent,
+		"user-agent",
+		"",
+		Flags.UserAgent,
+		"User agent sent with Access API requests, overrides the network configuration",
+	)
+
+	cmd.PersistentFlags().Float64VarP(
+		&Flags.RateLimit,
+		"rate-limit",
+		"",
+		Flags.RateLimit,
+		"Maximum Access API requests per second, overrides the network configuration",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Proxy,
+		"proxy",
+		"",
+		Flags.Proxy,
+		"HTTP or SOCKS5 proxy URL used for Access API connections, HTTP_PROXY, HTTPS_PROXY and NO_PROXY are used if not set",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.Cache,
+		"cache",
+		"",
+		Flags.Cache,
+		"Cache read-only Access API responses in memory and on disk",
+	)
+
+	cmd.PersistentFlags().DurationVarP(
+		&Flags.CacheTTL,
+		"cache-ttl",
+		"",
+		Flags.CacheTTL,
+		"Duration cached Access API responses are valid for",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.AutoEmulator,
+		"auto-emulator",
+		"",
+		Flags.AutoEmulator,
+		"Start an in-memory emulator for the duration of the command when using the emulator network",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.Quiet,
+		"quiet",
+		"q",
+		Flags.Quiet,
+		"Hide progress spinners and emoji",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.NonInteractive,
+		"non-interactive",
+		"",
+		Flags.NonInteractive,
+		"Disable prompts, prompts use their default value or fail if input is required",
+	)
+
+	cmd.PersistentFlags().BoolVarP(
+		&Flags.Verbose,
+		"verbose",
+		"",
+		Flags.Verbose,
+		"Show the raw errors returned by the access API together with the suggestions",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Answers,
+		"answers",
+		"",
+		Flags.Answers,
+		"JSON file with the answers to the prompts by question ID, \"-\" reads them from stdin",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Color,
+		"color",
+		"",
+		Flags.Color,
+		"Use colors and emoji in the output, options: \"auto\", \"always\", \"never\", auto disables them if NO_COLOR is set or the output is not a terminal",
+	)
+
+	cmd.PersistentFlags().StringVarP(
+		&Flags.Theme,
+		"theme",
+		"",
+		Flags.Theme,
+		"Output color theme, options: \"default\", \"high-contrast\"",
+	)
 }
 
 // bindFlags bind all the flags needed.
@@ -0,0 +1,126 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package command
+
+import (
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/settings"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// mainnetPolicy gets the policy mode of the destructive mainnet operation from the global settings.
+var mainnetPolicy = settings.MainnetPolicy
+
+// confirmMainnet asks to type the account name to confirm the destructive mainnet operation.
+var confirmMainnet = util.MainnetConfirmationPrompt
+
+// GuardMainnet applies the mainnet policy from the global settings to the destructive operation
+// signed by the account, operations on other networks are not guarded. The operation is on mainnet
+// if the account address is valid on the mainnet chain, regardless of the network name and host.
+//
+// The policy is bypassed if the --yes-i-am-sure flag is set to the account name. With the co-sign
+// policy the co-signer account key is added to the account so the co-signer signs the transactions as well.
+func GuardMainnet(
+	operation string,
+	description string,
+	globalFlags GlobalFlags,
+	flow flowkit.Services,
+	state *flowkit.State,
+	account *accounts.Account,
+) error {
+	network := flow.Network()
+	onMainnet := account.Address.IsValid(flowsdk.Mainnet) ||
+		network.Name == config.MainnetNetwork.Name ||
+		network.Host == config.MainnetNetwork.Host
+	if !onMainnet {
+		return nil
+	}
+
+	if globalFlags.YesIAmSure != "" {
+		if globalFlags.YesIAmSure != account.Name {
+			return WithExitCode(ExitValidationError, fmt.Errorf(
+				"the --yes-i-am-sure flag value %s doesn't match the account name %s",
+				globalFlags.YesIAmSure,
+				account.Name,
+			))
+		}
+		return nil
+	}
+
+	switch mode := mainnetPolicy(operation); mode {
+	case settings.PolicyNone:
+		return nil
+	case settings.PolicyConfirm:
+		confirmMainnet(description, account.Name)
+		return nil
+	case settings.PolicyCoSign:
+		return coSign(globalFlags, state, account)
+	case settings.PolicyBlock:
+		return WithExitCode(ExitValidationError, fmt.Errorf(
+			"%s on mainnet is blocked by the mainnet policy, use the --yes-i-am-sure=%s flag to bypass it",
+			description,
+			account.Name,
+		))
+	default:
+		return fmt.Errorf("invalid mainnet policy %s for %s, change it with 'flow settings mainnet-policy'", mode, operation)
+	}
+}
+
+// coSign adds the co-signer account key to the account signing the destructive mainnet operation.
+func coSign(globalFlags GlobalFlags, state *flowkit.State, account *accounts.Account) error {
+	if globalFlags.CoSigner == "" {
+		return WithExitCode(ExitValidationError, fmt.Errorf(
+			"the mainnet policy requires a co-signer, use the --cosigner flag with the name of an account holding another key of %s",
+			account.Name,
+		))
+	}
+
+	coSigner, err := state.Accounts().ByName(globalFlags.CoSigner)
+	if err != nil {
+		return err
+	}
+
+	if coSigner.Address != account.Address {
+		return WithExitCode(ExitValidationError, fmt.Errorf(
+			"co-signer account %s address 0x%s doesn't match the account %s address 0x%s",
+			coSigner.Name,
+			coSigner.Address,
+			account.Name,
+			account.Address,
+		))
+	}
+	if coSigner.Key.Index() == account.Key.Index() {
+		return WithExitCode(ExitValidationError, fmt.Errorf(
+			"co-signer account %s must use another key than the account %s, both use key index %d",
+			coSigner.Name,
+			account.Name,
+			account.Key.Index(),
+		))
+	}
+
+	if _, ok := account.Key.(*accounts.CoSignedKey); !ok {
+		account.Key = accounts.NewCoSignedKey(account.Key, coSigner.Key)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package command
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/settings"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_GuardMainnet(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	srv.Network.Return(config.MainnetNetwork)
+
+	address := flow.HexToAddress("0xf233dcee88fe0abe")
+	key := func(index int) accounts.Key {
+		pkey, err := crypto.GeneratePrivateKey(crypto.ECDSA_secp256k1, make([]byte, crypto.MinSeedLength))
+		require.NoError(t, err)
+		return accounts.NewHexKeyFromPrivateKey(index, crypto.SHA3_256, pkey)
+	}
+	state.Accounts().AddOrUpdate(&accounts.Account{Name: "admin", Address: address, Key: key(0)})
+	state.Accounts().AddOrUpdate(&accounts.Account{Name: "admin-2", Address: address, Key: key(1)})
+	state.Accounts().AddOrUpdate(&accounts.Account{Name: "other", Address: flow.HexToAddress("0x01"), Key: key(1)})
+
+	policy := func(mode string) {
+		mainnetPolicy = func(string) string { return mode }
+	}
+	var confirmed string
+	confirmMainnet = func(_ string, accountName string) { confirmed = accountName }
+	t.Cleanup(func() {
+		mainnetPolicy = settings.MainnetPolicy
+		confirmMainnet = util.MainnetConfirmationPrompt
+	})
+
+	account, err := state.Accounts().ByName("admin")
+	require.NoError(t, err)
+	guard := func(flags GlobalFlags) error {
+		return GuardMainnet(settings.PolicyContractRemove, "remove contract Foo", flags, srv.Mock, state, account)
+	}
+
+	t.Run("Confirm", func(t *testing.T) {
+		policy(settings.PolicyConfirm)
+		require.NoError(t, guard(GlobalFlags{}))
+		assert.Equal(t, "admin", confirmed)
+	})
+
+	t.Run("Block", func(t *testing.T) {
+		policy(settings.PolicyBlock)
+		err := guard(GlobalFlags{Yes: true})
+		assert.ErrorContains(t, err, "remove contract Foo on mainnet is blocked by the mainnet policy")
+	})
+
+	t.Run("Bypass", func(t *testing.T) {
+		policy(settings.PolicyBlock)
+		assert.NoError(t, guard(GlobalFlags{YesIAmSure: "admin"}))
+
+		err := guard(GlobalFlags{YesIAmSure: "other"})
+		assert.ErrorContains(t, err, "doesn't match the account name admin")
+	})
+
+	t.Run("Co-sign", func(t *testing.T) {
+		policy(settings.PolicyCoSign)
+		err := guard(GlobalFlags{})
+		assert.ErrorContains(t, err, "the mainnet policy requires a co-signer")
+
+		err = guard(GlobalFlags{CoSigner: "other"})
+		assert.ErrorContains(t, err, "co-signer account other address 0x0000000000000001 doesn't match")
+
+		require.NoError(t, guard(GlobalFlags{CoSigner: "admin-2"}))
+		coSigned, ok := account.Key.(*accounts.CoSignedKey)
+		require.True(t, ok)
+		require.Len(t, coSigned.CoSigners(), 1)
+		assert.Equal(t, 1, coSigned.CoSigners()[0].Index())
+		assert.Equal(t, 0, account.Key.Index())
+	})
+
+	t.Run("Other Network", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Network.Return(config.TestnetNetwork)
+		policy(settings.PolicyBlock)
+
+		account, err := state.Accounts().ByName("emulator-account")
+		require.NoError(t, err)
+		assert.NoError(t, GuardMainnet(settings.PolicyContractUpdate, "update", GlobalFlags{}, srv.Mock, state, account))
+	})
+
+	t.Run("Mainnet Account On Custom Network", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Network.Return(config.Network{Name: "mainnet-node", Host: "access.example.com:9000"})
+		policy(settings.PolicyBlock)
+
+		account := &accounts.Account{Name: "admin", Address: address, Key: key(0)}
+		err := GuardMainnet(settings.PolicyContractUpdate, "update", GlobalFlags{}, srv.Mock, state, account)
+		assert.ErrorContains(t, err, "update on mainnet is blocked by the mainnet policy")
+	})
+}
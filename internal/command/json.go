@@ -0,0 +1,117 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OutputVersion is the latest version of the JSON output.
+//
+// Selecting a version with the output-version flag wraps the JSON output of every command
+// in an envelope, the schema of a version never changes once released:
+//
+//	{"version": 1, "command": "flow accounts get", "result": <command result>}
+//	{"version": 1, "command": "flow accounts get", "error": {"type": "command_error", "message": "...", "code": "NotFound", "exitCode": 1}}
+//
+// The result is the value returned by the command result JSON method, it is null for commands without a result.
+// The error code is the Access API status code and is omitted for errors not returned by the Access API,
+// the exit code is the exit code of the command for the failure class of the error.
+// Version 0 outputs the command result without the envelope and errors as text.
+const OutputVersion = 1
+
+// commandPath is the full path of the running command included in the versioned JSON output.
+var commandPath = ""
+
+type jsonResult struct {
+	Version int    `json:"version"`
+	Command string `json:"command"`
+	Result  any    `json:"result"`
+}
+
+type jsonError struct {
+	Version int           `json:"version"`
+	Command string        `json:"command"`
+	Error   jsonErrorInfo `json:"error"`
+}
+
+type jsonErrorInfo struct {
+	Type       string `json:"type"`
+	Message    string `json:"message"`
+	Code       string `json:"code,omitempty"`
+	ExitCode   int    `json:"exitCode"`
+	Suggestion string `json:"suggestion,omitempty"`
+	Docs       string `json:"docs,omitempty"`
+}
+
+// validateOutputVersion checks the output version is supported.
+func validateOutputVersion(version int) error {
+	if version < 0 || version > OutputVersion {
+		return fmt.Errorf("unsupported output version %d, supported versions are 0 to %d", version, OutputVersion)
+	}
+	return nil
+}
+
+// versionedJSON checks whether the output is JSON wrapped in the envelope of a supported version.
+func versionedJSON(flags GlobalFlags) bool {
+	return strings.ToLower(flags.Format) == formatJSON && flags.OutputVersion > 0 && flags.OutputVersion <= OutputVersion
+}
+
+// EncodeJSON encodes a value output by the command as JSON, wrapped in the envelope if an output version is selected.
+//
+// Commands writing results while running, such as following new blocks, should use it for each written value.
+func EncodeJSON(flags GlobalFlags, value any) ([]byte, error) {
+	if !versionedJSON(flags) {
+		return json.Marshal(value)
+	}
+
+	return json.Marshal(jsonResult{
+		Version: flags.OutputVersion,
+		Command: commandPath,
+		Result:  value,
+	})
+}
+
+// encodeJSONError encodes the error in the versioned envelope, the description is used as the error type.
+func encodeJSONError(flags GlobalFlags, description string, err error) []byte {
+	info := jsonErrorInfo{
+		Type:     strings.ReplaceAll(strings.ToLower(description), " ", "_"),
+		Message:  err.Error(),
+		ExitCode: exitCode(description, err),
+	}
+	if s, ok := status.FromError(err); ok && s.Code() != codes.OK {
+		info.Code = s.Code().String()
+	}
+	if s := errorSuggestion(err); s != nil {
+		info.Suggestion = s.hint
+		info.Docs = s.docs
+	}
+
+	out, _ := json.Marshal(jsonError{
+		Version: flags.OutputVersion,
+		Command: commandPath,
+		Error:   info,
+	})
+	return out
+}
@@ -0,0 +1,173 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/user"
+	"runtime"
+	"time"
+
+	"github.com/dukex/mixpanel"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/build"
+	"github.com/onflow/flow-cli/internal/settings"
+)
+
+// The token is injected at build-time using ldflags
+var mixpanelToken = ""
+
+// collectorTimeout limits how long sending the usage can delay the exit of the command.
+const collectorTimeout = 2 * time.Second
+
+// runningUsage is the usage of the running command, nil if the user didn't opt in to usage metrics.
+var runningUsage *commandUsage
+
+type commandUsage struct {
+	command string
+	started time.Time
+}
+
+// usageEvent is the anonymized usage of a command, it contains no arguments, flag values or paths.
+type usageEvent struct {
+	Event      string    `json:"event"`
+	UserID     string    `json:"userId"`
+	Command    string    `json:"command"`
+	DurationMs int64     `json:"durationMs"`
+	ExitCode   int       `json:"exitCode"`
+	Version    string    `json:"version"`
+	OS         string    `json:"os"`
+	Arch       string    `json:"arch"`
+	Timestamp  time.Time `json:"timestamp"`
+}
+
+// startUsage starts measuring the command if the user opted in to usage metrics.
+func startUsage(cmd *cobra.Command) {
+	if !settings.MetricsEnabled() {
+		return
+	}
+	runningUsage = &commandUsage{command: cmd.CommandPath(), started: time.Now()}
+}
+
+// finishUsage tracks the usage of the running command with the exit code, only once per command.
+func finishUsage(code int) {
+	if runningUsage == nil {
+		return
+	}
+	usage := runningUsage
+	runningUsage = nil
+
+	TrackUsage(usage.command, time.Since(usage.started), code)
+}
+
+// exit tracks the usage of the running command and exits with the code.
+func exit(code int) {
+	finishUsage(code)
+	os.Exit(code)
+}
+
+// TrackUsage records the usage of the command in the local summary and sends it to the configured
+// collector or the Flow CLI maintainers, if the user opted in to usage metrics.
+//
+// Commands running until they are interrupted, such as the emulator, track their usage when they
+// start with no duration.
+func TrackUsage(command string, duration time.Duration, code int) {
+	if !settings.MetricsEnabled() {
+		return
+	}
+
+	_ = settings.RecordUsage(command, duration, code != ExitSuccess)
+
+	event := usageEvent{
+		Event:      "cli-command",
+		UserID:     anonymousUserID(),
+		Command:    command,
+		DurationMs: duration.Milliseconds(),
+		ExitCode:   code,
+		Version:    build.Semver(),
+		OS:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		Timestamp:  time.Now().UTC(),
+	}
+
+	if collector := settings.MetricsCollector(); collector != "" {
+		_ = sendUsage(collector, event)
+		return
+	}
+
+	if mixpanelToken == "" {
+		return
+	}
+	_ = mixpanel.New(mixpanelToken, "").Track(event.UserID, event.Event, &mixpanel.Event{
+		IP: "0", // do not track IPs
+		Properties: map[string]any{
+			"command":    event.Command,
+			"version":    event.Version,
+			"os":         event.OS,
+			"durationMs": event.DurationMs,
+			"exitCode":   event.ExitCode,
+		},
+	})
+}
+
+// sendUsage posts the usage event as JSON to the self-hosted collector.
+func sendUsage(collector string, event usageEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), collectorTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, collector, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("collector responded with status %s", res.Status)
+	}
+	return nil
+}
+
+// anonymousUserID calculates a user ID that doesn't leak any personal information.
+func anonymousUserID() string {
+	usr, err := user.Current()
+	if err != nil {
+		usr = &user.User{} // just use empty values
+	}
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%s%s", usr.Username, usr.Uid)))
+	return base64.StdEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,57 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_SendUsage(t *testing.T) {
+	var received usageEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+
+		if received.Command == "flow fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	event := usageEvent{
+		Event:      "cli-command",
+		UserID:     anonymousUserID(),
+		Command:    "flow accounts get",
+		DurationMs: 1500,
+		ExitCode:   ExitNetworkError,
+	}
+	require.NoError(t, sendUsage(server.URL, event))
+	assert.Equal(t, event, received)
+	assert.NotEmpty(t, received.UserID)
+
+	event.Command = "flow fail"
+	assert.EqualError(t, sendUsage(server.URL, event), "collector responded with status 500 Internal Server Error")
+}
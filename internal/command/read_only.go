@@ -0,0 +1,86 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var _ flowkit.ReaderWriter = &readOnlyReaderWriter{}
+
+// readOnlyReaderWriter reads the files but only records the writes, so the changes commands
+// would make can be presented instead of being saved.
+type readOnlyReaderWriter struct {
+	flowkit.ReaderWriter
+	original map[string][]byte
+	written  map[string][]byte
+	paths    []string // written paths in order of writes
+}
+
+func newReadOnlyReaderWriter(rw flowkit.ReaderWriter) *readOnlyReaderWriter {
+	return &readOnlyReaderWriter{
+		ReaderWriter: rw,
+		original:     make(map[string][]byte),
+		written:      make(map[string][]byte),
+	}
+}
+
+// ReadFile reads the file including the changes that were not saved.
+func (r *readOnlyReaderWriter) ReadFile(source string) ([]byte, error) {
+	if data, ok := r.written[source]; ok {
+		return data, nil
+	}
+
+	return r.ReaderWriter.ReadFile(source)
+}
+
+// WriteFile records the data that would be written to the file.
+func (r *readOnlyReaderWriter) WriteFile(filename string, data []byte, _ os.FileMode) error {
+	if _, ok := r.written[filename]; !ok {
+		original, _ := r.ReaderWriter.ReadFile(filename) // file might not exist yet
+		r.original[filename] = original
+		r.paths = append(r.paths, filename)
+	}
+
+	r.written[filename] = data
+	return nil
+}
+
+// logChanges logs the line differences for all the files which would be written.
+func (r *readOnlyReaderWriter) logChanges(logger output.Logger) {
+	for _, path := range r.paths {
+		diff := util.LineDiff(string(r.original[path]), string(r.written[path]))
+		if diff == "" {
+			continue
+		}
+
+		logger.Info(fmt.Sprintf(
+			"\n%s Read-only mode, changes to %s were not saved:\n%s",
+			output.WarningEmoji(),
+			output.Bold(path),
+			strings.TrimSuffix(diff, "\n"),
+		))
+	}
+}
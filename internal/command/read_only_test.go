@@ -0,0 +1,52 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadOnlyReaderWriter(t *testing.T) {
+	fs := afero.Afero{Fs: afero.NewMemMapFs()}
+	require.NoError(t, fs.WriteFile("flow.json", []byte("{\n}\n"), 0644))
+
+	rw := newReadOnlyReaderWriter(fs)
+	require.NoError(t, rw.WriteFile("flow.json", []byte("{\n\t\"version\": 1\n}\n"), 0644))
+	require.NoError(t, rw.WriteFile("alice.pkey", []byte("0x12"), 0600))
+
+	// changes are not saved
+	saved, err := fs.ReadFile("flow.json")
+	require.NoError(t, err)
+	assert.Equal(t, "{\n}\n", string(saved))
+	exists, _ := fs.Exists("alice.pkey")
+	assert.False(t, exists)
+
+	// but are visible when reading
+	data, err := rw.ReadFile("flow.json")
+	require.NoError(t, err)
+	assert.Equal(t, "{\n\t\"version\": 1\n}\n", string(data))
+
+	assert.Equal(t, []string{"flow.json", "alice.pkey"}, rw.paths)
+	assert.Equal(t, "{\n}\n", string(rw.original["flow.json"]))
+	assert.Empty(t, rw.original["alice.pkey"])
+}
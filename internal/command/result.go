@@ -19,15 +19,22 @@
 package command
 
 import (
+	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/onflow/flow-go-sdk/access/grpc"
+	"github.com/pelletier/go-toml/v2"
 	"github.com/spf13/afero"
 	"golang.org/x/exp/maps"
+	"gopkg.in/yaml.v3"
 
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
@@ -43,6 +50,12 @@ type Result interface {
 	JSON() any
 }
 
+// RowsResult is implemented by results that can also be output as rows in the "csv" and "jsonl" formats.
+type RowsResult interface {
+	// Rows returns the column names in order and the rows with values keyed by the column names.
+	Rows() ([]string, []map[string]any)
+}
+
 // ContainsFlag checks if output flag is present for the provided field.
 func ContainsFlag(flags []string, field string) bool {
 	for _, n := range flags {
@@ -71,27 +84,214 @@ func formatResult(result Result, filterFlag string, formatFlag string) (string,
 
 	switch strings.ToLower(formatFlag) {
 	case formatJSON:
-		jsonRes, _ := json.Marshal(result.JSON())
+		jsonRes, err := EncodeJSON(Flags, result.JSON())
+		if err != nil {
+			return "", err
+		}
 		return string(jsonRes), nil
 	case formatInline:
 		return result.Oneliner(), nil
+	case formatYAML:
+		return formatYAMLValue(result.JSON())
+	case formatTOML:
+		return formatTOMLValue(result.JSON())
+	case formatCSV, formatJSONL:
+		rowsResult, ok := result.(RowsResult)
+		if !ok {
+			return "", fmt.Errorf("output format %s is not supported by this command", formatFlag)
+		}
+		columns, rows := rowsResult.Rows()
+		if strings.ToLower(formatFlag) == formatCSV {
+			return formatCSVRows(columns, rows)
+		}
+		return formatJSONLRows(rows)
 	default:
 		return result.String(), nil
 	}
 }
 
+// formatNilResult formats the versioned JSON output of commands not providing a result.
+func formatNilResult() string {
+	jsonRes, _ := EncodeJSON(Flags, nil)
+	return string(jsonRes)
+}
+
+// formatYAMLValue formats the JSON value of the result as YAML, so it has the same fields as the JSON output.
+func formatYAMLValue(value any) (string, error) {
+	normalized, err := normalizeValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	out, err := yaml.Marshal(normalized)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// formatTOMLValue formats the JSON value of the result as TOML, so it has the same fields as the JSON output.
+//
+// TOML documents must be tables, so values that are not objects are output as the result key,
+// and null values are omitted since TOML can't represent them.
+func formatTOMLValue(value any) (string, error) {
+	normalized, err := normalizeValue(value)
+	if err != nil {
+		return "", err
+	}
+
+	table, ok := normalized.(map[string]any)
+	if !ok {
+		table = map[string]any{"result": normalized}
+	}
+
+	out, err := toml.Marshal(omitNullValues(table))
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
+// normalizeValue converts the value to the generic types produced by decoding its JSON encoding,
+// numbers are decoded as integers when possible to preserve their precision.
+func normalizeValue(value any) (any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+
+	var decoded any
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+
+	return convertNumbers(decoded), nil
+}
+
+func convertNumbers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = convertNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = convertNumbers(item)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		if u, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			return u
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	}
+
+	return value
+}
+
+func omitNullValues(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if item == nil {
+				delete(v, key)
+				continue
+			}
+			v[key] = omitNullValues(item)
+		}
+	case []any:
+		items := make([]any, 0, len(v))
+		for _, item := range v {
+			if item != nil {
+				items = append(items, omitNullValues(item))
+			}
+		}
+		return items
+	}
+
+	return value
+}
+
+// formatCSVRows formats rows as CSV with a header of column names, nested values are encoded as JSON.
+func formatCSVRows(columns []string, rows []map[string]any) (string, error) {
+	var b bytes.Buffer
+	writer := csv.NewWriter(&b)
+
+	_ = writer.Write(columns)
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			switch value := row[column].(type) {
+			case nil:
+				record[i] = ""
+			case string:
+				record[i] = value
+			case json.Number, bool, int, uint64:
+				record[i] = fmt.Sprintf("%v", value)
+			default:
+				encoded, err := json.Marshal(value)
+				if err != nil {
+					return "", err
+				}
+				record[i] = string(encoded)
+			}
+		}
+		_ = writer.Write(record)
+	}
+
+	writer.Flush()
+	return b.String(), writer.Error()
+}
+
+// formatJSONLRows formats rows as JSON lines, one JSON object per row.
+func formatJSONLRows(rows []map[string]any) (string, error) {
+	var b bytes.Buffer
+	for _, row := range rows {
+		line, err := json.Marshal(row)
+		if err != nil {
+			return "", err
+		}
+		b.Write(line)
+		b.WriteString("\n")
+	}
+
+	return b.String(), nil
+}
+
 // outputResult to selected media.
-func outputResult(result string, saveFlag string, formatFlag string, filterFlag string) error {
-	if saveFlag != "" {
-		af := afero.Afero{
-			Fs: afero.NewOsFs(),
+//
+// Saved results are separated by a new line when appended, so each result starts on its own line.
+func outputResult(result string, flags GlobalFlags, logger output.Logger) error {
+	if flags.Save != "" {
+		out, err := OpenOutput(flags)
+		if err != nil {
+			return err
 		}
+		defer out.Close()
 
-		fmt.Printf("%s result saved to: %s \n", output.SaveEmoji(), saveFlag)
-		return af.WriteFile(saveFlag, []byte(result), 0644)
+		if flags.SaveAppend && !strings.HasSuffix(result, "\n") {
+			result += "\n"
+		}
+		if _, err := io.WriteString(out, result); err != nil {
+			return fmt.Errorf("failed to save result: %w", err)
+		}
+
+		logger.Info(fmt.Sprintf("%s result saved to: %s", output.SaveEmoji(), flags.Save))
+		return nil
 	}
 
-	if formatFlag == formatInline || filterFlag != "" {
+	if flags.Format == formatInline || flags.Format == formatCSV || flags.Format == formatJSONL ||
+		flags.Format == formatYAML || flags.Format == formatTOML || flags.Filter != "" {
 		_, _ = fmt.Fprintf(os.Stdout, "%s", result)
 	} else { // default normal output
 		_, _ = fmt.Fprintf(os.Stdout, "\n%s\n\n", result)
@@ -99,6 +299,38 @@ func outputResult(result string, saveFlag string, formatFlag string, filterFlag
 	return nil
 }
 
+// OpenOutput returns the writer for command results, the file set with the save flag or stdout.
+//
+// Missing directories of the file are created and the file is appended to if the save append
+// flag is set. Streaming commands write their results to it as they are produced.
+func OpenOutput(flags GlobalFlags) (io.WriteCloser, error) {
+	if flags.Save == "" {
+		return nopWriteCloser{os.Stdout}, nil
+	}
+
+	fs := afero.NewOsFs()
+	if err := fs.MkdirAll(filepath.Dir(flags.Save), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for saving the result: %w", err)
+	}
+
+	mode := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if flags.SaveAppend {
+		mode = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+
+	file, err := fs.OpenFile(flags.Save, mode, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file for saving the result: %w", err)
+	}
+	return file, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
 // filterResultValue returns a value by its name filtered from other result values.
 func filterResultValue(result Result, filter string) (any, error) {
 	res, ok := result.JSON().(map[string]any)
@@ -123,23 +355,39 @@ func handleError(description string, err error) {
 		return
 	}
 
+	err = withSuggestion(err)
+
+	// tooling reading the versioned JSON output receives errors in the same envelope
+	if versionedJSON(Flags) {
+		_, _ = fmt.Fprintf(os.Stdout, "%s\n", encodeJSONError(Flags, description, err))
+		exit(exitCode(description, err))
+	}
+
+	if s := errorSuggestion(err); s != nil {
+		printSuggestedError(description, err, s)
+		exit(exitCode(description, err))
+	}
+
 	// TODO(sideninja): refactor this to better handle errors not by string matching
 	// handle rpc error
 	switch t := err.(type) {
 	case *grpc.RPCError:
 		_, _ = fmt.Fprintf(os.Stderr, "%s Grpc Error: %s \n", output.ErrorEmoji(), t.GRPCStatus().Err().Error())
+		printRawError(err)
 	default:
 		if errors.Is(err, config.ErrOutdatedFormat) {
 			_, _ = fmt.Fprintf(os.Stderr, "%s Config Error: %s \n", output.ErrorEmoji(), err.Error())
-			_, _ = fmt.Fprintf(os.Stderr, "%s Please reset configuration using: 'flow init --reset'. Read more about new configuration here: https://github.com/onflow/flow-cli/releases/tag/v0.17.0", output.TryEmoji())
+			_, _ = fmt.Fprintf(os.Stderr, "%s Please migrate configuration using: 'flow config migrate'. Read more about new configuration here: https://github.com/onflow/flow-cli/releases/tag/v0.17.0", output.TryEmoji())
 		} else if errors.Is(err, config.ErrDoesNotExist) {
 			_, _ = fmt.Fprintf(os.Stderr, "%s Config Error: %s \n", output.ErrorEmoji(), err.Error())
 			_, _ = fmt.Fprintf(os.Stderr, "%s Please create configuration using: flow init", output.TryEmoji())
 		} else if strings.Contains(err.Error(), "transport:") {
 			_, _ = fmt.Fprintf(os.Stderr, "%s %s \n", output.ErrorEmoji(), strings.Split(err.Error(), "transport:")[1])
 			_, _ = fmt.Fprintf(os.Stderr, "%s Make sure your emulator is running or connection address is correct.", output.TryEmoji())
+			printRawError(err)
 		} else if strings.Contains(err.Error(), "NotFound desc =") {
 			_, _ = fmt.Fprintf(os.Stderr, "%s Not Found:%s \n", output.ErrorEmoji(), strings.Split(err.Error(), "NotFound desc =")[1])
+			printRawError(err)
 		} else if strings.Contains(err.Error(), "code = InvalidArgument desc = ") {
 			desc := strings.Split(err.Error(), "code = InvalidArgument desc = ")
 			_, _ = fmt.Fprintf(os.Stderr, "%s Invalid argument: %s \n", output.ErrorEmoji(), desc[len(desc)-1])
@@ -148,17 +396,12 @@ func handleError(description string, err error) {
 			} else {
 				_, _ = fmt.Fprintf(os.Stderr, "%s Check your argument and flags value, you can use --help.", output.TryEmoji())
 			}
-		} else if strings.Contains(err.Error(), "invalid signature:") {
-			_, _ = fmt.Fprintf(os.Stderr, "%s Invalid signature: %s \n", output.ErrorEmoji(), strings.Split(err.Error(), "invalid signature:")[1])
-			_, _ = fmt.Fprintf(os.Stderr, "%s Check the signer private key is provided or is in the correct format. If running emulator, make sure it's using the same configuration as this command.", output.TryEmoji())
-		} else if strings.Contains(err.Error(), "signature could not be verified using public key with") {
-			_, _ = fmt.Fprintf(os.Stderr, "%s %s: %s \n", output.ErrorEmoji(), description, err)
-			_, _ = fmt.Fprintf(os.Stderr, "%s If you are running emulator locally make sure that the emulator was started with the same config as used in this command. \nTry restarting the emulator.", output.TryEmoji())
+			printRawError(err)
 		} else {
 			_, _ = fmt.Fprintf(os.Stderr, "%s %s: %s", output.ErrorEmoji(), description, err)
 		}
 	}
 
 	fmt.Println()
-	os.Exit(1)
+	exit(exitCode(description, err))
 }
@@ -0,0 +1,201 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+type rowsResult struct{}
+
+func (r *rowsResult) String() string   { return "" }
+func (r *rowsResult) Oneliner() string { return "" }
+func (r *rowsResult) JSON() any        { return nil }
+func (r *rowsResult) Rows() ([]string, []map[string]any) {
+	return []string{"name", "amount", "tags"}, []map[string]any{
+		{"name": "Alice, Bob", "amount": json.Number("1.5"), "tags": []any{"a"}},
+		{"name": "Carol"},
+	}
+}
+
+type textResult struct{}
+
+func (r *textResult) String() string   { return "" }
+func (r *textResult) Oneliner() string { return "" }
+func (r *textResult) JSON() any        { return nil }
+
+func Test_FormatRows(t *testing.T) {
+	t.Run("CSV", func(t *testing.T) {
+		res, err := formatResult(&rowsResult{}, "", "csv")
+		require.NoError(t, err)
+		assert.Equal(t, "name,amount,tags\n\"Alice, Bob\",1.5,\"[\"\"a\"\"]\"\nCarol,,\n", res)
+	})
+
+	t.Run("JSONL", func(t *testing.T) {
+		res, err := formatResult(&rowsResult{}, "", "jsonl")
+		require.NoError(t, err)
+		assert.Equal(t, "{\"amount\":1.5,\"name\":\"Alice, Bob\",\"tags\":[\"a\"]}\n{\"name\":\"Carol\"}\n", res)
+	})
+
+	t.Run("Fail unsupported", func(t *testing.T) {
+		_, err := formatResult(&textResult{}, "", "csv")
+		assert.EqualError(t, err, "output format csv is not supported by this command")
+	})
+}
+
+type mapResult struct{}
+
+func (r *mapResult) String() string   { return "" }
+func (r *mapResult) Oneliner() string { return "" }
+func (r *mapResult) JSON() any        { return map[string]any{"height": 10} }
+
+func Test_VersionedJSON(t *testing.T) {
+	defaultFlags := Flags
+	defer func() {
+		Flags = defaultFlags
+		commandPath = ""
+	}()
+	commandPath = "flow blocks get"
+
+	t.Run("Unversioned", func(t *testing.T) {
+		Flags.OutputVersion = 0
+		res, err := formatResult(&mapResult{}, "", "json")
+		require.NoError(t, err)
+		assert.Equal(t, `{"height":10}`, res)
+	})
+
+	t.Run("Versioned", func(t *testing.T) {
+		Flags.Format = formatJSON
+		Flags.OutputVersion = OutputVersion
+		res, err := formatResult(&mapResult{}, "", "json")
+		require.NoError(t, err)
+		assert.Equal(t, `{"version":1,"command":"flow blocks get","result":{"height":10}}`, res)
+		assert.Equal(t, `{"version":1,"command":"flow blocks get","result":null}`, formatNilResult())
+	})
+
+	t.Run("Versioned error", func(t *testing.T) {
+		Flags.Format = formatJSON
+		Flags.OutputVersion = OutputVersion
+		err := status.Error(codes.NotFound, "block not found")
+		assert.Equal(t,
+			`{"version":1,"command":"flow blocks get","error":{"type":"command_error","message":"failed to get block: rpc error: code = NotFound desc = block not found","code":"NotFound","exitCode":1}}`,
+			string(encodeJSONError(Flags, "Command Error", fmt.Errorf("failed to get block: %w", err))),
+		)
+		assert.Equal(t,
+			`{"version":1,"command":"flow blocks get","error":{"type":"host_error","message":"invalid network","exitCode":2}}`,
+			string(encodeJSONError(Flags, "Host Error", fmt.Errorf("invalid network"))),
+		)
+	})
+
+	t.Run("Fail unsupported version", func(t *testing.T) {
+		assert.NoError(t, validateOutputVersion(0))
+		assert.EqualError(t, validateOutputVersion(2), "unsupported output version 2, supported versions are 0 to 1")
+	})
+}
+
+type nestedResult struct{}
+
+func (r *nestedResult) String() string   { return "" }
+func (r *nestedResult) Oneliner() string { return "" }
+func (r *nestedResult) JSON() any {
+	return map[string]any{
+		"address": "f8d6e0586b0a20c7",
+		"balance": json.Number("10.5"),
+		"keys":    []map[string]any{{"index": uint64(0), "weight": 1000, "revoked": false}},
+		"code":    nil,
+	}
+}
+
+func Test_FormatDocuments(t *testing.T) {
+	t.Run("YAML", func(t *testing.T) {
+		res, err := formatResult(&nestedResult{}, "", "yaml")
+		require.NoError(t, err)
+		assert.Equal(t, "address: f8d6e0586b0a20c7\nbalance: 10.5\ncode: null\nkeys:\n    - index: 0\n      revoked: false\n      weight: 1000\n", res)
+	})
+
+	t.Run("TOML", func(t *testing.T) {
+		res, err := formatResult(&nestedResult{}, "", "toml")
+		require.NoError(t, err)
+		assert.Equal(t, "address = 'f8d6e0586b0a20c7'\nbalance = 10.5\n\n[[keys]]\nindex = 0\nrevoked = false\nweight = 1000\n", res)
+	})
+
+	t.Run("TOML not object", func(t *testing.T) {
+		res, err := formatTOMLValue([]string{"a", "b"})
+		require.NoError(t, err)
+		assert.Equal(t, "result = ['a', 'b']\n", res)
+	})
+}
+
+func Test_SaveResult(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "results", "nested", "result.json")
+	logger := output.NewLogger(&bytes.Buffer{}, output.InfoLog, output.TextLogFormat)
+
+	t.Run("Create directories", func(t *testing.T) {
+		flags := GlobalFlags{Save: path, Format: formatJSON}
+		require.NoError(t, outputResult(`{"a":1}`, flags, logger))
+		require.NoError(t, outputResult(`{"a":2}`, flags, logger))
+
+		saved, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":2}`, string(saved))
+	})
+
+	t.Run("Append", func(t *testing.T) {
+		flags := GlobalFlags{Save: path, SaveAppend: true, Format: formatJSON}
+		require.NoError(t, outputResult(`{"a":3}`, flags, logger))
+		require.NoError(t, outputResult("{\"a\":4}\n", flags, logger))
+
+		saved, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, "{\"a\":2}{\"a\":3}\n{\"a\":4}\n", string(saved))
+	})
+
+	t.Run("Stream", func(t *testing.T) {
+		streamPath := filepath.Join(filepath.Dir(path), "stream", "blocks.jsonl")
+		flags := GlobalFlags{Save: streamPath, SaveAppend: true}
+		for i := 0; i < 2; i++ {
+			out, err := OpenOutput(flags)
+			require.NoError(t, err)
+			_, _ = fmt.Fprintf(out, "%d\n", i)
+			require.NoError(t, out.Close())
+		}
+
+		saved, err := os.ReadFile(streamPath)
+		require.NoError(t, err)
+		assert.Equal(t, "0\n1\n", string(saved))
+	})
+
+	t.Run("Stdout", func(t *testing.T) {
+		out, err := OpenOutput(GlobalFlags{})
+		require.NoError(t, err)
+		assert.NoError(t, out.Close())
+	})
+}
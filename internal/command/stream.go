@@ -0,0 +1,63 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"io"
+	"strings"
+)
+
+// StreamWriter writes the results of streaming commands as they are produced.
+//
+// Each result is formatted with the output format and filter flags like the result of other commands,
+// so following output can be piped into the same tools as the output of a single run. The CSV header
+// is only written with the first result, and results listing values in YAML or TOML concatenate into a
+// single list of the values.
+type StreamWriter struct {
+	out     io.Writer
+	flags   GlobalFlags
+	written bool
+}
+
+// NewStreamWriter returns a stream writer writing formatted results to the output.
+func NewStreamWriter(out io.Writer, flags GlobalFlags) *StreamWriter {
+	return &StreamWriter{
+		out:   out,
+		flags: flags,
+	}
+}
+
+// Write formats the result and writes it on its own lines.
+func (w *StreamWriter) Write(result Result) error {
+	formatted, err := formatResult(result, w.flags.Filter, w.flags.Format)
+	if err != nil {
+		return err
+	}
+
+	if w.written && w.flags.Filter == "" && strings.ToLower(w.flags.Format) == formatCSV {
+		_, formatted, _ = strings.Cut(formatted, "\n")
+	}
+	if formatted != "" && !strings.HasSuffix(formatted, "\n") {
+		formatted += "\n"
+	}
+
+	w.written = true
+	_, err = io.WriteString(w.out, formatted)
+	return err
+}
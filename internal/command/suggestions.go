@@ -0,0 +1,137 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+const (
+	accountKeysDocs = "https://developers.flow.com/learn/concepts/accounts-and-keys"
+	cliDocs         = "https://developers.flow.com/tools/toolchains/flow-cli"
+)
+
+// suggestion is an actionable hint for a common failure, matched by the error message.
+type suggestion struct {
+	// patterns of which any must be contained in the error message
+	patterns []string
+	hint     string
+	docs     string
+}
+
+var suggestions = []suggestion{{
+	patterns: []string{"does not have a valid sequence number", "sequence number mismatch"},
+	hint: "The sequence number of the proposal key changed, another transaction with the same key was likely sent at the same time. " +
+		"Wait for the pending transactions of the proposer to be sealed and retry, or propose with a different key using --proposer-key-index.",
+	docs: accountKeysDocs,
+}, {
+	patterns: []string{"does not have sufficient signatures", "insufficient signature weight"},
+	hint: "The keys signing for an account must have a total weight of at least 1000. " +
+		"Sign with additional keys of the account or check the key index of the account in the configuration.",
+	docs: accountKeysDocs,
+}, {
+	patterns: []string{"make sure contract", "could not be resolved from provided contracts"},
+	hint: "The imported contract isn't deployed on the network by this project. " +
+		"Add an alias of the contract for the network with 'flow config add contract' or add it to the network deployments with 'flow config add deployment'.",
+	docs: cliDocs,
+}, {
+	patterns: []string{"signature could not be verified using public key", "invalid signature:", "public key does not match"},
+	hint: "The private key of the signer doesn't match the account key on the network. " +
+		"Check the signer private key and key index in the configuration, if running the emulator make sure it was started with the same configuration as this command.",
+	docs: accountKeysDocs,
+}}
+
+// suggestedError is an error with a suggestion how to fix it.
+type suggestedError struct {
+	err        error
+	suggestion suggestion
+}
+
+func (e *suggestedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *suggestedError) Unwrap() error {
+	return e.err
+}
+
+// withSuggestion wraps the error with the suggestion for the failure, if the failure is a known one.
+func withSuggestion(err error) error {
+	var suggested *suggestedError
+	if err == nil || errors.As(err, &suggested) {
+		return err
+	}
+
+	message := err.Error()
+	for _, s := range suggestions {
+		for _, pattern := range s.patterns {
+			if strings.Contains(message, pattern) {
+				return &suggestedError{err: err, suggestion: s}
+			}
+		}
+	}
+	return err
+}
+
+// errorSuggestion returns the suggestion the error was wrapped with, nil if it has none.
+func errorSuggestion(err error) *suggestion {
+	var suggested *suggestedError
+	if errors.As(err, &suggested) {
+		return &suggested.suggestion
+	}
+	return nil
+}
+
+// conciseMessage returns the message of the access API error without the gRPC status, since the
+// description contains the actual failure, the full error is shown with the verbose flag.
+func conciseMessage(err error) string {
+	message := err.Error()
+	if i := strings.LastIndex(message, "desc = "); i >= 0 {
+		return message[i+len("desc = "):]
+	}
+	return message
+}
+
+// printSuggestedError prints the error with its suggestion and the raw error in verbose mode.
+func printSuggestedError(description string, err error, s *suggestion) {
+	_, _ = fmt.Fprintf(os.Stderr, "%s %s: %s \n", output.ErrorEmoji(), description, conciseMessage(err))
+	_, _ = fmt.Fprintf(os.Stderr, "%s %s\n", output.TryEmoji(), s.hint)
+	_, _ = fmt.Fprintf(os.Stderr, "Read more: %s\n", s.docs)
+	printRawError(err)
+}
+
+// printRawError prints the full error and the gRPC status code in verbose mode.
+func printRawError(err error) {
+	if !Flags.Verbose {
+		return
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "\nRaw error: %s\n", err)
+	if s, ok := status.FromError(err); ok && s.Code() != codes.OK {
+		_, _ = fmt.Fprintf(os.Stderr, "Status code: %s\n", s.Code())
+	}
+}
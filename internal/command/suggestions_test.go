@@ -0,0 +1,95 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func Test_Suggestions(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		hint string
+	}{
+		{
+			"sequence number",
+			status.Error(codes.InvalidArgument, "[Error Code: 1007] invalid proposal key: public key 0 on account f8d6e0586b0a20c7 does not have a valid sequence number 2, expected 3"),
+			"--proposer-key-index",
+		},
+		{
+			"signer weight",
+			fmt.Errorf("transaction failed: [Error Code: 1006] account 01cf0e2f2f715450 does not have sufficient signatures (unauthorized access)"),
+			"weight of at least 1000",
+		},
+		{
+			"contract alias",
+			fmt.Errorf("import \"FungibleToken\" could not be resolved, make sure contract FungibleToken is added to the deployments or has an alias on the network"),
+			"flow config add contract",
+		},
+		{
+			"key mismatch",
+			fmt.Errorf("invalid signature: signature could not be verified using public key with index 0 on account f8d6e0586b0a20c7"),
+			"doesn't match the account key",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := withSuggestion(test.err)
+			s := errorSuggestion(err)
+			require.NotNil(t, s)
+			assert.Contains(t, s.hint, test.hint)
+			assert.NotEmpty(t, s.docs)
+			assert.ErrorIs(t, err, test.err)
+			assert.Equal(t, test.err.Error(), err.Error())
+			assert.Same(t, err, withSuggestion(err))
+		})
+	}
+
+	t.Run("Unknown failure", func(t *testing.T) {
+		err := fmt.Errorf("failed")
+		assert.Same(t, err, withSuggestion(err))
+		assert.Nil(t, errorSuggestion(err))
+		assert.Nil(t, withSuggestion(nil))
+	})
+
+	t.Run("Concise message", func(t *testing.T) {
+		err := fmt.Errorf("failed to send: %w", status.Error(codes.InvalidArgument, "invalid proposal key"))
+		assert.Equal(t, "invalid proposal key", conciseMessage(err))
+		assert.Equal(t, "failed", conciseMessage(fmt.Errorf("failed")))
+	})
+
+	t.Run("JSON error", func(t *testing.T) {
+		err := withSuggestion(status.Error(codes.InvalidArgument, "public key 0 on account f8d6e0586b0a20c7 does not have a valid sequence number 2, expected 3"))
+
+		var out struct{ Error map[string]any }
+		require.NoError(t, json.Unmarshal(encodeJSONError(GlobalFlags{OutputVersion: 1}, "Transaction Error", err), &out))
+		assert.Equal(t, "InvalidArgument", out.Error["code"])
+		assert.Contains(t, out.Error["suggestion"], "--proposer-key-index")
+		assert.Equal(t, accountKeysDocs, out.Error["docs"])
+	})
+}
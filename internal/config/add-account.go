@@ -109,9 +109,11 @@ func addAccount(
 		return nil, err
 	}
 
-	return &result{
-		result: fmt.Sprintf("Account %s added to the configuration", raw.Name),
-	}, nil
+	return changeResult(
+		globalFlags,
+		fmt.Sprintf("Account %s added to the configuration", raw.Name),
+		fmt.Sprintf("Account %s not added", raw.Name),
+	), nil
 
 }
 
@@ -101,9 +101,11 @@ func addContract(
 		return nil, err
 	}
 
-	return &result{
-		result: fmt.Sprintf("Contract %s added to the configuration", raw.Name),
-	}, nil
+	return changeResult(
+		globalFlags,
+		fmt.Sprintf("Contract %s added to the configuration", raw.Name),
+		fmt.Sprintf("Contract %s not added", raw.Name),
+	), nil
 }
 
 func flagsToContractData(flags flagsAddContract) (*util.ContractData, bool, error) {
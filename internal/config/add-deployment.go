@@ -84,9 +84,11 @@ func addDeployment(
 		return nil, err
 	}
 
-	return &result{
-		result: "Deployment added to the configuration.\nYou can deploy using 'flow project deploy' command",
-	}, nil
+	return changeResult(
+		globalFlags,
+		"Deployment added to the configuration.\nYou can deploy using 'flow project deploy' command",
+		"Deployment not added",
+	), nil
 }
 
 func flagsToDeploymentData(flags flagsAddDeployment) (*util.DeploymentData, bool, error) {
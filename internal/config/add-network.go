@@ -20,21 +20,27 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsAddNetwork struct {
-	Name string `flag:"name" info:"Network name"`
-	Host string `flag:"host" info:"Flow Access API host address"`
-	Key  string `flag:"network-key" info:"Flow Access API host network key for secure client connections"`
+	Name    string `flag:"name" info:"Network name"`
+	Host    string `flag:"host" info:"Flow Access API host address"`
+	Key     string `flag:"network-key" info:"Flow Access API host network key for secure client connections"`
+	Archive string `flag:"archive-host" info:"Archive access node host address used to execute scripts at past blocks"`
+	Preset  string `flag:"preset" info:"Built-in network to add (emulator, testnet, mainnet, sandboxnet, canarynet, crescendo, previewnet)"`
 }
 
 var addNetworkFlags = flagsAddNetwork{}
@@ -43,7 +49,7 @@ var addNetworkCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "network",
 		Short:   "Add network to configuration",
-		Example: "flow config add network",
+		Example: "flow config add network\nflow config add network --preset crescendo",
 		Args:    cobra.NoArgs,
 	},
 	Flags: &addNetworkFlags,
@@ -67,9 +73,10 @@ func addNetwork(
 	}
 
 	state.Networks().AddOrUpdate(config.Network{
-		Name: raw["name"],
-		Host: raw["host"],
-		Key:  raw["key"],
+		Name:        raw["name"],
+		Host:        raw["host"],
+		Key:         raw["key"],
+		ArchiveHost: raw["archive"],
 	})
 
 	err = state.SaveEdited(globalFlags.ConfigPaths)
@@ -77,12 +84,33 @@ func addNetwork(
 		return nil, err
 	}
 
-	return &result{
-		result: fmt.Sprintf("Network %s added to the configuration", raw["name"]),
-	}, nil
+	return changeResult(
+		globalFlags,
+		fmt.Sprintf("Network %s added to the configuration", raw["name"]),
+		fmt.Sprintf("Network %s not added", raw["name"]),
+	), nil
 }
 
 func flagsToNetworkData(flags flagsAddNetwork) (map[string]string, bool, error) {
+	if flags.Preset != "" {
+		preset, err := config.PresetNetworks.ByName(flags.Preset)
+		if err != nil {
+			return nil, true, fmt.Errorf(
+				"invalid network preset %s, valid presets are: %s",
+				flags.Preset,
+				strings.Join(config.PresetNetworks.Names(), ", "),
+			)
+		}
+
+		// preset values can be overridden by flags
+		if flags.Name == "" {
+			flags.Name = preset.Name
+		}
+		if flags.Host == "" {
+			flags.Host = preset.Host
+		}
+	}
+
 	if flags.Name == "" && flags.Host == "" {
 		return nil, false, nil
 	}
@@ -93,19 +121,53 @@ func flagsToNetworkData(flags flagsAddNetwork) (map[string]string, bool, error)
 		return nil, true, fmt.Errorf("host must be provided")
 	}
 
-	_, err := url.ParseRequestURI(flags.Host)
-	if err != nil {
+	if err := validateHost(flags.Host); err != nil {
 		return nil, true, err
 	}
+	if flags.Archive != "" {
+		if err := validateHost(flags.Archive); err != nil {
+			return nil, true, err
+		}
+	}
 
-	err = util.ValidateECDSAP256Pub(flags.Key)
-	if err != nil {
-		return nil, true, fmt.Errorf("invalid network-key provided")
+	if flags.Key != "" {
+		err := util.ValidateECDSAP256Pub(flags.Key)
+		if err != nil {
+			return nil, true, fmt.Errorf("invalid network-key provided")
+		}
 	}
 
 	return map[string]string{
-		"name": flags.Name,
-		"host": flags.Host,
-		"key":  flags.Key,
+		"name":    flags.Name,
+		"host":    flags.Host,
+		"key":     flags.Key,
+		"archive": flags.Archive,
 	}, true, nil
 }
+
+// validateHost checks the host is either a gRPC address with a valid port or a REST API URL.
+func validateHost(host string) error {
+	if gateway.IsHTTPHost(host) {
+		u, err := url.Parse(host)
+		if err != nil || u.Hostname() == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+			return fmt.Errorf("invalid host %s, expected format: http(s)://<host>[:<port>]", host)
+		}
+		if u.Port() != "" {
+			return validatePort(host, u.Port())
+		}
+		return nil
+	}
+
+	hostname, port, err := net.SplitHostPort(host)
+	if err != nil || hostname == "" {
+		return fmt.Errorf("invalid host %s, expected format: <host>:<port>", host)
+	}
+	return validatePort(host, port)
+}
+
+func validatePort(host string, port string) error {
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("invalid port in host %s, port must be a number between 1 and 65535", host)
+	}
+	return nil
+}
@@ -19,7 +19,11 @@
 package config
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/command"
 )
 
 var Cmd = &cobra.Command{
@@ -30,6 +34,10 @@ var Cmd = &cobra.Command{
 
 func init() {
 	initCommand.AddToParent(Cmd)
+	setupCoreContractsCommand.AddToParent(Cmd)
+	schemaCommand.AddToParent(Cmd)
+	migrateCommand.AddToParent(Cmd)
+	encryptKeysCommand.AddToParent(Cmd)
 	Cmd.AddCommand(addCmd)
 	Cmd.AddCommand(removeCmd)
 }
@@ -38,8 +46,20 @@ type result struct {
 	result string
 }
 
+// changeResult reports a change to the configuration, with the no-save flag
+// it reports the change was not saved instead.
+func changeResult(globalFlags command.GlobalFlags, saved string, notSaved string) *result {
+	if globalFlags.NoSave {
+		return &result{
+			result: fmt.Sprintf("%s, the configuration was not saved because of the --no-save flag", notSaved),
+		}
+	}
+
+	return &result{result: saved}
+}
+
 func (r *result) JSON() any {
-	return nil
+	return map[string]any{"result": r.result}
 }
 
 func (r *result) String() string {
@@ -0,0 +1,140 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_SetupCoreContracts(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		_, state, _ := util.TestMocks(t)
+		state.Contracts().AddOrUpdate(config.Contract{Name: "FungibleToken", Location: "./FungibleToken.cdc"})
+
+		added, err := addCoreContractAliases(state, []string{"mainnet", "testnet"})
+		require.NoError(t, err)
+		assert.Len(t, added, len(util.CoreContracts))
+
+		ft, err := state.Contracts().ByName("FungibleToken")
+		require.NoError(t, err)
+		assert.Equal(t, "./FungibleToken.cdc", ft.Location)
+		assert.Equal(t, "f233dcee88fe0abe", ft.Aliases.ByNetwork("mainnet").Address.String())
+		assert.Equal(t, "9a0766d93b6608b7", ft.Aliases.ByNetwork("testnet").Address.String())
+		assert.Nil(t, ft.Aliases.ByNetwork("emulator"))
+
+		nft, err := state.Contracts().ByName("NonFungibleToken")
+		require.NoError(t, err)
+		assert.Equal(t, "1d7e57aa55817448", nft.Aliases.ByNetwork("mainnet").Address.String())
+	})
+
+	t.Run("Fail unknown network", func(t *testing.T) {
+		_, state, _ := util.TestMocks(t)
+
+		_, err := addCoreContractAliases(state, []string{"foo"})
+		assert.EqualError(t, err, "network named foo does not exist in configuration")
+	})
+}
+
+func Test_EncryptKeys(t *testing.T) {
+	_, state, _ := util.TestMocks(t)
+	account, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	original, err := account.Key.PrivateKey()
+	require.NoError(t, err)
+
+	encrypted, err := encryptAccountKeys(state, []string{"alice"}, "secret")
+	require.NoError(t, err)
+	assert.Empty(t, encrypted)
+
+	encrypted, err = encryptAccountKeys(state, nil, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"emulator-account"}, encrypted)
+
+	account, err = state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	key, ok := account.Key.(*accounts.EncryptedKey)
+	require.True(t, ok)
+
+	decrypted, err := accounts.DecryptPrivateKey(key.ToConfig().EncryptedKey, key.SigAlgo(), "secret")
+	require.NoError(t, err)
+	assert.Equal(t, (*original).String(), decrypted.String())
+
+	// already encrypted keys are skipped
+	encrypted, err = encryptAccountKeys(state, nil, "secret")
+	require.NoError(t, err)
+	assert.Empty(t, encrypted)
+}
+
+func Test_AddNetworkPreset(t *testing.T) {
+	raw, provided, err := flagsToNetworkData(flagsAddNetwork{Preset: "crescendo"})
+	require.NoError(t, err)
+	assert.True(t, provided)
+	assert.Equal(t, "crescendo", raw["name"])
+	assert.Equal(t, config.CrescendoNetwork.Host, raw["host"])
+
+	raw, _, err = flagsToNetworkData(flagsAddNetwork{Preset: "sandboxnet", Name: "sandbox"})
+	require.NoError(t, err)
+	assert.Equal(t, "sandbox", raw["name"])
+	assert.Equal(t, config.SandboxnetNetwork.Host, raw["host"])
+
+	raw, _, err = flagsToNetworkData(flagsAddNetwork{Preset: "emulator"})
+	require.NoError(t, err)
+	assert.Equal(t, "127.0.0.1:3569", raw["host"])
+
+	_, _, err = flagsToNetworkData(flagsAddNetwork{Preset: "foonet"})
+	assert.EqualError(t, err, "invalid network preset foonet, valid presets are: emulator, testnet, mainnet, sandboxnet, canarynet, crescendo, previewnet")
+}
+
+func Test_AddNetworkHost(t *testing.T) {
+	for _, host := range []string{"127.0.0.1:3569", "access.devnet.nodes.onflow.org:9000", "[::1]:3569", "https://rest-testnet.onflow.org", "http://localhost:8888"} {
+		_, _, err := flagsToNetworkData(flagsAddNetwork{Name: "foo", Host: host})
+		assert.NoError(t, err, host)
+	}
+
+	_, _, err := flagsToNetworkData(flagsAddNetwork{Name: "foo", Host: "localhost:abc"})
+	assert.EqualError(t, err, "invalid port in host localhost:abc, port must be a number between 1 and 65535")
+
+	_, _, err = flagsToNetworkData(flagsAddNetwork{Name: "foo", Host: "localhost:70000"})
+	assert.EqualError(t, err, "invalid port in host localhost:70000, port must be a number between 1 and 65535")
+
+	_, _, err = flagsToNetworkData(flagsAddNetwork{Name: "foo", Host: "https://rest.example.org:abc"})
+	assert.EqualError(t, err, "invalid host https://rest.example.org:abc, expected format: http(s)://<host>[:<port>]")
+
+	_, _, err = flagsToNetworkData(flagsAddNetwork{Name: "foo", Host: "localhost"})
+	assert.EqualError(t, err, "invalid host localhost, expected format: <host>:<port>")
+
+	_, _, err = flagsToNetworkData(flagsAddNetwork{Name: "foo", Host: "localhost:3569", Archive: "archive:x"})
+	assert.EqualError(t, err, "invalid port in host archive:x, port must be a number between 1 and 65535")
+}
+
+func Test_ChangeResult(t *testing.T) {
+	res := changeResult(command.GlobalFlags{}, "network removed", "network not removed")
+	assert.Equal(t, "network removed", res.String())
+
+	res = changeResult(command.GlobalFlags{NoSave: true}, "network removed", "network not removed")
+	assert.Equal(t, "network not removed, the configuration was not saved because of the --no-save flag", res.String())
+}
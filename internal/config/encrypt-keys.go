@@ -0,0 +1,142 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsEncryptKeys struct {
+	Accounts []string `flag:"account" info:"Comma separated list of accounts to encrypt, by default all accounts are encrypted"`
+	Keychain bool     `default:"false" flag:"keychain" info:"Store the passphrase in the OS keychain"`
+}
+
+var encryptKeysFlags = flagsEncryptKeys{}
+
+var encryptKeysCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "encrypt-keys",
+		Short:   "Encrypt plaintext account private keys in configuration",
+		Example: "flow config encrypt-keys --account alice,bob",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &encryptKeysFlags,
+	RunS:  encryptKeys,
+}
+
+func encryptKeys(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	for _, name := range encryptKeysFlags.Accounts {
+		if _, err := state.Accounts().ByName(name); err != nil {
+			return nil, err
+		}
+	}
+
+	passphrase, err := accounts.EnvPassphrase()
+	if err != nil {
+		passphrase, err = util.PassphrasePrompt(true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	encrypted, err := encryptAccountKeys(state, encryptKeysFlags.Accounts, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(encrypted) == 0 {
+		return &result{result: "No plaintext account keys found to encrypt."}, nil
+	}
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	if encryptKeysFlags.Keychain {
+		if err := util.SaveKeychainPassphrase(passphrase); err != nil {
+			return nil, err
+		}
+		logger.Info("Passphrase stored in the OS keychain.")
+	}
+
+	return changeResult(
+		globalFlags,
+		fmt.Sprintf(
+			"Private keys encrypted for accounts:\n%s\n\nProvide the passphrase using %s environment variable or the OS keychain when signing.",
+			strings.Join(encrypted, "\n"),
+			accounts.PassphraseEnv,
+		),
+		fmt.Sprintf("Private keys not encrypted for accounts %s", strings.Join(encrypted, ", ")),
+	), nil
+}
+
+// encryptAccountKeys replaces plaintext hex keys of the accounts with keys encrypted with the passphrase
+// and returns the names of the encrypted accounts, if no accounts are provided all accounts are encrypted.
+//
+// Keys loaded from environment variables are not stored in the configuration, so they are skipped.
+func encryptAccountKeys(state *flowkit.State, names []string, passphrase string) ([]string, error) {
+	encrypted := make([]string, 0)
+	for _, account := range *state.Accounts() {
+		if len(names) > 0 && !slices.Contains(names, account.Name) {
+			continue
+		}
+
+		hexKey, ok := account.Key.(*accounts.HexKey)
+		if !ok {
+			continue
+		}
+
+		if conf, err := state.Config().Accounts.ByName(account.Name); err == nil && conf.Key.Env != "" {
+			continue
+		}
+
+		privateKey, err := hexKey.PrivateKey()
+		if err != nil {
+			return nil, err
+		}
+
+		key, err := accounts.NewEncryptedKey(*privateKey, hexKey.Index(), hexKey.HashAlgo(), passphrase)
+		if err != nil {
+			return nil, err
+		}
+
+		account.Key = key
+		state.Accounts().AddOrUpdate(&account)
+		encrypted = append(encrypted, account.Name)
+	}
+
+	return encrypted, nil
+}
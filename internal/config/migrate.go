@@ -0,0 +1,148 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	configJson "github.com/onflow/flow-cli/flowkit/config/json"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsMigrate struct {
+	DryRun bool `default:"false" flag:"dry-run" info:"Only print the changes without saving the configuration"`
+}
+
+var migrateFlags = flagsMigrate{}
+
+var migrateCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "migrate",
+		Short:   "Migrate configuration to the latest format",
+		Example: "flow config migrate --dry-run",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &migrateFlags,
+	Run:   migrate,
+}
+
+func migrate(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	_ output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	paths := globalFlags.ConfigPaths
+	if config.IsDefaultPath(paths) {
+		paths = []string{config.DefaultPath}
+	}
+
+	results := make([]*migrateResult, 0)
+	for _, path := range paths {
+		raw, err := readerWriter.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration %s: %w", path, err)
+		}
+
+		migrated, applied, err := configJson.Migrate(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to migrate configuration %s: %w", path, err)
+		}
+
+		if len(applied) > 0 && !migrateFlags.DryRun {
+			err = readerWriter.WriteFile(path, migrated, 0644)
+			if err != nil {
+				return nil, fmt.Errorf("failed to save configuration %s: %w", path, err)
+			}
+		}
+
+		results = append(results, &migrateResult{
+			path:    path,
+			applied: applied,
+			diff:    util.LineDiff(string(raw), string(migrated)),
+		})
+	}
+
+	return &migrateResults{results: results, dryRun: migrateFlags.DryRun}, nil
+}
+
+type migrateResult struct {
+	path    string
+	applied []string
+	diff    string
+}
+
+type migrateResults struct {
+	results []*migrateResult
+	dryRun  bool
+}
+
+func (r *migrateResults) JSON() any {
+	result := make(map[string]any)
+	for _, res := range r.results {
+		result[res.path] = map[string]any{
+			"migrations": res.applied,
+			"diff":       res.diff,
+		}
+	}
+	return result
+}
+
+func (r *migrateResults) String() string {
+	var out strings.Builder
+	for _, res := range r.results {
+		if len(res.applied) == 0 {
+			out.WriteString(fmt.Sprintf("Configuration %s is already up to date.\n", res.path))
+			continue
+		}
+
+		out.WriteString(res.diff)
+		out.WriteString("\n")
+
+		status := "migrated"
+		if r.dryRun {
+			status = "can be migrated"
+		}
+		out.WriteString(fmt.Sprintf("Configuration %s %s:\n", res.path, status))
+		for _, applied := range res.applied {
+			out.WriteString(fmt.Sprintf(" - %s\n", applied))
+		}
+	}
+
+	return strings.TrimSuffix(out.String(), "\n")
+}
+
+func (r *migrateResults) Oneliner() string {
+	migrated := make([]string, 0)
+	for _, res := range r.results {
+		if len(res.applied) > 0 {
+			migrated = append(migrated, res.path)
+		}
+	}
+
+	return fmt.Sprintf("Migrated configurations: %s", strings.Join(migrated, ", "))
+}
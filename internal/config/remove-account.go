@@ -33,10 +33,11 @@ var removeAccountFlags = flagsRemoveAccount{}
 
 var removeAccountCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "account <name>",
-		Short:   "Remove account from configuration",
-		Example: "flow config remove account Foo",
-		Args:    cobra.MaximumNArgs(1),
+		Use:               "account <name>",
+		Short:             "Remove account from configuration",
+		Example:           "flow config remove account Foo",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: command.CompleteAccounts,
 	},
 	Flags: &removeAccountFlags,
 	RunS:  removeAccount,
@@ -66,7 +67,5 @@ func removeAccount(
 		return nil, err
 	}
 
-	return &result{
-		result: "account removed",
-	}, nil
+	return changeResult(globalFlags, "account removed", "account not removed"), nil
 }
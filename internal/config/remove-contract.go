@@ -33,10 +33,11 @@ var removeContractFlags = flagsRemoveContract{}
 
 var removeContractCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "contract <name>",
-		Short:   "Remove contract from configuration",
-		Example: "flow config remove contract Foo",
-		Args:    cobra.MaximumNArgs(1),
+		Use:               "contract <name>",
+		Short:             "Remove contract from configuration",
+		Example:           "flow config remove contract Foo",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: command.CompleteContracts,
 	},
 	Flags: &removeContractFlags,
 	RunS:  removeContract,
@@ -66,7 +67,5 @@ func removeContract(
 		return nil, err
 	}
 
-	return &result{
-		result: "contract removed",
-	}, nil
+	return changeResult(globalFlags, "contract removed", "contract not removed"), nil
 }
@@ -33,10 +33,11 @@ var removeDeploymentFlags = flagsRemoveDeployment{}
 
 var removeDeploymentCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "deployment <account> <network>",
-		Short:   "Remove deployment from configuration",
-		Example: "flow config remove deployment Foo testnet",
-		Args:    cobra.MaximumNArgs(2),
+		Use:               "deployment <account> <network>",
+		Short:             "Remove deployment from configuration",
+		Example:           "flow config remove deployment Foo testnet",
+		Args:              cobra.MaximumNArgs(2),
+		ValidArgsFunction: command.CompleteArgs(command.CompleteAccounts, command.CompleteNetworks),
 	},
 	Flags: &removeDeploymentFlags,
 	RunS:  removeDeployment,
@@ -68,7 +69,5 @@ func removeDeployment(
 		return nil, err
 	}
 
-	return &result{
-		result: "deployment removed",
-	}, nil
+	return changeResult(globalFlags, "deployment removed", "deployment not removed"), nil
 }
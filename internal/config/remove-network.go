@@ -33,10 +33,11 @@ var removeNetworkFlags = flagsRemoveNetwork{}
 
 var removeNetworkCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "network <name>",
-		Short:   "Remove network from configuration",
-		Example: "flow config remove network Foo",
-		Args:    cobra.MaximumNArgs(1),
+		Use:               "network <name>",
+		Short:             "Remove network from configuration",
+		Example:           "flow config remove network Foo",
+		Args:              cobra.MaximumNArgs(1),
+		ValidArgsFunction: command.CompleteNetworks,
 	},
 	Flags: &removeNetworkFlags,
 	RunS:  removeNetwork,
@@ -66,7 +67,5 @@ func removeNetwork(
 		return nil, err
 	}
 
-	return &result{
-		result: "network removed",
-	}, nil
+	return changeResult(globalFlags, "network removed", "network not removed"), nil
 }
@@ -0,0 +1,74 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/invopop/jsonschema"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	configJson "github.com/onflow/flow-cli/flowkit/config/json"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsSchema struct{}
+
+var schemaFlags = flagsSchema{}
+
+var schemaCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "schema",
+		Short:   "Output the JSON schema of the configuration",
+		Example: "flow config schema --save flow.schema.json",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &schemaFlags,
+	Run:   schema,
+}
+
+func schema(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	return &schemaResult{schema: configJson.GenerateSchema()}, nil
+}
+
+type schemaResult struct {
+	schema *jsonschema.Schema
+}
+
+func (r *schemaResult) JSON() any {
+	return r.schema
+}
+
+func (r *schemaResult) String() string {
+	out, _ := json.MarshalIndent(r.schema, "", "  ")
+	return string(out)
+}
+
+func (r *schemaResult) Oneliner() string {
+	out, _ := json.Marshal(r.schema)
+	return string(out)
+}
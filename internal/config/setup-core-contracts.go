@@ -0,0 +1,114 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsSetupCoreContracts struct {
+	Networks []string `default:"emulator,testnet,mainnet" flag:"network" info:"Comma separated list of networks for which core contract aliases are added"`
+}
+
+var setupCoreContractsFlags = flagsSetupCoreContracts{}
+
+var setupCoreContractsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "setup-core-contracts",
+		Short:   "Add core contract aliases to configuration",
+		Example: "flow config setup-core-contracts --network mainnet,testnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &setupCoreContractsFlags,
+	RunS:  setupCoreContracts,
+}
+
+func setupCoreContracts(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	added, err := addCoreContractAliases(state, setupCoreContractsFlags.Networks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	return changeResult(
+		globalFlags,
+		fmt.Sprintf(
+			"Core contract aliases added to the configuration for networks %s:\n%s",
+			strings.Join(setupCoreContractsFlags.Networks, ", "),
+			strings.Join(added, "\n"),
+		),
+		fmt.Sprintf(
+			"Core contract aliases not added for networks %s",
+			strings.Join(setupCoreContractsFlags.Networks, ", "),
+		),
+	), nil
+}
+
+// addCoreContractAliases adds aliases for all the core contracts deployed on the networks and returns added contract names.
+func addCoreContractAliases(state *flowkit.State, networks []string) ([]string, error) {
+	for _, network := range networks {
+		if _, err := state.Networks().ByName(network); err != nil {
+			return nil, err
+		}
+	}
+
+	added := make([]string, 0)
+	for _, core := range util.CoreContracts {
+		contract, err := state.Contracts().ByName(core.Name)
+		if err != nil {
+			state.Contracts().AddOrUpdate(config.Contract{Name: core.Name})
+			contract, _ = state.Contracts().ByName(core.Name)
+		}
+
+		aliased := false
+		for _, network := range networks {
+			address, err := core.Address(network)
+			if err != nil {
+				continue // not deployed on the network
+			}
+			contract.Aliases.AddOrUpdate(network, address)
+			aliased = true
+		}
+
+		if aliased {
+			added = append(added, core.Name)
+		}
+	}
+
+	return added, nil
+}
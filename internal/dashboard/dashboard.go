@@ -0,0 +1,230 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package dashboard implements an interactive terminal dashboard of the project on a network.
+package dashboard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsDashboard struct {
+	Interval time.Duration `default:"2s" flag:"interval" info:"Interval at which the dashboard is refreshed"`
+	Blocks   int           `default:"10" flag:"blocks" info:"Number of latest blocks to show"`
+}
+
+var dashboardFlags = flagsDashboard{}
+
+var Command = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "ui",
+		Short:   "Run an interactive dashboard of the accounts, blocks, transactions and deployments on a network",
+		Example: "flow ui\nflow ui --network testnet --interval 5s",
+		Args:    cobra.NoArgs,
+		GroupID: "tools",
+	},
+	Flags: &dashboardFlags,
+	RunS:  runDashboard,
+}
+
+func runDashboard(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if err := validateFlags(dashboardFlags); err != nil {
+		return nil, command.WithExitCode(command.ExitValidationError, err)
+	}
+
+	in, out := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if util.NonInteractive || !term.IsTerminal(in) || !term.IsTerminal(out) {
+		return nil, command.WithExitCode(
+			command.ExitValidationError,
+			errors.New("the dashboard requires an interactive terminal"),
+		)
+	}
+
+	previous, err := term.MakeRaw(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize the terminal: %w", err)
+	}
+
+	// switch to the alternate screen and hide the cursor, both are restored on exit
+	fmt.Print("\x1b[?1049h\x1b[?25l")
+	defer func() {
+		fmt.Print("\x1b[?25h\x1b[?1049l")
+		_ = term.Restore(in, previous)
+	}()
+
+	d := newDashboard(flow, state, dashboardFlags.Blocks)
+	return nil, d.run(context.Background(), os.Stdin, func(view string) {
+		fmt.Print("\x1b[H\x1b[2J" + strings.ReplaceAll(view, "\n", "\r\n"))
+	}, func() (int, int) {
+		width, height, err := term.GetSize(out)
+		if err != nil {
+			return 80, 24
+		}
+		return width, height
+	})
+}
+
+// validateFlags checks the dashboard flags before the terminal is taken over.
+func validateFlags(flags flagsDashboard) error {
+	if flags.Blocks < 1 {
+		return fmt.Errorf("number of blocks must be at least 1, got %d", flags.Blocks)
+	}
+	if flags.Interval <= 0 {
+		return fmt.Errorf("interval must be greater than 0, got %s", flags.Interval)
+	}
+	return nil
+}
+
+// run refreshes the dashboard data at the configured interval and handles the key presses
+// until the dashboard is closed.
+//
+// Data is loaded in the background so the dashboard stays responsive on slow networks,
+// at most one load is in progress at a time.
+func (d *dashboard) run(
+	ctx context.Context,
+	input io.Reader,
+	draw func(string),
+	size func() (int, int),
+) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	keys := make(chan string)
+	go readKeys(ctx, input, keys)
+
+	loaded := make(chan *snapshot)
+	loading := false
+	load := func() {
+		if loading {
+			return
+		}
+		loading = true
+		d.loading = true
+		go func() {
+			data := d.load(ctx)
+			select {
+			case loaded <- data:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(dashboardFlags.Interval)
+	defer ticker.Stop()
+
+	load()
+	for {
+		draw(d.render(size()))
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case data := <-loaded:
+			loading = false
+			d.loading = false
+			d.update(data)
+		case <-ticker.C:
+			load()
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			switch d.handleKey(key) {
+			case actionQuit:
+				return nil
+			case actionRefresh:
+				load()
+			}
+		}
+	}
+}
+
+// readKeys decodes the raw terminal input into key names and sends them to the channel.
+func readKeys(ctx context.Context, input io.Reader, keys chan<- string) {
+	defer close(keys)
+
+	buf := make([]byte, 16)
+	for {
+		n, err := input.Read(buf)
+		if err != nil {
+			return
+		}
+
+		for _, key := range decodeKeys(buf[:n]) {
+			select {
+			case keys <- key:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// escapeKeys are the names of the keys sent as escape sequences.
+var escapeKeys = map[string]string{
+	"\x1b[A": keyUp,
+	"\x1b[B": keyDown,
+	"\x1bOA": keyUp,
+	"\x1bOB": keyDown,
+}
+
+// decodeKeys converts the bytes read from the terminal to key names.
+func decodeKeys(input []byte) []string {
+	var keys []string
+	for i := 0; i < len(input); i++ {
+		if input[i] == 0x1b && i+2 < len(input) {
+			if key, ok := escapeKeys[string(input[i:i+3])]; ok {
+				keys = append(keys, key)
+				i += 2
+				continue
+			}
+		}
+
+		switch input[i] {
+		case 0x03:
+			keys = append(keys, keyInterrupt)
+		case 0x1b, 0x7f, 0x08:
+			keys = append(keys, keyBack)
+		case '\r', '\n':
+			keys = append(keys, keyEnter)
+		default:
+			keys = append(keys, string(input[i]))
+		}
+	}
+	return keys
+}
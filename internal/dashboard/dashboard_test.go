@@ -0,0 +1,164 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dashboard
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Dashboard(t *testing.T) {
+	require.NoError(t, output.SetColorMode(output.ColorNever))
+	defer func() { _ = output.SetColorMode(output.ColorAuto) }()
+
+	srv, state, _ := util.TestMocks(t)
+
+	sealed := tests.NewTransaction()
+	sealedResult := tests.NewTransactionResult([]flow.Event{
+		*tests.NewEvent(0, "A.0x1.Foo.Bar", []cadence.Field{{Identifier: "value", Type: cadence.IntType{}}}, []cadence.Value{cadence.NewInt(1)}),
+	})
+	sealedResult.Status = flow.TransactionStatusSealed
+	sealedResult.BlockHeight = 10
+
+	pending := tests.NewTransaction()
+	pendingResult := tests.NewTransactionResult(nil)
+	pendingResult.Status = flow.TransactionStatusExecuted
+	pendingResult.BlockHeight = 9
+
+	latest := tests.NewBlock()
+	latest.Height = 10
+	previous := tests.NewBlock()
+	previous.Height = 9
+	previous.ID = flow.HexToID("09")
+
+	srv.GetAccount.Return(tests.NewAccountWithAddress("f8d6e0586b0a20c7"), nil)
+	srv.GetBlock.Run(func(args mock.Arguments) {
+		query := args.Get(1).(flowkit.BlockQuery)
+		if query.Latest {
+			srv.GetBlock.Return(latest, nil)
+		} else {
+			assert.Equal(t, uint64(9), query.Height)
+			srv.GetBlock.Return(previous, nil)
+		}
+	})
+	srv.GetTransactionsByBlockID.Run(func(args mock.Arguments) {
+		if args.Get(1).(flow.Identifier) == previous.ID {
+			srv.GetTransactionsByBlockID.Return([]*flow.Transaction{pending}, []*flow.TransactionResult{pendingResult}, nil)
+		} else {
+			srv.GetTransactionsByBlockID.Return([]*flow.Transaction{sealed}, []*flow.TransactionResult{sealedResult}, nil)
+		}
+	})
+
+	d := newDashboard(srv.Mock, state, 2)
+
+	t.Run("Load", func(t *testing.T) {
+		d.update(d.load(context.Background()))
+		require.NoError(t, d.data.err)
+
+		require.Len(t, d.data.accounts, 1)
+		assert.Equal(t, "emulator-account", d.data.accounts[0].name)
+		require.Len(t, d.data.blocks, 2)
+		assert.Equal(t, uint64(10), d.data.blocks[0].block.Height)
+
+		require.Len(t, d.data.transactions, 2)
+		assert.Equal(t, pending.ID(), d.data.transactions[0].tx.ID())
+		assert.Contains(t, d.pending, pending.ID())
+		assert.NotContains(t, d.pending, sealed.ID())
+	})
+
+	t.Run("Render overview", func(t *testing.T) {
+		view := d.render(120, 40)
+		assert.Contains(t, view, "Flow Dashboard  emulator")
+		assert.Contains(t, view, "emulator-account  0xf8d6e0586b0a20c7")
+		assert.Contains(t, view, "no deployments for this network")
+		assert.Contains(t, view, "> "+shortID(pending.ID()))
+		assert.Contains(t, view, "EXECUTED")
+		assert.Len(t, strings.Split(view, "\n"), 40)
+	})
+
+	t.Run("Navigate", func(t *testing.T) {
+		assert.Equal(t, actionNone, d.handleKey(keyDown))
+		assert.Equal(t, 1, d.selected)
+		d.handleKey(keyDown)
+		assert.Equal(t, 1, d.selected)
+
+		d.handleKey(keyEnter)
+		assert.Equal(t, viewTransaction, d.view)
+		assert.Contains(t, d.render(120, 40), sealed.ID().String())
+
+		d.handleKey("e")
+		assert.Equal(t, viewEvents, d.view)
+		assert.Contains(t, d.render(120, 40), "value: 1")
+
+		d.handleKey(keyBack)
+		assert.Equal(t, viewTransaction, d.view)
+		d.handleKey(keyBack)
+		assert.Equal(t, viewOverview, d.view)
+
+		assert.Equal(t, actionRefresh, d.handleKey("r"))
+		assert.Equal(t, actionQuit, d.handleKey("q"))
+		assert.Equal(t, actionQuit, d.handleKey(keyInterrupt))
+	})
+
+	t.Run("Follow pending transactions", func(t *testing.T) {
+		sealedPending := *pendingResult
+		sealedPending.Status = flow.TransactionStatusSealed
+		d.numBlocks = 1
+		srv.GetTransactionByID.Return(pending, &sealedPending, nil)
+
+		d.update(d.load(context.Background()))
+		require.Len(t, d.data.transactions, 2)
+		assert.Equal(t, flow.TransactionStatusSealed, d.data.transactions[1].result.Status)
+		assert.Empty(t, d.pending)
+	})
+}
+
+func Test_ValidateFlags(t *testing.T) {
+	assert.NoError(t, validateFlags(flagsDashboard{Interval: time.Second, Blocks: 10}))
+	assert.EqualError(t, validateFlags(flagsDashboard{Interval: time.Second}), "number of blocks must be at least 1, got 0")
+	assert.EqualError(t, validateFlags(flagsDashboard{Blocks: 10}), "interval must be greater than 0, got 0s")
+	assert.EqualError(t, validateFlags(flagsDashboard{Interval: -time.Second, Blocks: 10}), "interval must be greater than 0, got -1s")
+}
+
+func Test_DecodeKeys(t *testing.T) {
+	assert.Equal(
+		t,
+		[]string{keyUp, keyDown, keyEnter, "e", keyBack, keyInterrupt},
+		decodeKeys([]byte("\x1b[A\x1b[B\re\x1b\x03")),
+	)
+}
+
+func Test_Truncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abcdef", 3))
+	assert.Equal(t, "abcdef", truncate("abcdef", 10))
+	assert.Equal(t, "\x1b[31mab\x1b[0m", truncate("\x1b[31mabcd\x1b[0m", 2))
+}
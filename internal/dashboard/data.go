@@ -0,0 +1,253 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dashboard
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/project"
+)
+
+const (
+	deploymentDeployed    = "deployed"
+	deploymentOutdated    = "outdated"
+	deploymentNotDeployed = "not deployed"
+)
+
+type accountStatus struct {
+	name    string
+	address flowsdk.Address
+	balance uint64
+	err     error
+}
+
+type blockSummary struct {
+	block        *flowsdk.Block
+	transactions int
+}
+
+type transactionStatus struct {
+	tx     *flowsdk.Transaction
+	result *flowsdk.TransactionResult
+}
+
+func (t transactionStatus) pending() bool {
+	return t.result.Status < flowsdk.TransactionStatusSealed
+}
+
+type deploymentStatus struct {
+	name    string
+	account string
+	address flowsdk.Address
+	status  string
+}
+
+// snapshot is the dashboard data loaded from the network at one point in time.
+type snapshot struct {
+	loaded         time.Time
+	err            error
+	accounts       []accountStatus
+	blocks         []blockSummary
+	transactions   []transactionStatus
+	deployments    []deploymentStatus
+	deploymentsErr error
+}
+
+type dashboard struct {
+	flow      flowkit.Services
+	state     *flowkit.State
+	numBlocks int
+
+	// pending are the transactions seen before they were sealed, they are followed until sealed
+	// even after they are no longer included in the latest blocks
+	pending map[flowsdk.Identifier]struct{}
+
+	data     *snapshot
+	loading  bool
+	view     string
+	selected int
+	// detail is the transaction shown in the transaction and events views
+	detail *transactionStatus
+}
+
+func newDashboard(flow flowkit.Services, state *flowkit.State, blocks int) *dashboard {
+	return &dashboard{
+		flow:      flow,
+		state:     state,
+		numBlocks: blocks,
+		pending:   make(map[flowsdk.Identifier]struct{}),
+		data:      &snapshot{},
+		view:      viewOverview,
+	}
+}
+
+// load fetches the accounts, the latest blocks with their transactions and the deployment status.
+//
+// Failures of individual accounts and deployments are kept in the snapshot, so the rest of the
+// dashboard is still shown, only failing to get the latest block fails the whole snapshot.
+func (d *dashboard) load(ctx context.Context) *snapshot {
+	data := &snapshot{loaded: time.Now()}
+
+	for _, account := range *d.state.Accounts() {
+		status := accountStatus{name: account.Name, address: account.Address}
+		onChain, err := d.flow.GetAccount(ctx, account.Address)
+		if err != nil {
+			status.err = err
+		} else {
+			status.balance = onChain.Balance
+		}
+		data.accounts = append(data.accounts, status)
+	}
+
+	latest, err := d.flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+	if err != nil {
+		data.err = fmt.Errorf("failed to get the latest block: %w", err)
+		return data
+	}
+
+	seen := make(map[flowsdk.Identifier]bool)
+	for i := 0; i < d.numBlocks && uint64(i) <= latest.Height; i++ {
+		block := latest
+		if i > 0 {
+			block, err = d.flow.GetBlock(ctx, flowkit.BlockQuery{Height: latest.Height - uint64(i)})
+			if err != nil {
+				data.err = fmt.Errorf("failed to get block %d: %w", latest.Height-uint64(i), err)
+				return data
+			}
+		}
+
+		txs, results, err := d.flow.GetTransactionsByBlockID(ctx, block.ID)
+		if err != nil {
+			data.err = fmt.Errorf("failed to get transactions of block %d: %w", block.Height, err)
+			return data
+		}
+
+		data.blocks = append(data.blocks, blockSummary{block: block, transactions: len(txs)})
+		for j, tx := range txs {
+			if j >= len(results) {
+				break
+			}
+			seen[tx.ID()] = true
+			data.transactions = append(data.transactions, transactionStatus{tx: tx, result: results[j]})
+		}
+	}
+
+	for id := range d.pending {
+		if seen[id] {
+			continue
+		}
+		tx, result, err := d.flow.GetTransactionByID(ctx, id, false)
+		if err != nil {
+			continue
+		}
+		data.transactions = append(data.transactions, transactionStatus{tx: tx, result: result})
+	}
+
+	d.pending = make(map[flowsdk.Identifier]struct{})
+	for _, tx := range data.transactions {
+		if tx.pending() {
+			d.pending[tx.tx.ID()] = struct{}{}
+		}
+	}
+
+	// pending transactions first, then the latest
+	sort.SliceStable(data.transactions, func(i, j int) bool {
+		a, b := data.transactions[i], data.transactions[j]
+		if a.pending() != b.pending() {
+			return a.pending()
+		}
+		return a.result.BlockHeight > b.result.BlockHeight
+	})
+
+	data.deployments, data.deploymentsErr = d.loadDeployments(ctx)
+	return data
+}
+
+// loadDeployments compares the deployment contracts with resolved imports to the code deployed on the network.
+func (d *dashboard) loadDeployments(ctx context.Context) ([]deploymentStatus, error) {
+	network := d.flow.Network()
+	contracts, err := d.state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	replacer := project.NewImportReplacer(contracts, d.state.AliasesForNetwork(network))
+	onChain := make(map[flowsdk.Address]map[string][]byte)
+	deployments := make([]deploymentStatus, 0, len(contracts))
+
+	for _, contract := range contracts {
+		deployed, ok := onChain[contract.AccountAddress]
+		if !ok {
+			account, err := d.flow.GetAccount(ctx, contract.AccountAddress)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch account %s: %w", contract.AccountName, err)
+			}
+			deployed = account.Contracts
+			onChain[contract.AccountAddress] = deployed
+		}
+
+		deployment := deploymentStatus{
+			name:    contract.Name,
+			account: contract.AccountName,
+			address: contract.AccountAddress,
+			status:  deploymentNotDeployed,
+		}
+
+		if code, exists := deployed[config.BaseName(contract.Name)]; exists {
+			deployment.status = deploymentOutdated
+			program, err := project.NewProgram(contract.Code(), contract.Args, contract.Location())
+			if err == nil {
+				program, err = replacer.Replace(program)
+			}
+			if err == nil && bytes.Equal(code, program.Code()) {
+				deployment.status = deploymentDeployed
+			}
+		}
+
+		deployments = append(deployments, deployment)
+	}
+
+	return deployments, nil
+}
+
+// update replaces the dashboard data, keeping the previous data if the new snapshot failed
+// to load so the dashboard doesn't go blank on a transient network error.
+func (d *dashboard) update(data *snapshot) {
+	if data.err != nil && len(d.data.blocks) > 0 {
+		previous := *d.data
+		previous.err = data.err
+		data = &previous
+	}
+	d.data = data
+
+	if d.selected >= len(d.data.transactions) {
+		d.selected = len(d.data.transactions) - 1
+	}
+	if d.selected < 0 {
+		d.selected = 0
+	}
+}
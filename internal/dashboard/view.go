@@ -0,0 +1,316 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dashboard
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+)
+
+const (
+	viewOverview    = "overview"
+	viewTransaction = "transaction"
+	viewEvents      = "events"
+)
+
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyEnter     = "enter"
+	keyBack      = "back"
+	keyInterrupt = "ctrl+c"
+)
+
+type action int
+
+const (
+	actionNone action = iota
+	actionQuit
+	actionRefresh
+)
+
+// handleKey updates the dashboard for the pressed key and returns the action the key requests.
+//
+// Keys:
+//
+//	up/down or k/j  select a transaction
+//	enter           show the selected transaction
+//	e               show the events of the selected transaction
+//	esc/backspace   go back to the overview
+//	r               refresh now
+//	q, ctrl+c       quit
+func (d *dashboard) handleKey(key string) action {
+	switch key {
+	case "q", keyInterrupt:
+		return actionQuit
+	case "r":
+		return actionRefresh
+	case keyBack:
+		if d.view == viewEvents {
+			d.view = viewTransaction
+		} else {
+			d.view = viewOverview
+		}
+	case keyUp, "k":
+		if d.view == viewOverview && d.selected > 0 {
+			d.selected--
+		}
+	case keyDown, "j":
+		if d.view == viewOverview && d.selected < len(d.data.transactions)-1 {
+			d.selected++
+		}
+	case keyEnter, "e":
+		if d.view == viewOverview {
+			if d.selected >= len(d.data.transactions) {
+				return actionNone
+			}
+			tx := d.data.transactions[d.selected]
+			d.detail = &tx
+		}
+		d.view = viewTransaction
+		if key == "e" {
+			d.view = viewEvents
+		}
+	}
+
+	return actionNone
+}
+
+// render returns the current view cropped to the terminal size.
+func (d *dashboard) render(width int, height int) string {
+	var b bytes.Buffer
+
+	network := d.flow.Network()
+	_, _ = fmt.Fprintf(&b, "%s  %s (%s)", output.Bold("Flow Dashboard"), network.Name, network.Host)
+	if len(d.data.blocks) > 0 {
+		_, _ = fmt.Fprintf(&b, "  block %d", d.data.blocks[0].block.Height)
+	}
+	switch {
+	case d.data.loaded.IsZero():
+		_, _ = fmt.Fprintf(&b, "  loading...")
+	case d.loading:
+		_, _ = fmt.Fprintf(&b, "  refreshing...")
+	default:
+		_, _ = fmt.Fprintf(&b, "  updated %s", d.data.loaded.Format("15:04:05"))
+	}
+	_, _ = fmt.Fprintf(&b, "\n")
+	if d.data.err != nil {
+		_, _ = fmt.Fprintf(&b, "%s\n", output.Red(d.data.err.Error()))
+	}
+	_, _ = fmt.Fprintf(&b, "\n")
+
+	help := "[↑/↓] select  [enter] details  [e] events  [r] refresh  [q] quit"
+	switch d.view {
+	case viewTransaction:
+		d.renderTransaction(&b)
+		help = "[e] events  [esc] back  [r] refresh  [q] quit"
+	case viewEvents:
+		d.renderEvents(&b)
+		help = "[esc] back  [r] refresh  [q] quit"
+	default:
+		d.renderOverview(&b)
+	}
+
+	lines := strings.Split(strings.TrimRight(b.String(), "\n"), "\n")
+	if height > 1 && len(lines) > height-1 {
+		lines = lines[:height-1]
+	}
+	for len(lines) < height-1 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, output.Italic(help))
+
+	for i, line := range lines {
+		lines[i] = truncate(line, width)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (d *dashboard) renderOverview(b *bytes.Buffer) {
+	writer := newTabWriter(b)
+
+	_, _ = fmt.Fprintf(writer, "%s\n", output.Bold("ACCOUNTS"))
+	if len(d.data.accounts) == 0 {
+		_, _ = fmt.Fprintf(writer, "  no accounts configured\n")
+	}
+	for _, account := range d.data.accounts {
+		balance := fmt.Sprintf("%s FLOW", cadence.UFix64(account.balance))
+		if account.err != nil {
+			balance = output.Red("unavailable")
+		}
+		_, _ = fmt.Fprintf(writer, "  %s\t0x%s\t%s\n", account.name, account.address, balance)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\n%s\n", output.Bold("DEPLOYMENTS"))
+	if d.data.deploymentsErr != nil {
+		_, _ = fmt.Fprintf(writer, "  %s\n", output.Red(d.data.deploymentsErr.Error()))
+	} else if len(d.data.deployments) == 0 {
+		_, _ = fmt.Fprintf(writer, "  no deployments for this network\n")
+	}
+	for _, deployment := range d.data.deployments {
+		status := deployment.status
+		switch status {
+		case deploymentDeployed:
+			status = output.Green(status)
+		case deploymentOutdated:
+			status = output.Magenta(status)
+		}
+		_, _ = fmt.Fprintf(writer, "  %s\t%s (0x%s)\t%s\n", deployment.name, deployment.account, deployment.address, status)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\n%s\n", output.Bold("LATEST BLOCKS"))
+	for _, summary := range d.data.blocks {
+		_, _ = fmt.Fprintf(writer, "  %d\t%s\t%s\t%d txs\n",
+			summary.block.Height,
+			shortID(summary.block.ID),
+			summary.block.Timestamp.Format("15:04:05"),
+			summary.transactions,
+		)
+	}
+
+	_, _ = fmt.Fprintf(writer, "\n%s\n", output.Bold("TRANSACTIONS"))
+	if len(d.data.transactions) == 0 {
+		_, _ = fmt.Fprintf(writer, "  no transactions in the latest blocks\n")
+	}
+	for i, tx := range d.data.transactions {
+		cursor := " "
+		if i == d.selected {
+			cursor = ">"
+		}
+		_, _ = fmt.Fprintf(writer, "%s %s\t%d\tpayer 0x%s\t%s\n",
+			cursor,
+			shortID(tx.tx.ID()),
+			tx.result.BlockHeight,
+			tx.tx.Payer,
+			statusText(tx.result),
+		)
+	}
+
+	_ = writer.Flush()
+}
+
+func (d *dashboard) renderTransaction(b *bytes.Buffer) {
+	if d.detail == nil {
+		return
+	}
+	tx, result := d.detail.tx, d.detail.result
+	writer := newTabWriter(b)
+
+	_, _ = fmt.Fprintf(writer, "%s\n", output.Bold("TRANSACTION"))
+	_, _ = fmt.Fprintf(writer, "ID\t%s\n", tx.ID())
+	_, _ = fmt.Fprintf(writer, "Status\t%s\n", statusText(result))
+	_, _ = fmt.Fprintf(writer, "Block\t%d (%s)\n", result.BlockHeight, result.BlockID)
+	_, _ = fmt.Fprintf(writer, "Payer\t0x%s\n", tx.Payer)
+	_, _ = fmt.Fprintf(writer, "Proposer\t0x%s\n", tx.ProposalKey.Address)
+	for i, authorizer := range tx.Authorizers {
+		_, _ = fmt.Fprintf(writer, "Authorizer %d\t0x%s\n", i, authorizer)
+	}
+	_, _ = fmt.Fprintf(writer, "Gas Limit\t%d\n", tx.GasLimit)
+	_, _ = fmt.Fprintf(writer, "Events\t%d\n", len(result.Events))
+	if result.Error != nil {
+		_, _ = fmt.Fprintf(writer, "Error\t%s\n", output.Red(result.Error.Error()))
+	}
+	_ = writer.Flush()
+
+	_, _ = fmt.Fprintf(b, "\n%s\n%s\n", output.Bold("SCRIPT"), strings.TrimSpace(string(tx.Script)))
+}
+
+func (d *dashboard) renderEvents(b *bytes.Buffer) {
+	if d.detail == nil {
+		return
+	}
+
+	_, _ = fmt.Fprintf(b, "%s %s\n", output.Bold("EVENTS OF"), d.detail.tx.ID())
+	if len(d.detail.result.Events) == 0 {
+		_, _ = fmt.Fprintf(b, "  no events\n")
+	}
+	for _, event := range d.detail.result.Events {
+		_, _ = fmt.Fprintf(b, "\n  %d  %s\n", event.EventIndex, output.Bold(event.Type))
+		for _, field := range eventFields(event) {
+			_, _ = fmt.Fprintf(b, "      %s\n", field)
+		}
+	}
+}
+
+// eventFields returns the event fields formatted as name: value.
+func eventFields(event flowsdk.Event) []string {
+	fields := make([]string, 0, len(event.Value.Fields))
+	for i, field := range event.Value.EventType.Fields {
+		if i >= len(event.Value.Fields) {
+			break
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s", field.Identifier, event.Value.Fields[i]))
+	}
+	return fields
+}
+
+func statusText(result *flowsdk.TransactionResult) string {
+	status := result.Status.String()
+	switch {
+	case result.Error != nil:
+		return output.Red(status + " (failed)")
+	case result.Status == flowsdk.TransactionStatusSealed:
+		return output.Green(status)
+	default:
+		return output.Magenta(status)
+	}
+}
+
+// newTabWriter creates a tab writer padding with spaces, since the width of tabs in the
+// terminal can't be accounted for when truncating the lines.
+func newTabWriter(b *bytes.Buffer) *tabwriter.Writer {
+	return tabwriter.NewWriter(b, 0, 8, 2, ' ', 0)
+}
+
+func shortID(id flowsdk.Identifier) string {
+	return id.String()[:8]
+}
+
+// truncate cuts the line to the width of the terminal, ignoring the color escape sequences.
+func truncate(line string, width int) string {
+	if width <= 0 {
+		return line
+	}
+
+	visible := 0
+	escape := false
+	for i, r := range line {
+		switch {
+		case escape:
+			escape = r != 'm'
+		case r == '\x1b':
+			escape = true
+		default:
+			visible++
+			if visible > width && strings.Contains(line, "\x1b") {
+				return line[:i] + "\x1b[0m"
+			} else if visible > width {
+				return line[:i]
+			}
+		}
+	}
+	return line
+}
@@ -0,0 +1,490 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type DataFlag struct {
+	DBPath string `default:"" flag:"dbpath" info:"Emulator data directory, defaults to the emulator configuration persist directory or ./flowdb"`
+	All    bool   `default:"false" flag:"all" info:"Prune the emulator database together with the snapshots"`
+}
+
+var dataFlag = DataFlag{}
+
+var DataCmd = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "data <list|prune|export|import> [bundle]",
+		Short:   "Manage persisted emulator data",
+		Example: "flow emulator data export scenario.tar.gz\nflow emulator data import scenario.tar.gz",
+		Args:    cobra.RangeArgs(1, 2),
+	},
+	Flags: &dataFlag,
+	RunS:  data,
+}
+
+const (
+	defaultDataDir     = "./flowdb"
+	emulatorDatabase   = "emulator.sqlite"
+	snapshotFilePrefix = "snapshot_"
+	bundleManifest     = "manifest.json"
+	bundleVersion      = 1
+)
+
+// dataManifest describes the emulator state contained in a data bundle.
+type dataManifest struct {
+	Version          int                `json:"version"`
+	Created          time.Time          `json:"created"`
+	ServiceAddress   string             `json:"serviceAddress"`
+	ServicePublicKey string             `json:"servicePublicKey,omitempty"`
+	Accounts         []manifestAccount  `json:"accounts"`
+	Contracts        []manifestContract `json:"contracts"`
+}
+
+type manifestAccount struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+type manifestContract struct {
+	Name    string `json:"name"`
+	Account string `json:"account"`
+}
+
+// dataFile is a persisted emulator database file.
+type dataFile struct {
+	Name     string
+	Snapshot bool
+	Size     int64
+	Modified time.Time
+}
+
+type dataList struct {
+	Dir   string
+	Files []dataFile
+}
+
+func (d *dataList) JSON() any {
+	files := make([]map[string]any, 0, len(d.Files))
+	for _, file := range d.Files {
+		files = append(files, map[string]any{
+			"name":     file.Name,
+			"snapshot": file.Snapshot,
+			"size":     file.Size,
+			"modified": file.Modified,
+		})
+	}
+
+	return map[string]any{
+		"dir":   d.Dir,
+		"files": files,
+	}
+}
+
+func (d *dataList) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	_, _ = fmt.Fprintf(writer, "Data Directory\t%s\n", d.Dir)
+	if len(d.Files) == 0 {
+		_, _ = fmt.Fprintf(writer, "No persisted emulator data\n")
+	}
+	for _, file := range d.Files {
+		kind := "database"
+		if file.Snapshot {
+			kind = "snapshot"
+		}
+		_, _ = fmt.Fprintf(
+			writer, "%s\t%s\t%d bytes\t%s\n", file.Name, kind, file.Size, file.Modified.Format(time.RFC3339),
+		)
+	}
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (d *dataList) Oneliner() string {
+	names := make([]string, 0, len(d.Files))
+	for _, file := range d.Files {
+		names = append(names, file.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+type dataResult struct {
+	Result   string
+	Path     string
+	Manifest *dataManifest
+}
+
+func (r *dataResult) JSON() any {
+	result := map[string]any{
+		"result": r.Result,
+		"path":   r.Path,
+	}
+	if r.Manifest != nil {
+		result["manifest"] = r.Manifest
+	}
+	return result
+}
+
+func (r *dataResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	_, _ = fmt.Fprintf(writer, "%s\n", r.Result)
+	_, _ = fmt.Fprintf(writer, "Path\t%s\n", r.Path)
+	if r.Manifest != nil {
+		_, _ = fmt.Fprintf(writer, "Created\t%s\n", r.Manifest.Created.Format(time.RFC3339))
+		for _, account := range r.Manifest.Accounts {
+			_, _ = fmt.Fprintf(writer, "Account\t%s (0x%s)\n", account.Name, account.Address)
+		}
+		for _, contract := range r.Manifest.Contracts {
+			_, _ = fmt.Fprintf(writer, "Contract\t%s on %s\n", contract.Name, contract.Account)
+		}
+	}
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *dataResult) Oneliner() string {
+	return fmt.Sprintf("%s: %s", r.Result, r.Path)
+}
+
+type dataCommand string
+
+const (
+	dataCommandList   dataCommand = "list"
+	dataCommandPrune  dataCommand = "prune"
+	dataCommandExport dataCommand = "export"
+	dataCommandImport dataCommand = "import"
+)
+
+func data(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	dir := dataDir(state, dataFlag.DBPath)
+
+	switch dataCommand(args[0]) {
+	case dataCommandList:
+		files, err := listDataFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+		return &dataList{Dir: dir, Files: files}, nil
+
+	case dataCommandPrune:
+		files, err := listDataFiles(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		var names []string
+		for _, file := range files {
+			if file.Snapshot || dataFlag.All {
+				names = append(names, file.Name)
+			}
+		}
+		if len(names) == 0 {
+			return &dataResult{Result: "No emulator data to prune", Path: dir}, nil
+		}
+		if !globalFlags.Yes && !util.RemoveEmulatorDataPrompt(names) {
+			return nil, command.WithExitCode(command.ExitAborted, fmt.Errorf("emulator data prune cancelled"))
+		}
+
+		err = removeDataFiles(dir, names)
+		if err != nil {
+			return nil, err
+		}
+		return &dataResult{Result: fmt.Sprintf("Pruned %s", strings.Join(names, ", ")), Path: dir}, nil
+
+	case dataCommandExport:
+		if len(args) < 2 {
+			return nil, fmt.Errorf("data export command requires bundle file argument")
+		}
+
+		manifest, err := newDataManifest(state)
+		if err != nil {
+			return nil, err
+		}
+
+		err = exportData(dir, args[1], manifest)
+		if err != nil {
+			return nil, err
+		}
+		return &dataResult{Result: "Emulator data exported", Path: args[1], Manifest: manifest}, nil
+
+	case dataCommandImport:
+		if len(args) < 2 {
+			return nil, fmt.Errorf("data import command requires bundle file argument")
+		}
+
+		_, err := os.Stat(filepath.Join(dir, emulatorDatabase))
+		if err == nil && !globalFlags.Yes && !util.RemoveEmulatorDataPrompt([]string{emulatorDatabase}) {
+			return nil, command.WithExitCode(command.ExitAborted, fmt.Errorf("emulator data import cancelled"))
+		}
+
+		manifest, err := importData(args[1], dir)
+		if err != nil {
+			return nil, err
+		}
+
+		local, err := newDataManifest(state)
+		if err == nil && manifest.ServicePublicKey != local.ServicePublicKey {
+			logger.Info(output.Italic(
+				"The emulator service account key of the imported data is different from the configured key, " +
+					"transactions signed by the service account will fail until the same key is configured.",
+			))
+		}
+
+		return &dataResult{Result: "Emulator data imported", Path: dir, Manifest: manifest}, nil
+
+	default:
+		return nil, fmt.Errorf("invalid data command: valid commands are: 'list', 'prune', 'export', 'import'")
+	}
+}
+
+// dataDir resolves the emulator data directory from the flag, the default emulator configuration or the emulator default.
+func dataDir(state *flowkit.State, dbPath string) string {
+	if dbPath != "" {
+		return dbPath
+	}
+	if emulator := state.Config().Emulators.Default(); emulator != nil && emulator.PersistDir != "" {
+		return emulator.PersistDir
+	}
+	return defaultDataDir
+}
+
+// listDataFiles returns the emulator database and snapshots persisted in the directory.
+func listDataFiles(dir string) ([]dataFile, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the emulator data directory: %w", err)
+	}
+
+	var files []dataFile
+	for _, entry := range entries {
+		snapshot := strings.HasPrefix(entry.Name(), snapshotFilePrefix)
+		if entry.IsDir() || (!snapshot && entry.Name() != emulatorDatabase) {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, dataFile{
+			Name:     entry.Name(),
+			Snapshot: snapshot,
+			Size:     info.Size(),
+			Modified: info.ModTime(),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return !files[i].Snapshot && files[j].Snapshot
+	})
+
+	return files, nil
+}
+
+func removeDataFiles(dir string, names []string) error {
+	for _, name := range names {
+		err := os.Remove(filepath.Join(dir, name))
+		if err != nil {
+			return fmt.Errorf("failed to remove emulator data %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// newDataManifest describes the project emulator accounts and contracts, keys are never included.
+func newDataManifest(state *flowkit.State) (*dataManifest, error) {
+	serviceAccount, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &dataManifest{
+		Version:        bundleVersion,
+		Created:        time.Now().UTC(),
+		ServiceAddress: serviceAccount.Address.String(),
+		Accounts:       []manifestAccount{},
+		Contracts:      []manifestContract{},
+	}
+	if privateKey, err := serviceAccount.Key.PrivateKey(); err == nil {
+		manifest.ServicePublicKey = (*privateKey).PublicKey().String()
+	}
+
+	for _, account := range *state.AccountsForNetwork(config.EmulatorNetwork) {
+		manifest.Accounts = append(manifest.Accounts, manifestAccount{
+			Name:    account.Name,
+			Address: account.Address.String(),
+		})
+	}
+	for _, deployment := range state.Deployments().ByNetwork(config.EmulatorNetwork.Name) {
+		for _, contract := range deployment.Contracts {
+			manifest.Contracts = append(manifest.Contracts, manifestContract{
+				Name:    contract.Name,
+				Account: deployment.Account,
+			})
+		}
+	}
+
+	return manifest, nil
+}
+
+// exportData writes a gzipped tar bundle with the manifest and the emulator database,
+// the database contains the state of all the accounts, contracts and storage.
+func exportData(dir string, bundle string, manifest *dataManifest) error {
+	database, err := os.ReadFile(filepath.Join(dir, emulatorDatabase))
+	if errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("no persisted emulator data in %s, start the emulator with the --persist flag", dir)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read the emulator database: %w", err)
+	}
+
+	manifestData, err := json.MarshalIndent(manifest, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	gz := gzip.NewWriter(&b)
+	archive := tar.NewWriter(gz)
+	for _, file := range []struct {
+		name string
+		data []byte
+	}{
+		{bundleManifest, manifestData},
+		{emulatorDatabase, database},
+	} {
+		err = archive.WriteHeader(&tar.Header{
+			Name:    file.name,
+			Mode:    0644,
+			Size:    int64(len(file.data)),
+			ModTime: manifest.Created,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = archive.Write(file.data)
+		if err != nil {
+			return err
+		}
+	}
+	if err = archive.Close(); err != nil {
+		return err
+	}
+	if err = gz.Close(); err != nil {
+		return err
+	}
+
+	err = os.WriteFile(bundle, b.Bytes(), 0644)
+	if err != nil {
+		return fmt.Errorf("failed to write the emulator data bundle: %w", err)
+	}
+	return nil
+}
+
+// importData extracts the emulator database from the bundle into the data directory and returns the bundle manifest.
+func importData(bundle string, dir string) (*dataManifest, error) {
+	file, err := os.Open(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the emulator data bundle: %w", err)
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("invalid emulator data bundle: %w", err)
+	}
+	archive := tar.NewReader(gz)
+
+	var manifest *dataManifest
+	var database []byte
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid emulator data bundle: %w", err)
+		}
+
+		content, err := io.ReadAll(archive)
+		if err != nil {
+			return nil, err
+		}
+
+		switch header.Name {
+		case bundleManifest:
+			manifest = &dataManifest{}
+			err = json.Unmarshal(content, manifest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid emulator data bundle manifest: %w", err)
+			}
+		case emulatorDatabase:
+			database = content
+		}
+	}
+
+	if manifest == nil || database == nil {
+		return nil, fmt.Errorf("invalid emulator data bundle: missing %s or %s", bundleManifest, emulatorDatabase)
+	}
+	if manifest.Version > bundleVersion {
+		return nil, fmt.Errorf("emulator data bundle version %d is not supported, update the CLI to a newer version", manifest.Version)
+	}
+
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(filepath.Join(dir, emulatorDatabase), database, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write the emulator database: %w", err)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,96 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Data(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+
+	dir := t.TempDir()
+	bundle := filepath.Join(t.TempDir(), "scenario.tar.gz")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, emulatorDatabase), []byte("state"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "snapshot_first"), []byte("first"), 0644))
+	dataFlag.DBPath = dir
+	flags := command.GlobalFlags{Yes: true}
+
+	t.Run("List", func(t *testing.T) {
+		result, err := data([]string{"list"}, flags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, "emulator.sqlite,snapshot_first", result.Oneliner())
+	})
+
+	t.Run("Export and import", func(t *testing.T) {
+		result, err := data([]string{"export", bundle}, flags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		manifest := result.(*dataResult).Manifest
+		assert.Equal(t, "f8d6e0586b0a20c7", manifest.ServiceAddress)
+
+		imported := t.TempDir()
+		dataFlag.DBPath = imported
+		defer func() { dataFlag.DBPath = dir }()
+
+		result, err = data([]string{"import", bundle}, flags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, manifest.ServicePublicKey, result.(*dataResult).Manifest.ServicePublicKey)
+
+		database, err := os.ReadFile(filepath.Join(imported, emulatorDatabase))
+		require.NoError(t, err)
+		assert.Equal(t, "state", string(database))
+	})
+
+	t.Run("Prune", func(t *testing.T) {
+		_, err := data([]string{"prune"}, flags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		files, err := listDataFiles(dir)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, emulatorDatabase, files[0].Name)
+	})
+
+	t.Run("Prune all", func(t *testing.T) {
+		dataFlag.All = true
+		defer func() { dataFlag.All = false }()
+		_, err := data([]string{"prune"}, flags, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+
+		files, err := listDataFiles(dir)
+		require.NoError(t, err)
+		assert.Len(t, files, 0)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		_, err := data([]string{"export", bundle}, flags, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "no persisted emulator data in "+dir+", start the emulator with the --persist flag")
+
+		_, err = data([]string{"import", filepath.Join(dir, "missing.tar.gz")}, flags, util.NoLogger, srv.Mock, state)
+		assert.ErrorContains(t, err, "failed to open the emulator data bundle")
+	})
+}
@@ -0,0 +1,265 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type LogsFlag struct {
+	File     string `default:"" flag:"file" info:"Emulator log file, written by redirecting the emulator output (e.g. flow emulator --log-format json > emulator.log)"`
+	Follow   bool   `default:"false" flag:"follow" info:"Keep streaming new log entries as they are written"`
+	Level    string `default:"" flag:"level" info:"Minimum log level to show. Valid values are: debug, info, warn, error, fatal, panic"`
+	Contains string `default:"" flag:"contains" info:"Only show log entries containing the text"`
+}
+
+var logsFlag = LogsFlag{}
+
+var LogsCmd = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "logs --file <log file>",
+		Short:   "Stream the logs of a running emulator",
+		Example: "flow emulator logs --file emulator.log --follow --level warn --contains panic",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &logsFlag,
+	Run:   logs,
+}
+
+// logLevels are the emulator log levels ordered by severity.
+var logLevels = []string{"debug", "info", "warn", "error", "fatal", "panic"}
+
+// consoleLevels maps the level abbreviations of the emulator text log format to log levels.
+var consoleLevels = map[string]string{
+	"DBG": "debug",
+	"INF": "info",
+	"WRN": "warn",
+	"ERR": "error",
+	"FTL": "fatal",
+	"PNC": "panic",
+}
+
+var ansiColors = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// logPollInterval is the interval at which the log file is checked for new entries when following.
+const logPollInterval = 250 * time.Millisecond
+
+// logEntry is a single emulator log entry.
+type logEntry struct {
+	Time    string
+	Level   string
+	Message string
+	Fields  map[string]any
+	raw     string
+}
+
+func (e logEntry) JSON() any {
+	result := make(map[string]any)
+	for name, value := range e.Fields {
+		result[name] = value
+	}
+	result["time"] = e.Time
+	result["level"] = e.Level
+	result["message"] = e.Message
+
+	return result
+}
+
+func (e logEntry) String() string {
+	parts := []string{e.Time, strings.ToUpper(e.Level), e.Message}
+
+	names := make([]string, 0, len(e.Fields))
+	for name := range e.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		parts = append(parts, fmt.Sprintf("%s=%v", name, e.Fields[name]))
+	}
+
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+// parseLogLine parses a line of the emulator log in the JSON or text log format.
+func parseLogLine(line string) logEntry {
+	line = ansiColors.ReplaceAllString(line, "")
+	entry := logEntry{raw: line}
+
+	var fields map[string]any
+	if json.Unmarshal([]byte(line), &fields) == nil {
+		entry.Level, _ = fields["level"].(string)
+		entry.Time, _ = fields["time"].(string)
+		// the emulator uses msg as the message field name
+		for _, name := range []string{"message", "msg"} {
+			if message, ok := fields[name].(string); ok {
+				entry.Message = message
+				delete(fields, name)
+			}
+		}
+		delete(fields, "level")
+		delete(fields, "time")
+		entry.Fields = fields
+		return entry
+	}
+
+	// text format is: time level message key=value...
+	parts := strings.SplitN(line, " ", 3)
+	if len(parts) == 3 {
+		if level, ok := consoleLevels[parts[1]]; ok {
+			entry.Time = parts[0]
+			entry.Level = level
+			entry.Message = parts[2]
+			return entry
+		}
+	}
+
+	entry.Message = line
+	return entry
+}
+
+// logFilter selects the log entries to show.
+type logFilter struct {
+	level    int
+	contains string
+}
+
+func newLogFilter(level string, contains string) (logFilter, error) {
+	filter := logFilter{contains: contains}
+	if level == "" {
+		return filter, nil
+	}
+
+	filter.level = slices.Index(logLevels, strings.ToLower(level))
+	if filter.level == -1 {
+		return filter, fmt.Errorf("invalid log level %s, valid levels are: %s", level, strings.Join(logLevels, ", "))
+	}
+
+	return filter, nil
+}
+
+func (f logFilter) matches(entry logEntry) bool {
+	// entries without a known level are only filtered by the content
+	level := slices.Index(logLevels, entry.Level)
+	if level != -1 && level < f.level {
+		return false
+	}
+
+	return strings.Contains(entry.raw, f.contains)
+}
+
+// streamLogs reads the log entries and writes the ones matching the filter,
+// if follow is set it keeps waiting for new entries until the reader fails.
+func streamLogs(reader io.Reader, follow bool, filter logFilter, write func(logEntry)) error {
+	buffered := bufio.NewReader(reader)
+	var line string
+	for {
+		chunk, err := buffered.ReadString('\n')
+		line += chunk
+		if errors.Is(err, io.EOF) {
+			if !follow {
+				break
+			}
+			time.Sleep(logPollInterval)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		line = strings.TrimRight(line, "\r\n")
+		if line != "" {
+			entry := parseLogLine(line)
+			if filter.matches(entry) {
+				write(entry)
+			}
+		}
+		line = ""
+	}
+
+	if line != "" {
+		entry := parseLogLine(line)
+		if filter.matches(entry) {
+			write(entry)
+		}
+	}
+
+	return nil
+}
+
+func logs(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	if logsFlag.File == "" {
+		return nil, fmt.Errorf(
+			"the emulator admin API doesn't provide a log stream, redirect the emulator output to a file and provide it with the --file flag",
+		)
+	}
+
+	filter, err := newLogFilter(logsFlag.Level, logsFlag.Contains)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(logsFlag.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the emulator log file: %w", err)
+	}
+	defer file.Close()
+
+	out, err := command.OpenOutput(globalFlags)
+	if err != nil {
+		return nil, err
+	}
+	defer out.Close()
+
+	// entries are written as they are read, so they can be piped into other tools or saved while following
+	err = streamLogs(file, logsFlag.Follow, filter, func(entry logEntry) {
+		if globalFlags.Format == "json" {
+			encoded, _ := command.EncodeJSON(globalFlags, entry.JSON())
+			_, _ = fmt.Fprintln(out, string(encoded))
+			return
+		}
+		_, _ = fmt.Fprintln(out, entry.String())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the emulator log file: %w", err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,85 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseLogLine(t *testing.T) {
+	t.Run("JSON format", func(t *testing.T) {
+		entry := parseLogLine(`{"level":"warn","port":3569,"time":"2023-10-10T10:00:00Z","message":"slow block"}`)
+		assert.Equal(t, "warn", entry.Level)
+		assert.Equal(t, "slow block", entry.Message)
+		assert.Equal(t, "2023-10-10T10:00:00Z WARN slow block port=3569", entry.String())
+		assert.Equal(t, map[string]any{
+			"level":   "warn",
+			"message": "slow block",
+			"time":    "2023-10-10T10:00:00Z",
+			"port":    float64(3569),
+		}, entry.JSON())
+	})
+
+	t.Run("Text format", func(t *testing.T) {
+		entry := parseLogLine("\x1b[90m10:00AM\x1b[0m \x1b[32mINF\x1b[0m Starting gRPC server port=3569")
+		assert.Equal(t, "info", entry.Level)
+		assert.Equal(t, "10:00AM", entry.Time)
+		assert.Equal(t, "Starting gRPC server port=3569", entry.Message)
+	})
+
+	t.Run("Unknown format", func(t *testing.T) {
+		entry := parseLogLine("panic: runtime error")
+		assert.Equal(t, "", entry.Level)
+		assert.Equal(t, "panic: runtime error", entry.Message)
+	})
+}
+
+func Test_StreamLogs(t *testing.T) {
+	logs := strings.Join([]string{
+		`{"level":"debug","message":"executing script"}`,
+		`{"level":"info","msg":"block committed"}`,
+		`{"level":"error","message":"transaction failed: panic"}`,
+		`panic: runtime error`,
+		`{"level":"warn","message":"unknown account"}`,
+	}, "\n")
+
+	stream := func(level string, contains string) []string {
+		filter, err := newLogFilter(level, contains)
+		require.NoError(t, err)
+
+		var messages []string
+		err = streamLogs(strings.NewReader(logs), false, filter, func(entry logEntry) {
+			messages = append(messages, entry.Message)
+		})
+		require.NoError(t, err)
+		return messages
+	}
+
+	assert.Len(t, stream("", ""), 5)
+	assert.Equal(t, []string{"transaction failed: panic", "panic: runtime error", "unknown account"}, stream("warn", ""))
+	assert.Equal(t, []string{"transaction failed: panic", "panic: runtime error"}, stream("", "panic"))
+	assert.Equal(t, []string{"transaction failed: panic"}, stream("error", "failed"))
+
+	_, err := newLogFilter("trace", "")
+	assert.EqualError(t, err, "invalid log level trace, valid levels are: debug, info, warn, error, fatal, panic")
+}
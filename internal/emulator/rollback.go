@@ -0,0 +1,130 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type RollbackFlag struct {
+	Height    uint64 `default:"0" flag:"height" info:"Block height to rollback the emulator to"`
+	AdminHost string `default:"localhost:8080" flag:"admin-host" info:"Emulator admin API host address"`
+}
+
+var rollbackFlag = RollbackFlag{}
+
+var RollbackCmd = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "rollback --height <block height>",
+		Short:   "Rollback the emulator state to a previous block height",
+		Example: "flow emulator rollback --height 10",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &rollbackFlag,
+	Run:   rollback,
+}
+
+type rollbackResult struct {
+	BlockID string
+	Height  uint64
+}
+
+func (r *rollbackResult) JSON() any {
+	return map[string]any{
+		"blockID": r.BlockID,
+		"height":  r.Height,
+	}
+}
+
+func (r *rollbackResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	_, _ = fmt.Fprintf(writer, "Emulator rolled back\n")
+	_, _ = fmt.Fprintf(writer, "Block ID\t%s\n", r.BlockID)
+	_, _ = fmt.Fprintf(writer, "Height\t%d", r.Height)
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *rollbackResult) Oneliner() string {
+	return fmt.Sprintf("%s (%d)", r.BlockID, r.Height)
+}
+
+func requestRollback(height uint64) (*http.Request, error) {
+	form := url.Values{"height": {strconv.FormatUint(height, 10)}}
+	request, err := http.NewRequest("POST", adminEndpoint(rollbackFlag.AdminHost, "rollback"), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	return request, nil
+}
+
+func rollback(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	latest, err := flow.GetBlock(context.Background(), flowkit.LatestBlockQuery)
+	if err != nil {
+		return nil, err
+	}
+	if rollbackFlag.Height >= latest.Height {
+		return nil, fmt.Errorf(
+			"rollback height %d must be lower than the latest block height %d", rollbackFlag.Height, latest.Height,
+		)
+	}
+
+	logger.StartProgress(fmt.Sprintf("Rolling back the emulator to block height %d...", rollbackFlag.Height))
+	defer logger.StopProgress()
+
+	req, err := requestRollback(rollbackFlag.Height)
+	if err != nil {
+		return nil, err
+	}
+	err = makeRequest(req, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := flow.GetBlock(context.Background(), flowkit.LatestBlockQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rollbackResult{
+		BlockID: block.ID.String(),
+		Height:  block.Height,
+	}, nil
+}
@@ -0,0 +1,64 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Rollback(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	block := tests.NewBlock()
+	block.Height = 10
+	srv.GetBlock.Return(block, nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/emulator/rollback", r.URL.Path)
+		height, err := strconv.ParseUint(r.FormValue("height"), 10, 64)
+		require.NoError(t, err)
+		block.Height = height
+	}))
+	defer server.Close()
+	rollbackFlag.AdminHost = strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("Success", func(t *testing.T) {
+		rollbackFlag.Height = 5
+		result, err := rollback(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), result.(*rollbackResult).Height)
+	})
+
+	t.Run("Fail height not lower than latest", func(t *testing.T) {
+		rollbackFlag.Height = 5
+		_, err := rollback(nil, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "rollback height 5 must be lower than the latest block height 5")
+	})
+}
@@ -0,0 +1,351 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var SeedCmd = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "seed [seed file]",
+		Short:   "Seed the emulator with accounts, contracts and transactions from a seed file",
+		Example: "flow emulator seed seed.json",
+		Args:    cobra.MaximumNArgs(1),
+	},
+	Flags: &struct{}{},
+	RunS:  seed,
+}
+
+// seedFile declares the emulator state created by the seed.
+type seedFile struct {
+	// Accounts are created on the emulator unless they already exist and funded with the balance.
+	Accounts []seedAccount `json:"accounts"`
+	// Deploy all the contracts in the emulator deployments.
+	Deploy bool `json:"deploy"`
+	// Transactions are sent in order after the accounts are created and the contracts deployed.
+	Transactions []seedTransaction `json:"transactions"`
+}
+
+type seedAccount struct {
+	Name    string `json:"name"`
+	Balance string `json:"balance,omitempty"`
+}
+
+type seedTransaction struct {
+	Location string          `json:"location"`
+	Signer   string          `json:"signer"`
+	Args     json.RawMessage `json:"args,omitempty"`
+}
+
+const seedGasLimit = 9999
+
+// transferFlowTransaction funds the seeded accounts from the emulator service account which holds the token supply.
+const transferFlowTransaction = `
+import FungibleToken from 0xee82856bf20e2aa6
+import FlowToken from 0x0ae53cb6e3f42a79
+
+transaction(amount: UFix64, to: Address) {
+	let sentVault: @FungibleToken.Vault
+
+	prepare(signer: AuthAccount) {
+		let vault = signer.borrow<&FlowToken.Vault>(from: /storage/flowTokenVault)
+			?? panic("Could not borrow a reference to the service account vault")
+		self.sentVault <- vault.withdraw(amount: amount)
+	}
+
+	execute {
+		let receiver = getAccount(to).getCapability(/public/flowTokenReceiver)
+			.borrow<&{FungibleToken.Receiver}>()
+			?? panic("Could not borrow a reference to the receiver")
+		receiver.deposit(from: <-self.sentVault)
+	}
+}`
+
+type seedResult struct {
+	Accounts     []string
+	Contracts    []string
+	Transactions []string
+}
+
+func (r *seedResult) JSON() any {
+	return map[string]any{
+		"accounts":     r.Accounts,
+		"contracts":    r.Contracts,
+		"transactions": r.Transactions,
+	}
+}
+
+func (r *seedResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+	_, _ = fmt.Fprintf(writer, "Emulator seeded\n")
+	for _, account := range r.Accounts {
+		_, _ = fmt.Fprintf(writer, "Account\t%s\n", account)
+	}
+	for _, contract := range r.Contracts {
+		_, _ = fmt.Fprintf(writer, "Contract\t%s\n", contract)
+	}
+	for _, tx := range r.Transactions {
+		_, _ = fmt.Fprintf(writer, "Transaction\t%s\n", tx)
+	}
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *seedResult) Oneliner() string {
+	return fmt.Sprintf(
+		"accounts: %d, contracts: %d, transactions: %d", len(r.Accounts), len(r.Contracts), len(r.Transactions),
+	)
+}
+
+func seed(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if flow.Network().Name != config.EmulatorNetwork.Name {
+		return nil, fmt.Errorf("only the emulator network can be seeded")
+	}
+
+	location := ""
+	if len(args) > 0 {
+		location = args[0]
+	} else if emulator := state.Config().Emulators.Default(); emulator != nil {
+		location = emulator.Seed
+	}
+	if location == "" {
+		return nil, fmt.Errorf("provide the seed file argument or configure the emulator seed file")
+	}
+
+	logger.StartProgress(fmt.Sprintf("Seeding the emulator from %s...", location))
+	defer logger.StopProgress()
+
+	return applySeed(context.Background(), flow, state, location)
+}
+
+// seedOnStart seeds the emulator from the configured seed file once the emulator started on the port.
+func seedOnStart(state *flowkit.State, location string, port int) {
+	network := config.Network{Name: config.EmulatorNetwork.Name, Host: fmt.Sprintf("127.0.0.1:%d", port)}
+	gw, err := gateway.NewGrpcGateway(network)
+	if err != nil {
+		fmt.Printf("%s Failed to seed the emulator: %s\n", output.ErrorEmoji(), err.Error())
+		return
+	}
+
+	flow := flowkit.NewFlowkit(state, network, gw, output.NewStdoutLogger(output.NoneLog))
+	for start := time.Now(); flow.Ping() != nil; time.Sleep(500 * time.Millisecond) {
+		if time.Since(start) > time.Minute {
+			fmt.Printf("%s Failed to seed the emulator: the emulator didn't start\n", output.ErrorEmoji())
+			return
+		}
+	}
+
+	result, err := applySeed(context.Background(), flow, state, location)
+	if err != nil {
+		fmt.Printf("%s Failed to seed the emulator: %s\n", output.ErrorEmoji(), err.Error())
+		return
+	}
+	fmt.Printf("%s Emulator seeded from %s: %s\n", output.SuccessEmoji(), location, result.Oneliner())
+}
+
+// applySeed creates the seed accounts, deploys the contracts and sends the seed transactions.
+//
+// Accounts existing on the emulator are skipped, so the seed can be applied to a persisted emulator again.
+func applySeed(ctx context.Context, flow flowkit.Services, state *flowkit.State, location string) (*seedResult, error) {
+	content, err := state.ReadFile(location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the seed file: %w", err)
+	}
+
+	var seed seedFile
+	err = json.Unmarshal(content, &seed)
+	if err != nil {
+		return nil, fmt.Errorf("invalid seed file %s: %w", location, err)
+	}
+
+	service, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &seedResult{Accounts: []string{}, Contracts: []string{}, Transactions: []string{}}
+
+	created := false
+	for _, account := range seed.Accounts {
+		address, isNew, err := seedAccountOnEmulator(ctx, flow, state, service, account)
+		if err != nil {
+			return nil, fmt.Errorf("failed to seed account %s: %w", account.Name, err)
+		}
+		created = created || isNew
+		result.Accounts = append(result.Accounts, fmt.Sprintf("%s (0x%s)", account.Name, address))
+	}
+
+	// addresses of the created accounts are saved so the project uses them
+	if created {
+		err = state.SaveDefault()
+		if err != nil {
+			return nil, fmt.Errorf("failed to save the seeded accounts: %w", err)
+		}
+	}
+
+	if seed.Deploy {
+		contracts, err := flow.DeployProject(ctx, flowkit.UpdateExistingContract(true))
+		if err != nil {
+			return nil, fmt.Errorf("failed to deploy the seed contracts: %w", err)
+		}
+		for _, contract := range contracts {
+			result.Contracts = append(result.Contracts, fmt.Sprintf("%s (0x%s)", contract.Name, contract.AccountAddress))
+		}
+	}
+
+	for _, tx := range seed.Transactions {
+		id, err := sendSeedTransaction(ctx, flow, state, tx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send seed transaction %s: %w", tx.Location, err)
+		}
+		result.Transactions = append(result.Transactions, fmt.Sprintf("%s (%s)", tx.Location, id))
+	}
+
+	return result, nil
+}
+
+// seedAccountOnEmulator creates and funds the account unless it already exists on the emulator.
+//
+// Accounts configured in the project are created with their key, other accounts are added to the project
+// using the service account key.
+func seedAccountOnEmulator(
+	ctx context.Context,
+	flow flowkit.Services,
+	state *flowkit.State,
+	service *accounts.Account,
+	seed seedAccount,
+) (flowsdk.Address, bool, error) {
+	account, err := state.Accounts().ByName(seed.Name)
+	if err == nil {
+		if _, err := flow.GetAccount(ctx, account.Address); err == nil {
+			return account.Address, false, nil
+		}
+	} else {
+		account = &accounts.Account{Name: seed.Name, Key: service.Key}
+	}
+
+	privateKey, err := account.Key.PrivateKey()
+	if err != nil {
+		return flowsdk.EmptyAddress, false, fmt.Errorf("only accounts with private keys can be seeded: %w", err)
+	}
+
+	created, _, err := flow.CreateAccount(ctx, service, []accounts.PublicKey{{
+		Public:   (*privateKey).PublicKey(),
+		Weight:   flowsdk.AccountKeyWeightThreshold,
+		SigAlgo:  account.Key.SigAlgo(),
+		HashAlgo: account.Key.HashAlgo(),
+	}})
+	if err != nil {
+		return flowsdk.EmptyAddress, false, err
+	}
+
+	account.Address = created.Address
+	state.Accounts().AddOrUpdate(account)
+
+	if seed.Balance != "" {
+		amount, err := cadence.NewUFix64(seed.Balance)
+		if err != nil {
+			return flowsdk.EmptyAddress, false, fmt.Errorf("invalid balance %s: %w", seed.Balance, err)
+		}
+
+		_, txResult, err := flow.SendTransaction(
+			ctx,
+			transactions.SingleAccountRole(*service),
+			flowkit.Script{
+				Code: []byte(transferFlowTransaction),
+				Args: []cadence.Value{amount, cadence.NewAddress(created.Address)},
+			},
+			seedGasLimit,
+		)
+		if err != nil {
+			return flowsdk.EmptyAddress, false, err
+		}
+		if txResult.Error != nil {
+			return flowsdk.EmptyAddress, false, fmt.Errorf("failed to fund the account: %w", txResult.Error)
+		}
+	}
+
+	return created.Address, true, nil
+}
+
+func sendSeedTransaction(
+	ctx context.Context,
+	flow flowkit.Services,
+	state *flowkit.State,
+	tx seedTransaction,
+) (flowsdk.Identifier, error) {
+	code, err := state.ReadFile(tx.Location)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+
+	var args []cadence.Value
+	if len(tx.Args) > 0 {
+		args, err = arguments.ParseJSON(string(tx.Args))
+		if err != nil {
+			return flowsdk.EmptyID, err
+		}
+	}
+
+	signer, err := state.Accounts().ByName(tx.Signer)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+
+	sent, txResult, err := flow.SendTransaction(
+		ctx,
+		transactions.SingleAccountRole(*signer),
+		flowkit.Script{Code: code, Args: args, Location: tx.Location},
+		seedGasLimit,
+	)
+	if err != nil {
+		return flowsdk.EmptyID, err
+	}
+	if txResult.Error != nil {
+		return flowsdk.EmptyID, txResult.Error
+	}
+
+	return sent.ID(), nil
+}
@@ -0,0 +1,76 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Seed(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	require.NoError(t, rw.WriteFile("seed.json", []byte(`{
+		"accounts": [{ "name": "alice", "balance": "10.0" }],
+		"deploy": true,
+		"transactions": [{ "location": "setup.cdc", "signer": "alice", "args": [{ "type": "String", "value": "hello" }] }]
+	}`), 0644))
+	require.NoError(t, rw.WriteFile("setup.cdc", []byte(`transaction(greeting: String) {}`), 0644))
+
+	var scripts []flowkit.Script
+	srv.SendTransaction.Run(func(args mock.Arguments) {
+		scripts = append(scripts, args.Get(2).(flowkit.Script))
+	}).Return(tests.NewTransaction(), tests.NewTransactionResult(nil), nil)
+	srv.DeployProject.Return([]*project.Contract{{Name: "Hello", AccountAddress: tests.NewAccountWithAddress("0x02").Address}}, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := applySeed(context.Background(), srv.Mock, state, "seed.json")
+		require.NoError(t, err)
+
+		assert.Equal(t, "accounts: 1, contracts: 1, transactions: 1", result.Oneliner())
+		require.Len(t, scripts, 2)
+		assert.Equal(t, []cadence.Value{cadence.UFix64(10_00000000), cadence.NewAddress(tests.NewAccountWithAddress("0x01").Address)}, scripts[0].Args)
+		assert.Equal(t, []cadence.Value{cadence.String("hello")}, scripts[1].Args)
+
+		alice, err := state.Accounts().ByName("alice")
+		require.NoError(t, err)
+		assert.Equal(t, "0000000000000001", alice.Address.String())
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		_, err := applySeed(context.Background(), srv.Mock, state, "missing.json")
+		assert.ErrorContains(t, err, "failed to read the seed file")
+
+		srv.Network.Return(config.TestnetNetwork)
+		_, err = seed([]string{"seed.json"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "only the emulator network can be seeded")
+	})
+}
@@ -35,7 +35,9 @@ import (
 	"github.com/onflow/flow-cli/internal/util"
 )
 
-type SnapshotFlag struct{}
+type SnapshotFlag struct {
+	AdminHost string `default:"localhost:8080" flag:"admin-host" info:"Emulator admin API host address"`
+}
 
 var snapshotFlag = SnapshotFlag{}
 
@@ -110,16 +112,31 @@ func (r *snapShotResult) Oneliner() string {
 	return fmt.Sprintf("%s : %s (%d) %s", r.Name, r.BlockID, r.Height, r.Result)
 }
 
-const snapshotEndpoint = "http://localhost:8080/emulator/snapshots"
+// snapshotEndpoint returns the snapshots endpoint of the emulator admin API.
+func snapshotEndpoint() string {
+	return adminEndpoint(snapshotFlag.AdminHost, "snapshots")
+}
+
+// adminEndpoint returns the endpoint of the emulator admin API on the host.
+func adminEndpoint(host string, path string) string {
+	return fmt.Sprintf("http://%s/emulator/%s", host, path)
+}
 
+// makeRequest sends the request to the emulator admin API and decodes the response into v if provided.
 func makeRequest(r *http.Request, v any) error {
 	resp, err := http.DefaultClient.Do(r)
 	if err != nil {
-		return fmt.Errorf("emulator snapshot request error: %w", err)
+		return fmt.Errorf("emulator admin request error: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("emulator snapshot request error: status_code=%d", resp.StatusCode)
+	if resp.StatusCode == http.StatusMethodNotAllowed {
+		return fmt.Errorf("emulator admin request error: the emulator doesn't support %s requests, update the emulator to a newer version", r.Method)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("emulator admin request error: status_code=%d", resp.StatusCode)
+	}
+	if v == nil {
+		return nil
 	}
 
 	body, err := io.ReadAll(resp.Body)
@@ -136,7 +153,7 @@ func makeRequest(r *http.Request, v any) error {
 }
 
 func requestListSnapshots() (*http.Request, error) {
-	request, err := http.NewRequest("GET", snapshotEndpoint, nil)
+	request, err := http.NewRequest("GET", snapshotEndpoint(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -145,7 +162,7 @@ func requestListSnapshots() (*http.Request, error) {
 
 func requestCreateSnapshot(name string) (*http.Request, error) {
 	requestBody := bytes.NewBufferString(fmt.Sprintf("name=%s", name))
-	request, err := http.NewRequest("POST", snapshotEndpoint, requestBody)
+	request, err := http.NewRequest("POST", snapshotEndpoint(), requestBody)
 	request.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	if err != nil {
 		return nil, err
@@ -154,7 +171,7 @@ func requestCreateSnapshot(name string) (*http.Request, error) {
 }
 
 func requestLoadSnapshot(name string) (*http.Request, error) {
-	request, err := http.NewRequest("PUT", fmt.Sprintf("%s/%s", snapshotEndpoint, name), nil)
+	request, err := http.NewRequest("PUT", fmt.Sprintf("%s/%s", snapshotEndpoint(), name), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,60 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+func Test_Snapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`["first", "second"]`))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+	snapshotFlag.AdminHost = strings.TrimPrefix(server.URL, "http://")
+
+	t.Run("List snapshots", func(t *testing.T) {
+		result, err := snapshot([]string{"list"}, command.GlobalFlags{}, nil, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "first,second", result.Oneliner())
+	})
+
+	t.Run("Fail unsupported request", func(t *testing.T) {
+		_, err := snapshot([]string{"load", "first"}, command.GlobalFlags{}, nil, nil, nil)
+		assert.EqualError(t, err, "emulator admin request error: the emulator doesn't support PUT requests, update the emulator to a newer version")
+	})
+
+	t.Run("Fail invalid command", func(t *testing.T) {
+		_, err := snapshot([]string{"delete", "first"}, command.GlobalFlags{}, nil, nil, nil)
+		assert.EqualError(t, err, "invalid snapshot command: valid commands are: 'list', 'create', 'load'")
+	})
+}
@@ -22,13 +22,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"sync"
+	"strconv"
+	"strings"
 
 	"github.com/onflow/flow-emulator/cmd/emulator/start"
 	"github.com/onflow/flow-emulator/emulator"
 	"github.com/onflow/flow-go-sdk/crypto"
 	"github.com/spf13/afero"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/exp/slices"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/config"
@@ -50,7 +53,7 @@ func configuredServiceKey(
 	var state *flowkit.State
 	var err error
 	loader := &afero.Afero{Fs: afero.NewOsFs()}
-	command.UsageMetrics(Cmd, &sync.WaitGroup{})
+	command.TrackUsage(Cmd.CommandPath(), 0, command.ExitSuccess)
 
 	if init {
 		if sigAlgo == crypto.UnknownSignatureAlgorithm {
@@ -71,7 +74,7 @@ func configuredServiceKey(
 			}
 		}
 	} else {
-		state, err = flowkit.Load(command.Flags.ConfigPaths, loader)
+		state, err = flowkit.LoadWithVars(command.Flags.ConfigPaths, loader, command.Flags.Vars)
 		if err != nil {
 			if errors.Is(err, config.ErrDoesNotExist) {
 				exitf(1, "🙏 Configuration is missing, initialize it with: 'flow init' and then rerun this command.")
@@ -110,7 +113,121 @@ func init() {
 	Cmd.Use = "emulator"
 	Cmd.Short = "Run Flow network for development"
 	Cmd.GroupID = "tools"
+	Cmd.PreRun = applyEmulatorConfig
+	Cmd.Flags().String("fork", "", fmt.Sprintf(
+		"network to fork, the state is lazily fetched from the network. Valid values are: %s",
+		strings.Join(config.ForkNetworks, ", "),
+	))
+	Cmd.Flags().Uint64("fork-height", 0, "block height to fork the network at, defaults to the latest sealed height")
 	SnapshotCmd.AddToParent(Cmd)
+	RollbackCmd.AddToParent(Cmd)
+	LogsCmd.AddToParent(Cmd)
+	DataCmd.AddToParent(Cmd)
+	SeedCmd.AddToParent(Cmd)
+}
+
+// applyEmulatorConfig sets the emulator flags from the default emulator configuration,
+// flags explicitly provided on the command line take precedence over the configuration.
+func applyEmulatorConfig(cmd *cobra.Command, _ []string) {
+	var emulator *config.Emulator
+	state, err := flowkit.LoadWithVars(command.Flags.ConfigPaths, &afero.Afero{Fs: afero.NewOsFs()}, command.Flags.Vars)
+	// configuration errors are reported when obtaining the service key
+	if err == nil {
+		emulator = state.Config().Emulators.Default()
+	}
+
+	if emulator != nil {
+		for name, value := range emulatorFlagValues(*emulator) {
+			if cmd.Flags().Changed(name) {
+				continue
+			}
+
+			err := cmd.Flags().Set(name, value)
+			if err != nil {
+				exitf(1, "invalid emulator configuration value for flag %s: %s", name, err.Error())
+			}
+		}
+	}
+
+	err = applyFork(cmd.Flags())
+	if err != nil {
+		exitf(1, err.Error())
+	}
+
+	// the seed is applied in the background once the emulator is started
+	if emulator != nil && emulator.Seed != "" {
+		port, _ := cmd.Flags().GetInt("port")
+		go seedOnStart(state, emulator.Seed, port)
+	}
+}
+
+// applyFork translates the fork flags to the emulator chain ID and start block height flags.
+func applyFork(flags *pflag.FlagSet) error {
+	fork, _ := flags.GetString("fork")
+	height, _ := flags.GetUint64("fork-height")
+
+	if fork == "" {
+		if height != 0 {
+			return fmt.Errorf("fork height can only be used when forking a network with the --fork flag")
+		}
+		return nil
+	}
+
+	if !slices.Contains(config.ForkNetworks, fork) {
+		return fmt.Errorf(
+			"invalid fork network %s, valid networks are: %s", fork, strings.Join(config.ForkNetworks, ", "),
+		)
+	}
+
+	if flags.Changed("chain-id") {
+		chainID, _ := flags.GetString("chain-id")
+		if chainID != fork {
+			return fmt.Errorf("chain ID %s conflicts with the forked network %s", chainID, fork)
+		}
+	}
+
+	err := flags.Set("chain-id", fork)
+	if err != nil {
+		return err
+	}
+	if height != 0 {
+		return flags.Set("start-block-height", strconv.FormatUint(height, 10))
+	}
+
+	return nil
+}
+
+// emulatorFlagValues maps the emulator configuration to emulator command flag values.
+func emulatorFlagValues(emulator config.Emulator) map[string]string {
+	values := make(map[string]string)
+
+	if emulator.Port != 0 {
+		values["port"] = strconv.Itoa(emulator.Port)
+	}
+	if emulator.RestPort != 0 {
+		values["rest-port"] = strconv.Itoa(emulator.RestPort)
+	}
+	if emulator.BlockTime != 0 {
+		values["block-time"] = emulator.BlockTime.String()
+	}
+	if emulator.PersistDir != "" {
+		values["persist"] = "true"
+		values["dbpath"] = emulator.PersistDir
+	}
+	if emulator.SigAlgo != crypto.UnknownSignatureAlgorithm {
+		values["service-sig-algo"] = emulator.SigAlgo.String()
+	}
+	if emulator.HashAlgo != crypto.UnknownHashAlgorithm {
+		values["service-hash-algo"] = emulator.HashAlgo.String()
+	}
+	if emulator.Fork != "" {
+		values["fork"] = emulator.Fork
+	}
+	if emulator.ForkHeight != 0 {
+		values["fork-height"] = strconv.FormatUint(emulator.ForkHeight, 10)
+	}
+
+	return values
 }
 
 func exitf(code int, msg string, args ...any) {
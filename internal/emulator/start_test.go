@@ -0,0 +1,84 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package emulator
+
+import (
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_EmulatorFlagValues(t *testing.T) {
+	values := emulatorFlagValues(config.Emulator{
+		Port:       3570,
+		Fork:       "testnet",
+		ForkHeight: 100,
+	})
+
+	assert.Equal(t, map[string]string{
+		"port":        "3570",
+		"fork":        "testnet",
+		"fork-height": "100",
+	}, values)
+}
+
+func Test_ApplyFork(t *testing.T) {
+	newFlags := func(args ...string) *pflag.FlagSet {
+		flags := pflag.NewFlagSet("emulator", pflag.ContinueOnError)
+		flags.String("chain-id", "emulator", "")
+		flags.Uint64("start-block-height", 0, "")
+		flags.String("fork", "", "")
+		flags.Uint64("fork-height", 0, "")
+		require.NoError(t, flags.Parse(args))
+		return flags
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		flags := newFlags("--fork", "mainnet", "--fork-height", "100")
+		require.NoError(t, applyFork(flags))
+
+		chainID, _ := flags.GetString("chain-id")
+		height, _ := flags.GetUint64("start-block-height")
+		assert.Equal(t, "mainnet", chainID)
+		assert.Equal(t, uint64(100), height)
+	})
+
+	t.Run("No Fork", func(t *testing.T) {
+		flags := newFlags()
+		require.NoError(t, applyFork(flags))
+
+		chainID, _ := flags.GetString("chain-id")
+		assert.Equal(t, "emulator", chainID)
+	})
+
+	t.Run("Fail", func(t *testing.T) {
+		err := applyFork(newFlags("--fork", "previewnet"))
+		assert.EqualError(t, err, "invalid fork network previewnet, valid networks are: mainnet, testnet")
+
+		err = applyFork(newFlags("--fork-height", "100"))
+		assert.EqualError(t, err, "fork height can only be used when forking a network with the --fork flag")
+
+		err = applyFork(newFlags("--fork", "testnet", "--chain-id", "mainnet"))
+		assert.EqualError(t, err, "chain ID mainnet conflicts with the forked network testnet")
+	})
+}
@@ -41,6 +41,8 @@ var Cmd = &cobra.Command{
 
 func init() {
 	getCommand.AddToParent(Cmd)
+	forwardCommand.AddToParent(Cmd)
+	indexCommand.AddToParent(Cmd)
 }
 
 type EventResult struct {
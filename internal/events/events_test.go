@@ -19,14 +19,21 @@
 package events
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/tests"
@@ -72,6 +79,124 @@ func Test_Get(t *testing.T) {
 		assert.Nil(t, result)
 	})
 
+	t.Run("Fail follow with end", func(t *testing.T) {
+		inArgs := []string{"test.event"}
+		eventsFlags.Follow = true
+		eventsFlags.Start = 0
+		eventsFlags.End = 20
+
+		_, err := get(inArgs, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		assert.EqualError(t, err, "end flag cannot be combined with follow flag")
+		eventsFlags.Follow = false
+		eventsFlags.End = 0
+	})
+}
+
+func Test_FollowEvents(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+	block := tests.NewBlock()
+	event := tests.NewEvent(0, "A.1.Test.Event", []cadence.Field{{Type: cadence.StringType{}, Identifier: "bar"}}, []cadence.Value{cadence.NewInt(1)})
+	srv.GetBlock.Return(block, nil)
+	srv.GetEvents.Run(func(args mock.Arguments) {
+		assert.Equal(t, []string{"A.1.Test.Event"}, args.Get(1).([]string))
+		assert.Equal(t, block.Height-1, args.Get(2).(uint64))
+		assert.Equal(t, block.Height, args.Get(3).(uint64))
+	}).Return([]flow.BlockEvents{
+		{Height: block.Height - 1},
+		{Height: block.Height, Events: []flow.Event{*event}},
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var written []flow.BlockEvents
+	next := followEvents(ctx, srv.Mock, util.NoLogger, []string{"A.1.Test.Event"}, block.Height-1, func(events []flow.BlockEvents) {
+		written = append(written, events...)
+		cancel()
+	})
+
+	assert.Equal(t, block.Height+1, next)
+	assert.Equal(t, []flow.BlockEvents{{Height: block.Height, Events: []flow.Event{*event}}}, written)
+}
+
+func Test_FollowOutput(t *testing.T) {
+	event := tests.NewEvent(0, "A.1.Test.Event", []cadence.Field{{Type: cadence.StringType{}, Identifier: "bar"}}, []cadence.Value{cadence.String("baz")})
+	result := &EventResult{BlockEvents: []flow.BlockEvents{{Height: 1, Events: []flow.Event{*event}}}}
+
+	var csvOut bytes.Buffer
+	writer := command.NewStreamWriter(&csvOut, command.GlobalFlags{Format: "csv"})
+	require.NoError(t, writer.Write(result))
+	require.NoError(t, writer.Write(result))
+
+	lines := strings.Split(strings.TrimSpace(csvOut.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "blockHeight,"))
+	assert.True(t, strings.HasSuffix(lines[1], ",baz"))
+	assert.Equal(t, lines[1], lines[2])
+
+	var jsonlOut bytes.Buffer
+	writer = command.NewStreamWriter(&jsonlOut, command.GlobalFlags{Format: "jsonl"})
+	require.NoError(t, writer.Write(result))
+	require.NoError(t, writer.Write(result))
+
+	lines = strings.Split(strings.TrimSpace(jsonlOut.String()), "\n")
+	require.Len(t, lines, 2)
+	var row map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &row))
+	assert.Equal(t, "baz", row["values.bar"])
+}
+
+func Test_ResolveEventTypes(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress("0x01")
+		account.Contracts = map[string][]byte{
+			"FlowToken": []byte(`
+				pub contract FlowToken {
+					pub event TokensDeposited(amount: UFix64)
+					pub event TokensWithdrawn(amount: UFix64)
+					pub event TokensMinted(amount: UFix64)
+				}
+			`),
+		}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	t.Run("Success wildcard", func(t *testing.T) {
+		types, err := resolveEventTypes(context.Background(), srv.Mock, []string{"A.0000000000000001.FlowToken.*"}, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"A.0000000000000001.FlowToken.TokensDeposited",
+			"A.0000000000000001.FlowToken.TokensWithdrawn",
+			"A.0000000000000001.FlowToken.TokensMinted",
+		}, types)
+	})
+
+	t.Run("Success type filters", func(t *testing.T) {
+		types, err := resolveEventTypes(
+			context.Background(),
+			srv.Mock,
+			[]string{"A.0000000000000001.FlowToken.*", "flow.*"},
+			[]string{"Deposited", "*.TokensWithdrawn", "flow.AccountCreated"},
+		)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"A.0000000000000001.FlowToken.TokensDeposited",
+			"A.0000000000000001.FlowToken.TokensWithdrawn",
+			"flow.AccountCreated",
+		}, types)
+	})
+
+	t.Run("Fail no matches", func(t *testing.T) {
+		_, err := resolveEventTypes(context.Background(), srv.Mock, []string{"A.0000000000000001.FlowToken.*"}, []string{"Burned"})
+		assert.EqualError(t, err, "no event types matched the provided event names and type filters")
+	})
+
+	t.Run("Fail invalid pattern", func(t *testing.T) {
+		_, err := resolveEventTypes(context.Background(), srv.Mock, []string{"A.*.FlowToken.*"}, nil)
+		assert.ErrorContains(t, err, "invalid event type pattern A.*.FlowToken.*")
+	})
 }
 
 func Test_Result(t *testing.T) {
@@ -110,3 +235,171 @@ Events Block #1:
 		"values":        json.RawMessage{0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x7b, 0x22, 0x69, 0x64, 0x22, 0x3a, 0x22, 0x41, 0x2e, 0x66, 0x6f, 0x6f, 0x22, 0x2c, 0x22, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x3a, 0x5b, 0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x7b, 0x22, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3a, 0x22, 0x31, 0x22, 0x2c, 0x22, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3a, 0x22, 0x49, 0x6e, 0x74, 0x22, 0x7d, 0x2c, 0x22, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x3a, 0x22, 0x62, 0x61, 0x72, 0x22, 0x7d, 0x5d, 0x7d, 0x2c, 0x22, 0x74, 0x79, 0x70, 0x65, 0x22, 0x3a, 0x22, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x7d, 0xa},
 	}}, event.JSON())
 }
+
+func Test_ResultRows(t *testing.T) {
+	amountType := cadence.UFix64Type{}
+	vaultType := &cadence.StructType{
+		QualifiedIdentifier: "Vault",
+		Fields:              []cadence.Field{{Identifier: "id", Type: cadence.UInt64Type{}}},
+	}
+	amount, _ := cadence.NewUFix64("1.5")
+
+	event := EventResult{
+		BlockEvents: []flow.BlockEvents{{
+			Height: 10,
+			Events: []flow.Event{
+				*tests.NewEvent(
+					1,
+					"A.foo.Deposited",
+					[]cadence.Field{
+						{Identifier: "amount", Type: amountType},
+						{Identifier: "to", Type: &cadence.OptionalType{Type: cadence.AddressType{}}},
+						{Identifier: "vault", Type: vaultType},
+						{Identifier: "tags", Type: &cadence.VariableSizedArrayType{ElementType: cadence.StringType{}}},
+					},
+					[]cadence.Value{
+						amount,
+						cadence.NewOptional(nil),
+						cadence.NewStruct([]cadence.Value{cadence.NewUInt64(7)}).WithType(vaultType),
+						cadence.NewArray([]cadence.Value{cadence.String("a"), cadence.String("b")}),
+					},
+				),
+			},
+		}},
+	}
+
+	columns, rows := event.Rows()
+	assert.Equal(t, append(eventColumns, "values.amount", "values.to", "values.vault.id", "values.tags"), columns)
+	require.Len(t, rows, 1)
+	assert.Equal(t, uint64(10), rows[0]["blockHeight"])
+	assert.Equal(t, "A.foo.Deposited", rows[0]["type"])
+	assert.Equal(t, json.Number("1.50000000"), rows[0]["values.amount"])
+	assert.Nil(t, rows[0]["values.to"])
+	assert.Equal(t, json.Number("7"), rows[0]["values.vault.id"])
+	assert.Equal(t, []any{"a", "b"}, rows[0]["values.tags"])
+}
+
+func Test_Forward(t *testing.T) {
+	var received []map[string]any
+	failures := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		var payload map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		received = append(received, payload)
+	}))
+	defer server.Close()
+
+	var deliveryLog bytes.Buffer
+	f := &forwarder{
+		url:         server.URL,
+		client:      server.Client(),
+		retries:     2,
+		backoff:     time.Millisecond,
+		deliveryLog: &deliveryLog,
+		logger:      util.NoLogger,
+	}
+
+	t.Run("Success forward range in order", func(t *testing.T) {
+		received = nil
+		srv, _, _ := util.TestMocks(t)
+		srv.GetEvents.Return([]flow.BlockEvents{
+			{Height: 5, Events: []flow.Event{*tests.NewEvent(2, "A.foo.Bar", nil, nil)}},
+			{Height: 4, Events: []flow.Event{*tests.NewEvent(1, "A.foo.Bar", nil, nil)}},
+			{Height: 4, Events: []flow.Event{*tests.NewEvent(0, "A.foo.Baz", nil, nil)}},
+		}, nil)
+
+		err := f.forwardRange(context.Background(), srv.Mock, []string{"A.foo.Bar", "A.foo.Baz"}, 4, 5)
+		assert.NoError(t, err)
+		require.Len(t, received, 3)
+		assert.Equal(t, "A.foo.Baz", received[0]["type"])
+		assert.Equal(t, float64(4), received[1]["blockHeight"])
+		assert.Equal(t, float64(5), received[2]["blockHeight"])
+	})
+
+	t.Run("Success after retries", func(t *testing.T) {
+		failures = 2
+		deliveryLog.Reset()
+
+		result := f.deliver(context.Background(), eventPayload{Type: "A.foo.Bar", BlockHeight: 1})
+		assert.True(t, result.Delivered)
+		assert.Equal(t, 3, result.Attempts)
+
+		var logged delivery
+		assert.NoError(t, json.Unmarshal(deliveryLog.Bytes(), &logged))
+		assert.True(t, logged.Delivered)
+		assert.Equal(t, http.StatusOK, logged.StatusCode)
+	})
+
+	t.Run("Fail after retries", func(t *testing.T) {
+		failures = 3
+		deliveryLog.Reset()
+
+		result := f.deliver(context.Background(), eventPayload{Type: "A.foo.Bar", BlockHeight: 1})
+		assert.False(t, result.Delivered)
+		assert.Equal(t, 3, result.Attempts)
+		assert.Equal(t, http.StatusInternalServerError, result.StatusCode)
+		assert.Contains(t, deliveryLog.String(), `"error":"endpoint responded with status 500 Internal Server Error"`)
+	})
+}
+
+func Test_Index(t *testing.T) {
+	srv, _, _ := util.TestMocks(t)
+
+	idx, err := openEventIndex(filepath.Join(t.TempDir(), "events.sqlite"))
+	require.NoError(t, err)
+	defer idx.Close()
+
+	indexer := &eventIndexer{index: idx, flow: srv.Mock, logger: util.NoLogger, workers: 1}
+	eventType := "A.foo.Deposited"
+
+	t.Run("Success index from height", func(t *testing.T) {
+		amount, _ := cadence.NewUFix64("2.0")
+		srv.GetEvents.Run(func(args mock.Arguments) {
+			assert.Equal(t, uint64(100), args.Get(2).(uint64))
+			assert.Equal(t, uint64(120), args.Get(3).(uint64))
+		}).Return([]flow.BlockEvents{{
+			Height: 110,
+			Events: []flow.Event{*tests.NewEvent(
+				0,
+				eventType,
+				[]cadence.Field{{Identifier: "amount", Type: cadence.UFix64Type{}}},
+				[]cadence.Value{amount},
+			)},
+		}}, nil)
+
+		count, err := indexer.sync(context.Background(), []string{eventType}, 100, 120)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+
+		checkpoints, err := idx.checkpoints()
+		require.NoError(t, err)
+		assert.Equal(t, map[string]uint64{eventType: 120}, checkpoints)
+
+		var height uint64
+		var payload string
+		err = idx.db.QueryRow("SELECT block_height, payload FROM events WHERE type = ?", eventType).Scan(&height, &payload)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(110), height)
+		assert.JSONEq(t, `{"amount": 2.00000000}`, payload)
+	})
+
+	t.Run("Success continue from checkpoint", func(t *testing.T) {
+		srv.GetEvents.Run(func(args mock.Arguments) {
+			assert.Equal(t, uint64(121), args.Get(2).(uint64))
+			assert.Equal(t, uint64(130), args.Get(3).(uint64))
+		}).Return([]flow.BlockEvents{}, nil)
+
+		count, err := indexer.sync(context.Background(), []string{eventType}, 100, 130)
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+
+		checkpoints, err := idx.checkpoints()
+		require.NoError(t, err)
+		assert.Equal(t, uint64(130), checkpoints[eventType])
+	})
+}
@@ -0,0 +1,306 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsForward struct {
+	URL         string        `flag:"url" info:"HTTP endpoint each matching event is posted to"`
+	Type        []string      `flag:"type" info:"Event type to forward, supports wildcards and can be provided multiple times"`
+	Start       uint64        `flag:"start" info:"Block height to start forwarding from, defaults to the latest block"`
+	Interval    time.Duration `default:"2s" flag:"interval" info:"Interval at which the network is polled for new blocks"`
+	Retries     int           `default:"3" flag:"retries" info:"Number of times a failed delivery is retried"`
+	DeliveryLog string        `flag:"delivery-log" info:"File the result of each delivery is appended to as JSON lines"`
+}
+
+var forwardFlags = flagsForward{}
+
+var forwardCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "forward",
+		Short: "Forward events to an HTTP endpoint as they are emitted",
+		Args:  cobra.NoArgs,
+		Example: `#post every new deposit event to a webhook
+flow events forward --url https://example.com/hook --type A.1654653399040a61.FlowToken.TokensDeposited --network mainnet
+
+#forward all events of a contract starting at a block height and record deliveries
+flow events forward --url https://example.com/hook --type "A.1654653399040a61.FlowToken.*" --start 11559500 --delivery-log deliveries.jsonl`,
+	},
+	Flags: &forwardFlags,
+	Run:   forward,
+}
+
+// forwardBackoff is the delay before the first retry of a failed delivery, doubled on each following retry.
+const forwardBackoff = time.Second
+
+func forward(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	endpoint, err := url.Parse(forwardFlags.URL)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" {
+		return nil, fmt.Errorf("please provide a valid http or https url to forward events to")
+	}
+	if len(forwardFlags.Type) == 0 {
+		return nil, fmt.Errorf("please provide at least one event type to forward")
+	}
+
+	// stop forwarding when interrupted, so the delivery log is closed and the command exits cleanly
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	eventTypes, err := resolveEventTypes(ctx, flow, forwardFlags.Type, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	f := &forwarder{
+		url:     endpoint.String(),
+		client:  &http.Client{Timeout: 10 * time.Second},
+		retries: forwardFlags.Retries,
+		backoff: forwardBackoff,
+		logger:  logger,
+	}
+
+	if forwardFlags.DeliveryLog != "" {
+		file, err := os.OpenFile(forwardFlags.DeliveryLog, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open delivery log: %w", err)
+		}
+		defer file.Close()
+		f.deliveryLog = file
+	}
+
+	next := forwardFlags.Start
+	if next == 0 {
+		latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+		if err != nil {
+			return nil, err
+		}
+		next = latest.Height + 1
+	}
+
+	logger.Info(fmt.Sprintf("Forwarding %d event types to %s from block %d", len(eventTypes), f.url, next))
+
+	for {
+		latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Failed to get latest block: %s", err))
+		} else if latest.Height >= next {
+			if err := f.forwardRange(ctx, flow, eventTypes, next, latest.Height); err != nil {
+				logger.Error(fmt.Sprintf("Failed to get events in blocks %d-%d: %s", next, latest.Height, err))
+			} else {
+				next = latest.Height + 1
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			logger.Info("Stopped forwarding events")
+			return nil, nil
+		case <-time.After(forwardFlags.Interval):
+		}
+	}
+}
+
+// forwarder posts events to an HTTP endpoint, retrying failed deliveries and recording each delivery.
+type forwarder struct {
+	url         string
+	client      *http.Client
+	retries     int
+	backoff     time.Duration
+	deliveryLog io.Writer
+	logger      output.Logger
+}
+
+// eventPayload is the decoded event posted to the endpoint.
+type eventPayload struct {
+	Type             string    `json:"type"`
+	BlockHeight      uint64    `json:"blockHeight"`
+	BlockID          string    `json:"blockID"`
+	BlockTimestamp   time.Time `json:"blockTimestamp"`
+	TransactionID    string    `json:"transactionId"`
+	TransactionIndex int       `json:"transactionIndex"`
+	EventIndex       int       `json:"eventIndex"`
+	Values           any       `json:"values"`
+}
+
+// delivery is the record of a single event delivery written to the delivery log.
+type delivery struct {
+	Time          time.Time `json:"time"`
+	Type          string    `json:"type"`
+	BlockHeight   uint64    `json:"blockHeight"`
+	TransactionID string    `json:"transactionId"`
+	EventIndex    int       `json:"eventIndex"`
+	Delivered     bool      `json:"delivered"`
+	Attempts      int       `json:"attempts"`
+	StatusCode    int       `json:"statusCode,omitempty"`
+	Error         string    `json:"error,omitempty"`
+}
+
+// forwardRange fetches the events in the inclusive block range and delivers them in the order they were emitted.
+func (f *forwarder) forwardRange(
+	ctx context.Context,
+	flow flowkit.Services,
+	eventTypes []string,
+	start uint64,
+	end uint64,
+) error {
+	blockEvents, err := flow.GetEvents(ctx, eventTypes, start, end, nil)
+	if err != nil {
+		return err
+	}
+
+	payloads := make([]eventPayload, 0)
+	for _, block := range blockEvents {
+		for _, event := range block.Events {
+			payloads = append(payloads, newEventPayload(block, event))
+		}
+	}
+
+	sort.SliceStable(payloads, func(i, j int) bool {
+		a, b := payloads[i], payloads[j]
+		if a.BlockHeight != b.BlockHeight {
+			return a.BlockHeight < b.BlockHeight
+		}
+		if a.TransactionIndex != b.TransactionIndex {
+			return a.TransactionIndex < b.TransactionIndex
+		}
+		return a.EventIndex < b.EventIndex
+	})
+
+	for _, payload := range payloads {
+		f.deliver(ctx, payload)
+	}
+
+	return nil
+}
+
+func newEventPayload(block flowsdk.BlockEvents, event flowsdk.Event) eventPayload {
+	return eventPayload{
+		Type:             event.Type,
+		BlockHeight:      block.Height,
+		BlockID:          block.BlockID.String(),
+		BlockTimestamp:   block.BlockTimestamp.UTC(),
+		TransactionID:    event.TransactionID.String(),
+		TransactionIndex: event.TransactionIndex,
+		EventIndex:       event.EventIndex,
+		Values:           decodeValue(event.Value),
+	}
+}
+
+// deliver posts the event to the endpoint, retrying with a growing backoff until it succeeds or the retries run out.
+func (f *forwarder) deliver(ctx context.Context, payload eventPayload) delivery {
+	result := delivery{
+		Type:          payload.Type,
+		BlockHeight:   payload.BlockHeight,
+		TransactionID: payload.TransactionID,
+		EventIndex:    payload.EventIndex,
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		result.Error = err.Error()
+		f.record(result)
+		return result
+	}
+
+	backoff := f.backoff
+	for attempt := 1; attempt <= f.retries+1; attempt++ {
+		result.Attempts = attempt
+		result.StatusCode, err = f.post(ctx, body)
+		if err == nil {
+			result.Delivered = true
+			result.Error = ""
+			break
+		}
+		result.Error = err.Error()
+
+		if attempt <= f.retries {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+
+	f.record(result)
+	return result
+}
+
+func (f *forwarder) post(ctx context.Context, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("endpoint responded with status %s", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
+
+// record logs the delivery and appends it to the delivery log if one is used.
+func (f *forwarder) record(result delivery) {
+	result.Time = time.Now().UTC()
+
+	if result.Delivered {
+		f.logger.Info(fmt.Sprintf("Delivered %s from block %d in transaction %s", result.Type, result.BlockHeight, result.TransactionID))
+	} else {
+		f.logger.Error(fmt.Sprintf(
+			"Failed delivering %s from block %d in transaction %s after %d attempts: %s",
+			result.Type, result.BlockHeight, result.TransactionID, result.Attempts, result.Error,
+		))
+	}
+
+	if f.deliveryLog != nil {
+		line, _ := json.Marshal(result)
+		_, _ = f.deliveryLog.Write(append(line, '\n'))
+	}
+}
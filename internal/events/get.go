@@ -21,7 +21,12 @@ package events
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
@@ -30,11 +35,14 @@ import (
 )
 
 type flagsEvents struct {
-	Start   uint64 `flag:"start" info:"Start block height"`
-	End     uint64 `flag:"end" info:"End block height"`
-	Last    uint64 `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
-	Workers int    `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
-	Batch   uint64 `default:"25" flag:"batch" info:"Number of blocks each worker will fetch"`
+	Start    uint64        `flag:"start" info:"Start block height"`
+	End      uint64        `flag:"end" info:"End block height"`
+	Last     uint64        `default:"10" flag:"last" info:"Fetch number of blocks relative to the last block. Ignored if the start flag is set. Used as a default if no flags are provided"`
+	Workers  int           `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+	Batch    uint64        `default:"250" flag:"batch" info:"Number of blocks each worker will fetch, limited to the access node maximum of 250"`
+	Type     []string      `default:"" flag:"type" info:"Only fetch event types containing or matching the value, can be provided multiple times"`
+	Follow   bool          `default:"false" flag:"follow" info:"Keep printing matching events from new sealed blocks, starting after the latest block or at the start flag"`
+	Interval time.Duration `default:"2s" flag:"interval" info:"Interval at which new blocks are polled when following"`
 }
 
 var eventsFlags = flagsEvents{}
@@ -55,6 +63,18 @@ flow events get A.1654653399040a61.FlowToken.TokensDeposited --last 20 --network
 
 #if you want to fetch multiple event types that is done by sending in more events. Even fetching will be done in parallel.
 flow events get A.1654653399040a61.FlowToken.TokensDeposited A.1654653399040a61.FlowToken.TokensWithdrawn
+
+#wildcards fetch all matching events declared by the contract, optionally narrowed by the type flags
+flow events get "A.1654653399040a61.FlowToken.*" --type TokensDeposited --type TokensWithdrawn --network mainnet
+
+#save events with decoded fields as columns for spreadsheets or data analysis, use jsonl for one JSON object per line
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --last 100 --output csv --save events.csv
+
+#large block ranges are split into requests of at most 250 blocks and fetched concurrently
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --start 11000000 --end 11500000 --workers 20 --network mainnet
+
+#print new events as blocks are sealed, interrupting prints the height to resume from with the start flag
+flow events get A.1654653399040a61.FlowToken.TokensDeposited --follow --network mainnet
 	`,
 	},
 	Flags: &eventsFlags,
@@ -63,11 +83,15 @@ flow events get A.1654653399040a61.FlowToken.TokensDeposited A.1654653399040a61.
 
 func get(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
 	_ flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
+	if eventsFlags.Follow {
+		return nil, follow(args, globalFlags, logger, flow)
+	}
+
 	var err error
 	start := eventsFlags.Start
 	end := eventsFlags.End
@@ -95,14 +119,20 @@ func get(
 	logger.StartProgress("Fetching events...")
 	defer logger.StopProgress()
 
+	eventTypes, err := resolveEventTypes(context.Background(), flow, args, eventsFlags.Type)
+	if err != nil {
+		return nil, err
+	}
+
 	events, err := flow.GetEvents(
 		context.Background(),
-		args,
+		eventTypes,
 		start,
 		end,
 		&flowkit.EventWorker{
 			Count:           eventsFlags.Workers,
 			BlocksPerWorker: eventsFlags.Batch,
+			Progress:        output.LoggerProgress(logger, "Fetching events"),
 		},
 	)
 	if err != nil {
@@ -111,3 +141,103 @@ func get(
 
 	return &EventResult{BlockEvents: events}, nil
 }
+
+// follow prints the matching events of every new sealed block until interrupted.
+//
+// Events are written as they are fetched in the same output format as a single fetch,
+// so the output can be piped into other tools or saved while following.
+func follow(args []string, globalFlags command.GlobalFlags, logger output.Logger, flow flowkit.Services) error {
+	if eventsFlags.End != 0 {
+		return fmt.Errorf("end flag cannot be combined with follow flag")
+	}
+
+	out, err := command.OpenOutput(globalFlags)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	eventTypes, err := resolveEventTypes(ctx, flow, args, eventsFlags.Type)
+	if err != nil {
+		return err
+	}
+
+	next := eventsFlags.Start
+	if next == 0 {
+		latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+		if err != nil {
+			return err
+		}
+		next = latest.Height + 1
+	}
+
+	writer := command.NewStreamWriter(out, globalFlags)
+	next = followEvents(ctx, flow, logger, eventTypes, next, func(blockEvents []flowsdk.BlockEvents) {
+		if err := writer.Write(&EventResult{BlockEvents: blockEvents}); err != nil {
+			logger.Error(fmt.Sprintf("Failed to write events: %s", err))
+		}
+	})
+
+	logger.Info(fmt.Sprintf("Stopped following events, resume with --start %d", next))
+	return nil
+}
+
+// followEvents polls the network for new sealed blocks and writes the events from the next height onwards
+// until the context is done. Failed requests are retried on the next poll, so following survives
+// lost connections, and the next height to fetch is returned.
+//
+// Only blocks containing events are written, so polls without new events produce no output.
+func followEvents(
+	ctx context.Context,
+	flow flowkit.Services,
+	logger output.Logger,
+	eventTypes []string,
+	next uint64,
+	write func([]flowsdk.BlockEvents),
+) uint64 {
+	logger.Info(fmt.Sprintf("Following %d event types from block %d", len(eventTypes), next))
+
+	for {
+		latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.Error(fmt.Sprintf("Failed to get latest block, retrying: %s", err))
+			}
+		} else if latest.Height >= next {
+			events, err := flow.GetEvents(ctx, eventTypes, next, latest.Height, &flowkit.EventWorker{
+				Count:           eventsFlags.Workers,
+				BlocksPerWorker: eventsFlags.Batch,
+			})
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Error(fmt.Sprintf("Failed to get events in blocks %d-%d, retrying: %s", next, latest.Height, err))
+				}
+			} else {
+				if withEvents := blocksWithEvents(events); len(withEvents) > 0 {
+					write(withEvents)
+				}
+				next = latest.Height + 1
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return next
+		case <-time.After(eventsFlags.Interval):
+		}
+	}
+}
+
+// blocksWithEvents returns the block events containing at least one event.
+func blocksWithEvents(blockEvents []flowsdk.BlockEvents) []flowsdk.BlockEvents {
+	withEvents := make([]flowsdk.BlockEvents, 0, len(blockEvents))
+	for _, block := range blockEvents {
+		if len(block.Events) > 0 {
+			withEvents = append(withEvents, block)
+		}
+	}
+	return withEvents
+}
@@ -0,0 +1,351 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	_ "github.com/glebarez/go-sqlite"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsIndex struct {
+	DB       string        `default:"events.sqlite" flag:"db" info:"SQLite database file the events are indexed into"`
+	Type     []string      `flag:"type" info:"Event type to index, supports wildcards and can be provided multiple times"`
+	From     uint64        `flag:"from" info:"Block height to start indexing from for event types without a checkpoint, defaults to the latest block"`
+	Follow   bool          `default:"false" flag:"follow" info:"Keep indexing new blocks as they are produced"`
+	Interval time.Duration `default:"2s" flag:"interval" info:"Interval at which the network is polled for new blocks when following"`
+	Workers  int           `default:"10" flag:"workers" info:"Number of workers to use when fetching events in parallel"`
+}
+
+var indexFlags = flagsIndex{}
+
+var indexCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "index",
+		Short: "Index events into a local SQLite database",
+		Args:  cobra.NoArgs,
+		Example: `#index deposit events from a block height, running again continues from the last indexed height
+flow events index --db events.sqlite --type A.1654653399040a61.FlowToken.TokensDeposited --from 1000000 --network mainnet
+
+#keep the index in sync with new blocks
+flow events index --db events.sqlite --type "A.1654653399040a61.FlowToken.*" --follow --network mainnet
+
+#query the index, event values are stored as JSON in the payload column
+sqlite3 events.sqlite "SELECT block_height, json_extract(payload, '$.amount') FROM events WHERE type LIKE '%TokensDeposited'"`,
+	},
+	Flags: &indexFlags,
+	Run:   index,
+}
+
+// indexChunkBlocks is the number of blocks fetched and stored in a single database transaction.
+const indexChunkBlocks = 10 * flowkit.MaxEventBlockRange
+
+func index(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	if len(indexFlags.Type) == 0 {
+		return nil, fmt.Errorf("please provide at least one event type to index")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	eventTypes, err := resolveEventTypes(ctx, flow, indexFlags.Type, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	idx, err := openEventIndex(indexFlags.DB)
+	if err != nil {
+		return nil, err
+	}
+	defer idx.Close()
+
+	indexer := &eventIndexer{
+		index:   idx,
+		flow:    flow,
+		logger:  logger,
+		workers: indexFlags.Workers,
+	}
+
+	result := &indexResult{db: indexFlags.DB, types: eventTypes}
+	for {
+		latest, err := flow.GetBlock(ctx, flowkit.LatestBlockQuery)
+		if err != nil {
+			return nil, err
+		}
+
+		from := indexFlags.From
+		if from == 0 {
+			from = latest.Height
+		}
+
+		count, err := indexer.sync(ctx, eventTypes, from, latest.Height)
+		result.events += count
+		if err != nil {
+			return nil, err
+		}
+		result.height = latest.Height
+
+		if !indexFlags.Follow {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, nil
+		case <-time.After(indexFlags.Interval):
+		}
+	}
+}
+
+// eventIndexer syncs events from the network into the event index.
+type eventIndexer struct {
+	index   *eventIndex
+	flow    flowkit.Services
+	logger  output.Logger
+	workers int
+}
+
+// sync indexes the events up to the end height, continuing each event type from its checkpoint
+// or from the provided height if the event type was not indexed before. It returns the number of indexed events.
+func (i *eventIndexer) sync(ctx context.Context, eventTypes []string, from uint64, end uint64) (int, error) {
+	checkpoints, err := i.index.checkpoints()
+	if err != nil {
+		return 0, err
+	}
+
+	// group event types by their next height, so types indexed together are fetched together
+	groups := make(map[uint64][]string)
+	for _, eventType := range eventTypes {
+		next := from
+		if height, ok := checkpoints[eventType]; ok {
+			next = height + 1
+		}
+		groups[next] = append(groups[next], eventType)
+	}
+
+	heights := maps.Keys(groups)
+	slices.Sort(heights)
+
+	count := 0
+	for _, start := range heights {
+		types := groups[start]
+		for start <= end {
+			if err := ctx.Err(); err != nil {
+				return count, nil
+			}
+
+			chunkEnd := start + indexChunkBlocks - 1
+			if chunkEnd > end {
+				chunkEnd = end
+			}
+
+			i.logger.StartProgress(fmt.Sprintf("Indexing events in blocks %d-%d of %d...", start, chunkEnd, end))
+			blockEvents, err := i.flow.GetEvents(ctx, types, start, chunkEnd, &flowkit.EventWorker{
+				Count:           i.workers,
+				BlocksPerWorker: flowkit.MaxEventBlockRange,
+			})
+			i.logger.StopProgress()
+			if err != nil {
+				if ctx.Err() != nil {
+					return count, nil
+				}
+				return count, err
+			}
+
+			stored, err := i.index.store(blockEvents, types, chunkEnd)
+			if err != nil {
+				return count, err
+			}
+			count += stored
+
+			start = chunkEnd + 1
+		}
+	}
+
+	return count, nil
+}
+
+// eventIndex is a SQLite database of events with the last indexed height of each event type.
+type eventIndex struct {
+	db *sql.DB
+}
+
+const eventIndexSchema = `
+CREATE TABLE IF NOT EXISTS events (
+	block_height INTEGER NOT NULL,
+	block_id TEXT NOT NULL,
+	block_timestamp TEXT NOT NULL,
+	transaction_id TEXT NOT NULL,
+	transaction_index INTEGER NOT NULL,
+	event_index INTEGER NOT NULL,
+	type TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	PRIMARY KEY (transaction_id, event_index)
+);
+CREATE INDEX IF NOT EXISTS events_type_height ON events (type, block_height);
+CREATE TABLE IF NOT EXISTS checkpoints (
+	type TEXT PRIMARY KEY,
+	height INTEGER NOT NULL
+);`
+
+func openEventIndex(path string) (*eventIndex, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event index: %w", err)
+	}
+
+	if _, err := db.Exec(eventIndexSchema); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to create event index: %w", err)
+	}
+
+	return &eventIndex{db: db}, nil
+}
+
+func (e *eventIndex) Close() error {
+	return e.db.Close()
+}
+
+// checkpoints returns the last indexed height by event type.
+func (e *eventIndex) checkpoints() (map[string]uint64, error) {
+	rows, err := e.db.Query("SELECT type, height FROM checkpoints")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	checkpoints := make(map[string]uint64)
+	for rows.Next() {
+		var eventType string
+		var height uint64
+		if err := rows.Scan(&eventType, &height); err != nil {
+			return nil, err
+		}
+		checkpoints[eventType] = height
+	}
+
+	return checkpoints, rows.Err()
+}
+
+// store saves the events and moves the checkpoint of the event types to the height in a single transaction.
+func (e *eventIndex) store(blockEvents []flowsdk.BlockEvents, eventTypes []string, height uint64) (int, error) {
+	tx, err := e.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	count := 0
+	for _, block := range blockEvents {
+		for _, event := range block.Events {
+			payload, err := json.Marshal(decodeValue(event.Value))
+			if err != nil {
+				return 0, err
+			}
+
+			_, err = tx.Exec(
+				`INSERT OR IGNORE INTO events 
+				(block_height, block_id, block_timestamp, transaction_id, transaction_index, event_index, type, payload) 
+				VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+				block.Height,
+				block.BlockID.String(),
+				block.BlockTimestamp.UTC().Format(time.RFC3339Nano),
+				event.TransactionID.String(),
+				event.TransactionIndex,
+				event.EventIndex,
+				event.Type,
+				string(payload),
+			)
+			if err != nil {
+				return 0, err
+			}
+			count++
+		}
+	}
+
+	for _, eventType := range eventTypes {
+		_, err := tx.Exec(
+			"INSERT INTO checkpoints (type, height) VALUES (?, ?) ON CONFLICT(type) DO UPDATE SET height = excluded.height",
+			eventType,
+			height,
+		)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return count, tx.Commit()
+}
+
+type indexResult struct {
+	db     string
+	types  []string
+	events int
+	height uint64
+}
+
+func (r *indexResult) JSON() any {
+	return map[string]any{
+		"db":     r.db,
+		"types":  r.types,
+		"events": r.events,
+		"height": r.height,
+	}
+}
+
+func (r *indexResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Database\t%s\n", r.db)
+	_, _ = fmt.Fprintf(writer, "Indexed Height\t%d\n", r.height)
+	_, _ = fmt.Fprintf(writer, "Indexed Events\t%d\n", r.events)
+	_, _ = fmt.Fprintf(writer, "Event Types\t%s\n", strings.Join(r.types, ", "))
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *indexResult) Oneliner() string {
+	return fmt.Sprintf("Indexed %d events up to height %d into %s", r.events, r.height, r.db)
+}
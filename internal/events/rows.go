@@ -0,0 +1,162 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+)
+
+// eventColumns are the columns present for every event row, decoded event fields are appended after them.
+var eventColumns = []string{
+	"blockHeight",
+	"blockID",
+	"blockTimestamp",
+	"transactionId",
+	"transactionIndex",
+	"eventIndex",
+	"type",
+}
+
+// Rows returns a row for each event with the decoded event fields flattened into "values." prefixed columns.
+func (e *EventResult) Rows() ([]string, []map[string]any) {
+	columns := append([]string{}, eventColumns...)
+	known := make(map[string]bool)
+	for _, c := range columns {
+		known[c] = true
+	}
+
+	rows := make([]map[string]any, 0)
+	addRow := func(block *flow.BlockEvents, event flow.Event) {
+		row := map[string]any{
+			"transactionId":    event.TransactionID.String(),
+			"transactionIndex": event.TransactionIndex,
+			"eventIndex":       event.EventIndex,
+			"type":             event.Type,
+		}
+		if block != nil {
+			row["blockHeight"] = block.Height
+			row["blockID"] = block.BlockID.String()
+			row["blockTimestamp"] = block.BlockTimestamp.UTC().Format(time.RFC3339Nano)
+		}
+
+		values := make(map[string]any)
+		var valueColumns []string
+		flattenValue("values", event.Value, values, &valueColumns)
+		for _, c := range valueColumns {
+			row[c] = values[c]
+			if !known[c] {
+				known[c] = true
+				columns = append(columns, c)
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	for i := range e.BlockEvents {
+		for _, event := range e.BlockEvents[i].Events {
+			addRow(&e.BlockEvents[i], event)
+		}
+	}
+	for _, event := range e.Events {
+		addRow(nil, event)
+	}
+
+	return columns, rows
+}
+
+// flattenValue decodes the cadence value into the row, composite fields are flattened into
+// separate columns named by their path, while arrays and dictionaries are kept as decoded values.
+func flattenValue(column string, value cadence.Value, row map[string]any, columns *[]string) {
+	if optional, ok := value.(cadence.Optional); ok && optional.Value != nil {
+		flattenValue(column, optional.Value, row, columns)
+		return
+	}
+
+	if composite, ok := value.(cadence.HasFields); ok {
+		values := composite.GetFieldValues()
+		for i, field := range composite.GetFields() {
+			if i < len(values) {
+				flattenValue(column+"."+field.Identifier, values[i], row, columns)
+			}
+		}
+		return
+	}
+
+	*columns = append(*columns, column)
+	row[column] = decodeValue(value)
+}
+
+// decodeValue converts the cadence value into a plain value which can be encoded as JSON.
+func decodeValue(value cadence.Value) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case cadence.Optional:
+		return decodeValue(v.Value)
+	case cadence.Void:
+		return nil
+	case cadence.Bool:
+		return bool(v)
+	case cadence.String:
+		return string(v)
+	case cadence.Character:
+		return string(v)
+	case cadence.Address:
+		return v.String()
+	case cadence.NumberValue:
+		return json.Number(v.String())
+	case cadence.TypeValue:
+		if v.StaticType == nil {
+			return ""
+		}
+		return v.StaticType.ID()
+	case cadence.Array:
+		values := make([]any, len(v.Values))
+		for i, element := range v.Values {
+			values[i] = decodeValue(element)
+		}
+		return values
+	case cadence.Dictionary:
+		values := make(map[string]any, len(v.Pairs))
+		for _, pair := range v.Pairs {
+			key, ok := decodeValue(pair.Key).(string)
+			if !ok {
+				key = pair.Key.String()
+			}
+			values[key] = decodeValue(pair.Value)
+		}
+		return values
+	case cadence.HasFields:
+		values := make(map[string]any)
+		fieldValues := v.GetFieldValues()
+		for i, field := range v.GetFields() {
+			if i < len(fieldValues) {
+				values[field.Identifier] = decodeValue(fieldValues[i])
+			}
+		}
+		return values
+	default:
+		return value.String()
+	}
+}
@@ -0,0 +1,145 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"golang.org/x/exp/slices"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/project"
+)
+
+// coreEvents are the events emitted by the protocol itself, which can't be resolved from contract code.
+var coreEvents = []string{
+	flowsdk.EventAccountCreated,
+	flowsdk.EventAccountKeyAdded,
+	flowsdk.EventAccountKeyRemoved,
+	flowsdk.EventAccountContractAdded,
+	flowsdk.EventAccountContractUpdated,
+	flowsdk.EventAccountContractRemoved,
+}
+
+// resolveEventTypes expands event names containing wildcards into the event types declared
+// on the network and keeps only the types matching any of the provided type filters.
+//
+// Wildcards follow the path match syntax, for example "A.1654653399040a61.FlowToken.*" or "flow.*".
+// Type filters match when they are contained in the event type, or match it as a wildcard pattern.
+func resolveEventTypes(
+	ctx context.Context,
+	flow flowkit.Services,
+	names []string,
+	filters []string,
+) ([]string, error) {
+	contracts := make(map[string]map[string][]byte) // account contracts cached by address
+	types := make([]string, 0)
+
+	add := func(eventType string) {
+		if !slices.Contains(types, eventType) && matchesTypeFilters(eventType, filters) {
+			types = append(types, eventType)
+		}
+	}
+
+	for _, name := range names {
+		if !strings.Contains(name, "*") {
+			add(name)
+			continue
+		}
+
+		if _, err := path.Match(name, ""); err != nil {
+			return nil, fmt.Errorf("invalid event type pattern %s: %w", name, err)
+		}
+
+		if strings.HasPrefix(name, "flow.") {
+			for _, eventType := range coreEvents {
+				if ok, _ := path.Match(name, eventType); ok {
+					add(eventType)
+				}
+			}
+			continue
+		}
+
+		parts := strings.Split(name, ".")
+		if len(parts) != 4 || parts[0] != "A" || strings.Contains(parts[1], "*") {
+			return nil, fmt.Errorf("invalid event type pattern %s, must be in the form A.<address>.<contract>.<event> with wildcards only in the contract and event name", name)
+		}
+
+		address := flowsdk.HexToAddress(parts[1])
+		accountContracts, ok := contracts[address.String()]
+		if !ok {
+			account, err := flow.GetAccount(ctx, address)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get contracts for event type pattern %s: %w", name, err)
+			}
+			accountContracts = account.Contracts
+			contracts[address.String()] = accountContracts
+		}
+
+		contractNames := make([]string, 0, len(accountContracts))
+		for contractName := range accountContracts {
+			contractNames = append(contractNames, contractName)
+		}
+		slices.Sort(contractNames)
+
+		for _, contractName := range contractNames {
+			if ok, _ := path.Match(parts[2], contractName); !ok {
+				continue
+			}
+
+			program, err := project.NewProgram(accountContracts[contractName], nil, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse contract %s: %w", contractName, err)
+			}
+
+			for _, event := range program.Events() {
+				if ok, _ := path.Match(parts[3], event); ok {
+					add(fmt.Sprintf("A.%s.%s.%s", address.String(), contractName, event))
+				}
+			}
+		}
+	}
+
+	if len(types) == 0 {
+		return nil, fmt.Errorf("no event types matched the provided event names and type filters")
+	}
+
+	return types, nil
+}
+
+func matchesTypeFilters(eventType string, filters []string) bool {
+	if len(filters) == 0 {
+		return true
+	}
+
+	for _, filter := range filters {
+		if strings.Contains(eventType, filter) {
+			return true
+		}
+		if ok, _ := path.Match(filter, eventType); ok {
+			return true
+		}
+	}
+
+	return false
+}
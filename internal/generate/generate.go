@@ -0,0 +1,150 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var Cmd = &cobra.Command{
+	Use:              "generate",
+	Short:            "Generate new Cadence files and code from the Cadence files of the project",
+	GroupID:          "tools",
+	TraverseChildren: true,
+}
+
+func init() {
+	goCommand.AddToParent(Cmd)
+	jsCommand.AddToParent(Cmd)
+	tsCommand.AddToParent(Cmd)
+	contractCommand.AddToParent(Cmd)
+	scriptCommand.AddToParent(Cmd)
+	transactionCommand.AddToParent(Cmd)
+}
+
+const (
+	scriptBinding      = "script"
+	transactionBinding = "transaction"
+)
+
+// cadenceFile is a script or transaction code is generated for.
+type cadenceFile struct {
+	// Name is the filename without the extension.
+	Name       string
+	Kind       string
+	Location   string
+	Code       []byte
+	Program    *ast.Program
+	Parameters []arguments.Parameter
+	// Result is the type returned by a script, it is nil for transactions and scripts without a result.
+	Result sema.Type
+}
+
+// findCadenceFiles returns the scripts and transactions in the paths, test files and contracts are skipped.
+func findCadenceFiles(paths []string, readerWriter flowkit.ReaderWriter) ([]cadenceFile, error) {
+	locations, err := util.FindCadenceFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]cadenceFile, 0)
+	for _, location := range locations {
+		if strings.HasSuffix(location, "_test.cdc") {
+			continue
+		}
+
+		code, err := readerWriter.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("error loading %s: %w", location, err)
+		}
+
+		file, ok, err := parseCadenceFile(location, code)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			files = append(files, file)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no scripts or transactions found in %s", strings.Join(paths, ", "))
+	}
+	return files, nil
+}
+
+// parseCadenceFile parses a script or transaction, other files are skipped.
+func parseCadenceFile(location string, code []byte) (cadenceFile, bool, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return cadenceFile{}, false, fmt.Errorf("error parsing %s: %w", location, err)
+	}
+
+	file := cadenceFile{
+		Name:     strings.TrimSuffix(filepath.Base(location), filepath.Ext(location)),
+		Location: filepath.ToSlash(location),
+		Code:     code,
+		Program:  program,
+	}
+
+	var returnType *ast.TypeAnnotation
+	if len(program.TransactionDeclarations()) == 1 {
+		file.Kind = transactionBinding
+	} else if main := sema.FunctionEntryPointDeclaration(program); main != nil {
+		file.Kind = scriptBinding
+		returnType = main.ReturnTypeAnnotation
+	} else {
+		return cadenceFile{}, false, nil
+	}
+
+	file.Parameters, err = arguments.ParseParameters(code, location)
+	if err != nil {
+		return cadenceFile{}, false, fmt.Errorf("error parsing %s: %w", location, err)
+	}
+
+	if returnType != nil {
+		checker, err := sema.NewChecker(
+			program,
+			common.StringLocation(location),
+			nil,
+			&sema.Config{AccessCheckMode: sema.AccessCheckModeNotSpecifiedUnrestricted},
+		)
+		if err != nil {
+			return cadenceFile{}, false, err
+		}
+
+		if result := checker.ConvertType(returnType.Type); result != sema.VoidType {
+			file.Result = result
+		}
+	}
+
+	return file, true, nil
+}
@@ -0,0 +1,591 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsGo struct {
+	Output  string `default:"bindings/bindings.go" flag:"output" info:"Filename of the generated Go code"`
+	Package string `default:"bindings" flag:"package" info:"Package name of the generated Go code"`
+}
+
+var goFlags = flagsGo{}
+
+var goCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "go [files or directories...]",
+		Short: "Generate typed Go functions for the scripts and transactions",
+		Long: `Generate a Go function for each script and transaction executing it with flowkit, the arguments are
+passed as a struct of Go values and script results are decoded to Go values. Scripts and transactions are
+found in the provided files and directories, or in the cadence directory of the project.`,
+		Example: "flow generate go\nflow generate go cadence/scripts cadence/transactions --output client/flow.go --package client",
+		Args:    cobra.ArbitraryArgs,
+	},
+	Flags: &goFlags,
+	Run:   generateGo,
+}
+
+func generateGo(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	readerWriter flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	if !token.IsIdentifier(goFlags.Package) {
+		return nil, fmt.Errorf("invalid package name %s", goFlags.Package)
+	}
+
+	paths := args
+	if len(paths) == 0 {
+		// the state is optional, contracts are not needed to find scripts and transactions
+		state, _ := flowkit.LoadWithVars(globalFlags.ConfigPaths, readerWriter, globalFlags.Vars)
+		paths = util.CadencePaths(state)
+	}
+
+	files, err := findCadenceFiles(paths, readerWriter)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := make([]goBinding, len(files))
+	for i, file := range files {
+		bindings[i] = newGoBinding(file)
+	}
+
+	code, err := renderGo(goFlags.Package, bindings)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(goFlags.Output), 0755)
+	if err != nil {
+		return nil, err
+	}
+	err = readerWriter.WriteFile(goFlags.Output, code, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error writing %s: %w", goFlags.Output, err)
+	}
+
+	logger.Info(fmt.Sprintf("%s Generated %d functions in %s", output.SuccessEmoji(), len(bindings), goFlags.Output))
+	return nil, nil
+}
+
+// goBinding is the generated Go function of a script or transaction.
+type goBinding struct {
+	Name     string
+	Kind     string
+	Location string
+	Code     string
+	Params   []goParam
+	// Result is the type returned by a script, it is nil for transactions and scripts without a result.
+	Result *goType
+}
+
+func (b goBinding) CodeConst() string {
+	return lowerFirst(b.Name) + "Code"
+}
+
+func (b goBinding) CodeLiteral() string {
+	if strings.Contains(b.Code, "`") {
+		return fmt.Sprintf("%q", b.Code)
+	}
+	return "`" + b.Code + "`"
+}
+
+type goParam struct {
+	Name  string
+	Field string
+	Type  goType
+}
+
+// goType is the Go type used for a Cadence type, with the Go expressions of the functions
+// encoding the Go value as a Cadence value and decoding it from a Cadence value.
+type goType struct {
+	Go     string
+	Encode string
+	Decode string
+}
+
+// valueType is used for the Cadence types without a Go type, e.g. composites, the values are not converted.
+var valueType = goType{Go: "cadence.Value", Encode: "encodeValue", Decode: "decodeValue"}
+
+// goPrimitive is a Cadence type converted to a Go type by the generated helpers.
+type goPrimitive struct {
+	Cadence string
+	Go      string
+	Encode  string
+	Decode  string
+}
+
+var goPrimitives = []goPrimitive{
+	{Cadence: "String", Go: "string", Encode: "cadence.NewString(v)", Decode: "string(v)"},
+	{Cadence: "Character", Go: "string", Encode: "cadence.NewCharacter(v)", Decode: "string(v)"},
+	{Cadence: "Bool", Go: "bool", Encode: "cadence.NewBool(v), nil", Decode: "bool(v)"},
+	{Cadence: "Address", Go: "flow.Address", Encode: "cadence.NewAddress(v), nil", Decode: "flow.Address(v)"},
+	{Cadence: "Int", Go: "*big.Int", Encode: "cadence.NewIntFromBig(v), nil", Decode: "v.Big()"},
+	{Cadence: "Int8", Go: "int8", Encode: "cadence.NewInt8(v), nil", Decode: "int8(v)"},
+	{Cadence: "Int16", Go: "int16", Encode: "cadence.NewInt16(v), nil", Decode: "int16(v)"},
+	{Cadence: "Int32", Go: "int32", Encode: "cadence.NewInt32(v), nil", Decode: "int32(v)"},
+	{Cadence: "Int64", Go: "int64", Encode: "cadence.NewInt64(v), nil", Decode: "int64(v)"},
+	{Cadence: "Int128", Go: "*big.Int", Encode: "cadence.NewInt128FromBig(v)", Decode: "v.Big()"},
+	{Cadence: "Int256", Go: "*big.Int", Encode: "cadence.NewInt256FromBig(v)", Decode: "v.Big()"},
+	{Cadence: "UInt", Go: "*big.Int", Encode: "cadence.NewUIntFromBig(v)", Decode: "v.Big()"},
+	{Cadence: "UInt8", Go: "uint8", Encode: "cadence.NewUInt8(v), nil", Decode: "uint8(v)"},
+	{Cadence: "UInt16", Go: "uint16", Encode: "cadence.NewUInt16(v), nil", Decode: "uint16(v)"},
+	{Cadence: "UInt32", Go: "uint32", Encode: "cadence.NewUInt32(v), nil", Decode: "uint32(v)"},
+	{Cadence: "UInt64", Go: "uint64", Encode: "cadence.NewUInt64(v), nil", Decode: "uint64(v)"},
+	{Cadence: "UInt128", Go: "*big.Int", Encode: "cadence.NewUInt128FromBig(v)", Decode: "v.Big()"},
+	{Cadence: "UInt256", Go: "*big.Int", Encode: "cadence.NewUInt256FromBig(v)", Decode: "v.Big()"},
+	{Cadence: "Word8", Go: "uint8", Encode: "cadence.NewWord8(v), nil", Decode: "uint8(v)"},
+	{Cadence: "Word16", Go: "uint16", Encode: "cadence.NewWord16(v), nil", Decode: "uint16(v)"},
+	{Cadence: "Word32", Go: "uint32", Encode: "cadence.NewWord32(v), nil", Decode: "uint32(v)"},
+	{Cadence: "Word64", Go: "uint64", Encode: "cadence.NewWord64(v), nil", Decode: "uint64(v)"},
+	{Cadence: "Fix64", Go: "cadence.Fix64", Encode: "v, nil", Decode: "v"},
+	{Cadence: "UFix64", Go: "cadence.UFix64", Encode: "v, nil", Decode: "v"},
+}
+
+func primitiveByCadence(name string) (goPrimitive, bool) {
+	for _, p := range goPrimitives {
+		if p.Cadence == name {
+			return p, true
+		}
+	}
+	return goPrimitive{}, false
+}
+
+// goTypeOf returns the Go type of the Cadence type, types without a Go type are passed as Cadence values.
+func goTypeOf(semaType sema.Type) goType {
+	switch t := semaType.(type) {
+	case *sema.OptionalType:
+		inner := goTypeOf(t.Type)
+		if inner == valueType {
+			return valueType
+		}
+		// types which are already pointers, e.g. *big.Int, use nil for the absent value
+		if strings.HasPrefix(inner.Go, "*") {
+			return goType{
+				Go: inner.Go,
+				Encode: fmt.Sprintf(
+					"(func(v %s) (cadence.Value, error) { return encodeOptionalPointer(v, %s) })", inner.Go, inner.Encode,
+				),
+				Decode: fmt.Sprintf(
+					"(func(value cadence.Value) (%s, error) { return decodeOptionalPointer(value, %s) })", inner.Go, inner.Decode,
+				),
+			}
+		}
+		return goType{
+			Go: "*" + inner.Go,
+			Encode: fmt.Sprintf(
+				"(func(v *%s) (cadence.Value, error) { return encodeOptional(v, %s) })", inner.Go, inner.Encode,
+			),
+			Decode: fmt.Sprintf(
+				"(func(value cadence.Value) (*%s, error) { return decodeOptional(value, %s) })", inner.Go, inner.Decode,
+			),
+		}
+
+	case sema.ArrayType:
+		element := goTypeOf(t.ElementType(false))
+		if element == valueType {
+			return valueType
+		}
+		return goType{
+			Go: "[]" + element.Go,
+			Encode: fmt.Sprintf(
+				"(func(v []%s) (cadence.Value, error) { return encodeArray(v, %s) })", element.Go, element.Encode,
+			),
+			Decode: fmt.Sprintf(
+				"(func(value cadence.Value) ([]%s, error) { return decodeArray(value, %s) })", element.Go, element.Decode,
+			),
+		}
+
+	case *sema.DictionaryType:
+		key, value := goTypeOf(t.KeyType), goTypeOf(t.ValueType)
+		// keys must be comparable by value in Go
+		if key == valueType || value == valueType || strings.HasPrefix(key.Go, "*") {
+			return valueType
+		}
+		mapType := fmt.Sprintf("map[%s]%s", key.Go, value.Go)
+		return goType{
+			Go: mapType,
+			Encode: fmt.Sprintf(
+				"(func(v %s) (cadence.Value, error) { return encodeDictionary(v, %s, %s) })", mapType, key.Encode, value.Encode,
+			),
+			Decode: fmt.Sprintf(
+				"(func(value cadence.Value) (%s, error) { return decodeDictionary(value, %s, %s) })", mapType, key.Decode, value.Decode,
+			),
+		}
+	}
+
+	if primitive, ok := primitiveByCadence(semaType.QualifiedString()); ok {
+		return goType{Go: primitive.Go, Encode: "encode" + primitive.Cadence, Decode: "decode" + primitive.Cadence}
+	}
+	return valueType
+}
+
+func newGoBinding(file cadenceFile) goBinding {
+	binding := goBinding{
+		Name:     goName(file.Name),
+		Kind:     file.Kind,
+		Location: file.Location,
+		Code:     string(file.Code),
+	}
+
+	for _, parameter := range file.Parameters {
+		binding.Params = append(binding.Params, goParam{
+			Name:  parameter.Name,
+			Field: goName(parameter.Name),
+			Type:  goTypeOf(parameter.Type),
+		})
+	}
+
+	if file.Result != nil {
+		result := goTypeOf(file.Result)
+		binding.Result = &result
+	}
+
+	return binding
+}
+
+// goInitialisms are written in upper case in Go identifiers, e.g. nft_id to NFTID.
+var goInitialisms = map[string]bool{"id": true, "nft": true, "ft": true, "url": true, "uri": true, "uuid": true, "json": true}
+
+// goName converts a Cadence file or parameter name to an exported Go identifier, e.g. get_balance to GetBalance.
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for _, word := range words {
+		lower := strings.ToLower(word)
+		switch {
+		case goInitialisms[lower]:
+			b.WriteString(strings.ToUpper(word))
+		case strings.HasSuffix(lower, "s") && goInitialisms[strings.TrimSuffix(lower, "s")]:
+			b.WriteString(strings.ToUpper(word[:len(word)-1]) + "s")
+		default:
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			b.WriteString(string(runes))
+		}
+	}
+
+	name = b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
+
+// renderGo renders the Go file with the bindings, the functions are sorted by name and must be unique.
+func renderGo(packageName string, bindings []goBinding) ([]byte, error) {
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].Name < bindings[j].Name
+	})
+
+	hasTransactions := false
+	for i, binding := range bindings {
+		if i > 0 && bindings[i-1].Name == binding.Name {
+			return nil, fmt.Errorf(
+				"%s and %s generate the same function %s, rename one of the files",
+				bindings[i-1].Location, binding.Location, binding.Name,
+			)
+		}
+		hasTransactions = hasTransactions || binding.Kind == transactionBinding
+	}
+
+	var b bytes.Buffer
+	err := goTemplate.Execute(&b, map[string]any{
+		"Package":         packageName,
+		"Bindings":        bindings,
+		"Primitives":      goPrimitives,
+		"HasTransactions": hasTransactions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w", err)
+	}
+	return code, nil
+}
+
+var goTemplate = template.Must(template.New("go").Parse(`// Code generated by flow generate go. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+{{- if .HasTransactions }}
+	"github.com/onflow/flow-cli/flowkit/transactions"
+{{- end }}
+)
+{{ range .Bindings }}
+const {{ .CodeConst }} = {{ .CodeLiteral }}
+{{ if .Params }}
+// {{ .Name }}Args are the arguments of the {{ .Kind }} {{ .Location }}.
+type {{ .Name }}Args struct {
+{{- range .Params }}
+	{{ .Field }} {{ .Type.Go }}
+{{- end }}
+}
+
+func (args {{ .Name }}Args) values() ([]cadence.Value, error) {
+	values := make([]cadence.Value, {{ len .Params }})
+	var err error
+{{- range $i, $p := .Params }}
+	if values[{{ $i }}], err = {{ $p.Type.Encode }}(args.{{ $p.Field }}); err != nil {
+		return nil, fmt.Errorf("invalid argument {{ $p.Name }}: %w", err)
+	}
+{{- end }}
+	return values, nil
+}
+{{ end }}
+{{- if eq .Kind "script" }}
+// {{ .Name }} executes the script {{ .Location }} at the latest block.
+func {{ .Name }}(ctx context.Context, services flowkit.Services{{ if .Params }}, args {{ .Name }}Args{{ end }}) {{ if .Result }}({{ .Result.Go }}, error){{ else }}error{{ end }} {
+{{- if .Result }}
+	var result {{ .Result.Go }}
+{{- end }}
+{{- if .Params }}
+	values, err := args.values()
+	if err != nil {
+		return {{ if .Result }}result, {{ end }}err
+	}
+{{- else }}
+	var values []cadence.Value
+{{- end }}
+
+	{{ if .Result }}value, err :={{ else }}_, err {{ if .Params }}={{ else }}:={{ end }}{{ end }} services.ExecuteScript(
+		ctx,
+		flowkit.Script{Code: []byte({{ .CodeConst }}), Args: values, Location: "{{ .Location }}"},
+		flowkit.LatestScriptQuery,
+	)
+{{- if .Result }}
+	if err != nil {
+		return result, err
+	}
+	return {{ .Result.Decode }}(value)
+{{- else }}
+	return err
+{{- end }}
+}
+{{ else }}
+// {{ .Name }} sends the transaction {{ .Location }} signed by the accounts and waits for the result.
+func {{ .Name }}(ctx context.Context, services flowkit.Services, roles transactions.AccountRoles, gasLimit uint64{{ if .Params }}, args {{ .Name }}Args{{ end }}) (*flow.Transaction, *flow.TransactionResult, error) {
+{{- if .Params }}
+	values, err := args.values()
+	if err != nil {
+		return nil, nil, err
+	}
+{{- else }}
+	var values []cadence.Value
+{{- end }}
+
+	return services.SendTransaction(
+		ctx,
+		roles,
+		flowkit.Script{Code: []byte({{ .CodeConst }}), Args: values, Location: "{{ .Location }}"},
+		gasLimit,
+	)
+}
+{{ end }}
+{{- end }}
+func unexpectedType(expected string, value cadence.Value) error {
+	if value == nil {
+		return fmt.Errorf("expected %s value, got nil", expected)
+	}
+	return fmt.Errorf("expected %s value, got %s", expected, value.Type().ID())
+}
+
+func encodeValue(v cadence.Value) (cadence.Value, error) {
+	return v, nil
+}
+
+func decodeValue(value cadence.Value) (cadence.Value, error) {
+	return value, nil
+}
+{{ range .Primitives }}
+func encode{{ .Cadence }}(v {{ .Go }}) (cadence.Value, error) {
+	return {{ .Encode }}
+}
+
+func decode{{ .Cadence }}(value cadence.Value) (result {{ .Go }}, err error) {
+	v, ok := value.(cadence.{{ .Cadence }})
+	if !ok {
+		return result, unexpectedType("{{ .Cadence }}", value)
+	}
+	return {{ .Decode }}, nil
+}
+{{ end }}
+func encodeOptional[T any](v *T, encode func(T) (cadence.Value, error)) (cadence.Value, error) {
+	if v == nil {
+		return cadence.NewOptional(nil), nil
+	}
+	value, err := encode(*v)
+	if err != nil {
+		return nil, err
+	}
+	return cadence.NewOptional(value), nil
+}
+
+func decodeOptional[T any](value cadence.Value, decode func(cadence.Value) (T, error)) (*T, error) {
+	optional, ok := value.(cadence.Optional)
+	if !ok {
+		return nil, unexpectedType("Optional", value)
+	}
+	if optional.Value == nil {
+		return nil, nil
+	}
+	v, err := decode(optional.Value)
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func encodeOptionalPointer[T any](v *T, encode func(*T) (cadence.Value, error)) (cadence.Value, error) {
+	if v == nil {
+		return cadence.NewOptional(nil), nil
+	}
+	value, err := encode(v)
+	if err != nil {
+		return nil, err
+	}
+	return cadence.NewOptional(value), nil
+}
+
+func decodeOptionalPointer[T any](value cadence.Value, decode func(cadence.Value) (*T, error)) (*T, error) {
+	optional, ok := value.(cadence.Optional)
+	if !ok {
+		return nil, unexpectedType("Optional", value)
+	}
+	if optional.Value == nil {
+		return nil, nil
+	}
+	return decode(optional.Value)
+}
+
+func encodeArray[T any](v []T, encode func(T) (cadence.Value, error)) (cadence.Value, error) {
+	values := make([]cadence.Value, len(v))
+	for i, element := range v {
+		value, err := encode(element)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return cadence.NewArray(values), nil
+}
+
+func decodeArray[T any](value cadence.Value, decode func(cadence.Value) (T, error)) ([]T, error) {
+	array, ok := value.(cadence.Array)
+	if !ok {
+		return nil, unexpectedType("Array", value)
+	}
+	result := make([]T, len(array.Values))
+	for i, element := range array.Values {
+		v, err := decode(element)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = v
+	}
+	return result, nil
+}
+
+func encodeDictionary[K comparable, V any](
+	v map[K]V,
+	encodeKey func(K) (cadence.Value, error),
+	encodeValue func(V) (cadence.Value, error),
+) (cadence.Value, error) {
+	pairs := make([]cadence.KeyValuePair, 0, len(v))
+	for key, value := range v {
+		k, err := encodeKey(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := encodeValue(value)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, cadence.KeyValuePair{Key: k, Value: v})
+	}
+	return cadence.NewDictionary(pairs), nil
+}
+
+func decodeDictionary[K comparable, V any](
+	value cadence.Value,
+	decodeKey func(cadence.Value) (K, error),
+	decodeValue func(cadence.Value) (V, error),
+) (map[K]V, error) {
+	dictionary, ok := value.(cadence.Dictionary)
+	if !ok {
+		return nil, unexpectedType("Dictionary", value)
+	}
+	result := make(map[K]V, len(dictionary.Pairs))
+	for _, pair := range dictionary.Pairs {
+		k, err := decodeKey(pair.Key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := decodeValue(pair.Value)
+		if err != nil {
+			return nil, err
+		}
+		result[k] = v
+	}
+	return result, nil
+}
+`))
@@ -0,0 +1,125 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_GoName(t *testing.T) {
+	names := map[string]string{
+		"get_balance":  "GetBalance",
+		"mint-nft":     "MintNFT",
+		"ids":          "IDs",
+		"nftID":        "NftID",
+		"setupAccount": "SetupAccount",
+		"1st":          "X1st",
+	}
+	for name, expected := range names {
+		assert.Equal(t, expected, goName(name), name)
+	}
+}
+
+func Test_GoType(t *testing.T) {
+	assert.Equal(t, "flow.Address", goTypeOf(sema.TheAddressType).Go)
+	assert.Equal(t, "*big.Int", goTypeOf(sema.IntType).Go)
+	assert.Equal(t, "*big.Int", goTypeOf(&sema.OptionalType{Type: sema.UInt256Type}).Go)
+	assert.Equal(t, "*cadence.UFix64", goTypeOf(&sema.OptionalType{Type: sema.UFix64Type}).Go)
+	assert.Equal(t, "[]uint8", goTypeOf(&sema.VariableSizedType{Type: sema.UInt8Type}).Go)
+	assert.Equal(t, "[]string", goTypeOf(&sema.ConstantSizedType{Type: sema.StringType, Size: 2}).Go)
+	assert.Equal(t, "map[string][]int64", goTypeOf(&sema.DictionaryType{
+		KeyType:   sema.StringType,
+		ValueType: &sema.VariableSizedType{Type: sema.Int64Type},
+	}).Go)
+
+	// dictionaries with keys not comparable in Go and types without a Go type are not converted
+	assert.Equal(t, valueType, goTypeOf(&sema.DictionaryType{KeyType: sema.IntType, ValueType: sema.StringType}))
+	assert.Equal(t, valueType, goTypeOf(sema.StoragePathType))
+	assert.Equal(t, valueType, goTypeOf(&sema.VariableSizedType{Type: sema.AnyStructType}))
+}
+
+func Test_GenerateGo(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"scripts/get_balance.cdc":    `pub fun main(address: Address, ids: [UInt64]): UFix64 { return 1.0 }`,
+		"scripts/ping.cdc":           `pub fun main() {}`,
+		"transactions/transfer.cdc":  `transaction(amount: UFix64, to: Address?) { prepare(signer: AuthAccount) {} }`,
+		"contracts/Foo.cdc":          `pub contract Foo {}`,
+		"tests/get_balance_test.cdc": `pub fun testBalance() {}`,
+	}
+	for name, code := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(code), 0644))
+	}
+
+	rw := &afero.Afero{Fs: afero.NewOsFs()}
+	goFlags = flagsGo{Output: filepath.Join(dir, "client", "flow.go"), Package: "client"}
+	defer func() { goFlags = flagsGo{} }()
+
+	t.Run("Success", func(t *testing.T) {
+		result, err := generateGo([]string{dir}, command.GlobalFlags{}, util.NoLogger, rw, nil)
+		require.NoError(t, err)
+		assert.Nil(t, result)
+
+		code, err := os.ReadFile(goFlags.Output)
+		require.NoError(t, err)
+
+		file, err := parser.ParseFile(token.NewFileSet(), "flow.go", code, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "client", file.Name.Name)
+
+		assert.Contains(t, string(code), "// Code generated by flow generate go. DO NOT EDIT.")
+		assert.Contains(t, string(code), "func GetBalance(ctx context.Context, services flowkit.Services, args GetBalanceArgs) (cadence.UFix64, error) {")
+		assert.Contains(t, string(code), "func Ping(ctx context.Context, services flowkit.Services) error {")
+		assert.Contains(t, string(code), "func Transfer(ctx context.Context, services flowkit.Services, roles transactions.AccountRoles, gasLimit uint64, args TransferArgs) (*flow.Transaction, *flow.TransactionResult, error) {")
+		assert.Contains(t, string(code), "IDs     []uint64")
+		assert.NotContains(t, string(code), "Foo")
+		assert.NotContains(t, string(code), "testBalance")
+	})
+
+	t.Run("Fail Duplicate Names", func(t *testing.T) {
+		duplicate := filepath.Join(dir, "transactions", "get-balance.cdc")
+		require.NoError(t, os.WriteFile(duplicate, []byte(`transaction {}`), 0644))
+		defer os.Remove(duplicate)
+
+		_, err := generateGo([]string{dir}, command.GlobalFlags{}, util.NoLogger, rw, nil)
+		assert.ErrorContains(t, err, "generate the same function GetBalance, rename one of the files")
+	})
+
+	t.Run("Fail Invalid Package", func(t *testing.T) {
+		goFlags.Package = "my-client"
+		defer func() { goFlags.Package = "client" }()
+
+		_, err := generateGo([]string{dir}, command.GlobalFlags{}, util.NoLogger, rw, nil)
+		assert.EqualError(t, err, "invalid package name my-client")
+	})
+}
@@ -0,0 +1,552 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsJS struct {
+	Output   string `default:"" flag:"output" info:"Filename of the generated code, defaults to bindings/bindings.js or bindings/bindings.ts"`
+	GasLimit uint64 `default:"9999" flag:"gas-limit" info:"Default gas limit of the generated transaction functions"`
+}
+
+var jsFlags = flagsJS{}
+
+var tsFlags = flagsJS{}
+
+var jsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "js [files or directories...]",
+		Short: "Generate JavaScript functions for the scripts and transactions using FCL",
+		Long: `Generate a JavaScript function for each script and transaction executing it with FCL, the contract imports
+are replaced with the addresses of the network configured with configureNetwork. Scripts and transactions are
+found in the provided files and directories, or in the cadence directory of the project.`,
+		Example: "flow generate js\nflow generate js cadence/scripts cadence/transactions --output src/flow.js",
+		Args:    cobra.ArbitraryArgs,
+	},
+	Flags: &jsFlags,
+	RunS: func(args []string, _ command.GlobalFlags, logger output.Logger, _ flowkit.Services, state *flowkit.State) (command.Result, error) {
+		return generateJS(args, logger, state, jsFlags, false)
+	},
+}
+
+var tsCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "ts [files or directories...]",
+		Short: "Generate TypeScript functions for the scripts and transactions using FCL",
+		Long: `Generate a typed TypeScript function for each script and transaction executing it with FCL, the contract
+imports are replaced with the addresses of the network configured with configureNetwork. Scripts and transactions
+are found in the provided files and directories, or in the cadence directory of the project.`,
+		Example: "flow generate ts\nflow generate ts cadence/scripts cadence/transactions --output src/flow.ts",
+		Args:    cobra.ArbitraryArgs,
+	},
+	Flags: &tsFlags,
+	RunS: func(args []string, _ command.GlobalFlags, logger output.Logger, _ flowkit.Services, state *flowkit.State) (command.Result, error) {
+		return generateJS(args, logger, state, tsFlags, true)
+	},
+}
+
+func generateJS(
+	args []string,
+	logger output.Logger,
+	state *flowkit.State,
+	flags flagsJS,
+	typescript bool,
+) (command.Result, error) {
+	outputPath := flags.Output
+	if outputPath == "" {
+		outputPath = filepath.Join("bindings", "bindings.js")
+		if typescript {
+			outputPath = filepath.Join("bindings", "bindings.ts")
+		}
+	}
+
+	paths := args
+	if len(paths) == 0 {
+		paths = util.CadencePaths(state)
+	}
+
+	files, err := findCadenceFiles(paths, state.ReaderWriter())
+	if err != nil {
+		return nil, err
+	}
+
+	contracts := make(map[string]bool)
+	bindings := make([]jsBinding, 0, len(files))
+	for _, file := range files {
+		binding, err := newJSBinding(file, state, contracts)
+		if err != nil {
+			// files with arguments FCL can't encode are skipped, so the other functions can still be generated
+			logger.Info(fmt.Sprintf("%s Skipping %s: %s", output.WarningEmoji(), file.Location, err.Error()))
+			continue
+		}
+		bindings = append(bindings, binding)
+	}
+	if len(bindings) == 0 {
+		return nil, fmt.Errorf("no scripts or transactions with supported arguments found")
+	}
+
+	code, err := renderJS(bindings, contractAddresses(state, contracts), flags.GasLimit, typescript)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(outputPath), 0755)
+	if err != nil {
+		return nil, err
+	}
+	err = state.ReaderWriter().WriteFile(outputPath, code, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("error writing %s: %w", outputPath, err)
+	}
+
+	logger.Info(fmt.Sprintf("%s Generated %d functions in %s", output.SuccessEmoji(), len(bindings), outputPath))
+	return nil, nil
+}
+
+// jsBinding is the generated FCL function of a script or transaction.
+type jsBinding struct {
+	Name     string
+	Kind     string
+	Location string
+	Code     string
+	Params   []jsParam
+	// Result is the TypeScript type of the decoded script result.
+	Result string
+}
+
+func (b jsBinding) CodeConst() string {
+	return b.Name + "Code"
+}
+
+func (b jsBinding) ArgsType() string {
+	return upperFirst(b.Name) + "Args"
+}
+
+func (b jsBinding) CodeLiteral() string {
+	if !strings.ContainsAny(b.Code, "`\\$") {
+		return "`" + b.Code + "`"
+	}
+	// JSON strings are valid JavaScript strings
+	encoded, _ := json.Marshal(b.Code)
+	return string(encoded)
+}
+
+type jsParam struct {
+	Name string
+	Type jsType
+}
+
+// jsType is the FCL type of a Cadence type with the TypeScript types of the argument and the decoded value.
+type jsType struct {
+	Arg    string
+	FCL    string
+	Result string
+}
+
+// jsPrimitives are the Cadence types which FCL encodes from a JavaScript value,
+// FCL decodes all numbers to JavaScript numbers.
+var jsPrimitives = map[string]jsType{
+	"String":    {Arg: "string", Result: "string"},
+	"Character": {Arg: "string", Result: "string"},
+	"Bool":      {Arg: "boolean", Result: "boolean"},
+	"Address":   {Arg: "string", Result: "string"},
+	"Fix64":     {Arg: "string", Result: "number"},
+	"UFix64":    {Arg: "string", Result: "number"},
+}
+
+func init() {
+	for _, name := range []string{
+		"Int", "Int8", "Int16", "Int32", "Int64", "Int128", "Int256",
+		"UInt", "UInt8", "UInt16", "UInt32", "UInt64", "UInt128", "UInt256",
+		"Word8", "Word16", "Word32", "Word64",
+	} {
+		jsPrimitives[name] = jsType{Arg: "string | number", Result: "number"}
+	}
+}
+
+// jsTypeOf returns the FCL type of the Cadence type, the type is not supported as an argument if the FCL type is empty.
+func jsTypeOf(semaType sema.Type) jsType {
+	switch t := semaType.(type) {
+	case *sema.OptionalType:
+		inner := jsTypeOf(t.Type)
+		return jsType{
+			Arg:    inner.Arg + " | null",
+			FCL:    fcl("t.Optional(%s)", inner.FCL),
+			Result: inner.Result + " | null",
+		}
+
+	case sema.ArrayType:
+		element := jsTypeOf(t.ElementType(false))
+		return jsType{
+			Arg:    jsArray(element.Arg),
+			FCL:    fcl("t.Array(%s)", element.FCL),
+			Result: jsArray(element.Result),
+		}
+
+	case *sema.DictionaryType:
+		key, value := jsTypeOf(t.KeyType), jsTypeOf(t.ValueType)
+		return jsType{
+			Arg:    fmt.Sprintf("{ key: %s; value: %s }[]", key.Arg, value.Arg),
+			FCL:    fcl("t.Dictionary({ key: %s, value: %s })", key.FCL, value.FCL),
+			Result: fmt.Sprintf("Record<string, %s>", value.Result),
+		}
+	}
+
+	name := semaType.QualifiedString()
+	if primitive, ok := jsPrimitives[name]; ok {
+		primitive.FCL = "t." + name
+		return primitive
+	}
+	return jsType{Arg: "any", Result: "any"}
+}
+
+// jsArray returns the TypeScript array type of the element type, union types are parenthesized.
+func jsArray(element string) string {
+	if strings.Contains(element, " | ") {
+		return fmt.Sprintf("(%s)[]", element)
+	}
+	return element + "[]"
+}
+
+// fcl formats a composed FCL type, the type is not supported if any of the inner types is not supported.
+func fcl(format string, types ...string) string {
+	for _, t := range types {
+		if t == "" {
+			return ""
+		}
+	}
+	args := make([]any, len(types))
+	for i, t := range types {
+		args[i] = t
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// newJSBinding creates the binding of the file, the names of the imported contracts are added to the contracts.
+func newJSBinding(file cadenceFile, state *flowkit.State, contracts map[string]bool) (jsBinding, error) {
+	binding := jsBinding{
+		Name:     jsName(file.Name),
+		Kind:     file.Kind,
+		Location: file.Location,
+		Code:     replaceImports(file, state, contracts),
+		Result:   "void",
+	}
+
+	for _, parameter := range file.Parameters {
+		t := jsTypeOf(parameter.Type)
+		if t.FCL == "" {
+			return jsBinding{}, fmt.Errorf(
+				"argument `%s` of type `%s` is not supported", parameter.Name, parameter.Type.QualifiedString(),
+			)
+		}
+		binding.Params = append(binding.Params, jsParam{Name: parameter.Name, Type: t})
+	}
+
+	if file.Result != nil {
+		binding.Result = jsTypeOf(file.Result).Result
+	}
+
+	return binding, nil
+}
+
+// replaceImports replaces imports of contracts configured in the project with FCL address placeholders,
+// e.g. import "Foo" to import Foo from 0xFoo, other imports are not changed.
+func replaceImports(file cadenceFile, state *flowkit.State, contracts map[string]bool) string {
+	code := string(file.Code)
+
+	imports := file.Program.ImportDeclarations()
+	// imports are replaced from the end, so the offsets of the previous imports are not changed
+	for i := len(imports) - 1; i >= 0; i-- {
+		declaration := imports[i]
+
+		var names []string
+		for _, identifier := range declaration.Identifiers {
+			names = append(names, identifier.Identifier)
+		}
+		if len(names) == 0 {
+			location := declaration.Location.String()
+			if _, ok := declaration.Location.(common.StringLocation); ok {
+				location = strings.TrimSuffix(filepath.Base(location), filepath.Ext(location))
+			}
+			names = append(names, location)
+		}
+
+		replaced := make([]string, len(names))
+		configured := true
+		for j, name := range names {
+			if _, err := state.Contracts().ByName(name); err != nil {
+				configured = false
+				break
+			}
+			replaced[j] = fmt.Sprintf("import %s from 0x%s", name, name)
+		}
+		if !configured {
+			continue
+		}
+
+		for _, name := range names {
+			contracts[name] = true
+		}
+		code = code[:declaration.StartPos.Offset] + strings.Join(replaced, "\n") + code[declaration.EndPos.Offset+1:]
+	}
+
+	return code
+}
+
+type jsNetwork struct {
+	Name      string
+	Addresses []jsAddress
+}
+
+type jsAddress struct {
+	Contract string
+	Address  string
+}
+
+// contractAddresses returns the addresses of the contracts for each network, using the alias of the network
+// or the account the contract is deployed to on the network. Contracts without an address are omitted.
+func contractAddresses(state *flowkit.State, contracts map[string]bool) []jsNetwork {
+	names := make([]string, 0, len(contracts))
+	for name := range contracts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	networks := make([]jsNetwork, 0)
+	for _, network := range *state.Networks() {
+		n := jsNetwork{Name: network.Name, Addresses: []jsAddress{}}
+		for _, name := range names {
+			if address := contractAddress(state, name, network.Name); address != "" {
+				n.Addresses = append(n.Addresses, jsAddress{Contract: name, Address: address})
+			}
+		}
+		networks = append(networks, n)
+	}
+	sort.Slice(networks, func(i, j int) bool {
+		return networks[i].Name < networks[j].Name
+	})
+	return networks
+}
+
+func contractAddress(state *flowkit.State, name string, network string) string {
+	contract, err := state.Contracts().ByName(name)
+	if err != nil {
+		return ""
+	}
+	if alias := contract.Aliases.ByNetwork(network); alias != nil {
+		return "0x" + alias.Address.String()
+	}
+
+	for _, deployment := range state.Deployments().ByNetwork(network) {
+		for _, deployed := range deployment.Contracts {
+			if deployed.Name != name {
+				continue
+			}
+			account, err := state.Accounts().ByName(state.DeploymentAccountName(deployment.Account))
+			if err != nil {
+				return ""
+			}
+			return "0x" + account.Address.String()
+		}
+	}
+	return ""
+}
+
+// jsName converts a Cadence filename to a JavaScript identifier, e.g. get_balance to getBalance.
+func jsName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+	for i, word := range words {
+		if i == 0 {
+			b.WriteString(lowerFirst(word))
+			continue
+		}
+		b.WriteString(upperFirst(word))
+	}
+
+	name = b.String()
+	if name == "" || unicode.IsDigit([]rune(name)[0]) {
+		name = "x" + name
+	}
+	return name
+}
+
+func upperFirst(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// renderJS renders the JavaScript or TypeScript file with the bindings, the functions are sorted by name and must be unique.
+func renderJS(bindings []jsBinding, networks []jsNetwork, gasLimit uint64, typescript bool) ([]byte, error) {
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].Name < bindings[j].Name
+	})
+	for i := 1; i < len(bindings); i++ {
+		if bindings[i-1].Name == bindings[i].Name {
+			return nil, fmt.Errorf(
+				"%s and %s generate the same function %s, rename one of the files",
+				bindings[i-1].Location, bindings[i].Location, bindings[i].Name,
+			)
+		}
+	}
+
+	command := "js"
+	if typescript {
+		command = "ts"
+	}
+
+	var b bytes.Buffer
+	err := jsTemplate.Execute(&b, map[string]any{
+		"Command":    command,
+		"TypeScript": typescript,
+		"Bindings":   bindings,
+		"Networks":   networks,
+		"GasLimit":   gasLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+var jsTemplate = template.Must(template.New("js").Parse(`// Code generated by flow generate {{ .Command }}. DO NOT EDIT.
+
+import * as fcl from "@onflow/fcl";
+{{ if .TypeScript }}
+export type Network = {{ range $i, $n := .Networks }}{{ if $i }} | {{ end }}"{{ $n.Name }}"{{ else }}string{{ end }};
+{{ else }}
+/** @typedef { {{- range $i, $n := .Networks }}{{ if $i }} | {{ end }}"{{ $n.Name }}"{{ else }}string{{ end -}} } Network */
+{{ end }}
+/**
+ * Addresses of the imported contracts on each network.
+{{- if not .TypeScript }}
+ * @type {Record<Network, Record<string, string>>}
+{{- end }}
+ */
+export const addresses{{ if .TypeScript }}: Record<Network, Record<string, string>>{{ end }} = {
+{{- range .Networks }}
+  "{{ .Name }}": {
+{{- range .Addresses }}
+    "{{ .Contract }}": "{{ .Address }}",
+{{- end }}
+  },
+{{- end }}
+};
+
+/**
+ * Configures FCL to replace the contract imports with the addresses of the network.
+{{- if not .TypeScript }}
+ * @param {Network} network
+{{- end }}
+ */
+export function configureNetwork(network{{ if .TypeScript }}: Network{{ end }}){{ if .TypeScript }}: void{{ end }} {
+  for (const [name, address] of Object.entries(addresses[network])) {
+    fcl.config().put(` + "`0x${name}`" + `, address);
+  }
+}
+{{ range .Bindings }}{{ $binding := . }}
+const {{ .CodeConst }} = {{ .CodeLiteral }};
+{{ if .Params }}
+{{- if $.TypeScript }}
+export type {{ .ArgsType }} = {
+{{- range .Params }}
+  {{ .Name }}: {{ .Type.Arg }};
+{{- end }}
+};
+{{ else }}
+/**
+ * @typedef {Object} {{ .ArgsType }}
+{{- range .Params }}
+ * @property { {{- .Type.Arg -}} } {{ .Name }}
+{{- end }}
+ */
+{{ end }}
+{{- end }}
+{{- if eq .Kind "script" }}
+/**
+ * Executes the script {{ .Location }}.
+{{- if not $.TypeScript }}
+{{- if .Params }}
+ * @param { {{- .ArgsType -}} } args
+{{- end }}
+ * @returns {Promise<{{ .Result }}>}
+{{- end }}
+ */
+export async function {{ .Name }}({{ if .Params }}args{{ if $.TypeScript }}: {{ .ArgsType }}{{ end }}{{ end }}){{ if $.TypeScript }}: Promise<{{ .Result }}>{{ end }} {
+  return fcl.query({
+    cadence: {{ .CodeConst }},
+{{- if .Params }}
+    args: (arg{{ if $.TypeScript }}: any{{ end }}, t{{ if $.TypeScript }}: any{{ end }}) => [
+{{- range .Params }}
+      arg(args.{{ .Name }}, {{ .Type.FCL }}),
+{{- end }}
+    ],
+{{- end }}
+  });
+}
+{{ else }}
+/**
+ * Sends the transaction {{ .Location }} signed by the current user and returns the transaction ID.
+{{- if not $.TypeScript }}
+{{- if .Params }}
+ * @param { {{- .ArgsType -}} } args
+{{- end }}
+ * @param {number} [limit]
+ * @returns {Promise<string>}
+{{- end }}
+ */
+export async function {{ .Name }}({{ if .Params }}args{{ if $.TypeScript }}: {{ .ArgsType }}{{ end }}, {{ end }}limit{{ if $.TypeScript }}: number{{ end }} = {{ $.GasLimit }}){{ if $.TypeScript }}: Promise<string>{{ end }} {
+  return fcl.mutate({
+    cadence: {{ .CodeConst }},
+{{- if .Params }}
+    args: (arg{{ if $.TypeScript }}: any{{ end }}, t{{ if $.TypeScript }}: any{{ end }}) => [
+{{- range .Params }}
+      arg(args.{{ .Name }}, {{ .Type.FCL }}),
+{{- end }}
+    ],
+{{- end }}
+    limit,
+  });
+}
+{{ end }}
+{{- end -}}
+`))
@@ -0,0 +1,122 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_JSName(t *testing.T) {
+	names := map[string]string{
+		"get_balance":  "getBalance",
+		"mint-nft":     "mintNft",
+		"SetupAccount": "setupAccount",
+		"1st":          "x1st",
+	}
+	for name, expected := range names {
+		assert.Equal(t, expected, jsName(name), name)
+	}
+}
+
+func Test_JSType(t *testing.T) {
+	assert.Equal(t, jsType{Arg: "string", FCL: "t.Address", Result: "string"}, jsTypeOf(sema.TheAddressType))
+	assert.Equal(t, jsType{Arg: "string | number", FCL: "t.UInt64", Result: "number"}, jsTypeOf(sema.UInt64Type))
+	assert.Equal(t, jsType{Arg: "string | null", FCL: "t.Optional(t.UFix64)", Result: "number | null"}, jsTypeOf(&sema.OptionalType{Type: sema.UFix64Type}))
+	assert.Equal(t, jsType{Arg: "(string | number)[]", FCL: "t.Array(t.Int8)", Result: "number[]"}, jsTypeOf(&sema.VariableSizedType{Type: sema.Int8Type}))
+	assert.Equal(t, jsType{
+		Arg:    "{ key: string; value: boolean }[]",
+		FCL:    "t.Dictionary({ key: t.String, value: t.Bool })",
+		Result: "Record<string, boolean>",
+	}, jsTypeOf(&sema.DictionaryType{KeyType: sema.StringType, ValueType: sema.BoolType}))
+
+	// types FCL can't encode have no FCL type
+	assert.Equal(t, "", jsTypeOf(sema.StoragePathType).FCL)
+	assert.Equal(t, "", jsTypeOf(&sema.VariableSizedType{Type: sema.AnyStructType}).FCL)
+}
+
+func Test_GenerateJS(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"scripts/get_balance.cdc":   "import \"Foo\"\nimport Bar from \"./Bar.cdc\"\npub fun main(address: Address, ids: [UInt64]): UFix64 { return 1.0 }",
+		"scripts/path.cdc":          `pub fun main(path: StoragePath) {}`,
+		"transactions/transfer.cdc": `transaction(amount: UFix64, to: Address?) { prepare(signer: AuthAccount) {} }`,
+	}
+	for name, code := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(code), 0644))
+	}
+
+	rw := &afero.Afero{Fs: afero.NewOsFs()}
+	state, err := flowkit.Init(rw, crypto.ECDSA_P256, crypto.SHA3_256)
+	require.NoError(t, err)
+	state.Contracts().AddOrUpdate(config.Contract{
+		Name:     "Foo",
+		Location: "contracts/Foo.cdc",
+		Aliases: config.Aliases{{
+			Network: config.TestnetNetwork.Name,
+			Address: flow.HexToAddress("0x01"),
+		}},
+	})
+
+	t.Run("Success TypeScript", func(t *testing.T) {
+		output := filepath.Join(dir, "src", "flow.ts")
+		_, err := generateJS([]string{dir}, util.NoLogger, state, flagsJS{Output: output, GasLimit: 100}, true)
+		require.NoError(t, err)
+
+		code, err := os.ReadFile(output)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(code), "// Code generated by flow generate ts. DO NOT EDIT.")
+		assert.Contains(t, string(code), "import Foo from 0xFoo\nimport Bar from \"./Bar.cdc\"\npub fun main")
+		assert.Contains(t, string(code), "\"testnet\": {\n    \"Foo\": \"0x0000000000000001\",\n  },")
+		assert.Contains(t, string(code), "export async function getBalance(args: GetBalanceArgs): Promise<number> {")
+		assert.Contains(t, string(code), "arg(args.ids, t.Array(t.UInt64)),")
+		assert.Contains(t, string(code), "export async function transfer(args: TransferArgs, limit: number = 100): Promise<string> {")
+		assert.Contains(t, string(code), "  to: string | null;")
+		assert.NotContains(t, string(code), "function path")
+	})
+
+	t.Run("Success JavaScript", func(t *testing.T) {
+		output := filepath.Join(dir, "src", "flow.js")
+		_, err := generateJS([]string{dir}, util.NoLogger, state, flagsJS{Output: output, GasLimit: 100}, false)
+		require.NoError(t, err)
+
+		code, err := os.ReadFile(output)
+		require.NoError(t, err)
+
+		assert.Contains(t, string(code), "// Code generated by flow generate js. DO NOT EDIT.")
+		assert.Contains(t, string(code), " * @property {(string | number)[]} ids")
+		assert.Contains(t, string(code), "export async function getBalance(args) {")
+		assert.Contains(t, string(code), "export async function transfer(args, limit = 100) {")
+	})
+}
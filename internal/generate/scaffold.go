@@ -0,0 +1,291 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+const (
+	contractScaffold    = "contract"
+	scriptScaffold      = "script"
+	transactionScaffold = "transaction"
+)
+
+// scaffoldDirs are the project directories the files are created in if no directory is provided.
+var scaffoldDirs = map[string]string{
+	contractScaffold:    filepath.Join("cadence", "contracts"),
+	scriptScaffold:      filepath.Join("cadence", "scripts"),
+	transactionScaffold: filepath.Join("cadence", "transactions"),
+}
+
+var testDir = filepath.Join("cadence", "tests")
+
+type flagsScaffold struct {
+	Dir      string `default:"" flag:"dir" info:"Directory of the created file, defaults to the cadence/contracts, cadence/scripts or cadence/transactions directory"`
+	WithTest bool   `default:"false" flag:"with-test" info:"Create a Cadence test file in the cadence/tests directory"`
+}
+
+var contractFlags = flagsScaffold{}
+
+var scriptFlags = flagsScaffold{}
+
+var transactionFlags = flagsScaffold{}
+
+var contractCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "contract <name>",
+		Short: "Create a new contract and add it to the configuration",
+		Long: `Create a new contract file, add the contract to the configuration and deploy it to the emulator
+service account with the project deployments.`,
+		Example: "flow generate contract HelloWorld --with-test",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &contractFlags,
+	RunS: func(args []string, globalFlags command.GlobalFlags, logger output.Logger, _ flowkit.Services, state *flowkit.State) (command.Result, error) {
+		return scaffold(args[0], contractScaffold, contractFlags, globalFlags, logger, state)
+	},
+}
+
+var scriptCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "script <name>",
+		Short:   "Create a new script",
+		Example: "flow generate script get_balance",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &scriptFlags,
+	RunS: func(args []string, globalFlags command.GlobalFlags, logger output.Logger, _ flowkit.Services, state *flowkit.State) (command.Result, error) {
+		return scaffold(args[0], scriptScaffold, scriptFlags, globalFlags, logger, state)
+	},
+}
+
+var transactionCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "transaction <name>",
+		Short:   "Create a new transaction",
+		Example: "flow generate transaction setup_account",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &transactionFlags,
+	RunS: func(args []string, globalFlags command.GlobalFlags, logger output.Logger, _ flowkit.Services, state *flowkit.State) (command.Result, error) {
+		return scaffold(args[0], transactionScaffold, transactionFlags, globalFlags, logger, state)
+	},
+}
+
+// scaffold creates the file of the kind with the name, contracts are added to the configuration.
+func scaffold(
+	name string,
+	kind string,
+	flags flagsScaffold,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	state *flowkit.State,
+) (command.Result, error) {
+	name = strings.TrimSuffix(name, ".cdc")
+	if kind == contractScaffold && !isIdentifier(name) {
+		return nil, fmt.Errorf("invalid contract name %s, the name must be a valid identifier", name)
+	}
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, fmt.Errorf("invalid %s name %s", kind, name)
+	}
+
+	dir := flags.Dir
+	if dir == "" {
+		dir = scaffoldDirs[kind]
+	}
+	location := filepath.Join(dir, name+".cdc")
+
+	paths := []string{location}
+	codes := []string{scaffoldCode(name, kind)}
+	if flags.WithTest {
+		test, err := scaffoldTest(name, kind, location)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, filepath.Join(testDir, name+"_test.cdc"))
+		codes = append(codes, test)
+	}
+
+	// all files are checked before any is written, so existing files are never partially overwritten
+	for _, path := range paths {
+		if _, err := os.Stat(path); err == nil {
+			return nil, fmt.Errorf("file %s already exists", path)
+		}
+	}
+
+	if kind == contractScaffold {
+		if _, err := state.Contracts().ByName(name); err == nil {
+			return nil, fmt.Errorf("contract %s already exists in the configuration", name)
+		}
+	}
+
+	for i, path := range paths {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := state.ReaderWriter().WriteFile(path, []byte(codes[i]), 0644); err != nil {
+			return nil, fmt.Errorf("error writing %s: %w", path, err)
+		}
+		logger.Info(fmt.Sprintf("%s Created %s", output.SuccessEmoji(), path))
+	}
+
+	if kind != contractScaffold {
+		return nil, nil
+	}
+
+	state.Contracts().AddOrUpdate(config.Contract{
+		Name:     name,
+		Location: filepath.ToSlash(location),
+	})
+
+	// the contract is only deployed if the project has an emulator service account
+	if account, err := state.EmulatorServiceAccount(); err == nil {
+		deployment := state.Deployments().ByAccountAndNetwork(account.Name, config.EmulatorNetwork.Name)
+		if deployment == nil {
+			state.Deployments().AddOrUpdate(config.Deployment{
+				Network: config.EmulatorNetwork.Name,
+				Account: account.Name,
+			})
+			deployment = state.Deployments().ByAccountAndNetwork(account.Name, config.EmulatorNetwork.Name)
+		}
+		deployment.AddContract(config.ContractDeployment{Name: name})
+	}
+
+	if err := state.SaveEdited(globalFlags.ConfigPaths); err != nil {
+		return nil, err
+	}
+	logger.Info(fmt.Sprintf("%s Added contract %s to the configuration", output.SuccessEmoji(), name))
+
+	return nil, nil
+}
+
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if !unicode.IsLetter(r) && r != '_' && (i == 0 || !unicode.IsDigit(r)) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+func scaffoldCode(name string, kind string) string {
+	switch kind {
+	case contractScaffold:
+		return fmt.Sprintf(contractTemplate, name)
+	case scriptScaffold:
+		return scriptTemplate
+	default:
+		return transactionTemplate
+	}
+}
+
+// scaffoldTest returns the test of the file at the location, the file is read relative to the test directory.
+func scaffoldTest(name string, kind string, location string) (string, error) {
+	path, err := filepath.Rel(testDir, location)
+	if err != nil {
+		return "", err
+	}
+	path = filepath.ToSlash(path)
+
+	switch kind {
+	case contractScaffold:
+		return fmt.Sprintf(contractTestTemplate, upperFirst(jsName(name)), name, path), nil
+	case scriptScaffold:
+		return fmt.Sprintf(scriptTestTemplate, upperFirst(jsName(name)), path), nil
+	default:
+		return fmt.Sprintf(transactionTestTemplate, upperFirst(jsName(name)), path), nil
+	}
+}
+
+const contractTemplate = `pub contract %s {
+
+    pub let greeting: String
+
+    init() {
+        self.greeting = "Hello, World!"
+    }
+}
+`
+
+const scriptTemplate = `pub fun main(): String {
+    return "Hello, World!"
+}
+`
+
+const transactionTemplate = `transaction {
+
+    prepare(signer: AuthAccount) {}
+
+    execute {}
+}
+`
+
+const contractTestTemplate = `import Test
+
+pub let blockchain = Test.newEmulatorBlockchain()
+pub let account = blockchain.createAccount()
+
+pub fun test%sDeployed() {
+    let err = blockchain.deployContract(
+        name: "%s",
+        code: Test.readFile("%s"),
+        account: account,
+        arguments: []
+    )
+    Test.expect(err, Test.beNil())
+}
+`
+
+const scriptTestTemplate = `import Test
+
+pub let blockchain = Test.newEmulatorBlockchain()
+
+pub fun test%s() {
+    let result = blockchain.executeScript(Test.readFile("%s"), [])
+    Test.expect(result, Test.beSucceeded())
+}
+`
+
+const transactionTestTemplate = `import Test
+
+pub let blockchain = Test.newEmulatorBlockchain()
+pub let account = blockchain.createAccount()
+
+pub fun test%s() {
+    let tx = Test.Transaction(
+        code: Test.readFile("%s"),
+        authorizers: [account.address],
+        signers: [account],
+        arguments: []
+    )
+    Test.expect(blockchain.executeTransaction(tx), Test.beSucceeded())
+}
+`
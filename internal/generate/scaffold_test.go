@@ -0,0 +1,112 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Scaffold(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(t.TempDir()))
+	defer func() { _ = os.Chdir(wd) }()
+
+	rw := &afero.Afero{Fs: afero.NewOsFs()}
+	state, err := flowkit.Init(rw, crypto.ECDSA_P256, crypto.SHA3_256)
+	require.NoError(t, err)
+	require.NoError(t, state.SaveDefault())
+
+	globalFlags := command.GlobalFlags{ConfigPaths: config.DefaultPaths()}
+
+	t.Run("Success Contract", func(t *testing.T) {
+		_, err := scaffold("Hello", contractScaffold, flagsScaffold{WithTest: true}, globalFlags, util.NoLogger, state)
+		require.NoError(t, err)
+
+		code, err := os.ReadFile(filepath.Join("cadence", "contracts", "Hello.cdc"))
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "pub contract Hello {")
+
+		test, err := os.ReadFile(filepath.Join("cadence", "tests", "Hello_test.cdc"))
+		require.NoError(t, err)
+		assert.Contains(t, string(test), `Test.readFile("../contracts/Hello.cdc")`)
+		assert.Contains(t, string(test), "pub fun testHelloDeployed() {")
+
+		saved, err := flowkit.Load(config.DefaultPaths(), rw)
+		require.NoError(t, err)
+		contract, err := saved.Contracts().ByName("Hello")
+		require.NoError(t, err)
+		assert.Equal(t, "cadence/contracts/Hello.cdc", contract.Location)
+
+		deployment := saved.Deployments().ByAccountAndNetwork("emulator-account", config.EmulatorNetwork.Name)
+		require.NotNil(t, deployment)
+		assert.Equal(t, []config.ContractDeployment{{Name: "Hello"}}, deployment.Contracts)
+	})
+
+	t.Run("Success Script", func(t *testing.T) {
+		_, err := scaffold("get_balance.cdc", scriptScaffold, flagsScaffold{Dir: "scripts", WithTest: true}, globalFlags, util.NoLogger, state)
+		require.NoError(t, err)
+
+		code, err := os.ReadFile(filepath.Join("scripts", "get_balance.cdc"))
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "pub fun main()")
+
+		test, err := os.ReadFile(filepath.Join("cadence", "tests", "get_balance_test.cdc"))
+		require.NoError(t, err)
+		assert.Contains(t, string(test), `Test.readFile("../../scripts/get_balance.cdc")`)
+		assert.Contains(t, string(test), "pub fun testGetBalance() {")
+	})
+
+	t.Run("Success Transaction", func(t *testing.T) {
+		_, err := scaffold("setup", transactionScaffold, flagsScaffold{}, globalFlags, util.NoLogger, state)
+		require.NoError(t, err)
+
+		code, err := os.ReadFile(filepath.Join("cadence", "transactions", "setup.cdc"))
+		require.NoError(t, err)
+		assert.Contains(t, string(code), "transaction {")
+
+		_, err = os.Stat(filepath.Join("cadence", "tests", "setup_test.cdc"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Fail Existing File", func(t *testing.T) {
+		_, err := scaffold("setup", transactionScaffold, flagsScaffold{}, globalFlags, util.NoLogger, state)
+		assert.EqualError(t, err, "file cadence/transactions/setup.cdc already exists")
+	})
+
+	t.Run("Fail Invalid Name", func(t *testing.T) {
+		_, err := scaffold("My-Contract", contractScaffold, flagsScaffold{}, globalFlags, util.NoLogger, state)
+		assert.EqualError(t, err, "invalid contract name My-Contract, the name must be a valid identifier")
+
+		_, err = scaffold("scripts/get", scriptScaffold, flagsScaffold{}, globalFlags, util.NoLogger, state)
+		assert.EqualError(t, err, "invalid script name scripts/get")
+	})
+}
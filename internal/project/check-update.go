@@ -0,0 +1,281 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	cadenceErrors "github.com/onflow/cadence/runtime/errors"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/stdlib"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+const (
+	updateCompatible   = "compatible"
+	updateIncompatible = "incompatible"
+	updateUnchanged    = "unchanged"
+	updateNew          = "new"
+)
+
+var checkUpdateCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "check-update",
+		Short:   "Check the local contracts can update the deployed contracts",
+		Example: "flow project check-update --network testnet\nflow project check-update --network testnet --output json",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	RunS:  checkUpdate,
+}
+
+func checkUpdate(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	logger.StartProgress("Checking contract updates...")
+	defer logger.StopProgress()
+
+	checks, err := checkContractUpdates(context.Background(), flow, state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkUpdateResult{network: flow.Network().Name, contracts: checks}, nil
+}
+
+// updateCheck is the result of validating the local contract as an update of the contract deployed on-chain.
+type updateCheck struct {
+	Name    string   `json:"name"`
+	Account string   `json:"account"`
+	Address string   `json:"address"`
+	Status  string   `json:"status"`
+	Errors  []string `json:"errors,omitempty"`
+}
+
+// checkContractUpdates validates the updates of the deployed contracts with the same rules the network
+// enforces when updating a contract, e.g. fields can't be added or have their types changed.
+func checkContractUpdates(ctx context.Context, flow flowkit.Services, state *flowkit.State) ([]updateCheck, error) {
+	network := flow.Network()
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	replacer := project.NewImportReplacer(contracts, state.AliasesForNetwork(network))
+	onChain := make(map[flowsdk.Address]map[string][]byte)
+	checks := make([]updateCheck, 0, len(contracts))
+
+	for _, contract := range contracts {
+		program, err := project.NewProgram(contract.Code(), contract.Args, contract.Location())
+		if err != nil {
+			return nil, err
+		}
+
+		program, err = replacer.Replace(program)
+		if err != nil {
+			return nil, err
+		}
+
+		deployed, ok := onChain[contract.AccountAddress]
+		if !ok {
+			account, err := flow.GetAccount(ctx, contract.AccountAddress)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch account %s: %w", contract.AccountName, err)
+			}
+			deployed = account.Contracts
+			onChain[contract.AccountAddress] = deployed
+		}
+
+		check := updateCheck{
+			Name:    contract.Name,
+			Account: contract.AccountName,
+			Address: fmt.Sprintf("0x%s", contract.AccountAddress),
+		}
+		check.validate(contract.AccountAddress, deployed, program.Code())
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// checkPlanUpdates validates the contract updates of the deploy plan the same way as checkContractUpdates.
+func checkPlanUpdates(ctx context.Context, flow flowkit.Services, plan *deployPlan) ([]updateCheck, error) {
+	onChain := make(map[flowsdk.Address]map[string][]byte)
+	checks := make([]updateCheck, 0)
+
+	for _, op := range plan.Operations {
+		if op.Action != planUpdate {
+			continue
+		}
+
+		address := flowsdk.HexToAddress(op.Address)
+		deployed, ok := onChain[address]
+		if !ok {
+			account, err := flow.GetAccount(ctx, address)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch account %s: %w", op.Account, err)
+			}
+			deployed = account.Contracts
+			onChain[address] = deployed
+		}
+
+		check := updateCheck{Name: op.Contract, Account: op.Account, Address: op.Address}
+		check.validate(address, deployed, []byte(op.Code))
+		checks = append(checks, check)
+	}
+
+	return checks, nil
+}
+
+// validate sets the status of updating the contract deployed to the address with the new code.
+func (c *updateCheck) validate(address flowsdk.Address, deployed map[string][]byte, code []byte) {
+	name := config.BaseName(c.Name)
+	existing, exists := deployed[name]
+	switch {
+	case !exists:
+		c.Status = updateNew
+	case bytes.Equal(existing, code):
+		c.Status = updateUnchanged
+	default:
+		c.Errors = validateContractUpdate(address, name, existing, code)
+		c.Status = updateCompatible
+		if len(c.Errors) > 0 {
+			c.Status = updateIncompatible
+		}
+	}
+}
+
+// validateContractUpdate returns all the reasons the new code is not a valid update of the old code.
+func validateContractUpdate(address flowsdk.Address, name string, oldCode []byte, newCode []byte) []string {
+	oldProgram, err := parser.ParseProgram(nil, oldCode, parser.Config{})
+	if err != nil {
+		return []string{fmt.Sprintf("failed to parse deployed contract: %s", err)}
+	}
+
+	newProgram, err := parser.ParseProgram(nil, newCode, parser.Config{})
+	if err != nil {
+		return []string{fmt.Sprintf("failed to parse local contract: %s", err)}
+	}
+
+	location := common.AddressLocation{Address: common.Address(address), Name: name}
+	err = stdlib.NewContractUpdateValidator(location, name, oldProgram, newProgram).Validate()
+	if err == nil {
+		return nil
+	}
+
+	var parentErr cadenceErrors.ParentError
+	if !errors.As(err, &parentErr) {
+		return []string{err.Error()}
+	}
+
+	reasons := make([]string, 0, len(parentErr.ChildErrors()))
+	for _, childErr := range parentErr.ChildErrors() {
+		reason := childErr.Error()
+		if secondary, ok := childErr.(cadenceErrors.SecondaryError); ok {
+			reason = fmt.Sprintf("%s: %s", reason, secondary.SecondaryError())
+		}
+		if positioned, ok := childErr.(ast.HasPosition); ok {
+			pos := positioned.StartPosition()
+			reason = fmt.Sprintf("%d:%d %s", pos.Line, pos.Column, reason)
+		}
+		reasons = append(reasons, reason)
+	}
+
+	return reasons
+}
+
+// incompatibleUpdatesError returns an error listing the incompatible contract updates or nil if all are compatible.
+func incompatibleUpdatesError(checks []updateCheck) error {
+	var b bytes.Buffer
+	for _, c := range checks {
+		if c.Status != updateIncompatible {
+			continue
+		}
+		_, _ = fmt.Fprintf(&b, "\n%s:", c.Name)
+		for _, reason := range c.Errors {
+			_, _ = fmt.Fprintf(&b, "\n  - %s", reason)
+		}
+	}
+
+	if b.Len() == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("incompatible contract updates, use --force flag to deploy anyway:%s", b.String())
+}
+
+type checkUpdateResult struct {
+	network   string
+	contracts []updateCheck
+}
+
+func (r *checkUpdateResult) compatible() bool {
+	return incompatibleUpdatesError(r.contracts) == nil
+}
+
+func (r *checkUpdateResult) JSON() any {
+	return map[string]any{
+		"network":    r.network,
+		"compatible": r.compatible(),
+		"contracts":  r.contracts,
+	}
+}
+
+func (r *checkUpdateResult) String() string {
+	var b bytes.Buffer
+	for _, c := range r.contracts {
+		status := output.Green(c.Status)
+		if c.Status == updateIncompatible {
+			status = output.Red(c.Status)
+		}
+		_, _ = fmt.Fprintf(&b, "%s -> %s (%s): %s\n", c.Name, c.Account, c.Address, status)
+
+		for _, reason := range c.Errors {
+			_, _ = fmt.Fprintf(&b, "  - %s\n", reason)
+		}
+	}
+
+	if r.compatible() {
+		_, _ = fmt.Fprintf(&b, "\n%s All contract updates on network %s are compatible", output.SuccessEmoji(), r.network)
+	} else {
+		_, _ = fmt.Fprintf(&b, "\n%s Contract updates on network %s are incompatible", output.ErrorEmoji(), r.network)
+	}
+
+	return b.String()
+}
+
+func (r *checkUpdateResult) Oneliner() string {
+	return fmt.Sprintf("compatible: %t", r.compatible())
+}
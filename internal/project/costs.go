@@ -0,0 +1,151 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+)
+
+// deployCosts contains the fees paid for the deployment transactions.
+//
+// Execution effort is the computation used by a transaction as charged by the network.
+type deployCosts struct {
+	Network              string            `json:"network"`
+	Transactions         []transactionCost `json:"transactions"`
+	TotalExecutionEffort string            `json:"totalExecutionEffort"`
+	TotalFees            string            `json:"totalFees"`
+}
+
+type transactionCost struct {
+	Contract        string `json:"contract"`
+	TransactionID   string `json:"transactionId"`
+	ExecutionEffort string `json:"executionEffort"`
+	InclusionEffort string `json:"inclusionEffort"`
+	Fees            string `json:"fees"`
+}
+
+// newDeployCosts collects the fees of the transactions which deployed the contracts.
+func newDeployCosts(ctx context.Context, flow flowkit.Services, contracts []*project.Contract) (*deployCosts, error) {
+	costs := &deployCosts{
+		Network:      flow.Network().Name,
+		Transactions: make([]transactionCost, 0, len(contracts)),
+	}
+
+	var totalEffort, totalFees cadence.UFix64
+	for _, contract := range contracts {
+		if contract.TransactionID == flowsdk.EmptyID {
+			continue // contract wasn't changed
+		}
+
+		_, result, err := flow.GetTransactionByID(ctx, contract.TransactionID, true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction %s: %w", contract.TransactionID, err)
+		}
+
+		events := flowkit.EventsFromTransaction(result)
+		fees := events.GetFees()
+		if fees == nil {
+			fees = &flowkit.TransactionFees{}
+		}
+		totalEffort += fees.ExecutionEffort
+		totalFees += fees.Amount
+
+		costs.Transactions = append(costs.Transactions, transactionCost{
+			Contract:        contract.Name,
+			TransactionID:   contract.TransactionID.String(),
+			ExecutionEffort: fees.ExecutionEffort.String(),
+			InclusionEffort: fees.InclusionEffort.String(),
+			Fees:            fees.Amount.String(),
+		})
+	}
+
+	costs.TotalExecutionEffort = totalEffort.String()
+	costs.TotalFees = totalFees.String()
+
+	return costs, nil
+}
+
+// reportDeployCosts collects the deployment costs and saves them to the report file if provided.
+//
+// Contracts are already deployed at this point, so failing to collect the costs is only reported as a warning.
+func reportDeployCosts(
+	flow flowkit.Services,
+	state *flowkit.State,
+	logger output.Logger,
+	contracts []*project.Contract,
+	file string,
+) (*deployCosts, error) {
+	costs, err := newDeployCosts(context.Background(), flow, contracts)
+	if err != nil {
+		logger.Info(fmt.Sprintf("%s Failed to collect deployment costs: %s", output.WarningEmoji(), err))
+		return nil, nil
+	}
+
+	if file != "" {
+		if err := costs.save(state, file); err != nil {
+			return nil, err
+		}
+	}
+
+	return costs, nil
+}
+
+// save the costs as a JSON report file.
+func (c *deployCosts) save(state *flowkit.State, file string) error {
+	raw, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = state.ReaderWriter().WriteFile(file, raw, os.FileMode(0644))
+	if err != nil {
+		return fmt.Errorf("failed to save deployment cost report: %w", err)
+	}
+
+	return nil
+}
+
+func (c *deployCosts) String() string {
+	var b bytes.Buffer
+	_, _ = fmt.Fprintf(&b, "Deployment costs:\n")
+	for _, tx := range c.Transactions {
+		_, _ = fmt.Fprintf(
+			&b,
+			"  %s (%s) fees %s FLOW, execution effort %s\n",
+			tx.Contract,
+			tx.TransactionID,
+			tx.Fees,
+			tx.ExecutionEffort,
+		)
+	}
+	_, _ = fmt.Fprintf(&b, "  Total fees %s FLOW, execution effort %s", c.TotalFees, c.TotalExecutionEffort)
+
+	return b.String()
+}
@@ -19,9 +19,11 @@
 package project
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	flowsdk "github.com/onflow/flow-go-sdk"
 	"github.com/spf13/cobra"
@@ -32,12 +34,21 @@ import (
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/flowkit/project"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/settings"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsDeploy struct {
-	Update   bool `flag:"update" default:"false" info:"use update flag to update existing contracts"`
-	ShowDiff bool `flag:"show-diff" default:"false" info:"use show-diff flag to show diff between existing and new contracts on update"`
+	Update      bool   `flag:"update" default:"false" info:"use update flag to update existing contracts, changes are shown and confirmation is required unless --yes is passed"`
+	ShowDiff    bool   `flag:"show-diff" default:"false" info:"use show-diff flag to show diff between existing and new contracts on update"`
+	MaxParallel int    `flag:"max-parallel" default:"1" info:"maximum number of accounts deployed to in parallel, by default contracts are deployed one by one"`
+	DryRun      bool   `flag:"dry-run" default:"false" info:"show the deploy plan without sending any transactions"`
+	SavePlan    string `flag:"save-plan" default:"" info:"save the deploy plan of a dry run to the JSON file"`
+	Plan        string `flag:"plan" default:"" info:"deploy exactly as specified by the deploy plan JSON file"`
+	Artifact    string `flag:"artifact" default:"" info:"deploy the contracts packed in the artifact JSON file without resolving them again"`
+	Prune       bool   `flag:"prune" default:"false" info:"remove contracts deployed to the deployment accounts which are no longer in the deployments"`
+	CostReport  string `flag:"cost-report" default:"" info:"save the fees and execution effort of the deployment transactions to the JSON file"`
+	Force       bool   `flag:"force" default:"false" info:"use force flag to update contracts even if the updates are incompatible with the deployed contracts"`
 }
 
 var deployFlags = flagsDeploy{}
@@ -46,7 +57,7 @@ var DeployCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "deploy",
 		Short:   "Deploy Cadence contracts",
-		Example: "flow project deploy --network testnet",
+		Example: "flow project deploy --network testnet\nflow project deploy --network testnet --dry-run --save-plan plan.json\nflow project deploy --network testnet --plan plan.json\nflow project deploy --network testnet --artifact artifact.json",
 	},
 	Flags: &deployFlags,
 	RunS:  deploy,
@@ -59,20 +70,92 @@ func deploy(
 	flow flowkit.Services,
 	state *flowkit.State,
 ) (command.Result, error) {
+	if deployFlags.Plan != "" {
+		plan, err := loadDeployPlan(state, deployFlags.Plan)
+		if err != nil {
+			return nil, err
+		}
+
+		return deployPlanned(plan, global, logger, flow, state)
+	}
+
+	if deployFlags.Artifact != "" {
+		artifact, err := loadDeployArtifact(state, deployFlags.Artifact)
+		if err != nil {
+			return nil, err
+		}
+
+		plan, err := artifact.plan(context.Background(), flow, deployFlags.Update)
+		if err != nil {
+			return nil, err
+		}
+
+		if deployFlags.DryRun {
+			return plan, nil
+		}
+
+		return deployPlanned(plan, global, logger, flow, state)
+	}
 
-	if flow.Network() == config.MainnetNetwork { // if using mainnet check for standard contract usage
+	if flow.Network().Name == config.MainnetNetwork.Name { // if using mainnet check for standard contract usage
 		err := checkForStandardContractUsageOnMainnet(state, logger, global.Yes)
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if deployFlags.DryRun {
+		plan, err := newDeployPlan(context.Background(), flow, state, deployFlags.Update, deployFlags.Prune)
+		if err != nil {
+			return nil, err
+		}
+
+		if deployFlags.SavePlan != "" {
+			if err := plan.save(state, deployFlags.SavePlan); err != nil {
+				return nil, err
+			}
+		}
+
+		return plan, nil
+	}
+
+	if deployFlags.Update {
+		for _, d := range state.Deployments().ByNetwork(flow.Network().Name) {
+			err := guardDeployment(settings.PolicyContractUpdate, "update contracts", global, flow, state, state.DeploymentAccountName(d.Account))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// report incompatible updates before any transaction is sent
+	if deployFlags.Update && !deployFlags.Force {
+		checks, err := checkContractUpdates(context.Background(), flow, state)
+		if err != nil {
+			return nil, err
+		}
+		if err := incompatibleUpdatesError(checks); err != nil {
+			return nil, err
+		}
+	}
+
 	deployFunc := flowkit.UpdateExistingContract(deployFlags.Update)
-	if deployFlags.ShowDiff {
-		deployFunc = util.ShowContractDiffPrompt(logger)
+	if deployFlags.Update || deployFlags.ShowDiff {
+		// show the changes of updated contracts and ask for confirmation unless approved upfront
+		deployFunc = util.ShowContractDiffPrompt(logger, global.Yes)
 	}
 
-	c, err := flow.DeployProject(context.Background(), deployFunc)
+	// hooks only run for contracts added or updated by the deployment
+	var pending map[string]bool
+	if hasHooks(state, flow.Network().Name) {
+		var err error
+		pending, err = pendingContracts(context.Background(), flow, state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := flow.DeployProjectParallel(context.Background(), deployFunc, deployFlags.MaxParallel)
 	if err != nil {
 		var projectErr *flowkit.ProjectDeploymentError
 		if errors.As(err, &projectErr) {
@@ -89,25 +172,140 @@ func deploy(
 		return nil, err
 	}
 
-	return &deployResult{c}, nil
+	if err := saveDeployHistory(flow, state, c); err != nil {
+		return nil, err
+	}
+
+	costs, err := reportDeployCosts(flow, state, logger, c, deployFlags.CostReport)
+	if err != nil {
+		return nil, err
+	}
+
+	if deployFlags.Prune {
+		err = pruneContracts(context.Background(), flow, state, logger, global)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	hooks, err := runDeploymentHooks(context.Background(), flow, state, logger, c, pending)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployResult{contracts: c, hooks: hooks, costs: costs}, nil
+}
+
+// deployPlanned executes the deploy plan and runs the hooks of the contracts it added or updated.
+func deployPlanned(
+	plan *deployPlan,
+	global command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	guarded := make(map[string]bool)
+	for _, op := range plan.Operations {
+		if (op.Action != planUpdate && op.Action != planRemove) || guarded[op.Action+op.Account] {
+			continue
+		}
+		guarded[op.Action+op.Account] = true
+
+		operation, description := settings.PolicyContractUpdate, "update contracts"
+		if op.Action == planRemove {
+			operation, description = settings.PolicyContractRemove, "remove contracts"
+		}
+
+		err := guardDeployment(operation, description, global, flow, state, op.Account)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// report incompatible updates before any transaction is sent
+	if !deployFlags.Force {
+		checks, err := checkPlanUpdates(context.Background(), flow, plan)
+		if err != nil {
+			return nil, err
+		}
+		if err := incompatibleUpdatesError(checks); err != nil {
+			return nil, err
+		}
+	}
+
+	c, err := executeDeployPlan(context.Background(), plan, logger, flow, state)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveDeployHistory(flow, state, c); err != nil {
+		return nil, err
+	}
+
+	costs, err := reportDeployCosts(flow, state, logger, c, deployFlags.CostReport)
+	if err != nil {
+		return nil, err
+	}
+
+	deployed := make(map[string]bool)
+	for _, op := range plan.Operations {
+		deployed[op.Contract] = op.Action == planAdd || op.Action == planUpdate
+	}
+
+	hooks, err := runDeploymentHooks(context.Background(), flow, state, logger, c, deployed)
+	if err != nil {
+		return nil, err
+	}
+
+	return &deployResult{contracts: c, hooks: hooks, costs: costs}, nil
 }
 
 type deployResult struct {
 	contracts []*project.Contract
+	hooks     []hookResult
+	costs     *deployCosts
 }
 
 func (r *deployResult) JSON() any {
-	result := make(map[string]any)
-
+	contracts := make(map[string]any)
 	for _, contract := range r.contracts {
-		result[contract.Name] = contract.AccountAddress.String()
+		contracts[contract.Name] = contract.AccountAddress.String()
+	}
+
+	result := map[string]any{"contracts": contracts}
+
+	if len(r.hooks) > 0 {
+		result["hooks"] = r.hooks
+	}
+
+	if r.costs != nil && len(r.costs.Transactions) > 0 {
+		result["costs"] = r.costs
 	}
 
 	return result
 }
 
 func (r *deployResult) String() string {
-	return ""
+	var b bytes.Buffer
+	if len(r.hooks) > 0 {
+		_, _ = fmt.Fprintf(&b, "Deployment hooks:\n")
+		for _, hook := range r.hooks {
+			if hook.Command != "" {
+				_, _ = fmt.Fprintf(&b, "  %s -> %s\n", hook.Contract, hook.Command)
+			} else {
+				_, _ = fmt.Fprintf(&b, "  %s -> %s (%s)\n", hook.Contract, hook.Transaction, hook.ID)
+			}
+		}
+	}
+
+	if r.costs != nil && len(r.costs.Transactions) > 0 {
+		if b.Len() > 0 {
+			_, _ = fmt.Fprintf(&b, "\n")
+		}
+		_, _ = fmt.Fprintf(&b, "%s\n", r.costs.String())
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
 }
 
 func (r *deployResult) Oneliner() string {
@@ -118,81 +316,28 @@ func (r *deployResult) Oneliner() string {
 // are referencing standard contract and if so warn the use that they should use the already
 // deployed contracts as an alias on mainnet instead of deploying their own copy.
 func checkForStandardContractUsageOnMainnet(state *flowkit.State, logger output.Logger, replace bool) error {
-	mainnetContracts := map[string]standardContract{
-		"FungibleToken": {
-			name:     "FungibleToken",
-			address:  flowsdk.HexToAddress("0xf233dcee88fe0abe"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/fungible-token",
-		},
-		"FlowToken": {
-			name:     "FlowToken",
-			address:  flowsdk.HexToAddress("0x1654653399040a61"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/flow-token",
-		},
-		"FlowFees": {
-			name:     "FlowFees",
-			address:  flowsdk.HexToAddress("0xf919ee77447b7497"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/flow-fees",
-		},
-		"FlowServiceAccount": {
-			name:     "FlowServiceAccount",
-			address:  flowsdk.HexToAddress("0xe467b9dd11fa00df"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/service-account",
-		},
-		"FlowStorageFees": {
-			name:     "FlowStorageFees",
-			address:  flowsdk.HexToAddress("0xe467b9dd11fa00df"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/service-account",
-		},
-		"FlowIDTableStaking": {
-			name:     "FlowIDTableStaking",
-			address:  flowsdk.HexToAddress("0x8624b52f9ddcd04a"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/staking-contract-reference",
-		},
-		"FlowEpoch": {
-			name:     "FlowEpoch",
-			address:  flowsdk.HexToAddress("0x8624b52f9ddcd04a"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/epoch-contract-reference",
-		},
-		"FlowClusterQC": {
-			name:     "FlowClusterQC",
-			address:  flowsdk.HexToAddress("0x8624b52f9ddcd04a"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/epoch-contract-reference",
-		},
-		"FlowDKG": {
-			name:     "FlowDKG",
-			address:  flowsdk.HexToAddress("0x8624b52f9ddcd04a"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/epoch-contract-reference",
-		},
-		"NonFungibleToken": {
-			name:     "NonFungibleToken",
-			address:  flowsdk.HexToAddress("0x1d7e57aa55817448"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/non-fungible-token",
-		},
-		"MetadataViews": {
-			name:     "MetadataViews",
-			address:  flowsdk.HexToAddress("0x1d7e57aa55817448"),
-			infoLink: "https://developers.flow.com/flow/core-contracts/nft-metadata",
-		},
-	}
-
 	contracts, err := state.DeploymentContractsByNetwork(config.MainnetNetwork)
 	if err != nil {
 		return err
 	}
 
 	for _, contract := range contracts {
-		standardContract, ok := mainnetContracts[contract.Name]
-		if !ok {
+		standardContract := util.CoreContractByName(contract.Name)
+		if standardContract == nil {
+			continue
+		}
+
+		address, err := standardContract.Address(config.MainnetNetwork.Name)
+		if err != nil {
 			continue
 		}
 
 		logger.Info(fmt.Sprintf("It seems like you are trying to deploy %s to Mainnet \n", contract.Name))
-		logger.Info(fmt.Sprintf("It is a standard contract already deployed at address 0x%s \n", standardContract.address.String()))
-		logger.Info(fmt.Sprintf("You can read more about it here: %s \n", standardContract.infoLink))
+		logger.Info(fmt.Sprintf("It is a standard contract already deployed at address 0x%s \n", address.String()))
+		logger.Info(fmt.Sprintf("You can read more about it here: %s \n", standardContract.InfoLink))
 
 		if replace || util.WantToUseMainnetVersionPrompt() {
-			err := replaceContractWithAlias(state, standardContract)
+			err := replaceContractWithAlias(state, standardContract.Name, address)
 			if err != nil {
 				return err
 			}
@@ -201,22 +346,16 @@ func checkForStandardContractUsageOnMainnet(state *flowkit.State, logger output.
 	return nil
 }
 
-type standardContract struct {
-	name     string
-	address  flowsdk.Address
-	infoLink string
-}
-
-func replaceContractWithAlias(state *flowkit.State, standardContract standardContract) error {
-	contract, err := state.Config().Contracts.ByName(standardContract.name)
+func replaceContractWithAlias(state *flowkit.State, name string, address flowsdk.Address) error {
+	contract, err := state.Config().Contracts.ByName(name)
 	if err != nil {
 		return err
 	}
-	contract.Aliases.Add(config.MainnetNetwork.Name, standardContract.address) // replace contract with an alias
+	contract.Aliases.Add(config.MainnetNetwork.Name, address) // replace contract with an alias
 
 	for di, d := range state.Config().Deployments.ByNetwork(config.MainnetNetwork.Name) {
 		for ci, c := range d.Contracts {
-			if c.Name == standardContract.name {
+			if c.Name == name {
 				state.Config().Deployments[di].Contracts = slices.Delete(state.Config().Deployments[di].Contracts, ci, ci+1)
 				if len(state.Config().Deployments[di].Contracts) == 0 {
 					_ = state.Config().Deployments.Remove(d.Account, d.Network)
@@ -227,3 +366,20 @@ func replaceContractWithAlias(state *flowkit.State, standardContract standardCon
 	}
 	return nil
 }
+
+// guardDeployment applies the mainnet policy to the operation on the contracts of the deployment account.
+func guardDeployment(
+	operation string,
+	description string,
+	global command.GlobalFlags,
+	flow flowkit.Services,
+	state *flowkit.State,
+	accountName string,
+) error {
+	account, err := state.Accounts().ByName(accountName)
+	if err != nil {
+		return err
+	}
+
+	return command.GuardMainnet(operation, fmt.Sprintf("%s of account %s", description, accountName), global, flow, state, account)
+}
@@ -0,0 +1,285 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+const (
+	diffInSync      = "in sync"
+	diffAhead       = "ahead"
+	diffBehind      = "behind"
+	diffDiverged    = "diverged"
+	diffNotDeployed = "not deployed"
+)
+
+var diffCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:               "diff <network> <other network>",
+		Short:             "Compare the deployed contracts between two networks",
+		Example:           "flow project diff testnet mainnet\nflow project diff testnet mainnet --output json",
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: command.CompleteArgs(command.CompleteNetworks, command.CompleteNetworks),
+	},
+	Flags: &struct{}{},
+	RunS:  diff,
+}
+
+func diff(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if args[0] == args[1] {
+		return nil, fmt.Errorf("networks to compare must be different")
+	}
+
+	services := make([]flowkit.Services, len(args))
+	for i, name := range args {
+		network, err := state.Networks().ByName(name)
+		if err != nil {
+			network, err = config.PresetNetworks.ByName(name)
+			if err != nil {
+				return nil, fmt.Errorf("network with name %s does not exist in configuration", name)
+			}
+		}
+
+		gw, err := gateway.NewHostGateway(*network)
+		if err != nil {
+			return nil, err
+		}
+		services[i] = flowkit.NewFlowkit(state, *network, gw, logger)
+	}
+
+	logger.StartProgress("Comparing deployed contracts...")
+	defer logger.StopProgress()
+
+	return diffNetworks(context.Background(), state, services[0], services[1])
+}
+
+// contractDiff is the result of comparing the contract deployed on two networks.
+//
+// Status is relative to the first network, a contract is ahead if the first network has the local
+// version of the contract deployed and behind if the other network has it.
+type contractDiff struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Diff   string `json:"diff,omitempty"`
+}
+
+// diffNetworks compares the code of all configured contracts deployed on the networks.
+//
+// Addresses of imports differ between networks, so imports are compared by the contract names only.
+func diffNetworks(ctx context.Context, state *flowkit.State, flow flowkit.Services, other flowkit.Services) (*diffResult, error) {
+	deployed, local, err := deployedContracts(ctx, state, flow)
+	if err != nil {
+		return nil, err
+	}
+
+	otherDeployed, otherLocal, err := deployedContracts(ctx, state, other)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &diffResult{
+		network:      flow.Network().Name,
+		otherNetwork: other.Network().Name,
+		contracts:    make([]contractDiff, 0, len(*state.Contracts())),
+	}
+
+	for _, contract := range *state.Contracts() {
+		code, exists := deployed[contract.Name]
+		otherCode, otherExists := otherDeployed[contract.Name]
+		localCode, ok := local[contract.Name]
+		if !ok {
+			localCode = otherLocal[contract.Name]
+		}
+
+		d := contractDiff{Name: contract.Name}
+		switch {
+		case !exists && !otherExists:
+			d.Status = diffNotDeployed
+		case !otherExists:
+			d.Status = diffAhead
+		case !exists:
+			d.Status = diffBehind
+		case normalizeCode(code) == normalizeCode(otherCode):
+			d.Status = diffInSync
+		case localCode != nil && normalizeCode(code) == normalizeCode(localCode):
+			d.Status = diffAhead
+		case localCode != nil && normalizeCode(otherCode) == normalizeCode(localCode):
+			d.Status = diffBehind
+		default:
+			d.Status = diffDiverged
+		}
+
+		if exists && otherExists && d.Status != diffInSync {
+			d.Diff = util.UnifiedDiff(string(otherCode), string(code), result.otherNetwork, result.network)
+		}
+
+		result.contracts = append(result.contracts, d)
+	}
+
+	return result, nil
+}
+
+// deployedContracts returns the code of the contracts deployed on the network by contract name,
+// and the local code of the contracts deployed by the network deployments.
+//
+// Contracts are looked up on the accounts of the network deployments and the network aliases.
+func deployedContracts(
+	ctx context.Context,
+	state *flowkit.State,
+	flow flowkit.Services,
+) (map[string][]byte, map[string][]byte, error) {
+	network := flow.Network()
+	addresses := make(map[string]flowsdk.Address)
+	local := make(map[string][]byte)
+
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, contract := range contracts {
+		addresses[contract.Name] = contract.AccountAddress
+		local[contract.Name] = stripImportAddresses(contract.Code())
+	}
+
+	for _, contract := range *state.Contracts() {
+		if _, ok := addresses[contract.Name]; ok {
+			continue
+		}
+		if alias := contract.Aliases.ByNetwork(network.Name); alias != nil {
+			addresses[contract.Name] = alias.Address
+		}
+	}
+
+	onChain := make(map[flowsdk.Address]map[string][]byte)
+	deployed := make(map[string][]byte)
+	for name, address := range addresses {
+		codes, ok := onChain[address]
+		if !ok {
+			account, err := flow.GetAccount(ctx, address)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to fetch account 0x%s on network %s: %w", address, network.Name, err)
+			}
+			codes = account.Contracts
+			onChain[address] = codes
+		}
+
+		if code, ok := codes[config.BaseName(name)]; ok {
+			deployed[name] = stripImportAddresses(code)
+		}
+	}
+
+	return deployed, local, nil
+}
+
+var (
+	importFromPattern   = regexp.MustCompile(`import(\s+[\w\s,]+?)\s+from\s+(?:0x[0-9a-fA-F]+|"[^"]*")`)
+	importStringPattern = regexp.MustCompile(`import\s+"([^"]+)"`)
+)
+
+// stripImportAddresses removes the import locations, so the imports only reference the contract names.
+func stripImportAddresses(code []byte) []byte {
+	code = importFromPattern.ReplaceAll(code, []byte("import$1"))
+	return importStringPattern.ReplaceAllFunc(code, func(match []byte) []byte {
+		location := string(importStringPattern.FindSubmatch(match)[1])
+		return []byte(fmt.Sprintf("import %s", strings.TrimSuffix(filepath.Base(location), ".cdc")))
+	})
+}
+
+type diffResult struct {
+	network      string
+	otherNetwork string
+	contracts    []contractDiff
+}
+
+func (r *diffResult) inSync() bool {
+	for _, c := range r.contracts {
+		if c.Status != diffInSync && c.Status != diffNotDeployed {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *diffResult) statusText(status string) string {
+	switch status {
+	case diffInSync:
+		return output.Green(status)
+	case diffAhead:
+		return output.Magenta(fmt.Sprintf("%s is ahead of %s", r.network, r.otherNetwork))
+	case diffBehind:
+		return output.Magenta(fmt.Sprintf("%s is behind %s", r.network, r.otherNetwork))
+	case diffDiverged:
+		return output.Red(status)
+	default:
+		return status
+	}
+}
+
+func (r *diffResult) JSON() any {
+	return map[string]any{
+		"network":      r.network,
+		"otherNetwork": r.otherNetwork,
+		"inSync":       r.inSync(),
+		"contracts":    r.contracts,
+	}
+}
+
+func (r *diffResult) String() string {
+	var b bytes.Buffer
+	for _, c := range r.contracts {
+		_, _ = fmt.Fprintf(&b, "%s: %s\n", c.Name, r.statusText(c.Status))
+		if c.Diff != "" {
+			_, _ = fmt.Fprintf(&b, "%s\n", c.Diff)
+		}
+	}
+
+	if r.inSync() {
+		_, _ = fmt.Fprintf(&b, "\n%s Contracts on networks %s and %s are in sync", output.SuccessEmoji(), r.network, r.otherNetwork)
+	} else {
+		_, _ = fmt.Fprintf(&b, "\n%s Contracts on networks %s and %s differ", output.ErrorEmoji(), r.network, r.otherNetwork)
+	}
+
+	return b.String()
+}
+
+func (r *diffResult) Oneliner() string {
+	return fmt.Sprintf("in sync: %t", r.inSync())
+}
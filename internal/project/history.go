@@ -0,0 +1,302 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+// historyFile is the local file recording all the project deploys.
+const historyFile = "flow.history.json"
+
+type deployHistory struct {
+	Deploys []historyDeploy `json:"deploys"`
+}
+
+type historyDeploy struct {
+	ID         int               `json:"id"`
+	Network    string            `json:"network"`
+	Timestamp  time.Time         `json:"timestamp"`
+	RollbackTo int               `json:"rollbackTo,omitempty"` // ID of the deploy this deploy rolled back to
+	Contracts  []historyContract `json:"contracts"`
+}
+
+type historyContract struct {
+	Contract      string            `json:"contract"`
+	Account       string            `json:"account"`
+	Address       string            `json:"address"`
+	CodeHash      string            `json:"codeHash"`
+	TransactionID string            `json:"transactionId"`
+	Args          []json.RawMessage `json:"args,omitempty"` // JSON-Cadence encoded initializer arguments
+	Code          string            `json:"code,omitempty"` // code with resolved imports
+}
+
+func loadHistory(state *flowkit.State) (*deployHistory, error) {
+	raw, err := state.ReaderWriter().ReadFile(historyFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return &deployHistory{Deploys: make([]historyDeploy, 0)}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deploy history: %w", err)
+	}
+
+	var history deployHistory
+	if err := json.Unmarshal(raw, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse deploy history: %w", err)
+	}
+
+	return &history, nil
+}
+
+func (h *deployHistory) save(state *flowkit.State) error {
+	raw, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = state.ReaderWriter().WriteFile(historyFile, raw, os.FileMode(0644))
+	if err != nil {
+		return fmt.Errorf("failed to save deploy history: %w", err)
+	}
+
+	return nil
+}
+
+func (h *deployHistory) byID(id int) (*historyDeploy, error) {
+	for i := range h.Deploys {
+		if h.Deploys[i].ID == id {
+			return &h.Deploys[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("deploy with ID %d not found in deploy history", id)
+}
+
+// recordDeploy adds the contracts deployed to the network to the history file, deploys without
+// any deployed contracts are not recorded.
+func recordDeploy(state *flowkit.State, network string, contracts []historyContract, rollbackTo int) error {
+	if len(contracts) == 0 {
+		return nil
+	}
+
+	history, err := loadHistory(state)
+	if err != nil {
+		return err
+	}
+
+	id := 1
+	if len(history.Deploys) > 0 {
+		id = history.Deploys[len(history.Deploys)-1].ID + 1
+	}
+
+	history.Deploys = append(history.Deploys, historyDeploy{
+		ID:         id,
+		Network:    network,
+		Timestamp:  time.Now().UTC(),
+		RollbackTo: rollbackTo,
+		Contracts:  contracts,
+	})
+
+	return history.save(state)
+}
+
+// saveDeployHistory records the contracts deployed by the project deployment in the history file.
+func saveDeployHistory(flow flowkit.Services, state *flowkit.State, contracts []*project.Contract) error {
+	records, err := deployedHistoryContracts(flow, state, contracts)
+	if err != nil {
+		return err
+	}
+
+	return recordDeploy(state, flow.Network().Name, records, 0)
+}
+
+// deployedHistoryContracts converts the contracts deployed by the project deployment to history records.
+func deployedHistoryContracts(
+	network flowkit.Services,
+	state *flowkit.State,
+	contracts []*project.Contract,
+) ([]historyContract, error) {
+	all, err := state.DeploymentContractsByNetwork(network.Network())
+	if err != nil {
+		return nil, err
+	}
+	replacer := project.NewImportReplacer(all, state.AliasesForNetwork(network.Network()))
+
+	records := make([]historyContract, 0)
+	for _, contract := range contracts {
+		if contract.TransactionID == flowsdk.EmptyID {
+			continue
+		}
+
+		program, err := project.NewProgram(contract.Code(), contract.Args, contract.Location())
+		if err != nil {
+			return nil, err
+		}
+		program, err = replacer.Replace(program)
+		if err != nil {
+			return nil, err
+		}
+
+		args := make([]json.RawMessage, 0, len(contract.Args))
+		for _, arg := range contract.Args {
+			encoded, err := jsoncdc.Encode(arg)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, bytes.TrimSpace(encoded))
+		}
+
+		records = append(records, newHistoryContract(
+			contract.Name,
+			contract.AccountName,
+			contract.AccountAddress,
+			program.Code(),
+			args,
+			contract.TransactionID,
+		))
+	}
+
+	return records, nil
+}
+
+func newHistoryContract(
+	name string,
+	account string,
+	address flowsdk.Address,
+	code []byte,
+	args []json.RawMessage,
+	txID flowsdk.Identifier,
+) historyContract {
+	hash := sha256.Sum256(code)
+
+	return historyContract{
+		Contract:      name,
+		Account:       account,
+		Address:       fmt.Sprintf("0x%s", address),
+		CodeHash:      hex.EncodeToString(hash[:]),
+		TransactionID: txID.String(),
+		Args:          args,
+		Code:          string(code),
+	}
+}
+
+var historyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "history",
+		Short:   "Show the history of project deploys on the network",
+		Example: "flow project history --network testnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	RunS:  history,
+}
+
+func history(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	all, err := loadHistory(state)
+	if err != nil {
+		return nil, err
+	}
+
+	deploys := make([]historyDeploy, 0)
+	for _, d := range all.Deploys {
+		if d.Network == flow.Network().Name {
+			deploys = append(deploys, d)
+		}
+	}
+
+	return &historyResult{network: flow.Network().Name, deploys: deploys}, nil
+}
+
+type historyResult struct {
+	network string
+	deploys []historyDeploy
+}
+
+func (r *historyResult) JSON() any {
+	deploys := make([]historyDeploy, 0, len(r.deploys))
+	for _, d := range r.deploys {
+		contracts := make([]historyContract, 0, len(d.Contracts))
+		for _, c := range d.Contracts {
+			c.Code = "" // code is only kept for rollbacks
+			contracts = append(contracts, c)
+		}
+		d.Contracts = contracts
+		deploys = append(deploys, d)
+	}
+
+	return deploys
+}
+
+func (r *historyResult) String() string {
+	if len(r.deploys) == 0 {
+		return fmt.Sprintf("No deploys recorded for network %s", r.network)
+	}
+
+	var b bytes.Buffer
+	for _, d := range r.deploys {
+		_, _ = fmt.Fprintf(&b, "Deploy %d at %s", d.ID, d.Timestamp.Format(time.RFC3339))
+		if d.RollbackTo != 0 {
+			_, _ = fmt.Fprintf(&b, " (rollback to deploy %d)", d.RollbackTo)
+		}
+		_, _ = fmt.Fprintf(&b, "\n")
+
+		for _, c := range d.Contracts {
+			_, _ = fmt.Fprintf(
+				&b,
+				"  %s -> %s (%s) hash %s, transaction %s\n",
+				c.Contract,
+				c.Account,
+				c.Address,
+				c.CodeHash[:16],
+				c.TransactionID,
+			)
+		}
+		_, _ = fmt.Fprintf(&b, "\n")
+	}
+
+	return strings.TrimSuffix(b.String(), "\n\n")
+}
+
+func (r *historyResult) Oneliner() string {
+	return fmt.Sprintf("%d deploys recorded for network %s", len(r.deploys), r.network)
+}
@@ -0,0 +1,180 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+)
+
+// hookResult reports a deployment hook run after the contract was deployed.
+type hookResult struct {
+	Contract    string `json:"contract"`
+	Transaction string `json:"transaction,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Command     string `json:"command,omitempty"`
+}
+
+// hasHooks checks whether any contract deployment on the network defines hooks.
+func hasHooks(state *flowkit.State, network string) bool {
+	for _, d := range state.Deployments().ByNetwork(network) {
+		for _, c := range d.Contracts {
+			if len(c.Hooks) > 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// pendingContracts returns the names of contracts which will be added or updated by the deployment.
+func pendingContracts(ctx context.Context, flow flowkit.Services, state *flowkit.State) (map[string]bool, error) {
+	plan, err := newDeployPlan(ctx, flow, state, true, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[string]bool)
+	for _, op := range plan.Operations {
+		pending[op.Contract] = op.Action == planAdd || op.Action == planUpdate
+	}
+
+	return pending, nil
+}
+
+// runDeploymentHooks runs the hooks of the deployed contracts in the deployment order.
+//
+// Hook transactions are signed by the account the contract was deployed to, and hook commands
+// get the deployment details in the FLOW_NETWORK, FLOW_CONTRACT, FLOW_ACCOUNT and FLOW_ADDRESS environment variables.
+func runDeploymentHooks(
+	ctx context.Context,
+	flow flowkit.Services,
+	state *flowkit.State,
+	logger output.Logger,
+	contracts []*project.Contract,
+	deployed map[string]bool,
+) ([]hookResult, error) {
+	network := flow.Network().Name
+	results := make([]hookResult, 0)
+
+	for _, contract := range contracts {
+		if !deployed[contract.Name] {
+			continue
+		}
+
+		deployment := state.DeploymentByAccountAndNetwork(contract.AccountName, network)
+		if deployment == nil {
+			continue
+		}
+
+		account, err := state.Accounts().ByName(contract.AccountName)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range deployment.Contracts {
+			if c.Name != contract.Name {
+				continue
+			}
+
+			for _, hook := range c.Hooks {
+				result, err := runDeploymentHook(ctx, flow, state, logger, account, contract.Name, hook)
+				if err != nil {
+					return nil, err
+				}
+				results = append(results, *result)
+			}
+		}
+	}
+
+	return results, nil
+}
+
+func runDeploymentHook(
+	ctx context.Context,
+	flow flowkit.Services,
+	state *flowkit.State,
+	logger output.Logger,
+	account *accounts.Account,
+	contract string,
+	hook config.DeploymentHook,
+) (*hookResult, error) {
+	if hook.Command != "" {
+		logger.StartProgress(fmt.Sprintf("Running hook command of contract %s...", contract))
+
+		cmd := exec.CommandContext(ctx, "sh", "-c", hook.Command)
+		if runtime.GOOS == "windows" {
+			cmd = exec.CommandContext(ctx, "cmd", "/C", hook.Command)
+		}
+		cmd.Env = append(
+			os.Environ(),
+			fmt.Sprintf("FLOW_NETWORK=%s", flow.Network().Name),
+			fmt.Sprintf("FLOW_CONTRACT=%s", contract),
+			fmt.Sprintf("FLOW_ACCOUNT=%s", account.Name),
+			fmt.Sprintf("FLOW_ADDRESS=0x%s", account.Address),
+		)
+
+		out, err := cmd.CombinedOutput()
+		logger.StopProgress()
+		if err != nil {
+			return nil, fmt.Errorf("hook command of contract %s failed: %w\n%s", contract, err, out)
+		}
+		if len(out) > 0 {
+			logger.Info(strings.TrimSuffix(string(out), "\n"))
+		}
+
+		logger.Info(fmt.Sprintf("%s hook -> %s", output.Green(contract), hook.Command))
+		return &hookResult{Contract: contract, Command: hook.Command}, nil
+	}
+
+	code, err := state.ReadFile(hook.Transaction)
+	if err != nil {
+		return nil, fmt.Errorf("error loading hook transaction of contract %s: %w", contract, err)
+	}
+
+	tx, result, err := flow.SendTransaction(
+		ctx,
+		transactions.SingleAccountRole(*account),
+		flowkit.Script{Code: code, Args: hook.Args, Location: hook.Transaction},
+		flowsdk.DefaultTransactionGasLimit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("hook transaction %s of contract %s failed: %w", hook.Transaction, contract, err)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("hook transaction %s of contract %s failed: %w", hook.Transaction, contract, result.Error)
+	}
+
+	logger.Info(fmt.Sprintf("%s hook -> %s (%s)", output.Green(contract), hook.Transaction, tx.ID()))
+	return &hookResult{Contract: contract, Transaction: hook.Transaction, ID: tx.ID().String()}, nil
+}
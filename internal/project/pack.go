@@ -0,0 +1,265 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+var packCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "pack <file>",
+		Short:   "Pack the contracts with resolved imports into an artifact for deploying later",
+		Example: "flow project pack artifact.json --network testnet\nflow project deploy --network testnet --artifact artifact.json",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &struct{}{},
+	RunS:  pack,
+}
+
+func pack(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	artifact, err := newDeployArtifact(state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := artifact.save(state, args[0]); err != nil {
+		return nil, err
+	}
+
+	return &packResult{artifact: artifact, file: args[0]}, nil
+}
+
+// deployArtifact contains the contracts of the network deployments with resolved imports in the deployment order.
+//
+// The artifact is built once and deployed later without resolving the contracts again,
+// so exactly the audited code is deployed.
+type deployArtifact struct {
+	Network   string             `json:"network"`
+	Contracts []artifactContract `json:"contracts"`
+}
+
+type artifactContract struct {
+	Contract string            `json:"contract"`
+	Account  string            `json:"account"`
+	Address  string            `json:"address"`
+	Location string            `json:"location"`
+	Args     []json.RawMessage `json:"args,omitempty"` // JSON-Cadence encoded values
+	Code     string            `json:"code"`           // code with resolved imports
+	CodeHash string            `json:"codeHash"`       // SHA2-256 hash of the code
+}
+
+// newDeployArtifact resolves the network deployment contracts without accessing the network.
+func newDeployArtifact(state *flowkit.State, network config.Network) (*deployArtifact, error) {
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := state.AliasesForNetwork(network)
+	deployment, err := project.NewDeployment(contracts, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	err = deployment.ValidateArguments()
+	if err != nil {
+		return nil, err
+	}
+
+	sorted, err := deployment.Sort()
+	if err != nil {
+		return nil, err
+	}
+
+	replacer := project.NewImportReplacer(contracts, aliases)
+	artifact := &deployArtifact{
+		Network:   network.Name,
+		Contracts: make([]artifactContract, 0, len(sorted)),
+	}
+
+	for _, contract := range sorted {
+		program, err := project.NewProgram(contract.Code(), contract.Args, contract.Location())
+		if err != nil {
+			return nil, err
+		}
+
+		program, err = replacer.Replace(program)
+		if err != nil {
+			return nil, err
+		}
+
+		args := make([]json.RawMessage, 0, len(contract.Args))
+		for _, arg := range contract.Args {
+			encoded, err := jsoncdc.Encode(arg)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, bytes.TrimSpace(encoded))
+		}
+
+		artifact.Contracts = append(artifact.Contracts, artifactContract{
+			Contract: contract.Name,
+			Account:  contract.AccountName,
+			Address:  fmt.Sprintf("0x%s", contract.AccountAddress),
+			Location: contract.Location(),
+			Args:     args,
+			Code:     string(program.Code()),
+			CodeHash: codeHash(program.Code()),
+		})
+	}
+
+	return artifact, nil
+}
+
+func codeHash(code []byte) string {
+	hash := sha256.Sum256(code)
+	return hex.EncodeToString(hash[:])
+}
+
+// save the artifact as a JSON file.
+func (a *deployArtifact) save(state *flowkit.State, file string) error {
+	raw, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = state.ReaderWriter().WriteFile(file, raw, os.FileMode(0644))
+	if err != nil {
+		return fmt.Errorf("failed to save deploy artifact: %w", err)
+	}
+
+	return nil
+}
+
+// loadDeployArtifact loads the artifact and makes sure the contracts code wasn't changed since it was packed.
+func loadDeployArtifact(state *flowkit.State, file string) (*deployArtifact, error) {
+	raw, err := state.ReaderWriter().ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deploy artifact: %w", err)
+	}
+
+	var artifact deployArtifact
+	if err := json.Unmarshal(raw, &artifact); err != nil {
+		return nil, fmt.Errorf("failed to parse deploy artifact: %w", err)
+	}
+
+	for _, c := range artifact.Contracts {
+		if codeHash([]byte(c.Code)) != c.CodeHash {
+			return nil, fmt.Errorf("code of contract %s doesn't match the artifact code hash %s", c.Contract, c.CodeHash)
+		}
+	}
+
+	return &artifact, nil
+}
+
+// plan creates the deploy plan of the artifact contracts based on the contracts currently deployed on the network.
+func (a *deployArtifact) plan(ctx context.Context, flow flowkit.Services, update bool) (*deployPlan, error) {
+	if a.Network != flow.Network().Name {
+		return nil, fmt.Errorf("deploy artifact was packed for network %s, but network %s is used", a.Network, flow.Network().Name)
+	}
+
+	onChain := make(map[flowsdk.Address]map[string][]byte)
+	plan := &deployPlan{
+		Network:    a.Network,
+		Operations: make([]planOperation, 0, len(a.Contracts)),
+	}
+
+	for _, c := range a.Contracts {
+		address := flowsdk.HexToAddress(c.Address)
+		existing, ok := onChain[address]
+		if !ok {
+			account, err := flow.GetAccount(ctx, address)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch account %s: %w", c.Account, err)
+			}
+			existing = account.Contracts
+			onChain[address] = existing
+		}
+
+		action := planAdd
+		if code, exists := existing[config.BaseName(c.Contract)]; exists {
+			action = planUnchanged
+			if string(code) != c.Code {
+				if !update {
+					return nil, fmt.Errorf("contract %s exists in account %s, use --update flag to update it", c.Contract, c.Account)
+				}
+				action = planUpdate
+			}
+		}
+
+		plan.Operations = append(plan.Operations, planOperation{
+			Action:   action,
+			Contract: c.Contract,
+			Account:  c.Account,
+			Address:  c.Address,
+			Location: c.Location,
+			Args:     c.Args,
+			Code:     c.Code,
+		})
+	}
+
+	return plan, nil
+}
+
+type packResult struct {
+	artifact *deployArtifact
+	file     string
+}
+
+func (r *packResult) JSON() any {
+	return r.artifact
+}
+
+func (r *packResult) String() string {
+	var b bytes.Buffer
+	_, _ = fmt.Fprintf(&b, "Packed %d contracts for network %s:\n", len(r.artifact.Contracts), r.artifact.Network)
+	for _, c := range r.artifact.Contracts {
+		_, _ = fmt.Fprintf(&b, "%s -> %s (%s) hash %s\n", c.Contract, c.Account, c.Address, c.CodeHash[:16])
+	}
+	_, _ = fmt.Fprintf(&b, "\n%s Deploy artifact saved to %s", output.SuccessEmoji(), r.file)
+
+	return b.String()
+}
+
+func (r *packResult) Oneliner() string {
+	return fmt.Sprintf("Packed %d contracts to %s", len(r.artifact.Contracts), r.file)
+}
@@ -0,0 +1,330 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"golang.org/x/exp/maps"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+)
+
+const (
+	planAdd       = "add"
+	planUpdate    = "update"
+	planUnchanged = "unchanged"
+	planRemove    = "remove"
+)
+
+// deployPlan is the ordered list of contract operations a deployment performs on the network.
+type deployPlan struct {
+	Network    string          `json:"network"`
+	Operations []planOperation `json:"operations"`
+}
+
+type planOperation struct {
+	Action   string            `json:"action"`
+	Contract string            `json:"contract"`
+	Account  string            `json:"account"`
+	Address  string            `json:"address"`
+	Location string            `json:"location"`
+	Imports  map[string]string `json:"imports,omitempty"` // import locations with resolved addresses
+	Args     []json.RawMessage `json:"args,omitempty"`    // JSON-Cadence encoded values
+	Code     string            `json:"code"`              // code with resolved imports
+}
+
+// newDeployPlan creates the deploy plan for the network without sending any transactions.
+//
+// Existing contracts with changed code are planned for update only if updating is allowed, and if pruning is enabled
+// the removal of contracts no longer listed in the deployments is planned after all the deployments.
+func newDeployPlan(
+	ctx context.Context,
+	flow flowkit.Services,
+	state *flowkit.State,
+	update bool,
+	prune bool,
+) (*deployPlan, error) {
+	network := flow.Network()
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	aliases := state.AliasesForNetwork(network)
+	deployment, err := project.NewDeployment(contracts, aliases)
+	if err != nil {
+		return nil, err
+	}
+
+	err = deployment.ValidateArguments()
+	if err != nil {
+		return nil, err
+	}
+
+	sorted, err := deployment.Sort()
+	if err != nil {
+		return nil, err
+	}
+
+	replacer := project.NewImportReplacer(contracts, aliases)
+	onChain := make(map[flowsdk.Address]map[string][]byte)
+	plan := &deployPlan{
+		Network:    network.Name,
+		Operations: make([]planOperation, 0, len(sorted)),
+	}
+
+	for _, contract := range sorted {
+		program, err := project.NewProgram(contract.Code(), contract.Args, contract.Location())
+		if err != nil {
+			return nil, err
+		}
+
+		imports, err := replacer.Imports(program)
+		if err != nil {
+			return nil, err
+		}
+		for location, address := range imports {
+			imports[location] = fmt.Sprintf("0x%s", address)
+		}
+
+		program, err = replacer.Replace(program)
+		if err != nil {
+			return nil, err
+		}
+
+		args := make([]json.RawMessage, 0, len(contract.Args))
+		for _, arg := range contract.Args {
+			encoded, err := jsoncdc.Encode(arg)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, bytes.TrimSpace(encoded))
+		}
+
+		existing, ok := onChain[contract.AccountAddress]
+		if !ok {
+			account, err := flow.GetAccount(ctx, contract.AccountAddress)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch account %s: %w", contract.AccountName, err)
+			}
+			existing = account.Contracts
+			onChain[contract.AccountAddress] = existing
+		}
+
+		action := planAdd
+		if code, exists := existing[config.BaseName(contract.Name)]; exists {
+			action = planUnchanged
+			if !bytes.Equal(code, program.Code()) {
+				if !update {
+					return nil, fmt.Errorf("contract %s exists in account %s, use --update flag to plan the update", contract.Name, contract.AccountName)
+				}
+				action = planUpdate
+			}
+		}
+
+		plan.Operations = append(plan.Operations, planOperation{
+			Action:   action,
+			Contract: contract.Name,
+			Account:  contract.AccountName,
+			Address:  fmt.Sprintf("0x%s", contract.AccountAddress),
+			Location: contract.Location(),
+			Imports:  imports,
+			Args:     args,
+			Code:     string(program.Code()),
+		})
+	}
+
+	if prune {
+		stale, err := staleContracts(ctx, flow, state)
+		if err != nil {
+			return nil, err
+		}
+
+		accountNames := maps.Keys(stale)
+		sort.Strings(accountNames)
+		for _, name := range accountNames {
+			account, err := state.Accounts().ByName(name)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, contract := range stale[name] {
+				plan.Operations = append(plan.Operations, planOperation{
+					Action:   planRemove,
+					Contract: contract,
+					Account:  account.Name,
+					Address:  fmt.Sprintf("0x%s", account.Address),
+				})
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+// save the plan as a JSON file which can be executed later.
+func (p *deployPlan) save(state *flowkit.State, file string) error {
+	raw, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = state.ReaderWriter().WriteFile(file, raw, os.FileMode(0644))
+	if err != nil {
+		return fmt.Errorf("failed to save deploy plan: %w", err)
+	}
+
+	return nil
+}
+
+func loadDeployPlan(state *flowkit.State, file string) (*deployPlan, error) {
+	raw, err := state.ReaderWriter().ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deploy plan: %w", err)
+	}
+
+	var plan deployPlan
+	if err := json.Unmarshal(raw, &plan); err != nil {
+		return nil, fmt.Errorf("failed to parse deploy plan: %w", err)
+	}
+
+	return &plan, nil
+}
+
+// executeDeployPlan deploys the contracts exactly as specified in the plan, in the planned order.
+func executeDeployPlan(
+	ctx context.Context,
+	plan *deployPlan,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) ([]*project.Contract, error) {
+	if plan.Network != flow.Network().Name {
+		return nil, fmt.Errorf("deploy plan was created for network %s, but network %s is used", plan.Network, flow.Network().Name)
+	}
+
+	logger.Info(fmt.Sprintf("\nExecuting deploy plan with %d contracts on network %s\n", len(plan.Operations), plan.Network))
+	defer logger.StopProgress()
+
+	contracts := make([]*project.Contract, 0, len(plan.Operations))
+	for _, op := range plan.Operations {
+		account, err := state.Accounts().ByName(op.Account)
+		if err != nil {
+			return nil, err
+		}
+		if account.Address != flowsdk.HexToAddress(op.Address) {
+			return nil, fmt.Errorf("address of account %s doesn't match the planned address %s", op.Account, op.Address)
+		}
+
+		args := make([]cadence.Value, 0, len(op.Args))
+		for _, raw := range op.Args {
+			arg, err := jsoncdc.Decode(nil, raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid argument of contract %s: %w", op.Contract, err)
+			}
+			args = append(args, arg)
+		}
+
+		if op.Action == planRemove {
+			if err := removeDeployedContract(ctx, flow, state, logger, account, op.Contract); err != nil {
+				return nil, fmt.Errorf("failed to remove contract %s: %w", op.Contract, err)
+			}
+			continue
+		}
+
+		contract := project.NewContract(op.Contract, op.Location, []byte(op.Code), account.Address, account.Name, args)
+		contracts = append(contracts, contract)
+
+		switch op.Action {
+		case planUnchanged:
+			logger.Info(fmt.Sprintf("%s -> %s [skipping, no changes found]", output.Italic(op.Contract), op.Address))
+			continue
+		case planAdd, planUpdate:
+		default:
+			return nil, fmt.Errorf("invalid action %s for contract %s in deploy plan", op.Action, op.Contract)
+		}
+
+		txID, _, err := flow.AddContract(
+			ctx,
+			account,
+			flowkit.Script{Code: []byte(op.Code), Args: args, Location: op.Location},
+			flowkit.UpdateExistingContract(op.Action == planUpdate),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deploy contract %s: %w", op.Contract, err)
+		}
+		contract.TransactionID = txID
+
+		logger.Info(fmt.Sprintf(
+			"%s -> %s (%s) %s",
+			output.Green(op.Contract),
+			op.Address,
+			txID.String(),
+			map[bool]string{true: "[updated]", false: ""}[op.Action == planUpdate],
+		))
+	}
+
+	logger.Info(fmt.Sprintf("\n%s All contracts deployed successfully", output.SuccessEmoji()))
+	return contracts, nil
+}
+
+func (p *deployPlan) JSON() any {
+	return p
+}
+
+func (p *deployPlan) String() string {
+	var b bytes.Buffer
+	_, _ = fmt.Fprintf(&b, "Deploy plan for network %s:\n", p.Network)
+
+	for i, op := range p.Operations {
+		_, _ = fmt.Fprintf(&b, "\n%d. %s %s -> %s (%s)\n", i+1, op.Action, op.Contract, op.Account, op.Address)
+
+		locations := make([]string, 0, len(op.Imports))
+		for location := range op.Imports {
+			locations = append(locations, location)
+		}
+		sort.Strings(locations)
+		for _, location := range locations {
+			_, _ = fmt.Fprintf(&b, "   import %s from %s\n", location, op.Imports[location])
+		}
+
+		for _, arg := range op.Args {
+			_, _ = fmt.Fprintf(&b, "   argument %s\n", arg)
+		}
+	}
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (p *deployPlan) Oneliner() string {
+	return fmt.Sprintf("Deploy plan for network %s with %d contracts", p.Network, len(p.Operations))
+}
@@ -31,4 +31,11 @@ var Cmd = &cobra.Command{
 
 func init() {
 	DeployCommand.AddToParent(Cmd)
+	verifyCommand.AddToParent(Cmd)
+	diffCommand.AddToParent(Cmd)
+	checkUpdateCommand.AddToParent(Cmd)
+	packCommand.AddToParent(Cmd)
+	removeContractCommand.AddToParent(Cmd)
+	historyCommand.AddToParent(Cmd)
+	rollbackCommand.AddToParent(Cmd)
 }
@@ -19,15 +19,23 @@
 package project
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
+	"github.com/onflow/cadence"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/mocks"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
@@ -36,7 +44,7 @@ func Test_ProjectDeploy(t *testing.T) {
 	srv, state, rw := util.TestMocks(t)
 
 	t.Run("Fail contract errors", func(t *testing.T) {
-		srv.DeployProject.Return(nil, &flowkit.ProjectDeploymentError{})
+		srv.DeployProjectParallel.Return(nil, &flowkit.ProjectDeploymentError{})
 		_, err := deploy([]string{}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
 		assert.EqualError(t, err, "failed deploying all contracts")
 	})
@@ -75,4 +83,579 @@ func Test_ProjectDeploy(t *testing.T) {
 		assert.Equal(t, "f233dcee88fe0abe", c.Aliases.ByNetwork(config.MainnetNetwork.Name).Address.String())
 	})
 
+	t.Run("Dry run plan and execute", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		for _, c := range []tests.Resource{tests.ContractA, tests.ContractB, tests.ContractSimpleWithArgs} {
+			state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+		}
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: config.EmulatorNetwork.Name,
+			Account: config.DefaultEmulator.ServiceAccount,
+			Contracts: []config.ContractDeployment{
+				{Name: tests.ContractB.Name},
+				{Name: tests.ContractA.Name},
+				{Name: tests.ContractSimpleWithArgs.Name, Args: []cadence.Value{cadence.UInt64(1)}},
+			},
+		})
+		acc, err := state.EmulatorServiceAccount()
+		require.NoError(t, err)
+
+		plan, err := newDeployPlan(context.Background(), srv.Mock, state, false, false)
+		require.NoError(t, err)
+		require.Len(t, plan.Operations, 3)
+		assert.Equal(t, tests.ContractA.Name, plan.Operations[0].Contract)
+		assert.Equal(t, tests.ContractB.Name, plan.Operations[1].Contract)
+		assert.Equal(t, planAdd, plan.Operations[1].Action)
+		assert.Equal(t, map[string]string{"./contractA.cdc": "0x" + acc.Address.String()}, plan.Operations[1].Imports)
+		assert.Contains(t, plan.Operations[1].Code, "import ContractA from 0x"+acc.Address.String())
+		assert.Equal(t, `{"value":"1","type":"UInt64"}`, string(plan.Operations[2].Args[0]))
+		srv.Mock.AssertNotCalled(t, "AddContract")
+
+		require.NoError(t, plan.save(state, "plan.json"))
+		loaded, err := loadDeployPlan(state, "plan.json")
+		require.NoError(t, err)
+
+		deployed := make([]flowkit.Script, 0)
+		srv.AddContract.Run(func(args mock.Arguments) {
+			deployed = append(deployed, args.Get(2).(flowkit.Script))
+		})
+
+		contracts, err := executeDeployPlan(context.Background(), loaded, util.NoLogger, srv.Mock, state)
+		require.NoError(t, err)
+		assert.Len(t, contracts, 3)
+		require.Len(t, deployed, 3)
+		assert.Equal(t, plan.Operations[1].Code, string(deployed[1].Code))
+		assert.Equal(t, []cadence.Value{cadence.UInt64(1)}, deployed[2].Args)
+
+		loaded.Network = config.TestnetNetwork.Name
+		_, err = executeDeployPlan(context.Background(), loaded, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "deploy plan was created for network testnet, but network emulator is used")
+	})
+}
+
+func Test_ProjectVerify(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	for _, c := range []tests.Resource{tests.ContractA, tests.ContractB, tests.ContractHelloString} {
+		state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+	}
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network: config.EmulatorNetwork.Name,
+		Account: config.DefaultEmulator.ServiceAccount,
+		Contracts: []config.ContractDeployment{
+			{Name: tests.ContractA.Name},
+			{Name: tests.ContractB.Name},
+			{Name: tests.ContractHelloString.Name},
+		},
+	})
+	acc, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(acc.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractA.Name: []byte("// ContractA\npub  contract ContractA {}"),
+			tests.ContractB.Name: []byte(fmt.Sprintf("import ContractA from 0x%s\npub contract ContractB {\n}", acc.Address)),
+		}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	result, err := verify(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+
+	res := result.(*verifyResult)
+	require.Len(t, res.contracts, 3)
+	verifications := make(map[string]contractVerification)
+	for _, c := range res.contracts {
+		verifications[c.Name] = c
+	}
+
+	assert.Equal(t, verifyNormalized, verifications[tests.ContractA.Name].Status)
+	assert.Equal(t, "1:1", verifications[tests.ContractA.Name].FirstDifference)
+	assert.Equal(t, verifyMismatch, verifications[tests.ContractB.Name].Status)
+	assert.Contains(t, verifications[tests.ContractB.Name].Diff, "-pub contract ContractB {\n-}\n")
+	assert.Equal(t, verifyNotDeployed, verifications[tests.ContractHelloString.Name].Status)
+	assert.False(t, res.verified())
+
+	assert.Equal(t, "pub contract A { let s = \"a // b\" }", normalizeCode([]byte("pub contract A {\n  // comment\n  let s = \"a // b\" /* block */\n}")))
+}
+
+func Test_ProjectDiff(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	for _, c := range []tests.Resource{tests.ContractA, tests.ContractB, tests.ContractHelloString, tests.ContractC} {
+		state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+	}
+	for _, network := range []config.Network{config.EmulatorNetwork, config.TestnetNetwork} {
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network: network.Name,
+			Account: config.DefaultEmulator.ServiceAccount,
+			Contracts: []config.ContractDeployment{
+				{Name: tests.ContractA.Name},
+				{Name: tests.ContractB.Name},
+				{Name: tests.ContractHelloString.Name},
+			},
+		})
+	}
+	acc, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(acc.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractA.Name: []byte("pub contract ContractA {}"),
+			tests.ContractB.Name: []byte("import ContractA from 0x01cf0e2f2f715450\npub contract ContractB {}"),
+		}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	other := mocks.DefaultMockServices()
+	other.Network.Return(config.TestnetNetwork)
+	other.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(acc.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractA.Name:           []byte("// ContractA\npub  contract ContractA {}\n"),
+			tests.ContractB.Name:           []byte("import ContractA from 0x179b6b1cb6755e31\npub contract ContractB { pub let x: Int }"),
+			tests.ContractHelloString.Name: tests.ContractHelloString.Source,
+		}
+		other.GetAccount.Return(account, nil)
+	})
+
+	result, err := diffNetworks(context.Background(), state, srv.Mock, other.Mock)
+	require.NoError(t, err)
+	require.Len(t, result.contracts, 4)
+
+	diffs := make(map[string]contractDiff)
+	for _, c := range result.contracts {
+		diffs[c.Name] = c
+	}
+
+	assert.Equal(t, diffInSync, diffs[tests.ContractA.Name].Status)
+	assert.Equal(t, diffAhead, diffs[tests.ContractB.Name].Status)
+	assert.Contains(t, diffs[tests.ContractB.Name].Diff, " import ContractA\n-pub contract ContractB { pub let x: Int }\n+pub contract ContractB {}\n")
+	assert.Equal(t, diffBehind, diffs[tests.ContractHelloString.Name].Status)
+	assert.Equal(t, diffNotDeployed, diffs[tests.ContractC.Name].Status)
+	assert.False(t, result.inSync())
+
+	assert.Equal(
+		t,
+		"import Foo\nimport Bar\nimport Baz, Qux\n",
+		string(stripImportAddresses([]byte("import Foo from 0x01\nimport \"./contracts/Bar.cdc\"\nimport Baz, Qux from \"Baz\"\n"))),
+	)
+}
+
+func Test_ProjectCheckUpdate(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	for _, c := range []tests.Resource{tests.ContractA, tests.ContractB, tests.ContractHelloString} {
+		state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+	}
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network: config.EmulatorNetwork.Name,
+		Account: config.DefaultEmulator.ServiceAccount,
+		Contracts: []config.ContractDeployment{
+			{Name: tests.ContractA.Name},
+			{Name: tests.ContractB.Name},
+			{Name: tests.ContractHelloString.Name},
+		},
+	})
+	acc, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(acc.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractB.Name: []byte(fmt.Sprintf(
+				"import ContractA from 0x%s\npub contract ContractB {\n pub let x: Int\n init() { self.x = 1 }\n}",
+				acc.Address,
+			)),
+			tests.ContractHelloString.Name: []byte(`
+				pub contract Hello {
+					pub let greeting: Int
+					init() {
+						self.greeting = 1
+					}
+				}`),
+		}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	result, err := checkUpdate(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+
+	res := result.(*checkUpdateResult)
+	require.Len(t, res.contracts, 3)
+	checks := make(map[string]updateCheck)
+	for _, c := range res.contracts {
+		checks[c.Name] = c
+	}
+
+	assert.Equal(t, updateNew, checks[tests.ContractA.Name].Status)
+	assert.Equal(t, updateCompatible, checks[tests.ContractB.Name].Status)
+	assert.Equal(t, updateIncompatible, checks[tests.ContractHelloString.Name].Status)
+	require.Len(t, checks[tests.ContractHelloString.Name].Errors, 1)
+	assert.Contains(t, checks[tests.ContractHelloString.Name].Errors[0], "mismatching field `greeting` in `Hello`")
+	assert.False(t, res.compatible())
+
+	err = incompatibleUpdatesError(res.contracts)
+	assert.ErrorContains(t, err, "incompatible contract updates, use --force flag to deploy anyway:\nHello:\n  - ")
+
+	plan := &deployPlan{
+		Network: config.EmulatorNetwork.Name,
+		Operations: []planOperation{{
+			Action:   planUpdate,
+			Contract: tests.ContractHelloString.Name,
+			Account:  acc.Name,
+			Address:  acc.Address.String(),
+			Code:     string(tests.ContractHelloString.Source),
+		}},
+	}
+	_, err = deployPlanned(plan, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	assert.ErrorContains(t, err, "incompatible contract updates, use --force flag to deploy anyway:\nHello:\n  - ")
+	srv.Mock.AssertNotCalled(t, "AddContract", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func Test_ProjectPack(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	for _, c := range []tests.Resource{tests.ContractA, tests.ContractB} {
+		state.Contracts().AddOrUpdate(config.Contract{Name: c.Name, Location: c.Filename})
+	}
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network: config.EmulatorNetwork.Name,
+		Account: config.DefaultEmulator.ServiceAccount,
+		Contracts: []config.ContractDeployment{
+			{Name: tests.ContractB.Name},
+			{Name: tests.ContractA.Name},
+		},
+	})
+	acc, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	result, err := pack([]string{"artifact.json"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+	packed := result.(*packResult).artifact
+	require.Len(t, packed.Contracts, 2)
+	assert.Equal(t, tests.ContractA.Name, packed.Contracts[0].Contract)
+	assert.Equal(t, tests.ContractB.Name, packed.Contracts[1].Contract)
+	assert.Contains(t, packed.Contracts[1].Code, fmt.Sprintf("import ContractA from 0x%s", acc.Address))
+
+	artifact, err := loadDeployArtifact(state, "artifact.json")
+	require.NoError(t, err)
+	require.Len(t, artifact.Contracts, 2)
+	assert.Equal(t, packed.Contracts[1].Code, artifact.Contracts[1].Code)
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(acc.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractA.Name: tests.ContractA.Source,
+		}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	plan, err := artifact.plan(context.Background(), srv.Mock, false)
+	require.NoError(t, err)
+	require.Len(t, plan.Operations, 2)
+	assert.Equal(t, planUnchanged, plan.Operations[0].Action)
+	assert.Equal(t, planAdd, plan.Operations[1].Action)
+	assert.Equal(t, artifact.Contracts[1].Code, plan.Operations[1].Code)
+
+	artifact.Network = config.TestnetNetwork.Name
+	_, err = artifact.plan(context.Background(), srv.Mock, false)
+	assert.EqualError(t, err, "deploy artifact was packed for network testnet, but network emulator is used")
+
+	// changed code is detected when loading the artifact
+	artifact.Contracts[1].Code = "pub contract ContractB { pub let x: Int }"
+	require.NoError(t, artifact.save(state, "artifact.json"))
+	_, err = loadDeployArtifact(state, "artifact.json")
+	assert.EqualError(t, err, fmt.Sprintf("code of contract ContractB doesn't match the artifact code hash %s", artifact.Contracts[1].CodeHash))
+
+	_, err = rw.ReadFile("artifact.json")
+	assert.NoError(t, err)
+}
+
+func Test_ProjectDeployCosts(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+
+	fees := func(amount string, effort string) flow.Event {
+		a, _ := cadence.NewUFix64(amount)
+		e, _ := cadence.NewUFix64(effort)
+		return *tests.NewEvent(0,
+			"A.f919ee77447b7497.FlowFees.FeesDeducted",
+			[]cadence.Field{
+				{Identifier: "amount", Type: cadence.UFix64Type{}},
+				{Identifier: "inclusionEffort", Type: cadence.UFix64Type{}},
+				{Identifier: "executionEffort", Type: cadence.UFix64Type{}},
+			},
+			[]cadence.Value{a, cadence.UFix64(100000000), e},
+		)
+	}
+
+	srv.GetTransactionByID.Run(func(args mock.Arguments) {
+		id := args.Get(1).(flow.Identifier)
+		event := fees("0.00001000", "0.00002000")
+		if id == flow.HexToID("02") {
+			event = fees("0.00003000", "0.00004000")
+		}
+		srv.GetTransactionByID.Return(tests.NewTransaction(), tests.NewTransactionResult([]flow.Event{event}), nil)
+	})
+
+	contractA := project.NewContract(tests.ContractA.Name, tests.ContractA.Filename, tests.ContractA.Source, flow.HexToAddress("01"), "alice", nil)
+	contractA.TransactionID = flow.HexToID("01")
+	contractB := project.NewContract(tests.ContractB.Name, tests.ContractB.Filename, tests.ContractB.Source, flow.HexToAddress("01"), "alice", nil)
+	contractB.TransactionID = flow.HexToID("02")
+	unchanged := project.NewContract(tests.ContractC.Name, tests.ContractC.Filename, tests.ContractC.Source, flow.HexToAddress("01"), "alice", nil)
+
+	costs, err := reportDeployCosts(srv.Mock, state, util.NoLogger, []*project.Contract{contractA, contractB, unchanged}, "costs.json")
+	require.NoError(t, err)
+	require.Len(t, costs.Transactions, 2)
+	assert.Equal(t, "0.00001000", costs.Transactions[0].Fees)
+	assert.Equal(t, "0.00004000", costs.Transactions[1].ExecutionEffort)
+	assert.Equal(t, "0.00004000", costs.TotalFees)
+	assert.Equal(t, "0.00006000", costs.TotalExecutionEffort)
+
+	raw, err := rw.ReadFile("costs.json")
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"totalFees": "0.00004000"`)
+
+	result := &deployResult{contracts: []*project.Contract{contractA, contractB}, costs: costs}
+	assert.Contains(t, result.String(), "Total fees 0.00004000 FLOW, execution effort 0.00006000")
+	assert.Equal(t, map[string]any{
+		"contracts": map[string]any{
+			tests.ContractA.Name: "0000000000000001",
+			tests.ContractB.Name: "0000000000000001",
+		},
+		"costs": costs,
+	}, result.JSON())
+}
+
+func Test_ProjectRemoveContract(t *testing.T) {
+	setup := func(t *testing.T) (*mocks.MockServices, *flowkit.State, *accounts.Account) {
+		srv, state, _ := util.TestMocks(t)
+		state.Contracts().AddOrUpdate(config.Contract{Name: tests.ContractA.Name, Location: tests.ContractA.Filename})
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   config.DefaultEmulator.ServiceAccount,
+			Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}},
+		})
+		acc, err := state.EmulatorServiceAccount()
+		require.NoError(t, err)
+
+		return srv, state, acc
+	}
+
+	t.Run("Remove contract", func(t *testing.T) {
+		srv, state, acc := setup(t)
+
+		result, err := removeContract(
+			[]string{tests.ContractA.Name},
+			command.GlobalFlags{ConfigPaths: []string{"test.json"}},
+			util.NoLogger,
+			srv.Mock,
+			state,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, "Contracts [ContractA] removed from account emulator-account and project deployments", result.String())
+		srv.Mock.AssertCalled(t, "RemoveContract", mock.Anything, acc, tests.ContractA.Name)
+		assert.Empty(t, state.Deployments().ByNetwork(config.EmulatorNetwork.Name))
+
+		_, err = removeContract([]string{"Missing"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "contract Missing is not deployed on network emulator, use --account flag to specify the account")
+	})
+
+	t.Run("Prune stale contracts", func(t *testing.T) {
+		srv, state, acc := setup(t)
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			account := tests.NewAccountWithAddress(acc.Address.String())
+			account.Contracts = map[string][]byte{
+				tests.ContractA.Name: tests.ContractA.Source,
+				"Stale":              []byte("pub contract Stale {}"),
+			}
+			srv.GetAccount.Return(account, nil)
+		})
+
+		stale, err := staleContracts(context.Background(), srv.Mock, state)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{acc.Name: {"Stale"}}, stale)
+
+		plan, err := newDeployPlan(context.Background(), srv.Mock, state, false, true)
+		require.NoError(t, err)
+		require.Len(t, plan.Operations, 2)
+		assert.Equal(t, planRemove, plan.Operations[1].Action)
+		assert.Equal(t, "Stale", plan.Operations[1].Contract)
+
+		err = pruneContracts(context.Background(), srv.Mock, state, util.NoLogger, command.GlobalFlags{Yes: true})
+		require.NoError(t, err)
+		srv.Mock.AssertCalled(t, "RemoveContract", mock.Anything, acc, "Stale")
+		srv.Mock.AssertNotCalled(t, "RemoveContract", mock.Anything, acc, tests.ContractA.Name)
+	})
+}
+
+func Test_ProjectDeploymentHooks(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	state.Contracts().AddOrUpdate(config.Contract{Name: tests.ContractA.Name, Location: tests.ContractA.Filename})
+	state.Contracts().AddOrUpdate(config.Contract{Name: tests.ContractB.Name, Location: tests.ContractB.Filename})
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network: config.EmulatorNetwork.Name,
+		Account: config.DefaultEmulator.ServiceAccount,
+		Contracts: []config.ContractDeployment{
+			{Name: tests.ContractA.Name, Hooks: []config.DeploymentHook{
+				{Transaction: tests.TransactionSimple.Filename},
+				{Command: "echo $FLOW_CONTRACT $FLOW_NETWORK"},
+			}},
+			{Name: tests.ContractB.Name, Hooks: []config.DeploymentHook{{Command: "exit 1"}}},
+		},
+	})
+	acc, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	require.True(t, hasHooks(state, config.EmulatorNetwork.Name))
+
+	srv.SendTransaction.Run(func(args mock.Arguments) {
+		roles := args.Get(1).(transactions.AccountRoles)
+		assert.Equal(t, acc.Name, roles.Proposer.Name)
+		assert.Equal(t, acc.Name, roles.Authorizers[0].Name)
+		assert.Equal(t, tests.TransactionSimple.Filename, args.Get(2).(flowkit.Script).Location)
+	}).Return(tests.NewTransaction(), &flow.TransactionResult{}, nil)
+
+	contracts, err := state.DeploymentContractsByNetwork(config.EmulatorNetwork)
+	require.NoError(t, err)
+
+	pending, err := pendingContracts(context.Background(), srv.Mock, state)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{tests.ContractA.Name: true, tests.ContractB.Name: true}, pending)
+
+	// only hooks of deployed contracts run
+	results, err := runDeploymentHooks(
+		context.Background(),
+		srv.Mock,
+		state,
+		util.NoLogger,
+		contracts,
+		map[string]bool{tests.ContractA.Name: true},
+	)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, tests.TransactionSimple.Filename, results[0].Transaction)
+	assert.Equal(t, "echo $FLOW_CONTRACT $FLOW_NETWORK", results[1].Command)
+
+	_, err = runDeploymentHooks(context.Background(), srv.Mock, state, util.NoLogger, contracts, pending)
+	assert.ErrorContains(t, err, "hook command of contract ContractB failed")
+}
+
+func Test_ProjectRollback(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	acc, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	v1 := []byte("pub contract ContractA { pub let version: Int; init() { self.version = 1 } }")
+	v2 := []byte("pub contract ContractA { pub let version: Int; init() { self.version = 2 } }")
+	txID := flow.HexToID("01")
+
+	err = recordDeploy(state, config.EmulatorNetwork.Name, []historyContract{
+		newHistoryContract(tests.ContractA.Name, acc.Name, acc.Address, v1, nil, txID),
+	}, 0)
+	require.NoError(t, err)
+	err = recordDeploy(state, config.EmulatorNetwork.Name, []historyContract{
+		newHistoryContract(tests.ContractA.Name, acc.Name, acc.Address, v2, nil, txID),
+		newHistoryContract(tests.ContractB.Name, acc.Name, acc.Address, tests.ContractB.Source, nil, txID),
+	}, 0)
+	require.NoError(t, err)
+
+	result, err := history(nil, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+	require.NoError(t, err)
+	assert.Equal(t, "2 deploys recorded for network emulator", result.Oneliner())
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(acc.Address.String())
+		account.Contracts = map[string][]byte{tests.ContractA.Name: v2}
+		srv.GetAccount.Return(account, nil)
+	})
+
+	h, err := loadHistory(state)
+	require.NoError(t, err)
+
+	_, err = rollbackTo(context.Background(), command.GlobalFlags{}, srv.Mock, state, util.NoLogger, h, 5, false)
+	assert.EqualError(t, err, "deploy with ID 5 not found in deploy history")
+
+	// rolling back to a version with a different field type is an incompatible update
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(acc.Address.String())
+		account.Contracts = map[string][]byte{
+			tests.ContractA.Name: []byte("pub contract ContractA { pub let version: String; init() { self.version = \"2\" } }"),
+		}
+		srv.GetAccount.Return(account, nil)
+	})
+	_, err = rollbackTo(context.Background(), command.GlobalFlags{}, srv.Mock, state, util.NoLogger, h, 1, false)
+	assert.ErrorContains(t, err, "incompatible contract updates, use --force flag to deploy anyway:\nContractA:\n  - ")
+	srv.Mock.AssertNotCalled(t, "AddContract", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	srv.GetAccount.Run(func(args mock.Arguments) {
+		account := tests.NewAccountWithAddress(acc.Address.String())
+		account.Contracts = map[string][]byte{tests.ContractA.Name: v2}
+		srv.GetAccount.Return(account, nil)
+	})
+	rolledBack, err := rollbackTo(context.Background(), command.GlobalFlags{}, srv.Mock, state, util.NoLogger, h, 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{tests.ContractA.Name}, rolledBack.redeployed)
+	assert.Equal(t, []string{fmt.Sprintf("0x%s.%s", acc.Address, tests.ContractB.Name)}, rolledBack.skipped)
+	srv.Mock.AssertCalled(t, "AddContract", mock.Anything, acc, mock.MatchedBy(func(script flowkit.Script) bool {
+		return string(script.Code) == string(v1)
+	}), mock.Anything)
+
+	h, err = loadHistory(state)
+	require.NoError(t, err)
+	require.Len(t, h.Deploys, 3)
+	assert.Equal(t, 1, h.Deploys[2].RollbackTo)
+	assert.Equal(t, h.Deploys[0].Contracts[0].CodeHash, h.Deploys[2].Contracts[0].CodeHash)
+}
+
+func Test_ProjectRollbackOrder(t *testing.T) {
+	srv, state, rw := util.TestMocks(t)
+	acc, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+
+	// Apple imports Zebra, so Zebra is redeployed first although it is sorted after Apple
+	zebra := []byte(`pub contract Zebra {}`)
+	apple := []byte("import \"Zebra\"\npub contract Apple {}")
+	require.NoError(t, rw.WriteFile("zebra.cdc", zebra, 0644))
+	require.NoError(t, rw.WriteFile("apple.cdc", apple, 0644))
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Apple", Location: "apple.cdc"})
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Zebra", Location: "zebra.cdc"})
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   acc.Name,
+		Contracts: []config.ContractDeployment{{Name: "Apple"}, {Name: "Zebra"}},
+	})
+
+	resolvedApple := []byte(fmt.Sprintf("import Zebra from 0x%s\npub contract Apple {}", acc.Address))
+	err = recordDeploy(state, config.EmulatorNetwork.Name, []historyContract{
+		newHistoryContract("Zebra", acc.Name, acc.Address, zebra, nil, flow.HexToID("01")),
+		newHistoryContract("Apple", acc.Name, acc.Address, resolvedApple, nil, flow.HexToID("01")),
+	}, 0)
+	require.NoError(t, err)
+
+	// both contracts were removed, redeploying Apple fails
+	srv.GetAccount.Return(tests.NewAccountWithAddress(acc.Address.String()), nil)
+	redeployed := make([]string, 0)
+	srv.AddContract.Run(func(mock.Arguments) {}).Return(
+		func(_ context.Context, _ *accounts.Account, script flowkit.Script, _ flowkit.UpdateContract) (flow.Identifier, bool, error) {
+			if string(script.Code) == string(resolvedApple) {
+				return flow.EmptyID, false, fmt.Errorf("failed sending transaction")
+			}
+			redeployed = append(redeployed, string(script.Code))
+			return flow.HexToID("02"), false, nil
+		},
+		false,
+		nil,
+	)
+
+	h, err := loadHistory(state)
+	require.NoError(t, err)
+	_, err = rollbackTo(context.Background(), command.GlobalFlags{}, srv.Mock, state, util.NoLogger, h, 1, false)
+	assert.EqualError(t, err, "failed to roll back contract Apple: failed sending transaction")
+	assert.Equal(t, []string{string(zebra)}, redeployed)
+
+	// the contract rolled back before the failure is recorded
+	h, err = loadHistory(state)
+	require.NoError(t, err)
+	require.Len(t, h.Deploys, 2)
+	assert.Equal(t, 1, h.Deploys[1].RollbackTo)
+	require.Len(t, h.Deploys[1].Contracts, 1)
+	assert.Equal(t, "Zebra", h.Deploys[1].Contracts[0].Contract)
 }
@@ -0,0 +1,248 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/settings"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsRemoveContract struct {
+	Account string `flag:"account" default:"" info:"account name the contract is removed from, defaults to the account the contract is deployed to on the network"`
+}
+
+var removeContractFlags = flagsRemoveContract{}
+
+var removeContractCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:               "remove-contract <name>",
+		Short:             "Remove a deployed contract from the network and project deployments",
+		Example:           "flow project remove-contract HelloWorld --network testnet --account testnet-account",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: command.CompleteContracts,
+	},
+	Flags: &removeContractFlags,
+	RunS:  removeContract,
+}
+
+func removeContract(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	name := args[0]
+	network := flow.Network().Name
+
+	accountName := removeContractFlags.Account
+	if accountName == "" {
+		for _, d := range state.Deployments().ByNetwork(network) {
+			for _, c := range d.Contracts {
+				if c.Name == name {
+					accountName = state.DeploymentAccountName(d.Account)
+				}
+			}
+		}
+		if accountName == "" {
+			return nil, fmt.Errorf("contract %s is not deployed on network %s, use --account flag to specify the account", name, network)
+		}
+	}
+
+	account, err := state.Accounts().ByName(accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = command.GuardMainnet(
+		settings.PolicyContractRemove,
+		fmt.Sprintf("remove contract %s from account %s", name, account.Name),
+		globalFlags,
+		flow,
+		state,
+		account,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	err = removeDeployedContract(context.Background(), flow, state, logger, account, name)
+	if err != nil {
+		return nil, err
+	}
+
+	err = state.SaveEdited(globalFlags.ConfigPaths)
+	if err != nil {
+		return nil, err
+	}
+
+	return &removeContractResult{
+		contracts: []string{name},
+		account:   account,
+	}, nil
+}
+
+// removeDeployedContract removes the contract from the account and from the account deployment on the network.
+func removeDeployedContract(
+	ctx context.Context,
+	flow flowkit.Services,
+	state *flowkit.State,
+	logger output.Logger,
+	account *accounts.Account,
+	name string,
+) error {
+	id, err := flow.RemoveContract(ctx, account, name)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf(
+		"%s -> 0x%s (%s) [removed]",
+		output.Red(name),
+		account.Address,
+		id.String(),
+	))
+
+	deployment := state.DeploymentByAccountAndNetwork(account.Name, flow.Network().Name)
+	if deployment != nil {
+		deployment.RemoveContract(name)
+		if len(deployment.Contracts) == 0 {
+			_ = state.Deployments().Remove(deployment.Account, flow.Network().Name)
+		}
+	}
+
+	return nil
+}
+
+// staleContracts returns the contract names by account for all contracts deployed on-chain to the network
+// deployment accounts that are no longer listed in the account deployments.
+func staleContracts(ctx context.Context, flow flowkit.Services, state *flowkit.State) (map[string][]string, error) {
+	stale := make(map[string][]string)
+
+	for _, d := range state.Deployments().ByNetwork(flow.Network().Name) {
+		account, err := state.Accounts().ByName(state.DeploymentAccountName(d.Account))
+		if err != nil {
+			return nil, err
+		}
+
+		onChain, err := flow.GetAccount(ctx, account.Address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch account %s: %w", account.Name, err)
+		}
+
+		names := maps.Keys(onChain.Contracts)
+		sort.Strings(names)
+		for _, name := range names {
+			deployed := false
+			for _, c := range d.Contracts {
+				deployed = deployed || config.BaseName(c.Name) == name
+			}
+			if !deployed {
+				stale[account.Name] = append(stale[account.Name], name)
+			}
+		}
+	}
+
+	return stale, nil
+}
+
+// pruneContracts removes the contracts deployed to the network deployment accounts which are no longer
+// listed in the deployments, the removal must be confirmed unless approved upfront.
+func pruneContracts(
+	ctx context.Context,
+	flow flowkit.Services,
+	state *flowkit.State,
+	logger output.Logger,
+	global command.GlobalFlags,
+) error {
+	stale, err := staleContracts(ctx, flow, state)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	accountNames := maps.Keys(stale)
+	sort.Strings(accountNames)
+
+	contracts := make([]string, 0)
+	for _, name := range accountNames {
+		for _, contract := range stale[name] {
+			contracts = append(contracts, fmt.Sprintf("%s (%s)", contract, name))
+		}
+	}
+
+	if !global.Yes && !util.PruneContractsPrompt(contracts) {
+		return nil
+	}
+
+	for _, name := range accountNames {
+		account, err := state.Accounts().ByName(name)
+		if err != nil {
+			return err
+		}
+
+		err = guardDeployment(settings.PolicyContractRemove, "remove contracts", global, flow, state, name)
+		if err != nil {
+			return err
+		}
+
+		for _, contract := range stale[name] {
+			if err := removeDeployedContract(ctx, flow, state, logger, account, contract); err != nil {
+				return fmt.Errorf("failed to remove contract %s: %w", contract, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+type removeContractResult struct {
+	contracts []string
+	account   *accounts.Account
+}
+
+func (r *removeContractResult) JSON() any {
+	return map[string]any{
+		"account":   r.account.Name,
+		"address":   r.account.Address.String(),
+		"contracts": r.contracts,
+	}
+}
+
+func (r *removeContractResult) String() string {
+	return fmt.Sprintf("Contracts %v removed from account %s and project deployments", r.contracts, r.account.Name)
+}
+
+func (r *removeContractResult) Oneliner() string {
+	return r.String()
+}
@@ -0,0 +1,325 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/settings"
+)
+
+type flagsRollback struct {
+	To    int  `flag:"to" default:"0" info:"ID of the deploy to roll back to, see flow project history"`
+	Force bool `flag:"force" default:"false" info:"use force flag to roll back contracts even if the updates are incompatible with the deployed contracts"`
+}
+
+var rollbackFlags = flagsRollback{}
+
+var rollbackCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "rollback",
+		Short:   "Redeploy contract versions of a previous project deploy",
+		Example: "flow project rollback --to 3 --network testnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &rollbackFlags,
+	RunS:  rollback,
+}
+
+func rollback(
+	_ []string,
+	global command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if rollbackFlags.To == 0 {
+		return nil, fmt.Errorf("deploy ID is required, use --to flag to specify it")
+	}
+
+	history, err := loadHistory(state)
+	if err != nil {
+		return nil, err
+	}
+
+	return rollbackTo(context.Background(), global, flow, state, logger, history, rollbackFlags.To, rollbackFlags.Force)
+}
+
+// rollbackTo redeploys the contract versions that were current after the target deploy
+// and records the rollback as a new deploy in the history.
+//
+// Updates of deployed contracts are guarded by the mainnet policy of the accounts and validated the same way
+// as project deploy updates, unless forced.
+func rollbackTo(
+	ctx context.Context,
+	global command.GlobalFlags,
+	flow flowkit.Services,
+	state *flowkit.State,
+	logger output.Logger,
+	history *deployHistory,
+	id int,
+	force bool,
+) (*rollbackResult, error) {
+	network := flow.Network().Name
+
+	target, err := history.byID(id)
+	if err != nil {
+		return nil, err
+	}
+	if target.Network != network {
+		return nil, fmt.Errorf("deploy %d was made on network %s, but network %s is used", id, target.Network, network)
+	}
+
+	// latest version of every contract as of the target deploy, keyed by address and contract name
+	versions := make(map[string]historyContract)
+	later := make(map[string]bool)
+	for _, d := range history.Deploys {
+		if d.Network != network {
+			continue
+		}
+		for _, c := range d.Contracts {
+			key := c.Address + "." + c.Contract
+			if d.ID <= id {
+				versions[key] = c
+			} else if _, ok := versions[key]; !ok {
+				later[key] = true
+			}
+		}
+	}
+
+	// imported contracts are redeployed first, in the deployment order of the configured contracts
+	order, err := deploymentOrder(state, flow.Network())
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(versions))
+	for key := range versions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	sort.SliceStable(keys, func(i, j int) bool {
+		first, configured := order[keys[i]]
+		second, otherConfigured := order[keys[j]]
+		if configured != otherConfigured {
+			return configured
+		}
+		return first < second
+	})
+
+	logger.Info(fmt.Sprintf("Rolling back contracts on network %s to deploy %d\n", network, id))
+	defer logger.StopProgress()
+
+	result := &rollbackResult{network: network, to: id}
+	redeploys := make([]rollbackContract, 0)
+	checks := make([]updateCheck, 0)
+	guarded := make(map[string]bool)
+	for _, key := range keys {
+		version := versions[key]
+
+		account, err := state.Accounts().ByName(version.Account)
+		if err != nil {
+			return nil, err
+		}
+		if fmt.Sprintf("0x%s", account.Address) != version.Address {
+			return nil, fmt.Errorf("address of account %s doesn't match the recorded address %s", version.Account, version.Address)
+		}
+
+		onChain, err := flow.GetAccount(ctx, account.Address)
+		if err != nil {
+			return nil, err
+		}
+		deployed, exists := onChain.Contracts[version.Contract]
+		if exists {
+			hash := sha256.Sum256(deployed)
+			if hex.EncodeToString(hash[:]) == version.CodeHash {
+				logger.Info(fmt.Sprintf("%s -> %s [skipping, already at deploy version]", output.Italic(version.Contract), version.Address))
+				result.unchanged = append(result.unchanged, version.Contract)
+				continue
+			}
+
+			if !guarded[account.Name] {
+				guarded[account.Name] = true
+				err := guardDeployment(settings.PolicyContractUpdate, "roll back contracts", global, flow, state, account.Name)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			check := updateCheck{
+				Name:    version.Contract,
+				Account: account.Name,
+				Address: version.Address,
+				Errors:  validateContractUpdate(account.Address, version.Contract, deployed, []byte(version.Code)),
+				Status:  updateCompatible,
+			}
+			if len(check.Errors) > 0 {
+				check.Status = updateIncompatible
+			}
+			checks = append(checks, check)
+		}
+
+		args := make([]cadence.Value, 0, len(version.Args))
+		for _, raw := range version.Args {
+			arg, err := jsoncdc.Decode(nil, raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid argument of contract %s: %w", version.Contract, err)
+			}
+			args = append(args, arg)
+		}
+
+		redeploys = append(redeploys, rollbackContract{version: version, account: account, args: args, exists: exists})
+	}
+
+	// report incompatible updates before any transaction is sent
+	if !force {
+		if err := incompatibleUpdatesError(checks); err != nil {
+			return nil, err
+		}
+	}
+
+	records := make([]historyContract, 0)
+	for _, redeploy := range redeploys {
+		version, account := redeploy.version, redeploy.account
+
+		txID, _, err := flow.AddContract(
+			ctx,
+			account,
+			flowkit.Script{Code: []byte(version.Code), Args: redeploy.args},
+			flowkit.UpdateExistingContract(redeploy.exists),
+		)
+		if err != nil {
+			err = fmt.Errorf("failed to roll back contract %s: %w", version.Contract, err)
+			// contracts rolled back before the failure are already changed on chain
+			if len(records) > 0 {
+				if recordErr := recordDeploy(state, network, records, id); recordErr != nil {
+					return nil, fmt.Errorf("%w, the rolled back contracts were not recorded: %s", err, recordErr)
+				}
+			}
+			return nil, err
+		}
+
+		logger.Info(fmt.Sprintf("%s -> %s (%s) [rolled back]", output.Green(version.Contract), version.Address, txID.String()))
+		result.redeployed = append(result.redeployed, version.Contract)
+		records = append(records, newHistoryContract(
+			version.Contract,
+			version.Account,
+			account.Address,
+			[]byte(version.Code),
+			version.Args,
+			txID,
+		))
+	}
+
+	for key := range later {
+		result.skipped = append(result.skipped, key)
+	}
+	sort.Strings(result.skipped)
+	for _, key := range result.skipped {
+		logger.Info(fmt.Sprintf(
+			"%s Contract %s was first deployed after deploy %d and is left unchanged",
+			output.WarningEmoji(),
+			key,
+			id,
+		))
+	}
+
+	if err := recordDeploy(state, network, records, id); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// deploymentOrder returns the position of every contract configured for the network in the deployment order,
+// keyed by the account address and contract name.
+func deploymentOrder(state *flowkit.State, network config.Network) (map[string]int, error) {
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment, err := project.NewDeployment(contracts, state.AliasesForNetwork(network))
+	if err != nil {
+		return nil, err
+	}
+
+	sorted, err := deployment.Sort()
+	if err != nil {
+		return nil, err
+	}
+
+	order := make(map[string]int, len(sorted))
+	for i, contract := range sorted {
+		order[fmt.Sprintf("0x%s.%s", contract.AccountAddress, contract.Name)] = i
+	}
+	return order, nil
+}
+
+// rollbackContract is a contract version redeployed by the rollback.
+type rollbackContract struct {
+	version historyContract
+	account *accounts.Account
+	args    []cadence.Value
+	exists  bool // whether the contract is deployed and is updated
+}
+
+type rollbackResult struct {
+	network    string
+	to         int
+	redeployed []string
+	unchanged  []string
+	skipped    []string // contracts deployed only after the target deploy
+}
+
+func (r *rollbackResult) JSON() any {
+	return map[string]any{
+		"network":    r.network,
+		"to":         r.to,
+		"redeployed": r.redeployed,
+		"unchanged":  r.unchanged,
+		"skipped":    r.skipped,
+	}
+}
+
+func (r *rollbackResult) String() string {
+	var b bytes.Buffer
+	_, _ = fmt.Fprintf(&b, "Rolled back to deploy %d on network %s\n", r.to, r.network)
+	_, _ = fmt.Fprintf(&b, "Redeployed: %d, unchanged: %d, skipped: %d", len(r.redeployed), len(r.unchanged), len(r.skipped))
+
+	return b.String()
+}
+
+func (r *rollbackResult) Oneliner() string {
+	return fmt.Sprintf("rolled back %d contracts to deploy %d", len(r.redeployed), r.to)
+}
@@ -0,0 +1,250 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package project
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+const (
+	verifyMatch       = "match"
+	verifyNormalized  = "normalized match"
+	verifyMismatch    = "mismatch"
+	verifyNotDeployed = "not deployed"
+)
+
+var verifyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "verify",
+		Short:   "Verify the deployed contracts match the local contracts",
+		Example: "flow project verify --network mainnet\nflow project verify --network mainnet --output json",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	RunS:  verify,
+}
+
+func verify(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	logger.StartProgress("Verifying deployed contracts...")
+	defer logger.StopProgress()
+
+	contracts, err := verifyContracts(context.Background(), flow, state)
+	if err != nil {
+		return nil, err
+	}
+
+	return &verifyResult{network: flow.Network().Name, contracts: contracts}, nil
+}
+
+// contractVerification is the result of comparing the local contract with the contract deployed on-chain.
+type contractVerification struct {
+	Name    string `json:"name"`
+	Account string `json:"account"`
+	Address string `json:"address"`
+	Status  string `json:"status"`
+	// FirstDifference is the line and column of the first differing byte, e.g. "3:14"
+	FirstDifference string `json:"firstDifference,omitempty"`
+	Diff            string `json:"diff,omitempty"`
+}
+
+// verifyContracts compares the deployment contracts with resolved imports to the code deployed on the network.
+func verifyContracts(ctx context.Context, flow flowkit.Services, state *flowkit.State) ([]contractVerification, error) {
+	network := flow.Network()
+	contracts, err := state.DeploymentContractsByNetwork(network)
+	if err != nil {
+		return nil, err
+	}
+
+	replacer := project.NewImportReplacer(contracts, state.AliasesForNetwork(network))
+	onChain := make(map[flowsdk.Address]map[string][]byte)
+	verifications := make([]contractVerification, 0, len(contracts))
+
+	for _, contract := range contracts {
+		program, err := project.NewProgram(contract.Code(), contract.Args, contract.Location())
+		if err != nil {
+			return nil, err
+		}
+
+		program, err = replacer.Replace(program)
+		if err != nil {
+			return nil, err
+		}
+
+		deployed, ok := onChain[contract.AccountAddress]
+		if !ok {
+			account, err := flow.GetAccount(ctx, contract.AccountAddress)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch account %s: %w", contract.AccountName, err)
+			}
+			deployed = account.Contracts
+			onChain[contract.AccountAddress] = deployed
+		}
+
+		verification := contractVerification{
+			Name:    contract.Name,
+			Account: contract.AccountName,
+			Address: fmt.Sprintf("0x%s", contract.AccountAddress),
+		}
+
+		code, exists := deployed[config.BaseName(contract.Name)]
+		local := program.Code()
+		switch {
+		case !exists:
+			verification.Status = verifyNotDeployed
+		case bytes.Equal(code, local):
+			verification.Status = verifyMatch
+		default:
+			verification.FirstDifference = firstDifference(code, local)
+			verification.Status = verifyMismatch
+			if normalizeCode(code) == normalizeCode(local) {
+				verification.Status = verifyNormalized
+			} else {
+				verification.Diff = util.UnifiedDiff(string(code), string(local), "deployed", "local")
+			}
+		}
+
+		verifications = append(verifications, verification)
+	}
+
+	return verifications, nil
+}
+
+// firstDifference returns the line and column of the first byte that differs between the codes.
+func firstDifference(a []byte, b []byte) string {
+	line, column := 1, 1
+	for i := 0; i < len(a) && i < len(b) && a[i] == b[i]; i++ {
+		column++
+		if a[i] == '\n' {
+			line++
+			column = 1
+		}
+	}
+
+	return fmt.Sprintf("%d:%d", line, column)
+}
+
+// normalizeCode removes comments and collapses whitespace, so only the meaningful code is compared.
+func normalizeCode(code []byte) string {
+	var out strings.Builder
+	inString := false
+
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		switch {
+		case inString:
+			out.WriteByte(c)
+			if c == '\\' && i+1 < len(code) {
+				i++
+				out.WriteByte(code[i])
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+			out.WriteByte(c)
+		case c == '/' && i+1 < len(code) && code[i+1] == '/':
+			for i < len(code) && code[i] != '\n' {
+				i++
+			}
+			out.WriteByte(' ')
+		case c == '/' && i+1 < len(code) && code[i+1] == '*':
+			end := bytes.Index(code[i+2:], []byte("*/"))
+			if end == -1 {
+				i = len(code)
+			} else {
+				i += end + 3
+			}
+			out.WriteByte(' ')
+		default:
+			out.WriteByte(c)
+		}
+	}
+
+	return strings.Join(strings.Fields(out.String()), " ")
+}
+
+type verifyResult struct {
+	network   string
+	contracts []contractVerification
+}
+
+func (r *verifyResult) verified() bool {
+	for _, c := range r.contracts {
+		if c.Status != verifyMatch && c.Status != verifyNormalized {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *verifyResult) JSON() any {
+	return map[string]any{
+		"network":   r.network,
+		"verified":  r.verified(),
+		"contracts": r.contracts,
+	}
+}
+
+func (r *verifyResult) String() string {
+	var b bytes.Buffer
+	for _, c := range r.contracts {
+		status := output.Green(c.Status)
+		if c.Status == verifyNormalized {
+			status = fmt.Sprintf("%s (whitespace or comments differ from %s)", output.Green(c.Status), c.FirstDifference)
+		} else if c.Status != verifyMatch {
+			status = output.Red(c.Status)
+		}
+		_, _ = fmt.Fprintf(&b, "%s -> %s (%s): %s\n", c.Name, c.Account, c.Address, status)
+
+		if c.Status == verifyMismatch {
+			_, _ = fmt.Fprintf(&b, "first difference at %s\n%s\n", c.FirstDifference, c.Diff)
+		}
+	}
+
+	if r.verified() {
+		_, _ = fmt.Fprintf(&b, "\n%s All contracts on network %s match the local contracts", output.SuccessEmoji(), r.network)
+	} else {
+		_, _ = fmt.Fprintf(&b, "\n%s Contracts on network %s don't match the local contracts", output.ErrorEmoji(), r.network)
+	}
+
+	return b.String()
+}
+
+func (r *verifyResult) Oneliner() string {
+	return fmt.Sprintf("verified: %t", r.verified())
+}
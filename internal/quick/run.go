@@ -57,7 +57,7 @@ var RunCommand = &command.Command{
 type runResult struct{}
 
 func (r *runResult) JSON() any {
-	return nil
+	return map[string]any{}
 }
 
 func (r *runResult) String() string {
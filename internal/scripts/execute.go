@@ -34,8 +34,8 @@ import (
 
 type Flags struct {
 	ArgsJSON    string `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	BlockID     string `default:"" flag:"block-id" info:"block ID to execute the script at"`
-	BlockHeight uint64 `default:"" flag:"block-height" info:"block height to execute the script at"`
+	BlockID     string `default:"" flag:"block-id" info:"block ID to execute the script at, uses the network archive host if configured"`
+	BlockHeight uint64 `default:"" flag:"block-height" info:"block height to execute the script at, uses the network archive host if configured"`
 }
 
 var flags = Flags{}
@@ -78,7 +78,7 @@ func SendScript(code []byte, argsArr []string, location string, flow flowkit.Ser
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("error parsing script arguments: %w", err)
+		return nil, command.WithExitCode(command.ExitValidationError, fmt.Errorf("error parsing script arguments: %w", err))
 	}
 
 	query := flowkit.ScriptQuery{}
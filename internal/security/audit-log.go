@@ -0,0 +1,163 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package security
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	auditlog "github.com/onflow/flow-cli/internal/audit"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/settings"
+)
+
+type flagsAuditLog struct {
+	Network   string `default:"" flag:"log-network" info:"Only show the transactions sent to the network"`
+	Account   string `default:"" flag:"account" info:"Only show the transactions signed by the account name or address"`
+	Operation string `default:"" flag:"operation" info:"Only show the operation, e.g. contract-update or key-add"`
+	Limit     int    `default:"20" flag:"limit" info:"Number of the latest entries shown, 0 shows all"`
+}
+
+var auditLogFlags = flagsAuditLog{}
+
+var auditLogCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "audit-log",
+		Short: "Show the audit log of the transactions sent by the CLI",
+		Long: `Show the audit log of the transactions sent by the CLI, including contract deployments and key changes,
+with the accounts, network, transaction ID and code hash. The log is verified to be unchanged when it's read.
+Recording is configured with 'flow settings audit-log'.`,
+		Example: "flow security audit-log --log-network mainnet --operation contract-update",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &auditLogFlags,
+	Run:   auditLog,
+}
+
+func auditLog(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	entries, err := auditlog.Read(settings.AuditLogPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the audit log %s: %w", settings.AuditLogPath(), err)
+	}
+
+	return &auditLogResult{
+		entries: filterAuditLog(entries, auditLogFlags),
+		path:    settings.AuditLogPath(),
+	}, nil
+}
+
+// filterAuditLog returns the latest entries matching the flags.
+func filterAuditLog(entries []auditlog.Entry, flags flagsAuditLog) []auditlog.Entry {
+	account := strings.TrimPrefix(flags.Account, "0x")
+
+	filtered := make([]auditlog.Entry, 0, len(entries))
+	for _, entry := range entries {
+		if flags.Network != "" && entry.Network != flags.Network {
+			continue
+		}
+		if flags.Operation != "" && entry.Operation != flags.Operation {
+			continue
+		}
+		if account != "" && !signedBy(entry, account) {
+			continue
+		}
+		filtered = append(filtered, entry)
+	}
+
+	if flags.Limit > 0 && len(filtered) > flags.Limit {
+		filtered = filtered[len(filtered)-flags.Limit:]
+	}
+	return filtered
+}
+
+func signedBy(entry auditlog.Entry, account string) bool {
+	addresses := append([]string{entry.Payer, entry.Proposer}, entry.Authorizers...)
+	for _, value := range append(addresses, entry.Accounts...) {
+		if value == account {
+			return true
+		}
+	}
+	return false
+}
+
+type auditLogResult struct {
+	entries []auditlog.Entry
+	path    string
+}
+
+func (r *auditLogResult) JSON() any {
+	return r.entries
+}
+
+func (r *auditLogResult) String() string {
+	if len(r.entries) == 0 {
+		return fmt.Sprintf("No transactions found in the audit log %s.", r.path)
+	}
+
+	var b bytes.Buffer
+	writer := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "Time\tNetwork\tOperation\tAccounts\tContract\tTransaction ID\tCode Hash\tCommand\n")
+	for _, entry := range r.entries {
+		operation := entry.Operation
+		if entry.Error != "" {
+			operation += " (failed)"
+		}
+
+		accounts := strings.Join(entry.Accounts, ", ")
+		if accounts == "" {
+			accounts = "0x" + entry.Payer
+		}
+
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.Time.Local().Format("2006-01-02 15:04:05"),
+			entry.Network,
+			operation,
+			accounts,
+			entry.Contract,
+			entry.TransactionID,
+			shortHash(entry.CodeHash),
+			entry.Command,
+		)
+	}
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func (r *auditLogResult) Oneliner() string {
+	return fmt.Sprintf("%d transactions in the audit log %s", len(r.entries), r.path)
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
@@ -0,0 +1,451 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsAudit struct {
+	Offline bool `default:"false" flag:"offline" info:"Skip checking whether the keys are active on the mainnet accounts"`
+}
+
+var auditFlags = flagsAudit{}
+
+var auditCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "audit",
+		Short: "Audit the project for exposed secrets",
+		Long: `Audit the project for exposed secrets, the configuration, .env files and key files are checked for
+plaintext private keys, files with secrets missing from .gitignore and secrets committed to the git history.
+Plaintext keys of mainnet accounts are checked against the keys active on the accounts.`,
+		Example: "flow security audit",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &auditFlags,
+	RunS:  audit,
+}
+
+type severity int
+
+const (
+	severityLow severity = iota
+	severityMedium
+	severityHigh
+	severityCritical
+)
+
+func (s severity) String() string {
+	return [...]string{"low", "medium", "high", "critical"}[s]
+}
+
+// finding is a security issue found in the project.
+type finding struct {
+	severity severity
+	file     string
+	message  string
+	fix      string
+}
+
+// mainnetAccount fetches the account from mainnet, replaced in tests.
+var mainnetAccount = func(address flowsdk.Address) (*flowsdk.Account, error) {
+	gw, err := gateway.NewGrpcGateway(config.MainnetNetwork)
+	if err != nil {
+		return nil, err
+	}
+	return gw.GetAccount(address)
+}
+
+// skippedDirs are not scanned for secret files.
+var skippedDirs = []string{".git", "node_modules", "imports"}
+
+var (
+	envFilePattern    = regexp.MustCompile(`^\.env(\..+)?$`)
+	privateKeyPattern = regexp.MustCompile(`(?i)(^|[^0-9a-f])(0x)?[0-9a-f]{64}([^0-9a-f]|$)`)
+)
+
+func audit(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	configPath := config.DefaultPath
+	if len(globalFlags.ConfigPaths) > 0 {
+		configPath = globalFlags.ConfigPaths[len(globalFlags.ConfigPaths)-1]
+	}
+
+	// the project directory containing the configuration is audited
+	dir, err := filepath.Abs(filepath.Dir(configPath))
+	if err != nil {
+		return nil, err
+	}
+	configPath = filepath.Base(configPath)
+
+	logger.StartProgress("Auditing the project...")
+	defer logger.StopProgress()
+
+	a := &auditor{dir: dir, git: newGit(dir)}
+	a.auditAccounts(state, configPath)
+	if err := a.auditFiles(state); err != nil {
+		return nil, err
+	}
+
+	return &auditResult{findings: a.sorted()}, nil
+}
+
+type auditor struct {
+	dir      string
+	git      *git
+	findings []finding
+}
+
+func (a *auditor) add(f finding) {
+	a.findings = append(a.findings, f)
+}
+
+func (a *auditor) sorted() []finding {
+	sort.SliceStable(a.findings, func(i, j int) bool {
+		if a.findings[i].severity != a.findings[j].severity {
+			return a.findings[i].severity > a.findings[j].severity
+		}
+		return a.findings[i].file < a.findings[j].file
+	})
+	return a.findings
+}
+
+// auditAccounts checks the accounts with the key material stored in plaintext.
+func (a *auditor) auditAccounts(state *flowkit.State, configPath string) {
+	for _, account := range *state.Accounts() {
+		conf := account.Key.ToConfig()
+
+		file := configPath
+		if account.FromFile != "" {
+			file = account.FromFile
+		}
+
+		var secret string
+		switch {
+		case conf.Type == config.KeyTypeHex && conf.Env == "" && conf.PrivateKey != nil:
+			secret = strings.TrimPrefix(conf.PrivateKey.String(), "0x")
+		case conf.Type == config.KeyTypeBip44:
+			secret = conf.Mnemonic
+		case conf.Type == config.KeyTypeFile:
+			file = conf.Location
+		default:
+			continue // keys stored encrypted, in KMS, environment or held by an external signer
+		}
+
+		chain, err := util.GetAddressNetwork(account.Address)
+		if err != nil || chain == flowsdk.Emulator {
+			continue // emulator keys are not secret
+		}
+
+		fix := fmt.Sprintf("Encrypt the key with 'flow config encrypt-keys --account %s' or read it from an environment variable.", account.Name)
+		if chain == flowsdk.Testnet {
+			a.add(finding{severityHigh, file, fmt.Sprintf("Plaintext private key of testnet account %s (0x%s).", account.Name, account.Address), fix})
+		} else {
+			a.add(a.mainnetKeyFinding(account, file, fix))
+		}
+
+		if secret != "" && a.git != nil {
+			if commit := a.git.commitContaining(secret); commit != "" {
+				a.add(finding{
+					severityCritical,
+					file,
+					fmt.Sprintf("Private key of account %s was committed to the git history (commit %s).", account.Name, commit),
+					"Rotate the key on the account, removing the secret from the history is not enough once it was pushed.",
+				})
+			}
+		}
+	}
+}
+
+// mainnetKeyFinding checks whether the plaintext key is active on the mainnet account.
+func (a *auditor) mainnetKeyFinding(account accounts.Account, file string, fix string) finding {
+	message := fmt.Sprintf("Plaintext private key of mainnet account %s (0x%s)", account.Name, account.Address)
+	if auditFlags.Offline {
+		return finding{severityCritical, file, message + ".", fix}
+	}
+
+	pkey, err := account.Key.PrivateKey()
+	if err != nil {
+		return finding{severityCritical, file, fmt.Sprintf("%s, the key could not be read: %s.", message, err), fix}
+	}
+
+	onChain, err := mainnetAccount(account.Address)
+	if err != nil {
+		return finding{severityCritical, file, fmt.Sprintf("%s, could not check the account keys: %s.", message, err), fix}
+	}
+
+	if key := activeKey(onChain, (*pkey).PublicKey()); key != nil {
+		return finding{
+			severityCritical,
+			file,
+			fmt.Sprintf("%s is active on the account (key index %d, weight %d).", message, key.Index, key.Weight),
+			fix + " Rotate the key since it was stored unencrypted.",
+		}
+	}
+
+	return finding{severityMedium, file, message + " is not active on the account.", "Remove the unused key from the configuration."}
+}
+
+func activeKey(account *flowsdk.Account, publicKey crypto.PublicKey) *flowsdk.AccountKey {
+	for _, key := range account.Keys {
+		if !key.Revoked && key.Weight > 0 && key.PublicKey.Equals(publicKey) {
+			return key
+		}
+	}
+	return nil
+}
+
+// auditFiles checks the key files and .env files with secrets are ignored and not committed.
+func (a *auditor) auditFiles(state *flowkit.State) error {
+	keyFiles := make(map[string]bool)
+	for _, account := range *state.Accounts() {
+		if conf := account.Key.ToConfig(); conf.Type == config.KeyTypeFile {
+			keyFiles[filepath.Clean(conf.Location)] = true
+		}
+	}
+
+	ignore := newIgnoreMatcher(a.dir, a.git)
+
+	return filepath.WalkDir(a.dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if entry != nil && entry.IsDir() && path != a.dir {
+				return filepath.SkipDir // unreadable directories are skipped
+			}
+			return err
+		}
+
+		rel, _ := filepath.Rel(a.dir, path)
+		if entry.IsDir() {
+			for _, skipped := range skippedDirs {
+				if entry.Name() == skipped {
+					return filepath.SkipDir
+				}
+			}
+			return nil
+		}
+
+		var kind string
+		switch {
+		case filepath.Ext(path) == ".pkey" || keyFiles[rel]:
+			kind = "Private key file"
+		case envFilePattern.MatchString(entry.Name()):
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			if !containsPrivateKey(content) {
+				return nil
+			}
+			kind = "Environment file with a private key"
+		default:
+			return nil
+		}
+
+		if !ignore.ignored(rel) {
+			a.add(finding{
+				severityHigh,
+				rel,
+				fmt.Sprintf("%s is not ignored by git.", kind),
+				fmt.Sprintf("Add %s to .gitignore.", rel),
+			})
+		}
+
+		if a.git != nil {
+			if commit := a.git.commitTouching(rel); commit != "" {
+				a.add(finding{
+					severityCritical,
+					rel,
+					fmt.Sprintf("%s was committed to the git history (commit %s).", kind, commit),
+					"Rotate the keys in the file, removing the file from the history is not enough once it was pushed.",
+				})
+			}
+		}
+
+		return nil
+	})
+}
+
+// containsPrivateKey checks whether any value of the environment file looks like a hex encoded private key.
+func containsPrivateKey(content []byte) bool {
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, value, ok := strings.Cut(line, "="); ok && privateKeyPattern.MatchString(strings.Trim(value, `"' `)) {
+			return true
+		}
+	}
+	return false
+}
+
+// git runs the git commands in the project repository.
+type git struct {
+	dir string
+}
+
+// newGit returns nil if git is not installed or the directory is not in a repository.
+func newGit(dir string) *git {
+	g := &git{dir: dir}
+	if _, err := g.run("rev-parse", "--is-inside-work-tree"); err != nil {
+		return nil
+	}
+	return g
+}
+
+func (g *git) run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = g.dir
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// commitContaining returns the latest commit adding or removing the text in any branch.
+func (g *git) commitContaining(text string) string {
+	out, _ := g.run("log", "--all", "-1", "--format=%h", "-S", text)
+	return out
+}
+
+// commitTouching returns the latest commit changing the file in any branch.
+func (g *git) commitTouching(path string) string {
+	out, _ := g.run("log", "--all", "-1", "--format=%h", "--", path)
+	return out
+}
+
+// ignoreMatcher checks whether files are ignored by git, using git if available
+// or the patterns of the .gitignore in the project directory otherwise.
+type ignoreMatcher struct {
+	git      *git
+	patterns []string
+}
+
+func newIgnoreMatcher(dir string, g *git) *ignoreMatcher {
+	m := &ignoreMatcher{git: g}
+	if g != nil {
+		return m
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		return m
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") && !strings.HasPrefix(line, "!") {
+			m.patterns = append(m.patterns, strings.TrimSuffix(strings.TrimPrefix(line, "/"), "/"))
+		}
+	}
+	return m
+}
+
+func (m *ignoreMatcher) ignored(path string) bool {
+	if m.git != nil {
+		_, err := m.git.run("check-ignore", "-q", path)
+		var exitErr *exec.ExitError
+		return err == nil || (errors.As(err, &exitErr) && exitErr.ExitCode() != 1)
+	}
+
+	path = filepath.ToSlash(path)
+	for _, pattern := range m.patterns {
+		target := path
+		if !strings.Contains(pattern, "/") {
+			target = filepath.Base(path)
+		}
+		if ok, _ := filepath.Match(pattern, target); ok {
+			return true
+		}
+		if strings.HasPrefix(path, pattern+"/") { // ignored directory
+			return true
+		}
+	}
+	return false
+}
+
+type auditResult struct {
+	findings []finding
+}
+
+func (r *auditResult) JSON() any {
+	findings := make([]map[string]string, 0, len(r.findings))
+	for _, f := range r.findings {
+		findings = append(findings, map[string]string{
+			"severity": f.severity.String(),
+			"file":     f.file,
+			"message":  f.message,
+			"fix":      f.fix,
+		})
+	}
+	return map[string]any{"findings": findings}
+}
+
+func (r *auditResult) String() string {
+	if len(r.findings) == 0 {
+		return fmt.Sprintf("%s No security issues found.", output.SuccessEmoji())
+	}
+
+	var b bytes.Buffer
+	writer := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	for _, f := range r.findings {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\t%s\n", strings.ToUpper(f.severity.String()), f.file, f.message)
+		_, _ = fmt.Fprintf(writer, "\t\t%s\n", output.Italic(f.fix))
+	}
+	_ = writer.Flush()
+
+	return fmt.Sprintf("%s Found %d security issues:\n\n%s", output.ErrorEmoji(), len(r.findings), b.String())
+}
+
+func (r *auditResult) Oneliner() string {
+	counts := make(map[severity]int)
+	for _, f := range r.findings {
+		counts[f.severity]++
+	}
+	return fmt.Sprintf(
+		"critical: %d, high: %d, medium: %d, low: %d",
+		counts[severityCritical], counts[severityHigh], counts[severityMedium], counts[severityLow],
+	)
+}
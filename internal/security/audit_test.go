@@ -0,0 +1,138 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package security
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	auditlog "github.com/onflow/flow-cli/internal/audit"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Audit(t *testing.T) {
+	_, state, _ := util.TestMocks(t)
+	dir := t.TempDir()
+
+	mainnetKey, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, "dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")
+	require.NoError(t, err)
+	testnetKey, err := crypto.DecodePrivateKeyHex(crypto.ECDSA_P256, "21c5dfdeb0ff03a7a73ef39788563b62c89adea67bbb21ab95e5f710bd1d40b7")
+	require.NoError(t, err)
+
+	mainnetAddress := flowsdk.HexToAddress("1654653399040a61")
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    "prod",
+		Address: mainnetAddress,
+		Key:     accounts.NewHexKeyFromPrivateKey(0, crypto.SHA3_256, mainnetKey),
+	})
+	state.Accounts().AddOrUpdate(&accounts.Account{
+		Name:    "dev",
+		Address: flowsdk.HexToAddress("7e60df042a9c0868"),
+		Key:     accounts.NewHexKeyFromPrivateKey(0, crypto.SHA3_256, testnetKey),
+	})
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("# keys\n*.pkey\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ignored.pkey"), []byte(testnetKey.String()), 0600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "keys"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "keys", ".env.testnet"), []byte("# deployer\nKEY="+testnetKey.String()+"\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".env"), []byte("NETWORK=testnet\n"), 0600))
+
+	defer func(get func(flowsdk.Address) (*flowsdk.Account, error)) { mainnetAccount = get }(mainnetAccount)
+	mainnetAccount = func(address flowsdk.Address) (*flowsdk.Account, error) {
+		assert.Equal(t, mainnetAddress, address)
+		return &flowsdk.Account{Address: address, Keys: []*flowsdk.AccountKey{
+			{Index: 0, PublicKey: testnetKey.PublicKey(), Weight: 1000},
+			{Index: 3, PublicKey: mainnetKey.PublicKey(), Weight: 1000},
+		}}, nil
+	}
+
+	a := &auditor{dir: dir}
+	a.auditAccounts(state, "flow.json")
+	require.NoError(t, a.auditFiles(state))
+
+	result := &auditResult{findings: a.sorted()}
+	assert.Equal(t, map[string]any{"findings": []map[string]string{{
+		"severity": "critical",
+		"file":     "flow.json",
+		"message":  "Plaintext private key of mainnet account prod (0x1654653399040a61) is active on the account (key index 3, weight 1000).",
+		"fix":      "Encrypt the key with 'flow config encrypt-keys --account prod' or read it from an environment variable. Rotate the key since it was stored unencrypted.",
+	}, {
+		"severity": "high",
+		"file":     "flow.json",
+		"message":  "Plaintext private key of testnet account dev (0x7e60df042a9c0868).",
+		"fix":      "Encrypt the key with 'flow config encrypt-keys --account dev' or read it from an environment variable.",
+	}, {
+		"severity": "high",
+		"file":     filepath.Join("keys", ".env.testnet"),
+		"message":  "Environment file with a private key is not ignored by git.",
+		"fix":      "Add " + filepath.Join("keys", ".env.testnet") + " to .gitignore.",
+	}}}, result.JSON())
+	assert.Equal(t, "critical: 1, high: 2, medium: 0, low: 0", result.Oneliner())
+
+	t.Run("Inactive mainnet key", func(t *testing.T) {
+		mainnetAccount = func(address flowsdk.Address) (*flowsdk.Account, error) {
+			return &flowsdk.Account{Address: address, Keys: []*flowsdk.AccountKey{
+				{Index: 0, PublicKey: mainnetKey.PublicKey(), Weight: 1000, Revoked: true},
+			}}, nil
+		}
+
+		account, err := state.Accounts().ByName("prod")
+		require.NoError(t, err)
+
+		f := (&auditor{dir: dir}).mainnetKeyFinding(*account, "flow.json", "")
+		assert.Equal(t, severityMedium, f.severity)
+		assert.Equal(t, "Plaintext private key of mainnet account prod (0x1654653399040a61) is not active on the account.", f.message)
+	})
+}
+
+func Test_ContainsPrivateKey(t *testing.T) {
+	assert.True(t, containsPrivateKey([]byte(`KEY="0xdd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47"`)))
+	assert.True(t, containsPrivateKey([]byte("export KEY=dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")))
+	assert.False(t, containsPrivateKey([]byte("# KEY=dd72967fd2bd75234ae9037dd4694c1f00baad63a10c35172bf65fbb8ad74b47")))
+	assert.False(t, containsPrivateKey([]byte("NETWORK=testnet\nADDRESS=0x1654653399040a61")))
+}
+
+func Test_FilterAuditLog(t *testing.T) {
+	entries := []auditlog.Entry{
+		{TransactionID: "1", Network: "testnet", Operation: auditlog.OperationContractAdd, Payer: "01cf0e2f2f715450", Accounts: []string{"alice"}},
+		{TransactionID: "2", Network: "mainnet", Operation: auditlog.OperationContractUpdate, Payer: "f233dcee88fe0abe", Accounts: []string{"admin"}},
+		{TransactionID: "3", Network: "mainnet", Operation: auditlog.OperationTransaction, Payer: "f233dcee88fe0abe", Accounts: []string{"admin"}},
+	}
+
+	ids := func(entries []auditlog.Entry) []string {
+		result := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			result = append(result, entry.TransactionID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []string{"2", "3"}, ids(filterAuditLog(entries, flagsAuditLog{Network: "mainnet"})))
+	assert.Equal(t, []string{"2"}, ids(filterAuditLog(entries, flagsAuditLog{Operation: auditlog.OperationContractUpdate})))
+	assert.Equal(t, []string{"2", "3"}, ids(filterAuditLog(entries, flagsAuditLog{Account: "0xf233dcee88fe0abe"})))
+	assert.Equal(t, []string{"1"}, ids(filterAuditLog(entries, flagsAuditLog{Account: "alice"})))
+	assert.Equal(t, []string{"3"}, ids(filterAuditLog(entries, flagsAuditLog{Limit: 1})))
+}
@@ -0,0 +1,184 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package serve
+
+// openAPIDocument describes the API, so clients can be generated for other languages.
+const openAPIDocument = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "Flow CLI project API",
+    "description": "Execute scripts, send transactions and get accounts of the Flow project served by flow serve.",
+    "version": "1.0.0"
+  },
+  "security": [{"bearerAuth": []}],
+  "paths": {
+    "/v1/scripts": {
+      "post": {
+        "summary": "Execute a script",
+        "operationId": "executeScript",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ScriptRequest"}}}
+        },
+        "responses": {
+          "200": {
+            "description": "Value returned by the script",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ScriptResult"}}}
+          },
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/transactions": {
+      "post": {
+        "summary": "Send a transaction signed by a configured account and wait until it is sealed",
+        "operationId": "sendTransaction",
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TransactionRequest"}}}
+        },
+        "responses": {
+          "200": {
+            "description": "Sealed transaction, the error is set if the transaction was reverted",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TransactionResult"}}}
+          },
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    },
+    "/v1/accounts/{account}": {
+      "get": {
+        "summary": "Get an account",
+        "operationId": "getAccount",
+        "parameters": [{
+          "name": "account",
+          "in": "path",
+          "required": true,
+          "description": "Address or name of an account in the configuration",
+          "schema": {"type": "string"}
+        }],
+        "responses": {
+          "200": {
+            "description": "Account",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Account"}}}
+          },
+          "default": {"$ref": "#/components/responses/Error"}
+        }
+      }
+    }
+  },
+  "components": {
+    "securitySchemes": {
+      "bearerAuth": {"type": "http", "scheme": "bearer"}
+    },
+    "responses": {
+      "Error": {
+        "description": "Error",
+        "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Error"}}}
+      }
+    },
+    "schemas": {
+      "ScriptRequest": {
+        "type": "object",
+        "properties": {
+          "code": {"type": "string", "description": "Cadence code, either code or path must be provided"},
+          "path": {"type": "string", "description": "Path of the Cadence file relative to the project directory"},
+          "args": {"type": "array", "items": {"$ref": "#/components/schemas/CadenceValue"}}
+        }
+      },
+      "TransactionRequest": {
+        "type": "object",
+        "properties": {
+          "code": {"type": "string", "description": "Cadence code, either code or path must be provided"},
+          "path": {"type": "string", "description": "Path of the Cadence file relative to the project directory"},
+          "args": {"type": "array", "items": {"$ref": "#/components/schemas/CadenceValue"}},
+          "signer": {"type": "string", "description": "Name of the account in the configuration signing the transaction as proposer, payer and authorizer"},
+          "gasLimit": {"type": "integer", "format": "uint64"}
+        }
+      },
+      "ScriptResult": {
+        "type": "object",
+        "properties": {
+          "value": {"$ref": "#/components/schemas/CadenceValue"}
+        }
+      },
+      "TransactionResult": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "string"},
+          "status": {"type": "string"},
+          "block_id": {"type": "string"},
+          "block_height": {"type": "integer"},
+          "payer": {"type": "string"},
+          "authorizers": {"type": "string"},
+          "payload": {"type": "string"},
+          "error": {"type": "string"},
+          "events": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "index": {"type": "integer"},
+                "type": {"type": "string"},
+                "values": {"$ref": "#/components/schemas/CadenceValue"}
+              }
+            }
+          }
+        }
+      },
+      "Account": {
+        "type": "object",
+        "properties": {
+          "address": {"type": "string"},
+          "balance": {"type": "string"},
+          "contracts": {"type": "array", "items": {"type": "string"}},
+          "keys": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "index": {"type": "integer"},
+                "publicKey": {"type": "string"},
+                "sigAlgo": {"type": "string"},
+                "hashAlgo": {"type": "string"},
+                "weight": {"type": "integer"},
+                "revoked": {"type": "boolean"}
+              }
+            }
+          }
+        }
+      },
+      "CadenceValue": {
+        "type": "object",
+        "description": "Value in JSON-Cadence format, e.g. {\"type\": \"UInt64\", \"value\": \"42\"}",
+        "properties": {
+          "type": {"type": "string"},
+          "value": {}
+        }
+      },
+      "Error": {
+        "type": "object",
+        "properties": {
+          "error": {"type": "string"}
+        }
+      }
+    }
+  }
+}
+`
@@ -0,0 +1,370 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package serve implements a local HTTP API executing scripts, sending transactions and getting accounts of the project.
+package serve
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/onflow/cadence"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/scripts"
+	"github.com/onflow/flow-cli/internal/transactions"
+)
+
+type flagsServe struct {
+	Host     string `default:"127.0.0.1" flag:"host" info:"Host the API listens on"`
+	Port     int    `default:"8090" flag:"port" info:"Port the API listens on"`
+	Token    string `default:"" flag:"token" info:"Bearer token required by the API, a random token is generated if not provided"`
+	Signer   string `default:"" flag:"signer" info:"Account name from configuration signing transactions without a requested signer, defaults to the emulator service account"`
+	GasLimit uint64 `default:"1000" flag:"gas-limit" info:"Gas limit of transactions without a requested gas limit"`
+}
+
+var serveFlags = flagsServe{}
+
+var Command = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a local HTTP API executing scripts, sending transactions and getting accounts of the project",
+		Long: `Serve a local HTTP API executing scripts, sending transactions signed by the configured accounts and getting
+accounts on the selected network. Every request except the OpenAPI document at /openapi.json must provide the
+token in the Authorization header, e.g. Authorization: Bearer <token>.`,
+		Example: "flow serve\nflow serve --port 9000 --token secret --network testnet --signer testnet-account",
+		Args:    cobra.NoArgs,
+		GroupID: "tools",
+	},
+	Flags: &serveFlags,
+	RunS:  serve,
+}
+
+func serve(
+	_ []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	token := serveFlags.Token
+	if token == "" {
+		var err error
+		token, err = generateToken()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(serveFlags.Host, strconv.Itoa(serveFlags.Port)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+
+	s := &server{
+		flow:     flow,
+		state:    state,
+		global:   globalFlags,
+		token:    token,
+		signer:   serveFlags.Signer,
+		gasLimit: serveFlags.GasLimit,
+	}
+	httpServer := &http.Server{
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	// stop serving when interrupted, requests in progress are completed before exiting
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.Serve(listener)
+	}()
+
+	logger.Info(fmt.Sprintf("Serving the project API on http://%s for network %s", listener.Addr(), flow.Network().Name))
+	if serveFlags.Token == "" {
+		logger.Info(fmt.Sprintf("Authorization: Bearer %s", token))
+	}
+
+	select {
+	case err := <-errs:
+		return nil, err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return nil, err
+	}
+
+	logger.Info("Stopped serving the project API")
+	return nil, nil
+}
+
+func generateToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+type server struct {
+	flow  flowkit.Services
+	state *flowkit.State
+	// global are the global flags the server was started with, applied to the transactions it sends
+	global   command.GlobalFlags
+	token    string
+	signer   string
+	gasLimit uint64
+	// sending is locked while a transaction is sent, so transactions of the same proposer don't use the same sequence number
+	sending sync.Mutex
+}
+
+func (s *server) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.json", s.openAPI)
+	mux.Handle("/v1/scripts", s.authorized(http.MethodPost, s.executeScript))
+	mux.Handle("/v1/transactions", s.authorized(http.MethodPost, s.sendTransaction))
+	mux.Handle("/v1/accounts/", s.authorized(http.MethodGet, s.getAccount))
+	return mux
+}
+
+// authorized handles requests with the method and the bearer token of the server.
+func (s *server) authorized(method string, handle func(http.ResponseWriter, *http.Request)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid bearer token"))
+			return
+		}
+		if r.Method != method {
+			w.Header().Set("Allow", method)
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+			return
+		}
+		handle(w, r)
+	})
+}
+
+// codeRequest is the body of the script and transaction requests, the code is provided directly
+// or as the path of a file in the project.
+type codeRequest struct {
+	Code string `json:"code"`
+	Path string `json:"path"`
+	// Args are the arguments in JSON-Cadence format.
+	Args     json.RawMessage `json:"args"`
+	Signer   string          `json:"signer"`
+	GasLimit uint64          `json:"gasLimit"`
+}
+
+func (s *server) readCode(r *http.Request) (codeRequest, []byte, string, error) {
+	var req codeRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(nil, r.Body, 1<<20))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return req, nil, "", fmt.Errorf("invalid request body: %w", err)
+	}
+
+	if (req.Code == "") == (req.Path == "") {
+		return req, nil, "", errors.New("provide either code or path")
+	}
+	if req.Code != "" {
+		return req, []byte(req.Code), "", nil
+	}
+
+	// files outside of the project directory are not read
+	path := filepath.Clean(req.Path)
+	if filepath.IsAbs(path) || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return req, nil, "", fmt.Errorf("path %s must be relative to the project directory", req.Path)
+	}
+	code, err := s.state.ReaderWriter().ReadFile(path)
+	if err != nil {
+		return req, nil, "", fmt.Errorf("error loading %s: %w", req.Path, err)
+	}
+	return req, code, path, nil
+}
+
+func (s *server) executeScript(w http.ResponseWriter, r *http.Request) {
+	req, code, location, err := s.readCode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := scripts.SendScript(code, nil, location, s.flow, scripts.Flags{ArgsJSON: argsJSON(req.Args)})
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"value": result.JSON()})
+}
+
+func (s *server) sendTransaction(w http.ResponseWriter, r *http.Request) {
+	req, code, location, err := s.readCode(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	flags := transactions.Flags{
+		ArgsJSON: argsJSON(req.Args),
+		Signer:   req.Signer,
+		GasLimit: req.GasLimit,
+	}
+	if flags.Signer == "" {
+		flags.Signer = s.signer
+	}
+	if flags.GasLimit == 0 {
+		flags.GasLimit = s.gasLimit
+	}
+
+	s.sending.Lock()
+	result, err := transactions.SendTransaction(code, nil, location, s.global, s.flow, s.state, flags)
+	s.sending.Unlock()
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result.JSON())
+}
+
+// getAccount gets the account by the address or the name of an account in the configuration.
+func (s *server) getAccount(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/v1/accounts/")
+	if name == "" {
+		writeError(w, http.StatusNotFound, errors.New("provide the address or name of the account"))
+		return
+	}
+
+	address, isAddress := parseAddress(name)
+	if account, err := s.state.Accounts().ByName(name); err == nil {
+		address = account.Address
+	} else if !isAddress {
+		writeError(w, http.StatusNotFound, fmt.Errorf("account %s is not an address or an account in the configuration", name))
+		return
+	}
+
+	account, err := s.flow.GetAccount(r.Context(), address)
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	keys := make([]map[string]any, 0, len(account.Keys))
+	for _, key := range account.Keys {
+		keys = append(keys, map[string]any{
+			"index":     key.Index,
+			"publicKey": fmt.Sprintf("%x", key.PublicKey.Encode()),
+			"sigAlgo":   key.SigAlgo.String(),
+			"hashAlgo":  key.HashAlgo.String(),
+			"weight":    key.Weight,
+			"revoked":   key.Revoked,
+		})
+	}
+	contracts := make([]string, 0, len(account.Contracts))
+	for name := range account.Contracts {
+		contracts = append(contracts, name)
+	}
+	sort.Strings(contracts)
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"address":   "0x" + account.Address.Hex(),
+		"balance":   cadence.UFix64(account.Balance).String(),
+		"keys":      keys,
+		"contracts": contracts,
+	})
+}
+
+// parseAddress parses a hex address, unlike flowsdk.HexToAddress other strings are not partially decoded.
+func parseAddress(value string) (flowsdk.Address, bool) {
+	trimmed := strings.TrimPrefix(value, "0x")
+	if len(trimmed)%2 == 1 {
+		trimmed = "0" + trimmed
+	}
+	b, err := hex.DecodeString(trimmed)
+	if err != nil || len(b) == 0 || len(b) > flowsdk.AddressLength {
+		return flowsdk.EmptyAddress, false
+	}
+	return flowsdk.BytesToAddress(b), true
+}
+
+func (s *server) openAPI(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not allowed", r.Method))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(openAPIDocument))
+}
+
+// argsJSON returns the JSON-Cadence arguments, missing arguments are empty so they are validated against the parameters.
+func argsJSON(args json.RawMessage) string {
+	if string(args) == "null" {
+		return ""
+	}
+	return string(args)
+}
+
+// errorStatus returns the HTTP status of an error returned by the project operations.
+func errorStatus(err error) int {
+	switch command.ExitCodeOf(err) {
+	case command.ExitValidationError:
+		return http.StatusBadRequest
+	case command.ExitNetworkError:
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]any{"error": err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(value)
+}
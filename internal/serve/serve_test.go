@@ -0,0 +1,134 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package serve
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Serve(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	s := &server{flow: srv.Mock, state: state, token: "secret", gasLimit: 1000}
+	handler := s.handler()
+
+	request := func(method string, path string, body string, token string) (int, map[string]any) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		var response map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		return rec.Code, response
+	}
+
+	t.Run("Success Script", func(t *testing.T) {
+		srv.ExecuteScript.Run(func(args mock.Arguments) {
+			script := args.Get(1).(flowkit.Script)
+			assert.Equal(t, tests.ScriptArgString.Filename, script.Location)
+			assert.Equal(t, []cadence.Value{cadence.String("Foo")}, script.Args)
+		}).Return(cadence.String("Hello Foo"), nil).Once()
+
+		status, response := request(
+			http.MethodPost,
+			"/v1/scripts",
+			`{"path": "scriptArg.cdc", "args": [{"type": "String", "value": "Foo"}]}`,
+			"secret",
+		)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, map[string]any{"value": map[string]any{"type": "String", "value": "Hello Foo"}}, response)
+	})
+
+	t.Run("Success Transaction", func(t *testing.T) {
+		tx := tests.NewTransaction()
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			assert.Equal(t, config.DefaultEmulator.ServiceAccount, roles.Payer.Name)
+			assert.Equal(t, uint64(1000), args.Get(3).(uint64))
+		}).Return(tx, tests.NewTransactionResult(nil), nil).Once()
+
+		status, response := request(http.MethodPost, "/v1/transactions", `{"code": "transaction {}"}`, "secret")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, tx.ID().String(), response["id"])
+	})
+
+	t.Run("Success Account", func(t *testing.T) {
+		srv.GetAccount.Run(func(args mock.Arguments) {
+			assert.Equal(t, flow.HexToAddress("f8d6e0586b0a20c7"), args.Get(1).(flow.Address))
+		}).Return(tests.NewAccountWithAddress("f8d6e0586b0a20c7"), nil).Once()
+
+		status, response := request(http.MethodGet, "/v1/accounts/emulator-account", "", "secret")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "0xf8d6e0586b0a20c7", response["address"])
+	})
+
+	t.Run("Fail Unauthorized", func(t *testing.T) {
+		status, response := request(http.MethodPost, "/v1/scripts", `{"code": "pub fun main() {}"}`, "wrong")
+		assert.Equal(t, http.StatusUnauthorized, status)
+		assert.Equal(t, "missing or invalid bearer token", response["error"])
+	})
+
+	t.Run("Fail Method", func(t *testing.T) {
+		status, _ := request(http.MethodGet, "/v1/scripts", "", "secret")
+		assert.Equal(t, http.StatusMethodNotAllowed, status)
+	})
+
+	t.Run("Fail Invalid Request", func(t *testing.T) {
+		status, response := request(http.MethodPost, "/v1/scripts", `{"path": "../secret.cdc"}`, "secret")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "path ../secret.cdc must be relative to the project directory", response["error"])
+
+		status, response = request(http.MethodPost, "/v1/scripts", `{}`, "secret")
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.Equal(t, "provide either code or path", response["error"])
+
+		status, _ = request(http.MethodPost, "/v1/scripts", `{"code": "pub fun main() {}", "args": [{"type": "Foo"}]}`, "secret")
+		assert.Equal(t, http.StatusBadRequest, status)
+	})
+
+	t.Run("Fail Unknown Account", func(t *testing.T) {
+		status, response := request(http.MethodGet, "/v1/accounts/alice", "", "secret")
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.Equal(t, "account alice is not an address or an account in the configuration", response["error"])
+	})
+
+	t.Run("Success OpenAPI", func(t *testing.T) {
+		status, response := request(http.MethodGet, "/openapi.json", "", "")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "3.0.3", response["openapi"])
+	})
+}
@@ -0,0 +1,85 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settings
+
+import (
+	"sort"
+
+	"github.com/spf13/viper"
+)
+
+// Alias is a named command with default flags, expanded by the root command.
+type Alias struct {
+	Name    string `mapstructure:"name" yaml:"name"`
+	Command string `mapstructure:"command" yaml:"command"`
+}
+
+// Aliases gets the command aliases sorted by name.
+//
+// Aliases are kept as a list instead of a map because the settings keys are case-insensitive.
+func Aliases() ([]Alias, error) {
+	if err := loadViper(); err != nil {
+		return nil, err
+	}
+
+	var list []Alias
+	if err := viper.UnmarshalKey(aliases, &list); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+	return list, nil
+}
+
+// SetAlias adds the command alias or replaces the command of an existing alias with the same name.
+func SetAlias(name string, command string) error {
+	list, err := Aliases()
+	if err != nil {
+		return err
+	}
+
+	list = removeAlias(list, name)
+	return Set(aliases, append(list, Alias{Name: name, Command: command}))
+}
+
+// RemoveAlias removes the command alias, it returns false if the alias doesn't exist.
+func RemoveAlias(name string) (bool, error) {
+	list, err := Aliases()
+	if err != nil {
+		return false, err
+	}
+
+	remaining := removeAlias(list, name)
+	if len(remaining) == len(list) {
+		return false, nil
+	}
+	return true, Set(aliases, remaining)
+}
+
+func removeAlias(list []Alias, name string) []Alias {
+	remaining := make([]Alias, 0, len(list))
+	for _, alias := range list {
+		if alias.Name != name {
+			remaining = append(remaining, alias)
+		}
+	}
+	return remaining
+}
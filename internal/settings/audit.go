@@ -0,0 +1,116 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package settings
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const auditLogFile = "flow-cli.audit.log"
+
+// AuditLogPath is the path of the append-only audit log of the transactions sent by the CLI.
+func AuditLogPath() string {
+	return path.Join(FileDir(), auditLogFile)
+}
+
+// AuditLogEnabled checks whether the transactions sent by the CLI are recorded in the audit log.
+func AuditLogEnabled() bool {
+	if err := loadViper(); err != nil {
+		return true
+	}
+	return viper.GetBool(auditLog)
+}
+
+// AuditSink gets the URL the audit log entries are sent to in addition to the local audit log, empty if none.
+func AuditSink() string {
+	if err := loadViper(); err != nil {
+		return ""
+	}
+	return viper.GetString(auditSink)
+}
+
+func SetAuditLog(enabled bool) error {
+	return Set(auditLog, enabled)
+}
+
+func SetAuditSink(url string) error {
+	return Set(auditSink, url)
+}
+
+var auditLogFlags = struct {
+	Sink string
+}{}
+
+var auditLogSettings = &cobra.Command{
+	Use:   "audit-log <on|off|show>",
+	Short: "Configure the audit log of the transactions sent by the CLI",
+	Long: `Every transaction sent by the CLI, including contract deployments and key changes, is recorded in the local
+append-only audit log with the accounts, network, transaction ID and code hash. Transactions sent to the emulator
+are not recorded. The entries can be sent to a remote sink as well and are shown with 'flow security audit-log'.`,
+	Example:   "flow settings audit-log show\nflow settings audit-log on --sink https://audit.example.com/flow\nflow settings audit-log off",
+	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: []string{on, off, show},
+	RunE:      handleAuditLogSettings,
+}
+
+func init() {
+	auditLogSettings.Flags().StringVar(
+		&auditLogFlags.Sink,
+		"sink",
+		"",
+		"URL the audit log entries are sent to with a POST request in addition to the local audit log, \"none\" removes it",
+	)
+}
+
+func handleAuditLogSettings(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("sink") {
+		sink := auditLogFlags.Sink
+		if sink == "none" {
+			sink = ""
+		} else if u, err := url.Parse(sink); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid sink URL %s, the URL must start with http:// or https://", sink)
+		}
+
+		if err := SetAuditSink(sink); err != nil {
+			return errors.Wrap(err, "failed to update audit log settings")
+		}
+	}
+
+	if args[0] != show {
+		if err := SetAuditLog(args[0] == on); err != nil {
+			return errors.Wrap(err, "failed to update audit log settings")
+		}
+	}
+
+	state := "disabled"
+	if AuditLogEnabled() {
+		state = "enabled"
+	}
+	fmt.Printf("Audit log is %s, entries are recorded in %s.\n", state, AuditLogPath())
+	if sink := AuditSink(); sink != "" {
+		fmt.Printf("Entries are sent to %s as well.\n", sink)
+	}
+
+	return nil
+}
@@ -30,4 +30,6 @@ var Cmd = &cobra.Command{
 
 func init() {
 	Cmd.AddCommand(metricsSettings)
+	Cmd.AddCommand(mainnetPolicySettings)
+	Cmd.AddCommand(auditLogSettings)
 }
@@ -25,14 +25,29 @@ import (
 )
 
 const (
-	metricsEnabled = "MetricsEnabled"
-	flowserPath    = "FlowserPath"
+	metricsEnabled   = "MetricsEnabled"
+	metricsOptIn     = "MetricsOptIn"
+	metricsCollector = "MetricsCollector"
+	flowserPath      = "FlowserPath"
+	aliases          = "Aliases"
+	mainnetPolicy    = "MainnetPolicy"
+	auditLog         = "AuditLog"
+	auditSink        = "AuditSink"
 )
 
 // defaults holds the default values for global settings
 var defaults = map[string]any{
-	metricsEnabled: true,
-	flowserPath:    getDefaultInstallDir(),
+	metricsEnabled:   true,
+	metricsOptIn:     false,
+	metricsCollector: "",
+	flowserPath:      getDefaultInstallDir(),
+	aliases:          []Alias{},
+	auditLog:         true,
+	auditSink:        "",
+	mainnetPolicy: map[string]string{
+		PolicyContractUpdate: PolicyConfirm,
+		PolicyContractRemove: PolicyConfirm,
+	},
 }
 
 const (
@@ -19,39 +19,141 @@
 package settings
 
 import (
+	"bytes"
 	"fmt"
+	"net/url"
+	"sort"
+	"text/tabwriter"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
-const enable = "enable"
+const (
+	on      = "on"
+	off     = "off"
+	show    = "show"
+	enable  = "enable"
+	disable = "disable"
+)
 
-const disable = "disable"
+var metricsFlags = struct {
+	Collector string
+}{}
 
 var metricsSettings = &cobra.Command{
-	Use:       "metrics",
-	Short:     "Configure command usage metrics settings",
-	Example:   "flow settings metrics disable \nflow settings metrics enable",
+	Use:   "metrics <on|off|show>",
+	Short: "Configure command usage metrics settings",
+	Long: `Usage metrics are only recorded after opting in. They contain the command name without arguments,
+the duration, the exit code, the CLI version and operating system, and an anonymous user ID.
+A summary of the recorded usage is kept locally and shown with 'flow settings metrics show'.`,
+	Example:   "flow settings metrics on\nflow settings metrics on --collector https://metrics.example.com/events\nflow settings metrics show\nflow settings metrics off",
 	Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
-	ValidArgs: []string{enable, disable},
+	ValidArgs: []string{on, off, show, enable, disable},
 	RunE:      handleMetricsSettings,
 }
 
+func init() {
+	metricsSettings.Flags().StringVar(
+		&metricsFlags.Collector,
+		"collector",
+		"",
+		"URL of a self-hosted collector the usage metrics are sent to instead of the Flow CLI maintainers, \"default\" resets it",
+	)
+}
+
 // handleMetricsSettings sets global settings for metrics
 func handleMetricsSettings(
-	_ *cobra.Command,
+	cmd *cobra.Command,
 	args []string,
 ) error {
-	enabled := args[0] == enable
-	if err := Set(metricsEnabled, enabled); err != nil {
+	if cmd.Flags().Changed("collector") {
+		collector := metricsFlags.Collector
+		if collector == "default" {
+			collector = ""
+		} else if u, err := url.Parse(collector); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid collector URL %s, the URL must start with http:// or https://", collector)
+		}
+
+		if err := SetMetricsCollector(collector); err != nil {
+			return errors.Wrap(err, "failed to update metrics settings")
+		}
+	}
+
+	if args[0] == show {
+		summary, err := metricsSummary()
+		if err != nil {
+			return err
+		}
+		fmt.Print(summary)
+		return nil
+	}
+
+	enabled := args[0] == on || args[0] == enable
+	if err := SetMetrics(enabled); err != nil {
 		return errors.Wrap(err, "failed to update metrics settings")
 	}
 
-	fmt.Println(fmt.Sprintf(
-		"Command usage tracking is %sd. Settings were updated in %s \n",
-		args[0],
-		FileName()))
+	state := "disabled"
+	if enabled {
+		state = "enabled"
+	}
+	fmt.Printf("Command usage tracking is %s. Settings were updated in %s \n\n", state, FileName())
 
 	return nil
 }
+
+// metricsSummary describes the metrics settings and the local usage summary, most used commands first.
+func metricsSummary() (string, error) {
+	summary, err := UsageSummary()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read the usage summary")
+	}
+
+	var b bytes.Buffer
+	if MetricsEnabled() {
+		destination := "the Flow CLI maintainers"
+		if collector := MetricsCollector(); collector != "" {
+			destination = collector
+		}
+		_, _ = fmt.Fprintf(&b, "Command usage tracking is enabled, usage is sent to %s.\n", destination)
+	} else {
+		_, _ = fmt.Fprintf(&b, "Command usage tracking is disabled, enable it with 'flow settings metrics on'.\n")
+	}
+
+	if len(summary) == 0 {
+		_, _ = fmt.Fprintf(&b, "No command usage was recorded.\n")
+		return b.String(), nil
+	}
+
+	commands := make([]string, 0, len(summary))
+	for command := range summary {
+		commands = append(commands, command)
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		a, b := summary[commands[i]], summary[commands[j]]
+		if a.Runs != b.Runs {
+			return a.Runs > b.Runs
+		}
+		return commands[i] < commands[j]
+	})
+
+	_, _ = fmt.Fprintf(&b, "\nLocal usage summary (%s):\n", UsageFilePath())
+	writer := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "Command\tRuns\tFailures\tAverage\tSlowest\tLast Used\n")
+	for _, command := range commands {
+		usage := summary[command]
+		_, _ = fmt.Fprintf(writer, "%s\t%d\t%d\t%s\t%s\t%s\n",
+			command,
+			usage.Runs,
+			usage.Failures,
+			usage.AverageDuration(),
+			time.Duration(usage.MaxMs)*time.Millisecond,
+			usage.LastUsedAt.Local().Format("2006-01-02 15:04"),
+		)
+	}
+	_ = writer.Flush()
+
+	return b.String(), nil
+}
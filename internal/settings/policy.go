@@ -0,0 +1,138 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package settings
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// Destructive mainnet operations guarded by the mainnet policy.
+const (
+	PolicyContractUpdate = "contract-update"
+	PolicyContractRemove = "contract-remove"
+	PolicyKeyRevoke      = "key-revoke"
+	PolicyKeyRotate      = "key-rotate"
+)
+
+// Modes of the mainnet policy.
+const (
+	// PolicyNone runs the operation without any additional check.
+	PolicyNone = "none"
+	// PolicyConfirm requires typing the account name to confirm the operation.
+	PolicyConfirm = "confirm"
+	// PolicyCoSign requires a co-signer key of the same account to sign the operation.
+	PolicyCoSign = "cosign"
+	// PolicyBlock refuses to run the operation.
+	PolicyBlock = "block"
+)
+
+var policyOperations = []string{PolicyContractUpdate, PolicyContractRemove, PolicyKeyRevoke, PolicyKeyRotate}
+
+var policyModes = []string{PolicyNone, PolicyConfirm, PolicyCoSign, PolicyBlock}
+
+// MainnetPolicy gets the policy mode of the destructive mainnet operation, confirmation is required by default.
+func MainnetPolicy(operation string) string {
+	if err := loadViper(); err != nil {
+		return PolicyConfirm
+	}
+
+	mode := viper.GetStringMapString(mainnetPolicy)[operation]
+	if mode == "" {
+		return PolicyConfirm
+	}
+	return mode
+}
+
+// SetMainnetPolicy sets the policy mode of the destructive mainnet operation.
+func SetMainnetPolicy(operation string, mode string) error {
+	if !contains(policyOperations, operation) {
+		return fmt.Errorf("invalid operation %s, valid operations are: %s", operation, strings.Join(policyOperations, ", "))
+	}
+	if !contains(policyModes, mode) {
+		return fmt.Errorf("invalid policy mode %s, valid modes are: %s", mode, strings.Join(policyModes, ", "))
+	}
+
+	policy := make(map[string]string)
+	for _, op := range policyOperations {
+		policy[op] = MainnetPolicy(op)
+	}
+	policy[operation] = mode
+
+	return Set(mainnetPolicy, policy)
+}
+
+var mainnetPolicySettings = &cobra.Command{
+	Use:   "mainnet-policy [<operation> <none|confirm|cosign|block>]",
+	Short: "Configure the policy guarding destructive mainnet operations",
+	Long: `Destructive mainnet operations (contract-update, contract-remove, key-revoke, key-rotate) are guarded by a policy mode:
+  none     run the operation without any additional check
+  confirm  require typing the account name to confirm the operation
+  cosign   require a co-signer key of the same account, provided with the --cosigner flag
+  block    refuse to run the operation
+
+Any policy is bypassed with the --yes-i-am-sure flag set to the name of the account.`,
+	Example: "flow settings mainnet-policy\nflow settings mainnet-policy contract-update cosign\nflow settings mainnet-policy contract-remove block\nflow settings mainnet-policy key-revoke block",
+	Args: cobra.MatchAll(cobra.RangeArgs(0, 2), func(cmd *cobra.Command, args []string) error {
+		if len(args) == 1 {
+			return fmt.Errorf("the policy mode for operation %s is missing", args[0])
+		}
+		return nil
+	}),
+	RunE: handleMainnetPolicySettings,
+}
+
+func handleMainnetPolicySettings(_ *cobra.Command, args []string) error {
+	if len(args) == 2 {
+		if err := SetMainnetPolicy(args[0], args[1]); err != nil {
+			return errors.Wrap(err, "failed to update mainnet policy settings")
+		}
+		fmt.Printf("Mainnet policy for %s is set to %s. Settings were updated in %s \n\n", args[0], args[1], FileName())
+		return nil
+	}
+
+	fmt.Print(mainnetPolicySummary())
+	return nil
+}
+
+func mainnetPolicySummary() string {
+	var b bytes.Buffer
+	writer := tabwriter.NewWriter(&b, 0, 8, 2, ' ', 0)
+	_, _ = fmt.Fprintf(writer, "Operation\tPolicy\n")
+	for _, operation := range policyOperations {
+		_, _ = fmt.Fprintf(writer, "%s\t%s\n", operation, MainnetPolicy(operation))
+	}
+	_ = writer.Flush()
+
+	return b.String()
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
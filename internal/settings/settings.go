@@ -123,10 +123,35 @@ func SetFlowserPath(path string) error {
 	return Set(flowserPath, path)
 }
 
-// MetricsEnabled checks whether metric tracking is enabled.
+// MetricsEnabled checks whether the user opted in to metric tracking.
+//
+// The previous metrics enabled setting is written to the settings file by default,
+// so only the explicit opt in setting enables tracking.
 func MetricsEnabled() bool {
 	if err := loadViper(); err != nil {
-		return true
+		return false
 	}
-	return viper.GetBool(metricsEnabled)
+	return viper.GetBool(metricsOptIn) && viper.GetBool(metricsEnabled)
+}
+
+// MetricsCollector gets the URL of the self-hosted collector the usage metrics are sent to,
+// empty if the metrics are sent to the Flow CLI maintainers.
+func MetricsCollector() string {
+	if err := loadViper(); err != nil {
+		return ""
+	}
+	return viper.GetString(metricsCollector)
+}
+
+// SetMetrics opts in or out of metric tracking, the previous metrics enabled setting is
+// updated as well so older versions sharing the settings file respect the choice.
+func SetMetrics(enabled bool) error {
+	if err := Set(metricsOptIn, enabled); err != nil {
+		return err
+	}
+	return Set(metricsEnabled, enabled)
+}
+
+func SetMetricsCollector(url string) error {
+	return Set(metricsCollector, url)
 }
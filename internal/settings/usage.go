@@ -0,0 +1,99 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"time"
+)
+
+const usageFile = "flow-cli.usage.json"
+
+// CommandUsage is the local summary of the runs of a command.
+type CommandUsage struct {
+	Runs       int       `json:"runs"`
+	Failures   int       `json:"failures"`
+	TotalMs    int64     `json:"totalMs"`
+	MaxMs      int64     `json:"maxMs"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+}
+
+// AverageDuration is the average duration of the runs of the command.
+func (u CommandUsage) AverageDuration() time.Duration {
+	if u.Runs == 0 {
+		return 0
+	}
+	return time.Duration(u.TotalMs/int64(u.Runs)) * time.Millisecond
+}
+
+// UsageFilePath is the path of the file the local usage summary is kept in.
+func UsageFilePath() string {
+	return path.Join(FileDir(), usageFile)
+}
+
+// UsageSummary returns the local usage summary by command, it is empty if nothing was recorded yet.
+func UsageSummary() (map[string]CommandUsage, error) {
+	summary := make(map[string]CommandUsage)
+
+	data, err := os.ReadFile(UsageFilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return summary, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
+
+// RecordUsage adds the run of the command to the local usage summary.
+func RecordUsage(command string, duration time.Duration, failed bool) error {
+	summary, err := UsageSummary()
+	if err != nil {
+		return err
+	}
+
+	usage := summary[command]
+	usage.Runs++
+	if failed {
+		usage.Failures++
+	}
+	usage.TotalMs += duration.Milliseconds()
+	if duration.Milliseconds() > usage.MaxMs {
+		usage.MaxMs = duration.Milliseconds()
+	}
+	usage.LastUsedAt = time.Now().UTC()
+	summary[command] = usage
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := createSettingsDir(); err != nil {
+		return err
+	}
+	return os.WriteFile(UsageFilePath(), data, 0644)
+}
@@ -0,0 +1,53 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package settings
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RecordUsage(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+
+	summary, err := UsageSummary()
+	require.NoError(t, err)
+	assert.Empty(t, summary)
+
+	require.NoError(t, RecordUsage("flow accounts get", 100*time.Millisecond, false))
+	require.NoError(t, RecordUsage("flow accounts get", 300*time.Millisecond, true))
+	require.NoError(t, RecordUsage("flow blocks get", 50*time.Millisecond, false))
+
+	summary, err = UsageSummary()
+	require.NoError(t, err)
+	require.Len(t, summary, 2)
+
+	usage := summary["flow accounts get"]
+	assert.Equal(t, 2, usage.Runs)
+	assert.Equal(t, 1, usage.Failures)
+	assert.Equal(t, int64(300), usage.MaxMs)
+	assert.Equal(t, 200*time.Millisecond, usage.AverageDuration())
+	assert.False(t, usage.LastUsedAt.IsZero())
+}
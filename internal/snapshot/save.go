@@ -19,6 +19,7 @@
 package snapshot
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 
@@ -40,6 +41,14 @@ var saveCommand = &command.Command{
 	Run:   save,
 }
 
+// snapshotHead contains the part of an encoded snapshot describing its head block.
+type snapshotHead struct {
+	Head struct {
+		ChainID string
+		Height  uint64
+	}
+}
+
 func save(
 	args []string,
 	_ command.GlobalFlags,
@@ -55,11 +64,15 @@ func save(
 	}
 
 	snapshotBytes, err := flow.Gateway().GetLatestProtocolStateSnapshot()
+	logger.StopProgress()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get latest finalized protocol snapshot from gateway: %w", err)
 	}
 
-	logger.StopProgress()
+	var snapshot snapshotHead
+	if err := json.Unmarshal(snapshotBytes, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode protocol snapshot: %w", err)
+	}
 
 	outputPath, err := filepath.Abs(fileName)
 	if err != nil {
@@ -71,5 +84,10 @@ func save(
 		return nil, fmt.Errorf("failed to write protocol snapshot file to %s: %w", outputPath, err)
 	}
 
-	return &saveResult{OutputPath: outputPath}, nil
+	return &saveResult{
+		OutputPath: outputPath,
+		Height:     snapshot.Head.Height,
+		ChainID:    snapshot.Head.ChainID,
+		Size:       len(snapshotBytes),
+	}, nil
 }
@@ -19,9 +19,12 @@
 package snapshot
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/util"
 )
 
 var Cmd = &cobra.Command{
@@ -37,14 +40,31 @@ func init() {
 // saveResult represents the result of the snapshot save command.
 type saveResult struct {
 	OutputPath string
+	Height     uint64
+	ChainID    string
+	Size       int
 }
 
 func (r *saveResult) JSON() any {
-	return map[string]string{"path": r.OutputPath}
+	return map[string]any{
+		"path":    r.OutputPath,
+		"height":  r.Height,
+		"chainID": r.ChainID,
+		"size":    r.Size,
+	}
 }
 
 func (r *saveResult) String() string {
-	return fmt.Sprintf("snapshot saved: %s", r.OutputPath)
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Path\t%s\n", r.OutputPath)
+	_, _ = fmt.Fprintf(writer, "Block Height\t%d\n", r.Height)
+	_, _ = fmt.Fprintf(writer, "Chain ID\t%s\n", r.ChainID)
+	_, _ = fmt.Fprintf(writer, "Size\t%d bytes\n", r.Size)
+
+	_ = writer.Flush()
+	return b.String()
 }
 
 func (r *saveResult) Oneliner() string {
@@ -0,0 +1,60 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package snapshot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type snapshotGateway struct {
+	*gateway.MemoryGateway
+	snapshot []byte
+}
+
+func (g *snapshotGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
+	return g.snapshot, nil
+}
+
+func Test_Save(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+	latest := snapshotGateway{
+		MemoryGateway: gateway.NewMemoryGateway(),
+		snapshot:      []byte(`{"Head": {"ChainID": "flow-testnet", "Height": 120}}`),
+	}
+	srv.Gateway.Return(&latest)
+
+	result, err := save([]string{"snapshot.json"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+	require.NoError(t, err)
+
+	saved := result.(*saveResult)
+	assert.Equal(t, uint64(120), saved.Height)
+	assert.Equal(t, "flow-testnet", saved.ChainID)
+	assert.Equal(t, len(latest.snapshot), saved.Size)
+
+	content, err := rw.ReadFile(saved.OutputPath)
+	require.NoError(t, err)
+	assert.Equal(t, latest.snapshot, content)
+}
@@ -0,0 +1,223 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var NetworkInfoCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "network-info",
+		Short:   "Display chain, spork and access node version information of the network",
+		Example: "flow network-info --network mainnet",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	Run:   networkInfo,
+}
+
+func networkInfo(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	logger.StartProgress(fmt.Sprintf("Loading %s network info...", flow.Network().Name))
+	defer logger.StopProgress()
+
+	return getNetworkInfo(flow.Network(), flow.Gateway(), gateway.NewHostGateway)
+}
+
+// protocolSnapshot contains the values used from the JSON encoded protocol state snapshot.
+type protocolSnapshot struct {
+	Head struct {
+		ChainID   string
+		Height    uint64
+		View      uint64
+		Timestamp time.Time
+	}
+	Phase  int
+	Epochs struct {
+		Current struct {
+			Counter   uint64
+			FirstView uint64
+			FinalView uint64
+		}
+	}
+	Params struct {
+		ChainID              string
+		SporkID              string
+		SporkRootBlockHeight uint64
+		ProtocolVersion      uint
+	}
+}
+
+// epochPhases are the names of the epoch phases by their value in the snapshot.
+var epochPhases = []string{"undefined", "staking", "setup", "committed"}
+
+func (s *protocolSnapshot) phase() string {
+	if s.Phase < 0 || s.Phase >= len(epochPhases) {
+		return epochPhases[0]
+	}
+	return epochPhases[s.Phase]
+}
+
+// getNetworkInfo gets the protocol state snapshot and the latest sealed block from the gateway,
+// and the access node version using a direct connection to the network host.
+//
+// The access node version is optional, since not all access nodes and the emulator support it.
+func getNetworkInfo(
+	network config.Network,
+	gw gateway.Gateway,
+	newGateway func(config.Network) (gateway.Gateway, error),
+) (*networkInfoResult, error) {
+	result := &networkInfoResult{network: network}
+
+	latest, err := gw.GetLatestBlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest block: %w", err)
+	}
+	result.latestHeight = latest.Height
+
+	snapshotBytes, err := gw.GetLatestProtocolStateSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the latest protocol state snapshot: %w", err)
+	}
+
+	var snapshot protocolSnapshot
+	if err := json.Unmarshal(snapshotBytes, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode the protocol state snapshot: %w", err)
+	}
+	result.snapshot = &snapshot
+
+	hostNetwork := network
+	hostNetwork.FallbackHosts = nil
+	hostNetwork.ArchiveHost = ""
+	if host, err := newGateway(hostNetwork); err == nil {
+		if versioned, ok := host.(interface {
+			GetNodeVersionInfo() (*gateway.NodeVersionInfo, error)
+		}); ok {
+			result.version, result.versionErr = versioned.GetNodeVersionInfo()
+		}
+	}
+
+	return result, nil
+}
+
+type networkInfoResult struct {
+	network      config.Network
+	latestHeight uint64
+	snapshot     *protocolSnapshot
+	version      *gateway.NodeVersionInfo
+	versionErr   error
+}
+
+func (r *networkInfoResult) JSON() any {
+	result := map[string]any{
+		"network":             r.network.Name,
+		"accessNode":          r.network.Host,
+		"chainId":             r.snapshot.Params.ChainID,
+		"sporkId":             r.snapshot.Params.SporkID,
+		"sporkRootHeight":     r.snapshot.Params.SporkRootBlockHeight,
+		"protocolVersion":     r.snapshot.Params.ProtocolVersion,
+		"latestSealedHeight":  r.latestHeight,
+		"snapshotHeight":      r.snapshot.Head.Height,
+		"snapshotView":        r.snapshot.Head.View,
+		"snapshotTimestamp":   r.snapshot.Head.Timestamp,
+		"epochCounter":        r.snapshot.Epochs.Current.Counter,
+		"epochPhase":          r.snapshot.phase(),
+		"epochFirstView":      r.snapshot.Epochs.Current.FirstView,
+		"epochFinalView":      r.snapshot.Epochs.Current.FinalView,
+		"nodeVersion":         nil,
+		"nodeCommit":          nil,
+		"nodeProtocolVersion": nil,
+	}
+
+	if r.version != nil {
+		result["nodeVersion"] = r.version.Semver
+		result["nodeCommit"] = r.version.Commit
+		result["nodeProtocolVersion"] = r.version.ProtocolVersion
+	}
+
+	return result
+}
+
+func (r *networkInfoResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Network\t%s\n", r.network.Name)
+	_, _ = fmt.Fprintf(writer, "Access Node\t%s\n", r.network.Host)
+	_, _ = fmt.Fprintf(writer, "Chain ID\t%s\n", r.snapshot.Params.ChainID)
+	_, _ = fmt.Fprintf(writer, "Spork ID\t%s\n", r.snapshot.Params.SporkID)
+	_, _ = fmt.Fprintf(writer, "Spork Root Height\t%d\n", r.snapshot.Params.SporkRootBlockHeight)
+	_, _ = fmt.Fprintf(writer, "Protocol Version\t%d\n", r.snapshot.Params.ProtocolVersion)
+	_, _ = fmt.Fprintf(writer, "Latest Sealed Height\t%d\n", r.latestHeight)
+
+	_, _ = fmt.Fprintf(writer, "\nProtocol Snapshot\t\n")
+	_, _ = fmt.Fprintf(writer, "    Height\t%d\n", r.snapshot.Head.Height)
+	_, _ = fmt.Fprintf(writer, "    View\t%d\n", r.snapshot.Head.View)
+	_, _ = fmt.Fprintf(writer, "    Timestamp\t%s\n", r.snapshot.Head.Timestamp)
+	_, _ = fmt.Fprintf(writer, "    Epoch\t%d (%s phase, views %d-%d)\n",
+		r.snapshot.Epochs.Current.Counter,
+		r.snapshot.phase(),
+		r.snapshot.Epochs.Current.FirstView,
+		r.snapshot.Epochs.Current.FinalView,
+	)
+
+	_, _ = fmt.Fprintf(writer, "\nAccess Node Software\t\n")
+	if r.version != nil {
+		_, _ = fmt.Fprintf(writer, "    Version\t%s\n", r.version.Semver)
+		_, _ = fmt.Fprintf(writer, "    Commit\t%s\n", r.version.Commit)
+		_, _ = fmt.Fprintf(writer, "    Protocol Version\t%d\n", r.version.ProtocolVersion)
+	} else if r.versionErr != nil {
+		_, _ = fmt.Fprintf(writer, "    Version\tunavailable: %s\n", r.versionErr)
+	} else {
+		_, _ = fmt.Fprintf(writer, "    Version\tunavailable\n")
+	}
+
+	_, _ = fmt.Fprintf(writer,
+		"\nBlocks below the spork root height %d are only available from the access nodes of previous sporks.\n",
+		r.snapshot.Params.SporkRootBlockHeight,
+	)
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (r *networkInfoResult) Oneliner() string {
+	return fmt.Sprintf(
+		"Network: %s, Chain ID: %s, Spork Root Height: %d, Latest Sealed Height: %d",
+		r.network.Name, r.snapshot.Params.ChainID, r.snapshot.Params.SporkRootBlockHeight, r.latestHeight,
+	)
+}
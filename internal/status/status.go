@@ -21,54 +21,198 @@ package status
 import (
 	"bytes"
 	"fmt"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsStatus struct {
+	All bool `default:"false" flag:"all" info:"Display the status of all the configured networks"`
 }
 
 var statusFlags = flagsStatus{}
 
 var Command = &command.Command{
 	Cmd: &cobra.Command{
-		Use:   "status",
-		Short: "Display the status of the Flow network",
+		Use:     "status",
+		Short:   "Display the status of the Flow network",
+		Example: "flow status\nflow status --all",
 	},
 	Flags: &statusFlags,
 	RunS:  status,
 }
 
+const (
+	kindGRPC    = "gRPC"
+	kindREST    = "REST"
+	kindArchive = "archive"
+)
+
 func status(
 	_ []string,
 	_ command.GlobalFlags,
 	_ output.Logger,
 	flow flowkit.Services,
-	_ *flowkit.State,
+	state *flowkit.State,
 ) (command.Result, error) {
-	err := flow.Ping()
+	networks := config.Networks{flow.Network()}
+	if statusFlags.All {
+		networks = config.DefaultNetworks
+		if state != nil {
+			networks = *state.Networks()
+		}
+	}
 
 	return &result{
-		network:    flow.Network().Name,
-		accessNode: flow.Network().Host,
-		err:        err,
+		networks: checkNetworks(networks, gateway.NewHostGateway),
 	}, nil
 }
 
-type result struct {
-	network    string
-	accessNode string
-	err        error
+// checkNetworks checks the status of all the networks concurrently.
+func checkNetworks(
+	networks config.Networks,
+	newGateway func(config.Network) (gateway.Gateway, error),
+) []*networkStatus {
+	statuses := make([]*networkStatus, len(networks))
+
+	var wg sync.WaitGroup
+	for i, network := range networks {
+		wg.Add(1)
+		go func(i int, network config.Network) {
+			defer wg.Done()
+			statuses[i] = checkNetwork(network, newGateway)
+		}(i, network)
+	}
+	wg.Wait()
+
+	return statuses
+}
+
+// checkNetwork checks the status of all the network hosts and the archive host.
+func checkNetwork(network config.Network, newGateway func(config.Network) (gateway.Gateway, error)) *networkStatus {
+	status := &networkStatus{name: network.Name}
+
+	for _, host := range network.Hosts() {
+		kind := kindGRPC
+		if gateway.IsHTTPHost(host) {
+			kind = kindREST
+		}
+		status.endpoints = append(status.endpoints, checkEndpoint(network, host, kind, newGateway))
+	}
+
+	if network.ArchiveHost != "" {
+		status.endpoints = append(status.endpoints, checkEndpoint(network, network.ArchiveHost, kindArchive, newGateway))
+	}
+
+	return status
+}
+
+// checkEndpoint pings the host and gets the latest sealed height and the node version if supported.
+func checkEndpoint(
+	network config.Network,
+	host string,
+	kind string,
+	newGateway func(config.Network) (gateway.Gateway, error),
+) endpointStatus {
+	endpoint := endpointStatus{host: host, kind: kind}
+
+	hostNetwork := network
+	hostNetwork.Host = host
+	hostNetwork.FallbackHosts = nil
+	hostNetwork.ArchiveHost = ""
+	if kind == kindArchive {
+		hostNetwork.Key = ""
+	}
+	if gateway.IsHTTPHost(host) {
+		hostNetwork.Key = ""
+		hostNetwork.TLS = config.TLSConfig{}
+	}
+
+	gw, err := newGateway(hostNetwork)
+	if err != nil {
+		endpoint.err = err
+		return endpoint
+	}
+
+	start := time.Now()
+	endpoint.err = gw.Ping()
+	endpoint.latency = time.Since(start)
+	if endpoint.err != nil {
+		return endpoint
+	}
+
+	if block, err := gw.GetLatestBlock(); err == nil {
+		endpoint.height = block.Height
+	}
+
+	if versioned, ok := gw.(interface {
+		GetNodeVersionInfo() (*gateway.NodeVersionInfo, error)
+	}); ok {
+		if info, err := versioned.GetNodeVersionInfo(); err == nil {
+			endpoint.version = info.Semver
+		}
+	}
+
+	return endpoint
+}
+
+type endpointStatus struct {
+	host    string
+	kind    string
+	err     error
+	latency time.Duration
+	height  uint64
+	version string
+}
+
+func (e endpointStatus) status() string {
+	if e.err == nil {
+		return "ONLINE"
+	}
+
+	return "OFFLINE"
+}
+
+func (e endpointStatus) coloredStatus() string {
+	if e.err == nil {
+		return output.Green(e.status())
+	}
+
+	return output.Red(e.status())
+}
+
+type networkStatus struct {
+	name      string
+	endpoints []endpointStatus
+}
+
+// primary returns the first reachable access node, or the first access node if none is reachable.
+func (n *networkStatus) primary() endpointStatus {
+	for _, endpoint := range n.endpoints {
+		if endpoint.kind != kindArchive && endpoint.err == nil {
+			return endpoint
+		}
+	}
+
+	return n.endpoints[0]
 }
 
-// getStatus returns string representation for Flow network status.
-func (r *result) getStatus() string {
-	if r.err == nil {
+// online checks whether any access node of the network is reachable.
+func (n *networkStatus) online() bool {
+	return n.primary().err == nil
+}
+
+func (n *networkStatus) getStatus() string {
+	if n.online() {
 		return "ONLINE"
 	}
 
@@ -76,31 +220,98 @@ func (r *result) getStatus() string {
 }
 
 // getColoredStatus returns colored string representation for Flow network status.
-func (r *result) getColoredStatus() string {
-	if r.err == nil {
-		return output.Green(r.getStatus())
+func (n *networkStatus) getColoredStatus() string {
+	if n.online() {
+		return output.Green(n.getStatus())
 	}
 
-	return output.Red(output.Red(r.getStatus()))
+	return output.Red(n.getStatus())
 }
 
 // getIcon returns emoji icon representing Flow network status.
-func (r *result) getIcon() string {
-	if r.err == nil {
+func (n *networkStatus) getIcon() string {
+	if n.online() {
 		return output.GoEmoji()
 	}
 
 	return output.StopEmoji()
 }
 
+func (n *networkStatus) JSON() map[string]any {
+	primary := n.primary()
+	result := map[string]any{
+		"network":    n.name,
+		"accessNode": primary.host,
+		"status":     n.getStatus(),
+	}
+
+	if n.online() {
+		result["latency"] = primary.latency.Milliseconds()
+		result["latestSealedHeight"] = primary.height
+		if primary.version != "" {
+			result["nodeVersion"] = primary.version
+		}
+	}
+
+	endpoints := make([]map[string]any, 0, len(n.endpoints))
+	for _, endpoint := range n.endpoints {
+		e := map[string]any{
+			"host":   endpoint.host,
+			"type":   endpoint.kind,
+			"status": endpoint.status(),
+		}
+		if endpoint.err == nil {
+			e["latency"] = endpoint.latency.Milliseconds()
+		} else {
+			e["error"] = endpoint.err.Error()
+		}
+		endpoints = append(endpoints, e)
+	}
+	result["endpoints"] = endpoints
+
+	return result
+}
+
+type result struct {
+	networks []*networkStatus
+}
+
 // String converts result to a string.
 func (r *result) String() string {
 	var b bytes.Buffer
 	writer := util.CreateTabWriter(&b)
 
-	_, _ = fmt.Fprintf(writer, "Status:\t %s %s\n", r.getIcon(), r.getColoredStatus())
-	_, _ = fmt.Fprintf(writer, "Network:\t %s\n", r.network)
-	_, _ = fmt.Fprintf(writer, "Access Node:\t %s\n", r.accessNode)
+	for i, network := range r.networks {
+		if i > 0 {
+			_, _ = fmt.Fprintf(writer, "\t\n")
+		}
+
+		primary := network.primary()
+		_, _ = fmt.Fprintf(writer, "Status:\t %s %s\n", network.getIcon(), network.getColoredStatus())
+		_, _ = fmt.Fprintf(writer, "Network:\t %s\n", network.name)
+		_, _ = fmt.Fprintf(writer, "Access Node:\t %s\n", primary.host)
+		if network.online() {
+			_, _ = fmt.Fprintf(writer, "Latency:\t %s\n", primary.latency.Round(time.Millisecond))
+			_, _ = fmt.Fprintf(writer, "Latest Sealed Height:\t %d\n", primary.height)
+			if primary.version != "" {
+				_, _ = fmt.Fprintf(writer, "Node Version:\t %s\n", primary.version)
+			}
+		}
+
+		if len(network.endpoints) > 1 {
+			for j, endpoint := range network.endpoints {
+				label := ""
+				if j == 0 {
+					label = "Endpoints:"
+				}
+				_, _ = fmt.Fprintf(writer, "%s\t %s %s %s", label, endpoint.host, endpoint.kind, endpoint.coloredStatus())
+				if endpoint.err == nil {
+					_, _ = fmt.Fprintf(writer, " %s", endpoint.latency.Round(time.Millisecond))
+				}
+				_, _ = fmt.Fprintf(writer, "\n")
+			}
+		}
+	}
 
 	_ = writer.Flush()
 	return b.String()
@@ -108,16 +319,28 @@ func (r *result) String() string {
 
 // JSON converts result to a JSON.
 func (r *result) JSON() any {
-	result := make(map[string]string)
+	if len(r.networks) == 1 {
+		return r.networks[0].JSON()
+	}
 
-	result["network"] = r.network
-	result["accessNode"] = r.accessNode
-	result["status"] = r.getStatus()
+	networks := make([]map[string]any, 0, len(r.networks))
+	for _, network := range r.networks {
+		networks = append(networks, network.JSON())
+	}
 
-	return result
+	return networks
 }
 
 // Oneliner returns result as one liner grep friendly.
 func (r *result) Oneliner() string {
-	return r.getStatus()
+	if len(r.networks) == 1 {
+		return r.networks[0].getStatus()
+	}
+
+	statuses := make([]string, 0, len(r.networks))
+	for _, network := range r.networks {
+		statuses = append(statuses, fmt.Sprintf("%s:%s", network.name, network.getStatus()))
+	}
+
+	return strings.Join(statuses, " ")
 }
@@ -0,0 +1,124 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package status
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+)
+
+func Test_Status(t *testing.T) {
+	newGateway := func(network config.Network) (gateway.Gateway, error) {
+		if network.Host == "offline:9000" {
+			return nil, fmt.Errorf("failed to connect to host %s", network.Host)
+		}
+		return gateway.NewMemoryGateway(), nil
+	}
+
+	t.Run("Check networks", func(t *testing.T) {
+		networks := config.Networks{{
+			Name:          "testnet",
+			Host:          "offline:9000",
+			FallbackHosts: []string{"https://rest-testnet.onflow.org"},
+			ArchiveHost:   "archive.testnet.nodes.onflow.org:9000",
+		}, {
+			Name: "private",
+			Host: "offline:9000",
+		}}
+
+		statuses := checkNetworks(networks, newGateway)
+		require.Len(t, statuses, 2)
+
+		testnet := statuses[0]
+		assert.True(t, testnet.online())
+		require.Len(t, testnet.endpoints, 3)
+		assert.Equal(t, kindGRPC, testnet.endpoints[0].kind)
+		assert.Error(t, testnet.endpoints[0].err)
+		assert.Equal(t, kindREST, testnet.endpoints[1].kind)
+		assert.NoError(t, testnet.endpoints[1].err)
+		assert.Equal(t, kindArchive, testnet.endpoints[2].kind)
+		assert.NoError(t, testnet.endpoints[2].err)
+		assert.Equal(t, "https://rest-testnet.onflow.org", testnet.primary().host)
+
+		private := statuses[1]
+		assert.False(t, private.online())
+
+		r := &result{networks: statuses}
+		assert.Equal(t, "testnet:ONLINE private:OFFLINE", r.Oneliner())
+
+		json := r.JSON().([]map[string]any)
+		assert.Equal(t, "https://rest-testnet.onflow.org", json[0]["accessNode"])
+		assert.Equal(t, uint64(0), json[0]["latestSealedHeight"])
+		assert.Equal(t, "OFFLINE", json[1]["status"])
+	})
+}
+
+type snapshotGateway struct {
+	*gateway.MemoryGateway
+	snapshot []byte
+}
+
+func (g *snapshotGateway) GetLatestProtocolStateSnapshot() ([]byte, error) {
+	return g.snapshot, nil
+}
+
+func Test_NetworkInfo(t *testing.T) {
+	newGateway := func(network config.Network) (gateway.Gateway, error) {
+		return nil, fmt.Errorf("failed to connect to host %s", network.Host)
+	}
+	network := config.Network{Name: "testnet", Host: "access.devnet.nodes.onflow.org:9000"}
+
+	t.Run("Success", func(t *testing.T) {
+		gw := &snapshotGateway{
+			MemoryGateway: gateway.NewMemoryGateway(),
+			snapshot: []byte(`{
+				"Head": {"ChainID": "flow-testnet", "Height": 120, "View": 130},
+				"Phase": 2,
+				"Epochs": {"Current": {"Counter": 7, "FirstView": 100, "FinalView": 200}},
+				"Params": {"ChainID": "flow-testnet", "SporkID": "abcd", "SporkRootBlockHeight": 100, "ProtocolVersion": 3}
+			}`),
+		}
+
+		result, err := getNetworkInfo(network, gw, newGateway)
+		require.NoError(t, err)
+		assert.Nil(t, result.version)
+		assert.Equal(t, "setup", result.snapshot.phase())
+		assert.Equal(t,
+			"Network: testnet, Chain ID: flow-testnet, Spork Root Height: 100, Latest Sealed Height: 0",
+			result.Oneliner(),
+		)
+
+		json := result.JSON().(map[string]any)
+		assert.Equal(t, uint64(100), json["sporkRootHeight"])
+		assert.Equal(t, uint64(7), json["epochCounter"])
+		assert.Nil(t, json["nodeVersion"])
+		assert.Contains(t, result.String(), "spork root height 100")
+	})
+
+	t.Run("Fail unsupported snapshot", func(t *testing.T) {
+		_, err := getNetworkInfo(network, gateway.NewMemoryGateway(), newGateway)
+		assert.ErrorContains(t, err, "failed to get the latest protocol state snapshot")
+	})
+}
@@ -28,11 +28,14 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 )
 
-type flagsDev struct{}
+type flagsDev struct {
+	FromConfig bool `default:"false" flag:"from-config" info:"Watch contracts defined in the configuration instead of the contracts folder"`
+}
 
 var devFlags = flagsDev{}
 
@@ -41,7 +44,7 @@ var DevCommand = &command.Command{
 		Use:     "dev",
 		Short:   "Build your Flow project",
 		Args:    cobra.ExactArgs(0),
-		Example: "flow dev",
+		Example: "flow dev\nflow dev --from-config",
 		GroupID: "super",
 	},
 	Flags: &devFlags,
@@ -74,6 +77,10 @@ func dev(
 
 	flow.SetLogger(output.NewStdoutLogger(output.NoneLog))
 
+	if devFlags.FromConfig {
+		return nil, devFromConfig(*service, flow, state)
+	}
+
 	project, err := newProject(
 		*service,
 		flow,
@@ -107,3 +114,19 @@ func dev(
 
 	return nil, nil
 }
+
+// devFromConfig watches the contracts defined in the configuration and keeps them updated on the emulator.
+func devFromConfig(service accounts.Account, flow flowkit.Services, state *flowkit.State) error {
+	project, err := newConfigProject(service, flow, state)
+	if err != nil {
+		return err
+	}
+
+	err = project.startup()
+	if err != nil {
+		return err
+	}
+	project.print()
+
+	return project.watch()
+}
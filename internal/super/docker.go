@@ -0,0 +1,229 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package super
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	texttemplate "text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/build"
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsDocker struct {
+	Devcontainer bool `default:"false" flag:"devcontainer" info:"Also generate a dev container configuration using the Docker Compose services"`
+	Force        bool `default:"false" flag:"force" info:"Overwrite existing files"`
+}
+
+var dockerFlags = flagsDocker{}
+
+var dockerCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "docker",
+		Short: "Generate a Docker Compose setup running the emulator, dev wallet and contract deployment",
+		Long: `Generate a Docker Compose setup of the project in the current directory. The emulator and the dev wallet
+are started with the project configuration and the contracts of the emulator deployments are deployed once
+the emulator is ready, so the local network is started with docker compose up.`,
+		Example: "flow setup docker\nflow setup docker --devcontainer",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &dockerFlags,
+	RunS:  setupDocker,
+}
+
+func init() {
+	dockerCommand.AddToParent(SetupCommand.Cmd)
+}
+
+const (
+	dockerfileName    = "Dockerfile.flow"
+	composeName       = "docker-compose.yml"
+	devcontainerName  = ".devcontainer/devcontainer.json"
+	defaultWalletPort = 8701
+)
+
+func setupDocker(
+	_ []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	files, err := dockerFiles(state, dockerFlags.Devcontainer)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{dockerfileName, composeName}
+	if dockerFlags.Devcontainer {
+		names = append(names, devcontainerName)
+	}
+
+	if !dockerFlags.Force {
+		for _, name := range names {
+			if _, err := os.Stat(name); err == nil {
+				return nil, fmt.Errorf("file %s already exists, use --force to overwrite it", name)
+			}
+		}
+	}
+
+	for _, name := range names {
+		if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+			return nil, err
+		}
+		if err := state.ReaderWriter().WriteFile(name, files[name], 0644); err != nil {
+			return nil, fmt.Errorf("error writing %s: %w", name, err)
+		}
+		logger.Info(fmt.Sprintf("%s Created %s", output.SuccessEmoji(), name))
+	}
+
+	logger.Info("Start the local network with: docker compose up")
+	return nil, nil
+}
+
+// dockerFiles renders the files of the Docker setup by name.
+//
+// The services use the default emulator of the configuration, and the contracts are only deployed
+// if the project has deployments on the emulator network.
+func dockerFiles(state *flowkit.State, devcontainer bool) (map[string][]byte, error) {
+	emulator := state.Config().Emulators.Default()
+	if emulator == nil {
+		return nil, fmt.Errorf("no default emulator in the configuration")
+	}
+
+	port, restPort := emulator.Port, emulator.RestPort
+	if port == 0 {
+		port = config.DefaultEmulator.Port
+	}
+	if restPort == 0 {
+		restPort = 8888
+	}
+
+	version := ""
+	if build.IsDefined(build.Semver()) {
+		version = build.Semver()
+	}
+
+	data := map[string]any{
+		"Version":    version,
+		"Port":       port,
+		"RestPort":   restPort,
+		"WalletPort": defaultWalletPort,
+		"Deploy":     len(state.Deployments().ByNetwork(config.EmulatorNetwork.Name)) > 0,
+		"Dockerfile": dockerfileName,
+		"Compose":    composeName,
+	}
+
+	templates := map[string]*texttemplate.Template{
+		dockerfileName: dockerfileTemplate,
+		composeName:    composeTemplate,
+	}
+	if devcontainer {
+		templates[devcontainerName] = devcontainerTemplate
+	}
+
+	files := make(map[string][]byte)
+	for name, t := range templates {
+		var b bytes.Buffer
+		if err := t.Execute(&b, data); err != nil {
+			return nil, err
+		}
+		files[name] = b.Bytes()
+	}
+	return files, nil
+}
+
+var dockerfileTemplate = texttemplate.Must(texttemplate.New("dockerfile").Parse(`# Generated by flow setup docker, image with the Flow CLI running the services of the project.
+FROM debian:bookworm-slim
+
+RUN apt-get update \
+    && apt-get install -y --no-install-recommends ca-certificates curl \
+    && rm -rf /var/lib/apt/lists/*
+
+ARG FLOW_CLI_VERSION={{ .Version }}
+RUN sh -ci "$(curl -fsSL https://raw.githubusercontent.com/onflow/flow-cli/master/install.sh)" -- $FLOW_CLI_VERSION
+ENV PATH="/root/.local/bin:${PATH}"
+
+WORKDIR /app
+`))
+
+// the dev wallet and the deployment share the network of the emulator, so the emulator hosts of the configuration are valid
+var composeTemplate = texttemplate.Must(texttemplate.New("compose").Parse(`# Generated by flow setup docker, start the local network with: docker compose up
+services:
+  emulator:
+    build:
+      context: .
+      dockerfile: {{ .Dockerfile }}
+    command: flow emulator
+    volumes:
+      - .:/app
+    ports:
+      - "{{ .Port }}:{{ .Port }}"
+      - "{{ .RestPort }}:{{ .RestPort }}"
+      - "{{ .WalletPort }}:{{ .WalletPort }}"
+    healthcheck:
+      test: ["CMD", "curl", "-fs", "http://localhost:{{ .RestPort }}/v1/blocks?height=sealed"]
+      interval: 2s
+      timeout: 5s
+      retries: 30
+
+  dev-wallet:
+    build:
+      context: .
+      dockerfile: {{ .Dockerfile }}
+    command: flow dev-wallet --port {{ .WalletPort }} --emulator-host http://localhost:{{ .RestPort }}
+    volumes:
+      - .:/app
+    network_mode: service:emulator
+    depends_on:
+      emulator:
+        condition: service_healthy
+{{- if .Deploy }}
+
+  deploy:
+    build:
+      context: .
+      dockerfile: {{ .Dockerfile }}
+    command: flow project deploy --network emulator --update
+    volumes:
+      - .:/app
+    network_mode: service:emulator
+    restart: "no"
+    depends_on:
+      emulator:
+        condition: service_healthy
+{{- end }}
+`))
+
+var devcontainerTemplate = texttemplate.Must(texttemplate.New("devcontainer").Parse(`{
+  "name": "Flow",
+  "dockerComposeFile": "../{{ .Compose }}",
+  "service": "emulator",
+  "workspaceFolder": "/app",
+  "forwardPorts": [{{ .Port }}, {{ .RestPort }}, {{ .WalletPort }}]
+}
+`))
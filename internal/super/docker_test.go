@@ -0,0 +1,61 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package super
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_DockerFiles(t *testing.T) {
+	_, state, _ := util.TestMocks(t)
+
+	t.Run("Without Deployments", func(t *testing.T) {
+		files, err := dockerFiles(state, false)
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+
+		compose := string(files[composeName])
+		assert.Contains(t, compose, `- "3569:3569"`)
+		assert.Contains(t, compose, "command: flow dev-wallet --port 8701 --emulator-host http://localhost:8888")
+		assert.NotContains(t, compose, "flow project deploy")
+		assert.Contains(t, string(files[dockerfileName]), "FROM debian:bookworm-slim")
+	})
+
+	t.Run("With Deployments", func(t *testing.T) {
+		state.Deployments().AddOrUpdate(config.Deployment{
+			Network:   config.EmulatorNetwork.Name,
+			Account:   config.DefaultEmulator.ServiceAccount,
+			Contracts: []config.ContractDeployment{{Name: "Foo"}},
+		})
+
+		files, err := dockerFiles(state, true)
+		require.NoError(t, err)
+		require.Len(t, files, 3)
+
+		compose := string(files[composeName])
+		assert.Contains(t, compose, "command: flow project deploy --network emulator --update")
+		assert.Contains(t, string(files[devcontainerName]), `"forwardPorts": [3569, 8888, 8701]`)
+	})
+}
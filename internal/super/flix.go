@@ -30,6 +30,7 @@ import (
 	"github.com/onflow/flow-cli/internal/command"
 	"github.com/onflow/flow-cli/internal/scripts"
 	"github.com/onflow/flow-cli/internal/transactions"
+	"github.com/onflow/flow-cli/internal/trust"
 
 	"github.com/onflow/flow-cli/flowkit"
 
@@ -63,6 +64,9 @@ var FlixCommand = &command.Command{
 	RunS:  execute,
 }
 
+// flixServerURL is the FLIX server the templates are fetched from.
+const flixServerURL = "https://flix.flow.com/v1/templates"
+
 type flixQueryTypes string
 
 const (
@@ -97,28 +101,36 @@ func getType(s string) flixQueryTypes {
 
 func execute(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	logger output.Logger,
 	flow flowkit.Services,
 	state *flowkit.State,
 ) (result command.Result, err error) {
-	flixService := flixkit.NewFlixService(&flixkit.Config{})
+	flixService := flixkit.NewFlixService(&flixkit.Config{FlixServerURL: flixServerURL})
 	ctx := context.Background()
 	var template *flixkit.FlowInteractionTemplate
 	flixQuery := args[0]
 
 	switch getType(flixQuery) {
 	case flixId:
-		template, err = flixService.GetFlixByID(ctx, flixQuery)
+		raw, err := flixService.GetFlixByIDRaw(ctx, flixQuery)
 		if err != nil {
 			return nil, fmt.Errorf("could not find flix with id %s: %w", flixQuery, err)
 		}
+		template, err = parseVerifiedFlix(fmt.Sprintf("%s/%s", flixServerURL, flixQuery), raw)
+		if err != nil {
+			return nil, err
+		}
 
 	case flixName:
-		template, err = flixService.GetFlix(ctx, flixQuery)
+		raw, err := flixService.GetFlixRaw(ctx, flixQuery)
 		if err != nil {
 			return nil, fmt.Errorf("could not find flix with name %s: %w", flixQuery, err)
 		}
+		template, err = parseVerifiedFlix(fmt.Sprintf("%s?name=%s", flixServerURL, flixQuery), raw)
+		if err != nil {
+			return nil, err
+		}
 
 	case flixPath:
 		file, err := os.ReadFile(flixQuery)
@@ -159,5 +171,18 @@ func execute(
 		Exclude:     flags.Exclude,
 		GasLimit:    flags.GasLimit,
 	}
-	return transactions.SendTransaction([]byte(cadenceWithImportsReplaced), args[1:], "", flow, state, transactionFlags)
+	return transactions.SendTransaction([]byte(cadenceWithImportsReplaced), args[1:], "", globalFlags, flow, state, transactionFlags)
+}
+
+// parseVerifiedFlix verifies the template fetched from the URL against the trust file and parses it.
+func parseVerifiedFlix(url string, raw string) (*flixkit.FlowInteractionTemplate, error) {
+	if err := trust.Verify(url, []byte(raw)); err != nil {
+		return nil, fmt.Errorf("could not verify flix %s: %w", url, err)
+	}
+
+	template, err := flixkit.ParseFlix(raw)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse flix %s: %w", url, err)
+	}
+	return template, nil
 }
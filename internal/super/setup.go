@@ -27,6 +27,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -36,11 +37,14 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/trust"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
 type flagsSetup struct {
-	Scaffold bool `default:"" flag:"scaffold" info:"Use provided scaffolds for project creation"`
+	Scaffold bool   `default:"" flag:"scaffold" info:"Use provided scaffolds for project creation"`
+	Template string `default:"" flag:"template" info:"Name of the built-in template or ID of the registry scaffold used for project creation"`
+	Registry string `default:"" flag:"registry" info:"URL or path of the scaffold registry, defaults to the Flow CLI registry"`
 }
 
 var setupFlags = flagsSetup{}
@@ -49,7 +53,7 @@ var SetupCommand = &command.Command{
 	Cmd: &cobra.Command{
 		Use:     "setup <project name>",
 		Short:   "Start a new Flow project",
-		Example: "flow setup my-project",
+		Example: "flow setup my-project\nflow setup my-project --template nft",
 		Args:    cobra.ExactArgs(1),
 		GroupID: "super",
 	},
@@ -60,6 +64,7 @@ var SetupCommand = &command.Command{
 const scaffoldListURL = "https://raw.githubusercontent.com/onflow/flow-cli/master/scaffolds.json"
 
 type scaffold struct {
+	ID          string `json:"id"`
 	Repo        string `json:"repo"`
 	Branch      string `json:"branch"`
 	Name        string `json:"name"`
@@ -81,16 +86,34 @@ func create(
 		return nil, err
 	}
 
-	scaffolds, err := getScaffolds()
+	if t, ok := templateByName(setupFlags.Template); ok {
+		logger.StartProgress(fmt.Sprintf("Creating your project %s from template %s", targetDir, t.name))
+		err = generateTemplate(targetDir, t)
+		logger.StopProgress()
+		if err != nil {
+			return nil, fmt.Errorf("failed creating project from template: %w", err)
+		}
 
+		return &setupResult{targetDir: targetDir}, nil
+	}
+
+	scaffolds, err := getScaffolds(setupFlags.Registry)
 	if err != nil {
 		return nil, err
 	}
+	if len(scaffolds) == 0 {
+		return nil, fmt.Errorf("no valid scaffolds found in the registry")
+	}
 
 	// default to first scaffold - basic scaffold
 	pickedScaffold := scaffolds[0]
 
-	if setupFlags.Scaffold {
+	if setupFlags.Template != "" {
+		pickedScaffold, err = scaffoldByID(scaffolds, setupFlags.Template)
+		if err != nil {
+			return nil, err
+		}
+	} else if setupFlags.Scaffold {
 		scaffoldItems := make([]util.ScaffoldItem, 0)
 		for i, s := range scaffolds {
 			scaffoldItems = append(
@@ -144,31 +167,54 @@ func getTargetDirectory(directory string) (string, error) {
 	return target, nil
 }
 
-func getScaffolds() ([]scaffold, error) {
-	httpClient := http.Client{
-		Timeout: time.Second * 5,
+// getScaffolds fetches the scaffold list from the registry, which is either a URL or a local file path.
+func getScaffolds(registry string) ([]scaffold, error) {
+	if registry == "" {
+		registry = scaffoldListURL
 	}
 
-	req, err := http.NewRequest(http.MethodGet, scaffoldListURL, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed creating request for scaffold list: %w", err)
-	}
+	var body []byte
+	if strings.HasPrefix(registry, "http://") || strings.HasPrefix(registry, "https://") {
+		httpClient := http.Client{
+			Timeout: time.Second * 5,
+		}
 
-	res, err := httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed requesting scaffold list: %w", err)
-	}
-	if res.Body != nil {
-		defer res.Body.Close()
-	}
+		req, err := http.NewRequest(http.MethodGet, registry, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating request for scaffold list: %w", err)
+		}
 
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed reading scaffold list response: %w", err)
+		res, err := httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed requesting scaffold list: %w", err)
+		}
+		if res.Body != nil {
+			defer res.Body.Close()
+		}
+
+		body, err = io.ReadAll(res.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading scaffold list response: %w", err)
+		}
+
+		// scaffolds are pinned to a commit, so verifying the list verifies the cloned scaffolds as well
+		if err := trust.Verify(registry, body); err != nil {
+			return nil, fmt.Errorf("failed verifying scaffold list: %w", err)
+		}
+	} else {
+		var err error
+		body, err = os.ReadFile(registry)
+		if err != nil {
+			return nil, fmt.Errorf("failed reading scaffold registry: %w", err)
+		}
 	}
 
+	return parseScaffolds(body)
+}
+
+func parseScaffolds(body []byte) ([]scaffold, error) {
 	var all []scaffold
-	err = json.Unmarshal(body, &all)
+	err := json.Unmarshal(body, &all)
 	if err != nil {
 		return nil, fmt.Errorf("failed parsing scaffold list response: %w", err)
 	}
@@ -183,6 +229,21 @@ func getScaffolds() ([]scaffold, error) {
 	return valid, nil
 }
 
+// scaffoldByID returns the scaffold with the ID, reporting all the available templates if not found.
+func scaffoldByID(scaffolds []scaffold, id string) (scaffold, error) {
+	available := templateNames()
+	for _, s := range scaffolds {
+		if s.ID == id {
+			return s, nil
+		}
+		if s.ID != "" {
+			available = append(available, s.ID)
+		}
+	}
+
+	return scaffold{}, fmt.Errorf("template %s not found, available templates: %s", id, strings.Join(available, ", "))
+}
+
 func cloneScaffold(targetDir string, conf scaffold) error {
 	repo, err := git.PlainClone(targetDir, false, &git.CloneOptions{
 		URL: conf.Repo,
@@ -246,5 +307,5 @@ func (s *setupResult) Oneliner() string {
 }
 
 func (s *setupResult) JSON() any {
-	return nil
+	return map[string]any{"targetDir": s.targetDir}
 }
@@ -0,0 +1,437 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package super
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/onflow/flow-go-sdk/crypto"
+	"github.com/spf13/afero"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// template is a starter project generated locally, without downloading a scaffold.
+type template struct {
+	name        string
+	description string
+	contracts   []string // names of the contracts in the contracts folder
+	files       map[string]string
+}
+
+var templates = []template{{
+	name:        "empty",
+	description: "Empty project containing only basic folder structure and flow.json configuration.",
+	files:       map[string]string{},
+}, {
+	name:        "fungible-token",
+	description: "Example fungible token contract with transactions, scripts and tests.",
+	contracts:   []string{"ExampleToken"},
+	files: map[string]string{
+		"cadence/contracts/ExampleToken.cdc":     exampleTokenContract,
+		"cadence/transactions/setup_account.cdc": exampleTokenSetup,
+		"cadence/transactions/mint_tokens.cdc":   exampleTokenMint,
+		"cadence/scripts/get_balance.cdc":        exampleTokenBalance,
+		"cadence/tests/ExampleToken_test.cdc":    exampleTokenTest,
+	},
+}, {
+	name:        "nft",
+	description: "Example non-fungible token contract with transactions, scripts and tests.",
+	contracts:   []string{"ExampleNFT"},
+	files: map[string]string{
+		"cadence/contracts/ExampleNFT.cdc":          exampleNFTContract,
+		"cadence/transactions/setup_collection.cdc": exampleNFTSetup,
+		"cadence/transactions/mint_nft.cdc":         exampleNFTMint,
+		"cadence/scripts/get_ids.cdc":               exampleNFTIDs,
+		"cadence/tests/ExampleNFT_test.cdc":         exampleNFTTest,
+	},
+}}
+
+// projectDirs are created for every template, even if they don't contain any files.
+var projectDirs = []string{
+	filepath.Join(cadenceDir, contractDir),
+	filepath.Join(cadenceDir, scriptDir),
+	filepath.Join(cadenceDir, transactionDir),
+	filepath.Join(cadenceDir, "tests"),
+}
+
+func templateByName(name string) (template, bool) {
+	for _, t := range templates {
+		if t.name == name {
+			return t, true
+		}
+	}
+	return template{}, false
+}
+
+func templateNames() []string {
+	names := make([]string, 0, len(templates))
+	for _, t := range templates {
+		names = append(names, t.name)
+	}
+	return names
+}
+
+// generateTemplate creates the template files and the project configuration in the target directory.
+//
+// Template contracts are added to the configuration and deployed to the emulator service account.
+func generateTemplate(targetDir string, t template) error {
+	for _, dir := range projectDirs {
+		if err := os.MkdirAll(filepath.Join(targetDir, dir), 0755); err != nil {
+			return err
+		}
+	}
+
+	files := map[string]string{"README.md": fmt.Sprintf(templateReadme, t.description)}
+	for name, content := range t.files {
+		files[name] = content
+	}
+
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(targetDir, name), []byte(strings.TrimLeft(content, "\n")), 0644)
+		if err != nil {
+			return err
+		}
+	}
+
+	state, err := flowkit.Init(&afero.Afero{Fs: afero.NewOsFs()}, crypto.ECDSA_P256, crypto.SHA3_256)
+	if err != nil {
+		return err
+	}
+
+	if len(t.contracts) > 0 {
+		deployment := config.Deployment{
+			Network: config.EmulatorNetwork.Name,
+			Account: config.DefaultEmulator.ServiceAccount,
+		}
+		for _, name := range t.contracts {
+			state.Contracts().AddOrUpdate(config.Contract{
+				Name:     name,
+				Location: filepath.ToSlash(filepath.Join(cadenceDir, contractDir, fmt.Sprintf("%s%s", name, cadenceExt))),
+			})
+			deployment.AddContract(config.ContractDeployment{Name: name})
+		}
+		state.Deployments().AddOrUpdate(deployment)
+	}
+
+	return state.Save(filepath.Join(targetDir, config.DefaultPath))
+}
+
+const templateReadme = `
+# Flow Project
+
+%s
+
+## Project structure
+
+- ` + "`cadence/contracts`" + ` contracts deployed by the project
+- ` + "`cadence/scripts`" + ` scripts reading the project state
+- ` + "`cadence/transactions`" + ` transactions changing the project state
+- ` + "`cadence/tests`" + ` Cadence tests of the project
+- ` + "`flow.json`" + ` project configuration
+
+## Development
+
+Start the emulator with ` + "`flow emulator`" + ` and run ` + "`flow dev`" + ` to keep the contracts deployed while you work.
+Run the tests with ` + "`flow test cadence/tests/*.cdc`" + `.
+`
+
+const exampleTokenContract = `
+pub contract ExampleToken {
+
+    pub var totalSupply: UFix64
+
+    pub let VaultStoragePath: StoragePath
+    pub let MinterStoragePath: StoragePath
+
+    pub event TokensMinted(amount: UFix64)
+
+    pub resource Vault {
+        pub var balance: UFix64
+
+        init(balance: UFix64) {
+            self.balance = balance
+        }
+
+        pub fun withdraw(amount: UFix64): @Vault {
+            pre {
+                amount <= self.balance: "Amount withdrawn must be less than or equal to the balance"
+            }
+            self.balance = self.balance - amount
+            return <- create Vault(balance: amount)
+        }
+
+        pub fun deposit(from: @Vault) {
+            self.balance = self.balance + from.balance
+            destroy from
+        }
+    }
+
+    pub resource Minter {
+        pub fun mint(amount: UFix64): @Vault {
+            ExampleToken.totalSupply = ExampleToken.totalSupply + amount
+            emit TokensMinted(amount: amount)
+            return <- create Vault(balance: amount)
+        }
+    }
+
+    pub fun createEmptyVault(): @Vault {
+        return <- create Vault(balance: 0.0)
+    }
+
+    init() {
+        self.totalSupply = 0.0
+        self.VaultStoragePath = /storage/exampleTokenVault
+        self.MinterStoragePath = /storage/exampleTokenMinter
+
+        self.account.save(<- create Minter(), to: self.MinterStoragePath)
+    }
+}
+`
+
+const exampleTokenSetup = `
+import "ExampleToken"
+
+transaction {
+    prepare(signer: AuthAccount) {
+        if signer.borrow<&ExampleToken.Vault>(from: ExampleToken.VaultStoragePath) == nil {
+            signer.save(<- ExampleToken.createEmptyVault(), to: ExampleToken.VaultStoragePath)
+        }
+    }
+}
+`
+
+const exampleTokenMint = `
+import "ExampleToken"
+
+transaction(amount: UFix64) {
+    prepare(signer: AuthAccount) {
+        let minter = signer.borrow<&ExampleToken.Minter>(from: ExampleToken.MinterStoragePath)
+            ?? panic("Signer is not the token minter")
+        let vault = signer.borrow<&ExampleToken.Vault>(from: ExampleToken.VaultStoragePath)
+            ?? panic("Signer has no token vault, run the setup_account transaction first")
+
+        vault.deposit(from: <- minter.mint(amount: amount))
+    }
+}
+`
+
+const exampleTokenBalance = `
+import "ExampleToken"
+
+pub fun main(address: Address): UFix64 {
+    let vault = getAuthAccount(address).borrow<&ExampleToken.Vault>(from: ExampleToken.VaultStoragePath)
+        ?? panic("Account has no token vault")
+
+    return vault.balance
+}
+`
+
+const exampleTokenTest = `
+import Test
+
+pub let blockchain = Test.newEmulatorBlockchain()
+pub let account = blockchain.createAccount()
+
+pub fun setup() {
+    let err = blockchain.deployContract(
+        name: "ExampleToken",
+        code: Test.readFile("../contracts/ExampleToken.cdc"),
+        account: account,
+        arguments: []
+    )
+    Test.expect(err, Test.beNil())
+
+    blockchain.useConfiguration(Test.Configuration({
+        "ExampleToken": account.address
+    }))
+}
+
+pub fun testMintTokens() {
+    let setup = Test.Transaction(
+        code: Test.readFile("../transactions/setup_account.cdc"),
+        authorizers: [account.address],
+        signers: [account],
+        arguments: []
+    )
+    Test.expect(blockchain.executeTransaction(setup), Test.beSucceeded())
+
+    let mint = Test.Transaction(
+        code: Test.readFile("../transactions/mint_tokens.cdc"),
+        authorizers: [account.address],
+        signers: [account],
+        arguments: [42.0]
+    )
+    Test.expect(blockchain.executeTransaction(mint), Test.beSucceeded())
+
+    let result = blockchain.executeScript(Test.readFile("../scripts/get_balance.cdc"), [account.address])
+    Test.expect(result, Test.beSucceeded())
+    Test.assertEqual(42.0, result.returnValue! as! UFix64)
+}
+`
+
+const exampleNFTContract = `
+pub contract ExampleNFT {
+
+    pub var totalSupply: UInt64
+
+    pub let CollectionStoragePath: StoragePath
+    pub let MinterStoragePath: StoragePath
+
+    pub event Minted(id: UInt64)
+
+    pub resource NFT {
+        pub let id: UInt64
+
+        init(id: UInt64) {
+            self.id = id
+        }
+    }
+
+    pub resource Collection {
+        pub var ownedNFTs: @{UInt64: NFT}
+
+        init() {
+            self.ownedNFTs <- {}
+        }
+
+        pub fun deposit(token: @NFT) {
+            let old <- self.ownedNFTs[token.id] <- token
+            destroy old
+        }
+
+        pub fun withdraw(id: UInt64): @NFT {
+            return <- (self.ownedNFTs.remove(key: id) ?? panic("NFT not found in the collection"))
+        }
+
+        pub fun getIDs(): [UInt64] {
+            return self.ownedNFTs.keys
+        }
+
+        destroy() {
+            destroy self.ownedNFTs
+        }
+    }
+
+    pub resource Minter {
+        pub fun mint(): @NFT {
+            ExampleNFT.totalSupply = ExampleNFT.totalSupply + 1
+            emit Minted(id: ExampleNFT.totalSupply)
+            return <- create NFT(id: ExampleNFT.totalSupply)
+        }
+    }
+
+    pub fun createEmptyCollection(): @Collection {
+        return <- create Collection()
+    }
+
+    init() {
+        self.totalSupply = 0
+        self.CollectionStoragePath = /storage/exampleNFTCollection
+        self.MinterStoragePath = /storage/exampleNFTMinter
+
+        self.account.save(<- create Minter(), to: self.MinterStoragePath)
+    }
+}
+`
+
+const exampleNFTSetup = `
+import "ExampleNFT"
+
+transaction {
+    prepare(signer: AuthAccount) {
+        if signer.borrow<&ExampleNFT.Collection>(from: ExampleNFT.CollectionStoragePath) == nil {
+            signer.save(<- ExampleNFT.createEmptyCollection(), to: ExampleNFT.CollectionStoragePath)
+        }
+    }
+}
+`
+
+const exampleNFTMint = `
+import "ExampleNFT"
+
+transaction {
+    prepare(signer: AuthAccount) {
+        let minter = signer.borrow<&ExampleNFT.Minter>(from: ExampleNFT.MinterStoragePath)
+            ?? panic("Signer is not the NFT minter")
+        let collection = signer.borrow<&ExampleNFT.Collection>(from: ExampleNFT.CollectionStoragePath)
+            ?? panic("Signer has no NFT collection, run the setup_collection transaction first")
+
+        collection.deposit(token: <- minter.mint())
+    }
+}
+`
+
+const exampleNFTIDs = `
+import "ExampleNFT"
+
+pub fun main(address: Address): [UInt64] {
+    let collection = getAuthAccount(address).borrow<&ExampleNFT.Collection>(from: ExampleNFT.CollectionStoragePath)
+        ?? panic("Account has no NFT collection")
+
+    return collection.getIDs()
+}
+`
+
+const exampleNFTTest = `
+import Test
+
+pub let blockchain = Test.newEmulatorBlockchain()
+pub let account = blockchain.createAccount()
+
+pub fun setup() {
+    let err = blockchain.deployContract(
+        name: "ExampleNFT",
+        code: Test.readFile("../contracts/ExampleNFT.cdc"),
+        account: account,
+        arguments: []
+    )
+    Test.expect(err, Test.beNil())
+
+    blockchain.useConfiguration(Test.Configuration({
+        "ExampleNFT": account.address
+    }))
+}
+
+pub fun testMintNFT() {
+    let setup = Test.Transaction(
+        code: Test.readFile("../transactions/setup_collection.cdc"),
+        authorizers: [account.address],
+        signers: [account],
+        arguments: []
+    )
+    Test.expect(blockchain.executeTransaction(setup), Test.beSucceeded())
+
+    let mint = Test.Transaction(
+        code: Test.readFile("../transactions/mint_nft.cdc"),
+        authorizers: [account.address],
+        signers: [account],
+        arguments: []
+    )
+    Test.expect(blockchain.executeTransaction(mint), Test.beSucceeded())
+
+    let result = blockchain.executeScript(Test.readFile("../scripts/get_ids.cdc"), [account.address])
+    Test.expect(result, Test.beSucceeded())
+    Test.assertEqual([1 as UInt64], result.returnValue! as! [UInt64])
+}
+`
@@ -0,0 +1,79 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package super
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_GenerateTemplate(t *testing.T) {
+	for _, tmpl := range templates {
+		t.Run(tmpl.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, generateTemplate(dir, tmpl))
+
+			for _, d := range projectDirs {
+				assert.DirExists(t, filepath.Join(dir, d))
+			}
+			for name := range tmpl.files {
+				assert.FileExists(t, filepath.Join(dir, name))
+			}
+
+			state, err := flowkit.Load(
+				[]string{filepath.Join(dir, config.DefaultPath)},
+				&afero.Afero{Fs: afero.NewOsFs()},
+			)
+			require.NoError(t, err)
+			require.Len(t, *state.Contracts(), len(tmpl.contracts))
+
+			for _, c := range *state.Contracts() {
+				assert.FileExists(t, filepath.Join(dir, c.Location))
+			}
+		})
+	}
+}
+
+func Test_ScaffoldByID(t *testing.T) {
+	registry := filepath.Join(t.TempDir(), "scaffolds.json")
+	err := os.WriteFile(registry, []byte(`[
+		{"id": "web", "name": "Web", "repo": "https://example.com/web.git", "description": "Web dapp", "commit": "abc"},
+		{"id": "invalid", "name": "Invalid"}
+	]`), 0644)
+	require.NoError(t, err)
+
+	scaffolds, err := getScaffolds(registry)
+	require.NoError(t, err)
+	require.Len(t, scaffolds, 1)
+
+	s, err := scaffoldByID(scaffolds, "web")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/web.git", s.Repo)
+
+	_, err = scaffoldByID(scaffolds, "missing")
+	assert.EqualError(t, err, "template missing not found, available templates: empty, fungible-token, nft, web")
+}
@@ -0,0 +1,310 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package super
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/radovskyb/watcher"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+const (
+	statusPending   = "pending"
+	statusSynced    = "synced"
+	statusUnchanged = "unchanged"
+	statusFailed    = "failed"
+	statusMissing   = "missing"
+)
+
+type contractStatus struct {
+	name     string
+	location string
+	path     string // absolute path of the contract file
+	account  string
+	address  flow.Address
+	status   string
+	updated  time.Time
+}
+
+// configProject watches the contracts defined in the configuration, as opposed to the project
+// which builds the configuration from the contracts folder.
+type configProject struct {
+	*project
+	watcher   *watcher.Watcher
+	contracts []*contractStatus
+	err       error // error of the last deployment
+}
+
+func newConfigProject(
+	serviceAccount accounts.Account,
+	flow flowkit.Services,
+	state *flowkit.State,
+) (*configProject, error) {
+	contracts := make([]*contractStatus, 0)
+	for _, c := range *state.Contracts() {
+		if c.Location == "" || c.Aliases.ByNetwork(emulator) != nil {
+			continue // aliased contracts are already deployed
+		}
+
+		path, err := filepath.Abs(c.Location)
+		if err != nil {
+			return nil, err
+		}
+
+		contracts = append(contracts, &contractStatus{
+			name:     c.Name,
+			location: c.Location,
+			path:     path,
+			status:   statusPending,
+		})
+	}
+
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("no contracts to watch found in the configuration")
+	}
+
+	return &configProject{
+		project: &project{
+			service:        &serviceAccount,
+			flow:           flow,
+			state:          state,
+			pathNameLookup: make(map[string]string),
+		},
+		watcher:   watcher.New(),
+		contracts: contracts,
+	}, nil
+}
+
+// startup deploys all the contracts, contracts without an emulator deployment are deployed
+// to their own account which is created as needed.
+func (p *configProject) startup() error {
+	for _, c := range p.contracts {
+		p.pathNameLookup[c.location] = c.name
+
+		account := p.deploymentAccount(c.name)
+		if account == "" {
+			account = strings.ToLower(c.name)
+			if err := p.addContractAccount(account, c.name); err != nil {
+				return err
+			}
+		}
+
+		acc, err := p.state.Accounts().ByName(account)
+		if err != nil {
+			return err
+		}
+		c.account = acc.Name
+		c.address = acc.Address
+	}
+
+	p.sync(nil)
+
+	return p.state.SaveDefault()
+}
+
+// deploymentAccount returns the name of the account the contract is deployed to on the emulator, or empty if none.
+func (p *configProject) deploymentAccount(contract string) string {
+	for _, d := range p.state.Deployments().ByNetwork(emulator) {
+		for _, c := range d.Contracts {
+			if c.Name == contract {
+				return d.Account
+			}
+		}
+	}
+
+	return ""
+}
+
+// addContractAccount adds the contract deployment to the account, creating the account if it doesn't exist.
+func (p *configProject) addContractAccount(account string, contract string) error {
+	existing, err := p.state.Accounts().ByName(account)
+	if err != nil {
+		if err := p.addAccount(account); err != nil {
+			return err
+		}
+	} else {
+		chain, err := util.GetAddressNetwork(existing.Address)
+		if err != nil || chain != flow.Emulator {
+			return fmt.Errorf("account %s already exists and is not an emulator account, add a deployment for contract %s", account, contract)
+		}
+		if p.state.Deployments().ByAccountAndNetwork(account, emulator) == nil {
+			p.state.Deployments().AddOrUpdate(config.Deployment{Network: emulator, Account: account})
+		}
+	}
+
+	p.state.Deployments().
+		ByAccountAndNetwork(account, emulator).
+		AddContract(config.ContractDeployment{Name: contract})
+
+	return nil
+}
+
+// sync deploys the project and updates the contract statuses, the changed contract is removed
+// before deploying so updates are not restricted by contract updatability during development.
+func (p *configProject) sync(changed *contractStatus) {
+	if changed != nil {
+		if acc, err := p.state.Accounts().ByName(changed.account); err == nil {
+			_, _ = p.flow.RemoveContract(context.Background(), acc, changed.name)
+		}
+	}
+
+	deployed, err := p.flow.DeployProject(context.Background(), flowkit.UpdateExistingContract(true))
+	p.err = err
+	now := time.Now()
+
+	failed := make(map[string]bool)
+	var deployErr *flowkit.ProjectDeploymentError
+	if errors.As(err, &deployErr) {
+		for name := range deployErr.Contracts() {
+			failed[name] = true
+		}
+	}
+	// errors not related to a specific contract, e.g. unresolved imports, fail the changed contracts
+	projectFailed := err != nil && len(failed) == 0
+
+	transactions := make(map[string]flow.Identifier)
+	for _, d := range deployed {
+		transactions[d.Name] = d.TransactionID
+	}
+
+	for _, c := range p.contracts {
+		if c.status == statusMissing {
+			continue
+		}
+
+		txID, ok := transactions[c.name]
+		switch {
+		case failed[c.name], projectFailed && (changed == nil || changed == c):
+			c.status = statusFailed
+			c.updated = now
+		case ok && txID != flow.EmptyID:
+			c.status = statusSynced
+			c.updated = now
+		case ok && c.status == statusPending:
+			c.status = statusUnchanged
+		}
+	}
+}
+
+// watch the contract files and redeploy the project on any change.
+func (p *configProject) watch() error {
+	for _, c := range p.contracts {
+		if err := p.watcher.Add(c.path); err != nil {
+			return fmt.Errorf("error watching contract %s: %w", c.name, err)
+		}
+	}
+
+	go func() {
+		err := p.watcher.Start(500 * time.Millisecond)
+		if err != nil {
+			panic(err)
+		}
+	}()
+
+	for {
+		select {
+		case event := <-p.watcher.Event:
+			contract := p.contractByPath(event.Path)
+			if contract == nil {
+				continue
+			}
+
+			switch event.Op {
+			case watcher.Write:
+				contract.status = statusPending
+				p.print()
+				p.sync(contract)
+			case watcher.Remove, watcher.Rename:
+				contract.status = statusMissing
+				contract.updated = time.Now()
+			}
+			p.print()
+		case err := <-p.watcher.Error:
+			return err
+		case <-p.watcher.Closed:
+			return nil
+		}
+	}
+}
+
+func (p *configProject) contractByPath(path string) *contractStatus {
+	for _, c := range p.contracts {
+		if c.path == path {
+			return c
+		}
+	}
+	return nil
+}
+
+// print the status of all the watched contracts.
+func (p *configProject) print() {
+	clearScreen()
+	fmt.Println(output.Italic("The development environment will watch the contracts defined in your configuration and automatically keep them updated on the emulator.\n"))
+
+	if p.err != nil {
+		fmt.Println(errorBanner())
+	} else {
+		fmt.Println(okBanner())
+	}
+
+	fmt.Println(statusTable(p.contracts))
+
+	if p.err != nil {
+		fmt.Println(failureDeployment(p.err, p.pathNameLookup))
+	}
+}
+
+func statusTable(contracts []*contractStatus) string {
+	var b bytes.Buffer
+	w := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "Contract", "Account", "Address", "Updated", "Status")
+	for _, c := range contracts {
+		updated := "-"
+		if !c.updated.IsZero() {
+			updated = c.updated.Format("15:04:05")
+		}
+
+		status := c.status
+		switch c.status {
+		case statusSynced:
+			status = output.Green(status)
+		case statusFailed, statusMissing:
+			status = output.Red(status)
+		}
+
+		_, _ = fmt.Fprintf(w, "%s\t%s\t0x%s\t%s\t%s\n", c.name, c.account, c.address, updated, status)
+	}
+
+	_ = w.Flush()
+	return b.String()
+}
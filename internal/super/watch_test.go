@@ -0,0 +1,82 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package super
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	flowkitProject "github.com/onflow/flow-cli/flowkit/project"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_ConfigProject(t *testing.T) {
+	srv, state, _ := util.TestMocks(t)
+	state.Contracts().AddOrUpdate(config.Contract{Name: tests.ContractA.Name, Location: tests.ContractA.Filename})
+	state.Contracts().AddOrUpdate(config.Contract{Name: tests.ContractB.Name, Location: tests.ContractB.Filename})
+	service, err := state.EmulatorServiceAccount()
+	require.NoError(t, err)
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   service.Name,
+		Contracts: []config.ContractDeployment{{Name: tests.ContractA.Name}},
+	})
+
+	created := tests.NewAccountWithAddress("0x179b6b1cb6755e31")
+	srv.CreateAccount.Return(created, flow.EmptyID, nil)
+	srv.DeployProject.Run(func(args mock.Arguments) {
+		contractA := flowkitProject.NewContract(tests.ContractA.Name, tests.ContractA.Filename, tests.ContractA.Source, service.Address, service.Name, nil)
+		contractB := flowkitProject.NewContract(tests.ContractB.Name, tests.ContractB.Filename, tests.ContractB.Source, created.Address, "contractb", nil)
+		contractB.TransactionID = flow.HexToID("01")
+		srv.DeployProject.Return([]*flowkitProject.Contract{contractA, contractB}, nil)
+	})
+
+	p, err := newConfigProject(*service, srv.Mock, state)
+	require.NoError(t, err)
+	require.NoError(t, p.startup())
+
+	// contract without deployment gets its own account
+	deployment := state.Deployments().ByAccountAndNetwork("contractb", config.EmulatorNetwork.Name)
+	require.NotNil(t, deployment)
+	assert.Equal(t, tests.ContractB.Name, deployment.Contracts[0].Name)
+	srv.Mock.AssertNumberOfCalls(t, "CreateAccount", 1)
+
+	require.Len(t, p.contracts, 2)
+	assert.Equal(t, service.Name, p.contracts[0].account)
+	assert.Equal(t, statusUnchanged, p.contracts[0].status)
+	assert.Equal(t, created.Address, p.contracts[1].address)
+	assert.Equal(t, statusSynced, p.contracts[1].status)
+
+	deployErr := &flowkit.ProjectDeploymentError{}
+	srv.DeployProject.Run(func(args mock.Arguments) {
+		srv.DeployProject.Return(nil, deployErr)
+	})
+	p.sync(p.contracts[0])
+	srv.Mock.AssertCalled(t, "RemoveContract", mock.Anything, service, tests.ContractA.Name)
+	assert.Equal(t, statusFailed, p.contracts[0].status)
+	assert.Equal(t, statusSynced, p.contracts[1].status)
+	assert.Contains(t, statusTable(p.contracts), "contractb")
+}
@@ -0,0 +1,538 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/onflow/cadence"
+	jsoncdc "github.com/onflow/cadence/encoding/json"
+	"github.com/onflow/cadence/runtime/parser"
+	"github.com/onflow/cadence/runtime/sema"
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/accounts"
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/gateway"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/flowkit/transactions"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+type flagsFuzz struct {
+	Runs     int      `default:"100" flag:"runs" info:"Number of times the transaction is sent with random arguments"`
+	Seed     int64    `default:"0" flag:"seed" info:"Seed of the random arguments, a random seed is used if not set"`
+	Signers  []string `default:"" flag:"signer" info:"Account names from configuration used as authorizers, the first account is the proposer and the last the payer, defaults to the emulator service account"`
+	Expect   []string `default:"" flag:"expect" info:"Expected error messages, failures containing one of the messages are not reported"`
+	GasLimit uint64   `default:"9999" flag:"gas-limit" info:"Transaction gas limit"`
+}
+
+var fuzzFlags = flagsFuzz{}
+
+var FuzzCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "fuzz <transaction filename>",
+		Short: "Send a transaction with random arguments to find inputs causing failures",
+		Long: `Send a transaction with random arguments conforming to the parameter types on an in-memory emulator,
+the signer accounts are created and the emulator deployments are deployed first. Inputs causing the transaction
+to fail with an error not matching the expected errors are reported.`,
+		Example: "flow test fuzz transfer.cdc\nflow test fuzz transfer.cdc --runs 1000 --seed 1234 --expect \"amount must be positive\"",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &fuzzFlags,
+	RunS:  fuzz,
+}
+
+func fuzz(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.Services,
+	state *flowkit.State,
+) (command.Result, error) {
+	if fuzzFlags.Runs < 1 {
+		return nil, fmt.Errorf("the '--runs' flag must be at least 1")
+	}
+
+	filename := args[0]
+	code, err := state.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error loading transaction file: %w", err)
+	}
+
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("error parsing transaction file: %w", err)
+	}
+	if len(program.TransactionDeclarations()) != 1 {
+		return nil, fmt.Errorf("%s is not a transaction", filename)
+	}
+
+	parameters, err := arguments.ParseParameters(code, filename)
+	if err != nil {
+		return nil, err
+	}
+	if len(parameters) == 0 {
+		return nil, fmt.Errorf("the transaction has no parameters to fuzz")
+	}
+
+	seed := fuzzFlags.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	f := &fuzzer{random: rand.New(rand.NewSource(seed))}
+
+	// unsupported parameter types are reported before the emulator is started
+	if _, err := f.arguments(parameters); err != nil {
+		return nil, err
+	}
+
+	logger.StartProgress("Starting the emulator...")
+	defer logger.StopProgress()
+
+	ctx := context.Background()
+	flow, roles, err := fuzzEmulator(ctx, state, fuzzFlags.Signers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prepare the emulator: %w", err)
+	}
+
+	result := &fuzzResult{Filename: filename, Runs: fuzzFlags.Runs, Seed: seed}
+	reported := make(map[string]bool)
+	for run := 1; run <= fuzzFlags.Runs; run++ {
+		logger.StartProgress(fmt.Sprintf("Fuzzing %s, run %d of %d...", filename, run, fuzzFlags.Runs))
+
+		values, err := f.arguments(parameters)
+		if err != nil {
+			return nil, err
+		}
+
+		tx, txResult, err := flow.SendTransaction(
+			ctx,
+			roles,
+			flowkit.Script{Code: code, Args: values, Location: filename},
+			fuzzFlags.GasLimit,
+		)
+		if err == nil {
+			err = txResult.Error
+		}
+		if err == nil || isExpected(err, fuzzFlags.Expect) {
+			continue
+		}
+
+		// errors refer to the code by the transaction ID, which differs for every run
+		message := strings.TrimSpace(err.Error())
+		if tx != nil {
+			message = strings.ReplaceAll(message, tx.ID().String(), filename)
+		}
+
+		// failures are reported once with the first input causing them
+		if reported[message] {
+			continue
+		}
+		reported[message] = true
+		result.Failures = append(result.Failures, fuzzFailure{Run: run, Args: values, Error: message})
+	}
+
+	return result, nil
+}
+
+func isExpected(err error, expected []string) bool {
+	for _, message := range expected {
+		if message != "" && strings.Contains(err.Error(), message) {
+			return true
+		}
+	}
+	return false
+}
+
+// fuzzEmulator starts an in-memory emulator with the signer accounts and the emulator deployments of the project.
+//
+// Accounts are created with their configured keys, the addresses are only changed in the loaded state.
+func fuzzEmulator(
+	ctx context.Context,
+	state *flowkit.State,
+	signers []string,
+) (flowkit.Services, transactions.AccountRoles, error) {
+	service, err := state.EmulatorServiceAccount()
+	if err != nil {
+		return nil, transactions.AccountRoles{}, err
+	}
+	privateKey, err := service.Key.PrivateKey()
+	if err != nil {
+		return nil, transactions.AccountRoles{}, fmt.Errorf("only hexadecimal keys can be used as the emulator service account key: %w", err)
+	}
+
+	gw := gateway.NewEmulatorGateway(&gateway.EmulatorKey{
+		PublicKey: (*privateKey).PublicKey(),
+		SigAlgo:   service.Key.SigAlgo(),
+		HashAlgo:  service.Key.HashAlgo(),
+	})
+	flow := flowkit.NewFlowkit(state, config.EmulatorNetwork, gw, output.NewStdoutLogger(output.NoneLog))
+
+	names := make([]string, 0)
+	for _, name := range signers {
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		names = append(names, service.Name)
+	}
+
+	deployments := state.Deployments().ByNetwork(config.EmulatorNetwork.Name)
+	created := map[string]bool{service.Name: true}
+	for _, name := range append(append([]string{}, names...), deploymentAccounts(state, deployments)...) {
+		if created[name] {
+			continue
+		}
+		if err := createFuzzAccount(ctx, flow, state, service, name); err != nil {
+			return nil, transactions.AccountRoles{}, fmt.Errorf("failed to create account %s: %w", name, err)
+		}
+		created[name] = true
+	}
+
+	if len(deployments) > 0 {
+		if _, err := flow.DeployProject(ctx, flowkit.UpdateExistingContract(true)); err != nil {
+			return nil, transactions.AccountRoles{}, err
+		}
+	}
+
+	authorizers := make([]accounts.Account, len(names))
+	for i, name := range names {
+		account, err := state.Accounts().ByName(name)
+		if err != nil {
+			return nil, transactions.AccountRoles{}, err
+		}
+		authorizers[i] = *account
+	}
+
+	// the payer signs the envelope, so it must be the last signer
+	return flow, transactions.AccountRoles{
+		Proposer:    authorizers[0],
+		Authorizers: authorizers,
+		Payer:       authorizers[len(authorizers)-1],
+	}, nil
+}
+
+func deploymentAccounts(state *flowkit.State, deployments config.Deployments) []string {
+	names := make([]string, len(deployments))
+	for i, deployment := range deployments {
+		names[i] = state.DeploymentAccountName(deployment.Account)
+	}
+	return names
+}
+
+func createFuzzAccount(
+	ctx context.Context,
+	flow flowkit.Services,
+	state *flowkit.State,
+	service *accounts.Account,
+	name string,
+) error {
+	account, err := state.Accounts().ByName(name)
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := account.Key.PrivateKey()
+	if err != nil {
+		return fmt.Errorf("only accounts with private keys can be used: %w", err)
+	}
+
+	created, _, err := flow.CreateAccount(ctx, service, []accounts.PublicKey{{
+		Public:   (*privateKey).PublicKey(),
+		Weight:   flowsdk.AccountKeyWeightThreshold,
+		SigAlgo:  account.Key.SigAlgo(),
+		HashAlgo: account.Key.HashAlgo(),
+	}})
+	if err != nil {
+		return err
+	}
+
+	account.Address = created.Address
+	state.Accounts().AddOrUpdate(account)
+	return nil
+}
+
+// maxFuzzDepth limits the nesting of generated arrays, dictionaries and optionals.
+const maxFuzzDepth = 3
+
+// integerBits are the sizes of the integer types, the unbounded Int and UInt are generated in the 256-bit range.
+var integerBits = map[string]uint{
+	"Int": 256, "Int8": 8, "Int16": 16, "Int32": 32, "Int64": 64, "Int128": 128, "Int256": 256,
+	"UInt": 256, "UInt8": 8, "UInt16": 16, "UInt32": 32, "UInt64": 64, "UInt128": 128, "UInt256": 256,
+	"Word8": 8, "Word16": 16, "Word32": 32, "Word64": 64,
+	"Fix64": 64, "UFix64": 64,
+}
+
+// fuzzRunes are used to generate strings, including quotes, escapes, and multi-byte characters.
+var fuzzRunes = []rune("abcXYZ019 _-.\"'\\\n\t{}<>/éß漢🌊")
+
+// fuzzer generates random argument values of parameter types.
+//
+// Values are biased towards the edges of the types, e.g. the minimum and maximum integers or empty strings.
+type fuzzer struct {
+	random *rand.Rand
+}
+
+func (f *fuzzer) arguments(parameters []arguments.Parameter) ([]cadence.Value, error) {
+	values := make([]cadence.Value, len(parameters))
+	for i, parameter := range parameters {
+		value, err := f.value(parameter.Type, 0)
+		if err != nil {
+			return nil, fmt.Errorf("parameter `%s` of type `%s` can not be fuzzed: %w", parameter.Name, parameter.Type.QualifiedString(), err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func (f *fuzzer) value(semaType sema.Type, depth int) (cadence.Value, error) {
+	switch t := semaType.(type) {
+	case *sema.OptionalType:
+		if depth >= maxFuzzDepth || f.random.Intn(4) == 0 {
+			return cadence.NewOptional(nil), nil
+		}
+		value, err := f.value(t.Type, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		return cadence.NewOptional(value), nil
+
+	case *sema.VariableSizedType:
+		return f.array(t.Type, f.length(depth), depth)
+
+	case *sema.ConstantSizedType:
+		return f.array(t.Type, int(t.Size), depth)
+
+	case *sema.DictionaryType:
+		pairs := make([]cadence.KeyValuePair, 0)
+		keys := make(map[string]bool)
+		for i := f.length(depth); i > 0; i-- {
+			key, err := f.value(t.KeyType, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			value, err := f.value(t.ValueType, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if keys[key.String()] {
+				continue
+			}
+			keys[key.String()] = true
+			pairs = append(pairs, cadence.KeyValuePair{Key: key, Value: value})
+		}
+		return cadence.NewDictionary(pairs), nil
+
+	case *sema.AddressType:
+		var address [flowsdk.AddressLength]byte
+		if f.random.Intn(3) != 0 {
+			f.random.Read(address[:])
+		}
+		return cadence.NewAddress(address), nil
+
+	case *sema.NumericType, *sema.FixedPointNumericType:
+		return f.number(string(t.ID()))
+	}
+
+	switch semaType {
+	case sema.BoolType:
+		return cadence.NewBool(f.random.Intn(2) == 0), nil
+	case sema.StringType:
+		return cadence.NewString(f.string())
+	case sema.CharacterType:
+		return cadence.NewCharacter(string(fuzzRunes[f.random.Intn(len(fuzzRunes))]))
+	}
+
+	return nil, fmt.Errorf("unsupported type")
+}
+
+func (f *fuzzer) array(elementType sema.Type, length int, depth int) (cadence.Value, error) {
+	values := make([]cadence.Value, length)
+	for i := range values {
+		value, err := f.value(elementType, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return cadence.NewArray(values), nil
+}
+
+func (f *fuzzer) length(depth int) int {
+	if depth >= maxFuzzDepth || f.random.Intn(4) == 0 {
+		return 0
+	}
+	return 1 + f.random.Intn(4)
+}
+
+func (f *fuzzer) string() string {
+	length := f.random.Intn(16)
+	switch f.random.Intn(6) {
+	case 0:
+		length = 0
+	case 1:
+		length = 256
+	}
+
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		b.WriteRune(fuzzRunes[f.random.Intn(len(fuzzRunes))])
+	}
+	return b.String()
+}
+
+// number generates an integer or fixed-point number in the range of the type, fixed-point numbers
+// are generated as the integer of their smallest unit.
+func (f *fuzzer) number(typeID string) (cadence.Value, error) {
+	bits, ok := integerBits[typeID]
+	if !ok {
+		return nil, fmt.Errorf("unsupported type")
+	}
+
+	min, max := big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), bits)
+	if strings.HasPrefix(typeID, "Int") || typeID == "Fix64" {
+		max.Rsh(max, 1)
+		min.Neg(max)
+	}
+	max.Sub(max, big.NewInt(1))
+
+	var number *big.Int
+	switch f.random.Intn(3) {
+	case 0:
+		edges := []*big.Int{min, max, big.NewInt(0), big.NewInt(1)}
+		if min.Sign() < 0 {
+			edges = append(edges, big.NewInt(-1))
+		}
+		number = edges[f.random.Intn(len(edges))]
+	case 1:
+		// small numbers are more likely to pass validation and reach deeper code
+		number = big.NewInt(f.random.Int63n(1000))
+		if number.Cmp(max) > 0 {
+			number.Mod(number, new(big.Int).Add(max, big.NewInt(1)))
+		}
+		if min.Sign() < 0 && f.random.Intn(2) == 0 {
+			number.Neg(number)
+		}
+	default:
+		span := new(big.Int).Sub(max, min)
+		number = new(big.Int).Rand(f.random, span.Add(span, big.NewInt(1)))
+		number.Add(number, min)
+	}
+
+	value := number.String()
+	if typeID == "Fix64" || typeID == "UFix64" {
+		value = formatFixedPoint(number)
+	}
+	return arguments.ParseLiteral(fmt.Sprintf("%s as %s", value, typeID))
+}
+
+// formatFixedPoint formats the number of the smallest fixed-point units as a decimal with 8 fractional digits.
+func formatFixedPoint(number *big.Int) string {
+	sign := ""
+	if number.Sign() < 0 {
+		sign = "-"
+	}
+	integer, fraction := new(big.Int).QuoRem(new(big.Int).Abs(number), big.NewInt(100_000_000), new(big.Int))
+	return fmt.Sprintf("%s%s.%08d", sign, integer, fraction.Int64())
+}
+
+type fuzzFailure struct {
+	Run   int
+	Args  []cadence.Value
+	Error string
+}
+
+// argsJSON returns the arguments in the JSON-Cadence format used by the args-json flag of transactions send.
+func (f fuzzFailure) argsJSON() string {
+	encoded := make([]string, len(f.Args))
+	for i, arg := range f.Args {
+		b, err := jsoncdc.Encode(arg)
+		if err != nil {
+			encoded[i] = fmt.Sprintf("%q", arg.String())
+			continue
+		}
+		encoded[i] = strings.TrimSpace(string(b))
+	}
+	return fmt.Sprintf("[%s]", strings.Join(encoded, ","))
+}
+
+type fuzzResult struct {
+	Filename string
+	Runs     int
+	Seed     int64
+	Failures []fuzzFailure
+}
+
+func (r *fuzzResult) JSON() any {
+	failures := make([]map[string]any, len(r.Failures))
+	for i, failure := range r.Failures {
+		failures[i] = map[string]any{
+			"run":   failure.Run,
+			"args":  failure.argsJSON(),
+			"error": failure.Error,
+		}
+	}
+
+	return map[string]any{
+		"file":     r.Filename,
+		"runs":     r.Runs,
+		"seed":     r.Seed,
+		"failures": failures,
+	}
+}
+
+func (r *fuzzResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "File\t%s\n", r.Filename)
+	_, _ = fmt.Fprintf(writer, "Runs\t%d\n", r.Runs)
+	_, _ = fmt.Fprintf(writer, "Seed\t%d\n", r.Seed)
+	_, _ = fmt.Fprintf(writer, "Failures\t%d\n", len(r.Failures))
+	_ = writer.Flush()
+
+	for _, failure := range r.Failures {
+		_, _ = fmt.Fprintf(&b, "\n%s Run %d failed with arguments:\n%s\n%s\n", output.ErrorEmoji(), failure.Run, failure.argsJSON(), failure.Error)
+	}
+
+	return b.String()
+}
+
+func (r *fuzzResult) Oneliner() string {
+	return fmt.Sprintf("runs: %d, seed: %d, failures: %d", r.Runs, r.Seed, len(r.Failures))
+}
+
+func (r *fuzzResult) ExitCode() int {
+	if len(r.Failures) > 0 {
+		return command.ExitError
+	}
+	return command.ExitSuccess
+}
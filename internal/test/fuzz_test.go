@@ -0,0 +1,121 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/sema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/arguments"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func TestFuzz(t *testing.T) {
+	t.Run("Values", func(t *testing.T) {
+		parameters, err := arguments.ParseParameters([]byte(`
+			transaction(a: UFix64, b: Int8, c: [String], d: {Address: Bool?}, e: [Character; 2], f: UInt256, g: Fix64) {}
+		`), "tx.cdc")
+		require.NoError(t, err)
+
+		f := &fuzzer{random: rand.New(rand.NewSource(1))}
+		for i := 0; i < 200; i++ {
+			values, err := f.arguments(parameters)
+			require.NoError(t, err)
+			require.Len(t, values, len(parameters))
+
+			assert.IsType(t, cadence.UFix64(0), values[0])
+			assert.IsType(t, cadence.Int8(0), values[1])
+			assert.IsType(t, cadence.Array{}, values[2])
+			assert.IsType(t, cadence.Dictionary{}, values[3])
+			assert.Len(t, values[4].(cadence.Array).Values, 2)
+			assert.IsType(t, cadence.UInt256{}, values[5])
+			assert.IsType(t, cadence.Fix64(0), values[6])
+		}
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		parameters := []arguments.Parameter{{Name: "a", Type: sema.UInt64Type}, {Name: "b", Type: sema.StringType}}
+
+		a, err := (&fuzzer{random: rand.New(rand.NewSource(42))}).arguments(parameters)
+		require.NoError(t, err)
+		b, err := (&fuzzer{random: rand.New(rand.NewSource(42))}).arguments(parameters)
+		require.NoError(t, err)
+		assert.Equal(t, a, b)
+	})
+
+	t.Run("Unsupported Type", func(t *testing.T) {
+		parameters, err := arguments.ParseParameters([]byte(`transaction(path: StoragePath) {}`), "tx.cdc")
+		require.NoError(t, err)
+
+		_, err = (&fuzzer{random: rand.New(rand.NewSource(1))}).arguments(parameters)
+		assert.EqualError(t, err, "parameter `path` of type `StoragePath` can not be fuzzed: unsupported type")
+	})
+
+	t.Run("Fixed Point", func(t *testing.T) {
+		assert.Equal(t, "0.00000001", formatFixedPoint(big.NewInt(1)))
+		assert.Equal(t, "-12.50000000", formatFixedPoint(big.NewInt(-1_250_000_000)))
+		assert.Equal(t, "184467440737.09551615", formatFixedPoint(new(big.Int).SetUint64(^uint64(0))))
+	})
+
+	t.Run("Run", func(t *testing.T) {
+		_, state, rw := util.TestMocks(t)
+		require.NoError(t, rw.WriteFile("tx.cdc", []byte(`
+			transaction(amount: UInt8) {
+				prepare(signer: AuthAccount) {
+					assert(amount < 200, message: "amount too large")
+				}
+			}
+		`), 0644))
+
+		fuzzFlags = flagsFuzz{Runs: 20, Seed: 1, GasLimit: 9999}
+		defer func() { fuzzFlags = flagsFuzz{} }()
+
+		result, err := fuzz([]string{"tx.cdc"}, command.GlobalFlags{}, util.NoLogger, nil, state)
+		require.NoError(t, err)
+
+		r := result.(*fuzzResult)
+		require.Len(t, r.Failures, 1)
+		assert.Contains(t, r.Failures[0].Error, "amount too large")
+		assert.Contains(t, r.Failures[0].Error, "tx.cdc:4:")
+		assert.Equal(t, command.ExitError, r.ExitCode())
+
+		fuzzFlags.Expect = []string{"amount too large"}
+		result, err = fuzz([]string{"tx.cdc"}, command.GlobalFlags{}, util.NoLogger, nil, state)
+		require.NoError(t, err)
+		assert.Empty(t, result.(*fuzzResult).Failures)
+	})
+
+	t.Run("Fail Script", func(t *testing.T) {
+		_, state, rw := util.TestMocks(t)
+		require.NoError(t, rw.WriteFile("script.cdc", []byte(`pub fun main(a: Int): Int { return a }`), 0644))
+
+		fuzzFlags = flagsFuzz{Runs: 1}
+		defer func() { fuzzFlags = flagsFuzz{} }()
+
+		_, err := fuzz([]string{"script.cdc"}, command.GlobalFlags{}, util.NoLogger, nil, state)
+		assert.EqualError(t, err, "script.cdc is not a transaction")
+	})
+}
@@ -0,0 +1,194 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	cdcTests "github.com/onflow/cadence-tools/test"
+	"github.com/onflow/cadence/runtime"
+	"github.com/onflow/cadence/runtime/parser"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+// Test functions are functions declared in test files with this prefix.
+const testFunctionPrefix = "test"
+
+// gasReport contains the computation used by each test of each test file.
+//
+// The computation is measured as the number of Cadence statements executed in contracts,
+// transactions and scripts during the test, including the test setup.
+type gasReport map[string]map[string]uint64
+
+// gasRegression is a test using more computation than allowed by the baseline and tolerance.
+type gasRegression struct {
+	File     string
+	Test     string
+	Baseline uint64
+	Used     uint64
+}
+
+func (r gasRegression) increase() float64 {
+	return float64(r.Used-r.Baseline) / float64(r.Baseline) * 100
+}
+
+// gasResult is the result of comparing the gas report against the baseline.
+type gasResult struct {
+	Path        string
+	Written     bool
+	Regressions []gasRegression
+}
+
+// runGasTests runs each test of the test file with a separate coverage report to measure its computation.
+func runGasTests(
+	scriptPath string,
+	code []byte,
+	state *flowkit.State,
+) (cdcTests.Results, map[string]uint64, error) {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := make(cdcTests.Results, 0)
+	usage := make(map[string]uint64)
+	for _, function := range program.FunctionDeclarations() {
+		name := function.Identifier.Identifier
+		if !strings.HasPrefix(name, testFunctionPrefix) {
+			continue
+		}
+
+		coverageReport := runtime.NewCoverageReport()
+		runner := cdcTests.NewTestRunner().
+			WithImportResolver(importResolver(scriptPath, state)).
+			WithFileResolver(fileResolver(scriptPath, state)).
+			WithCoverageReport(coverageReport)
+
+		result, err := runner.RunTest(string(code), name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		results = append(results, *result)
+		usage[name] = statementsExecuted(coverageReport)
+	}
+
+	return results, usage, nil
+}
+
+// statementsExecuted is the total of line hits in the coverage report.
+func statementsExecuted(coverageReport *runtime.CoverageReport) uint64 {
+	var total uint64
+	for _, coverage := range coverageReport.Coverage {
+		for _, hits := range coverage.LineHits {
+			total += uint64(hits)
+		}
+	}
+	return total
+}
+
+// compareGas returns the tests of the report using more computation than the baseline increased by the
+// tolerance percentage, tests missing from the baseline are not compared.
+func compareGas(baseline gasReport, report gasReport, tolerance float64) []gasRegression {
+	regressions := make([]gasRegression, 0)
+	for file, tests := range report {
+		for test, used := range tests {
+			base, ok := baseline[file][test]
+			if !ok {
+				continue
+			}
+			if float64(used) > float64(base)*(1+tolerance/100) {
+				regressions = append(regressions, gasRegression{
+					File:     file,
+					Test:     test,
+					Baseline: base,
+					Used:     used,
+				})
+			}
+		}
+	}
+
+	sort.Slice(regressions, func(i, j int) bool {
+		if regressions[i].File != regressions[j].File {
+			return regressions[i].File < regressions[j].File
+		}
+		return regressions[i].Test < regressions[j].Test
+	})
+	return regressions
+}
+
+// checkGas compares the report against the baseline saved at the path, the report is saved
+// as the baseline instead if it doesn't exist yet or update is set.
+func checkGas(path string, report gasReport, tolerance float64, update bool) (*gasResult, error) {
+	result := &gasResult{Path: path}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("error reading gas report: %w", err)
+	}
+
+	if err == nil && !update {
+		var baseline gasReport
+		if err := json.Unmarshal(data, &baseline); err != nil {
+			return nil, fmt.Errorf("error parsing gas report %s: %w", path, err)
+		}
+		result.Regressions = compareGas(baseline, report, tolerance)
+		return result, nil
+	}
+
+	data, err = json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error serializing gas report: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return nil, fmt.Errorf("error writing gas report file: %w", err)
+	}
+
+	result.Written = true
+	return result, nil
+}
+
+func (r *gasResult) String() string {
+	if r.Written {
+		return fmt.Sprintf("Gas report baseline written to %s\n", r.Path)
+	}
+	if len(r.Regressions) == 0 {
+		return fmt.Sprintf("Gas report: no regressions compared to %s\n", r.Path)
+	}
+
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Gas report: regressions compared to %s\n", r.Path))
+	for _, regression := range r.Regressions {
+		b.WriteString(fmt.Sprintf(
+			"- REGRESSION: %s %s used %d statements, baseline %d (+%.1f%%)\n",
+			regression.File,
+			regression.Test,
+			regression.Used,
+			regression.Baseline,
+			regression.increase(),
+		))
+	}
+	return b.String()
+}
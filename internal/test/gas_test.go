@@ -0,0 +1,95 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/tests"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func TestGasReport(t *testing.T) {
+	t.Run("Run", func(t *testing.T) {
+		_, state, _ := util.TestMocks(t)
+		state.Contracts().AddOrUpdate(config.Contract{
+			Name:     tests.ContractHelloString.Name,
+			Location: tests.ContractHelloString.Filename,
+		})
+
+		script := tests.TestScriptWithImport
+		results, _, gas, err := runTests(
+			map[string][]byte{script.Filename: script.Source},
+			state,
+			flagsTests{GasReport: "gas.json"},
+		)
+
+		require.NoError(t, err)
+		require.Len(t, results[script.Filename], 1)
+		assert.NoError(t, results[script.Filename][0].Error)
+
+		testName := results[script.Filename][0].TestName
+		assert.Greater(t, gas[script.Filename][testName], uint64(0))
+	})
+
+	t.Run("Compare", func(t *testing.T) {
+		baseline := gasReport{"a_test.cdc": {"testA": 100, "testB": 100}}
+		report := gasReport{
+			"a_test.cdc": {"testA": 105, "testB": 106, "testC": 1000},
+			"b_test.cdc": {"testD": 1000},
+		}
+
+		regressions := compareGas(baseline, report, 5)
+		require.Len(t, regressions, 1)
+		assert.Equal(t, gasRegression{File: "a_test.cdc", Test: "testB", Baseline: 100, Used: 106}, regressions[0])
+		assert.InDelta(t, 6.0, regressions[0].increase(), 0.001)
+
+		assert.Empty(t, compareGas(baseline, report, 10))
+	})
+
+	t.Run("Baseline", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gas.json")
+		report := gasReport{"a_test.cdc": {"testA": 100}}
+
+		result, err := checkGas(path, report, 5, false)
+		require.NoError(t, err)
+		assert.True(t, result.Written)
+		assert.Equal(t, "Gas report baseline written to "+path+"\n", result.String())
+
+		result, err = checkGas(path, gasReport{"a_test.cdc": {"testA": 200}}, 5, false)
+		require.NoError(t, err)
+		assert.False(t, result.Written)
+		require.Len(t, result.Regressions, 1)
+		assert.Contains(t, result.String(), "- REGRESSION: a_test.cdc testA used 200 statements, baseline 100 (+100.0%)")
+
+		result, err = checkGas(path, gasReport{"a_test.cdc": {"testA": 200}}, 5, true)
+		require.NoError(t, err)
+		assert.True(t, result.Written)
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"a_test.cdc": {"testA": 200}}`, string(data))
+	})
+}
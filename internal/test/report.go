@@ -0,0 +1,191 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/common"
+	"github.com/onflow/cadence/runtime/interpreter"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+// testFailure is the source file and line a test failed at, the line is zero if it is unknown.
+type testFailure struct {
+	File    string
+	Line    int
+	Message string
+}
+
+// failureOf finds where the test of the test file failed from the test error.
+func failureOf(scriptPath string, err error, state *flowkit.State) testFailure {
+	failure := testFailure{
+		File:    scriptPath,
+		Message: err.Error(),
+	}
+
+	var interpreterErr interpreter.Error
+	if !errors.As(err, &interpreterErr) {
+		return failure
+	}
+	if interpreterErr.Err != nil {
+		failure.Message = interpreterErr.Err.Error()
+	}
+
+	if stringLocation, ok := interpreterErr.Location.(common.StringLocation); ok {
+		failure.File = sourceFile(scriptPath, stringLocation.String(), state)
+	}
+
+	var positioned interface {
+		error
+		ast.HasPosition
+	}
+	if errors.As(interpreterErr.Err, &positioned) {
+		failure.Line = positioned.StartPosition().Line
+	}
+
+	return failure
+}
+
+// sourceFile resolves the file of an imported location the same way imports are resolved for test files.
+func sourceFile(scriptPath string, location string, state *flowkit.State) string {
+	if strings.Contains(location, helperScriptSubstr) {
+		return absolutePath(scriptPath, location)
+	}
+
+	for _, c := range *state.Contracts() {
+		if c.Name == location {
+			return c.Location
+		}
+	}
+	return location
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Line      int           `xml:"line,attr,omitempty"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Content string `xml:",chardata"`
+}
+
+// junitReport creates a JUnit XML report with a test suite for each test file.
+func junitReport(r *result, state *flowkit.State) ([]byte, error) {
+	suites := junitTestSuites{}
+
+	for _, scriptPath := range r.scriptPaths() {
+		suite := junitTestSuite{Name: scriptPath}
+
+		for _, testResult := range r.Results[scriptPath] {
+			testCase := junitTestCase{
+				Name:      testResult.TestName,
+				ClassName: scriptPath,
+				File:      scriptPath,
+			}
+
+			if testResult.Error != nil {
+				failure := failureOf(scriptPath, testResult.Error, state)
+				testCase.File = failure.File
+				testCase.Line = failure.Line
+				testCase.Failure = &junitFailure{
+					Message: failure.Message,
+					Type:    "failure",
+					Content: testResult.Error.Error(),
+				}
+				suite.Failures++
+			}
+
+			suite.TestCases = append(suite.TestCases, testCase)
+			suite.Tests++
+		}
+
+		suites.Suites = append(suites.Suites, suite)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// githubAnnotations creates a GitHub Actions error annotation for each failing test.
+//
+// See https://docs.github.com/en/actions/using-workflows/workflow-commands-for-github-actions#setting-an-error-message
+func githubAnnotations(r *result, state *flowkit.State) string {
+	var b strings.Builder
+
+	for _, scriptPath := range r.scriptPaths() {
+		for _, testResult := range r.Results[scriptPath] {
+			if testResult.Error == nil {
+				continue
+			}
+
+			failure := failureOf(scriptPath, testResult.Error, state)
+			properties := []string{fmt.Sprintf("file=%s", escapeProperty(failure.File))}
+			if failure.Line > 0 {
+				properties = append(properties, fmt.Sprintf("line=%d", failure.Line))
+			}
+			properties = append(properties, fmt.Sprintf("title=%s", escapeProperty(testResult.TestName)))
+
+			b.WriteString(fmt.Sprintf(
+				"::error %s::%s\n",
+				strings.Join(properties, ","),
+				escapeData(failure.Message),
+			))
+		}
+	}
+
+	return b.String()
+}
+
+func escapeData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+func escapeProperty(s string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeData(s))
+}
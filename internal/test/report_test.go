@@ -0,0 +1,117 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package test
+
+import (
+	"encoding/xml"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+const failingScript = `import Test
+import "Bad"
+
+pub fun testPass() {
+    Test.assert(true)
+}
+
+pub fun testAssert() {
+    Test.assert(false, message: "not true")
+}
+
+pub fun testContract() {
+    Bad().check()
+}
+`
+
+func failingResult(t *testing.T) (*result, *flowkit.State) {
+	_, state, _ := util.TestMocks(t)
+	_ = state.ReaderWriter().WriteFile(
+		"contracts/Bad.cdc",
+		[]byte("pub struct Bad {\n    pub fun check() {\n        assert(false, message: \"bad\")\n    }\n}\n"),
+		os.ModeTemporary,
+	)
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Bad", Location: "contracts/Bad.cdc"})
+
+	results, _, err := testCode(map[string][]byte{"tests/bad_test.cdc": []byte(failingScript)}, state, flagsTests{})
+	require.NoError(t, err)
+
+	return &result{Results: results}, state
+}
+
+func TestReports(t *testing.T) {
+	t.Run("Failure Location", func(t *testing.T) {
+		r, state := failingResult(t)
+		results := r.Results["tests/bad_test.cdc"]
+		require.Len(t, results, 3)
+
+		assert.Equal(t,
+			testFailure{File: "tests/bad_test.cdc", Line: 9, Message: "assertion failed: not true"},
+			failureOf("tests/bad_test.cdc", results[1].Error, state),
+		)
+		assert.Equal(t,
+			testFailure{File: "contracts/Bad.cdc", Line: 3, Message: "assertion failed: bad"},
+			failureOf("tests/bad_test.cdc", results[2].Error, state),
+		)
+	})
+
+	t.Run("JUnit", func(t *testing.T) {
+		r, state := failingResult(t)
+
+		report, err := junitReport(r, state)
+		require.NoError(t, err)
+
+		var suites junitTestSuites
+		require.NoError(t, xml.Unmarshal(report, &suites))
+		assert.Equal(t, 3, suites.Tests)
+		assert.Equal(t, 2, suites.Failures)
+		require.Len(t, suites.Suites, 1)
+
+		cases := suites.Suites[0].TestCases
+		require.Len(t, cases, 3)
+		assert.Equal(t, "testPass", cases[0].Name)
+		assert.Nil(t, cases[0].Failure)
+		assert.Equal(t, "testAssert", cases[1].Name)
+		assert.Equal(t, 9, cases[1].Line)
+		assert.Equal(t, "assertion failed: not true", cases[1].Failure.Message)
+		assert.Equal(t, "contracts/Bad.cdc", cases[2].File)
+	})
+
+	t.Run("GitHub Annotations", func(t *testing.T) {
+		r, state := failingResult(t)
+
+		assert.Equal(t,
+			"::error file=tests/bad_test.cdc,line=9,title=testAssert::assertion failed: not true\n"+
+				"::error file=contracts/Bad.cdc,line=3,title=testContract::assertion failed: bad\n",
+			githubAnnotations(r, state),
+		)
+	})
+
+	t.Run("Escape", func(t *testing.T) {
+		assert.Equal(t, "a%25b%0Ac", escapeData("a%b\nc"))
+		assert.Equal(t, "a%3Ab%2Cc", escapeProperty("a:b,c"))
+	})
+}
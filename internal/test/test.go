@@ -22,9 +22,15 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/fs"
+	"math/rand"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	cdcTests "github.com/onflow/cadence-tools/test"
 	"github.com/onflow/cadence/runtime"
@@ -46,10 +52,21 @@ const helperScriptSubstr = "_helper"
 // scripts and transactions are excluded from coverage report.
 const contractsCoverCode = "contracts"
 
+// Test files found in directories must end with this suffix.
+const testFileSuffix = "_test.cdc"
+
 type flagsTests struct {
-	Cover        bool   `default:"false" flag:"cover" info:"Use the cover flag to calculate coverage report"`
-	CoverProfile string `default:"coverage.json" flag:"coverprofile" info:"Filename to write the calculated coverage report. Supported extensions are .json and .lcov"`
-	CoverCode    string `default:"all" flag:"covercode" info:"Use the covercode flag to calculate coverage report only for certain types of code. Available values are \"all\" & \"contracts\""`
+	Cover        bool    `default:"false" flag:"cover" info:"Use the cover flag to calculate coverage report"`
+	CoverProfile string  `default:"coverage.json" flag:"coverprofile" info:"Filename to write the calculated coverage report. Supported extensions are .json and .lcov"`
+	CoverCode    string  `default:"all" flag:"covercode" info:"Use the covercode flag to calculate coverage report only for certain types of code. Available values are \"all\" & \"contracts\""`
+	Random       bool    `default:"false" flag:"random" info:"Run the test files in a random order, the seed is included in the results"`
+	Seed         int64   `default:"0" flag:"seed" info:"Seed of the random order the test files are run in, implies the random flag"`
+	Parallel     int     `default:"1" flag:"parallel" info:"Number of test files run in parallel, can not be used with the cover flag"`
+	GasReport    string  `default:"" flag:"gas-report" info:"Baseline file of the computation used by each test, tests fail if they regress beyond the tolerance, it is written if it doesn't exist"`
+	GasTolerance float64 `default:"5" flag:"gas-tolerance" info:"Percentage the computation used by a test can exceed the gas report baseline"`
+	GasUpdate    bool    `default:"false" flag:"gas-update" info:"Overwrite the gas report baseline with the computation used"`
+	JUnit        string  `default:"" flag:"junit" info:"Filename to write a JUnit XML report of the test results"`
+	GitHub       bool    `default:"false" flag:"github-annotations" info:"Print GitHub Actions error annotations with the file and line of failing tests to stderr"`
 }
 
 var testFlags = flagsTests{}
@@ -58,10 +75,12 @@ var status = 0
 
 var TestCommand = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "test <filename>",
-		Short:   "Run Cadence tests",
-		Example: `flow test script.cdc`,
-		Args:    cobra.MinimumNArgs(1),
+		Use:   "test [files or directories...]",
+		Short: "Run Cadence tests",
+		Long: `Run Cadence tests in the provided files, test files ending with _test.cdc are found in the
+provided directories, or in the current directory if no files or directories are provided.`,
+		Example: "flow test\nflow test script_test.cdc\nflow test ./tests --parallel 4\nflow test --random --seed 1234\nflow test --gas-report gas.json\nflow test --junit report.xml --github-annotations",
+		Args:    cobra.ArbitraryArgs,
 		GroupID: "tools",
 	},
 	Flags:  &testFlags,
@@ -69,6 +88,10 @@ var TestCommand = &command.Command{
 	Status: &status,
 }
 
+func init() {
+	FuzzCommand.AddToParent(TestCommand.Cmd)
+}
+
 func run(
 	args []string,
 	_ command.GlobalFlags,
@@ -79,9 +102,39 @@ func run(
 	if !testFlags.Cover && testFlags.CoverProfile != "coverage.json" {
 		return nil, fmt.Errorf("the '--coverprofile' flag requires the '--cover' flag")
 	}
+	if testFlags.Parallel < 1 {
+		return nil, fmt.Errorf("the '--parallel' flag must be at least 1")
+	}
+	if testFlags.Cover && testFlags.Parallel > 1 {
+		return nil, fmt.Errorf("the '--parallel' flag can not be used with the '--cover' flag")
+	}
+	if testFlags.Cover && testFlags.GasReport != "" {
+		return nil, fmt.Errorf("the '--gas-report' flag can not be used with the '--cover' flag")
+	}
+	if testFlags.GasReport == "" && testFlags.GasUpdate {
+		return nil, fmt.Errorf("the '--gas-update' flag requires the '--gas-report' flag")
+	}
+	if testFlags.GasTolerance < 0 {
+		return nil, fmt.Errorf("the '--gas-tolerance' flag can not be negative")
+	}
+
+	flags := testFlags
+	if flags.Seed != 0 {
+		flags.Random = true
+	} else if flags.Random {
+		flags.Seed = time.Now().UnixNano()
+	}
+
+	filenames, err := findTestFiles(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(filenames) == 0 {
+		return nil, fmt.Errorf("no test files found, test files must end with %s", testFileSuffix)
+	}
 
 	testFiles := make(map[string][]byte, 0)
-	for _, filename := range args {
+	for _, filename := range filenames {
 		code, err := state.ReadFile(filename)
 
 		if err != nil {
@@ -91,11 +144,22 @@ func run(
 		testFiles[filename] = code
 	}
 
-	res, coverageReport, err := testCode(testFiles, state, testFlags)
+	res, coverageReport, gas, err := runTests(testFiles, state, flags)
 	if err != nil {
 		return nil, err
 	}
 
+	var gasRes *gasResult
+	if gas != nil {
+		gasRes, err = checkGas(flags.GasReport, gas, flags.GasTolerance, flags.GasUpdate)
+		if err != nil {
+			return nil, err
+		}
+		if len(gasRes.Regressions) > 0 {
+			status = 1
+		}
+	}
+
 	if coverageReport != nil {
 		var file []byte
 		var err error
@@ -119,10 +183,86 @@ func run(
 		}
 	}
 
-	return &result{
+	r := &result{
 		Results:        res,
 		CoverageReport: coverageReport,
-	}, nil
+		Gas:            gasRes,
+	}
+	if flags.Random {
+		r.Seed = flags.Seed
+	}
+
+	if flags.JUnit != "" {
+		report, err := junitReport(r, state)
+		if err != nil {
+			return nil, fmt.Errorf("error serializing JUnit report: %w", err)
+		}
+		if err := os.WriteFile(flags.JUnit, report, 0644); err != nil {
+			return nil, fmt.Errorf("error writing JUnit report file: %w", err)
+		}
+	}
+	if flags.GitHub {
+		_, _ = fmt.Fprint(os.Stderr, githubAnnotations(r, state))
+	}
+
+	return r, nil
+}
+
+// findTestFiles returns the provided files and the test files found in the provided directories,
+// the current directory is searched if no paths are provided.
+func findTestFiles(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil || !info.IsDir() {
+			// files are read from the project state, so they are added even if they are not found here
+			files = append(files, p)
+			continue
+		}
+
+		err = filepath.WalkDir(p, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if file != p && strings.HasPrefix(entry.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(entry.Name(), testFileSuffix) {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error finding test files in %s: %w", p, err)
+		}
+	}
+
+	return files, nil
+}
+
+// testOrder returns the order test files are run in, sorted or shuffled with the seed if random is set.
+func testOrder(testFiles map[string][]byte, flags flagsTests) []string {
+	order := make([]string, 0, len(testFiles))
+	for scriptPath := range testFiles {
+		order = append(order, scriptPath)
+	}
+	sort.Strings(order)
+
+	if flags.Random {
+		random := rand.New(rand.NewSource(flags.Seed))
+		random.Shuffle(len(order), func(i, j int) {
+			order[i], order[j] = order[j], order[i]
+		})
+	}
+
+	return order
 }
 
 func testCode(
@@ -130,8 +270,17 @@ func testCode(
 	state *flowkit.State,
 	flags flagsTests,
 ) (map[string]cdcTests.Results, *runtime.CoverageReport, error) {
+	results, coverageReport, _, err := runTests(testFiles, state, flags)
+	return results, coverageReport, err
+}
+
+// runTests runs the test files, the gas report is only returned if the gas report flag is set.
+func runTests(
+	testFiles map[string][]byte,
+	state *flowkit.State,
+	flags flagsTests,
+) (map[string]cdcTests.Results, *runtime.CoverageReport, gasReport, error) {
 	var coverageReport *runtime.CoverageReport
-	runner := cdcTests.NewTestRunner()
 	if flags.Cover {
 		coverageReport = runtime.NewCoverageReport()
 		if flags.CoverCode == contractsCoverCode {
@@ -146,27 +295,68 @@ func testCode(
 				},
 			)
 		}
-		runner = runner.WithCoverageReport(coverageReport)
 	}
 
-	testResults := make(map[string]cdcTests.Results, 0)
-	for scriptPath, code := range testFiles {
-		runner := runner.
-			WithImportResolver(importResolver(scriptPath, state)).
-			WithFileResolver(fileResolver(scriptPath, state))
-		results, err := runner.RunTests(string(code))
-		if err != nil {
-			return nil, nil, err
+	parallel := flags.Parallel
+	if parallel < 1 || coverageReport != nil {
+		parallel = 1
+	}
+
+	order := testOrder(testFiles, flags)
+	results := make([]cdcTests.Results, len(order))
+	usage := make([]map[string]uint64, len(order))
+	errs := make([]error, len(order))
+
+	// each test file is run by its own runner, since runners are not safe for concurrent use
+	var wg sync.WaitGroup
+	limit := make(chan struct{}, parallel)
+	for i, scriptPath := range order {
+		wg.Add(1)
+		limit <- struct{}{}
+		go func(i int, scriptPath string) {
+			defer func() {
+				<-limit
+				wg.Done()
+			}()
+
+			if flags.GasReport != "" {
+				results[i], usage[i], errs[i] = runGasTests(scriptPath, testFiles[scriptPath], state)
+				return
+			}
+
+			runner := cdcTests.NewTestRunner().
+				WithImportResolver(importResolver(scriptPath, state)).
+				WithFileResolver(fileResolver(scriptPath, state))
+			if coverageReport != nil {
+				runner = runner.WithCoverageReport(coverageReport)
+			}
+			results[i], errs[i] = runner.RunTests(string(testFiles[scriptPath]))
+		}(i, scriptPath)
+	}
+	wg.Wait()
+
+	var gas gasReport
+	if flags.GasReport != "" {
+		gas = make(gasReport, len(order))
+	}
+
+	testResults := make(map[string]cdcTests.Results, len(order))
+	for i, scriptPath := range order {
+		if errs[i] != nil {
+			return nil, nil, nil, errs[i]
+		}
+		testResults[scriptPath] = results[i]
+		if gas != nil {
+			gas[scriptPath] = usage[i]
 		}
-		testResults[scriptPath] = results
-		for _, result := range results {
+		for _, result := range results[i] {
 			if result.Error != nil {
 				status = 1
 				break
 			}
 		}
 	}
-	return testResults, coverageReport, nil
+	return testResults, coverageReport, gas, nil
 }
 
 func importResolver(scriptPath string, state *flowkit.State) cdcTests.ImportResolver {
@@ -233,6 +423,18 @@ func absolutePath(basePath, filePath string) string {
 type result struct {
 	Results        map[string]cdcTests.Results
 	CoverageReport *runtime.CoverageReport
+	Seed           int64
+	Gas            *gasResult
+}
+
+// scriptPaths returns the test files of the results sorted by path.
+func (r *result) scriptPaths() []string {
+	scriptPaths := make([]string, 0, len(r.Results))
+	for scriptPath := range r.Results {
+		scriptPaths = append(scriptPaths, scriptPath)
+	}
+	sort.Strings(scriptPaths)
+	return scriptPaths
 }
 
 var _ command.Result = &result{}
@@ -259,6 +461,21 @@ func (r *result) JSON() any {
 			"info": r.CoverageReport.Percentage(),
 		}
 	}
+	if r.Seed != 0 {
+		if results["meta"] == nil {
+			results["meta"] = make(map[string]string)
+		}
+		results["meta"]["seed"] = fmt.Sprint(r.Seed)
+	}
+	if r.Gas != nil {
+		gas := map[string]string{"report": r.Gas.Path}
+		for _, regression := range r.Gas.Regressions {
+			gas[fmt.Sprintf("%s:%s", regression.File, regression.Test)] = fmt.Sprintf(
+				"REGRESSION: used %d, baseline %d", regression.Used, regression.Baseline,
+			)
+		}
+		results["gas"] = gas
+	}
 
 	return results
 }
@@ -267,12 +484,18 @@ func (r *result) String() string {
 	var b bytes.Buffer
 	writer := util.CreateTabWriter(&b)
 
-	for scriptPath, testResult := range r.Results {
-		_, _ = fmt.Fprint(writer, cdcTests.PrettyPrintResults(testResult, scriptPath))
+	for _, scriptPath := range r.scriptPaths() {
+		_, _ = fmt.Fprint(writer, cdcTests.PrettyPrintResults(r.Results[scriptPath], scriptPath))
 	}
 	if r.CoverageReport != nil {
 		_, _ = fmt.Fprint(writer, r.CoverageReport.String())
 	}
+	if r.Seed != 0 {
+		_, _ = fmt.Fprintf(writer, "Seed: %d\n", r.Seed)
+	}
+	if r.Gas != nil {
+		_, _ = fmt.Fprint(writer, r.Gas.String())
+	}
 
 	_ = writer.Flush()
 
@@ -282,13 +505,19 @@ func (r *result) String() string {
 func (r *result) Oneliner() string {
 	var builder strings.Builder
 
-	for scriptPath, testResult := range r.Results {
-		builder.WriteString(cdcTests.PrettyPrintResults(testResult, scriptPath))
+	for _, scriptPath := range r.scriptPaths() {
+		builder.WriteString(cdcTests.PrettyPrintResults(r.Results[scriptPath], scriptPath))
 	}
 	if r.CoverageReport != nil {
 		builder.WriteString(r.CoverageReport.String())
 		builder.WriteString("\n")
 	}
+	if r.Seed != 0 {
+		builder.WriteString(fmt.Sprintf("Seed: %d\n", r.Seed))
+	}
+	if r.Gas != nil {
+		builder.WriteString(r.Gas.String())
+	}
 
 	return builder.String()
 }
@@ -20,6 +20,7 @@ package test
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/onflow/cadence/runtime/common"
@@ -343,4 +344,68 @@ func TestExecutingTests(t *testing.T) {
 			coverageReport.String(),
 		)
 	})
+
+	t.Run("in parallel", func(t *testing.T) {
+		t.Parallel()
+		_, state, _ := util.TestMocks(t)
+
+		testFiles := map[string][]byte{
+			"a_test.cdc": tests.TestScriptSimple.Source,
+			"b_test.cdc": tests.TestScriptSimpleFailing.Source,
+			"c_test.cdc": tests.TestScriptSimple.Source,
+		}
+		results, _, err := testCode(testFiles, state, flagsTests{Parallel: 2})
+
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.NoError(t, results["a_test.cdc"][0].Error)
+		assert.Error(t, results["b_test.cdc"][0].Error)
+		assert.NoError(t, results["c_test.cdc"][0].Error)
+	})
+}
+
+func TestTestOrder(t *testing.T) {
+	testFiles := map[string][]byte{
+		"a_test.cdc": nil,
+		"b_test.cdc": nil,
+		"c_test.cdc": nil,
+		"d_test.cdc": nil,
+	}
+
+	assert.Equal(t,
+		[]string{"a_test.cdc", "b_test.cdc", "c_test.cdc", "d_test.cdc"},
+		testOrder(testFiles, flagsTests{}),
+	)
+
+	random := testOrder(testFiles, flagsTests{Random: true, Seed: 1234})
+	assert.ElementsMatch(t, []string{"a_test.cdc", "b_test.cdc", "c_test.cdc", "d_test.cdc"}, random)
+	assert.Equal(t, random, testOrder(testFiles, flagsTests{Random: true, Seed: 1234}))
+}
+
+func TestFindTestFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{
+		"foo_test.cdc",
+		"foo.cdc",
+		"nested/bar_test.cdc",
+		".hidden/baz_test.cdc",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), nil, 0644))
+	}
+
+	t.Run("Directory", func(t *testing.T) {
+		files, err := findTestFiles([]string{dir})
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{
+			filepath.Join(dir, "foo_test.cdc"),
+			filepath.Join(dir, "nested/bar_test.cdc"),
+		}, files)
+	})
+
+	t.Run("Files", func(t *testing.T) {
+		files, err := findTestFiles([]string{"script.cdc", filepath.Join(dir, "foo.cdc")})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"script.cdc", filepath.Join(dir, "foo.cdc")}, files)
+	})
 }
@@ -0,0 +1,195 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tools
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+// explorers are the block explorers of the public networks.
+var explorers = []struct {
+	network config.Network
+	url     string
+}{
+	{config.MainnetNetwork, "https://www.flowdiver.io"},
+	{config.TestnetNetwork, "https://testnet.flowdiver.io"},
+}
+
+// openBrowser opens the URL, replaced in tests.
+var openBrowser = util.OpenBrowser
+
+type flagsExplore struct{}
+
+var exploreFlags = flagsExplore{}
+
+var Explore = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "explore [<tx_id> | <address>]",
+		Short: "Explore the network in a block explorer",
+		Long: `Explore the network in a block explorer, the transaction or the account is opened if provided.
+
+On the emulator Flowser is started for the project after checking the emulator is running,
+on the public networks the block explorer is opened in the browser.`,
+		Example: "flow explore\nflow explore 07a8...b433 --network testnet\nflow explore 0xf8d6e0586b0a20c7 --network mainnet",
+		Args:    cobra.MaximumNArgs(1),
+		GroupID: "tools",
+	},
+	Flags: &exploreFlags,
+	Run:   explore,
+}
+
+func explore(
+	args []string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	reader flowkit.ReaderWriter,
+	flow flowkit.Services,
+) (command.Result, error) {
+	id := ""
+	if len(args) == 1 {
+		id = args[0]
+	}
+
+	return nil, OpenExplorer(id, globalFlags, logger, reader, flow)
+}
+
+// OpenExplorer opens the transaction or account ID in the block explorer of the network,
+// the emulator is explored with Flowser started for the project.
+func OpenExplorer(
+	id string,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	reader flowkit.ReaderWriter,
+	flow flowkit.Services,
+) error {
+	network := flow.Network()
+
+	if isEmulatorNetwork(network) {
+		host, err := detectEmulator(network, globalFlags.ConfigPaths, reader)
+		if err != nil {
+			return err
+		}
+
+		projectPath, _ := os.Getwd()
+		logger.Info(fmt.Sprintf(
+			"%s Exploring project %s on the emulator running at %s",
+			output.SuccessEmoji(),
+			output.Bold(filepath.Base(projectPath)),
+			host,
+		))
+		if id != "" {
+			logger.Info(fmt.Sprintf("Look up %s in Flowser once it starts.", id))
+		}
+
+		return launchFlowser(logger, reader)
+	}
+
+	url, err := explorerURL(network, id)
+	if err != nil {
+		return err
+	}
+
+	logger.Info(fmt.Sprintf("%s Opening %s", output.SuccessEmoji(), url))
+	return openBrowser(url)
+}
+
+// explorerURL returns the block explorer URL of the transaction or account on the network.
+func explorerURL(network config.Network, id string) (string, error) {
+	base := ""
+	for _, explorer := range explorers {
+		if explorer.network.Name == network.Name || explorer.network.Host == network.Host {
+			base = explorer.url
+			break
+		}
+	}
+	if base == "" {
+		return "", fmt.Errorf("no block explorer known for network %s", network.Name)
+	}
+
+	id = strings.TrimPrefix(id, "0x")
+	switch {
+	case id == "":
+		return base, nil
+	case isTransactionID(id):
+		return fmt.Sprintf("%s/tx/%s", base, id), nil
+	case isAddress(id):
+		return fmt.Sprintf("%s/account/0x%s", base, flowsdk.HexToAddress(id).Hex()), nil
+	}
+
+	return "", fmt.Errorf("invalid transaction ID or address: %s", id)
+}
+
+func isTransactionID(id string) bool {
+	b, err := hex.DecodeString(id)
+	return err == nil && len(b) == len(flowsdk.EmptyID)
+}
+
+func isAddress(id string) bool {
+	if len(id)%2 == 1 {
+		id = "0" + id
+	}
+	b, err := hex.DecodeString(id)
+	return err == nil && len(b) > 0 && len(b) <= flowsdk.AddressLength
+}
+
+// isEmulatorNetwork checks whether the network is the emulator running locally.
+func isEmulatorNetwork(network config.Network) bool {
+	if network.Name == config.EmulatorNetwork.Name {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(network.Host)
+	return err == nil && (host == "localhost" || host == "127.0.0.1")
+}
+
+// detectEmulator returns the host of the running emulator, the network host is checked first
+// followed by the ports of the emulators configured in the project.
+func detectEmulator(network config.Network, configPaths []string, reader flowkit.ReaderWriter) (string, error) {
+	hosts := []string{network.Host}
+	if state, err := flowkit.Load(configPaths, reader); err == nil {
+		for _, emulator := range state.Config().Emulators {
+			hosts = append(hosts, fmt.Sprintf("127.0.0.1:%d", emulator.Port))
+		}
+	}
+
+	for _, host := range hosts {
+		conn, err := net.DialTimeout("tcp", host, 500*time.Millisecond)
+		if err == nil {
+			_ = conn.Close()
+			return host, nil
+		}
+	}
+
+	return "", fmt.Errorf("emulator is not running on %s, start it with 'flow emulator'", network.Host)
+}
@@ -0,0 +1,95 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tools
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_ExplorerURL(t *testing.T) {
+	const txID = "07a8cf9b5a8e1bdcb16eb61f68d9b3a4e8d3e4c1f0bd7d5e6f5c5b1d0a9fb433"
+
+	testCases := []struct {
+		network config.Network
+		id      string
+		url     string
+	}{
+		{config.MainnetNetwork, "", "https://www.flowdiver.io"},
+		{config.TestnetNetwork, txID, "https://testnet.flowdiver.io/tx/" + txID},
+		{config.MainnetNetwork, "0x" + txID, "https://www.flowdiver.io/tx/" + txID},
+		{config.MainnetNetwork, "0x1654653399040a61", "https://www.flowdiver.io/account/0x1654653399040a61"},
+		{config.Network{Name: "production", Host: config.MainnetNetwork.Host}, "f233dcee88fe0abe", "https://www.flowdiver.io/account/0xf233dcee88fe0abe"},
+	}
+
+	for _, tc := range testCases {
+		url, err := explorerURL(tc.network, tc.id)
+		require.NoError(t, err)
+		assert.Equal(t, tc.url, url)
+	}
+
+	_, err := explorerURL(config.MainnetNetwork, "0xzz")
+	assert.EqualError(t, err, "invalid transaction ID or address: zz")
+
+	_, err = explorerURL(config.Network{Name: "custom", Host: "access.example.com:9000"}, "")
+	assert.EqualError(t, err, "no block explorer known for network custom")
+}
+
+func Test_Explore(t *testing.T) {
+	srv, _, rw := util.TestMocks(t)
+
+	t.Run("Success testnet", func(t *testing.T) {
+		srv.Network.Return(config.TestnetNetwork)
+
+		var opened string
+		openBrowser = func(url string) error {
+			opened = url
+			return nil
+		}
+		defer func() { openBrowser = util.OpenBrowser }()
+
+		_, err := explore([]string{"0x01cf0e2f2f715450"}, command.GlobalFlags{}, util.NoLogger, rw, srv.Mock)
+		require.NoError(t, err)
+		assert.Equal(t, "https://testnet.flowdiver.io/account/0x01cf0e2f2f715450", opened)
+	})
+
+	t.Run("Detect emulator", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		network := config.Network{Name: "emulator", Host: listener.Addr().String()}
+		assert.True(t, isEmulatorNetwork(network))
+
+		host, err := detectEmulator(network, nil, rw)
+		require.NoError(t, err)
+		assert.Equal(t, listener.Addr().String(), host)
+
+		listener.Close()
+		_, err = detectEmulator(network, nil, rw)
+		assert.ErrorContains(t, err, "emulator is not running")
+	})
+}
@@ -54,32 +54,37 @@ var Flowser = &command.Command{
 func runFlowser(
 	_ []string,
 	_ command.GlobalFlags,
-	_ output.Logger,
+	logger output.Logger,
 	reader flowkit.ReaderWriter,
 	_ flowkit.Services,
 ) (command.Result, error) {
+	return nil, launchFlowser(logger, reader)
+}
+
+// launchFlowser starts Flowser for the project in the current directory, installing it first if needed.
+func launchFlowser(logger output.Logger, reader flowkit.ReaderWriter) error {
 	if runtime.GOOS != settings.Windows && runtime.GOOS != settings.Darwin {
 		fmt.Println("If you want Flowser to be supported on Linux please vote here: https://github.com/onflowser/flowser/discussions/142")
-		return nil, errors.New("OS not supported, only supporting Windows and Mac OS")
+		return errors.New("OS not supported, only supporting Windows and Mac OS")
 	}
 
 	flowser := flowser.New()
 
 	installPath, err := settings.GetFlowserPath()
 	if err != nil {
-		return nil, fmt.Errorf("failure reading setting: %w", err)
+		return fmt.Errorf("failure reading setting: %w", err)
 	}
 
 	if !flowser.Installed(installPath) {
-		installPath, err = installFlowser(flowser, installPath)
+		installPath, err = installFlowser(flowser, installPath, logger)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	projectPath, err := os.Getwd()
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	// check if current directory is existing flow project if not then don't pass project path to Flowser, so user can choose a project
@@ -89,15 +94,10 @@ func runFlowser(
 	}
 
 	fmt.Printf("%s Starting up Flowser, please wait...\n", output.SuccessEmoji())
-	err = flowser.Run(installPath, projectPath)
-	if err != nil {
-		return nil, err
-	}
-
-	return nil, nil
+	return flowser.Run(installPath, projectPath)
 }
 
-func installFlowser(flowser *flowser.App, installPath string) (string, error) {
+func installFlowser(flowser *flowser.App, installPath string, logger output.Logger) (string, error) {
 	fmt.Println("It looks like Flowser is not yet installed on your system.")
 	installChoice := util.InstallPrompt()
 	if installChoice == util.CancelInstall {
@@ -117,7 +117,6 @@ func installFlowser(flowser *flowser.App, installPath string) (string, error) {
 		_ = settings.SetFlowserPath(installPath)
 	}
 
-	logger := output.NewStdoutLogger(output.InfoLog)
 	logger.StartProgress(fmt.Sprintf("%s Installing Flowser, this may take few minutes, please wait ", output.TryEmoji()))
 	defer logger.StopProgress()
 
@@ -20,27 +20,31 @@ package tools
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	devWallet "github.com/onflow/fcl-dev-wallet/go/wallet"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
 )
 
 type flagsWallet struct {
 	Port uint   `default:"8701" flag:"port" info:"Dev wallet port to listen on"`
-	Host string `default:"http://localhost:8888" flag:"emulator-host" info:"Host for access node connection"`
+	Host string `default:"" flag:"emulator-host" info:"Host for access node connection, defaults to the REST API of the configured emulator"`
 }
 
 var walletFlags = flagsWallet{}
 
 var DevWallet = &command.Command{
 	Cmd: &cobra.Command{
-		Use:     "dev-wallet",
-		Short:   "Run a development wallet",
+		Use:   "dev-wallet",
+		Short: "Run a development wallet",
+		Long: `Run the FCL development wallet signing with the emulator service account of the project, and print the FCL
+configuration of a frontend using the wallet and the contracts deployed to the emulator.`,
 		Example: "flow dev-wallet",
 		Args:    cobra.ExactArgs(0),
 		GroupID: "tools",
@@ -66,11 +70,19 @@ func wallet(
 		return nil, err
 	}
 
+	host := walletFlags.Host
+	if host == "" {
+		host = defaultEmulatorHost
+		if emulator := state.Config().Emulators.Default(); emulator != nil && emulator.RestPort != 0 {
+			host = fmt.Sprintf("http://localhost:%d", emulator.RestPort)
+		}
+	}
+
 	conf := devWallet.FlowConfig{
 		Address:    fmt.Sprintf("0x%s", service.Address.String()),
 		PrivateKey: strings.TrimPrefix((*privateKey).String(), "0x"),
 		PublicKey:  strings.TrimPrefix((*privateKey).PublicKey().String(), "0x"),
-		AccessNode: walletFlags.Host,
+		AccessNode: host,
 	}
 
 	srv, err := devWallet.NewHTTPServer(walletFlags.Port, &conf)
@@ -80,7 +92,49 @@ func wallet(
 
 	fmt.Printf("%s Starting dev wallet server on port %d\n", output.SuccessEmoji(), walletFlags.Port)
 	fmt.Printf("%s  Make sure the emulator is running\n", output.WarningEmoji())
+	fmt.Printf("\nConfigure FCL in your frontend with:\n\n%s\n", fclConfig(state, host, walletFlags.Port))
 
 	srv.Start()
 	return nil, nil
 }
+
+// defaultEmulatorHost is the REST API of the emulator if no REST port is configured.
+const defaultEmulatorHost = "http://localhost:8888"
+
+// fclConfig returns the FCL configuration of a frontend using the dev wallet and the emulator,
+// the contract imports are replaced with the addresses of the contracts deployed to or aliased on the emulator.
+func fclConfig(state *flowkit.State, host string, port uint) string {
+	var b strings.Builder
+	b.WriteString("fcl.config()\n")
+	b.WriteString("  .put(\"flow.network\", \"local\")\n")
+	b.WriteString(fmt.Sprintf("  .put(\"accessNode.api\", \"%s\")\n", host))
+	b.WriteString(fmt.Sprintf("  .put(\"discovery.wallet\", \"http://localhost:%d/fcl/authn\")", port))
+
+	addresses := make(map[string]string)
+	for _, contract := range *state.Contracts() {
+		if alias := contract.Aliases.ByNetwork(config.EmulatorNetwork.Name); alias != nil {
+			addresses[contract.Name] = alias.Address.String()
+		}
+	}
+	for _, deployment := range state.Deployments().ByNetwork(config.EmulatorNetwork.Name) {
+		account, err := state.Accounts().ByName(state.DeploymentAccountName(deployment.Account))
+		if err != nil {
+			continue
+		}
+		for _, contract := range deployment.Contracts {
+			addresses[contract.Name] = account.Address.String()
+		}
+	}
+
+	names := make([]string, 0, len(addresses))
+	for name := range addresses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		b.WriteString(fmt.Sprintf("\n  .put(\"0x%s\", \"0x%s\")", name, addresses[name]))
+	}
+
+	b.WriteString(";")
+	return b.String()
+}
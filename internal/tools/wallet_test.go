@@ -0,0 +1,52 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package tools
+
+import (
+	"testing"
+
+	"github.com/onflow/flow-go-sdk"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_FCLConfig(t *testing.T) {
+	_, state, _ := util.TestMocks(t)
+
+	state.Contracts().AddOrUpdate(config.Contract{Name: "Foo", Location: "Foo.cdc"})
+	state.Contracts().AddOrUpdate(config.Contract{
+		Name:     "FungibleToken",
+		Location: "FungibleToken.cdc",
+		Aliases:  config.Aliases{{Network: config.EmulatorNetwork.Name, Address: flow.HexToAddress("ee82856bf20e2aa6")}},
+	})
+	state.Deployments().AddOrUpdate(config.Deployment{
+		Network:   config.EmulatorNetwork.Name,
+		Account:   config.DefaultEmulator.ServiceAccount,
+		Contracts: []config.ContractDeployment{{Name: "Foo"}},
+	})
+
+	assert.Equal(t, `fcl.config()
+  .put("flow.network", "local")
+  .put("accessNode.api", "http://localhost:8888")
+  .put("discovery.wallet", "http://localhost:8701/fcl/authn")
+  .put("0xFoo", "0xf8d6e0586b0a20c7")
+  .put("0xFungibleToken", "0xee82856bf20e2aa6");`, fclConfig(state, "http://localhost:8888", 8701))
+}
@@ -96,7 +96,7 @@ func build(
 		transactionArgs, err = arguments.ParseWithoutType(args[1:], code, filename)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+		return nil, command.WithExitCode(command.ExitValidationError, fmt.Errorf("error parsing transaction arguments: %w", err))
 	}
 
 	tx, err := flow.BuildTransaction(
@@ -119,7 +119,7 @@ func build(
 	}
 
 	if !globalFlags.Yes && !util.ApproveTransactionForBuildingPrompt(tx.FlowTransaction()) {
-		return nil, fmt.Errorf("transaction was not approved")
+		return nil, command.WithExitCode(command.ExitAborted, fmt.Errorf("transaction was not approved"))
 	}
 
 	return &transactionResult{
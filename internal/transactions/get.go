@@ -20,6 +20,7 @@ package transactions
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	flowsdk "github.com/onflow/flow-go-sdk"
@@ -28,12 +29,14 @@ import (
 	"github.com/onflow/flow-cli/flowkit"
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/tools"
 )
 
 type flagsGet struct {
 	Sealed  bool     `default:"true" flag:"sealed" info:"Wait for a sealed result"`
 	Include []string `default:"" flag:"include" info:"Fields to include in the output. Valid values: signatures, code, payload."`
 	Exclude []string `default:"" flag:"exclude" info:"Fields to exclude from the output. Valid values: events."`
+	Open    bool     `default:"false" flag:"open" info:"Open the transaction in the block explorer of the network"`
 }
 
 var getFlags = flagsGet{}
@@ -52,9 +55,9 @@ var getCommand = &command.Command{
 
 func get(
 	args []string,
-	_ command.GlobalFlags,
-	_ output.Logger,
-	_ flowkit.ReaderWriter,
+	globalFlags command.GlobalFlags,
+	logger output.Logger,
+	reader flowkit.ReaderWriter,
 	flow flowkit.Services,
 ) (command.Result, error) {
 	id := flowsdk.HexToID(strings.TrimPrefix(args[0], "0x"))
@@ -64,6 +67,14 @@ func get(
 		return nil, err
 	}
 
+	if getFlags.Open {
+		// the transaction is still displayed if the explorer can't be opened
+		err = tools.OpenExplorer(id.String(), globalFlags, logger, reader, flow)
+		if err != nil {
+			logger.Error(fmt.Sprintf("Could not open the block explorer: %s", err))
+		}
+	}
+
 	return &transactionResult{
 		result:  result,
 		tx:      tx,
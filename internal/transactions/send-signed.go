@@ -70,7 +70,7 @@ func sendSigned(
 	}
 
 	if !globalFlags.Yes && !util.ApproveTransactionForSendingPrompt(tx.FlowTransaction()) {
-		return nil, fmt.Errorf("transaction was not approved for sending")
+		return nil, command.WithExitCode(command.ExitAborted, fmt.Errorf("transaction was not approved for sending"))
 	}
 
 	logger.StartProgress(fmt.Sprintf("Sending transaction with ID: %s", tx.FlowTransaction().ID()))
@@ -86,5 +86,6 @@ func sendSigned(
 		tx:      sentTx,
 		include: sendSignedFlags.Include,
 		exclude: sendSignedFlags.Exclude,
+		sent:    true,
 	}, nil
 }
@@ -23,6 +23,8 @@ import (
 	"fmt"
 
 	"github.com/onflow/cadence"
+	"github.com/onflow/cadence/runtime/ast"
+	"github.com/onflow/cadence/runtime/parser"
 	"github.com/spf13/cobra"
 
 	"github.com/onflow/flow-cli/flowkit"
@@ -31,17 +33,19 @@ import (
 	"github.com/onflow/flow-cli/flowkit/output"
 	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/settings"
 )
 
 type Flags struct {
-	ArgsJSON    string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
-	Signer      string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and suthorizer"`
-	Proposer    string   `default:"" flag:"proposer" info:"Account name from configuration used as proposer"`
-	Payer       string   `default:"" flag:"payer" info:"Account name from configuration used as payer"`
-	Authorizers []string `default:"" flag:"authorizer" info:"Name of a single or multiple comma-separated accounts used as authorizers from configuration"`
-	Include     []string `default:"" flag:"include" info:"Fields to include in the output"`
-	Exclude     []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
-	GasLimit    uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
+	ArgsJSON     string   `default:"" flag:"args-json" info:"arguments in JSON-Cadence format"`
+	Signer       string   `default:"" flag:"signer" info:"Account name from configuration used to sign the transaction as proposer, payer and suthorizer"`
+	Proposer     string   `default:"" flag:"proposer" info:"Account name from configuration used as proposer"`
+	Payer        string   `default:"" flag:"payer" info:"Account name from configuration used as payer"`
+	PayerService bool     `default:"false" flag:"payer-service" info:"Pay the fees with the payer service configured on the network"`
+	Authorizers  []string `default:"" flag:"authorizer" info:"Name of a single or multiple comma-separated accounts used as authorizers from configuration"`
+	Include      []string `default:"" flag:"include" info:"Fields to include in the output"`
+	Exclude      []string `default:"" flag:"exclude" info:"Fields to exclude from the output (events)"`
+	GasLimit     uint64   `default:"1000" flag:"gas-limit" info:"transaction gas limit"`
 }
 
 var flags = Flags{}
@@ -59,7 +63,7 @@ var sendCommand = &command.Command{
 
 func send(
 	args []string,
-	_ command.GlobalFlags,
+	globalFlags command.GlobalFlags,
 	_ output.Logger,
 	flow flowkit.Services,
 	state *flowkit.State,
@@ -71,10 +75,18 @@ func send(
 		return nil, fmt.Errorf("error loading transaction file: %w", err)
 	}
 
-	return SendTransaction(code, args[1:], filename, flow, state, flags)
+	return SendTransaction(code, args[1:], filename, globalFlags, flow, state, flags)
 }
 
-func SendTransaction(code []byte, args []string, location string, flow flowkit.Services, state *flowkit.State, sendFlags Flags) (result command.Result, err error) {
+func SendTransaction(
+	code []byte,
+	args []string,
+	location string,
+	globalFlags command.GlobalFlags,
+	flow flowkit.Services,
+	state *flowkit.State,
+	sendFlags Flags,
+) (result command.Result, err error) {
 	proposerName := sendFlags.Proposer
 	var proposer *accounts.Account
 	if proposerName != "" {
@@ -102,14 +114,24 @@ func SendTransaction(code []byte, args []string, location string, flow flowkit.S
 		authorizers = append(authorizers, *authorizer)
 	}
 
+	if sendFlags.PayerService {
+		if payer != nil {
+			return nil, fmt.Errorf("payer flag cannot be combined with payer-service flag")
+		}
+		payer, err = accounts.NewPayerServiceAccount(flow.Network())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	signerName := sendFlags.Signer
 
-	if signerName == "" && proposer == nil && payer == nil && len(authorizers) == 0 {
+	if signerName == "" && proposer == nil && (payer == nil || sendFlags.PayerService) && len(authorizers) == 0 {
 		signerName = state.Config().Emulators.Default().ServiceAccount
 	}
 
 	if signerName != "" {
-		if proposer != nil || payer != nil || len(authorizers) > 0 {
+		if proposer != nil || (payer != nil && !sendFlags.PayerService) || len(authorizers) > 0 {
 			return nil, fmt.Errorf("signer flag cannot be combined with payer/proposer/authorizer flags")
 		}
 		signer, err := state.Accounts().ByName(signerName)
@@ -117,10 +139,16 @@ func SendTransaction(code []byte, args []string, location string, flow flowkit.S
 			return nil, fmt.Errorf("signer account: [%s] doesn't exists in configuration", signerName)
 		}
 		proposer = signer
-		payer = signer
+		if !sendFlags.PayerService {
+			payer = signer
+		}
 		authorizers = append(authorizers, *signer)
 	}
 
+	if err := guardKeyOperation(code, globalFlags, flow, state, proposer, payer, authorizers); err != nil {
+		return nil, err
+	}
+
 	var transactionArgs []cadence.Value
 	if sendFlags.ArgsJSON != "" {
 		transactionArgs, err = arguments.ParseJSON(sendFlags.ArgsJSON)
@@ -128,7 +156,7 @@ func SendTransaction(code []byte, args []string, location string, flow flowkit.S
 		transactionArgs, err = arguments.ParseWithoutType(args, code, location)
 	}
 	if err != nil {
-		return nil, fmt.Errorf("error parsing transaction arguments: %w", err)
+		return nil, command.WithExitCode(command.ExitValidationError, fmt.Errorf("error parsing transaction arguments: %w", err))
 	}
 
 	tx, txResult, err := flow.SendTransaction(
@@ -151,5 +179,90 @@ func SendTransaction(code []byte, args []string, location string, flow flowkit.S
 		tx:      tx,
 		include: sendFlags.Include,
 		exclude: sendFlags.Exclude,
+		sent:    true,
 	}, nil
 }
+
+// keyOperation finds the destructive key operation of the transaction from its parsed program, a transaction adding
+// and revoking keys rotates them. Empty if the transaction doesn't revoke keys or can't be parsed, in which case
+// it is rejected by the network anyway.
+//
+// Any invoked revoke member is treated as a key revocation, so keys referenced through a variable are guarded too.
+func keyOperation(code []byte) string {
+	program, err := parser.ParseProgram(nil, code, parser.Config{})
+	if err != nil {
+		return ""
+	}
+
+	var revokes, adds bool
+	ast.Inspect(program, func(element ast.Element) bool {
+		invocation, ok := element.(*ast.InvocationExpression)
+		if !ok {
+			return true
+		}
+		member, ok := invocation.InvokedExpression.(*ast.MemberExpression)
+		if !ok {
+			return true
+		}
+
+		switch member.Identifier.Identifier {
+		case "revoke", "removePublicKey":
+			revokes = true
+		case "addPublicKey":
+			adds = true
+		case "add":
+			if keys, ok := member.Expression.(*ast.MemberExpression); ok && keys.Identifier.Identifier == "keys" {
+				adds = true
+			}
+		}
+		return true
+	})
+
+	switch {
+	case !revokes:
+		return ""
+	case adds:
+		return settings.PolicyKeyRotate
+	default:
+		return settings.PolicyKeyRevoke
+	}
+}
+
+// guardKeyOperation applies the mainnet policy to the transaction revoking or rotating keys of the authorizers.
+//
+// The proposer and payer using the same account as an authorizer sign with the same key, so they are
+// co-signed as well with the co-sign policy.
+func guardKeyOperation(
+	code []byte,
+	globalFlags command.GlobalFlags,
+	flow flowkit.Services,
+	state *flowkit.State,
+	proposer *accounts.Account,
+	payer *accounts.Account,
+	authorizers []accounts.Account,
+) error {
+	operation := keyOperation(code)
+	if operation == "" {
+		return nil
+	}
+
+	description := "revoke keys"
+	if operation == settings.PolicyKeyRotate {
+		description = "rotate keys"
+	}
+
+	for i := range authorizers {
+		authorizer := &authorizers[i]
+		err := command.GuardMainnet(operation, fmt.Sprintf("%s of account %s", description, authorizer.Name), globalFlags, flow, state, authorizer)
+		if err != nil {
+			return err
+		}
+
+		for _, account := range []*accounts.Account{proposer, payer} {
+			if account.Name == authorizer.Name {
+				account.Key = authorizer.Key
+			}
+		}
+	}
+	return nil
+}
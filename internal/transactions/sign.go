@@ -115,7 +115,7 @@ func sign(
 
 	for _, signer := range signers {
 		if !globalFlags.Yes && !util.ApproveTransactionForSigningPrompt(tx.FlowTransaction()) {
-			return nil, fmt.Errorf("transaction was not approved for signing")
+			return nil, command.WithExitCode(command.ExitAborted, fmt.Errorf("transaction was not approved for signing"))
 		}
 
 		signed, err = flow.SignTransactionPayload(context.Background(), signer, payload)
@@ -53,6 +53,16 @@ type transactionResult struct {
 	tx      *flow.Transaction
 	include []string
 	exclude []string
+	sent    bool // the transaction was sent by the command, so an execution error fails the command
+}
+
+var _ command.ExitCodeResult = &transactionResult{}
+
+func (r *transactionResult) ExitCode() int {
+	if r.sent && r.result != nil && r.result.Error != nil {
+		return command.ExitTransactionReverted
+	}
+	return command.ExitSuccess
 }
 
 func (r *transactionResult) JSON() any {
@@ -20,6 +20,7 @@ package transactions
 
 import (
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/onflow/flow-cli/flowkit/tests"
 	"github.com/onflow/flow-cli/flowkit/transactions"
 	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/settings"
 	"github.com/onflow/flow-cli/internal/util"
 )
 
@@ -159,6 +161,37 @@ func Test_Send(t *testing.T) {
 		assert.NotNil(t, result)
 	})
 
+	t.Run("Success payer service", func(t *testing.T) {
+		inArgs := []string{tests.TransactionArgString.Filename, "test"}
+		network := config.TestnetNetwork
+		network.Payer = config.PayerService{URL: "https://payer.example.com", Address: flow.HexToAddress("0x02")}
+		srv.Network.Return(network)
+		flags.PayerService = true
+
+		srv.SendTransaction.Run(func(args mock.Arguments) {
+			roles := args.Get(1).(transactions.AccountRoles)
+			acc := config.DefaultEmulator.ServiceAccount
+			assert.Equal(t, flow.HexToAddress("0x02"), roles.Payer.Address)
+			assert.IsType(t, &accounts.PayerServiceKey{}, roles.Payer.Key)
+			assert.Equal(t, acc, roles.Proposer.Name)
+			assert.Equal(t, acc, roles.Authorizers[0].Name)
+		}).Return(nil, nil, nil)
+
+		result, err := send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+
+		flags.Payer = config.DefaultEmulator.ServiceAccount
+		_, err = send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "payer flag cannot be combined with payer-service flag")
+		flags.Payer = "" // reset
+
+		srv.Network.Return(config.EmulatorNetwork)
+		_, err = send(inArgs, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
+		assert.EqualError(t, err, "network emulator has no payer service configured")
+		flags.PayerService = false // reset
+	})
+
 	t.Run("Fail non-existing account", func(t *testing.T) {
 		flags.Proposer = "invalid"
 		_, err := send([]string{""}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
@@ -193,6 +226,43 @@ func Test_Send(t *testing.T) {
 		_, err := send([]string{"invalid"}, command.GlobalFlags{}, util.NoLogger, srv.Mock, state)
 		assert.EqualError(t, err, "error loading transaction file: open invalid: file does not exist")
 	})
+
+	t.Run("Fail guarding key revocation on mainnet", func(t *testing.T) {
+		srv, state, _ := util.TestMocks(t)
+		srv.Network.Return(config.MainnetNetwork)
+		code := []byte(`transaction { prepare(signer: AuthAccount) { signer.keys.revoke(keyIndex: 0) } }`)
+		sendFlags := Flags{Signer: config.DefaultEmulator.ServiceAccount}
+
+		_, err := SendTransaction(code, nil, "", command.GlobalFlags{YesIAmSure: "other"}, srv.Mock, state, sendFlags)
+		assert.EqualError(t, err, "the --yes-i-am-sure flag value other doesn't match the account name emulator-account")
+		srv.Mock.AssertNotCalled(t, "SendTransaction")
+
+		srv.SendTransaction.Return(nil, nil, nil)
+		_, err = SendTransaction(code, nil, "", command.GlobalFlags{YesIAmSure: "emulator-account"}, srv.Mock, state, sendFlags)
+		assert.NoError(t, err)
+	})
+}
+
+func Test_KeyOperation(t *testing.T) {
+	tests := map[string]string{
+		`transaction { prepare(signer: AuthAccount) { signer.keys.add(publicKey: key, hashAlgorithm: HashAlgorithm.SHA3_256, weight: 1000.0) } }`: "",
+		`transaction { prepare(signer: AuthAccount) { signer.contracts.remove(name: "revoke") } }`:                                                "",
+		`transaction { prepare(signer: AuthAccount) { signer.keys.revoke(keyIndex: 0) } }`:                                                        settings.PolicyKeyRevoke,
+		`transaction { prepare(signer: AuthAccount) { signer.keys .revoke (keyIndex: 0) } }`:                                                      settings.PolicyKeyRevoke,
+		`transaction { prepare(signer: AuthAccount) { signer.removePublicKey(0) } }`:                                                              settings.PolicyKeyRevoke,
+		`transaction { prepare(signer: AuthAccount) { let keys = signer.keys
+			keys.revoke(keyIndex: 0) } }`: settings.PolicyKeyRevoke,
+		`transaction { prepare(signer: AuthAccount) {
+			signer.keys.add(publicKey: key, hashAlgorithm: HashAlgorithm.SHA3_256, weight: 1000.0)
+			signer.keys
+				.revoke(keyIndex: 0)
+		} }`: settings.PolicyKeyRotate,
+		`transaction { prepare(signer: AuthAccount) { signer.addPublicKey(key); signer.removePublicKey(0) } }`: settings.PolicyKeyRotate,
+	}
+
+	for code, operation := range tests {
+		assert.Equal(t, operation, keyOperation([]byte(code)), code)
+	}
 }
 
 func Test_SendSigned(t *testing.T) {
@@ -391,4 +461,13 @@ Payload (hidden, use --include payload)`, "\n"), result.String())
 			"status":  "SEALED",
 		}, result.JSON())
 	})
+
+	t.Run("Exit code reverted", func(t *testing.T) {
+		reverted := *txResult
+		reverted.Error = fmt.Errorf("execution reverted")
+
+		assert.Equal(t, command.ExitSuccess, (&transactionResult{tx: tx, result: txResult, sent: true}).ExitCode())
+		assert.Equal(t, command.ExitSuccess, (&transactionResult{tx: tx, result: &reverted}).ExitCode())
+		assert.Equal(t, command.ExitTransactionReverted, (&transactionResult{tx: tx, result: &reverted, sent: true}).ExitCode())
+	})
 }
@@ -0,0 +1,165 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package trust
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var addKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "add-key <name> <public key>",
+		Short: "Trust the ed25519 public key signing remote content",
+		Long: `Trust the ed25519 public key signing remote content. Content from the sources signed by the key
+must have a valid signature, by default the key signs all sources.`,
+		Example: "flow trust add-key onflow 3f0f5c...e1a2 --source https://raw.githubusercontent.com/onflow/",
+		Args:    cobra.ExactArgs(2),
+	},
+	Flags: &addKeyFlags,
+	Run:   addKey,
+}
+
+type flagsAddKey struct {
+	Sources []string `default:"" flag:"source" info:"URL prefix of the content signed by the key, all content by default"`
+}
+
+var addKeyFlags = flagsAddKey{}
+
+var removeKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "remove-key <name>",
+		Short:   "Stop trusting the public key",
+		Example: "flow trust remove-key onflow",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &struct{}{},
+	Run:   removeKey,
+}
+
+var generateKeyCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "generate-key",
+		Short:   "Generate an ed25519 key pair for signing published content",
+		Example: "flow trust generate-key",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	Run:   generateKey,
+}
+
+func addKey(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	file, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := file.AddKey(args[0], args[1], addKeyFlags.Sources); err != nil {
+		return nil, command.WithExitCode(command.ExitValidationError, err)
+	}
+	if err := file.Save(); err != nil {
+		return nil, err
+	}
+
+	return &result{result: fmt.Sprintf("Key %s is trusted, trust file %s was updated.", args[0], FilePath())}, nil
+}
+
+func removeKey(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	file, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if !file.RemoveKey(args[0]) {
+		return nil, fmt.Errorf("key %s is not trusted", args[0])
+	}
+	if err := file.Save(); err != nil {
+		return nil, err
+	}
+
+	return &result{result: fmt.Sprintf("Key %s is no longer trusted, trust file %s was updated.", args[0], FilePath())}, nil
+}
+
+func generateKey(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate the key: %w", err)
+	}
+
+	return &keyResult{
+		privateKey: hex.EncodeToString(privateKey.Seed()),
+		publicKey:  hex.EncodeToString(publicKey),
+	}, nil
+}
+
+type keyResult struct {
+	privateKey string
+	publicKey  string
+}
+
+func (k *keyResult) JSON() any {
+	return map[string]any{
+		"private": k.privateKey,
+		"public":  k.publicKey,
+	}
+}
+
+func (k *keyResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "%s Store private key safely and don't share with anyone! \n", output.StopEmoji())
+	_, _ = fmt.Fprintf(writer, "Private Key \t %s \n", k.privateKey)
+	_, _ = fmt.Fprintf(writer, "Public Key \t %s \n", k.publicKey)
+	_ = writer.Flush()
+
+	_, _ = fmt.Fprintf(&b, "\nSign published content with 'flow trust sign', users trust it with 'flow trust add-key <name> %s'.", k.publicKey)
+	return b.String()
+}
+
+func (k *keyResult) Oneliner() string {
+	return fmt.Sprintf("Private Key: %s, Public Key: %s", k.privateKey, k.publicKey)
+}
@@ -0,0 +1,135 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package trust
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+var listCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "list",
+		Short:   "List the trusted keys and pinned checksums",
+		Example: "flow trust list",
+		Args:    cobra.NoArgs,
+	},
+	Flags: &struct{}{},
+	Run:   list,
+}
+
+var strictCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:       "strict <on|off>",
+		Short:     "Reject remote content which isn't verified by a pinned checksum or a trusted signature",
+		Example:   "flow trust strict on",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{"on", "off"},
+	},
+	Flags: &struct{}{},
+	Run:   strict,
+}
+
+func list(
+	_ []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	file, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &listResult{file: file, path: FilePath()}, nil
+}
+
+func strict(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	file, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	file.Strict = args[0] == "on"
+	if err := file.Save(); err != nil {
+		return nil, err
+	}
+
+	mode := "Unverified remote content is used unless trusted keys sign its source."
+	if file.Strict {
+		mode = "Unverified remote content is rejected."
+	}
+	return &result{result: fmt.Sprintf("%s Trust file %s was updated.", mode, FilePath())}, nil
+}
+
+type listResult struct {
+	file *File
+	path string
+}
+
+func (l *listResult) JSON() any {
+	return l.file
+}
+
+func (l *listResult) String() string {
+	var b bytes.Buffer
+	writer := util.CreateTabWriter(&b)
+
+	_, _ = fmt.Fprintf(writer, "Trust File\t%s\n", l.path)
+	_, _ = fmt.Fprintf(writer, "Strict\t%t\n", l.file.Strict)
+
+	_, _ = fmt.Fprintf(writer, "\nTrusted Keys\t%d\n", len(l.file.Keys))
+	for _, key := range l.file.Keys {
+		sources := "all sources"
+		if len(key.Sources) > 0 {
+			sources = strings.Join(key.Sources, ", ")
+		}
+		_, _ = fmt.Fprintf(writer, "  %s\t%s\t%s\n", key.Name, key.PublicKey, sources)
+	}
+
+	urls := maps.Keys(l.file.Checksums)
+	sort.Strings(urls)
+	_, _ = fmt.Fprintf(writer, "\nPinned Checksums\t%d\n", len(urls))
+	for _, url := range urls {
+		_, _ = fmt.Fprintf(writer, "  %s\t%s\n", url, l.file.Checksums[url])
+	}
+
+	_ = writer.Flush()
+	return b.String()
+}
+
+func (l *listResult) Oneliner() string {
+	return fmt.Sprintf("Strict: %t, Trusted Keys: %d, Pinned Checksums: %d", l.file.Strict, len(l.file.Keys), len(l.file.Checksums))
+}
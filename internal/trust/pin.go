@@ -0,0 +1,133 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package trust
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/config"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+type flagsPin struct {
+	Checksum string `default:"" flag:"checksum" info:"SHA-256 checksum of the content, the content is fetched to compute it if not provided"`
+}
+
+var pinFlags = flagsPin{}
+
+var pinCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "pin <url>",
+		Short: "Pin the checksum of remote content so only the exact content is trusted",
+		Example: `flow trust pin https://example.com/flow.core.json
+flow trust pin github:onflow/configs/core.json@v1.0.0 --checksum 9f86d0...0a08`,
+		Args: cobra.ExactArgs(1),
+	},
+	Flags: &pinFlags,
+	Run:   pin,
+}
+
+var unpinCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:     "unpin <url>",
+		Short:   "Remove the pinned checksum of remote content",
+		Example: "flow trust unpin https://example.com/flow.core.json",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &struct{}{},
+	Run:   unpin,
+}
+
+func pin(
+	args []string,
+	_ command.GlobalFlags,
+	logger output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	url, err := resolveURL(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	checksum := strings.ToLower(strings.TrimPrefix(pinFlags.Checksum, "sha256:"))
+	if checksum == "" {
+		logger.StartProgress(fmt.Sprintf("Fetching %s...", url))
+		data, err := fetch(url)
+		logger.StopProgress()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch %s: %w", url, err)
+		}
+		checksum = Checksum(data)
+	} else if len(checksum) != 64 || strings.Trim(checksum, "0123456789abcdef") != "" {
+		return nil, command.WithExitCode(command.ExitValidationError, fmt.Errorf("invalid checksum %s, expected hex encoded SHA-256 checksum", pinFlags.Checksum))
+	}
+
+	file, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	file.Checksums[url] = checksum
+	if err := file.Save(); err != nil {
+		return nil, err
+	}
+
+	return &result{result: fmt.Sprintf("Checksum %s of %s is pinned, trust file %s was updated.", checksum, url, FilePath())}, nil
+}
+
+func unpin(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	_ flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	url, err := resolveURL(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := file.Checksums[url]; !ok {
+		return nil, fmt.Errorf("no checksum of %s is pinned", url)
+	}
+	delete(file.Checksums, url)
+	if err := file.Save(); err != nil {
+		return nil, err
+	}
+
+	return &result{result: fmt.Sprintf("Checksum of %s is no longer pinned, trust file %s was updated.", url, FilePath())}, nil
+}
+
+// resolveURL resolves the location to the URL the content is fetched from, e.g. for git configuration includes.
+func resolveURL(location string) (string, error) {
+	if !config.IsRemoteInclude(location) {
+		return "", command.WithExitCode(command.ExitValidationError, fmt.Errorf("invalid URL %s, the URL must start with http:// or https://", location))
+	}
+	return config.ResolveIncludeURL(location)
+}
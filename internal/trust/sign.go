@@ -0,0 +1,97 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package trust
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/flowkit"
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+)
+
+// PrivateKeyEnv is the environment variable holding the private key signing published content.
+const PrivateKeyEnv = "FLOW_TRUST_PRIVATE_KEY"
+
+type flagsSign struct {
+	PrivateKey string `default:"" flag:"private-key" info:"Hex encoded ed25519 private key seed, read from FLOW_TRUST_PRIVATE_KEY if not provided"`
+}
+
+var signFlags = flagsSign{}
+
+var signCommand = &command.Command{
+	Cmd: &cobra.Command{
+		Use:   "sign <file>",
+		Short: "Sign the file published for others to fetch, the signature is written next to it",
+		Long: `Sign the file published for others to fetch. The signature is written to the file with the .sig suffix,
+which must be published next to the file so it is available at the file URL with the .sig suffix.`,
+		Example: "flow trust sign flow.core.json",
+		Args:    cobra.ExactArgs(1),
+	},
+	Flags: &signFlags,
+	Run:   sign,
+}
+
+func sign(
+	args []string,
+	_ command.GlobalFlags,
+	_ output.Logger,
+	reader flowkit.ReaderWriter,
+	_ flowkit.Services,
+) (command.Result, error) {
+	seed := signFlags.PrivateKey
+	if seed == "" {
+		seed = os.Getenv(PrivateKeyEnv)
+	}
+	if seed == "" {
+		return nil, command.WithExitCode(command.ExitValidationError, fmt.Errorf(
+			"missing private key, use the --private-key flag or the %s environment variable, generate one with 'flow trust generate-key'",
+			PrivateKeyEnv,
+		))
+	}
+
+	decoded, err := hex.DecodeString(strings.TrimPrefix(seed, "0x"))
+	if err != nil || len(decoded) != ed25519.SeedSize {
+		return nil, command.WithExitCode(command.ExitValidationError, fmt.Errorf("invalid private key, expected %d hex encoded bytes", ed25519.SeedSize))
+	}
+	privateKey := ed25519.NewKeyFromSeed(decoded)
+
+	data, err := reader.ReadFile(args[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the file: %w", err)
+	}
+
+	signaturePath := args[0] + signatureSuffix
+	signature := hex.EncodeToString(ed25519.Sign(privateKey, data))
+	if err := reader.WriteFile(signaturePath, []byte(signature+"\n"), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write the signature: %w", err)
+	}
+
+	return &result{result: fmt.Sprintf(
+		"Signature of %s was written to %s, publish it next to the file.\nPublic key to trust: %s",
+		args[0],
+		signaturePath,
+		hex.EncodeToString(privateKey.Public().(ed25519.PublicKey)),
+	)}, nil
+}
@@ -0,0 +1,330 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+// Package trust implements verification of the remote content fetched by the CLI against the trust file
+// and commands managing the trusted keys and checksums.
+//
+// Remote content is trusted if its SHA-256 checksum is pinned in the trust file, or if it's signed by
+// one of the trusted ed25519 keys. The signature is fetched from the content URL with the ".sig" suffix
+// added to the path, e.g. https://example.com/core.json.sig, and contains the hex encoded signature.
+//
+// Keys can be limited to the sources they sign, content from a source with trusted keys must be signed
+// by one of them.
+package trust
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/onflow/flow-cli/internal/settings"
+)
+
+var Cmd = &cobra.Command{
+	Use:              "trust",
+	Short:            "Manage the keys and checksums trusted when fetching remote content",
+	TraverseChildren: true,
+	GroupID:          "security",
+}
+
+func init() {
+	addKeyCommand.AddToParent(Cmd)
+	removeKeyCommand.AddToParent(Cmd)
+	pinCommand.AddToParent(Cmd)
+	unpinCommand.AddToParent(Cmd)
+	strictCommand.AddToParent(Cmd)
+	listCommand.AddToParent(Cmd)
+	generateKeyCommand.AddToParent(Cmd)
+	signCommand.AddToParent(Cmd)
+}
+
+// FileEnv is the environment variable overriding the trust file path.
+const FileEnv = "FLOW_TRUST_FILE"
+
+const signatureSuffix = ".sig"
+
+// Key is a trusted ed25519 public key signing remote content.
+type Key struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"publicKey"`
+	// Sources are the URL prefixes of the content signed by the key, the key signs all content if empty.
+	Sources []string `json:"sources,omitempty"`
+}
+
+// signs checks whether the key signs the content fetched from the URL.
+func (k Key) signs(url string) bool {
+	if len(k.Sources) == 0 {
+		return true
+	}
+	for _, source := range k.Sources {
+		if strings.HasPrefix(url, source) {
+			return true
+		}
+	}
+	return false
+}
+
+// File is the trust file listing the trusted keys and the pinned checksums of remote content by URL.
+type File struct {
+	// Strict rejects remote content which is not verified by a pinned checksum or a trusted signature.
+	Strict    bool              `json:"strict"`
+	Keys      []Key             `json:"keys"`
+	Checksums map[string]string `json:"checksums"`
+}
+
+// FilePath gets the trust file path, by default the file is kept next to the global settings.
+func FilePath() string {
+	if path := os.Getenv(FileEnv); path != "" {
+		return path
+	}
+	return filepath.Join(settings.FileDir(), "trust.json")
+}
+
+// Load reads the trust file, an empty trust file is returned if it doesn't exist.
+func Load() (*File, error) {
+	file := &File{Checksums: make(map[string]string)}
+
+	data, err := os.ReadFile(FilePath())
+	if errors.Is(err, os.ErrNotExist) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the trust file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("failed to parse the trust file %s: %w", FilePath(), err)
+	}
+	if file.Checksums == nil {
+		file.Checksums = make(map[string]string)
+	}
+	return file, nil
+}
+
+// Save writes the trust file.
+func (f *File) Save() error {
+	sort.Slice(f.Keys, func(i, j int) bool {
+		return f.Keys[i].Name < f.Keys[j].Name
+	})
+
+	data, err := json.MarshalIndent(f, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(FilePath()), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(FilePath(), append(data, '\n'), 0600)
+}
+
+// AddKey adds the trusted key signing the sources, or all content if no sources are provided,
+// an existing key with the same name is replaced.
+func (f *File) AddKey(name string, publicKey string, sources []string) error {
+	if _, err := decodePublicKey(publicKey); err != nil {
+		return err
+	}
+
+	f.RemoveKey(name)
+	f.Keys = append(f.Keys, Key{Name: name, PublicKey: strings.TrimPrefix(publicKey, "0x"), Sources: sources})
+	return nil
+}
+
+// keysFor returns the trusted keys signing the content fetched from the URL.
+func (f *File) keysFor(url string) []Key {
+	keys := make([]Key, 0)
+	for _, key := range f.Keys {
+		if key.signs(url) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// RemoveKey removes the trusted key, it returns false if the key doesn't exist.
+func (f *File) RemoveKey(name string) bool {
+	for i, key := range f.Keys {
+		if key.Name == name {
+			f.Keys = append(f.Keys[:i], f.Keys[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Verify verifies the content fetched from the URL is trusted.
+//
+// Content with a pinned checksum must match it, otherwise if trusted keys sign the source the signature is fetched
+// and must be valid for one of them. Content from sources without pinned checksums or trusted keys is only
+// rejected in strict mode.
+func (f *File) Verify(url string, data []byte) error {
+	if checksum, ok := f.Checksums[url]; ok {
+		if actual := Checksum(data); actual != checksum {
+			return fmt.Errorf("checksum %s doesn't match the pinned checksum %s", actual, checksum)
+		}
+		return nil
+	}
+
+	if keys := f.keysFor(url); len(keys) > 0 {
+		signatureURL, err := SignatureURL(url)
+		if err != nil {
+			return err
+		}
+
+		signature, err := fetchSignature(signatureURL)
+		if err != nil {
+			return fmt.Errorf("failed to fetch the signature %s: %w", signatureURL, err)
+		}
+		if signature == nil {
+			return fmt.Errorf("signature %s not found, content of %s must be signed by one of the trusted keys", signatureURL, url)
+		}
+
+		name, err := verifySignature(keys, data, signature)
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return fmt.Errorf("signature %s is not valid for any of the trusted keys", signatureURL)
+		}
+		return nil
+	}
+
+	if f.Strict {
+		return fmt.Errorf(
+			"content is not verified by a pinned checksum or a signature of a trusted key, pin it with 'flow trust pin %s'",
+			url,
+		)
+	}
+	return nil
+}
+
+// verifySignature returns the name of the key the signature is valid for, empty if none.
+func verifySignature(keys []Key, data []byte, signature []byte) (string, error) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(string(bytes.TrimSpace(signature)), "0x"))
+	if err != nil || len(decoded) != ed25519.SignatureSize {
+		return "", fmt.Errorf("invalid signature, expected %d hex encoded bytes", ed25519.SignatureSize)
+	}
+
+	for _, key := range keys {
+		publicKey, err := decodePublicKey(key.PublicKey)
+		if err != nil {
+			return "", fmt.Errorf("invalid trusted key %s: %w", key.Name, err)
+		}
+		if ed25519.Verify(publicKey, data, decoded) {
+			return key.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// Verify verifies the content fetched from the URL against the trust file.
+func Verify(url string, data []byte) error {
+	file, err := Load()
+	if err != nil {
+		return err
+	}
+	return file.Verify(url, data)
+}
+
+// Checksum returns the hex encoded SHA-256 checksum of the content.
+func Checksum(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// SignatureURL returns the URL of the content signature by adding the suffix to the URL path.
+func SignatureURL(url string) (string, error) {
+	u, err := neturl.Parse(url)
+	if err != nil {
+		return "", fmt.Errorf("invalid URL %s: %w", url, err)
+	}
+
+	u.Path += signatureSuffix
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+func decodePublicKey(publicKey string) (ed25519.PublicKey, error) {
+	decoded, err := hex.DecodeString(strings.TrimPrefix(publicKey, "0x"))
+	if err != nil || len(decoded) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid ed25519 public key, expected %d hex encoded bytes", ed25519.PublicKeySize)
+	}
+	return decoded, nil
+}
+
+var client = &http.Client{Timeout: 10 * time.Second}
+
+// fetch gets the content from the URL.
+func fetch(url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// fetchSignature gets the signature from the URL, nil if the content isn't signed.
+func fetchSignature(url string) ([]byte, error) {
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+type result struct {
+	result string
+}
+
+func (r *result) JSON() any {
+	return map[string]any{"result": r.result}
+}
+
+func (r *result) String() string {
+	return r.result
+}
+
+func (r *result) Oneliner() string {
+	return strings.ReplaceAll(r.result, "\n", " ")
+}
@@ -0,0 +1,147 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package trust
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/output"
+	"github.com/onflow/flow-cli/internal/command"
+	"github.com/onflow/flow-cli/internal/util"
+)
+
+func Test_Verify(t *testing.T) {
+	content := []byte(`{"networks":{"testnet":"access.devnet.nodes.onflow.org:9000"}}`)
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	signatures := map[string][]byte{
+		"/signed.json.sig":  []byte(hex.EncodeToString(ed25519.Sign(privateKey, content)) + "\n"),
+		"/invalid.json.sig": []byte(hex.EncodeToString(ed25519.Sign(privateKey, []byte("other"))) + "\n"),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature, ok := signatures[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(signature)
+	}))
+	defer server.Close()
+
+	t.Run("Pinned", func(t *testing.T) {
+		file := &File{Checksums: map[string]string{server.URL + "/core.json": Checksum(content)}}
+		assert.NoError(t, file.Verify(server.URL+"/core.json", content))
+
+		err := file.Verify(server.URL+"/core.json", []byte("changed"))
+		assert.ErrorContains(t, err, "doesn't match the pinned checksum")
+	})
+
+	t.Run("Signed", func(t *testing.T) {
+		file := &File{Strict: true}
+		require.NoError(t, file.AddKey("publisher", hex.EncodeToString(publicKey), nil))
+
+		assert.NoError(t, file.Verify(server.URL+"/signed.json", content))
+
+		err := file.Verify(server.URL+"/invalid.json", content)
+		assert.ErrorContains(t, err, "is not valid for any of the trusted keys")
+
+		err = file.Verify(server.URL+"/unsigned.json", content)
+		assert.ErrorContains(t, err, "must be signed by one of the trusted keys")
+	})
+
+	t.Run("Not Strict", func(t *testing.T) {
+		file := &File{}
+		assert.NoError(t, file.Verify(server.URL+"/unsigned.json", content))
+	})
+
+	t.Run("Not Strict Signed Source", func(t *testing.T) {
+		file := &File{}
+		require.NoError(t, file.AddKey("publisher", hex.EncodeToString(publicKey), []string{server.URL + "/"}))
+
+		assert.NoError(t, file.Verify(server.URL+"/signed.json", content))
+
+		err := file.Verify(server.URL+"/unsigned.json", content)
+		assert.ErrorContains(t, err, "must be signed by one of the trusted keys")
+
+		err = file.Verify(server.URL+"/invalid.json", content)
+		assert.ErrorContains(t, err, "is not valid for any of the trusted keys")
+
+		assert.NoError(t, file.Verify("https://example.com/unsigned.json", content))
+	})
+
+	t.Run("Invalid Key", func(t *testing.T) {
+		file := &File{}
+		assert.EqualError(t, file.AddKey("publisher", "0x1234", nil), "invalid ed25519 public key, expected 32 hex encoded bytes")
+	})
+}
+
+func Test_SignatureURL(t *testing.T) {
+	url, err := SignatureURL("https://example.com/configs/core.json")
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/configs/core.json.sig", url)
+
+	url, err = SignatureURL("https://flix.flow.com/v1/templates?name=transfer-flow")
+	require.NoError(t, err)
+	assert.Equal(t, "https://flix.flow.com/v1/templates.sig?name=transfer-flow", url)
+}
+
+func Test_Commands(t *testing.T) {
+	t.Setenv(FileEnv, filepath.Join(t.TempDir(), "trust.json"))
+	srv, _, rw := util.TestMocks(t)
+
+	generated, err := generateKey(nil, command.GlobalFlags{}, output.NewStdoutLogger(output.NoneLog), rw, srv.Mock)
+	require.NoError(t, err)
+	key := generated.(*keyResult)
+
+	_, err = addKey([]string{"publisher", key.publicKey}, command.GlobalFlags{}, output.NewStdoutLogger(output.NoneLog), rw, srv.Mock)
+	require.NoError(t, err)
+
+	content := []byte(`{"contracts":{}}`)
+	require.NoError(t, rw.WriteFile("core.json", content, 0644))
+	signFlags.PrivateKey = key.privateKey
+	_, err = sign([]string{"core.json"}, command.GlobalFlags{}, output.NewStdoutLogger(output.NoneLog), rw, srv.Mock)
+	require.NoError(t, err)
+
+	signature, err := rw.ReadFile("core.json.sig")
+	require.NoError(t, err)
+
+	file, err := Load()
+	require.NoError(t, err)
+	require.Len(t, file.Keys, 1)
+	name, err := verifySignature(file.Keys, content, signature)
+	require.NoError(t, err)
+	assert.Equal(t, "publisher", name)
+
+	pinFlags.Checksum = Checksum(content)
+	_, err = pin([]string{"github:onflow/configs/core.json@v1.0.0"}, command.GlobalFlags{}, output.NewStdoutLogger(output.NoneLog), rw, srv.Mock)
+	require.NoError(t, err)
+
+	file, err = Load()
+	require.NoError(t, err)
+	assert.Equal(t, Checksum(content), file.Checksums["https://raw.githubusercontent.com/onflow/configs/v1.0.0/core.json"])
+}
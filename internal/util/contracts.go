@@ -0,0 +1,176 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+
+	flowsdk "github.com/onflow/flow-go-sdk"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+// CoreContract defines a standard contract that is already deployed on Flow networks.
+type CoreContract struct {
+	Name      string
+	InfoLink  string
+	Addresses map[string]flowsdk.Address // addresses by network name
+}
+
+// Address returns the address of the core contract on the network or an error if not deployed there.
+func (c CoreContract) Address(network string) (flowsdk.Address, error) {
+	address, ok := c.Addresses[network]
+	if !ok {
+		return flowsdk.EmptyAddress, fmt.Errorf("core contract %s is not available on network %s", c.Name, network)
+	}
+
+	return address, nil
+}
+
+// CoreContracts is the registry of standard contracts and their addresses on each network.
+var CoreContracts = []CoreContract{
+	{
+		Name:     "FungibleToken",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/fungible-token",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xee82856bf20e2aa6"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x9a0766d93b6608b7"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0xf233dcee88fe0abe"),
+		},
+	},
+	{
+		Name:     "FungibleTokenMetadataViews",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/fungible-token",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xee82856bf20e2aa6"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x9a0766d93b6608b7"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0xf233dcee88fe0abe"),
+		},
+	},
+	{
+		Name:     "FlowToken",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/flow-token",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0x0ae53cb6e3f42a79"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x7e60df042a9c0868"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0x1654653399040a61"),
+		},
+	},
+	{
+		Name:     "FlowFees",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/flow-fees",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xe5a8b7f23e8b548f"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x912d5440f7e3769e"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0xf919ee77447b7497"),
+		},
+	},
+	{
+		Name:     "FlowServiceAccount",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/service-account",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xf8d6e0586b0a20c7"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x8c5303eaa26202d6"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0xe467b9dd11fa00df"),
+		},
+	},
+	{
+		Name:     "FlowStorageFees",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/service-account",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xf8d6e0586b0a20c7"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x8c5303eaa26202d6"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0xe467b9dd11fa00df"),
+		},
+	},
+	{
+		Name:     "FlowIDTableStaking",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/staking-contract-reference",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xf8d6e0586b0a20c7"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x9eca2b38b18b5dfe"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0x8624b52f9ddcd04a"),
+		},
+	},
+	{
+		Name:     "FlowEpoch",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/epoch-contract-reference",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xf8d6e0586b0a20c7"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x9eca2b38b18b5dfe"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0x8624b52f9ddcd04a"),
+		},
+	},
+	{
+		Name:     "FlowClusterQC",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/epoch-contract-reference",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xf8d6e0586b0a20c7"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x9eca2b38b18b5dfe"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0x8624b52f9ddcd04a"),
+		},
+	},
+	{
+		Name:     "FlowDKG",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/epoch-contract-reference",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xf8d6e0586b0a20c7"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x9eca2b38b18b5dfe"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0x8624b52f9ddcd04a"),
+		},
+	},
+	{
+		Name:     "NonFungibleToken",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/non-fungible-token",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xf8d6e0586b0a20c7"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x631e88ae7f1d7c20"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0x1d7e57aa55817448"),
+		},
+	},
+	{
+		Name:     "MetadataViews",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/nft-metadata",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xf8d6e0586b0a20c7"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x631e88ae7f1d7c20"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0x1d7e57aa55817448"),
+		},
+	},
+	{
+		Name:     "ViewResolver",
+		InfoLink: "https://developers.flow.com/flow/core-contracts/nft-metadata",
+		Addresses: map[string]flowsdk.Address{
+			config.EmulatorNetwork.Name: flowsdk.HexToAddress("0xf8d6e0586b0a20c7"),
+			config.TestnetNetwork.Name:  flowsdk.HexToAddress("0x631e88ae7f1d7c20"),
+			config.MainnetNetwork.Name:  flowsdk.HexToAddress("0x1d7e57aa55817448"),
+		},
+	},
+}
+
+// CoreContractByName returns the core contract from the registry or nil if the contract is not a core contract.
+func CoreContractByName(name string) *CoreContract {
+	for _, c := range CoreContracts {
+		if c.Name == name {
+			return &c
+		}
+	}
+
+	return nil
+}
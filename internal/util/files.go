@@ -0,0 +1,97 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/onflow/flow-cli/flowkit"
+)
+
+// Cadence files are found in directories by this extension.
+const cadenceExt = ".cdc"
+
+// Directory created by the project setup containing contracts, scripts, transactions and tests.
+const cadenceDir = "cadence"
+
+// CadencePaths returns the directories of the configured contracts and the cadence directory if it exists,
+// or the current directory if there are none. The state is optional.
+func CadencePaths(state *flowkit.State) []string {
+	var paths []string
+	if state != nil {
+		for _, contract := range *state.Contracts() {
+			paths = append(paths, filepath.Dir(contract.Location))
+		}
+	}
+	if info, err := os.Stat(cadenceDir); err == nil && info.IsDir() {
+		paths = append(paths, cadenceDir)
+	}
+
+	if len(paths) == 0 {
+		return []string{"."}
+	}
+	return paths
+}
+
+// FindCadenceFiles returns the provided files and the Cadence files found recursively in the provided directories,
+// sorted and without duplicates. Hidden directories are skipped.
+func FindCadenceFiles(paths []string) ([]string, error) {
+	found := make(map[string]struct{})
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", p, err)
+		}
+		if !info.IsDir() {
+			found[filepath.Clean(p)] = struct{}{}
+			continue
+		}
+
+		err = filepath.WalkDir(p, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if file != p && strings.HasPrefix(entry.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(file) == cadenceExt {
+				found[filepath.Clean(file)] = struct{}{}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error finding Cadence files in %s: %w", p, err)
+		}
+	}
+
+	files := make([]string, 0, len(found))
+	for file := range found {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,123 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// KeychainSecret reads the secret of the service stored in the OS keychain under the attribute value,
+// e.g. the passphrase stored under the project directory.
+func KeychainSecret(service string, attribute string, value string) (string, error) {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "find-generic-password", "-s", service, "-a", value, "-w")
+	case "linux":
+		cmd = exec.Command("secret-tool", "lookup", "service", service, attribute, value)
+	default:
+		return "", fmt.Errorf("keychain is not supported on %s", runtime.GOOS)
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// SaveKeychainSecret stores the secret of the service in the OS keychain under the attribute value.
+func SaveKeychainSecret(service string, attribute string, value string, label string, secret string) error {
+	cmd, err := saveKeychainCommand(runtime.GOOS, service, attribute, value, label, secret)
+	if err != nil {
+		return err
+	}
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return keychainError(out, err)
+	}
+	// security doesn't exit with an error in the interactive mode when the command fails, it only prints it
+	if msg := strings.TrimSpace(string(out)); runtime.GOOS == "darwin" && msg != "" {
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// saveKeychainCommand creates the command storing the secret in the keychain of the OS, the secret is
+// passed on the standard input so it is never visible in the process arguments.
+func saveKeychainCommand(goos string, service string, attribute string, value string, label string, secret string) (*exec.Cmd, error) {
+	switch goos {
+	case "darwin":
+		if strings.ContainsAny(service+value+secret, "\r\n") {
+			return nil, fmt.Errorf("keychain values can't contain line breaks")
+		}
+		// security reads the commands from the standard input in the interactive mode
+		cmd := exec.Command("security", "-i")
+		cmd.Stdin = bytes.NewBufferString(fmt.Sprintf(
+			"add-generic-password -U -s %s -a %s -w %s\n",
+			securityQuote(service),
+			securityQuote(value),
+			securityQuote(secret),
+		))
+		return cmd, nil
+	case "linux":
+		cmd := exec.Command("secret-tool", "store", "--label", label, "service", service, attribute, value)
+		cmd.Stdin = bytes.NewBufferString(secret)
+		return cmd, nil
+	default:
+		return nil, fmt.Errorf("keychain is not supported on %s", goos)
+	}
+}
+
+// securityQuote quotes the argument of a security command in the interactive mode.
+func securityQuote(arg string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(arg) + `"`
+}
+
+// DeleteKeychainSecret removes the secret of the service stored in the OS keychain under the attribute value.
+func DeleteKeychainSecret(service string, attribute string, value string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("security", "delete-generic-password", "-s", service, "-a", value)
+	case "linux":
+		cmd = exec.Command("secret-tool", "clear", "service", service, attribute, value)
+	default:
+		return fmt.Errorf("keychain is not supported on %s", runtime.GOOS)
+	}
+
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return keychainError(out, err)
+	}
+	return nil
+}
+
+// keychainError describes the keychain command failure with its output if there is any.
+func keychainError(out []byte, err error) error {
+	if msg := strings.TrimSpace(string(out)); msg != "" {
+		return errors.New(msg)
+	}
+	return err
+}
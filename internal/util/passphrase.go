@@ -0,0 +1,82 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/onflow/flow-cli/flowkit/accounts"
+)
+
+// keychainService is the service name under which the project passphrases are stored in the OS keychain.
+const keychainService = "flow-cli"
+
+// Passphrase obtains the passphrase for the encrypted keys, it's read from the environment,
+// the OS keychain or the user is prompted to enter it.
+func Passphrase() (string, error) {
+	if passphrase, err := accounts.EnvPassphrase(); err == nil {
+		return passphrase, nil
+	}
+
+	if passphrase, err := KeychainPassphrase(); err == nil && passphrase != "" {
+		return passphrase, nil
+	}
+
+	return PassphrasePrompt(false)
+}
+
+// KeychainPassphrase reads the passphrase of the current project from the OS keychain.
+func KeychainPassphrase() (string, error) {
+	project, err := keychainProject()
+	if err != nil {
+		return "", err
+	}
+
+	passphrase, err := KeychainSecret(keychainService, "project", project)
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase from keychain: %w", err)
+	}
+	return passphrase, nil
+}
+
+// SaveKeychainPassphrase stores the passphrase of the current project in the OS keychain.
+func SaveKeychainPassphrase(passphrase string) error {
+	project, err := keychainProject()
+	if err != nil {
+		return err
+	}
+
+	err = SaveKeychainSecret(keychainService, "project", project, "Flow CLI passphrase for "+project, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to save passphrase to keychain: %w", err)
+	}
+	return nil
+}
+
+// keychainProject identifies the project in the keychain by its directory.
+func keychainProject() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Abs(dir)
+}
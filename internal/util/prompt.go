@@ -19,6 +19,7 @@
 package util
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path"
@@ -26,17 +27,66 @@ import (
 	"strings"
 
 	"github.com/gosuri/uilive"
-	"github.com/manifoldco/promptui"
 	"github.com/onflow/flow-go-sdk"
 	"github.com/onflow/flow-go-sdk/crypto"
-	"github.com/sergi/go-diff/diffmatchpatch"
-	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 
+	"github.com/onflow/flow-cli/flowkit/accounts"
 	"github.com/onflow/flow-cli/flowkit/config"
 	"github.com/onflow/flow-cli/flowkit/output"
 )
 
+// NonInteractive disables the prompts, prompts with a safe default return it and prompts
+// requiring input exit with an error explaining how to provide the value without the prompt.
+var NonInteractive = false
+
+// requireInteractive exits if the prompts are disabled and not answered by a script,
+// the hint explains how to provide the input instead.
+func requireInteractive(input string, hint string) {
+	if !NonInteractive || scripted() {
+		return
+	}
+
+	Exit(ExitValidationError, fmt.Sprintf(
+		"%s %s is required but prompts are disabled in non-interactive mode, %s",
+		output.ErrorEmoji(), input, hint,
+	))
+}
+
+// promptsDisabled checks whether prompts with a safe default should return it without asking.
+func promptsDisabled() bool {
+	return NonInteractive && !scripted()
+}
+
+// input asks the question and returns the answer, it exits if the prompt is aborted or can't be answered.
+func input(question Question) string {
+	answer, err := prompter.Input(question)
+	exitOnPromptError(err)
+	return answer
+}
+
+// choose asks to choose one of the items and returns the index and the chosen item,
+// it exits if the prompt is aborted or can't be answered.
+func choose(question Question, items []string) (int, string) {
+	index, err := prompter.Select(question, items)
+	exitOnPromptError(err)
+	if index < 0 || index >= len(items) {
+		return index, ""
+	}
+	return index, items[index]
+}
+
+// exitOnPromptError exits if the prompt was aborted or the scripted answers can't answer it,
+// other terminal failures leave the answer empty.
+func exitOnPromptError(err error) {
+	if errors.Is(err, ErrPromptAborted) {
+		os.Exit(ExitAborted)
+	}
+	if err != nil && scripted() {
+		Exit(ExitValidationError, fmt.Sprintf("%s %s", output.ErrorEmoji(), err))
+	}
+}
+
 func ApproveTransactionForSigningPrompt(transaction *flow.Transaction) bool {
 	return ApproveTransactionPrompt(transaction, "⚠️  Do you want to SIGN this transaction?")
 }
@@ -50,6 +100,8 @@ func ApproveTransactionForSendingPrompt(transaction *flow.Transaction) bool {
 }
 
 func ApproveTransactionPrompt(tx *flow.Transaction, promptMsg string) bool {
+	requireInteractive("Transaction approval", "use the --yes flag to approve")
+
 	writer := uilive.New()
 
 	_, _ = fmt.Fprintf(writer, "\n")
@@ -100,12 +152,7 @@ func ApproveTransactionPrompt(tx *flow.Transaction, promptMsg string) bool {
 	_, _ = fmt.Fprintf(writer, "\n\n")
 	_ = writer.Flush()
 
-	prompt := promptui.Select{
-		Label: promptMsg,
-		Items: []string{"No", "Yes"},
-	}
-
-	_, result, _ := prompt.Run()
+	_, result := choose(Question{ID: "transaction-approval", Label: promptMsg}, []string{"No", "Yes"})
 
 	_, _ = fmt.Fprintf(writer, "\r\r")
 	_ = writer.Flush()
@@ -114,21 +161,17 @@ func ApproveTransactionPrompt(tx *flow.Transaction, promptMsg string) bool {
 }
 
 func AutocompletionPrompt() (string, string) {
-	prompt := promptui.Select{
-		Label: "❓ Select your shell (you can run 'echo $SHELL' to find out)",
-		Items: []string{"bash", "zsh", "powershell"},
-	}
+	requireInteractive("Shell selection", "provide the shell as an argument")
 
-	_, shell, _ := prompt.Run()
+	_, shell := choose(Question{
+		ID:    "shell",
+		Label: "❓ Select your shell (you can run 'echo $SHELL' to find out)",
+	}, []string{"bash", "zsh", "powershell"})
 	curOs := ""
 
 	switch shell {
 	case "bash":
-		prompt := promptui.Select{
-			Label: "❓ Select operation system",
-			Items: []string{"MacOS", "Linux"},
-		}
-		_, curOs, _ = prompt.Run()
+		_, curOs = choose(Question{ID: "os", Label: "❓ Select operation system"}, []string{"MacOS", "Linux"})
 	case "powershell":
 		fmt.Printf(`PowerShell Installation Guide:
 PS> flow config setup-completions powershell | Out-String | Invoke-Expression
@@ -143,7 +186,10 @@ PS> flow config setup-completions powershell > flow.ps1
 }
 
 func NamePrompt() string {
-	namePrompt := promptui.Prompt{
+	requireInteractive("Name", "provide the values with the command flags")
+
+	return input(Question{
+		ID:    "name",
 		Label: "Enter name",
 		Validate: func(s string) error {
 			if len(s) < 1 {
@@ -151,18 +197,14 @@ func NamePrompt() string {
 			}
 			return nil
 		},
-	}
-
-	name, err := namePrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
-
-	return name
+	})
 }
 
 func AccountNamePrompt(accountNames []string) string {
-	namePrompt := promptui.Prompt{
+	requireInteractive("Account name", "provide the account keys with the --key flag")
+
+	return input(Question{
+		ID:    "account-name",
 		Label: "Enter an account name",
 		Validate: func(s string) error {
 			if slices.Contains(accountNames, s) {
@@ -173,18 +215,12 @@ func AccountNamePrompt(accountNames []string) string {
 			}
 			return nil
 		},
-	}
-
-	name, err := namePrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
-
-	return name
+	})
 }
 
 func secureNetworkKeyPrompt() string {
-	networkKeyPrompt := promptui.Prompt{
+	networkKey, err := prompter.Input(Question{
+		ID:    "network-key",
 		Label: "Enter a valid host network key or leave blank",
 		Validate: func(s string) error {
 			if s == "" {
@@ -193,17 +229,17 @@ func secureNetworkKeyPrompt() string {
 
 			return ValidateECDSAP256Pub(s)
 		},
-	}
-	networkKey, err := networkKeyPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
+	})
+	if errors.Is(err, ErrPromptAborted) {
+		os.Exit(ExitAborted)
 	}
 
 	return networkKey
 }
 
 func addressPrompt() string {
-	addressPrompt := promptui.Prompt{
+	return input(Question{
+		ID:    "address",
 		Label: "Enter address",
 		Validate: func(s string) error {
 			if flow.HexToAddress(s) == flow.EmptyAddress {
@@ -211,66 +247,71 @@ func addressPrompt() string {
 			}
 			return nil
 		},
-	}
-
-	address, err := addressPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
-
-	return address
+	})
 }
 
 func contractPrompt(contractNames []string) string {
-	contractPrompt := promptui.Select{
-		Label: "Choose contract you wish to deploy",
-		Items: contractNames,
-	}
-	_, contractName, err := contractPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
-
+	_, contractName := choose(Question{ID: "contract", Label: "Choose contract you wish to deploy"}, contractNames)
 	return contractName
 }
 
 func addAnotherContractToDeploymentPrompt() bool {
-	addContractPrompt := promptui.Select{
-		Label: "Do you wish to add another contract for deployment?",
-		Items: []string{"No", "Yes"},
-	}
-	_, addMore, err := addContractPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
+	if promptsDisabled() {
+		return false
 	}
 
+	_, addMore := choose(Question{
+		ID:      "add-another-contract",
+		Label:   "Do you wish to add another contract for deployment?",
+		Default: "No",
+	}, []string{"No", "Yes"})
+
 	return addMore == "Yes"
 }
 
-// ShowContractDiffPrompt shows a diff between the new contract and the existing contract
-// and asks the user if they wish to continue with the deployment
-// returns true if the user wishes to continue with the deployment and false otherwise
-func ShowContractDiffPrompt(logger output.Logger) func([]byte, []byte) bool {
-	return func(newContract []byte, existingContract []byte) bool {
-		dmp := diffmatchpatch.New()
-		diffs := dmp.DiffMain(string(newContract), string(existingContract), false)
-		diffString := dmp.DiffPrettyText(diffs)
-		logger.Info(diffString)
-
-		deployPrompt := promptui.Prompt{
-			Label:     "Do you wish to deploy this contract?",
-			IsConfirm: true,
+// ShowContractDiffPrompt shows a diff between the existing contract and the new contract
+// and asks the user if they wish to continue with the deployment, unless the update is already approved.
+// Returns true if the user wishes to continue with the deployment and false otherwise.
+func ShowContractDiffPrompt(logger output.Logger, approved bool) func([]byte, []byte) bool {
+	return func(existingContract []byte, newContract []byte) bool {
+		logger.StopProgress()
+		diff := UnifiedDiff(string(existingContract), string(newContract), "deployed", "local")
+		logger.Info(fmt.Sprintf("\n%s", colorDiff(diff)))
+
+		if approved {
+			return true
 		}
+		requireInteractive("Contract update approval", "use the --yes flag to approve")
 
-		deploy, err := deployPrompt.Run()
-		if err == promptui.ErrInterrupt {
-			os.Exit(-1)
-		}
+		deploy := input(Question{
+			ID:      "contract-update",
+			Label:   "Do you wish to update this contract?",
+			Confirm: true,
+		})
 
 		return strings.ToLower(deploy) == "y"
 	}
 }
 
+// colorDiff colors the removed lines of the unified diff red and the added lines green.
+func colorDiff(diff string) string {
+	lines := strings.Split(strings.TrimSuffix(diff, "\n"), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+			lines[i] = output.Bold(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = output.Magenta(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = output.Red(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = output.Green(line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 type AccountData struct {
 	Name     string
 	Address  string
@@ -281,43 +322,31 @@ type AccountData struct {
 }
 
 func NewAccountPrompt() *AccountData {
-	var err error
 	account := &AccountData{
 		Name:    NamePrompt(),
 		Address: addressPrompt(),
 	}
 
-	sigAlgoPrompt := promptui.Select{
-		Label: "Choose signature algorithm",
-		Items: []string{"ECDSA_P256", "ECDSA_secp256k1"},
-	}
-	_, account.SigAlgo, err = sigAlgoPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
-
-	hashAlgoPrompt := promptui.Select{
-		Label: "Choose hashing algorithm",
-		Items: []string{"SHA3_256", "SHA2_256"},
-	}
-	_, account.HashAlgo, err = hashAlgoPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	_, account.SigAlgo = choose(
+		Question{ID: "sig-algo", Label: "Choose signature algorithm"},
+		[]string{"ECDSA_P256", "ECDSA_secp256k1"},
+	)
+	_, account.HashAlgo = choose(
+		Question{ID: "hash-algo", Label: "Choose hashing algorithm"},
+		[]string{"SHA3_256", "SHA2_256"},
+	)
 
-	keyPrompt := promptui.Prompt{
+	account.Key = input(Question{
+		ID:    "private-key",
 		Label: "Enter private key",
 		Validate: func(s string) error {
 			_, err := crypto.DecodePrivateKeyHex(crypto.StringToSignatureAlgorithm(account.SigAlgo), s)
 			return err
 		},
-	}
-	account.Key, err = keyPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	})
 
-	keyIndexPrompt := promptui.Prompt{
+	account.KeyIndex = input(Question{
+		ID:      "key-index",
 		Label:   "Enter key index (Default: 0)",
 		Default: "0",
 		Validate: func(s string) error {
@@ -330,12 +359,7 @@ func NewAccountPrompt() *AccountData {
 			}
 			return nil
 		},
-	}
-
-	account.KeyIndex, err = keyIndexPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	})
 
 	return account
 }
@@ -352,9 +376,9 @@ func NewContractPrompt() *ContractData {
 	contract := &ContractData{
 		Name: NamePrompt(),
 	}
-	var err error
 
-	sourcePrompt := promptui.Prompt{
+	contract.Source = input(Question{
+		ID:    "contract-source",
 		Label: "Enter contract file location",
 		Validate: func(s string) error {
 			if !config.Exists(s) {
@@ -363,13 +387,11 @@ func NewContractPrompt() *ContractData {
 
 			return nil
 		},
-	}
-	contract.Source, err = sourcePrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	})
 
-	emulatorAliasPrompt := promptui.Prompt{
+	// aliases are optional, so they are empty if not answered
+	contract.Emulator, _ = prompter.Input(Question{
+		ID:    "emulator-alias",
 		Label: "Enter emulator alias, if exists",
 		Validate: func(s string) error {
 			if s != "" && flow.HexToAddress(s) == flow.EmptyAddress {
@@ -378,10 +400,10 @@ func NewContractPrompt() *ContractData {
 
 			return nil
 		},
-	}
-	contract.Emulator, _ = emulatorAliasPrompt.Run()
+	})
 
-	testnetAliasPrompt := promptui.Prompt{
+	contract.Testnet, _ = prompter.Input(Question{
+		ID:    "testnet-alias",
 		Label: "Enter testnet alias, if exists",
 		Validate: func(s string) error {
 			if s != "" && flow.HexToAddress(s) == flow.EmptyAddress {
@@ -390,10 +412,10 @@ func NewContractPrompt() *ContractData {
 
 			return nil
 		},
-	}
-	contract.Testnet, _ = testnetAliasPrompt.Run()
+	})
 
-	mainnetAliasPrompt := promptui.Prompt{
+	contract.Mainnet, _ = prompter.Input(Question{
+		ID:    "mainnet-alias",
 		Label: "Enter mainnet alias, if exists",
 		Validate: func(s string) error {
 			if s != "" && flow.HexToAddress(s) == flow.EmptyAddress {
@@ -402,28 +424,16 @@ func NewContractPrompt() *ContractData {
 
 			return nil
 		},
-	}
-	contract.Mainnet, _ = mainnetAliasPrompt.Run()
+	})
 
 	return contract
 }
 
 func NewNetworkPrompt() map[string]string {
 	networkData := make(map[string]string)
-	var err error
 
 	networkData["name"] = NamePrompt()
-
-	hostPrompt := promptui.Prompt{
-		Label: "Enter host location",
-		Validate: func(s string) error {
-			return nil
-		},
-	}
-	networkData["host"], err = hostPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	networkData["host"] = input(Question{ID: "host", Label: "Enter host location"})
 
 	networkData["key"] = secureNetworkKeyPrompt()
 
@@ -441,36 +451,29 @@ func NewDeploymentPrompt(
 	accounts config.Accounts,
 	contracts config.Contracts,
 ) *DeploymentData {
+	requireInteractive("Deployment", "provide the values with the command flags")
+
 	deploymentData := &DeploymentData{}
-	var err error
 
 	networkNames := make([]string, 0)
 	for _, network := range networks {
 		networkNames = append(networkNames, network.Name)
 	}
 
-	networkPrompt := promptui.Select{
-		Label: "Choose network for deployment",
-		Items: networkNames,
-	}
-	_, deploymentData.Network, err = networkPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	_, deploymentData.Network = choose(
+		Question{ID: "deployment-network", Label: "Choose network for deployment"},
+		networkNames,
+	)
 
 	accountNames := make([]string, 0)
 	for _, account := range accounts {
 		accountNames = append(accountNames, account.Name)
 	}
 
-	accountPrompt := promptui.Select{
-		Label: "Choose an account to deploy to",
-		Items: accountNames,
-	}
-	_, deploymentData.Account, err = accountPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	_, deploymentData.Account = choose(
+		Question{ID: "deployment-account", Label: "Choose an account to deploy to"},
+		accountNames,
+	)
 
 	contractNames := make([]string, 0)
 	for _, contract := range contracts {
@@ -497,26 +500,21 @@ func NewDeploymentPrompt(
 }
 
 func RemoveAccountPrompt(accounts config.Accounts) string {
+	requireInteractive("Account name", "provide the account name as an argument")
+
 	accountNames := make([]string, 0)
 
 	for _, account := range accounts {
 		accountNames = append(accountNames, account.Name)
 	}
 
-	namePrompt := promptui.Select{
-		Label: "Choose an account name you wish to remove",
-		Items: accountNames,
-	}
-
-	_, name, err := namePrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
-
+	_, name := choose(Question{ID: "remove-account", Label: "Choose an account name you wish to remove"}, accountNames)
 	return name
 }
 
 func RemoveDeploymentPrompt(deployments config.Deployments) (account string, network string) {
+	requireInteractive("Deployment", "provide the account and network as arguments")
+
 	deploymentNames := make([]string, 0)
 
 	for _, deployment := range deployments {
@@ -536,110 +534,138 @@ func RemoveDeploymentPrompt(deployments config.Deployments) (account string, net
 		)
 	}
 
-	deployPrompt := promptui.Select{
-		Label: "Choose deployment you wish to remove",
-		Items: deploymentNames,
-	}
-
-	index, _, err := deployPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	index, _ := choose(Question{ID: "remove-deployment", Label: "Choose deployment you wish to remove"}, deploymentNames)
 
 	return deployments[index].Account, deployments[index].Network
 }
 
 func RemoveContractPrompt(contracts config.Contracts) string {
+	requireInteractive("Contract name", "provide the contract name as an argument")
+
 	contractNames := make([]string, 0)
 
 	for _, contract := range contracts {
 		contractNames = append(contractNames, contract.Name)
 	}
 
-	contractPrompt := promptui.Select{
-		Label: "Choose contract you wish to remove",
-		Items: contractNames,
-	}
-
-	_, name, err := contractPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
-
+	_, name := choose(Question{ID: "remove-contract", Label: "Choose contract you wish to remove"}, contractNames)
 	return name
 }
 
 func RemoveContractFromFlowJSONPrompt(contractName string) bool {
-	prompt := promptui.Select{
-		Label: fmt.Sprintf("Do you want to remove %s from your flow.json deployments?", contractName),
-		Items: []string{"Yes", "No"},
+	if promptsDisabled() {
+		return false
 	}
-	chosen, _, _ := prompt.Run()
+
+	chosen, _ := choose(Question{
+		ID:      "remove-contract-deployments",
+		Label:   fmt.Sprintf("Do you want to remove %s from your flow.json deployments?", contractName),
+		Default: "No",
+	}, []string{"Yes", "No"})
+
+	return chosen == 0
+}
+
+// PruneContractsPrompt asks whether the contracts no longer listed in deployments should be removed from the network.
+func PruneContractsPrompt(contracts []string) bool {
+	requireInteractive("Contract removal approval", "use the --yes flag to approve")
+
+	chosen, _ := choose(Question{
+		ID: "prune-contracts",
+		Label: fmt.Sprintf(
+			"Contracts %s are no longer in your flow.json deployments, do you want to remove them from the network?",
+			strings.Join(contracts, ", "),
+		),
+	}, []string{"Yes", "No"})
+
+	return chosen == 0
+}
+
+// MainnetConfirmationPrompt asks to type the account name to confirm the destructive mainnet operation.
+func MainnetConfirmationPrompt(operation string, accountName string) {
+	requireInteractive("Mainnet operation confirmation", fmt.Sprintf("use the --yes-i-am-sure=%s flag to confirm", accountName))
+
+	input(Question{
+		ID:    "mainnet-confirmation",
+		Label: fmt.Sprintf("You are about to %s on mainnet, type the account name %s to confirm", operation, accountName),
+		Validate: func(s string) error {
+			if s != accountName {
+				return fmt.Errorf("the account name doesn't match")
+			}
+			return nil
+		},
+	})
+}
+
+// RemoveEmulatorDataPrompt asks whether the emulator data files should be removed.
+func RemoveEmulatorDataPrompt(files []string) bool {
+	requireInteractive("Emulator data removal approval", "use the --yes flag to approve")
+
+	chosen, _ := choose(Question{
+		ID:    "remove-emulator-data",
+		Label: fmt.Sprintf("Do you want to remove the emulator data %s?", strings.Join(files, ", ")),
+	}, []string{"Yes", "No"})
 
 	return chosen == 0
 }
 
 func RemoveNetworkPrompt(networks config.Networks) string {
+	requireInteractive("Network name", "provide the network name as an argument")
+
 	networkNames := make([]string, 0)
 
 	for _, network := range networks {
 		networkNames = append(networkNames, network.Name)
 	}
 
-	networkPrompt := promptui.Select{
-		Label: "Choose network you wish to remove",
-		Items: networkNames,
-	}
-
-	_, name, err := networkPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
-
+	_, name := choose(Question{ID: "remove-network", Label: "Choose network you wish to remove"}, networkNames)
 	return name
 }
 
 func ReportCrash() bool {
-	prompt := promptui.Select{
-		Label: "🙏 Please report the crash so we can improve the CLI. Do you want to report it?",
-		Items: []string{"Yes, report the crash", "No"},
+	if promptsDisabled() {
+		return false
 	}
-	chosen, _, _ := prompt.Run()
+
+	chosen, _ := choose(Question{
+		ID:      "report-crash",
+		Label:   "🙏 Please report the crash so we can improve the CLI. Do you want to report it?",
+		Default: "No",
+	}, []string{"Yes, report the crash", "No"})
 
 	return chosen == 0
 }
 
 func CreateAccountNetworkPrompt() (string, config.Network) {
+	requireInteractive("Network", "provide the account keys with the --key flag")
+
 	networkMap := map[string]config.Network{
 		"Emulator": config.EmulatorNetwork,
 		"Testnet":  config.TestnetNetwork,
 		"Mainnet":  config.MainnetNetwork,
 	}
 
-	networkPrompt := promptui.Select{
-		Label: "Choose a network",
-		Items: maps.Keys(networkMap),
-	}
-
-	_, selectedNetwork, err := networkPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	// the networks are listed in a fixed order, so scripted answers can choose them by index
+	_, selectedNetwork := choose(
+		Question{ID: "network", Label: "Choose a network"},
+		[]string{"Emulator", "Testnet", "Mainnet"},
+	)
 	fmt.Println("")
 
 	return selectedNetwork, networkMap[selectedNetwork]
 }
 
 func WantToUseMainnetVersionPrompt() bool {
-	useMainnetVersionPrompt := promptui.Select{
-		Label: "Do you wish to use Mainnet version instead? (y/n)",
-		Items: []string{"Yes", "No"},
-	}
-	_, useMainnetVersion, err := useMainnetVersionPrompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
+	if promptsDisabled() {
+		return false
 	}
 
+	_, useMainnetVersion := choose(Question{
+		ID:      "use-mainnet-version",
+		Label:   "Do you wish to use Mainnet version instead? (y/n)",
+		Default: "No",
+	}, []string{"Yes", "No"})
+
 	return useMainnetVersion == "Yes"
 }
 
@@ -648,20 +674,23 @@ const CancelInstall = 1
 const AlreadyInstalled = 2
 
 func InstallPrompt() int {
-	prompt := promptui.Select{
-		Label: "Do you wish to install it",
-		Items: []string{"Yes", "No", "I've already installed it"},
-	}
-	index, _, err := prompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	requireInteractive("Install approval", "install it manually")
+
+	index, _ := choose(
+		Question{ID: "install", Label: "Do you wish to install it"},
+		[]string{"Yes", "No", "I've already installed it"},
+	)
 
 	return index
 }
 
 func InstallPathPrompt(defaultPath string) string {
-	prompt := promptui.Prompt{
+	if promptsDisabled() {
+		return path.Clean(defaultPath)
+	}
+
+	install := input(Question{
+		ID:      "install-path",
 		Label:   "Install path",
 		Default: defaultPath,
 		Validate: func(s string) error {
@@ -678,12 +707,7 @@ func InstallPathPrompt(defaultPath string) string {
 
 			return fmt.Errorf("path is invalid")
 		},
-	}
-
-	install, err := prompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
+	})
 
 	return path.Clean(install)
 }
@@ -696,6 +720,8 @@ type ScaffoldItem struct {
 }
 
 func ScaffoldPrompt(logger output.Logger, scaffoldItems []ScaffoldItem) int {
+	requireInteractive("Scaffold selection", "provide the scaffold with the --template flag")
+
 	const (
 		general = ""
 		mobile  = "mobile"
@@ -727,7 +753,8 @@ func ScaffoldPrompt(logger output.Logger, scaffoldItems []ScaffoldItem) int {
 	outputCategory(mobile, scaffoldItems)
 	outputCategory(unity, scaffoldItems)
 
-	prompt := promptui.Prompt{
+	answer := input(Question{
+		ID:    "scaffold",
 		Label: "Enter the scaffold number",
 		Validate: func(s string) error {
 			n, err := strconv.Atoi(s)
@@ -740,13 +767,8 @@ func ScaffoldPrompt(logger output.Logger, scaffoldItems []ScaffoldItem) int {
 			}
 			return nil
 		},
-	}
-
-	input, err := prompt.Run()
-	if err == promptui.ErrInterrupt {
-		os.Exit(-1)
-	}
-	num, _ := strconv.Atoi(input)
+	})
+	num, _ := strconv.Atoi(answer)
 
 	for _, item := range scaffoldItems {
 		if item.assignedIndex == num {
@@ -756,3 +778,78 @@ func ScaffoldPrompt(logger output.Logger, scaffoldItems []ScaffoldItem) int {
 
 	return 0
 }
+
+// TokenPrompt asks for the token of the third-party service provider, the hint explains how to provide it without the prompt.
+func TokenPrompt(provider string, hint string) string {
+	requireInteractive(fmt.Sprintf("Token of %s", provider), hint)
+
+	return input(Question{
+		ID:    "token",
+		Label: fmt.Sprintf("Enter the %s token", provider),
+		Mask:  true,
+		Validate: func(s string) error {
+			if strings.TrimSpace(s) == "" {
+				return fmt.Errorf("token can not be empty")
+			}
+			return nil
+		},
+	})
+}
+
+// PassphrasePrompt asks for the passphrase used to encrypt the account keys, optionally asking to confirm it.
+func PassphrasePrompt(confirm bool) (string, error) {
+	if promptsDisabled() {
+		return "", fmt.Errorf("passphrase is required but prompts are disabled in non-interactive mode, provide it with the %s environment variable", accounts.PassphraseEnv)
+	}
+
+	passphrase, err := prompter.Input(Question{
+		ID:    "passphrase",
+		Label: "Enter passphrase for encrypted keys",
+		Mask:  true,
+		Validate: func(s string) error {
+			if s == "" {
+				return fmt.Errorf("passphrase can not be empty")
+			}
+			return nil
+		},
+	})
+	if errors.Is(err, ErrPromptAborted) {
+		os.Exit(ExitAborted)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if confirm {
+		_, err = prompter.Input(Question{
+			ID:    "passphrase-confirm",
+			Label: "Confirm passphrase",
+			Mask:  true,
+			Validate: func(s string) error {
+				if s != passphrase {
+					return fmt.Errorf("passphrases don't match")
+				}
+				return nil
+			},
+		})
+		if errors.Is(err, ErrPromptAborted) {
+			os.Exit(ExitAborted)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return passphrase, nil
+}
+
+// WalletConnectPrompt displays the link the wallet must open to connect and sign with a walletconnect key.
+func WalletConnectPrompt(uri string) {
+	_, _ = fmt.Fprintf(
+		os.Stderr,
+		"\n%s\n\n%s\n\n%s\n\n",
+		output.Bold("🔗 Connect your wallet to sign the transaction"),
+		"Open the link below with a WalletConnect compatible Flow wallet (or paste it into the wallet's WalletConnect screen), then approve the connection and the transaction in the wallet:",
+		uri,
+	)
+}
@@ -0,0 +1,226 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/manifoldco/promptui"
+)
+
+// ErrPromptAborted is returned by the prompter when the user interrupts the prompt.
+var ErrPromptAborted = errors.New("prompt aborted")
+
+// Question is asked by a prompt.
+type Question struct {
+	// ID identifies the question in the scripted answers, e.g. "account-name".
+	ID    string
+	Label string
+	// Default is the initial value of an input, scripted answers use it for unanswered questions.
+	Default string
+	// Mask hides the input, e.g. for passphrases.
+	Mask bool
+	// Confirm asks a yes or no question, the answer is "y" if confirmed.
+	Confirm  bool
+	Validate func(string) error
+}
+
+// Prompter asks the questions of the prompts.
+//
+// The prompts use the terminal by default, a scripted prompter makes interactive flows
+// automatable and testable.
+type Prompter interface {
+	// Input asks for a text answer.
+	Input(question Question) (string, error)
+	// Select asks to choose one of the items and returns the index of the chosen item.
+	Select(question Question, items []string) (int, error)
+}
+
+var prompter Prompter = terminalPrompter{}
+
+// SetPrompter replaces the prompter used to ask the questions of all the prompts.
+func SetPrompter(p Prompter) {
+	prompter = p
+}
+
+// scripted checks whether the prompts are answered by a script instead of the user.
+func scripted() bool {
+	_, ok := prompter.(*ScriptedPrompter)
+	return ok
+}
+
+// terminalPrompter asks the questions interactively in the terminal.
+type terminalPrompter struct{}
+
+func (terminalPrompter) Input(question Question) (string, error) {
+	prompt := promptui.Prompt{
+		Label:     question.Label,
+		Default:   question.Default,
+		IsConfirm: question.Confirm,
+		Validate:  question.Validate,
+	}
+	if question.Mask {
+		prompt.Mask = '*'
+	}
+
+	answer, err := prompt.Run()
+	if err == promptui.ErrInterrupt {
+		return "", ErrPromptAborted
+	}
+	// a declined confirmation is an answer and not a failure
+	if question.Confirm && err == promptui.ErrAbort {
+		return "n", nil
+	}
+	return answer, err
+}
+
+func (terminalPrompter) Select(question Question, items []string) (int, error) {
+	prompt := promptui.Select{
+		Label: question.Label,
+		Items: items,
+	}
+
+	index, _, err := prompt.Run()
+	if err == promptui.ErrInterrupt {
+		return 0, ErrPromptAborted
+	}
+	return index, err
+}
+
+// ScriptedPrompter answers the questions from answers by question ID.
+//
+// Answers are read from a JSON object, a question asked more than once is answered with an array
+// of answers used in order. Selections are answered with the item, its index or true and false for
+// yes and no questions.
+type ScriptedPrompter struct {
+	answers map[string][]string
+}
+
+// NewScriptedPrompter reads the JSON answers from the reader.
+func NewScriptedPrompter(reader io.Reader) (*ScriptedPrompter, error) {
+	var raw map[string]any
+	if err := json.NewDecoder(reader).Decode(&raw); err != nil {
+		return nil, fmt.Errorf("failed to decode the answers, answers must be a JSON object of answers by question ID: %w", err)
+	}
+
+	answers := make(map[string][]string, len(raw))
+	for id, value := range raw {
+		values, ok := value.([]any)
+		if !ok {
+			values = []any{value}
+		}
+
+		for _, v := range values {
+			switch v := v.(type) {
+			case string:
+				answers[id] = append(answers[id], v)
+			case bool, float64:
+				answers[id] = append(answers[id], fmt.Sprint(v))
+			default:
+				return nil, fmt.Errorf("invalid answer for question %s, answers must be strings, numbers or booleans", id)
+			}
+		}
+	}
+
+	return &ScriptedPrompter{answers: answers}, nil
+}
+
+// next returns the next answer of the question, or the default if all answers were used.
+func (s *ScriptedPrompter) next(question Question) (string, error) {
+	answers := s.answers[question.ID]
+	if len(answers) == 0 {
+		if question.Default != "" {
+			return question.Default, nil
+		}
+		return "", fmt.Errorf("no answer for question %s (%s) in the answers", question.ID, question.Label)
+	}
+
+	s.answers[question.ID] = answers[1:]
+	return answers[0], nil
+}
+
+func (s *ScriptedPrompter) Input(question Question) (string, error) {
+	answer, err := s.next(question)
+	if err != nil {
+		return "", err
+	}
+
+	if question.Confirm {
+		if confirmed, ok := yesOrNo(answer); ok {
+			answer = "n"
+			if confirmed {
+				answer = "y"
+			}
+		}
+	}
+
+	if question.Validate != nil {
+		if err := question.Validate(answer); err != nil {
+			return "", fmt.Errorf("invalid answer for question %s: %w", question.ID, err)
+		}
+	}
+	return answer, nil
+}
+
+func (s *ScriptedPrompter) Select(question Question, items []string) (int, error) {
+	answer, err := s.next(question)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, item := range items {
+		if strings.EqualFold(item, answer) {
+			return i, nil
+		}
+	}
+
+	if confirmed, ok := yesOrNo(answer); ok {
+		prefix := "no"
+		if confirmed {
+			prefix = "yes"
+		}
+		for i, item := range items {
+			if strings.HasPrefix(strings.ToLower(item), prefix) {
+				return i, nil
+			}
+		}
+	}
+
+	if index, err := strconv.Atoi(answer); err == nil && index >= 0 && index < len(items) {
+		return index, nil
+	}
+
+	return 0, fmt.Errorf("invalid answer for question %s: %s is not one of %s", question.ID, answer, strings.Join(items, ", "))
+}
+
+// yesOrNo parses the answer of a yes or no question.
+func yesOrNo(answer string) (bool, bool) {
+	switch strings.ToLower(answer) {
+	case "true", "yes", "y":
+		return true, true
+	case "false", "no", "n":
+		return false, true
+	}
+	return false, false
+}
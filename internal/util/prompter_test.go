@@ -0,0 +1,137 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/onflow/flow-cli/flowkit/config"
+)
+
+func Test_ScriptedPrompter(t *testing.T) {
+	newPrompter := func(t *testing.T, answers string) *ScriptedPrompter {
+		p, err := NewScriptedPrompter(strings.NewReader(answers))
+		require.NoError(t, err)
+		return p
+	}
+
+	t.Run("Input", func(t *testing.T) {
+		p := newPrompter(t, `{"name": ["alice", "bob"], "key-index": 1, "contract-update": true}`)
+
+		name, err := p.Input(Question{ID: "name"})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", name)
+		name, err = p.Input(Question{ID: "name"})
+		require.NoError(t, err)
+		assert.Equal(t, "bob", name)
+
+		index, err := p.Input(Question{ID: "key-index"})
+		require.NoError(t, err)
+		assert.Equal(t, "1", index)
+
+		confirmed, err := p.Input(Question{ID: "contract-update", Confirm: true})
+		require.NoError(t, err)
+		assert.Equal(t, "y", confirmed)
+
+		host, err := p.Input(Question{ID: "host", Default: "127.0.0.1:3569"})
+		require.NoError(t, err)
+		assert.Equal(t, "127.0.0.1:3569", host)
+
+		_, err = p.Input(Question{ID: "name", Label: "Enter name"})
+		assert.EqualError(t, err, "no answer for question name (Enter name) in the answers")
+	})
+
+	t.Run("Validation", func(t *testing.T) {
+		p := newPrompter(t, `{"name": ""}`)
+		_, err := p.Input(Question{ID: "name", Validate: func(s string) error {
+			if s == "" {
+				return assert.AnError
+			}
+			return nil
+		}})
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		p := newPrompter(t, `{"network": ["testnet", 2], "report-crash": false, "install": "maybe"}`)
+
+		index, err := p.Select(Question{ID: "network"}, []string{"Emulator", "Testnet", "Mainnet"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, index)
+		index, err = p.Select(Question{ID: "network"}, []string{"Emulator", "Testnet", "Mainnet"})
+		require.NoError(t, err)
+		assert.Equal(t, 2, index)
+
+		index, err = p.Select(Question{ID: "report-crash"}, []string{"Yes, report the crash", "No"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, index)
+
+		_, err = p.Select(Question{ID: "install"}, []string{"Yes", "No"})
+		assert.EqualError(t, err, "invalid answer for question install: maybe is not one of Yes, No")
+	})
+
+	t.Run("Invalid answers", func(t *testing.T) {
+		_, err := NewScriptedPrompter(strings.NewReader(`["alice"]`))
+		assert.Error(t, err)
+
+		_, err = NewScriptedPrompter(strings.NewReader(`{"name": {"first": "alice"}}`))
+		assert.EqualError(t, err, "invalid answer for question name, answers must be strings, numbers or booleans")
+	})
+}
+
+func Test_ScriptedPrompts(t *testing.T) {
+	p, err := NewScriptedPrompter(strings.NewReader(`{
+		"account-name": "alice",
+		"network": "Testnet",
+		"deployment-network": "emulator",
+		"deployment-account": "emulator-account",
+		"contract": ["Foo", "Bar"],
+		"add-another-contract": ["yes", "no"]
+	}`))
+	require.NoError(t, err)
+
+	SetPrompter(p)
+	NonInteractive = true
+	defer func() {
+		SetPrompter(terminalPrompter{})
+		NonInteractive = false
+	}()
+
+	assert.Equal(t, "alice", AccountNamePrompt([]string{"emulator-account"}))
+
+	name, network := CreateAccountNetworkPrompt()
+	assert.Equal(t, "Testnet", name)
+	assert.Equal(t, config.TestnetNetwork, network)
+
+	deployment := NewDeploymentPrompt(
+		config.Networks{config.EmulatorNetwork},
+		config.Accounts{{Name: "emulator-account"}},
+		config.Contracts{{Name: "Foo"}, {Name: "Bar"}, {Name: "Baz"}},
+	)
+	assert.Equal(t, "emulator", deployment.Network)
+	assert.Equal(t, "emulator-account", deployment.Account)
+	assert.Equal(t, []string{"Foo", "Bar"}, deployment.Contracts)
+
+	// prompts with a safe default use it if they are not answered
+	assert.False(t, ReportCrash())
+}
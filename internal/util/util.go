@@ -23,7 +23,9 @@ import (
 	"encoding/hex"
 	"fmt"
 	"os"
+	"os/exec"
 	"path"
+	"runtime"
 	"strings"
 	"text/tabwriter"
 
@@ -35,11 +37,35 @@ import (
 
 const EnvPrefix = "FLOW"
 
+// Exit codes used by the prompts, the commands use the same codes for the failure classes.
+const (
+	ExitValidationError = 5   // input is invalid or missing
+	ExitAborted         = 130 // user declined a prompt or interrupted the command
+)
+
 func Exit(code int, msg string) {
 	fmt.Fprintln(os.Stderr, msg)
 	os.Exit(code)
 }
 
+// OpenBrowser opens the URL with the default browser of the OS.
+func OpenBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("could not open the browser, open %s manually: %w", url, err)
+	}
+	return nil
+}
+
 // AddToGitIgnore adds a new line to the .gitignore if one doesn't exist it creates it.
 func AddToGitIgnore(filename string, loader flowkit.ReaderWriter) error {
 	currentWd, err := os.Getwd()
@@ -111,3 +137,142 @@ func removeFromStringArray(s []string, el string) []string {
 
 	return s
 }
+
+// LineDiff returns changed lines prefixed with "+" for added and "-" for removed lines.
+func LineDiff(original string, changed string) string {
+	var out strings.Builder
+	for _, edit := range lineEdits(original, changed) {
+		if edit.op != ' ' {
+			out.WriteString(string(edit.op) + " " + edit.line + "\n")
+		}
+	}
+
+	return out.String()
+}
+
+// UnifiedDiff returns the differences in the unified diff format with three lines of context,
+// if there are no differences an empty string is returned.
+func UnifiedDiff(original string, changed string, originalName string, changedName string) string {
+	const context = 3
+	edits := lineEdits(original, changed)
+
+	var out strings.Builder
+	for start := 0; start < len(edits); {
+		first := start
+		for first < len(edits) && edits[first].op == ' ' {
+			first++
+		}
+		if first == len(edits) {
+			break
+		}
+
+		// extend the hunk with all the changes that are close enough to share the context
+		end := first
+		for i := first; i < len(edits); i++ {
+			if edits[i].op != ' ' {
+				end = i + 1
+			} else if i-end >= 2*context {
+				break
+			}
+		}
+
+		hunkStart := first - context
+		if hunkStart < start {
+			hunkStart = start
+		}
+		hunkEnd := end + context
+		if hunkEnd > len(edits) {
+			hunkEnd = len(edits)
+		}
+
+		if out.Len() == 0 {
+			out.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", originalName, changedName))
+		}
+
+		originalLine, changedLine := 1, 1
+		for _, edit := range edits[:hunkStart] {
+			if edit.op != '+' {
+				originalLine++
+			}
+			if edit.op != '-' {
+				changedLine++
+			}
+		}
+
+		var hunk strings.Builder
+		originalCount, changedCount := 0, 0
+		for _, edit := range edits[hunkStart:hunkEnd] {
+			if edit.op != '+' {
+				originalCount++
+			}
+			if edit.op != '-' {
+				changedCount++
+			}
+			hunk.WriteString(string(edit.op) + edit.line + "\n")
+		}
+		if originalCount == 0 {
+			originalLine--
+		}
+		if changedCount == 0 {
+			changedLine--
+		}
+
+		out.WriteString(fmt.Sprintf(
+			"@@ -%d,%d +%d,%d @@\n",
+			originalLine, originalCount, changedLine, changedCount,
+		))
+		out.WriteString(hunk.String())
+
+		start = hunkEnd
+	}
+
+	return out.String()
+}
+
+type lineEdit struct {
+	op   byte // ' ' for unchanged, '-' for removed and '+' for added line
+	line string
+}
+
+// lineEdits computes the line edits transforming the original to the changed text,
+// removed lines are listed before added lines.
+func lineEdits(original string, changed string) []lineEdit {
+	a := strings.Split(strings.TrimSuffix(original, "\n"), "\n")
+	b := strings.Split(strings.TrimSuffix(changed, "\n"), "\n")
+
+	// longest common subsequence lengths of line suffixes
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	edits := make([]lineEdit, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			edits = append(edits, lineEdit{' ', a[i]})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, lineEdit{'-', a[i]})
+			i++
+		default:
+			edits = append(edits, lineEdit{'+', b[j]})
+			j++
+		}
+	}
+
+	return edits
+}
@@ -0,0 +1,96 @@
+/*
+ * Flow CLI
+ *
+ * Copyright 2019 Dapper Labs, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *   http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package util
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LineDiff(t *testing.T) {
+	original := "{\n\t\"host\": \"127.0.0.1:3569\",\n\t\"accounts\": {}\n}\n"
+	migrated := "{\n\t\"version\": 1,\n\t\"networks\": {},\n\t\"accounts\": {}\n}\n"
+
+	assert.Equal(t,
+		"- \t\"host\": \"127.0.0.1:3569\",\n+ \t\"version\": 1,\n+ \t\"networks\": {},\n",
+		LineDiff(original, migrated),
+	)
+	assert.Empty(t, LineDiff(original, original))
+}
+
+func Test_UnifiedDiff(t *testing.T) {
+	original := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+	changed := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\n"
+
+	assert.Equal(t,
+		"--- deployed\n+++ local\n"+
+			"@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n"+
+			"@@ -10,3 +10,4 @@\n j\n k\n l\n+m\n",
+		UnifiedDiff(original, changed, "deployed", "local"),
+	)
+	assert.Empty(t, UnifiedDiff(original, original, "deployed", "local"))
+}
+
+func Test_NonInteractivePrompts(t *testing.T) {
+	NonInteractive = true
+	defer func() { NonInteractive = false }()
+
+	assert.False(t, ReportCrash())
+	assert.False(t, WantToUseMainnetVersionPrompt())
+	assert.False(t, RemoveContractFromFlowJSONPrompt("Foo"))
+	assert.Equal(t, "/opt/flowser", InstallPathPrompt("/opt/flowser/"))
+
+	_, err := PassphrasePrompt(false)
+	assert.EqualError(t, err, "passphrase is required but prompts are disabled in non-interactive mode, provide it with the FLOW_KEY_PASSPHRASE environment variable")
+}
+
+func Test_SaveKeychainCommand(t *testing.T) {
+	const secret = `provider "token" \ 7f3a9c`
+
+	for _, goos := range []string{"darwin", "linux"} {
+		t.Run(goos, func(t *testing.T) {
+			cmd, err := saveKeychainCommand(goos, "flow-cli", "project", "/home/user/my project", "label", secret)
+			require.NoError(t, err)
+
+			for _, arg := range cmd.Args {
+				assert.NotContains(t, arg, "7f3a9c")
+			}
+
+			stdin, err := io.ReadAll(cmd.Stdin)
+			require.NoError(t, err)
+			assert.Contains(t, string(stdin), "7f3a9c")
+		})
+	}
+
+	cmd, err := saveKeychainCommand("darwin", "flow-cli", "project", "/home/user/my project", "label", secret)
+	require.NoError(t, err)
+	stdin, err := io.ReadAll(cmd.Stdin)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"security", "-i"}, cmd.Args)
+	assert.Equal(t, `add-generic-password -U -s "flow-cli" -a "/home/user/my project" -w "provider \"token\" \\ 7f3a9c"`+"\n", string(stdin))
+
+	_, err = saveKeychainCommand("darwin", "flow-cli", "project", "project", "label", "secret\ndelete-keychain")
+	assert.EqualError(t, err, "keychain values can't contain line breaks")
+
+	_, err = saveKeychainCommand("windows", "flow-cli", "project", "project", "label", secret)
+	assert.EqualError(t, err, "keychain is not supported on windows")
+}